@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// activityEvent is a single entry of the activity feed
+type activityEvent struct {
+	Time     time.Time `json:"time"`
+	Kind     string    `json:"kind"`
+	Resource string    `json:"resource"`
+	UUID     string    `json:"uuid"`
+	Event    string    `json:"event"`
+	Details  string    `json:"details,omitempty"`
+	Trigger  string    `json:"trigger,omitempty"`
+}
+
+// activityFeed collects events newer than a cutoff
+type activityFeed struct {
+	since  time.Time
+	events []activityEvent
+}
+
+// activityCmd represents the activity command
+var activityCmd = &cobra.Command{
+	Use:   "activity",
+	Short: "Show recent activity",
+	Long:  "Show recent deployments and configuration changes across applications, services and databases",
+}
+
+// activityListCmd represents the activity list command
+var activityListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List recent deployments and changes",
+	Long: `List recent activity, newest first: deployments with their trigger (webhook, API, manual,
+rollback, pull request) and commit, resources that were created or changed, and, for a single
+application, environment variable changes.
+
+The Coolify API has no audit log endpoint, so the feed is assembled from deployment history and
+resource timestamps. It shows what changed and how deployments were triggered, but not which
+user made a change.
+
+Examples:
+  coolifyme activity list
+  coolifyme activity list --since 7d
+  coolifyme activity list --resource <app-uuid> --json`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		window, err := parseSince(sinceFlag)
+		if err != nil {
+			return err
+		}
+		resource, _ := cmd.Flags().GetString("resource")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		feed := &activityFeed{since: time.Now().Add(-window)}
+
+		apps, err := client.Applications().List(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list applications: %w", err)
+		}
+		for _, app := range apps {
+			uuid := stringOrDash(app.Uuid)
+			if resource != "" && uuid != resource {
+				continue
+			}
+			feed.addResource("application", stringOrDash(app.Name), uuid, app.CreatedAt, app.UpdatedAt)
+			if err := feed.addDeployments(ctx, client, app, limit); err != nil {
+				return err
+			}
+			if resource != "" {
+				envs, err := client.Applications().ListEnvs(ctx, uuid)
+				if err != nil {
+					return fmt.Errorf("failed to list environment variables: %w", err)
+				}
+				for _, env := range envs {
+					feed.addEnv(stringOrDash(app.Name), uuid, env)
+				}
+			}
+		}
+
+		services, err := client.Services().List(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list services: %w", err)
+		}
+		for _, service := range services {
+			if resource != "" && stringOrDash(service.Uuid) != resource {
+				continue
+			}
+			feed.addResource("service", stringOrDash(service.Name), stringOrDash(service.Uuid), apiTime(service.CreatedAt), apiTime(service.UpdatedAt))
+		}
+
+		rawDatabases, err := client.Databases().List(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list databases: %w", err)
+		}
+		var databases []struct {
+			UUID      string `json:"uuid"`
+			Name      string `json:"name"`
+			CreatedAt string `json:"created_at"`
+			UpdatedAt string `json:"updated_at"`
+		}
+		if err := json.Unmarshal([]byte(rawDatabases), &databases); err != nil {
+			return fmt.Errorf("failed to parse databases: %w", err)
+		}
+		for _, db := range databases {
+			if resource != "" && db.UUID != resource {
+				continue
+			}
+			feed.addResource("database", db.Name, db.UUID, apiTime(&db.CreatedAt), apiTime(&db.UpdatedAt))
+		}
+
+		sort.SliceStable(feed.events, func(i, j int) bool { return feed.events[i].Time.After(feed.events[j].Time) })
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			if feed.events == nil {
+				feed.events = []activityEvent{}
+			}
+			return outputJSON(feed.events)
+		}
+
+		if len(feed.events) == 0 {
+			fmt.Printf("No activity in the last %s\n", sinceFlag)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "TIME\tRESOURCE\tEVENT\tTRIGGER\tDETAILS")
+		_, _ = fmt.Fprintln(w, "----\t--------\t-----\t-------\t-------")
+		for _, e := range feed.events {
+			trigger := e.Trigger
+			if trigger == "" {
+				trigger = "-"
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s %s\t%s\t%s\t%s\n",
+				e.Time.Local().Format("2006-01-02 15:04:05"), e.Kind, e.Resource, e.Event, trigger, e.Details)
+		}
+		return w.Flush()
+	},
+}
+
+// add records an event when its timestamp is inside the window
+func (f *activityFeed) add(timestamp *time.Time, event activityEvent) {
+	if timestamp == nil || timestamp.Before(f.since) {
+		return
+	}
+	event.Time = *timestamp
+	f.events = append(f.events, event)
+}
+
+// apiTime parses a timestamp the API returns as a string, returning nil when it is missing or invalid
+func apiTime(timestamp *string) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, *timestamp)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// addResource records the creation and last change of a resource
+func (f *activityFeed) addResource(kind, name, uuid string, createdAt, updatedAt *time.Time) {
+	f.add(createdAt, activityEvent{Kind: kind, Resource: name, UUID: uuid, Event: "created"})
+	if updatedAt != nil && (createdAt == nil || !updatedAt.Equal(*createdAt)) {
+		f.add(updatedAt, activityEvent{Kind: kind, Resource: name, UUID: uuid, Event: "updated"})
+	}
+}
+
+// addDeployments records the recent deployments of an application
+func (f *activityFeed) addDeployments(ctx context.Context, client *clientpkg.Client, app coolify.Application, limit int) error {
+	deployments, err := client.Deployments().History(ctx, stringOrDash(app.Uuid), limit)
+	if err != nil {
+		return fmt.Errorf("failed to get deployments of %s: %w", stringOrDash(app.Name), err)
+	}
+
+	for _, d := range deployments {
+		var details []string
+		if d.Commit != nil && *d.Commit != "" && *d.Commit != "HEAD" {
+			details = append(details, shortCommit(*d.Commit))
+		}
+		if d.CommitMessage != nil && *d.CommitMessage != "" {
+			message, _, _ := strings.Cut(*d.CommitMessage, "\n")
+			details = append(details, message)
+		}
+
+		f.add(apiTime(d.CreatedAt), activityEvent{
+			Kind:     "application",
+			Resource: stringOrDash(app.Name),
+			UUID:     stringOrDash(app.Uuid),
+			Event:    "deployment " + stringOrDash(d.Status),
+			Details:  strings.Join(details, " "),
+			Trigger:  deploymentTrigger(d),
+		})
+	}
+	return nil
+}
+
+// addEnv records environment variable changes of an application
+func (f *activityFeed) addEnv(appName, appUUID string, env coolify.EnvironmentVariable) {
+	event := activityEvent{Kind: "application", Resource: appName, UUID: appUUID, Details: stringOrDash(env.Key)}
+	if env.CreatedAt != nil && env.UpdatedAt != nil && *env.CreatedAt == *env.UpdatedAt {
+		event.Event = "env added"
+	} else {
+		event.Event = "env changed"
+	}
+	f.add(apiTime(env.UpdatedAt), event)
+}
+
+// deploymentTrigger describes how a deployment was started
+func deploymentTrigger(d coolify.ApplicationDeploymentQueue) string {
+	var trigger string
+	switch {
+	case d.IsWebhook != nil && *d.IsWebhook:
+		trigger = "webhook"
+	case d.IsApi != nil && *d.IsApi:
+		trigger = "api"
+	default:
+		trigger = "manual"
+	}
+	if d.Rollback != nil && *d.Rollback {
+		trigger += ", rollback"
+	}
+	if d.RestartOnly != nil && *d.RestartOnly {
+		trigger += ", restart"
+	}
+	if d.PullRequestId != nil && *d.PullRequestId > 0 {
+		trigger += fmt.Sprintf(", PR #%d", *d.PullRequestId)
+	}
+	return trigger
+}
+
+// shortCommit abbreviates a commit SHA
+func shortCommit(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// parseSince parses a time window such as 90m, 24h or 7d
+func parseSince(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid --since '%s'", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid --since '%s' (use e.g. 90m, 24h or 7d)", value)
+	}
+	return d, nil
+}
+
+func init() {
+	rootCmd.AddCommand(activityCmd)
+	activityCmd.AddCommand(activityListCmd)
+
+	// Flags for activity list command
+	activityListCmd.Flags().String("resource", "", "Only show activity of this application, service or database UUID")
+	activityListCmd.Flags().String("since", "24h", "Time window to show (e.g. 90m, 24h, 7d)")
+	activityListCmd.Flags().Int("limit", 20, "Maximum number of deployments fetched per application")
+	activityListCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+}
@@ -2,7 +2,13 @@ package main
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
 	"github.com/spf13/cobra"
 )
 
@@ -21,18 +27,6 @@ var (
 		},
 	}
 
-	// Quick status check aliases
-	quickStatusCmd = &cobra.Command{
-		Use:     "status",
-		Aliases: []string{"st", "stat"},
-		Short:   "Quick status overview (alias for monitor status)",
-		Long:    "Show a quick overview of all resource statuses",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			// Forward to the monitor status command
-			return statusCmd.RunE(cmd, args)
-		},
-	}
-
 	// Quick health check alias
 	quickHealthCmd = &cobra.Command{
 		Use:     "health",
@@ -51,40 +45,99 @@ var (
 		Short: "List all available aliases",
 		Long:  "Display all available command aliases and their targets",
 		RunE: func(_ *cobra.Command, _ []string) error {
-			fmt.Println("📝 Available Command Aliases")
+			theme.Println("📝 Available Command Aliases")
 			fmt.Println("===========================")
 			fmt.Println()
 
-			fmt.Println("🚀 Deployment:")
-			fmt.Println("   deploy-app, deploy, dep  → deploy application <uuid>")
+			theme.Println("🚀 Deployment:")
+			theme.Println("   deploy-app, deploy, dep  → deploy application <uuid>")
 			fmt.Println()
 
-			fmt.Println("📊 Monitoring:")
-			fmt.Println("   status, st, stat         → monitor status")
-			fmt.Println("   health, ping, check      → monitor health")
+			theme.Println("📊 Monitoring:")
+			theme.Println("   status, st, stat         → consolidated application status")
+			theme.Println("   health, ping, check      → monitor health")
 			fmt.Println()
 
-			fmt.Println("📱 Applications:")
-			fmt.Println("   apps, app                → applications")
-			fmt.Println("   ls-apps                  → applications list")
+			theme.Println("📱 Applications:")
+			theme.Println("   apps, app                → applications")
+			theme.Println("   ls-apps                  → applications list")
 			fmt.Println()
 
-			fmt.Println("🖥️  Servers:")
-			fmt.Println("   servers, server, srv     → servers")
-			fmt.Println("   ls-servers               → servers list")
+			theme.Println("🖥️  Servers:")
+			theme.Println("   servers, server, srv     → servers")
+			theme.Println("   ls-servers               → servers list")
 			fmt.Println()
 
-			fmt.Println("🔧 Services:")
-			fmt.Println("   services, service, svc   → services")
-			fmt.Println("   ls-services              → services list")
+			theme.Println("🔧 Services:")
+			theme.Println("   services, service, svc   → services")
+			theme.Println("   ls-services              → services list")
 			fmt.Println()
 
-			fmt.Println("💡 Tip: Use 'coolifyme <alias> --help' for more information about any command")
+			aliases, err := config.GetAliases()
+			if err == nil && len(aliases) > 0 {
+				theme.Println("👤 User-defined:")
+				for _, name := range sortedAliasNames(aliases) {
+					theme.Printf("   %-24s → %s\n", name, aliases[name])
+				}
+				fmt.Println()
+			}
+
+			theme.Println("💡 Tip: Use 'coolifyme <alias> --help' for more information about any command")
 
 			return nil
 		},
 	}
 
+	// Create or replace a user-defined alias
+	setAliasCmd = &cobra.Command{
+		Use:   "set <name> <command>",
+		Short: "Create or replace a user-defined alias",
+		Long: `Create or replace a user-defined alias stored in the config file.
+
+Positional placeholders ($1, $2, ...) are replaced by the arguments given to the alias,
+the arguments without a placeholder are appended to the expanded command in order.
+
+Examples:
+  coolifyme alias set dp 'deploy application --wait'
+  coolifyme alias set logs 'applications logs $1 --lines 200'`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			name := args[0]
+			if isBuiltinCommand(name) {
+				return fmt.Errorf("'%s' is a built-in command and cannot be used as an alias", name)
+			}
+
+			expansion := strings.Join(args[1:], " ")
+			if _, err := splitCommandLine(expansion); err != nil {
+				return fmt.Errorf("invalid alias command: %w", err)
+			}
+
+			if err := config.SetAlias(name, expansion); err != nil {
+				return fmt.Errorf("failed to set alias: %w", err)
+			}
+
+			theme.Printf("✅ Alias '%s' → %s\n", name, expansion)
+			return nil
+		},
+	}
+
+	// Remove a user-defined alias
+	removeAliasCmd = &cobra.Command{
+		Use:     "remove <name>",
+		Aliases: []string{"rm", "delete"},
+		Short:   "Remove a user-defined alias",
+		Long:    "Remove a user-defined alias from the config file",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := config.RemoveAlias(args[0]); err != nil {
+				return fmt.Errorf("failed to remove alias: %w", err)
+			}
+
+			theme.Printf("✅ Alias '%s' removed\n", args[0])
+			return nil
+		},
+	}
+
 	// Container for all alias commands
 	aliasCmd = &cobra.Command{
 		Use:   "alias",
@@ -124,15 +177,167 @@ var (
 	}
 )
 
+// placeholderPattern matches positional placeholders such as $1 in alias expansions
+var placeholderPattern = regexp.MustCompile(`\$([1-9][0-9]*)`)
+
+// valueFlags lists global flags that consume the following argument
+var valueFlags = map[string]bool{
+	"--config": true, "--server": true, "-s": true, "--token": true, "-t": true,
+	"--profile": true, "-p": true, "--output": true, "-o": true, "--output-file": true, "--color": true,
+	"--theme": true, "--header": true, "-H": true,
+}
+
+// expandUserAlias replaces the first command word with its user-defined alias expansion.
+// Arguments are returned unchanged when no user-defined alias matches.
+func expandUserAlias(args []string, aliases map[string]string) ([]string, error) {
+	index := -1
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		if strings.HasPrefix(arg, "-") {
+			if valueFlags[arg] {
+				i++
+			}
+			continue
+		}
+		index = i
+		break
+	}
+
+	if index < 0 || isBuiltinCommand(args[index]) {
+		return args, nil
+	}
+
+	expansion, ok := aliases[strings.ToLower(args[index])]
+	if !ok {
+		return args, nil
+	}
+
+	words, err := splitCommandLine(expansion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alias '%s': %w", args[index], err)
+	}
+
+	rest := args[index+1:]
+	used := make(map[int]bool)
+	for i, word := range words {
+		var missing string
+		words[i] = placeholderPattern.ReplaceAllStringFunc(word, func(match string) string {
+			n, _ := strconv.Atoi(match[1:])
+			used[n] = true
+			if n > len(rest) {
+				missing = match
+				return match
+			}
+			return rest[n-1]
+		})
+		if missing != "" {
+			return nil, fmt.Errorf("alias '%s' requires argument %s", args[index], missing)
+		}
+	}
+	// Arguments without a placeholder are appended in order, also those skipped by a gap such as
+	// $2 without $1
+	expanded := make([]string, 0, len(args)+len(words))
+	expanded = append(expanded, args[:index]...)
+	expanded = append(expanded, words...)
+	for i, arg := range rest {
+		if !used[i+1] {
+			expanded = append(expanded, arg)
+		}
+	}
+	return expanded, nil
+}
+
+// splitCommandLine splits a command line into words, honouring single quotes, double quotes and backslashes
+func splitCommandLine(line string) ([]string, error) {
+	var (
+		words   []string
+		current strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash")
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+	return words, nil
+}
+
+// isBuiltinCommand reports whether name is a built-in command or one of its aliases
+func isBuiltinCommand(name string) bool {
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	return name == "help"
+}
+
+// sortedAliasNames returns the alias names in alphabetical order
+func sortedAliasNames(aliases map[string]string) []string {
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func init() {
 	// Add alias management commands
 	aliasCmd.AddCommand(listAliasesCmd)
+	aliasCmd.AddCommand(setAliasCmd)
+	aliasCmd.AddCommand(removeAliasCmd)
 
 	// Copy flags from original commands to aliases where needed
 	deployAppCmd.Flags().BoolP("force", "f", false, "Force deployment without confirmation")
+	addDeployNoCacheFlag(deployAppCmd)
 	deployAppCmd.Flags().Bool("debug", false, "Enable debug mode for deployment")
+	addDeployWaitFlags(deployAppCmd)
 
 	quickHealthCmd.Flags().BoolP("verbose", "v", false, "Verbose health check output")
+	addReportFlags(quickHealthCmd)
 
 	// Copy JSON flags for list commands
 	lsAppsCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
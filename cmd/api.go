@@ -4,7 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
 
+	"github.com/hongkongkiwi/coolifyme/internal/compat"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/hongkongkiwi/coolifyme/spec"
 	"github.com/spf13/cobra"
 )
 
@@ -42,13 +49,60 @@ var apiVersionCmd = &cobra.Command{
 			return nil
 		}
 
-		fmt.Printf("📋 Coolify API Version Information\n")
+		theme.Printf("📋 Coolify API Version Information\n")
 		fmt.Printf("==================================\n")
 		fmt.Printf("Version: %s\n", version)
 		return nil
 	},
 }
 
+// apiCapabilitiesCmd represents the api capabilities command
+var apiCapabilitiesCmd = &cobra.Command{
+	Use:   "capabilities",
+	Short: "Show which features the server supports",
+	Long: `Show the features that older Coolify releases lack and whether the server supports them.
+
+Support is learnt by calling the endpoints: the deployments of the first application are read,
+features that change something stay "unknown" until they are used. Without a feature, commands
+use the fallback shown, or fail with a "not supported by this server" error instead of a 404.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		caps, err := client.ProbeCapabilities(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to get API version: %w", err)
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			return outputJSON(map[string]interface{}{
+				"version":      caps.ServerVersion,
+				"capabilities": caps.List(),
+			})
+		}
+
+		theme.Printf("📋 Coolify %s\n", caps.ServerVersion)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "FEATURE\tSUPPORTED\tWITHOUT IT")
+		_, _ = fmt.Fprintln(w, "-------\t---------\t----------")
+		for _, c := range caps.List() {
+			supported := "unknown"
+			if c.Supported != nil {
+				supported = map[bool]string{true: "yes", false: "no"}[*c.Supported]
+			}
+			fallback := c.Fallback
+			if fallback == "" {
+				fallback = "fails"
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", c.Description, supported, fallback)
+		}
+		return w.Flush()
+	},
+}
+
 // apiEnableCmd represents the api enable command
 var apiEnableCmd = &cobra.Command{
 	Use:   "enable",
@@ -76,8 +130,8 @@ var apiEnableCmd = &cobra.Command{
 			return nil
 		}
 
-		fmt.Printf("✅ API access enabled successfully\n")
-		fmt.Printf("   📝 Response: %s\n", result)
+		theme.Printf("✅ API access enabled successfully\n")
+		theme.Printf("   📝 Response: %s\n", result)
 		return nil
 	},
 }
@@ -109,8 +163,8 @@ var apiDisableCmd = &cobra.Command{
 			return nil
 		}
 
-		fmt.Printf("✅ API access disabled successfully\n")
-		fmt.Printf("   📝 Response: %s\n", result)
+		theme.Printf("✅ API access disabled successfully\n")
+		theme.Printf("   📝 Response: %s\n", result)
 		return nil
 	},
 }
@@ -142,23 +196,146 @@ var apiHealthcheckCmd = &cobra.Command{
 			return nil
 		}
 
-		fmt.Printf("🩺 Coolify API Health Status\n")
+		theme.Printf("🩺 Coolify API Health Status\n")
 		fmt.Printf("===========================\n")
 		fmt.Printf("Status: %s\n", health)
 		return nil
 	},
 }
 
+// apiCheckCompatCmd represents the api check-compat command
+var apiCheckCompatCmd = &cobra.Command{
+	Use:   "check-compat",
+	Short: "Check API compatibility with the server",
+	Long: `Compare the OpenAPI spec this CLI was generated from with the spec of the Coolify server.
+
+The server version is read from /version and the official spec published for that release is
+downloaded. Endpoints and fields missing on the server mean it is older than the CLI supports;
+endpoints and fields only the server has mean it is newer than the CLI.
+
+Examples:
+  coolifyme api check-compat
+  coolifyme api check-compat --ref v4.0.0-beta.420
+  coolifyme api check-compat --spec ./openapi.yaml --strict`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		bundled, err := compat.Parse(spec.OpenAPI)
+		if err != nil {
+			return fmt.Errorf("failed to load bundled spec: %w", err)
+		}
+
+		ctx := context.Background()
+		specFile, _ := cmd.Flags().GetString("spec")
+		ref, _ := cmd.Flags().GetString("ref")
+		serverVersion := ""
+
+		var data []byte
+		source := specFile
+		switch {
+		case specFile != "":
+			data, err = os.ReadFile(specFile) // #nosec G304 - user-provided spec file
+			if err != nil {
+				return fmt.Errorf("failed to read spec file: %w", err)
+			}
+		default:
+			if ref == "" {
+				client, err := createClient()
+				if err != nil {
+					return fmt.Errorf("failed to create client: %w", err)
+				}
+				serverVersion, err = client.System().Version(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to get API version: %w", err)
+				}
+				ref = "v" + strings.TrimPrefix(serverVersion, "v")
+			}
+			source = fmt.Sprintf(clientpkg.OpenAPISpecURL, ref)
+			data, err = clientpkg.FetchOpenAPISpec(ctx, ref)
+			if err != nil {
+				return fmt.Errorf("failed to fetch the spec for %s (use --spec to compare with a local file): %w", ref, err)
+			}
+		}
+
+		server, err := compat.Parse(data)
+		if err != nil {
+			return err
+		}
+
+		diffs := compat.Compare(bundled, server)
+		missing := compat.Count(diffs, compat.ChangeMissing)
+		newer := compat.Count(diffs, compat.ChangeNewer)
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(map[string]interface{}{
+				"server_version": serverVersion,
+				"client_spec":    spec.SourceRef,
+				"server_spec":    source,
+				"compatible":     missing == 0,
+				"differences":    diffs,
+			}, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+		} else {
+			theme.Printf("🔍 Coolify API Compatibility\n")
+			fmt.Printf("============================\n")
+			if serverVersion != "" {
+				fmt.Printf("Server Version: %s\n", serverVersion)
+			}
+			fmt.Printf("Client Spec:    %s\n", spec.SourceRef)
+			fmt.Printf("Server Spec:    %s\n", source)
+			fmt.Println()
+
+			if len(diffs) == 0 {
+				theme.Printf("✅ The server API matches the API supported by this CLI\n")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "KIND\tCHANGE\tNAME")
+			_, _ = fmt.Fprintln(w, "----\t------\t----")
+			for _, diff := range diffs {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", diff.Kind, diff.Change, diff.Name)
+			}
+			_ = w.Flush()
+			fmt.Println()
+
+			if missing > 0 {
+				theme.Printf("⚠️  The server is missing %d endpoint(s)/field(s) used by this CLI - it is probably older than this CLI supports\n", missing)
+			}
+			if newer > 0 {
+				theme.Printf("💡 The server provides %d endpoint(s)/field(s) unknown to this CLI - upgrade coolifyme to use them\n", newer)
+			}
+		}
+
+		strict, _ := cmd.Flags().GetBool("strict")
+		if strict && missing > 0 {
+			return fmt.Errorf("server is missing %d endpoint(s)/field(s) used by this CLI", missing)
+		}
+		return nil
+	},
+}
+
 func init() {
 	// Add subcommands to api
 	apiCmd.AddCommand(apiVersionCmd)
+	apiCmd.AddCommand(apiCapabilitiesCmd)
 	apiCmd.AddCommand(apiEnableCmd)
 	apiCmd.AddCommand(apiDisableCmd)
 	apiCmd.AddCommand(apiHealthcheckCmd)
+	apiCmd.AddCommand(apiCheckCompatCmd)
 
 	// Flags for all commands
 	apiVersionCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+	apiCapabilitiesCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
 	apiEnableCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
 	apiDisableCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
 	apiHealthcheckCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+
+	// Flags for check-compat command
+	apiCheckCompatCmd.Flags().String("spec", "", "Compare with a local OpenAPI spec file instead of downloading it")
+	apiCheckCompatCmd.Flags().String("ref", "", "Compare with the official spec of this git ref instead of the server version")
+	apiCheckCompatCmd.Flags().Bool("strict", false, "Exit with an error when the server is missing endpoints or fields used by this CLI")
+	apiCheckCompatCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
 }
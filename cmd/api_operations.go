@@ -0,0 +1,248 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hongkongkiwi/coolifyme/internal/compat"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/hongkongkiwi/coolifyme/spec"
+	"github.com/spf13/cobra"
+)
+
+// operationCommands maps the operation IDs of the bundled OpenAPI specification to the commands
+// exposing them, without the program name. Add new commands here so 'api operations' counts
+// them; entries naming commands that do not exist are reported as stale.
+var operationCommands = map[string][]string{
+	"create-database-clickhouse":            {"databases create clickhouse"},
+	"create-database-dragonfly":             {"databases create dragonfly"},
+	"create-database-keydb":                 {"databases create keydb"},
+	"create-database-mariadb":               {"databases create mariadb"},
+	"create-database-mongodb":               {"databases create mongodb"},
+	"create-database-mysql":                 {"databases create mysql"},
+	"create-database-postgresql":            {"databases create postgresql"},
+	"create-database-redis":                 {"databases create redis"},
+	"create-dockercompose-application":      {"applications create"},
+	"create-dockerfile-application":         {"applications create"},
+	"create-dockerimage-application":        {"applications create"},
+	"create-env-by-application-uuid":        {"applications env create"},
+	"create-env-by-service-uuid":            {"services create-env"},
+	"create-private-deploy-key-application": {"applications create"},
+	"create-private-github-app-application": {"applications create"},
+	"create-private-key":                    {"keys create"},
+	"create-project":                        {"projects create"},
+	"create-public-application":             {"applications create", "applications create-wizard"},
+	"create-server":                         {"servers create", "servers add-wizard"},
+	"create-service":                        {"services create"},
+	"delete-application-by-uuid":            {"applications delete"},
+	"delete-database-by-uuid":               {"databases delete"},
+	"delete-env-by-application-uuid":        {"applications env delete"},
+	"delete-env-by-service-uuid":            {"services delete-env"},
+	"delete-private-key-by-uuid":            {"keys delete"},
+	"delete-project-by-uuid":                {"projects delete"},
+	"delete-server-by-uuid":                 {"servers delete"},
+	"delete-service-by-uuid":                {"services delete"},
+	"deploy-by-tag-or-uuid":                 {"deploy application", "deploy multiple"},
+	"disable-api":                           {"api disable"},
+	"enable-api":                            {"api enable"},
+	"get-application-by-uuid":               {"applications get", "applications limits get"},
+	"get-application-logs-by-uuid":          {"applications logs", "logs"},
+	"get-current-team":                      {"teams get-current"},
+	"get-current-team-members":              {"teams get-current-members"},
+	"get-database-by-uuid":                  {"databases get"},
+	"get-deployment-by-uuid":                {"deploy get"},
+	"get-domains-by-server-uuid":            {"servers get-domains"},
+	"get-environment-by-name-or-uuid":       {"projects get-environment"},
+	"get-members-by-team-id":                {"teams get-members"},
+	"get-private-key-by-uuid":               {"keys get"},
+	"get-project-by-uuid":                   {"projects get"},
+	"get-resources-by-server-uuid":          {"servers get-resources"},
+	"get-server-by-uuid":                    {"servers get", "servers proxy get", "servers proxy status"},
+	"get-service-by-uuid":                   {"services get"},
+	"get-team-by-id":                        {"teams get"},
+	"healthcheck":                           {"api healthcheck"},
+	"list-applications":                     {"applications list"},
+	"list-databases":                        {"databases list"},
+	"list-deployments":                      {"deploy list-all"},
+	"list-deployments-by-app-uuid":          {"deploy list"},
+	"list-envs-by-application-uuid":         {"applications env list"},
+	"list-envs-by-service-uuid":             {"services list-envs"},
+	"list-private-keys":                     {"keys list"},
+	"list-projects":                         {"projects list"},
+	"list-resources":                        {"resources list"},
+	"list-servers":                          {"servers list"},
+	"list-services":                         {"services list"},
+	"list-teams":                            {"teams list"},
+	"restart-application-by-uuid":           {"applications restart"},
+	"restart-database-by-uuid":              {"databases restart"},
+	"restart-service-by-uuid":               {"services restart"},
+	"start-application-by-uuid":             {"applications start"},
+	"start-database-by-uuid":                {"databases start"},
+	"start-service-by-uuid":                 {"services start"},
+	"stop-application-by-uuid":              {"applications stop"},
+	"stop-database-by-uuid":                 {"databases stop"},
+	"stop-service-by-uuid":                  {"services stop"},
+	"update-application-by-uuid":            {"applications update", "applications limits set"},
+	"update-database-by-uuid":               {"databases update"},
+	"update-env-by-application-uuid":        {"applications env update"},
+	"update-env-by-service-uuid":            {"services update-env"},
+	"update-envs-by-application-uuid":       {"applications env update-bulk", "applications env import"},
+	"update-envs-by-service-uuid":           {"services update-envs"},
+	"update-private-key":                    {"keys update"},
+	"update-project-by-uuid":                {"projects update"},
+	"update-server-by-uuid":                 {"servers update", "servers proxy set"},
+	"update-service-by-uuid":                {"services update"},
+	"validate-server-by-uuid":               {"servers validate"},
+	"version":                               {"api version"},
+}
+
+// operationCoverage is an operation of the bundled specification with the client methods and
+// commands exposing it
+type operationCoverage struct {
+	compat.Operation
+	ClientMethods []string `json:"client_methods"`
+	Commands      []string `json:"commands"`
+}
+
+// covered reports whether the operation has a typed client method and a command
+func (o operationCoverage) covered() bool {
+	return len(o.ClientMethods) > 0 && len(o.Commands) > 0
+}
+
+// apiOperationsCmd represents the api operations command
+var apiOperationsCmd = &cobra.Command{
+	Use:   "operations",
+	Short: "List the API operations and the client methods and commands covering them",
+	Long: `List every operation of the OpenAPI spec this CLI was generated from with the typed client
+methods and the commands exposing it, making coverage gaps visible.
+
+With --strict the command fails when an operation has no client method or command, or when
+commands are registered for operations or command paths that no longer exist, so CI catches
+regressions after 'task update-spec'.
+
+Examples:
+  coolifyme api operations
+  coolifyme api operations --missing
+  coolifyme api operations --json --strict`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		operations, err := compat.ParseOperations(spec.OpenAPI)
+		if err != nil {
+			return fmt.Errorf("failed to load bundled spec: %w", err)
+		}
+
+		var stale []string
+		known := make(map[string]bool, len(operations))
+		coverage := make([]operationCoverage, 0, len(operations))
+		withClient, withCommand := 0, 0
+		for _, operation := range operations {
+			known[operation.ID] = true
+			entry := operationCoverage{
+				Operation:     operation,
+				ClientMethods: append([]string{}, clientpkg.OperationMethods[operation.ID]...),
+				Commands:      []string{},
+			}
+			for _, path := range operationCommands[operation.ID] {
+				if commandExists(path) {
+					entry.Commands = append(entry.Commands, path)
+				} else {
+					stale = append(stale, fmt.Sprintf("%s: command '%s' does not exist", operation.ID, path))
+				}
+			}
+			if len(entry.ClientMethods) > 0 {
+				withClient++
+			}
+			if len(entry.Commands) > 0 {
+				withCommand++
+			}
+			coverage = append(coverage, entry)
+		}
+		for id := range operationCommands {
+			if !known[id] {
+				stale = append(stale, fmt.Sprintf("%s: not an operation of the bundled spec", id))
+			}
+		}
+		sort.Strings(stale)
+
+		missingOnly, _ := cmd.Flags().GetBool("missing")
+		shown := coverage
+		if missingOnly {
+			shown = nil
+			for _, entry := range coverage {
+				if !entry.covered() {
+					shown = append(shown, entry)
+				}
+			}
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			if shown == nil {
+				shown = []operationCoverage{}
+			}
+			if stale == nil {
+				stale = []string{}
+			}
+			output, err := json.MarshalIndent(map[string]interface{}{
+				"spec":               spec.SourceRef,
+				"operations":         len(operations),
+				"with_client_method": withClient,
+				"with_command":       withCommand,
+				"stale":              stale,
+				"coverage":           shown,
+			}, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+		} else {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "OPERATION\tMETHOD\tPATH\tCLIENT METHOD\tCOMMAND")
+			_, _ = fmt.Fprintln(w, "---------\t------\t----\t-------------\t-------")
+			for _, entry := range shown {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", entry.ID, entry.Method, entry.Path,
+					joinOrDash(entry.ClientMethods), joinOrDash(entry.Commands))
+			}
+			_ = w.Flush()
+			fmt.Println()
+
+			theme.Printf("📊 %d operations: %d with a client method, %d with a command\n", len(operations), withClient, withCommand)
+			for _, problem := range stale {
+				warn("command mapping", fmt.Errorf("stale: %s", problem))
+			}
+		}
+
+		strict, _ := cmd.Flags().GetBool("strict")
+		if strict && (withClient < len(operations) || withCommand < len(operations) || len(stale) > 0) {
+			return fmt.Errorf("%d operation(s) without a client method, %d without a command, %d stale mapping(s)",
+				len(operations)-withClient, len(operations)-withCommand, len(stale))
+		}
+		return nil
+	},
+}
+
+// commandExists reports whether a command path, without the program name, is a command of the CLI
+func commandExists(path string) bool {
+	found, _, err := rootCmd.Find(strings.Fields(path))
+	return err == nil && found.CommandPath() == rootCmd.Name()+" "+path
+}
+
+// joinOrDash joins names with commas, or returns "-" when there are none
+func joinOrDash(names []string) string {
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
+
+func init() {
+	apiCmd.AddCommand(apiOperationsCmd)
+
+	apiOperationsCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+	apiOperationsCmd.Flags().Bool("missing", false, "Only list operations without a client method or command")
+	apiOperationsCmd.Flags().Bool("strict", false, "Fail when an operation is not covered or a command mapping is stale")
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// appFilterCondition is a single key=value or key!=value condition given with --filter
+type appFilterCondition struct {
+	Key    string
+	Value  string
+	Negate bool
+}
+
+// parseAppFilters parses --filter expressions such as "status=exited" or "name!=*-staging"
+func parseAppFilters(expressions []string) ([]appFilterCondition, error) {
+	conditions := make([]appFilterCondition, 0, len(expressions))
+	for _, expression := range expressions {
+		condition := appFilterCondition{}
+		key, value, ok := strings.Cut(expression, "!=")
+		if ok {
+			condition.Negate = true
+		} else if key, value, ok = strings.Cut(expression, "="); !ok {
+			return nil, fmt.Errorf("invalid filter '%s', expected key=value or key!=value", expression)
+		}
+
+		condition.Key = strings.ToLower(strings.TrimSpace(key))
+		condition.Value = strings.ToLower(strings.TrimSpace(value))
+		switch condition.Key {
+		case "status":
+		case "name":
+			if _, err := path.Match(condition.Value, ""); err != nil {
+				return nil, fmt.Errorf("invalid name pattern '%s': %w", value, err)
+			}
+		default:
+			return nil, fmt.Errorf("unknown filter key '%s' (supported: status, name)", key)
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// matches reports whether an application satisfies the condition. Statuses match by prefix, so
+// "exited" matches "exited:unhealthy"; names accept shell wildcards.
+func (c appFilterCondition) matches(app coolify.Application) bool {
+	var match bool
+	switch c.Key {
+	case "status":
+		status := ""
+		if app.Status != nil {
+			status = strings.ToLower(*app.Status)
+		}
+		match = strings.HasPrefix(status, c.Value)
+	case "name":
+		name := ""
+		if app.Name != nil {
+			name = strings.ToLower(*app.Name)
+		}
+		match, _ = path.Match(c.Value, name)
+	}
+	return match != c.Negate
+}
+
+// addAppFilterFlags adds the flags selecting a subset of applications for bulk commands
+func addAppFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray("filter", nil, "Only include applications matching key=value or key!=value (keys: status, name with wildcards)")
+	cmd.Flags().String("server", "", "Only include applications on this server (UUID, UUID prefix or name)")
+	cmd.Flags().String("tag", "", "Only include applications with this tag")
+	addScopeFlags(cmd)
+}
+
+// selectApplications lists the applications matching the filter flags of a command
+func selectApplications(ctx context.Context, cmd *cobra.Command, client *clientpkg.Client) ([]coolify.Application, error) {
+	expressions, _ := cmd.Flags().GetStringArray("filter")
+	conditions, err := parseAppFilters(expressions)
+	if err != nil {
+		return nil, err
+	}
+
+	environmentIDs, err := scopeEnvironmentIDs(ctx, cmd, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var serverApps map[string]bool
+	if server, _ := cmd.Flags().GetString("server"); server != "" {
+		if serverApps, err = serverApplicationUUIDs(ctx, client, server); err != nil {
+			return nil, err
+		}
+	}
+
+	var apps []coolify.Application
+	if tag, _ := cmd.Flags().GetString("tag"); tag != "" {
+		apps, err = client.Applications().ListByTag(ctx, tag)
+	} else {
+		apps, err = client.Applications().List(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	selected := make([]coolify.Application, 0, len(apps))
+	for _, app := range apps {
+		if app.Uuid == nil {
+			continue
+		}
+		if environmentIDs != nil && (app.EnvironmentId == nil || !environmentIDs[*app.EnvironmentId]) {
+			continue
+		}
+		if serverApps != nil && !serverApps[*app.Uuid] {
+			continue
+		}
+		matched := true
+		for _, condition := range conditions {
+			if !condition.matches(app) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			selected = append(selected, app)
+		}
+	}
+	return selected, nil
+}
+
+// resolveResourceRef finds a resource by UUID, unique UUID prefix or name (case-insensitive)
+func resolveResourceRef(ctx context.Context, client *clientpkg.Client, kind clientpkg.ResourceKind, value string) (clientpkg.ResourceRef, error) {
+	refs, err := client.ListRefs(ctx, kind)
+	if err != nil {
+		return clientpkg.ResourceRef{}, fmt.Errorf("failed to list %ss: %w", kind, err)
+	}
+
+	var matches []clientpkg.ResourceRef
+	for _, ref := range refs {
+		if ref.UUID == value || strings.EqualFold(ref.Name, value) {
+			matches = []clientpkg.ResourceRef{ref}
+			break
+		}
+		if len(value) >= clientpkg.MinUUIDPrefix && strings.HasPrefix(ref.UUID, value) {
+			matches = append(matches, ref)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return clientpkg.ResourceRef{}, fmt.Errorf("%s '%s' not found", kind, value)
+	case 1:
+		return matches[0], nil
+	default:
+		return clientpkg.ResourceRef{}, fmt.Errorf("%s '%s' is ambiguous, use the full UUID", kind, value)
+	}
+}
+
+// serverApplicationUUIDs returns the UUIDs of the applications running on a server
+func serverApplicationUUIDs(ctx context.Context, client *clientpkg.Client, server string) (map[string]bool, error) {
+	ref, err := resolveResourceRef(ctx, client, clientpkg.KindServer, server)
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := client.Servers().GetResources(ctx, ref.UUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resources of server %s: %w", server, err)
+	}
+	var items []struct {
+		UUID string `json:"uuid"`
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal([]byte(resources), &items); err != nil {
+		return nil, fmt.Errorf("failed to parse resources of server %s: %w", server, err)
+	}
+
+	uuids := make(map[string]bool)
+	for _, item := range items {
+		if item.Type == "" || item.Type == "application" {
+			uuids[item.UUID] = true
+		}
+	}
+	return uuids, nil
+}
@@ -5,12 +5,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
+	"regexp"
+	"slices"
 	"strings"
-	"text/tabwriter"
 	"time"
 
 	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/envcrypt"
+	"github.com/hongkongkiwi/coolifyme/internal/envtemplate"
+	"github.com/hongkongkiwi/coolifyme/internal/output"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
 	"github.com/spf13/cobra"
 )
 
@@ -27,7 +36,9 @@ var applicationsListCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls"},
 	Short:   "List applications",
-	Long:    "List all applications in your Coolify instance",
+	Long: `List all applications in your Coolify instance.
+
+Use -o wide to add the project, environment and server of each application.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		client, err := createClient()
 		if err != nil {
@@ -40,6 +51,20 @@ var applicationsListCmd = &cobra.Command{
 			return fmt.Errorf("failed to list applications: %w", err)
 		}
 
+		environmentIDs, err := scopeEnvironmentIDs(ctx, cmd, client)
+		if err != nil {
+			return err
+		}
+		if environmentIDs != nil {
+			scoped := applications[:0]
+			for _, app := range applications {
+				if app.EnvironmentId != nil && environmentIDs[*app.EnvironmentId] {
+					scoped = append(scoped, app)
+				}
+			}
+			applications = scoped
+		}
+
 		jsonOutput, _ := cmd.Flags().GetBool("json")
 		if jsonOutput {
 			output, err := json.MarshalIndent(applications, "", "  ")
@@ -49,23 +74,26 @@ var applicationsListCmd = &cobra.Command{
 			fmt.Println(string(output))
 			return nil
 		}
+		if handled, err := outputWithTemplate(cmd, applications); handled {
+			return err
+		}
 
 		if len(applications) == 0 {
 			fmt.Println("No applications found")
 			return nil
 		}
 
-		// Create a tabwriter for nicely formatted output
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		defer func() {
-			_ = w.Flush()
-		}()
-
-		// Print header
-		_, _ = fmt.Fprintln(w, "UUID\tNAME\tSTATUS\tGIT REPOSITORY\tDOMAINS")
-		_, _ = fmt.Fprintln(w, "----\t----\t------\t--------------\t-------")
+		var ns *namespaces
+		headers := []string{"UUID", "NAME", "STATUS", "GIT REPOSITORY", "DOMAINS"}
+		if wideOutput(cmd) {
+			if ns, err = loadNamespaces(ctx, client); err != nil {
+				return err
+			}
+			headers = []string{"UUID", "NAME", "STATUS", "PROJECT", "ENVIRONMENT", "SERVER", "GIT REPOSITORY", "DOMAINS"}
+		}
 
-		// Print applications
+		// Print applications; each row goes to the table as it is built
+		table := output.NewTableWriter(os.Stdout, headers, pagedTable(false, pageSize(cmd)))
 		for _, app := range applications {
 			uuid := ""
 			name := ""
@@ -89,11 +117,17 @@ var applicationsListCmd = &cobra.Command{
 				domains = *app.Fqdn
 			}
 
-			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-				uuid, name, status, gitRepo, domains)
+			row := []string{uuid, name, status, gitRepo, domains}
+			if ns != nil {
+				project, environment := ns.environment(app.EnvironmentId)
+				row = []string{uuid, name, status, project, environment, ns.server(app.Uuid, nil), gitRepo, domains}
+			}
+			if err := table.Row(row...); err != nil {
+				return ignorePagingStopped(err)
+			}
 		}
 
-		return nil
+		return table.Flush()
 	},
 }
 
@@ -161,8 +195,32 @@ var applicationsGetCmd = &cobra.Command{
 var applicationsCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new application",
-	Long:  "Create a new application from a Git repository",
+	Long: `Create a new application from a public Git repository.
+
+Ports, install/build/start commands, directories, the Dockerfile location and domains can be
+set at creation time, so a working application needs no follow-up edits in the UI.
+
+Examples:
+  coolifyme applications create --repo https://github.com/user/app --project <uuid> --server <uuid> \
+    --environment production --ports-exposes 8080 --domains https://app.example.com
+  coolifyme applications create --repo https://github.com/user/site --build-pack static --publish-directory dist \
+    --install-command "npm ci" --build-command "npm run build" --ports-exposes 80 --project <uuid> --server <uuid> --environment production
+  coolifyme applications create --repo https://github.com/user/api --build-pack dockerfile --base-directory /api \
+    --dockerfile-location /docker/Dockerfile.prod --ports-exposes 8080 --project <uuid> --server <uuid> --environment production
+
+The request body can instead be read from a YAML or JSON file with --from-file, using the field
+names of the Coolify API. Together with --type this also creates applications from private
+repositories, Dockerfiles, Docker images and Docker Compose files:
+  coolifyme applications create --from-file app.yaml
+  coolifyme applications create --type dockerimage --from-file image-app.yaml`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
+		if fromFile, _ := cmd.Flags().GetString("from-file"); fromFile != "" {
+			return createApplicationFromFile(cmd)
+		}
+		if appType, _ := cmd.Flags().GetString("type"); appType != "public" {
+			return fmt.Errorf("--type %s requires --from-file", appType)
+		}
+
 		// Get flag values
 		repo, _ := cmd.Flags().GetString("repo")
 		branch, _ := cmd.Flags().GetString("branch")
@@ -170,6 +228,8 @@ var applicationsCreateCmd = &cobra.Command{
 		project, _ := cmd.Flags().GetString("project")
 		server, _ := cmd.Flags().GetString("server")
 		environment, _ := cmd.Flags().GetString("environment")
+		portsExposes, _ := cmd.Flags().GetString("ports-exposes")
+		dockerfileLocation, _ := cmd.Flags().GetString("dockerfile-location")
 
 		// Validate required fields
 		if repo == "" {
@@ -190,21 +250,143 @@ var applicationsCreateCmd = &cobra.Command{
 		if environment == "" {
 			return fmt.Errorf("environment name is required (--environment)")
 		}
+		if portsExposes == "" {
+			return fmt.Errorf("exposed ports are required (--ports-exposes, e.g. --ports-exposes 3000)")
+		}
+		if dockerfileLocation != "" && buildPack != "dockerfile" {
+			return fmt.Errorf("--dockerfile-location requires --build-pack dockerfile")
+		}
+
+		req := coolify.CreatePublicApplicationJSONRequestBody{
+			BuildPack:       coolify.CreatePublicApplicationJSONBodyBuildPack(buildPack),
+			EnvironmentName: environment,
+			GitBranch:       branch,
+			GitRepository:   repo,
+			PortsExposes:    portsExposes,
+			ProjectUuid:     project,
+			ServerUuid:      server,
+		}
+
+		// Optional settings are only sent when set
+		optional := map[string]**string{
+			"name":              &req.Name,
+			"description":       &req.Description,
+			"install-command":   &req.InstallCommand,
+			"build-command":     &req.BuildCommand,
+			"start-command":     &req.StartCommand,
+			"base-directory":    &req.BaseDirectory,
+			"publish-directory": &req.PublishDirectory,
+			"domains":           &req.Domains,
+		}
+		for flag, field := range optional {
+			if value, _ := cmd.Flags().GetString(flag); value != "" {
+				*field = &value
+			}
+		}
+		if buildPack == "static" {
+			isStatic := true
+			req.IsStatic = &isStatic
+		}
+		if cmd.Flags().Changed("instant-deploy") {
+			instantDeploy, _ := cmd.Flags().GetBool("instant-deploy")
+			req.InstantDeploy = &instantDeploy
+		}
+
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		ctx, guard := beginCreate(ctx, client, "application", req)
+		app, err := client.Applications().CreatePublic(ctx, req)
+		guard.finish(err)
+		if err != nil {
+			return fmt.Errorf("failed to create application: %w", err)
+		}
+		appUUID := stringOrDash(app.Uuid)
+
+		// The create endpoint does not accept the Dockerfile location, so it is set afterwards
+		if dockerfileLocation != "" {
+			if err := client.Applications().UpdateFields(ctx, appUUID, map[string]any{"dockerfile_location": dockerfileLocation}); err != nil {
+				return fmt.Errorf("application %s created but setting the Dockerfile location failed: %w", appUUID, err)
+			}
+		}
 
-		fmt.Printf("Creating application...\n")
-		fmt.Printf("Repository:   %s\n", repo)
-		fmt.Printf("Branch:       %s\n", branch)
-		fmt.Printf("Build Pack:   %s\n", buildPack)
-		fmt.Printf("Project:      %s\n", project)
-		fmt.Printf("Server:       %s\n", server)
-		fmt.Printf("Environment:  %s\n", environment)
+		if printQuietUUID(appUUID) {
+			return nil
+		}
 
-		// This is a placeholder - the actual implementation would depend on
-		// the complete API client implementation
-		return fmt.Errorf("application creation is not fully implemented yet - API client needs to be extended")
+		theme.Statusf("✅ Application created successfully\n")
+		fmt.Printf("   UUID:        %s\n", appUUID)
+		theme.Statusf("   Repository:  %s (%s)\n", repo, branch)
+		theme.Statusf("   Build Pack:  %s\n", buildPack)
+		theme.Statusf("   Ports:       %s\n", portsExposes)
+		if req.Domains != nil {
+			theme.Statusf("   Domains:     %s\n", *req.Domains)
+		}
+		return nil
 	},
 }
 
+// applicationTypes lists the kinds of applications that can be created with --from-file
+var applicationTypes = []string{"public", "private-github-app", "private-deploy-key", "dockerfile", "dockerimage", "dockercompose"}
+
+// createApplicationFromFile creates an application of the --type kind from the request body in --from-file
+func createApplicationFromFile(cmd *cobra.Command) error {
+	appType, _ := cmd.Flags().GetString("type")
+
+	client, err := createClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx := context.Background()
+	apps := client.Applications()
+	var app *coolify.Application
+	switch appType {
+	case "public":
+		app, err = createFromRequestFile(ctx, cmd, client, apps.CreatePublic)
+	case "private-github-app":
+		app, err = createFromRequestFile(ctx, cmd, client, apps.CreatePrivateGithubApp)
+	case "private-deploy-key":
+		app, err = createFromRequestFile(ctx, cmd, client, apps.CreatePrivateDeployKey)
+	case "dockerfile":
+		app, err = createFromRequestFile(ctx, cmd, client, apps.CreateDockerfile)
+	case "dockerimage":
+		app, err = createFromRequestFile(ctx, cmd, client, apps.CreateDockerImage)
+	case "dockercompose":
+		app, err = createFromRequestFile(ctx, cmd, client, apps.CreateDockerCompose)
+	default:
+		return fmt.Errorf("invalid application type '%s' (valid: %s)", appType, strings.Join(applicationTypes, ", "))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create application: %w", err)
+	}
+
+	appUUID := stringOrDash(app.Uuid)
+	if printQuietUUID(appUUID) {
+		return nil
+	}
+	theme.Statusf("✅ Application created successfully\n")
+	fmt.Printf("   UUID:        %s\n", appUUID)
+	return nil
+}
+
+// createFromRequestFile reads the request body of an application create call from --from-file
+// and sends it
+func createFromRequestFile[T any, R any](ctx context.Context, cmd *cobra.Command, client clientpkg.API, create func(context.Context, T) (R, error)) (R, error) {
+	var req T
+	if _, err := readRequestFile(cmd, &req); err != nil {
+		var zero R
+		return zero, err
+	}
+	ctx, guard := beginCreate(ctx, client, "application", req)
+	created, err := create(ctx, req)
+	guard.finish(err)
+	return created, err
+}
+
 // applicationsDeleteCmd represents the applications delete command
 var applicationsDeleteCmd = &cobra.Command{
 	Use:   "delete <uuid>",
@@ -216,16 +398,32 @@ var applicationsDeleteCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
+		if err := checkTokenAbility(cmd, client, clientpkg.AbilityWrite); err != nil {
+			return err
+		}
 
+		ctx := context.Background()
 		deleteVolumes, _ := cmd.Flags().GetBool("delete-volumes")
 		deleteConfigs, _ := cmd.Flags().GetBool("delete-configurations")
 
+		force := skipConfirmation(cmd)
+		target := confirm.Target{Kind: "application", ID: args[0], Dependents: deleteDependents(deleteVolumes, deleteConfigs)}
+		if !force {
+			if app, err := client.Applications().Get(ctx, args[0]); err == nil && app.Name != nil {
+				target.Name = *app.Name
+			}
+		}
+		if !confirm.Delete(target, force) {
+			theme.Println("❌ Deletion cancelled")
+			return nil
+		}
+
 		options := &coolify.DeleteApplicationByUuidParams{
 			DeleteVolumes:        &deleteVolumes,
 			DeleteConfigurations: &deleteConfigs,
 		}
 
-		err = client.Applications().Delete(context.Background(), args[0], options)
+		err = client.Applications().Delete(ctx, args[0], options)
 		if err != nil {
 			return fmt.Errorf("failed to delete application: %w", err)
 		}
@@ -239,17 +437,28 @@ var applicationsDeleteCmd = &cobra.Command{
 var applicationsUpdateCmd = &cobra.Command{
 	Use:   "update <uuid>",
 	Short: "Update an application",
-	Long:  "Update an application by UUID",
-	Args:  cobra.ExactArgs(1),
-	RunE: func(_ *cobra.Command, args []string) error {
+	Long: `Update an application by UUID with the fields read from a YAML or JSON file. Field names
+are those of the Coolify API, and only the fields in the file are changed.
+
+Examples:
+  coolifyme applications update <uuid> --from-file app.yaml
+  echo 'health_check_enabled: true' | coolifyme applications update <uuid> --from-file -`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req := coolify.UpdateApplicationByUuidJSONRequestBody{}
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
+		}
+		if !fromFile {
+			return fmt.Errorf("nothing to update, pass the fields to change with --from-file")
+		}
+
 		client, err := createClient()
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		// For now, this is a stub - you'd need to implement fields to update
-		req := coolify.UpdateApplicationByUuidJSONRequestBody{}
-
 		uuid, err := client.Applications().Update(context.Background(), args[0], req)
 		if err != nil {
 			return fmt.Errorf("failed to update application: %w", err)
@@ -262,15 +471,29 @@ var applicationsUpdateCmd = &cobra.Command{
 
 // applicationsStartCmd represents the applications start command
 var applicationsStartCmd = &cobra.Command{
-	Use:   "start <uuid>",
-	Short: "Start an application",
-	Long:  "Start an application by UUID",
-	Args:  cobra.ExactArgs(1),
+	Use:   "start <uuid>...",
+	Short: "Start one or more applications",
+	Long: `Start applications by UUID. Several applications, given as arguments or listed in a file
+with --file, are started concurrently and a result is printed for each.
+
+Examples:
+  coolifyme applications start <uuid>
+  coolifyme applications start <uuid1> <uuid2> <uuid3>
+  coolifyme applications start --file uuids.txt --concurrent 10`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		uuids, err := appUUIDArgs(cmd, args)
+		if err != nil {
+			return err
+		}
+
 		client, err := createClient()
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
+		if len(uuids) > 1 {
+			return runAppsOperationForUUIDs(cmd, client, "start", uuids)
+		}
+		args = uuids
 
 		force, _ := cmd.Flags().GetBool("force")
 		options := &coolify.StartApplicationByUuidParams{
@@ -283,12 +506,12 @@ var applicationsStartCmd = &cobra.Command{
 		}
 
 		if startResponse != nil {
-			fmt.Printf("✅ Application %s started successfully\n", args[0])
+			theme.Printf("✅ Application %s started successfully\n", args[0])
 			if startResponse.DeploymentUUID != "" {
-				fmt.Printf("   📦 Deployment UUID: %s\n", startResponse.DeploymentUUID)
+				theme.Printf("   📦 Deployment UUID: %s\n", startResponse.DeploymentUUID)
 			}
 			if startResponse.Message != "" {
-				fmt.Printf("   💬 Message: %s\n", startResponse.Message)
+				theme.Printf("   💬 Message: %s\n", startResponse.Message)
 			}
 		} else {
 			fmt.Printf("Application %s started successfully\n", args[0])
@@ -299,15 +522,29 @@ var applicationsStartCmd = &cobra.Command{
 
 // applicationsStopCmd represents the applications stop command
 var applicationsStopCmd = &cobra.Command{
-	Use:   "stop <uuid>",
-	Short: "Stop an application",
-	Long:  "Stop an application by UUID",
-	Args:  cobra.ExactArgs(1),
-	RunE: func(_ *cobra.Command, args []string) error {
+	Use:   "stop <uuid>...",
+	Short: "Stop one or more applications",
+	Long: `Stop applications by UUID. Several applications, given as arguments or listed in a file
+with --file, are stopped concurrently and a result is printed for each.
+
+Examples:
+  coolifyme applications stop <uuid>
+  coolifyme applications stop <uuid1> <uuid2>
+  coolifyme applications stop --file uuids.txt`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		uuids, err := appUUIDArgs(cmd, args)
+		if err != nil {
+			return err
+		}
+
 		client, err := createClient()
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
+		if len(uuids) > 1 {
+			return runAppsOperationForUUIDs(cmd, client, "stop", uuids)
+		}
+		args = uuids
 
 		err = client.Applications().Stop(context.Background(), args[0])
 		if err != nil {
@@ -321,15 +558,43 @@ var applicationsStopCmd = &cobra.Command{
 
 // applicationsRestartCmd represents the applications restart command
 var applicationsRestartCmd = &cobra.Command{
-	Use:   "restart <uuid>",
-	Short: "Restart an application",
-	Long:  "Restart an application by UUID",
-	Args:  cobra.ExactArgs(1),
-	RunE: func(_ *cobra.Command, args []string) error {
+	Use:   "restart <uuid>...",
+	Short: "Restart one or more applications",
+	Long: `Restart applications by UUID. Several applications, given as arguments or listed in a file
+with --file, are restarted concurrently and a result is printed for each.
+
+Examples:
+  coolifyme applications restart <uuid>
+  coolifyme applications restart <uuid1> <uuid2>
+  cat uuids.txt | coolifyme applications restart --file -
+
+With --at or --cron the restart is queued for a maintenance window instead, and run by
+'coolifyme scheduler run':
+  coolifyme applications restart <uuid> --at 03:00
+  coolifyme applications restart <uuid> --cron "0 3 * * 0"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		uuids, err := appUUIDArgs(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		if scheduleRequested(cmd) {
+			for _, uuid := range uuids {
+				if err := scheduleAction(cmd, "restart application "+uuid, []string{"applications", "restart", uuid}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
 		client, err := createClient()
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
+		if len(uuids) > 1 {
+			return runAppsOperationForUUIDs(cmd, client, "restart", uuids)
+		}
+		args = uuids
 
 		restartResponse, err := client.Applications().Restart(context.Background(), args[0])
 		if err != nil {
@@ -337,12 +602,12 @@ var applicationsRestartCmd = &cobra.Command{
 		}
 
 		if restartResponse != nil {
-			fmt.Printf("✅ Application %s restarted successfully\n", args[0])
+			theme.Printf("✅ Application %s restarted successfully\n", args[0])
 			if restartResponse.DeploymentUUID != "" {
-				fmt.Printf("   📦 Deployment UUID: %s\n", restartResponse.DeploymentUUID)
+				theme.Printf("   📦 Deployment UUID: %s\n", restartResponse.DeploymentUUID)
 			}
 			if restartResponse.Message != "" {
-				fmt.Printf("   💬 Message: %s\n", restartResponse.Message)
+				theme.Printf("   💬 Message: %s\n", restartResponse.Message)
 			}
 		} else {
 			fmt.Printf("Application %s restarted successfully\n", args[0])
@@ -355,20 +620,60 @@ var applicationsRestartCmd = &cobra.Command{
 var applicationsLogsCmd = &cobra.Command{
 	Use:   "logs <uuid>",
 	Short: "Get application logs",
-	Long:  "Get logs for an application by UUID",
-	Args:  cobra.ExactArgs(1),
+	Long: `Get logs for an application by UUID.
+
+With --follow the logs are polled and new lines are printed as they arrive, reconnecting
+automatically after network errors.
+
+--since and --until accept a duration ago (30m, 2h, 1d), a number of seconds ago or a time such
+as 2024-01-15T10:30:00 (local time unless a zone is given). The API can only return the last
+lines of the logs, so the time range is applied to the returned lines using the timestamps at the
+start of each line; without --lines the last 5000 lines are fetched. Lines without a timestamp,
+like stack traces, belong to the timestamped line before them. Docker timestamps in the logs are
+only printed with --timestamps.
+
+Examples:
+  coolifyme applications logs <uuid> --since 15m
+  coolifyme applications logs <uuid> --since 2024-01-15T10:00 --until 2024-01-15T10:30
+  coolifyme applications logs <uuid> --since 1h --lines 20000 --timestamps`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client, err := createClient()
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		lines, _ := cmd.Flags().GetInt("lines")
+		filter, err := logTimeFilterFromFlags(cmd, time.Now())
+		if err != nil {
+			return err
+		}
+		params := applicationLogsParams(cmd, filter)
+
+		if follow, _ := cmd.Flags().GetBool("follow"); follow {
+			interval, _ := cmd.Flags().GetInt("interval")
+			if interval < 1 {
+				interval = 2 // Default 2 seconds
+			}
+
+			var previous []string
+			return WatchLoop(context.Background(), getWatchConfig(cmd, time.Duration(interval)*time.Second), func(ctx context.Context) (bool, error) {
+				logs, err := client.Applications().GetLogs(ctx, args[0], params)
+				if err != nil {
+					return false, err
+				}
+
+				logs = strings.TrimRight(logs, "\n")
+				if logs == "" {
+					return false, nil
+				}
 
-		params := &coolify.GetApplicationLogsByUuidParams{}
-		if lines > 0 {
-			lines32 := int32(lines)
-			params.Lines = &lines32
+				current := strings.Split(logs, "\n")
+				for _, line := range filter.filter(newLogLines(previous, current)) {
+					fmt.Println(line)
+				}
+				previous = current
+				return false, nil
+			})
 		}
 
 		logs, err := client.Applications().GetLogs(context.Background(), args[0], params)
@@ -376,11 +681,148 @@ var applicationsLogsCmd = &cobra.Command{
 			return fmt.Errorf("failed to get application logs: %w", err)
 		}
 
-		fmt.Print(logs)
+		logs = strings.TrimRight(logs, "\n")
+		if logs == "" {
+			return nil
+		}
+		selected := filter.filter(strings.Split(logs, "\n"))
+		if filter.active() && !filter.found {
+			return fmt.Errorf("the logs contain no timestamps, so --since and --until cannot be applied")
+		}
+		for _, line := range selected {
+			fmt.Println(line)
+		}
 		return nil
 	},
 }
 
+// logTimeWindowLines is the number of log lines fetched to apply --since and --until to
+const logTimeWindowLines = 5000
+
+// addApplicationLogsFlags adds the flags selecting and following application logs
+func addApplicationLogsFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("lines", 0, "Number of lines to retrieve")
+	cmd.Flags().String("since", "", "Only show logs since a time or duration ago (e.g. 30m, 2h, 2024-01-15T10:30:00)")
+	cmd.Flags().String("until", "", "Only show logs before a time or duration ago (e.g. 10m, 2024-01-15T11:00:00)")
+	cmd.Flags().Bool("timestamps", false, "Keep the Docker timestamps at the start of log lines")
+	cmd.Flags().BoolP("follow", "f", false, "Follow the logs, printing new lines as they arrive")
+	cmd.Flags().IntP("interval", "i", 2, "Polling interval in seconds for --follow")
+	addWatchFlags(cmd)
+}
+
+// applicationLogsParams returns the parameters of an application logs request for --lines,
+// fetching the last logTimeWindowLines lines when a time range is given without --lines
+func applicationLogsParams(cmd *cobra.Command, filter *logTimeFilter) *coolify.GetApplicationLogsByUuidParams {
+	lines, _ := cmd.Flags().GetInt("lines")
+	if filter.active() && !cmd.Flags().Changed("lines") {
+		lines = logTimeWindowLines
+	}
+
+	params := &coolify.GetApplicationLogsByUuidParams{}
+	if lines > 0 {
+		lines32 := int32(lines)
+		params.Lines = &lines32
+	}
+	return params
+}
+
+// newLogLines returns the lines of a log snapshot that were not part of the previous snapshot,
+// using the longest overlap between the end of the previous and the start of the current one
+func newLogLines(previous, current []string) []string {
+	for overlap := min(len(previous), len(current)); overlap > 0; overlap-- {
+		if slices.Equal(previous[len(previous)-overlap:], current[:overlap]) {
+			return current[overlap:]
+		}
+	}
+	return current
+}
+
+// applicationsExecCmd represents the applications exec command
+var applicationsExecCmd = &cobra.Command{
+	Use:   "exec <uuid> --ssh <user@host> -- <command> [args...]",
+	Short: "Execute a command in an application container",
+	Long: `Execute a command in the running container of an application.
+
+The Coolify API cannot run commands, so the command is run directly on the server given with
+--ssh using docker exec, streaming stdin, stdout and stderr; a terminal gets a TTY, so shells
+and consoles work interactively. Every argument is passed on unchanged; use sh -c for pipes and
+other shell features. The container is the one named after the application UUID, never one of
+its preview deployments. Refused with --read-only.
+
+Examples:
+  coolifyme applications exec <uuid> --ssh root@10.0.0.5 -- php artisan migrate
+  coolifyme applications exec <uuid> --ssh root@10.0.0.5 -- sh -c 'ls -la /app | head'
+  coolifyme applications exec <uuid> --ssh root@10.0.0.5 -- sh`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		if err := refuseReadOnly(client, "docker exec"); err != nil {
+			return err
+		}
+
+		sshTarget, _ := cmd.Flags().GetString("ssh")
+		return execViaSSH(cmd.Context(), sshTarget, args[0], args[1:])
+	},
+}
+
+// applicationContainerFilter is the docker ps filter matching exactly the container of an
+// application: Coolify names it after the application UUID, with a deployment timestamp for
+// some build packs, while preview deployments add -pr-<id>
+func applicationContainerFilter(appUUID string) string {
+	return fmt.Sprintf("name=^%s(-[0-9]+)?$", regexp.QuoteMeta(appUUID))
+}
+
+// execViaSSH runs a command in the application container over SSH using docker exec
+func execViaSSH(ctx context.Context, target, appUUID string, command []string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	lookup := exec.CommandContext(ctx, "ssh", "--", target, // #nosec G204 -- arguments are passed to ssh, not a local shell
+		"docker ps -q --filter "+shellQuote(applicationContainerFilter(appUUID)))
+	lookup.Stderr = os.Stderr
+	out, err := lookup.Output()
+	if err != nil {
+		return fmt.Errorf("failed to find the container of application %s via ssh: %w", appUUID, err)
+	}
+	containers := strings.Fields(string(out))
+	if len(containers) == 0 {
+		return fmt.Errorf("no running container of application %s on %s", appUUID, target)
+	}
+
+	// ssh joins its arguments into one remote shell command line, so every word is quoted on
+	// its own. stdin is always passed on; a terminal also gets a TTY on both ends.
+	quoted := make([]string, len(command))
+	for i, arg := range command {
+		quoted[i] = shellQuote(arg)
+	}
+	sshArgs := []string{"--", target}
+	execFlags := "-i"
+	if theme.IsTerminal(os.Stdin) {
+		sshArgs = append([]string{"-t"}, sshArgs...)
+		execFlags = "-it"
+	}
+	remote := fmt.Sprintf("docker exec %s %s %s", execFlags, shellQuote(containers[0]), strings.Join(quoted, " "))
+
+	sshCmd := exec.CommandContext(ctx, "ssh", append(sshArgs, remote)...) // #nosec G204 -- arguments are passed to ssh, not a local shell
+	sshCmd.Stdin = os.Stdin
+	sshCmd.Stdout = os.Stdout
+	sshCmd.Stderr = os.Stderr
+
+	if err := sshCmd.Run(); err != nil {
+		return fmt.Errorf("failed to execute command via ssh: %w", err)
+	}
+	return nil
+}
+
+// shellQuote quotes a string for safe use as a single POSIX shell argument
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
 // applicationsEnvCmd represents the applications env command
 var applicationsEnvCmd = &cobra.Command{
 	Use:   "env",
@@ -390,7 +832,7 @@ var applicationsEnvCmd = &cobra.Command{
 
 func init() {
 	// Add subcommands to applications
-	applicationsCmd.AddCommand(applicationsListCmd)
+	applicationsCmd.AddCommand(supportsTemplateOutput(applicationsListCmd))
 	applicationsCmd.AddCommand(applicationsGetCmd)
 	applicationsCmd.AddCommand(applicationsCreateCmd)
 	applicationsCmd.AddCommand(applicationsDeleteCmd)
@@ -399,10 +841,13 @@ func init() {
 	applicationsCmd.AddCommand(applicationsStopCmd)
 	applicationsCmd.AddCommand(applicationsRestartCmd)
 	applicationsCmd.AddCommand(applicationsLogsCmd)
+	applicationsCmd.AddCommand(applicationsExecCmd)
 	applicationsCmd.AddCommand(applicationsEnvCmd)
 
 	// Flags for applications list command
 	applicationsListCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+	addScopeFlags(applicationsListCmd)
+	addPageSizeFlag(applicationsListCmd)
 
 	// Flags for applications get command
 	applicationsGetCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
@@ -414,18 +859,44 @@ func init() {
 	applicationsCreateCmd.Flags().String("project", "", "Project UUID (required)")
 	applicationsCreateCmd.Flags().String("server", "", "Server UUID (required)")
 	applicationsCreateCmd.Flags().String("environment", "", "Environment name (required)")
+	applicationsCreateCmd.Flags().String("name", "", "Application name")
+	applicationsCreateCmd.Flags().String("description", "", "Application description")
+	applicationsCreateCmd.Flags().String("ports-exposes", "", "Comma-separated ports exposed by the container, e.g. 3000 (required)")
+	applicationsCreateCmd.Flags().String("install-command", "", "Install command (e.g. npm ci)")
+	applicationsCreateCmd.Flags().String("build-command", "", "Build command (e.g. npm run build)")
+	applicationsCreateCmd.Flags().String("start-command", "", "Start command (e.g. npm start)")
+	applicationsCreateCmd.Flags().String("base-directory", "", "Base directory of the application in the repository")
+	applicationsCreateCmd.Flags().String("publish-directory", "", "Directory with the built files of static sites (e.g. dist)")
+	applicationsCreateCmd.Flags().String("dockerfile-location", "", "Dockerfile path relative to the base directory (dockerfile build pack)")
+	applicationsCreateCmd.Flags().String("domains", "", "Comma-separated domains, including the scheme (e.g. https://app.example.com)")
+	applicationsCreateCmd.Flags().Bool("instant-deploy", false, "Deploy the application right after creating it")
+	applicationsCreateCmd.Flags().String("type", "public", "Kind of application created with --from-file ("+strings.Join(applicationTypes, ", ")+")")
+	addFromFileFlag(applicationsCreateCmd, "type")
+
+	// Flags for applications update command
+	addFromFileFlag(applicationsUpdateCmd)
 
 	// Delete command flags
-	applicationsDeleteCmd.Flags().Bool("force", false, "Force delete")
+	addConfirmFlags(applicationsDeleteCmd, "Delete without confirmation")
 	applicationsDeleteCmd.Flags().Bool("delete-volumes", false, "Delete volumes")
 	applicationsDeleteCmd.Flags().Bool("delete-configurations", false, "Delete configurations")
 
 	// Start command flags
 	applicationsStartCmd.Flags().Bool("force", false, "Force start")
 
+	// Flags for starting, stopping and restarting several applications
+	for _, cmd := range []*cobra.Command{applicationsStartCmd, applicationsStopCmd, applicationsRestartCmd} {
+		cmd.Flags().String("file", "", "File with application UUIDs, one per line (- for standard input)")
+		cmd.Flags().Int("concurrent", 5, "Number of concurrent operations for several applications")
+	}
+	addScheduleFlags(applicationsRestartCmd)
+
 	// Logs command flags
-	applicationsLogsCmd.Flags().Int("lines", 0, "Number of lines to retrieve")
-	applicationsLogsCmd.Flags().Int("since", 0, "Show logs since N seconds ago")
+	addApplicationLogsFlags(applicationsLogsCmd)
+
+	// Exec command flags
+	applicationsExecCmd.Flags().String("ssh", "", "Server running the application (user@host), reached over SSH to run docker exec")
+	_ = applicationsExecCmd.MarkFlagRequired("ssh")
 
 	// Add env subcommands
 	applicationsEnvCmd.AddCommand(applicationsEnvListCmd)
@@ -438,20 +909,34 @@ func init() {
 	applicationsEnvCmd.AddCommand(applicationsEnvSyncCmd)
 	applicationsEnvCmd.AddCommand(applicationsEnvCleanupCmd)
 
+	// Flags for environment variable delete command
+	addConfirmFlags(applicationsEnvDeleteCmd, "Delete without confirmation")
+
 	// Flags for bulk environment variable update command
+	applicationsEnvCreateCmd.Flags().StringArrayP("env", "e", nil, "Environment variable to create as KEY=VALUE (repeatable)")
+	applicationsEnvCreateCmd.Flags().String("from-file", "", "Create the variables of a JSON object or .env file (- for stdin)")
+	applicationsEnvCreateCmd.Flags().Bool("build-time", false, "Make the variables available at build time")
+	applicationsEnvCreateCmd.Flags().Bool("preview", false, "Create the variables for preview deployments")
 	applicationsEnvUpdateBulkCmd.Flags().StringP("env-data", "d", "", "JSON string containing environment variables")
 	applicationsEnvUpdateBulkCmd.Flags().StringP("env-file", "f", "", "File containing environment variables in JSON format")
 
 	// Flags for .env file management commands
 	applicationsEnvExportCmd.Flags().StringP("file", "f", ".env", "Output .env file path")
 	applicationsEnvExportCmd.Flags().Bool("overwrite", false, "Overwrite existing file")
+	addEnvEncryptFlags(applicationsEnvExportCmd)
 	applicationsEnvImportCmd.Flags().StringP("file", "f", ".env", "Input .env file path")
 	applicationsEnvImportCmd.Flags().Bool("dry-run", false, "Show what would be imported without making changes")
+	applicationsEnvImportCmd.Flags().Bool("no-resolve", false, "Upload ${ENV:...}, ${FILE:...}, ${CMD:...} and ${VAULT:...} references unresolved")
+	addEnvDecryptFlags(applicationsEnvImportCmd)
 	applicationsEnvSyncCmd.Flags().StringP("file", "f", ".env", ".env file to sync")
 	applicationsEnvSyncCmd.Flags().Bool("dry-run", false, "Show what would be changed without making changes")
+	addEnvEncryptFlags(applicationsEnvSyncCmd)
+	addEnvDecryptFlags(applicationsEnvSyncCmd)
 	applicationsEnvCleanupCmd.Flags().StringP("file", "f", ".env", ".env file to clean up")
 	applicationsEnvCleanupCmd.Flags().Bool("dry-run", false, "Show what would be removed without making changes")
 	applicationsEnvCleanupCmd.Flags().Bool("backup", true, "Create backup before cleaning up")
+	addEnvEncryptFlags(applicationsEnvCleanupCmd)
+	addEnvDecryptFlags(applicationsEnvCleanupCmd)
 }
 
 // applicationsEnvListCmd represents the applications env list command
@@ -509,39 +994,216 @@ var applicationsEnvListCmd = &cobra.Command{
 
 // applicationsEnvCreateCmd represents the applications env create command
 var applicationsEnvCreateCmd = &cobra.Command{
-	Use:   "create <app-uuid> <key> <value>",
-	Short: "Create environment variable",
-	Long:  "Create a new environment variable for an application",
-	Args:  cobra.ExactArgs(3),
-	RunE: func(_ *cobra.Command, args []string) error {
+	Use:   "create <app-uuid> [<key> <value>]",
+	Short: "Create environment variables",
+	Long: `Create environment variables for an application: one given as key and value arguments, and
+any number given with repeated --env KEY=VALUE flags or read with --from-file from a JSON object
+({"KEY": "value"}) or a .env file (- reads standard input). Several variables are created with a
+single request; variables that already exist are reported and nothing is created.
+
+--build-time and --preview apply to all created variables.
+
+Examples:
+  coolifyme applications env create <app-uuid> API_URL https://api.example.com
+  coolifyme applications env create <app-uuid> --env LOG_LEVEL=info --env WORKERS=4 --build-time
+  coolifyme applications env create <app-uuid> --from-file vars.json --preview`,
+	Args: func(_ *cobra.Command, args []string) error {
+		if len(args) != 1 && len(args) != 3 {
+			return fmt.Errorf("accepts <app-uuid> and optionally <key> <value>, received %d argument(s)", len(args))
+		}
+		return nil
+	},
+	// Keep the value out of the command history
+	Annotations: map[string]string{historySecretArgsAnnotation: "2"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		vars, err := envCreateVars(cmd, args[1:])
+		if err != nil {
+			return err
+		}
+
 		client, err := createClient()
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		key := args[1]
-		value := args[2]
-		req := coolify.CreateEnvByApplicationUuidJSONRequestBody{
-			Key:   &key,
-			Value: &value,
+		ctx := context.Background()
+		appUUID := args[0]
+		preview, _ := cmd.Flags().GetBool("preview")
+
+		// Leave the flags to Coolify's defaults unless they were given
+		var buildTimeFlag, previewFlag *bool
+		if cmd.Flags().Changed("build-time") {
+			buildTime, _ := cmd.Flags().GetBool("build-time")
+			buildTimeFlag = &buildTime
+		}
+		if cmd.Flags().Changed("preview") {
+			previewFlag = &preview
+		}
+		multilineFlag := func(value string) *bool {
+			if !strings.Contains(value, "\n") {
+				return nil
+			}
+			multiline := true
+			return &multiline
+		}
+
+		if len(vars) == 1 {
+			key, value := vars[0][0], vars[0][1]
+			req := coolify.CreateEnvByApplicationUuidJSONRequestBody{
+				Key:         &key,
+				Value:       &value,
+				IsBuildTime: buildTimeFlag,
+				IsPreview:   previewFlag,
+				IsMultiline: multilineFlag(value),
+			}
+
+			uuid, err := client.Applications().CreateEnv(ctx, appUUID, req)
+			if err != nil {
+				return fmt.Errorf("failed to create environment variable: %w", err)
+			}
+
+			if printQuietUUID(uuid) {
+				return nil
+			}
+
+			fmt.Printf("Environment variable created: %s\n", uuid)
+			return nil
 		}
 
-		uuid, err := client.Applications().CreateEnv(context.Background(), args[0], req)
+		// The bulk endpoint updates existing variables, so refuse to overwrite them like a single create
+		envs, err := client.Applications().ListEnvs(ctx, appUUID)
 		if err != nil {
-			return fmt.Errorf("failed to create environment variable: %w", err)
+			return fmt.Errorf("failed to list environment variables: %w", err)
+		}
+		existing := make(map[string]bool)
+		for _, env := range envs {
+			if env.Key != nil && (env.IsPreview != nil && *env.IsPreview) == preview {
+				existing[*env.Key] = true
+			}
+		}
+		var duplicates []string
+		for _, kv := range vars {
+			if existing[kv[0]] {
+				duplicates = append(duplicates, kv[0])
+			}
+		}
+		if len(duplicates) > 0 {
+			return fmt.Errorf("environment variable(s) already exist: %s (use 'env update-bulk' to change them)", strings.Join(duplicates, ", "))
 		}
 
-		fmt.Printf("Environment variable created: %s\n", uuid)
+		var req coolify.UpdateEnvsByApplicationUuidJSONRequestBody
+		for _, kv := range vars {
+			key, value := kv[0], kv[1]
+			req.Data = append(req.Data, struct {
+				IsBuildTime *bool   `json:"is_build_time,omitempty"`
+				IsLiteral   *bool   `json:"is_literal,omitempty"`
+				IsMultiline *bool   `json:"is_multiline,omitempty"`
+				IsPreview   *bool   `json:"is_preview,omitempty"`
+				IsShownOnce *bool   `json:"is_shown_once,omitempty"`
+				Key         *string `json:"key,omitempty"`
+				Value       *string `json:"value,omitempty"`
+			}{
+				IsBuildTime: buildTimeFlag,
+				IsMultiline: multilineFlag(value),
+				IsPreview:   previewFlag,
+				Key:         &key,
+				Value:       &value,
+			})
+		}
+		if _, err := client.Applications().UpdateEnvs(ctx, appUUID, req); err != nil {
+			return fmt.Errorf("failed to create environment variables: %w", err)
+		}
+
+		theme.Printf("✅ Created %d environment variables\n", len(vars))
+		for _, kv := range vars {
+			fmt.Printf("   %s\n", kv[0])
+		}
 		return nil
 	},
 }
 
+// envCreateVars collects the variables of 'env create' from the key and value arguments, the
+// --env flags and the --from-file file, in that order, as key and value pairs
+func envCreateVars(cmd *cobra.Command, args []string) ([][2]string, error) {
+	var vars [][2]string
+	seen := make(map[string]bool)
+	add := func(key, value string) error {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return fmt.Errorf("environment variable names cannot be empty")
+		}
+		if seen[key] {
+			return fmt.Errorf("environment variable %s is given more than once", key)
+		}
+		seen[key] = true
+		vars = append(vars, [2]string{key, value})
+		return nil
+	}
+
+	if len(args) == 2 {
+		if err := add(args[0], args[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	flagVars, _ := cmd.Flags().GetStringArray("env")
+	for _, kv := range flagVars {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --env %q: use KEY=VALUE", kv)
+		}
+		if err := add(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if file, _ := cmd.Flags().GetString("from-file"); file != "" {
+		var content []byte
+		var err error
+		if file == "-" {
+			content, err = io.ReadAll(os.Stdin)
+		} else {
+			content, err = safeReadFile(file)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		fileVars := make(map[string]string)
+		if trimmed := strings.TrimSpace(string(content)); strings.HasSuffix(file, ".json") || strings.HasPrefix(trimmed, "{") {
+			if err := json.Unmarshal(content, &fileVars); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: expected a JSON object of names and string values: %w", file, err)
+			}
+		} else {
+			fileVars = parseEnvFile(string(content))
+		}
+
+		keys := make([]string, 0, len(fileVars))
+		for key := range fileVars {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if err := add(key, fileVars[key]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(vars) == 0 {
+		return nil, fmt.Errorf("no environment variables given (use <key> <value>, --env KEY=VALUE or --from-file)")
+	}
+	return vars, nil
+}
+
 // applicationsEnvUpdateCmd represents the applications env update command
 var applicationsEnvUpdateCmd = &cobra.Command{
 	Use:   "update <app-uuid> <key> <value>",
 	Short: "Update environment variable",
 	Long:  "Update an environment variable for an application",
 	Args:  cobra.ExactArgs(3),
+	// Keep the value out of the command history
+	Annotations: map[string]string{historySecretArgsAnnotation: "2"},
 	RunE: func(_ *cobra.Command, args []string) error {
 		client, err := createClient()
 		if err != nil {
@@ -636,13 +1298,17 @@ var applicationsEnvUpdateBulkCmd = &cobra.Command{
 		ctx := context.Background()
 		appUUID := args[0]
 
+		if err := takeEnvSnapshot(ctx, cmd, client, applicationEnvSnapshots, appUUID); err != nil {
+			return err
+		}
+
 		message, err := client.Applications().UpdateEnvs(ctx, appUUID, req)
 		if err != nil {
 			return fmt.Errorf("failed to bulk update environment variables: %w", err)
 		}
 
-		fmt.Printf("✅ Environment variables updated successfully\n")
-		fmt.Printf("   💬 Message: %s\n", message)
+		theme.Printf("✅ Environment variables updated successfully\n")
+		theme.Printf("   💬 Message: %s\n", message)
 		return nil
 	},
 }
@@ -653,11 +1319,20 @@ var applicationsEnvDeleteCmd = &cobra.Command{
 	Short: "Delete environment variable",
 	Long:  "Delete an environment variable for an application",
 	Args:  cobra.ExactArgs(2),
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		client, err := createClient()
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
+		if err := checkTokenAbility(cmd, client, clientpkg.AbilityWrite); err != nil {
+			return err
+		}
+
+		target := confirm.Target{Kind: "environment variable", ID: args[1], Dependents: []string{"application " + args[0]}}
+		if !confirm.Delete(target, skipConfirmation(cmd)) {
+			theme.Println("❌ Deletion cancelled")
+			return nil
+		}
 
 		message, err := client.Applications().DeleteEnv(context.Background(), args[0], args[1])
 		if err != nil {
@@ -673,8 +1348,17 @@ var applicationsEnvDeleteCmd = &cobra.Command{
 var applicationsEnvExportCmd = &cobra.Command{
 	Use:   "export <app-uuid>",
 	Short: "Export environment variables to .env file",
-	Long:  "Export all environment variables from an application to a .env file",
-	Args:  cobra.ExactArgs(1),
+	Long: `Export all environment variables from an application to a .env file.
+
+With --encrypt age:<recipient> the file is encrypted for an age recipient (requires the age tool),
+with --encrypt-passphrase it is encrypted with AES-256-GCM using a passphrase read from
+COOLIFYME_ENV_PASSPHRASE or prompted for. Encrypted files can be committed or shared safely;
+'env import', 'env sync' and 'env cleanup' decrypt them transparently.
+
+Examples:
+  coolifyme applications env export <app-uuid> --file .env.age --encrypt age:age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p
+  coolifyme applications env export <app-uuid> --file .env.enc --encrypt-passphrase`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client, err := createClient()
 		if err != nil {
@@ -690,6 +1374,11 @@ var applicationsEnvExportCmd = &cobra.Command{
 			return fmt.Errorf("file %s already exists, use --overwrite to replace it", filename)
 		}
 
+		encryption, err := envEncryptionFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
 		// Get environment variables
 		envs, err := client.Applications().ListEnvs(context.Background(), appUUID)
 		if err != nil {
@@ -716,13 +1405,21 @@ var applicationsEnvExportCmd = &cobra.Command{
 			}
 		}
 
+		data, err := encryption.encrypt([]byte(envContent.String()))
+		if err != nil {
+			return fmt.Errorf("failed to encrypt .env file: %w", err)
+		}
+
 		// Write to file
-		if err := os.WriteFile(filename, []byte(envContent.String()), 0o600); err != nil {
+		if err := os.WriteFile(filename, data, 0o600); err != nil {
 			return fmt.Errorf("failed to write .env file: %w", err)
 		}
 
-		fmt.Printf("✅ Environment variables exported to %s\n", filename)
-		fmt.Printf("   📝 Exported %d variables\n", len(envs))
+		theme.Printf("✅ Environment variables exported to %s\n", filename)
+		theme.Printf("   📝 Exported %d variables\n", len(envs))
+		if encryption.scheme != envcrypt.SchemeNone {
+			theme.Printf("   🔒 Encrypted with %s\n", encryption.scheme)
+		}
 		return nil
 	},
 }
@@ -731,8 +1428,20 @@ var applicationsEnvExportCmd = &cobra.Command{
 var applicationsEnvImportCmd = &cobra.Command{
 	Use:   "import <app-uuid>",
 	Short: "Import environment variables from .env file",
-	Long:  "Import environment variables from a .env file to an application",
-	Args:  cobra.ExactArgs(1),
+	Long: `Import environment variables from a .env file to an application. Files encrypted by 'env export' are decrypted transparently.
+
+Values can reference secrets that are resolved locally before the upload, so they never need to
+be stored in the .env file:
+
+  DB_PASSWORD=${ENV:PROD_DB_PASSWORD}        local environment variable
+  TLS_KEY=${FILE:~/secrets/tls.key}          file contents
+  API_KEY=${CMD:op read op://prod/api/key}   output of a shell command
+  STRIPE_KEY=${VAULT:secret/app#stripe}      HashiCorp Vault KV field (via the vault CLI)
+
+Other ${...} references, which Coolify resolves itself, are uploaded unchanged. Write $${ENV:X}
+for a literal ${ENV:X}, or pass --no-resolve to upload the file as it is. Only import files you
+trust, since ${CMD:...} runs commands.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client, err := createClient()
 		if err != nil {
@@ -743,8 +1452,8 @@ var applicationsEnvImportCmd = &cobra.Command{
 		filename, _ := cmd.Flags().GetString("file")
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 
-		// Read .env file
-		content, err := safeReadFile(filename)
+		// Read .env file, decrypting it if needed
+		content, _, err := readEnvFileContent(cmd, filename)
 		if err != nil {
 			return fmt.Errorf("failed to read .env file: %w", err)
 		}
@@ -756,13 +1465,28 @@ var applicationsEnvImportCmd = &cobra.Command{
 			return nil
 		}
 
+		// Resolve secret references, also on dry runs to catch unresolvable ones
+		resolved := envVars
+		var resolvedKeys []string
+		if noResolve, _ := cmd.Flags().GetBool("no-resolve"); !noResolve {
+			resolved, resolvedKeys, err = envtemplate.New().ExpandAll(context.Background(), envVars)
+			if err != nil {
+				return fmt.Errorf("failed to resolve environment variables: %w", err)
+			}
+		}
+
 		if dryRun {
-			fmt.Printf("🔍 Dry run: Would import %d environment variables:\n", len(envVars))
+			theme.Printf("🔍 Dry run: Would import %d environment variables:\n", len(envVars))
 			for key, value := range envVars {
+				if slices.Contains(resolvedKeys, key) {
+					fmt.Printf("   %s=%s (resolved)\n", key, value)
+					continue
+				}
 				fmt.Printf("   %s=%s\n", key, value)
 			}
 			return nil
 		}
+		envVars = resolved
 
 		// Convert to bulk update format
 		var envStructs []struct {
@@ -797,14 +1521,21 @@ var applicationsEnvImportCmd = &cobra.Command{
 			Data: envStructs,
 		}
 
+		if err := takeEnvSnapshot(context.Background(), cmd, client, applicationEnvSnapshots, appUUID); err != nil {
+			return err
+		}
+
 		message, err := client.Applications().UpdateEnvs(context.Background(), appUUID, req)
 		if err != nil {
 			return fmt.Errorf("failed to import environment variables: %w", err)
 		}
 
-		fmt.Printf("✅ Environment variables imported from %s\n", filename)
-		fmt.Printf("   📝 Imported %d variables\n", len(envVars))
-		fmt.Printf("   💬 Message: %s\n", message)
+		theme.Printf("✅ Environment variables imported from %s\n", filename)
+		theme.Printf("   📝 Imported %d variables\n", len(envVars))
+		if len(resolvedKeys) > 0 {
+			theme.Printf("   🔑 Resolved secret references in %s\n", strings.Join(resolvedKeys, ", "))
+		}
+		theme.Printf("   💬 Message: %s\n", message)
 		return nil
 	},
 }
@@ -813,7 +1544,7 @@ var applicationsEnvImportCmd = &cobra.Command{
 var applicationsEnvSyncCmd = &cobra.Command{
 	Use:   "sync <app-uuid>",
 	Short: "Sync .env file with application environment variables",
-	Long:  "Synchronize a .env file with the application's environment variables (bidirectional sync)",
+	Long:  "Synchronize a .env file with the application's environment variables (bidirectional sync). Encrypted files are decrypted transparently and written back with the same encryption; age encrypted files need --encrypt to be written back.",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client, err := createClient()
@@ -840,15 +1571,24 @@ var applicationsEnvSyncCmd = &cobra.Command{
 
 		// Read .env file (create if doesn't exist)
 		var fileEnvMap map[string]string
+		var encryption envFileEncryption
 		if _, err := os.Stat(filename); os.IsNotExist(err) {
 			fileEnvMap = make(map[string]string)
-			fmt.Printf("📄 .env file %s doesn't exist, will create it\n", filename)
+			theme.Printf("📄 .env file %s doesn't exist, will create it\n", filename)
 		} else {
-			content, err := safeReadFile(filename)
+			content, fileEncryption, err := readEnvFileContent(cmd, filename)
 			if err != nil {
 				return fmt.Errorf("failed to read .env file: %w", err)
 			}
 			fileEnvMap = parseEnvFile(string(content))
+			encryption = fileEncryption
+		}
+
+		// Explicit encryption flags override the encryption of the existing file
+		if cmd.Flags().Changed("encrypt") || cmd.Flags().Changed("encrypt-passphrase") {
+			if encryption, err = envEncryptionFromFlags(cmd); err != nil {
+				return err
+			}
 		}
 
 		// Compare and plan changes
@@ -880,11 +1620,11 @@ var applicationsEnvSyncCmd = &cobra.Command{
 		}
 
 		if dryRun {
-			fmt.Printf("🔍 Sync analysis for %s:\n", filename)
-			fmt.Printf("   📤 Would add to application: %d variables\n", len(toAddToApp))
-			fmt.Printf("   📥 Would add to .env file: %d variables\n", len(toAddToFile))
-			fmt.Printf("   🔄 Would update in application: %d variables\n", len(toUpdateInApp))
-			fmt.Printf("   🔄 Would update in .env file: %d variables\n", len(toUpdateInFile))
+			theme.Printf("🔍 Sync analysis for %s:\n", filename)
+			theme.Printf("   📤 Would add to application: %d variables\n", len(toAddToApp))
+			theme.Printf("   📥 Would add to .env file: %d variables\n", len(toAddToFile))
+			theme.Printf("   🔄 Would update in application: %d variables\n", len(toUpdateInApp))
+			theme.Printf("   🔄 Would update in .env file: %d variables\n", len(toUpdateInFile))
 			return nil
 		}
 
@@ -933,6 +1673,10 @@ var applicationsEnvSyncCmd = &cobra.Command{
 				Data: envStructs,
 			}
 
+			if err := saveEnvSnapshot(cmd, applicationEnvSnapshots, appUUID, appEnvs); err != nil {
+				return err
+			}
+
 			_, err := client.Applications().UpdateEnvs(context.Background(), appUUID, req)
 			if err != nil {
 				return fmt.Errorf("failed to update application environment variables: %w", err)
@@ -964,18 +1708,22 @@ var applicationsEnvSyncCmd = &cobra.Command{
 				envContent.WriteString(fmt.Sprintf("%s=%s\n", key, value))
 			}
 
-			if err := os.WriteFile(filename, []byte(envContent.String()), 0o600); err != nil {
+			data, err := encryption.encrypt([]byte(envContent.String()))
+			if err != nil {
+				return fmt.Errorf("failed to encrypt .env file: %w", err)
+			}
+			if err := os.WriteFile(filename, data, 0o600); err != nil {
 				return fmt.Errorf("failed to write .env file: %w", err)
 			}
 			hasChanges = true
 		}
 
 		if hasChanges {
-			fmt.Printf("✅ Environment variables synchronized\n")
-			fmt.Printf("   📤 Added/updated in application: %d variables\n", len(toAddToApp)+len(toUpdateInApp))
-			fmt.Printf("   📥 Added/updated in .env file: %d variables\n", len(toAddToFile)+len(toUpdateInFile))
+			theme.Printf("✅ Environment variables synchronized\n")
+			theme.Printf("   📤 Added/updated in application: %d variables\n", len(toAddToApp)+len(toUpdateInApp))
+			theme.Printf("   📥 Added/updated in .env file: %d variables\n", len(toAddToFile)+len(toUpdateInFile))
 		} else {
-			fmt.Printf("✅ Environment variables are already synchronized\n")
+			theme.Printf("✅ Environment variables are already synchronized\n")
 		}
 
 		return nil
@@ -986,7 +1734,7 @@ var applicationsEnvSyncCmd = &cobra.Command{
 var applicationsEnvCleanupCmd = &cobra.Command{
 	Use:   "cleanup <app-uuid>",
 	Short: "Clean up .env file",
-	Long:  "Remove environment variables from .env file that don't exist in the application",
+	Long:  "Remove environment variables from .env file that don't exist in the application. Encrypted files are decrypted transparently and written back with the same encryption, or as given with --encrypt or --encrypt-passphrase.",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client, err := createClient()
@@ -1017,14 +1765,21 @@ var applicationsEnvCleanupCmd = &cobra.Command{
 			}
 		}
 
-		// Read .env file
-		content, err := safeReadFile(filename)
+		// Read .env file, decrypting it if needed
+		content, encryption, err := readEnvFileContent(cmd, filename)
 		if err != nil {
 			return fmt.Errorf("failed to read .env file: %w", err)
 		}
 
 		fileEnvMap := parseEnvFile(string(content))
 
+		// Explicit encryption flags override the encryption of the existing file
+		if cmd.Flags().Changed("encrypt") || cmd.Flags().Changed("encrypt-passphrase") {
+			if encryption, err = envEncryptionFromFlags(cmd); err != nil {
+				return err
+			}
+		}
+
 		// Find variables to remove
 		toRemove := make([]string, 0)
 		for key := range fileEnvMap {
@@ -1034,13 +1789,13 @@ var applicationsEnvCleanupCmd = &cobra.Command{
 		}
 
 		if len(toRemove) == 0 {
-			fmt.Printf("✅ .env file is already clean - no variables to remove\n")
+			theme.Printf("✅ .env file is already clean - no variables to remove\n")
 			return nil
 		}
 
 		if dryRun {
-			fmt.Printf("🔍 Cleanup analysis for %s:\n", filename)
-			fmt.Printf("   🗑️  Would remove %d variables not in application:\n", len(toRemove))
+			theme.Printf("🔍 Cleanup analysis for %s:\n", filename)
+			theme.Printf("   🗑️  Would remove %d variables not in application:\n", len(toRemove))
 			for _, key := range toRemove {
 				fmt.Printf("      - %s\n", key)
 			}
@@ -1050,10 +1805,14 @@ var applicationsEnvCleanupCmd = &cobra.Command{
 		// Create backup if requested
 		if backup {
 			backupFilename := filename + ".backup." + time.Now().Format("20060102-150405")
-			if err := os.WriteFile(backupFilename, content, 0o600); err != nil {
+			original, err := safeReadFile(filename)
+			if err != nil {
 				return fmt.Errorf("failed to create backup: %w", err)
 			}
-			fmt.Printf("📄 Backup created: %s\n", backupFilename)
+			if err := os.WriteFile(backupFilename, original, 0o600); err != nil {
+				return fmt.Errorf("failed to create backup: %w", err)
+			}
+			theme.Printf("📄 Backup created: %s\n", backupFilename)
 		}
 
 		// Remove variables from map
@@ -1075,13 +1834,17 @@ var applicationsEnvCleanupCmd = &cobra.Command{
 			envContent.WriteString(fmt.Sprintf("%s=%s\n", key, value))
 		}
 
-		if err := os.WriteFile(filename, []byte(envContent.String()), 0o600); err != nil {
+		data, err := encryption.encrypt([]byte(envContent.String()))
+		if err != nil {
+			return fmt.Errorf("failed to encrypt .env file: %w", err)
+		}
+		if err := os.WriteFile(filename, data, 0o600); err != nil {
 			return fmt.Errorf("failed to write cleaned .env file: %w", err)
 		}
 
-		fmt.Printf("✅ .env file cleaned up\n")
-		fmt.Printf("   🗑️  Removed %d variables\n", len(toRemove))
-		fmt.Printf("   📝 Remaining %d variables\n", len(fileEnvMap))
+		theme.Printf("✅ .env file cleaned up\n")
+		theme.Printf("   🗑️  Removed %d variables\n", len(toRemove))
+		theme.Printf("   📝 Remaining %d variables\n", len(fileEnvMap))
 
 		return nil
 	},
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/githost"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// deployKeyInfo is the deploy key of an application
+type deployKeyInfo struct {
+	Application string `json:"application"`
+	Repository  string `json:"repository,omitempty"`
+	KeyUUID     string `json:"key_uuid"`
+	KeyName     string `json:"key_name"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	PublicKey   string `json:"public_key,omitempty"`
+	// DeployKeysURL is the repository page where deploy keys are added, for GitHub and GitLab
+	DeployKeysURL string `json:"deploy_keys_url,omitempty"`
+	// SharedWith are the other applications using the same key
+	SharedWith []string `json:"shared_with,omitempty"`
+}
+
+// applicationsDeployKeyCmd represents the applications deploy-key command
+var applicationsDeployKeyCmd = &cobra.Command{
+	Use:   "deploy-key",
+	Short: "Show and rotate the deploy key of an application",
+	Long: `Manage the SSH deploy key Coolify uses to clone the private repository of a deploy-key based
+application.
+
+Examples:
+  coolifyme applications deploy-key show <uuid>
+  coolifyme applications deploy-key rotate <uuid>`,
+}
+
+// applicationsDeployKeyShowCmd represents the applications deploy-key show command
+var applicationsDeployKeyShowCmd = &cobra.Command{
+	Use:   "show <uuid>",
+	Short: "Show the deploy key of an application and its public key",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		app, err := client.Applications().Get(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get application: %w", err)
+		}
+		info, err := applicationDeployKey(ctx, client, app)
+		if err != nil {
+			return err
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(info, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		theme.Printf("📦 Application: %s\n", info.Application)
+		if info.Repository != "" {
+			theme.Printf("🌿 Repository: %s\n", info.Repository)
+		}
+		theme.Printf("🔑 Deploy Key: %s (%s)\n", info.KeyName, info.KeyUUID)
+		if info.Fingerprint != "" {
+			fmt.Printf("   Fingerprint: %s\n", info.Fingerprint)
+		}
+		if len(info.SharedWith) > 0 {
+			fmt.Printf("   Also used by: %s\n", strings.Join(info.SharedWith, ", "))
+		}
+		if info.PublicKey != "" {
+			fmt.Printf("\n%s\n", info.PublicKey)
+		}
+		if info.DeployKeysURL != "" {
+			theme.Printf("\n🔗 Deploy keys of the repository: %s\n", info.DeployKeysURL)
+		}
+		return nil
+	},
+}
+
+// applicationsDeployKeyRotateCmd represents the applications deploy-key rotate command
+var applicationsDeployKeyRotateCmd = &cobra.Command{
+	Use:   "rotate <uuid>",
+	Short: "Replace the deploy key of an application with a new one",
+	Long: `Rotate the deploy key of a deploy-key based application:
+
+  1. a new ed25519 key pair is generated with ssh-keygen and stored in Coolify
+  2. its public key is printed, with the deploy keys page of GitHub and GitLab repositories
+  3. once you confirm that the public key is installed on the git host, the application is
+     switched to the new key
+  4. the old key is shown so it can be removed from the git host and Coolify
+
+Declining the switch deletes the new key again. The Coolify API does not document changing the
+key of an application, so the change is read back; versions that ignore it are reported, and the
+new key is deleted so the key can be changed in the Coolify UI instead.
+
+The old key is kept in Coolify since other applications or servers may use it; delete it with
+'coolifyme keys delete <uuid>' once deployments with the new key work.
+
+Examples:
+  coolifyme applications deploy-key rotate <uuid>
+  coolifyme applications deploy-key rotate <uuid> --force   # switch without waiting for confirmation`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		if err := checkTokenAbility(cmd, client, clientpkg.AbilityWrite); err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		appUUID := args[0]
+		app, err := client.Applications().Get(ctx, appUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get application: %w", err)
+		}
+		old, err := applicationDeployKey(ctx, client, app)
+		if err != nil {
+			return err
+		}
+
+		// Step 1: generate and store the new key
+		name := fmt.Sprintf("%s-deploy-key-%s", old.Application, time.Now().Format("20060102"))
+		theme.Printf("🔑 Generating a new deploy key for %s...\n", old.Application)
+		privateKey, publicKey, err := generateSSHKey("coolify-deploy-" + old.Application)
+		if err != nil {
+			return err
+		}
+		description := "Deploy key of application " + old.Application + ", created by coolifyme"
+		keyUUID, err := client.PrivateKeys().Create(ctx, coolify.CreatePrivateKeyJSONRequestBody{
+			Name:        &name,
+			Description: &description,
+			PrivateKey:  privateKey,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create private key: %w", err)
+		}
+		newKey, err := client.PrivateKeys().Get(ctx, keyUUID)
+		if err != nil {
+			discardDeployKey(ctx, client, keyUUID)
+			return fmt.Errorf("failed to read the new private key back: %w", err)
+		}
+		if newKey.Id == nil {
+			discardDeployKey(ctx, client, keyUUID)
+			return fmt.Errorf("the new private key %s has no ID", keyUUID)
+		}
+		theme.Printf("   ✅ Stored as %s (%s)\n", name, keyUUID)
+
+		// Step 2: the public key must be installed before the application uses it
+		fmt.Printf("\nAdd this public key as a read-only deploy key of %s:\n\n%s\n\n", cmp.Or(old.Repository, "the repository"), publicKey)
+		if old.DeployKeysURL != "" {
+			theme.Printf("🔗 %s\n\n", old.DeployKeysURL)
+		}
+
+		// Step 3: switch the application over
+		if !confirm.Action("Switch the application to the new key now? Deployments fail until the public key is installed.", skipConfirmation(cmd)) {
+			discardDeployKey(ctx, client, keyUUID)
+			theme.Println("❌ Rotation cancelled, the new key was deleted")
+			return nil
+		}
+		if err := client.Applications().SetDeployKey(ctx, appUUID, keyUUID, *newKey.Id); err != nil {
+			discardDeployKey(ctx, client, keyUUID)
+			if errors.Is(err, clientpkg.ErrDeployKeyNotApplied) {
+				return fmt.Errorf("%w; the new key was deleted, change the key of the application in the Coolify UI instead", err)
+			}
+			return err
+		}
+		theme.Printf("✅ Application %s now uses deploy key %s\n", old.Application, name)
+
+		// Step 4: the old key is left for the user to remove
+		theme.Printf("💡 Remove the old key %s from the git host", old.KeyName)
+		if len(old.SharedWith) > 0 {
+			fmt.Printf(" once %s use another key too\n", strings.Join(old.SharedWith, ", "))
+		} else {
+			fmt.Printf(" and delete it with 'coolifyme keys delete %s' once a deployment works\n", old.KeyUUID)
+		}
+		return nil
+	},
+}
+
+// applicationDeployKey returns the deploy key of an application, or an error for applications
+// that do not use one
+func applicationDeployKey(ctx context.Context, client *clientpkg.Client, app *coolify.Application) (*deployKeyInfo, error) {
+	info := &deployKeyInfo{Application: stringOrDash(app.Name)}
+	if app.PrivateKeyId == nil {
+		return nil, fmt.Errorf("application %s does not use a deploy key", info.Application)
+	}
+	if app.GitRepository != nil {
+		info.Repository = *app.GitRepository
+		if repo, err := githost.Parse(info.Repository, ""); err == nil {
+			info.DeployKeysURL = repo.DeployKeysURL()
+		}
+	}
+
+	keys, err := client.PrivateKeys().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list private keys: %w", err)
+	}
+	for _, key := range keys {
+		if key.Id == nil || *key.Id != *app.PrivateKeyId {
+			continue
+		}
+		info.KeyUUID, info.KeyName = stringOrDash(key.Uuid), stringOrDash(key.Name)
+		if key.Fingerprint != nil {
+			info.Fingerprint = *key.Fingerprint
+		}
+		if key.PublicKey != nil {
+			info.PublicKey = strings.TrimSpace(*key.PublicKey)
+		}
+	}
+	if info.KeyUUID == "" {
+		return nil, fmt.Errorf("private key %d of application %s not found", *app.PrivateKeyId, info.Application)
+	}
+
+	apps, err := client.Applications().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	for _, other := range apps {
+		if other.PrivateKeyId != nil && *other.PrivateKeyId == *app.PrivateKeyId && stringOrDash(other.Uuid) != stringOrDash(app.Uuid) {
+			info.SharedWith = append(info.SharedWith, stringOrDash(other.Name))
+		}
+	}
+	return info, nil
+}
+
+// discardDeployKey deletes a new key after a failed or cancelled rotation
+func discardDeployKey(ctx context.Context, client *clientpkg.Client, keyUUID string) {
+	if err := client.PrivateKeys().Delete(ctx, keyUUID); err != nil {
+		warn("deploy key rotation", fmt.Errorf("failed to delete the new private key %s: %w", keyUUID, err))
+	}
+}
+
+func init() {
+	applicationsCmd.AddCommand(applicationsDeployKeyCmd)
+	applicationsDeployKeyCmd.AddCommand(applicationsDeployKeyShowCmd)
+	applicationsDeployKeyCmd.AddCommand(applicationsDeployKeyRotateCmd)
+
+	applicationsDeployKeyShowCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+
+	addConfirmFlags(applicationsDeployKeyRotateCmd, "Switch to the new key without confirmation")
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// inspectSection is a titled group of settings shown by applications inspect
+type inspectSection struct {
+	Title string
+	Rows  [][2]string
+}
+
+// applicationSecretFields are the JSON fields of an application redacted unless --show-secrets is set
+var applicationSecretFields = []string{
+	"http_basic_auth_password",
+	"manual_webhook_secret_bitbucket",
+	"manual_webhook_secret_gitea",
+	"manual_webhook_secret_github",
+	"manual_webhook_secret_gitlab",
+}
+
+// applicationsInspectCmd represents the applications inspect command
+var applicationsInspectCmd = &cobra.Command{
+	Use:   "inspect <uuid>",
+	Short: "Inspect the full configuration of an application",
+	Long: `Show the complete configuration of an application grouped into sections: general, git, build,
+network, health check, resource limits and a summary of its environment variables.
+
+With -o yaml or -o json the complete application object is printed with sorted keys, which
+makes configuration reviews and diffs between applications or over time easy. Webhook secrets
+and the basic auth password are redacted unless --show-secrets is given.
+
+Examples:
+  coolifyme applications inspect <uuid>
+  coolifyme applications inspect <uuid> -o yaml > app.yaml
+  diff <(coolifyme apps inspect <uuid1> -o yaml) <(coolifyme apps inspect <uuid2> -o yaml)`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		app, err := client.Applications().Get(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get application: %w", err)
+		}
+
+		showSecrets, _ := cmd.Flags().GetBool("show-secrets")
+		format, _ := cmd.Flags().GetString("output")
+		switch format {
+		case "json", "yaml":
+			object, err := applicationObject(app, showSecrets)
+			if err != nil {
+				return err
+			}
+			if format == "yaml" {
+				return outputYAML(object)
+			}
+			return outputJSON(object)
+		case "", "table":
+		default:
+			return fmt.Errorf("unsupported output format '%s' (expected table, json or yaml)", format)
+		}
+
+		envs, err := client.Applications().ListEnvs(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to list environment variables: %w", err)
+		}
+
+		printInspectSections(applicationSections(app, envs))
+		return nil
+	},
+}
+
+// applicationObject converts an application to a generic object with sorted keys, redacting secrets
+func applicationObject(app *coolify.Application, showSecrets bool) (map[string]any, error) {
+	data, err := json.Marshal(app)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	var object map[string]any
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	if !showSecrets {
+		for _, field := range applicationSecretFields {
+			if value, ok := object[field].(string); ok && value != "" {
+				object[field] = "[REDACTED]"
+			}
+		}
+	}
+	return object, nil
+}
+
+// applicationSections groups the settings of an application for display
+func applicationSections(app *coolify.Application, envs []coolify.EnvironmentVariable) []inspectSection {
+	str := func(value *string) string { return stringOrDash(value) }
+	timestamp := func(value *time.Time) string {
+		if value == nil {
+			return "-"
+		}
+		return value.Format(time.RFC3339)
+	}
+	num := func(value *int) string {
+		if value == nil {
+			return "-"
+		}
+		return strconv.Itoa(*value)
+	}
+	flag := func(value *bool) string {
+		if value == nil {
+			return "-"
+		}
+		if *value {
+			return "yes"
+		}
+		return "no"
+	}
+
+	buildPack := "-"
+	if app.BuildPack != nil {
+		buildPack = string(*app.BuildPack)
+	}
+	redirect := "-"
+	if app.Redirect != nil {
+		redirect = string(*app.Redirect)
+	}
+
+	var buildTime, preview, multiline int
+	keys := make([]string, 0, len(envs))
+	for _, env := range envs {
+		if env.Key != nil {
+			keys = append(keys, *env.Key)
+		}
+		if env.IsBuildTime != nil && *env.IsBuildTime {
+			buildTime++
+		}
+		if env.IsPreview != nil && *env.IsPreview {
+			preview++
+		}
+		if env.IsMultiline != nil && *env.IsMultiline {
+			multiline++
+		}
+	}
+	sort.Strings(keys)
+	keyList := "-"
+	if len(keys) > 0 {
+		keyList = strings.Join(keys, ", ")
+	}
+
+	return []inspectSection{
+		{"General", [][2]string{
+			{"Name", str(app.Name)},
+			{"UUID", str(app.Uuid)},
+			{"Description", str(app.Description)},
+			{"Status", str(app.Status)},
+			{"Created", timestamp(app.CreatedAt)},
+			{"Updated", timestamp(app.UpdatedAt)},
+		}},
+		{"Git", [][2]string{
+			{"Repository", str(app.GitRepository)},
+			{"Branch", str(app.GitBranch)},
+			{"Commit", str(app.GitCommitSha)},
+			{"Full URL", str(app.GitFullUrl)},
+			{"Watch paths", str(app.WatchPaths)},
+		}},
+		{"Build", [][2]string{
+			{"Build pack", buildPack},
+			{"Base directory", str(app.BaseDirectory)},
+			{"Publish directory", str(app.PublishDirectory)},
+			{"Install command", str(app.InstallCommand)},
+			{"Build command", str(app.BuildCommand)},
+			{"Start command", str(app.StartCommand)},
+			{"Dockerfile location", str(app.DockerfileLocation)},
+			{"Dockerfile target", str(app.DockerfileTargetBuild)},
+			{"Compose location", str(app.DockerComposeLocation)},
+			{"Image", str(app.DockerRegistryImageName)},
+			{"Image tag", str(app.DockerRegistryImageTag)},
+			{"Static image", str(app.StaticImage)},
+			{"Pre-deployment", str(app.PreDeploymentCommand)},
+			{"Post-deployment", str(app.PostDeploymentCommand)},
+		}},
+		{"Network", [][2]string{
+			{"Domains", str(app.Fqdn)},
+			{"Redirect", redirect},
+			{"Exposed ports", str(app.PortsExposes)},
+			{"Port mappings", str(app.PortsMappings)},
+			{"Network aliases", str(app.CustomNetworkAliases)},
+			{"Basic auth", flag(app.IsHttpBasicAuthEnabled)},
+			{"Preview URL template", str(app.PreviewUrlTemplate)},
+		}},
+		{"Health Check", [][2]string{
+			{"Enabled", flag(app.HealthCheckEnabled)},
+			{"Method", str(app.HealthCheckMethod)},
+			{"Scheme", str(app.HealthCheckScheme)},
+			{"Host", str(app.HealthCheckHost)},
+			{"Port", str(app.HealthCheckPort)},
+			{"Path", str(app.HealthCheckPath)},
+			{"Expected code", num(app.HealthCheckReturnCode)},
+			{"Interval (s)", num(app.HealthCheckInterval)},
+			{"Timeout (s)", num(app.HealthCheckTimeout)},
+			{"Retries", num(app.HealthCheckRetries)},
+			{"Start period (s)", num(app.HealthCheckStartPeriod)},
+		}},
+		{"Limits", [][2]string{
+			{"CPUs", str(app.LimitsCpus)},
+			{"CPU set", str(app.LimitsCpuset)},
+			{"CPU shares", num(app.LimitsCpuShares)},
+			{"Memory", str(app.LimitsMemory)},
+			{"Memory reservation", str(app.LimitsMemoryReservation)},
+			{"Memory swap", str(app.LimitsMemorySwap)},
+			{"Swappiness", num(app.LimitsMemorySwappiness)},
+			{"Swarm replicas", num(app.SwarmReplicas)},
+		}},
+		{"Environment Variables", [][2]string{
+			{"Total", strconv.Itoa(len(envs))},
+			{"Build time", strconv.Itoa(buildTime)},
+			{"Preview", strconv.Itoa(preview)},
+			{"Multiline", strconv.Itoa(multiline)},
+			{"Keys", keyList},
+		}},
+	}
+}
+
+// printInspectSections prints titled sections of key/value rows
+func printInspectSections(sections []inspectSection) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer func() {
+		_ = w.Flush()
+	}()
+
+	for i, section := range sections {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		_, _ = fmt.Fprintf(w, "%s\n", section.Title)
+		_, _ = fmt.Fprintf(w, "%s\n", strings.Repeat("=", len(section.Title)))
+		for _, row := range section.Rows {
+			_, _ = fmt.Fprintf(w, "%s:\t%s\n", row[0], row[1])
+		}
+	}
+}
+
+func init() {
+	applicationsCmd.AddCommand(applicationsInspectCmd)
+
+	// Flags for inspect command
+	applicationsInspectCmd.Flags().Bool("show-secrets", false, "Include webhook secrets and the basic auth password in -o json/yaml output")
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// applicationsLimitsCmd represents the applications limits command
+var applicationsLimitsCmd = &cobra.Command{
+	Use:   "limits",
+	Short: "Show and change the resource limits of an application",
+	Long: `Show and change the Docker resource limits of an application: memory, memory plus swap,
+memory reservation, swappiness, number of CPUs, CPU set and CPU shares.
+
+Memory sizes use Docker's units (512m, 1g; 512MB and 512Mi are accepted too) and 0 means no
+limit. Coolify applies changed limits with the next deployment or restart of the application.`,
+}
+
+// applicationsLimitsGetCmd represents the applications limits get command
+var applicationsLimitsGetCmd = &cobra.Command{
+	Use:   "get <uuid>",
+	Short: "Show the resource limits of an application",
+	Long: `Show the resource limits of an application.
+
+Examples:
+  coolifyme applications limits get <uuid>
+  coolifyme applications limits get <uuid> --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		app, err := client.Applications().Get(context.Background(), args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get application: %w", err)
+		}
+		limits := clientpkg.ApplicationLimits(app)
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(limits, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "LIMIT\tVALUE")
+		_, _ = fmt.Fprintln(w, "-----\t-----")
+		for _, row := range limitRows(limits) {
+			_, _ = fmt.Fprintf(w, "%s\t%s\n", row[0], row[1])
+		}
+		return w.Flush()
+	},
+}
+
+// applicationsLimitsSetCmd represents the applications limits set command
+var applicationsLimitsSetCmd = &cobra.Command{
+	Use:   "set <uuid>",
+	Short: "Change the resource limits of an application",
+	Long: `Change the resource limits of an application. Only the limits given as flags are changed;
+the current and new values are shown before the update, and --dry-run stops there.
+
+--swap is the limit of memory plus swap like docker's --memory-swap: it must be at least
+--memory, 0 uses Docker's default of twice the memory and -1 allows unlimited swap.
+
+Examples:
+  coolifyme applications limits set <uuid> --memory 512m --cpus 0.5
+  coolifyme applications limits set <uuid> --memory 1g --swap 2g --dry-run
+  coolifyme applications limits set <uuid> --memory 0 --cpus 0`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		app, err := client.Applications().Get(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get application: %w", err)
+		}
+		current := clientpkg.ApplicationLimits(app)
+		limits, err := limitsFromFlags(cmd, current)
+		if err != nil {
+			return err
+		}
+
+		changed := printLimitsDiff(current, limits)
+		if changed == 0 {
+			theme.Printf("✅ The limits are already set, nothing to change\n")
+			return nil
+		}
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			theme.Printf("🔍 Dry run: %d limit(s) would change\n", changed)
+			return nil
+		}
+
+		if err := client.Applications().SetLimits(ctx, args[0], current, limits); err != nil {
+			return fmt.Errorf("failed to update limits: %w", err)
+		}
+		theme.Printf("✅ Updated %d limit(s) of %s\n", changed, stringOrDash(app.Name))
+		theme.Printf("💡 Redeploy or restart the application to apply them: coolifyme applications restart %s\n", args[0])
+		return nil
+	},
+}
+
+// limitsFromFlags returns the limits with the values of the given flags validated and applied
+func limitsFromFlags(cmd *cobra.Command, limits clientpkg.ResourceLimits) (clientpkg.ResourceLimits, error) {
+	if !cmd.Flags().Changed("memory") && !cmd.Flags().Changed("swap") && !cmd.Flags().Changed("memory-reservation") &&
+		!cmd.Flags().Changed("swappiness") && !cmd.Flags().Changed("cpus") && !cmd.Flags().Changed("cpuset") &&
+		!cmd.Flags().Changed("cpu-shares") {
+		return limits, fmt.Errorf("no limits given, use --memory, --swap, --memory-reservation, --swappiness, --cpus, --cpuset or --cpu-shares")
+	}
+
+	normalize := []struct {
+		flag  string
+		field *string
+		parse func(string) (string, error)
+	}{
+		{"memory", &limits.Memory, clientpkg.NormalizeMemory},
+		{"swap", &limits.MemorySwap, clientpkg.NormalizeSwap},
+		{"memory-reservation", &limits.MemoryReservation, clientpkg.NormalizeMemory},
+		{"cpus", &limits.CPUs, clientpkg.NormalizeCPUs},
+	}
+	for _, n := range normalize {
+		if !cmd.Flags().Changed(n.flag) {
+			continue
+		}
+		value, _ := cmd.Flags().GetString(n.flag)
+		normalized, err := n.parse(value)
+		if err != nil {
+			return limits, fmt.Errorf("invalid --%s: %w", n.flag, err)
+		}
+		*n.field = normalized
+	}
+
+	if cmd.Flags().Changed("cpuset") {
+		limits.CPUSet, _ = cmd.Flags().GetString("cpuset")
+		if err := clientpkg.ValidateCPUSet(limits.CPUSet); err != nil {
+			return limits, fmt.Errorf("invalid --cpuset: %w", err)
+		}
+	}
+	if cmd.Flags().Changed("swappiness") {
+		swappiness, _ := cmd.Flags().GetInt("swappiness")
+		limits.MemorySwappiness = &swappiness
+	}
+	if cmd.Flags().Changed("cpu-shares") {
+		shares, _ := cmd.Flags().GetInt("cpu-shares")
+		if shares < 0 {
+			return limits, fmt.Errorf("invalid --cpu-shares %d: must not be negative", shares)
+		}
+		limits.CPUShares = &shares
+	}
+	return limits, limits.Validate()
+}
+
+// limitRows returns the name and value of each limit for display
+func limitRows(limits clientpkg.ResourceLimits) [][2]string {
+	value := func(s string) string {
+		if s == "" || s == "0" {
+			return "unlimited"
+		}
+		return s
+	}
+	number := func(n *int) string {
+		if n == nil {
+			return "-"
+		}
+		return strconv.Itoa(*n)
+	}
+	cpuSet := limits.CPUSet
+	if cpuSet == "" {
+		cpuSet = "all"
+	}
+	swap := limits.MemorySwap
+	switch swap {
+	case "", "0":
+		swap = "default"
+	case "-1":
+		swap = "unlimited"
+	}
+	return [][2]string{
+		{"Memory", value(limits.Memory)},
+		{"Memory + swap", swap},
+		{"Memory reservation", value(limits.MemoryReservation)},
+		{"Swappiness", number(limits.MemorySwappiness)},
+		{"CPUs", value(limits.CPUs)},
+		{"CPU set", cpuSet},
+		{"CPU shares", number(limits.CPUShares)},
+	}
+}
+
+// printLimitsDiff prints the current and new value of every limit, marking changed ones, and
+// returns the number of changed limits
+func printLimitsDiff(current, limits clientpkg.ResourceLimits) int {
+	before, after := limitRows(current), limitRows(limits)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "\tLIMIT\tCURRENT\tNEW")
+	_, _ = fmt.Fprintln(w, "\t-----\t-------\t---")
+	changed := 0
+	for i := range before {
+		marker := ""
+		if before[i][1] != after[i][1] {
+			marker = "~"
+			changed++
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", marker, before[i][0], before[i][1], after[i][1])
+	}
+	_ = w.Flush()
+	fmt.Println()
+	return changed
+}
+
+func init() {
+	applicationsCmd.AddCommand(applicationsLimitsCmd)
+	applicationsLimitsCmd.AddCommand(applicationsLimitsGetCmd)
+	applicationsLimitsCmd.AddCommand(applicationsLimitsSetCmd)
+
+	applicationsLimitsGetCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+
+	applicationsLimitsSetCmd.Flags().String("memory", "", "Memory limit, e.g. 512m or 1g (0 for no limit)")
+	applicationsLimitsSetCmd.Flags().String("swap", "", "Memory plus swap limit, e.g. 1g (0 for Docker's default, -1 for unlimited)")
+	applicationsLimitsSetCmd.Flags().String("memory-reservation", "", "Soft memory limit, e.g. 256m (0 for none)")
+	applicationsLimitsSetCmd.Flags().Int("swappiness", 60, "Memory swappiness between 0 and 100")
+	applicationsLimitsSetCmd.Flags().String("cpus", "", "Number of CPUs, e.g. 0.5 or 2 (0 for no limit)")
+	applicationsLimitsSetCmd.Flags().String("cpuset", "", "CPUs the application may use, e.g. 0-3 or 0,2 (empty for all)")
+	applicationsLimitsSetCmd.Flags().Int("cpu-shares", 1024, "Relative CPU weight")
+	applicationsLimitsSetCmd.Flags().Bool("dry-run", false, "Show the changes without updating the application")
+}
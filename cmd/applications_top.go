@@ -0,0 +1,366 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// topConcurrency limits the servers queried at the same time by applications top
+const topConcurrency = 5
+
+// containerUsage is the resource usage of one application container
+type containerUsage struct {
+	Application   string  `json:"application" yaml:"application"`
+	UUID          string  `json:"uuid" yaml:"uuid"`
+	Container     string  `json:"container" yaml:"container"`
+	Server        string  `json:"server" yaml:"server"`
+	CPUPercent    float64 `json:"cpu_percent" yaml:"cpu_percent"`
+	MemoryUsage   string  `json:"memory_usage" yaml:"memory_usage"`
+	MemoryPercent float64 `json:"memory_percent" yaml:"memory_percent"`
+	NetIO         string  `json:"net_io" yaml:"net_io"`
+	BlockIO       string  `json:"block_io" yaml:"block_io"`
+	PIDs          string  `json:"pids" yaml:"pids"`
+}
+
+// topServer is a server queried for container statistics over SSH
+type topServer struct {
+	name string
+	ssh  *serverSSH
+}
+
+// applicationsTopCmd represents the applications top command
+var applicationsTopCmd = &cobra.Command{
+	Use:   "top [uuid]",
+	Short: "Show CPU and memory usage of application containers",
+	Long: `Show the CPU and memory usage of the containers of all applications, or of one application.
+
+The Coolify API does not expose container metrics, so the statistics are read with docker stats
+over SSH, using the address and user Coolify has for each server with the keys of your SSH agent
+and configuration, or the local key given with --identity. --use-server-key instead fetches the
+private key attached to each server through the API. Only servers running the selected
+applications are contacted.
+
+Rows are sorted by CPU usage by default; use --sort-by memory or --sort-by name to change it.
+With --watch the view refreshes every --interval seconds, like top.
+
+Examples:
+  coolifyme applications top
+  coolifyme applications top <uuid>
+  coolifyme applications top --watch --sort-by memory
+  coolifyme applications top -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		sortBy, _ := cmd.Flags().GetString("sort-by")
+		if sortBy != "cpu" && sortBy != "memory" && sortBy != "name" {
+			return fmt.Errorf("invalid --sort-by '%s' (use cpu, memory or name)", sortBy)
+		}
+		format, _ := cmd.Flags().GetString("output")
+		key := getSSHKeyOptions(cmd)
+
+		ctx := context.Background()
+		apps, err := topApplications(ctx, client, args)
+		if err != nil {
+			return err
+		}
+
+		servers, cleanup, err := topServers(ctx, client, apps, key)
+		defer cleanup()
+		if err != nil {
+			return err
+		}
+		if len(servers) == 0 {
+			theme.Println("📭 No servers found running the applications")
+			return nil
+		}
+
+		watch, _ := cmd.Flags().GetBool("watch")
+		if !watch {
+			usage, warnings, err := collectContainerUsage(ctx, servers, apps)
+			if err != nil {
+				return err
+			}
+			for _, warning := range warnings {
+				warn("applications top", errors.New(warning))
+			}
+			return printContainerUsage(usage, nil, sortBy, format)
+		}
+
+		interval, _ := cmd.Flags().GetInt("interval")
+		if interval < 1 {
+			interval = 2 // Default 2 seconds
+		}
+
+		return WatchLoop(ctx, getWatchConfig(cmd, time.Duration(interval)*time.Second), func(ctx context.Context) (bool, error) {
+			usage, warnings, err := collectContainerUsage(ctx, servers, apps)
+			if err != nil {
+				return false, err
+			}
+
+			// Clear screen (works on most terminals)
+			fmt.Print("\033[2J\033[H")
+			theme.Printf("🔄 Every %ds: coolifyme applications top    %s\n\n", interval, time.Now().Format("2006-01-02 15:04:05"))
+			if err := printContainerUsage(usage, warnings, sortBy, format); err != nil {
+				return true, err
+			}
+			return false, nil
+		})
+	},
+}
+
+// topApplications returns the applications to show, keyed by UUID
+func topApplications(ctx context.Context, client *clientpkg.Client, args []string) (map[string]string, error) {
+	apps := make(map[string]string)
+	if len(args) == 1 {
+		app, err := client.Applications().Get(ctx, args[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get application: %w", err)
+		}
+		apps[args[0]] = stringOrDash(app.Name)
+		return apps, nil
+	}
+
+	list, err := client.Applications().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	for _, app := range list {
+		if app.Uuid != nil {
+			apps[*app.Uuid] = stringOrDash(app.Name)
+		}
+	}
+	return apps, nil
+}
+
+// topServers finds the servers running the applications and prepares their SSH connections.
+// The returned cleanup removes the private keys written for ssh and must always be called.
+func topServers(ctx context.Context, client *clientpkg.Client, apps map[string]string, key sshKeyOptions) ([]topServer, func(), error) {
+	var cleanups []func()
+	cleanup := func() {
+		for _, c := range cleanups {
+			c()
+		}
+	}
+
+	list, err := client.Servers().List(ctx)
+	if err != nil {
+		return nil, cleanup, fmt.Errorf("failed to list servers: %w", err)
+	}
+
+	// Create semaphore for concurrency control
+	sem := make(chan struct{}, topConcurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var hosting []coolify.Server
+
+	for _, server := range list {
+		if server.Uuid == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(server coolify.Server) {
+			defer wg.Done()
+			sem <- struct{}{}        // Acquire semaphore
+			defer func() { <-sem }() // Release semaphore
+
+			resources, err := client.Servers().GetResources(ctx, *server.Uuid)
+			if err != nil {
+				return
+			}
+			var items []struct {
+				UUID string `json:"uuid"`
+			}
+			if err := json.Unmarshal([]byte(resources), &items); err != nil {
+				return
+			}
+			for _, item := range items {
+				if _, ok := apps[item.UUID]; ok {
+					mu.Lock()
+					hosting = append(hosting, server)
+					mu.Unlock()
+					return
+				}
+			}
+		}(server)
+	}
+	wg.Wait()
+
+	servers := make([]topServer, 0, len(hosting))
+	for _, server := range hosting {
+		ssh, err := openServerSSH(ctx, client, *server.Uuid, key, "-o", "BatchMode=yes")
+		if err != nil {
+			return nil, cleanup, fmt.Errorf("server %s: %w", stringOrDash(server.Name), err)
+		}
+		cleanups = append(cleanups, ssh.Close)
+		servers = append(servers, topServer{name: stringOrDash(server.Name), ssh: ssh})
+	}
+	return servers, cleanup, nil
+}
+
+// collectContainerUsage reads docker stats from all servers concurrently. Servers that cannot be
+// reached are reported as warnings; it only fails when no server could be read.
+func collectContainerUsage(ctx context.Context, servers []topServer, apps map[string]string) ([]containerUsage, []string, error) {
+	// Create semaphore for concurrency control
+	sem := make(chan struct{}, topConcurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var usage []containerUsage
+	var warnings []string
+	var lastErr error
+
+	for _, server := range servers {
+		wg.Add(1)
+		go func(server topServer) {
+			defer wg.Done()
+			sem <- struct{}{}        // Acquire semaphore
+			defer func() { <-sem }() // Release semaphore
+
+			rows, err := dockerStatsViaSSH(ctx, server, apps)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("%s: %v", server.name, err))
+				lastErr = err
+				return
+			}
+			usage = append(usage, rows...)
+		}(server)
+	}
+	wg.Wait()
+
+	if len(warnings) == len(servers) && lastErr != nil {
+		return nil, nil, fmt.Errorf("failed to read container statistics: %w", lastErr)
+	}
+	sort.Strings(warnings)
+	return usage, warnings, nil
+}
+
+// dockerStatsViaSSH runs docker stats on a server and returns the rows of application containers
+func dockerStatsViaSSH(ctx context.Context, server topServer, apps map[string]string) ([]containerUsage, error) {
+	output, err := server.ssh.Output(ctx, "docker stats --no-stream --format '{{json .}}'")
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []containerUsage
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		var stats struct {
+			Name     string `json:"Name"`
+			CPUPerc  string `json:"CPUPerc"`
+			MemUsage string `json:"MemUsage"`
+			MemPerc  string `json:"MemPerc"`
+			NetIO    string `json:"NetIO"`
+			BlockIO  string `json:"BlockIO"`
+			PIDs     string `json:"PIDs"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &stats); err != nil {
+			continue
+		}
+
+		// Coolify names application containers after the application UUID
+		for uuid, name := range apps {
+			if !strings.Contains(stats.Name, uuid) {
+				continue
+			}
+			rows = append(rows, containerUsage{
+				Application:   name,
+				UUID:          uuid,
+				Container:     stats.Name,
+				Server:        server.name,
+				CPUPercent:    parsePercent(stats.CPUPerc),
+				MemoryUsage:   stats.MemUsage,
+				MemoryPercent: parsePercent(stats.MemPerc),
+				NetIO:         stats.NetIO,
+				BlockIO:       stats.BlockIO,
+				PIDs:          stats.PIDs,
+			})
+			break
+		}
+	}
+	return rows, nil
+}
+
+// parsePercent parses a docker stats percentage such as "12.34%"
+func parsePercent(s string) float64 {
+	value, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// printContainerUsage sorts and prints the container usage as a table, JSON or YAML. The warnings
+// of servers that could not be read are printed with each refresh of --watch.
+func printContainerUsage(usage []containerUsage, warnings []string, sortBy, format string) error {
+	sort.SliceStable(usage, func(i, j int) bool {
+		switch sortBy {
+		case "memory":
+			if usage[i].MemoryPercent != usage[j].MemoryPercent {
+				return usage[i].MemoryPercent > usage[j].MemoryPercent
+			}
+		case "cpu":
+			if usage[i].CPUPercent != usage[j].CPUPercent {
+				return usage[i].CPUPercent > usage[j].CPUPercent
+			}
+		}
+		if usage[i].Application != usage[j].Application {
+			return usage[i].Application < usage[j].Application
+		}
+		return usage[i].Container < usage[j].Container
+	})
+
+	switch format {
+	case "json":
+		return outputJSON(usage)
+	case "yaml":
+		return outputYAML(usage)
+	}
+
+	for _, warning := range warnings {
+		fmt.Fprint(os.Stderr, theme.Sprintf("⚠️  %s\n", warning))
+	}
+	if len(usage) == 0 {
+		theme.Println("📭 No running application containers found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "APPLICATION\tCONTAINER\tSERVER\tCPU %\tMEMORY\tMEM %\tNET I/O\tPIDS")
+	_, _ = fmt.Fprintln(w, "-----------\t---------\t------\t-----\t------\t-----\t-------\t----")
+	for _, row := range usage {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%.2f%%\t%s\t%.2f%%\t%s\t%s\n",
+			row.Application, row.Container, row.Server, row.CPUPercent, row.MemoryUsage, row.MemoryPercent, row.NetIO, row.PIDs)
+	}
+	return w.Flush()
+}
+
+func init() {
+	applicationsCmd.AddCommand(applicationsTopCmd)
+
+	// Flags for top command
+	applicationsTopCmd.Flags().String("sort-by", "cpu", "Sort by cpu, memory or name")
+	applicationsTopCmd.Flags().BoolP("watch", "w", false, "Refresh the view periodically")
+	applicationsTopCmd.Flags().Int("interval", 2, "Refresh interval in seconds for --watch")
+	addSSHKeyFlags(applicationsTopCmd)
+	addWatchFlags(applicationsTopCmd)
+}
@@ -0,0 +1,715 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// bootstrapFile is the declarative description of a Coolify instance used by the bootstrap command
+type bootstrapFile struct {
+	PrivateKeys []bootstrapPrivateKey `yaml:"private_keys"`
+	Servers     []bootstrapServer     `yaml:"servers"`
+	Projects    []bootstrapProject    `yaml:"projects"`
+}
+
+// bootstrapPrivateKey describes an SSH private key
+type bootstrapPrivateKey struct {
+	Name           string `yaml:"name"`
+	Description    string `yaml:"description"`
+	PrivateKey     string `yaml:"private_key"`
+	PrivateKeyFile string `yaml:"private_key_file"`
+}
+
+// bootstrapServer describes a server to register
+type bootstrapServer struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	IP          string `yaml:"ip"`
+	Port        int    `yaml:"port"`
+	User        string `yaml:"user"`
+	PrivateKey  string `yaml:"private_key"`
+	Proxy       string `yaml:"proxy"`
+	BuildServer bool   `yaml:"build_server"`
+	Validate    *bool  `yaml:"validate"`
+}
+
+// bootstrapProject describes a project with its environments and resources
+type bootstrapProject struct {
+	Name         string                 `yaml:"name"`
+	Description  string                 `yaml:"description"`
+	Environments []string               `yaml:"environments"`
+	Applications []bootstrapApplication `yaml:"applications"`
+	Databases    []bootstrapDatabase    `yaml:"databases"`
+}
+
+// bootstrapApplication describes an application built from a public git repository or a Docker image
+type bootstrapApplication struct {
+	Name          string `yaml:"name"`
+	Description   string `yaml:"description"`
+	Environment   string `yaml:"environment"`
+	Server        string `yaml:"server"`
+	GitRepository string `yaml:"git_repository"`
+	GitBranch     string `yaml:"git_branch"`
+	BuildPack     string `yaml:"build_pack"`
+	Image         string `yaml:"image"`
+	PortsExposes  string `yaml:"ports_exposes"`
+	Domains       string `yaml:"domains"`
+	InstantDeploy bool   `yaml:"instant_deploy"`
+}
+
+// bootstrapDatabase describes a database
+type bootstrapDatabase struct {
+	Name          string `yaml:"name"`
+	Description   string `yaml:"description"`
+	Type          string `yaml:"type"`
+	Environment   string `yaml:"environment"`
+	Server        string `yaml:"server"`
+	Image         string `yaml:"image"`
+	InstantDeploy bool   `yaml:"instant_deploy"`
+}
+
+// bootstrapStep records what the bootstrap command did for a single resource
+type bootstrapStep struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+	UUID   string `json:"uuid,omitempty"`
+}
+
+// Bootstrap step actions
+const (
+	bootstrapCreated     = "created"
+	bootstrapExists      = "exists"
+	bootstrapWouldCreate = "would create"
+	bootstrapValidated   = "validated"
+)
+
+// bootstrapDatabaseTypes lists the database types accepted in bootstrap files
+var bootstrapDatabaseTypes = []string{"postgresql", "mysql", "mariadb", "redis", "mongodb", "keydb", "dragonfly", "clickhouse"}
+
+// bootstrapCmd represents the bootstrap command
+var bootstrapCmd = &cobra.Command{
+	Use:   "bootstrap",
+	Short: "Provision a Coolify instance from a file",
+	Long: `Provision a Coolify instance end-to-end from one declarative YAML file: private keys,
+servers (registered and validated), projects and environments, and initial applications and
+databases.
+
+Resources are matched by name, so re-running the command skips everything that already exists.
+Environment variables such as ${DEPLOY_KEY} in the file are expanded.
+
+  private_keys:
+    - name: deploy-key
+      private_key_file: ~/.ssh/id_ed25519
+  servers:
+    - name: web-1
+      ip: 203.0.113.10
+      user: root
+      private_key: deploy-key
+  projects:
+    - name: shop
+      environments: [production, staging]
+      applications:
+        - name: storefront
+          environment: production
+          server: web-1
+          git_repository: https://github.com/example/storefront
+          ports_exposes: "3000"
+        - name: proxy
+          server: web-1
+          image: nginx:1.27
+          ports_exposes: "80"
+      databases:
+        - name: shop-db
+          type: postgresql
+          server: web-1
+
+Examples:
+  coolifyme bootstrap --file bootstrap.yaml --dry-run
+  coolifyme bootstrap --file bootstrap.yaml`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		if file == "" {
+			return fmt.Errorf("bootstrap file is required (--file)")
+		}
+
+		spec, err := loadBootstrapFile(file)
+		if err != nil {
+			return err
+		}
+
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+
+		b := &bootstrapper{
+			client:  client,
+			dryRun:  dryRun,
+			verbose: !jsonOutput,
+			keys:    make(map[string]string),
+			servers: make(map[string]string),
+		}
+		runErr := b.run(context.Background(), spec)
+
+		if jsonOutput {
+			output, err := json.MarshalIndent(b.steps, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return runErr
+		}
+		if runErr != nil {
+			return runErr
+		}
+
+		fmt.Println()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "KIND\tNAME\tACTION\tUUID")
+		_, _ = fmt.Fprintln(w, "----\t----\t------\t----")
+		created := 0
+		for _, step := range b.steps {
+			if step.Action == bootstrapCreated {
+				created++
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", step.Kind, step.Name, step.Action, step.UUID)
+		}
+		_ = w.Flush()
+		fmt.Println()
+
+		if dryRun {
+			theme.Printf("📝 Dry run complete, nothing was changed\n")
+		} else {
+			theme.Printf("🎉 Bootstrap complete: %d resource(s) created\n", created)
+		}
+		return nil
+	},
+}
+
+// loadBootstrapFile reads, expands and validates a bootstrap file
+func loadBootstrapFile(path string) (*bootstrapFile, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - user-provided bootstrap file
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bootstrap file: %w", err)
+	}
+
+	var spec bootstrapFile
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(data))), &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse bootstrap file: %w", err)
+	}
+
+	if err := validateBootstrapFile(&spec); err != nil {
+		return nil, fmt.Errorf("invalid bootstrap file: %w", err)
+	}
+	return &spec, nil
+}
+
+// validateBootstrapFile checks required fields and fills in defaults before anything is created
+func validateBootstrapFile(spec *bootstrapFile) error {
+	for _, key := range spec.PrivateKeys {
+		if key.Name == "" {
+			return fmt.Errorf("private key without name")
+		}
+		if key.PrivateKey == "" && key.PrivateKeyFile == "" {
+			return fmt.Errorf("private key '%s': private_key or private_key_file is required", key.Name)
+		}
+	}
+
+	for i := range spec.Servers {
+		server := &spec.Servers[i]
+		if server.Name == "" || server.IP == "" || server.PrivateKey == "" {
+			return fmt.Errorf("server '%s': name, ip and private_key are required", server.Name)
+		}
+		if server.User == "" {
+			server.User = "root"
+		}
+		if server.Port == 0 {
+			server.Port = 22
+		}
+		switch server.Proxy {
+		case "", ProxyTraefik, "caddy", "none":
+		default:
+			return fmt.Errorf("server '%s': invalid proxy '%s' (expected traefik, caddy or none)", server.Name, server.Proxy)
+		}
+	}
+
+	for i := range spec.Projects {
+		project := &spec.Projects[i]
+		if project.Name == "" {
+			return fmt.Errorf("project without name")
+		}
+		if len(project.Environments) == 0 {
+			project.Environments = []string{"production"}
+		}
+
+		for j := range project.Applications {
+			app := &project.Applications[j]
+			if app.Name == "" || app.Server == "" {
+				return fmt.Errorf("project '%s': application '%s': name and server are required", project.Name, app.Name)
+			}
+			if (app.GitRepository == "") == (app.Image == "") {
+				return fmt.Errorf("project '%s': application '%s': set exactly one of git_repository or image", project.Name, app.Name)
+			}
+			if app.PortsExposes == "" {
+				return fmt.Errorf("project '%s': application '%s': ports_exposes is required", project.Name, app.Name)
+			}
+			if app.Environment == "" {
+				app.Environment = project.Environments[0]
+			}
+			if !containsString(project.Environments, app.Environment) {
+				return fmt.Errorf("project '%s': application '%s': environment '%s' is not listed in the project", project.Name, app.Name, app.Environment)
+			}
+		}
+
+		for j := range project.Databases {
+			db := &project.Databases[j]
+			if db.Name == "" || db.Server == "" {
+				return fmt.Errorf("project '%s': database '%s': name and server are required", project.Name, db.Name)
+			}
+			if !containsString(bootstrapDatabaseTypes, db.Type) {
+				return fmt.Errorf("project '%s': database '%s': invalid type '%s' (expected %s)", project.Name, db.Name, db.Type, strings.Join(bootstrapDatabaseTypes, ", "))
+			}
+			if db.Environment == "" {
+				db.Environment = project.Environments[0]
+			}
+			if !containsString(project.Environments, db.Environment) {
+				return fmt.Errorf("project '%s': database '%s': environment '%s' is not listed in the project", project.Name, db.Name, db.Environment)
+			}
+		}
+	}
+	return nil
+}
+
+// bootstrapper provisions the resources of a bootstrap file, remembering the UUIDs it resolved
+type bootstrapper struct {
+	client  *clientpkg.Client
+	dryRun  bool
+	verbose bool
+	steps   []bootstrapStep
+	keys    map[string]string
+	servers map[string]string
+}
+
+// record adds a step and prints it
+func (b *bootstrapper) record(kind, name, action, uuid string) {
+	b.steps = append(b.steps, bootstrapStep{Kind: kind, Name: name, Action: action, UUID: uuid})
+	if !b.verbose {
+		return
+	}
+	switch action {
+	case bootstrapCreated:
+		theme.Printf("✅ Created %s %s (%s)\n", kind, name, uuid)
+	case bootstrapValidated:
+		theme.Printf("✅ Validated %s %s\n", kind, name)
+	case bootstrapWouldCreate:
+		theme.Printf("📝 Would create %s %s\n", kind, name)
+	default:
+		fmt.Printf("   %s %s already exists, skipping\n", kind, name)
+	}
+}
+
+// run provisions private keys, servers and projects in dependency order, stopping at the first error
+func (b *bootstrapper) run(ctx context.Context, spec *bootstrapFile) error {
+	if err := b.privateKeys(ctx, spec.PrivateKeys); err != nil {
+		return err
+	}
+	if err := b.registerServers(ctx, spec.Servers); err != nil {
+		return err
+	}
+	for _, project := range spec.Projects {
+		if err := b.project(ctx, project); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// privateKeys creates the private keys that do not exist yet
+func (b *bootstrapper) privateKeys(ctx context.Context, keys []bootstrapPrivateKey) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	existing, err := b.client.PrivateKeys().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list private keys: %w", err)
+	}
+	for _, key := range existing {
+		if key.Name != nil && key.Uuid != nil {
+			b.keys[*key.Name] = *key.Uuid
+		}
+	}
+
+	for _, key := range keys {
+		if uuid, ok := b.keys[key.Name]; ok {
+			b.record("private-key", key.Name, bootstrapExists, uuid)
+			continue
+		}
+
+		content := key.PrivateKey
+		if content == "" {
+			data, err := os.ReadFile(expandHomePath(key.PrivateKeyFile)) // #nosec G304 - user-provided key file
+			if err != nil {
+				return fmt.Errorf("failed to read private key '%s': %w", key.Name, err)
+			}
+			content = string(data)
+		}
+
+		if b.dryRun {
+			b.keys[key.Name] = ""
+			b.record("private-key", key.Name, bootstrapWouldCreate, "")
+			continue
+		}
+
+		name, description := key.Name, key.Description
+		uuid, err := b.client.PrivateKeys().Create(ctx, coolify.CreatePrivateKeyJSONRequestBody{
+			Name:        &name,
+			Description: &description,
+			PrivateKey:  content,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create private key '%s': %w", key.Name, err)
+		}
+		b.keys[key.Name] = uuid
+		b.record("private-key", key.Name, bootstrapCreated, uuid)
+	}
+	return nil
+}
+
+// registerServers creates and validates the servers that do not exist yet
+func (b *bootstrapper) registerServers(ctx context.Context, servers []bootstrapServer) error {
+	existing, err := b.client.Servers().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list servers: %w", err)
+	}
+	for _, server := range existing {
+		if server.Name != nil && server.Uuid != nil {
+			b.servers[*server.Name] = *server.Uuid
+		}
+	}
+
+	for _, server := range servers {
+		if uuid, ok := b.servers[server.Name]; ok {
+			b.record("server", server.Name, bootstrapExists, uuid)
+			continue
+		}
+
+		keyUUID, ok := b.keys[server.PrivateKey]
+		if !ok {
+			// Not declared in the file, treat it as the UUID of an existing key
+			keyUUID = server.PrivateKey
+		}
+
+		if b.dryRun {
+			b.servers[server.Name] = ""
+			b.record("server", server.Name, bootstrapWouldCreate, "")
+			continue
+		}
+
+		name, description, ip, user, port := server.Name, server.Description, server.IP, server.User, server.Port
+		req := coolify.CreateServerJSONRequestBody{
+			Name:           &name,
+			Description:    &description,
+			Ip:             &ip,
+			User:           &user,
+			Port:           &port,
+			PrivateKeyUuid: &keyUUID,
+		}
+		if server.BuildServer {
+			req.IsBuildServer = &server.BuildServer
+		}
+		if server.Proxy != "" {
+			proxyType := coolify.CreateServerJSONBodyProxyType(server.Proxy)
+			req.ProxyType = &proxyType
+		}
+
+		uuid, err := b.client.Servers().Create(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to create server '%s': %w", server.Name, err)
+		}
+		b.servers[server.Name] = uuid
+		b.record("server", server.Name, bootstrapCreated, uuid)
+
+		if server.Validate == nil || *server.Validate {
+			if _, err := b.client.Servers().Validate(ctx, uuid); err != nil {
+				return fmt.Errorf("failed to validate server '%s': %w", server.Name, err)
+			}
+			b.record("server", server.Name, bootstrapValidated, uuid)
+		}
+	}
+	return nil
+}
+
+// project creates a project, its environments, applications and databases as needed
+func (b *bootstrapper) project(ctx context.Context, project bootstrapProject) error {
+	projectUUID := ""
+	if existing, err := b.client.Projects().Resolve(ctx, project.Name); err == nil {
+		projectUUID = *existing.Uuid
+		b.record("project", project.Name, bootstrapExists, projectUUID)
+	} else if b.dryRun {
+		b.record("project", project.Name, bootstrapWouldCreate, "")
+	} else {
+		name, description := project.Name, project.Description
+		projectUUID, err = b.client.Projects().Create(ctx, coolify.CreateProjectJSONRequestBody{Name: &name, Description: &description})
+		if err != nil {
+			return fmt.Errorf("failed to create project '%s': %w", project.Name, err)
+		}
+		b.record("project", project.Name, bootstrapCreated, projectUUID)
+	}
+
+	// Environment IDs are used to tell whether a named resource already exists in this project
+	environmentIDs := make(map[int]bool)
+	for _, env := range project.Environments {
+		label := project.Name + "/" + env
+		if projectUUID != "" {
+			if existing, err := b.client.Projects().GetEnvironment(ctx, projectUUID, env); err == nil {
+				if existing.Id != nil {
+					environmentIDs[*existing.Id] = true
+				}
+				b.record("environment", label, bootstrapExists, "")
+				continue
+			}
+		}
+		if b.dryRun {
+			b.record("environment", label, bootstrapWouldCreate, "")
+			continue
+		}
+
+		uuid, err := b.client.Projects().CreateEnvironment(ctx, projectUUID, env)
+		if err != nil {
+			return fmt.Errorf("failed to create environment '%s': %w", label, err)
+		}
+		if created, err := b.client.Projects().GetEnvironment(ctx, projectUUID, env); err == nil && created.Id != nil {
+			environmentIDs[*created.Id] = true
+		}
+		b.record("environment", label, bootstrapCreated, uuid)
+	}
+
+	if err := b.applications(ctx, project, projectUUID, environmentIDs); err != nil {
+		return err
+	}
+	return b.databases(ctx, project, projectUUID, environmentIDs)
+}
+
+// applications creates the applications of a project that do not exist yet
+func (b *bootstrapper) applications(ctx context.Context, project bootstrapProject, projectUUID string, environmentIDs map[int]bool) error {
+	if len(project.Applications) == 0 {
+		return nil
+	}
+
+	existing := make(map[string]string)
+	if len(environmentIDs) > 0 {
+		apps, err := b.client.Applications().List(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list applications: %w", err)
+		}
+		for _, app := range apps {
+			if app.Name != nil && app.Uuid != nil && app.EnvironmentId != nil && environmentIDs[*app.EnvironmentId] {
+				existing[*app.Name] = *app.Uuid
+			}
+		}
+	}
+
+	for _, app := range project.Applications {
+		if uuid, ok := existing[app.Name]; ok {
+			b.record("application", app.Name, bootstrapExists, uuid)
+			continue
+		}
+		if b.dryRun {
+			b.record("application", app.Name, bootstrapWouldCreate, "")
+			continue
+		}
+
+		serverUUID := b.serverUUID(app.Server)
+		name, description, domains, instant := app.Name, app.Description, app.Domains, app.InstantDeploy
+
+		var created *coolify.Application
+		var err error
+		if app.Image != "" {
+			image, tag := splitImageTag(app.Image)
+			req := coolify.CreateDockerimageApplicationJSONRequestBody{
+				Name:                    &name,
+				Description:             &description,
+				DockerRegistryImageName: image,
+				EnvironmentName:         app.Environment,
+				PortsExposes:            app.PortsExposes,
+				ProjectUuid:             projectUUID,
+				ServerUuid:              serverUUID,
+				InstantDeploy:           &instant,
+			}
+			if tag != "" {
+				req.DockerRegistryImageTag = &tag
+			}
+			if domains != "" {
+				req.Domains = &domains
+			}
+			created, err = b.client.Applications().CreateDockerImage(ctx, req)
+		} else {
+			branch, buildPack := app.GitBranch, app.BuildPack
+			if branch == "" {
+				branch = "main"
+			}
+			if buildPack == "" {
+				buildPack = "nixpacks"
+			}
+			req := coolify.CreatePublicApplicationJSONRequestBody{
+				Name:            &name,
+				Description:     &description,
+				BuildPack:       coolify.CreatePublicApplicationJSONBodyBuildPack(buildPack),
+				EnvironmentName: app.Environment,
+				GitBranch:       branch,
+				GitRepository:   app.GitRepository,
+				PortsExposes:    app.PortsExposes,
+				ProjectUuid:     projectUUID,
+				ServerUuid:      serverUUID,
+				InstantDeploy:   &instant,
+			}
+			if domains != "" {
+				req.Domains = &domains
+			}
+			created, err = b.client.Applications().CreatePublic(ctx, req)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create application '%s': %w", app.Name, err)
+		}
+		b.record("application", app.Name, bootstrapCreated, stringOrDash(created.Uuid))
+	}
+	return nil
+}
+
+// databases creates the databases of a project that do not exist yet
+func (b *bootstrapper) databases(ctx context.Context, project bootstrapProject, projectUUID string, environmentIDs map[int]bool) error {
+	if len(project.Databases) == 0 {
+		return nil
+	}
+
+	existing := make(map[string]string)
+	if len(environmentIDs) > 0 {
+		result, err := b.client.Databases().List(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list databases: %w", err)
+		}
+		var dbs []struct {
+			Name          string `json:"name"`
+			UUID          string `json:"uuid"`
+			EnvironmentID int    `json:"environment_id"`
+		}
+		if err := json.Unmarshal([]byte(result), &dbs); err != nil {
+			return fmt.Errorf("failed to parse databases: %w", err)
+		}
+		for _, db := range dbs {
+			if environmentIDs[db.EnvironmentID] {
+				existing[db.Name] = db.UUID
+			}
+		}
+	}
+
+	for _, db := range project.Databases {
+		if uuid, ok := existing[db.Name]; ok {
+			b.record("database", db.Name, bootstrapExists, uuid)
+			continue
+		}
+		if b.dryRun {
+			b.record("database", db.Name, bootstrapWouldCreate, "")
+			continue
+		}
+
+		uuid, err := b.createDatabase(ctx, db, projectUUID)
+		if err != nil {
+			return fmt.Errorf("failed to create database '%s': %w", db.Name, err)
+		}
+		b.record("database", db.Name, bootstrapCreated, uuid)
+	}
+	return nil
+}
+
+// createDatabase creates a database of the declared type
+func (b *bootstrapper) createDatabase(ctx context.Context, db bootstrapDatabase, projectUUID string) (string, error) {
+	env, server := db.Environment, b.serverUUID(db.Server)
+	name, description, instant := &db.Name, &db.Description, &db.InstantDeploy
+	var image *string
+	if db.Image != "" {
+		image = &db.Image
+	}
+
+	databases := b.client.Databases()
+	switch db.Type {
+	case "postgresql":
+		return databases.CreatePostgreSQL(ctx, coolify.CreateDatabasePostgresqlJSONRequestBody{EnvironmentName: env, ProjectUuid: projectUUID, ServerUuid: server, Name: name, Description: description, Image: image, InstantDeploy: instant})
+	case "mysql":
+		return databases.CreateMySQL(ctx, coolify.CreateDatabaseMysqlJSONRequestBody{EnvironmentName: env, ProjectUuid: projectUUID, ServerUuid: server, Name: name, Description: description, Image: image, InstantDeploy: instant})
+	case "mariadb":
+		return databases.CreateMariaDB(ctx, coolify.CreateDatabaseMariadbJSONRequestBody{EnvironmentName: env, ProjectUuid: projectUUID, ServerUuid: server, Name: name, Description: description, Image: image, InstantDeploy: instant})
+	case "redis":
+		return databases.CreateRedis(ctx, coolify.CreateDatabaseRedisJSONRequestBody{EnvironmentName: env, ProjectUuid: projectUUID, ServerUuid: server, Name: name, Description: description, Image: image, InstantDeploy: instant})
+	case "mongodb":
+		return databases.CreateMongoDB(ctx, coolify.CreateDatabaseMongodbJSONRequestBody{EnvironmentName: env, ProjectUuid: projectUUID, ServerUuid: server, Name: name, Description: description, Image: image, InstantDeploy: instant})
+	case "keydb":
+		return databases.CreateKeyDB(ctx, coolify.CreateDatabaseKeydbJSONRequestBody{EnvironmentName: env, ProjectUuid: projectUUID, ServerUuid: server, Name: name, Description: description, Image: image, InstantDeploy: instant})
+	case "dragonfly":
+		return databases.CreateDragonfly(ctx, coolify.CreateDatabaseDragonflyJSONRequestBody{EnvironmentName: env, ProjectUuid: projectUUID, ServerUuid: server, Name: name, Description: description, Image: image, InstantDeploy: instant})
+	case "clickhouse":
+		return databases.CreateClickHouse(ctx, coolify.CreateDatabaseClickhouseJSONRequestBody{EnvironmentName: env, ProjectUuid: projectUUID, ServerUuid: server, Name: name, Description: description, Image: image, InstantDeploy: instant})
+	}
+	return "", fmt.Errorf("unsupported database type: %s", db.Type)
+}
+
+// serverUUID resolves a server name from the file or the instance, treating unknown values as UUIDs
+func (b *bootstrapper) serverUUID(nameOrUUID string) string {
+	if uuid, ok := b.servers[nameOrUUID]; ok {
+		return uuid
+	}
+	return nameOrUUID
+}
+
+// splitImageTag splits a Docker image reference into name and tag
+func splitImageTag(image string) (string, string) {
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return image, ""
+	}
+	return image[:i], image[i+1:]
+}
+
+// expandHomePath expands a leading ~ to the user's home directory
+func expandHomePath(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// containsString reports whether a slice contains a value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	bootstrapCmd.Flags().StringP("file", "f", "", "Bootstrap file describing the resources to create")
+	bootstrapCmd.Flags().Bool("dry-run", false, "Show what would be created without changing anything")
+	bootstrapCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+}
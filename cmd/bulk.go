@@ -3,8 +3,15 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 	"sync"
 
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
 	"github.com/spf13/cobra"
 )
 
@@ -12,136 +19,160 @@ import (
 var appsStartAllCmd = &cobra.Command{
 	Use:   "start-all",
 	Short: "Start all applications",
-	Long:  "Start all applications with concurrency control and dry-run support",
-	RunE: func(cmd *cobra.Command, _ []string) error {
-		client, err := createClient()
-		if err != nil {
-			return fmt.Errorf("failed to create client: %w", err)
-		}
-
-		dryRun, _ := cmd.Flags().GetBool("dry-run")
-		concurrent, _ := cmd.Flags().GetInt("concurrent")
-
-		ctx := context.Background()
-		applications, err := client.Applications().List(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to list applications: %w", err)
-		}
-
-		// Collect all application UUIDs
-		var appUUIDs []string
-		for _, app := range applications {
-			if app.Uuid != nil {
-				appUUIDs = append(appUUIDs, *app.Uuid)
-			}
-		}
+	Long: `Start all applications, or the ones selected with filters, with concurrency control and
+dry-run support.
 
-		if len(appUUIDs) == 0 {
-			fmt.Println("📭 No applications found")
-			return nil
-		}
-
-		fmt.Printf("🚀 Starting %d applications...\n", len(appUUIDs))
-		if dryRun {
-			fmt.Println("🧪 DRY RUN - Applications that would be started:")
-			for _, uuid := range appUUIDs {
-				fmt.Printf("   📦 %s\n", uuid)
-			}
-			return nil
-		}
-
-		return bulkOperationApps(ctx, client, appUUIDs, "start", concurrent)
+Examples:
+  coolifyme applications start-all --filter status=exited --dry-run
+  coolifyme applications start-all --project shop --environment production`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runBulkAppsOperation(cmd, "start")
 	},
 }
 
 var appsStopAllCmd = &cobra.Command{
 	Use:   "stop-all",
 	Short: "Stop all applications",
-	Long:  "Stop all applications with concurrency control and dry-run support",
-	RunE: func(cmd *cobra.Command, _ []string) error {
-		client, err := createClient()
-		if err != nil {
-			return fmt.Errorf("failed to create client: %w", err)
-		}
-
-		dryRun, _ := cmd.Flags().GetBool("dry-run")
-		concurrent, _ := cmd.Flags().GetInt("concurrent")
-
-		ctx := context.Background()
-		applications, err := client.Applications().List(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to list applications: %w", err)
-		}
-
-		// Collect all application UUIDs
-		var appUUIDs []string
-		for _, app := range applications {
-			if app.Uuid != nil {
-				appUUIDs = append(appUUIDs, *app.Uuid)
-			}
-		}
+	Long: `Stop all applications, or the ones selected with filters, with concurrency control and
+dry-run support. The affected applications are listed and confirmed before stopping.
 
-		if len(appUUIDs) == 0 {
-			fmt.Println("📭 No applications found")
-			return nil
-		}
-
-		fmt.Printf("⏹️  Stopping %d applications...\n", len(appUUIDs))
-		if dryRun {
-			fmt.Println("🧪 DRY RUN - Applications that would be stopped:")
-			for _, uuid := range appUUIDs {
-				fmt.Printf("   📦 %s\n", uuid)
-			}
-			return nil
-		}
-
-		return bulkOperationApps(ctx, client, appUUIDs, "stop", concurrent)
+Examples:
+  coolifyme applications stop-all --server build-1 --dry-run
+  coolifyme applications stop-all --filter "name=*-preview" --force`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runBulkAppsOperation(cmd, "stop")
 	},
 }
 
 var appsRestartAllCmd = &cobra.Command{
 	Use:   "restart-all",
 	Short: "Restart all applications",
-	Long:  "Restart all applications with concurrency control and dry-run support",
+	Long: `Restart all applications, or the ones selected with filters, with concurrency control and
+dry-run support. The affected applications are listed and confirmed before restarting.
+
+Examples:
+  coolifyme applications restart-all --server web-1
+  coolifyme applications restart-all --tag api --filter status=running --dry-run`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
-		client, err := createClient()
-		if err != nil {
-			return fmt.Errorf("failed to create client: %w", err)
-		}
+		return runBulkAppsOperation(cmd, "restart")
+	},
+}
 
-		dryRun, _ := cmd.Flags().GetBool("dry-run")
-		concurrent, _ := cmd.Flags().GetInt("concurrent")
+// bulkAppsVerbs holds the progressive and past forms of each bulk application operation
+var bulkAppsVerbs = map[string][2]string{
+	"start":   {"🚀 Starting", "started"},
+	"stop":    {"⏹️  Stopping", "stopped"},
+	"restart": {"🔄 Restarting", "restarted"},
+}
 
-		ctx := context.Background()
-		applications, err := client.Applications().List(ctx)
+// runBulkAppsOperation runs an operation on the applications selected by the filter flags
+func runBulkAppsOperation(cmd *cobra.Command, operation string) error {
+	client, err := createClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	concurrent, _ := cmd.Flags().GetInt("concurrent")
+
+	ctx := context.Background()
+	applications, err := selectApplications(ctx, cmd, client)
+	if err != nil {
+		return err
+	}
+
+	if len(applications) == 0 {
+		theme.Println("📭 No applications found")
+		return nil
+	}
+
+	verbs := bulkAppsVerbs[operation]
+	if dryRun {
+		theme.Printf("🧪 DRY RUN - Applications that would be %s:\n", verbs[1])
+		printBulkApplications(applications)
+		return nil
+	}
+
+	theme.Printf("%s %d applications...\n", verbs[0], len(applications))
+	printBulkApplications(applications)
+
+	// Starting is harmless, stopping and restarting interrupt running applications
+	if operation != "start" && !confirm.Action(fmt.Sprintf("%d application(s) will be %s", len(applications), verbs[1]), skipConfirmation(cmd)) {
+		theme.Println("❌ Operation cancelled")
+		return nil
+	}
+
+	return bulkOperationApps(ctx, client, applications, operation, concurrent, false)
+}
+
+// appUUIDArgs returns the application UUIDs given as arguments and in the file of the --file flag
+// (one per line, "-" for standard input, # starts a comment), without duplicates
+func appUUIDArgs(cmd *cobra.Command, args []string) ([]string, error) {
+	uuids := append([]string{}, args...)
+	if file, _ := cmd.Flags().GetString("file"); file != "" {
+		var data []byte
+		var err error
+		if file == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(file) // #nosec G304 -- the path comes from the command line
+		}
 		if err != nil {
-			return fmt.Errorf("failed to list applications: %w", err)
+			return nil, fmt.Errorf("failed to read UUIDs: %w", err)
 		}
-
-		// Collect all application UUIDs
-		var appUUIDs []string
-		for _, app := range applications {
-			if app.Uuid != nil {
-				appUUIDs = append(appUUIDs, *app.Uuid)
+		for _, line := range strings.Split(string(data), "\n") {
+			line, _, _ = strings.Cut(line, "#")
+			if line = strings.TrimSpace(line); line != "" {
+				uuids = append(uuids, line)
 			}
 		}
+	}
 
-		if len(appUUIDs) == 0 {
-			fmt.Println("📭 No applications found")
-			return nil
+	seen := make(map[string]bool, len(uuids))
+	unique := uuids[:0]
+	for _, uuid := range uuids {
+		if !seen[uuid] {
+			seen[uuid] = true
+			unique = append(unique, uuid)
 		}
+	}
+	if len(unique) == 0 {
+		return nil, fmt.Errorf("no application UUIDs given (pass them as arguments or with --file)")
+	}
+	return unique, nil
+}
 
-		fmt.Printf("🔄 Restarting %d applications...\n", len(appUUIDs))
-		if dryRun {
-			fmt.Println("🧪 DRY RUN - Applications that would be restarted:")
-			for _, uuid := range appUUIDs {
-				fmt.Printf("   📦 %s\n", uuid)
+// runAppsOperationForUUIDs runs an operation on the given applications concurrently, with the
+// per-application results of the bulk operations
+func runAppsOperationForUUIDs(cmd *cobra.Command, client *clientpkg.Client, operation string, uuids []string) error {
+	concurrent, _ := cmd.Flags().GetInt("concurrent")
+	force, _ := cmd.Flags().GetBool("force")
+
+	// Names make the results readable; without them the UUIDs are enough
+	ctx := context.Background()
+	names := make(map[string]*string)
+	if apps, err := client.Applications().List(ctx); err == nil {
+		for _, app := range apps {
+			if app.Uuid != nil {
+				names[*app.Uuid] = app.Name
 			}
-			return nil
 		}
+	}
 
-		return bulkOperationApps(ctx, client, appUUIDs, "restart", concurrent)
-	},
+	applications := make([]coolify.Application, 0, len(uuids))
+	for _, uuid := range uuids {
+		applications = append(applications, coolify.Application{Uuid: &uuid, Name: names[uuid]})
+	}
+
+	theme.Printf("%s %d applications...\n", bulkAppsVerbs[operation][0], len(applications))
+	return bulkOperationApps(ctx, client, applications, operation, concurrent, force)
+}
+
+// printBulkApplications lists the applications affected by a bulk operation
+func printBulkApplications(applications []coolify.Application) {
+	for _, app := range applications {
+		theme.Printf("   📦 %s (%s) %s\n", stringOrDash(app.Name), stringOrDash(app.Uuid), stringOrDash(app.Status))
+	}
 }
 
 // Bulk operations for services
@@ -173,15 +204,15 @@ var servicesDeployAllCmd = &cobra.Command{
 		}
 
 		if len(serviceUUIDs) == 0 {
-			fmt.Println("📭 No services found")
+			theme.Println("📭 No services found")
 			return nil
 		}
 
-		fmt.Printf("🚀 Deploying %d services...\n", len(serviceUUIDs))
+		theme.Printf("🚀 Deploying %d services...\n", len(serviceUUIDs))
 		if dryRun {
-			fmt.Println("🧪 DRY RUN - Services that would be deployed:")
+			theme.Println("🧪 DRY RUN - Services that would be deployed:")
 			for _, uuid := range serviceUUIDs {
-				fmt.Printf("   🔧 %s\n", uuid)
+				theme.Printf("   🔧 %s\n", uuid)
 			}
 			return nil
 		}
@@ -190,8 +221,8 @@ var servicesDeployAllCmd = &cobra.Command{
 	},
 }
 
-// Helper function for bulk application operations
-func bulkOperationApps(_ context.Context, _ interface{}, uuids []string, operation string, concurrent int) error {
+// Helper function for bulk application operations; force is passed to start operations
+func bulkOperationApps(ctx context.Context, client *clientpkg.Client, applications []coolify.Application, operation string, concurrent int, force bool) error {
 	if concurrent <= 0 {
 		concurrent = 5 // Default concurrency
 	}
@@ -200,60 +231,58 @@ func bulkOperationApps(_ context.Context, _ interface{}, uuids []string, operati
 	sem := make(chan struct{}, concurrent)
 	var wg sync.WaitGroup
 	var mu sync.Mutex
-	results := make([]string, 0, len(uuids))
+	results := make([]string, 0, len(applications))
 
-	for _, uuid := range uuids {
+	for _, app := range applications {
 		wg.Add(1)
-		go func(appUUID string) {
+		go func(appUUID, appName string) {
 			defer wg.Done()
 			sem <- struct{}{}        // Acquire semaphore
 			defer func() { <-sem }() // Release semaphore
 
 			var err error
-			var result string
-
-			// Note: These operations would use the actual client methods when implemented
 			switch operation {
 			case "start":
-				// Placeholder for actual start implementation
-				// err = client.Applications().Start(ctx, appUUID)
-				result = fmt.Sprintf("✅ %s: start operation completed (placeholder)", appUUID)
+				var params *coolify.StartApplicationByUuidParams
+				if force {
+					params = &coolify.StartApplicationByUuidParams{Force: &force}
+				}
+				_, err = client.Applications().Start(ctx, appUUID, params)
 			case "stop":
-				// Placeholder for actual stop implementation
-				// err = client.Applications().Stop(ctx, appUUID)
-				result = fmt.Sprintf("✅ %s: stop operation completed (placeholder)", appUUID)
+				err = client.Applications().Stop(ctx, appUUID)
 			case "restart":
-				// Placeholder for actual restart implementation
-				// err = client.Applications().Restart(ctx, appUUID)
-				result = fmt.Sprintf("✅ %s: restart operation completed (placeholder)", appUUID)
+				_, err = client.Applications().Restart(ctx, appUUID)
 			default:
 				err = fmt.Errorf("unknown operation: %s", operation)
 			}
 
 			mu.Lock()
 			if err != nil {
-				results = append(results, fmt.Sprintf("❌ %s: %v", appUUID, err))
+				results = append(results, fmt.Sprintf("❌ %s (%s): %v", appName, appUUID, err))
 			} else {
-				results = append(results, result)
+				results = append(results, fmt.Sprintf("✅ %s (%s): %s", appName, appUUID, bulkAppsVerbs[operation][1]))
 			}
 			mu.Unlock()
-		}(uuid)
+		}(*app.Uuid, stringOrDash(app.Name))
 	}
 
 	wg.Wait()
 
 	// Display results
-	fmt.Println("\n📊 Bulk Operation Results:")
+	theme.Println("\n📊 Bulk Operation Results:")
 	fmt.Println("=========================")
 	successCount := 0
 	for _, result := range results {
-		fmt.Println(result)
-		if result[0:4] == "✅" {
+		theme.Println(result)
+		if strings.HasPrefix(result, "✅") {
 			successCount++
 		}
 	}
 
-	fmt.Printf("\n📈 Summary: %d/%d operations completed successfully\n", successCount, len(results))
+	theme.Printf("\n📈 Summary: %d/%d operations completed successfully\n", successCount, len(results))
+	if successCount < len(results) {
+		return fmt.Errorf("%d of %d operations failed", len(results)-successCount, len(results))
+	}
 	return nil
 }
 
@@ -301,17 +330,17 @@ func bulkOperationServices(_ context.Context, _ interface{}, uuids []string, ope
 	wg.Wait()
 
 	// Display results
-	fmt.Println("\n📊 Bulk Operation Results:")
+	theme.Println("\n📊 Bulk Operation Results:")
 	fmt.Println("=========================")
 	successCount := 0
 	for _, result := range results {
-		fmt.Println(result)
-		if result[0:4] == "✅" {
+		theme.Println(result)
+		if strings.HasPrefix(result, "✅") {
 			successCount++
 		}
 	}
 
-	fmt.Printf("\n📈 Summary: %d/%d operations completed successfully\n", successCount, len(results))
+	theme.Printf("\n📈 Summary: %d/%d operations completed successfully\n", successCount, len(results))
 	return nil
 }
 
@@ -328,4 +357,11 @@ func init() {
 		cmd.Flags().Bool("dry-run", false, "Show what would be done without executing")
 		cmd.Flags().Int("concurrent", 5, "Number of concurrent operations")
 	}
+
+	// Flags selecting the applications of bulk application operations
+	for _, cmd := range []*cobra.Command{appsStartAllCmd, appsStopAllCmd, appsRestartAllCmd} {
+		addAppFilterFlags(cmd)
+	}
+	addConfirmFlags(appsStopAllCmd, "Stop without confirmation")
+	addConfirmFlags(appsRestartAllCmd, "Restart without confirmation")
 }
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// completionBlockStart and completionBlockEnd delimit the lines added to shell rc files
+	completionBlockStart = "# >>> coolifyme completion >>>"
+	completionBlockEnd   = "# <<< coolifyme completion <<<"
+)
+
+// completionTarget describes where the completion script of a shell is installed
+type completionTarget struct {
+	Shell string
+	// Script is the path of the completion script
+	Script string
+	// RCFile is the shell startup file that needs to load the script, empty when the shell
+	// picks the script up on its own
+	RCFile string
+	// RCLines are added to RCFile between the completion block markers
+	RCLines []string
+}
+
+// completionInstallCmd represents the completion install command
+var completionInstallCmd = &cobra.Command{
+	Use:   "install [bash|zsh|fish]",
+	Short: "Install the completion script for your shell",
+	Long: `Write the completion script to the standard location of your shell so it is loaded in
+every new session. The shell is detected from $SHELL unless given.
+
+  bash  ~/.local/share/bash-completion/completions/coolifyme (loaded by bash-completion;
+        ~/.bashrc sources it when bash-completion is not installed)
+  zsh   ~/.zsh/completions/_coolifyme (added to fpath in ~/.zshrc)
+  fish  ~/.config/fish/completions/coolifyme.fish
+
+Changes to rc files are confirmed first and kept between marker comments, so
+'completion uninstall' can remove them again.
+
+Examples:
+  coolifyme completion install
+  coolifyme completion install zsh --yes`,
+	ValidArgs: []string{"bash", "zsh", "fish"},
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, err := completionTargetFor(args)
+		if err != nil {
+			return err
+		}
+
+		var script bytes.Buffer
+		switch target.Shell {
+		case "bash":
+			err = rootCmd.GenBashCompletionV2(&script, true)
+		case "zsh":
+			err = rootCmd.GenZshCompletion(&script)
+		case "fish":
+			err = rootCmd.GenFishCompletion(&script, true)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to generate completion: %w", err)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target.Script), 0o750); err != nil {
+			return fmt.Errorf("failed to create completion directory: %w", err)
+		}
+		if err := os.WriteFile(target.Script, script.Bytes(), 0o600); err != nil {
+			return fmt.Errorf("failed to write completion script: %w", err)
+		}
+		theme.Printf("✅ Installed %s completion to %s\n", target.Shell, target.Script)
+
+		if target.RCFile != "" {
+			content, err := readRCFile(target.RCFile)
+			if err != nil {
+				return err
+			}
+			if strings.Contains(content, completionBlockStart) {
+				theme.Printf("✅ %s already loads the completion\n", target.RCFile)
+			} else {
+				block := completionBlockStart + "\n" + strings.Join(target.RCLines, "\n") + "\n" + completionBlockEnd + "\n"
+				fmt.Printf("\nThe following lines will be added to %s:\n\n%s\n", target.RCFile, block)
+				if !confirm.Action(fmt.Sprintf("Update %s?", target.RCFile), skipConfirmation(cmd)) {
+					theme.Printf("⚠️  %s not updated, add the lines above yourself to load the completion\n", target.RCFile)
+					return nil
+				}
+				if content != "" && !strings.HasSuffix(content, "\n") {
+					content += "\n"
+				}
+				if err := os.WriteFile(target.RCFile, []byte(content+block), 0o600); err != nil {
+					return fmt.Errorf("failed to update %s: %w", target.RCFile, err)
+				}
+				theme.Printf("✅ Updated %s\n", target.RCFile)
+			}
+		}
+
+		theme.Printf("💡 Start a new %s session to use the completion\n", target.Shell)
+		return nil
+	},
+}
+
+// completionUninstallCmd represents the completion uninstall command
+var completionUninstallCmd = &cobra.Command{
+	Use:   "uninstall [bash|zsh|fish]",
+	Short: "Remove the completion script installed by 'completion install'",
+	Long: `Remove the completion script written by 'completion install' and the lines it added to
+your shell rc file. The shell is detected from $SHELL unless given.
+
+Examples:
+  coolifyme completion uninstall
+  coolifyme completion uninstall bash --yes`,
+	ValidArgs: []string{"bash", "zsh", "fish"},
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, err := completionTargetFor(args)
+		if err != nil {
+			return err
+		}
+
+		switch err := os.Remove(target.Script); {
+		case err == nil:
+			theme.Printf("✅ Removed %s\n", target.Script)
+		case os.IsNotExist(err):
+			theme.Printf("📭 No %s completion installed at %s\n", target.Shell, target.Script)
+		default:
+			return fmt.Errorf("failed to remove completion script: %w", err)
+		}
+
+		// Check the rc file even when the shell no longer needs it, e.g. after installing bash-completion
+		rcFile := target.RCFile
+		if rcFile == "" {
+			rcFile = completionRCFile(target.Shell)
+		}
+		if rcFile == "" {
+			return nil
+		}
+		content, err := readRCFile(rcFile)
+		if err != nil {
+			return err
+		}
+		updated, removed := removeCompletionBlock(content)
+		if !removed {
+			return nil
+		}
+		if !confirm.Action(fmt.Sprintf("Remove the coolifyme completion lines from %s?", rcFile), skipConfirmation(cmd)) {
+			theme.Printf("⚠️  %s not updated\n", rcFile)
+			return nil
+		}
+		if err := os.WriteFile(rcFile, []byte(updated), 0o600); err != nil {
+			return fmt.Errorf("failed to update %s: %w", rcFile, err)
+		}
+		theme.Printf("✅ Updated %s\n", rcFile)
+		return nil
+	},
+}
+
+// completionTargetFor returns the install location for the shell argument or the detected shell
+func completionTargetFor(args []string) (*completionTarget, error) {
+	shell := ""
+	if len(args) > 0 {
+		shell = args[0]
+	} else {
+		shell = filepath.Base(os.Getenv("SHELL"))
+		if shell == "." || shell == "" {
+			return nil, fmt.Errorf("cannot detect your shell from $SHELL, pass it as an argument (bash, zsh or fish)")
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	name := rootCmd.Name()
+
+	switch shell {
+	case "bash":
+		dataHome := os.Getenv("XDG_DATA_HOME")
+		if dataHome == "" {
+			dataHome = filepath.Join(home, ".local", "share")
+		}
+		target := &completionTarget{Shell: shell, Script: filepath.Join(dataHome, "bash-completion", "completions", name)}
+		if !bashCompletionInstalled() {
+			target.RCFile = completionRCFile(shell)
+			target.RCLines = []string{fmt.Sprintf("[ -f %q ] && . %q", target.Script, target.Script)}
+		}
+		return target, nil
+	case "zsh":
+		dir := filepath.Join(home, ".zsh", "completions")
+		return &completionTarget{
+			Shell:  shell,
+			Script: filepath.Join(dir, "_"+name),
+			RCFile: completionRCFile(shell),
+			RCLines: []string{
+				fmt.Sprintf("fpath=(%q $fpath)", dir),
+				"autoload -U compinit && compinit",
+			},
+		}, nil
+	case "fish":
+		configHome := os.Getenv("XDG_CONFIG_HOME")
+		if configHome == "" {
+			configHome = filepath.Join(home, ".config")
+		}
+		return &completionTarget{Shell: shell, Script: filepath.Join(configHome, "fish", "completions", name+".fish")}, nil
+	}
+	return nil, fmt.Errorf("completion install does not support shell '%s', use 'coolifyme completion %s' instead", shell, shell)
+}
+
+// completionRCFile returns the startup file of a shell that may load the completion
+func completionRCFile(shell string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	switch shell {
+	case "bash":
+		return filepath.Join(home, ".bashrc")
+	case "zsh":
+		if dir := os.Getenv("ZDOTDIR"); dir != "" {
+			return filepath.Join(dir, ".zshrc")
+		}
+		return filepath.Join(home, ".zshrc")
+	}
+	return ""
+}
+
+// bashCompletionInstalled reports whether bash-completion, which loads user completions on its own, is installed
+func bashCompletionInstalled() bool {
+	for _, path := range []string{
+		"/usr/share/bash-completion/bash_completion",
+		"/etc/bash_completion",
+		"/usr/local/etc/profile.d/bash_completion.sh",
+		"/opt/homebrew/etc/profile.d/bash_completion.sh",
+	} {
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// readRCFile returns the content of a shell rc file, or an empty string when it does not exist
+func readRCFile(path string) (string, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - shell rc file in the home directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return string(data), nil
+}
+
+// removeCompletionBlock removes the lines added by completion install from rc file content
+func removeCompletionBlock(content string) (string, bool) {
+	start := strings.Index(content, completionBlockStart)
+	if start < 0 {
+		return content, false
+	}
+	end := strings.Index(content[start:], completionBlockEnd)
+	if end < 0 {
+		return content, false
+	}
+	end += start + len(completionBlockEnd)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+	return content[:start] + content[end:], true
+}
+
+func init() {
+	completionCmd.AddCommand(completionInstallCmd)
+	completionCmd.AddCommand(completionUninstallCmd)
+
+	// Flags for completion install and uninstall commands
+	addConfirmFlags(completionInstallCmd, "Update rc files without confirmation")
+	addConfirmFlags(completionUninstallCmd, "Update rc files without confirmation")
+}
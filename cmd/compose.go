@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/textdiff"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// composeResource reads and writes the compose file of a kind of resource
+type composeResource struct {
+	// kind is the resource kind in messages, e.g. "service"
+	kind string
+	// command is the parent command in examples, e.g. "services"
+	command string
+	get     func(ctx context.Context, client *clientpkg.Client, uuid string) (string, error)
+	set     func(ctx context.Context, client *clientpkg.Client, uuid, encoded string) error
+}
+
+// serviceCompose reads and writes the compose file of services
+var serviceCompose = composeResource{
+	kind:    "service",
+	command: "services",
+	get: func(ctx context.Context, client *clientpkg.Client, uuid string) (string, error) {
+		service, err := client.Services().Get(ctx, uuid)
+		if err != nil {
+			return "", err
+		}
+		return stringValue(service.DockerComposeRaw), nil
+	},
+	set: func(ctx context.Context, client *clientpkg.Client, uuid, encoded string) error {
+		_, err := client.Services().Update(ctx, uuid, coolify.UpdateServiceByUuidJSONRequestBody{DockerComposeRaw: encoded})
+		return err
+	},
+}
+
+// applicationCompose reads and writes the compose file of Docker Compose applications
+var applicationCompose = composeResource{
+	kind:    "application",
+	command: "applications",
+	get: func(ctx context.Context, client *clientpkg.Client, uuid string) (string, error) {
+		app, err := client.Applications().Get(ctx, uuid)
+		if err != nil {
+			return "", err
+		}
+		if app.BuildPack != nil && *app.BuildPack != "dockercompose" {
+			return "", fmt.Errorf("application %s uses the %s build pack, not dockercompose", uuid, *app.BuildPack)
+		}
+		return stringValue(app.DockerComposeRaw), nil
+	},
+	set: func(ctx context.Context, client *clientpkg.Client, uuid, encoded string) error {
+		_, err := client.Applications().Update(ctx, uuid, coolify.UpdateApplicationByUuidJSONRequestBody{DockerComposeRaw: &encoded})
+		return err
+	},
+}
+
+// composeCmd returns the compose command with get and set subcommands for a kind of resource
+func composeCmd(resource composeResource) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compose",
+		Short: fmt.Sprintf("Show or replace the compose file of the %s", resource.kind),
+		Long: fmt.Sprintf(`Show or replace the Docker Compose file stored for %[1]s resources.
+
+Examples:
+  coolifyme %[2]s compose get <uuid> > docker-compose.yml
+  coolifyme %[2]s compose set <uuid> -f docker-compose.yml`, resource.kind, resource.command),
+	}
+	cmd.AddCommand(composeGetCmd(resource), composeSetCmd(resource))
+	return cmd
+}
+
+func composeGetCmd(resource composeResource) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <uuid>",
+		Short: fmt.Sprintf("Print the compose file of the %s", resource.kind),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			client, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+
+			compose, err := resource.get(context.Background(), client, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to get %s: %w", resource.kind, err)
+			}
+			if compose == "" {
+				return fmt.Errorf("%s %s has no compose file", resource.kind, args[0])
+			}
+
+			fmt.Print(compose)
+			if !strings.HasSuffix(compose, "\n") {
+				fmt.Println()
+			}
+			return nil
+		},
+	}
+}
+
+func composeSetCmd(resource composeResource) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <uuid>",
+		Short: fmt.Sprintf("Replace the compose file of the %s", resource.kind),
+		Long: fmt.Sprintf(`Replace the compose file of the %[1]s with a local file. The file is validated first (valid YAML
+with a services section), then the changes to the stored file are shown as a diff for
+confirmation. Redeploy the %[1]s to apply the new file.
+
+Examples:
+  coolifyme %[2]s compose set <uuid> -f docker-compose.yml
+  coolifyme %[2]s compose set <uuid> -f docker-compose.yml --dry-run
+  cat docker-compose.yml | coolifyme %[2]s compose set <uuid> -f - --yes`, resource.kind, resource.command),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, _ := cmd.Flags().GetString("file")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+			var content []byte
+			var err error
+			if file == "-" {
+				content, err = io.ReadAll(os.Stdin)
+			} else {
+				content, err = safeReadFile(file)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", file, err)
+			}
+			if err := validateComposeFile(content); err != nil {
+				return fmt.Errorf("invalid compose file %s: %w", file, err)
+			}
+
+			client, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+
+			ctx := context.Background()
+			current, err := resource.get(ctx, client, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to get %s: %w", resource.kind, err)
+			}
+
+			diff := textdiff.Unified("current", file, current, string(content))
+			if diff == "" {
+				fmt.Printf("The compose file of %s %s is up to date\n", resource.kind, args[0])
+				return nil
+			}
+			fmt.Print(diff)
+			if dryRun {
+				theme.Println("💡 Dry run, nothing was changed")
+				return nil
+			}
+
+			if err := checkTokenAbility(cmd, client, clientpkg.AbilityWrite); err != nil {
+				return err
+			}
+			if !confirm.Action(fmt.Sprintf("Replace the compose file of %s %s?", resource.kind, args[0]), skipConfirmation(cmd)) {
+				theme.Println("❌ Cancelled")
+				return nil
+			}
+
+			// Coolify expects the compose file base64 encoded
+			if err := resource.set(ctx, client, args[0], base64.StdEncoding.EncodeToString(content)); err != nil {
+				return fmt.Errorf("failed to update %s: %w", resource.kind, err)
+			}
+			theme.Printf("✅ Compose file of %s %s updated\n", resource.kind, args[0])
+			theme.Printf("💡 Redeploy the %s to apply it\n", resource.kind)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("file", "f", "", "Compose file to upload (- for standard input)")
+	cmd.Flags().Bool("dry-run", false, "Validate the file and show the diff without uploading it")
+	cmd.Flags().BoolP("yes", "y", false, "Replace without confirmation")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+// validateComposeFile checks that a compose file is valid YAML with a services section of
+// service definitions
+func validateComposeFile(content []byte) error {
+	var compose map[string]any
+	if err := yaml.Unmarshal(content, &compose); err != nil {
+		return err
+	}
+	services, ok := compose["services"].(map[string]any)
+	if !ok || len(services) == 0 {
+		return fmt.Errorf("no services defined")
+	}
+	for name, service := range services {
+		if _, ok := service.(map[string]any); !ok {
+			return fmt.Errorf("service %q is not a mapping", name)
+		}
+	}
+	return nil
+}
+
+// stringValue dereferences an optional string of the API types
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func init() {
+	servicesCmd.AddCommand(composeCmd(serviceCompose))
+	applicationsCmd.AddCommand(composeCmd(applicationCompose))
+}
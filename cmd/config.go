@@ -1,13 +1,20 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/selfupdate"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/hongkongkiwi/coolifyme/pkg/client"
 	"github.com/spf13/cobra"
 )
 
@@ -42,7 +49,7 @@ var configSetCmd = &cobra.Command{
 	Use:   "set",
 	Short: "Set global configuration values",
 	Long: `Set global configuration values that apply across all profiles.
-These settings include output format, logging level, and color preferences.`,
+These settings include output format, logging level, color preferences and updates.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		cfg, err := config.LoadConfig()
 		if err != nil {
@@ -72,7 +79,7 @@ These settings include output format, logging level, and color preferences.`,
 			}
 			cfg.OutputFormat = outputFormat
 			updated = true
-			fmt.Printf("✅ Output format set to: %s\n", outputFormat)
+			theme.Printf("✅ Output format set to: %s\n", outputFormat)
 		}
 
 		if logLevel != "" {
@@ -89,7 +96,7 @@ These settings include output format, logging level, and color preferences.`,
 			}
 			cfg.LogLevel = logLevel
 			updated = true
-			fmt.Printf("✅ Log level set to: %s\n", logLevel)
+			theme.Printf("✅ Log level set to: %s\n", logLevel)
 		}
 
 		if colorOutput != "" {
@@ -104,10 +111,44 @@ These settings include output format, logging level, and color preferences.`,
 			if !isValid {
 				return fmt.Errorf("invalid color setting: %s. Valid options: %s", colorOutput, strings.Join(validColors, ", "))
 			}
-			colorBool := colorOutput == "always"
-			cfg.ColorOutput = &colorBool
+			// auto is the absence of a setting, so that terminal detection applies
+			cfg.ColorOutput = nil
+			if colorOutput != "auto" {
+				colorBool := colorOutput == "always"
+				cfg.ColorOutput = &colorBool
+			}
+			updated = true
+			theme.Printf("✅ Color output set to: %s\n", colorOutput)
+		}
+
+		if cmd.Flags().Changed("confirm-by-name") {
+			cfg.ConfirmByName, _ = cmd.Flags().GetBool("confirm-by-name")
+			updated = true
+			theme.Printf("✅ Confirm deletes by name: %t\n", cfg.ConfirmByName)
+		}
+
+		if cmd.Flags().Changed("update-channel") {
+			updateChannel, _ := cmd.Flags().GetString("update-channel")
+			channel, err := selfupdate.ParseChannel(updateChannel)
+			if err != nil {
+				return err
+			}
+			cfg.UpdateChannel = string(channel)
+			updated = true
+			theme.Printf("✅ Update channel set to: %s\n", channel)
+		}
+
+		if cmd.Flags().Changed("update-check") {
+			updateCheck, _ := cmd.Flags().GetBool("update-check")
+			cfg.UpdateCheck = &updateCheck
+			updated = true
+			theme.Printf("✅ Background update check: %t\n", updateCheck)
+		}
+
+		if cmd.Flags().Changed("history") {
+			cfg.History, _ = cmd.Flags().GetBool("history")
 			updated = true
-			fmt.Printf("✅ Color output set to: %s\n", colorOutput)
+			theme.Printf("✅ Command history: %t\n", cfg.History)
 		}
 
 		if !updated {
@@ -118,7 +159,7 @@ These settings include output format, logging level, and color preferences.`,
 			return fmt.Errorf("failed to save configuration: %w", err)
 		}
 
-		fmt.Println("📁 Configuration saved successfully")
+		theme.Println("📁 Configuration saved successfully")
 		return nil
 	},
 }
@@ -144,31 +185,41 @@ var configShowCmd = &cobra.Command{
 			return nil
 		}
 
-		fmt.Printf("📋 Current Configuration\n")
+		theme.Printf("📋 Current Configuration\n")
 		fmt.Printf("=======================\n")
-		fmt.Printf("🔧 Active Profile:  %s\n", cfg.Profile)
-		fmt.Printf("🌐 Base URL:        %s\n", cfg.BaseURL)
+		theme.Printf("🔧 Active Profile:  %s\n", cfg.Profile)
+		theme.Printf("🌐 Base URL:        %s\n", cfg.BaseURL)
 		if cfg.APIToken != "" {
-			fmt.Printf("🔑 API Token:       %s...\n", cfg.APIToken[:minInt(8, len(cfg.APIToken))])
+			theme.Printf("🔑 API Token:       %s...\n", cfg.APIToken[:minInt(8, len(cfg.APIToken))])
 		} else {
-			fmt.Printf("🔑 API Token:       (not set)\n")
+			theme.Printf("🔑 API Token:       (not set)\n")
 		}
-		fmt.Printf("📄 Output Format:   %s\n", cfg.OutputFormat)
-		fmt.Printf("📊 Log Level:       %s\n", cfg.LogLevel)
+		if cfg.ReadOnly {
+			theme.Printf("🔒 Read-only:       yes (changes are refused)\n")
+		}
+		theme.Printf("📄 Output Format:   %s\n", cfg.OutputFormat)
+		theme.Printf("📊 Log Level:       %s\n", cfg.LogLevel)
 		if cfg.ColorOutput != nil {
 			if *cfg.ColorOutput {
-				fmt.Printf("🎨 Color Output:    enabled\n")
+				theme.Printf("🎨 Color Output:    enabled\n")
 			} else {
-				fmt.Printf("🎨 Color Output:    disabled\n")
+				theme.Printf("🎨 Color Output:    disabled\n")
 			}
 		} else {
-			fmt.Printf("🎨 Color Output:    auto\n")
+			theme.Printf("🎨 Color Output:    auto\n")
 		}
+		theme.Printf("🛡️  Confirm By Name: %t\n", cfg.ConfirmByName)
+		theme.Printf("📦 Update Channel:  %s\n", updateChannel(cfg))
+		theme.Printf("🔄 Update Check:    %t\n", cfg.UpdateCheck == nil || *cfg.UpdateCheck)
+		theme.Printf("📜 History:         %t\n", cfg.History)
 
 		// Show config file location
 		configDir, err := config.GetConfigDir()
 		if err == nil {
-			fmt.Printf("📁 Config File:     %s/config.yaml\n", configDir)
+			theme.Printf("📁 Config File:     %s/config.yaml\n", configDir)
+		}
+		if local, err := config.LoadLocal(); err == nil && local != nil {
+			theme.Printf("📁 Local Config:    %s\n", local.Path)
 		}
 
 		return nil
@@ -201,18 +252,219 @@ var configInitCmd = &cobra.Command{
 		}
 
 		configDir, _ := config.GetConfigDir()
-		fmt.Printf("✅ Configuration initialized\n")
-		fmt.Printf("   📁 Config file: %s/config.yaml\n", configDir)
-		fmt.Printf("   🔧 Default profile created: default\n")
+		theme.Printf("✅ Configuration initialized\n")
+		theme.Printf("   📁 Config file: %s/config.yaml\n", configDir)
+		theme.Printf("   🔧 Default profile created: default\n")
 		fmt.Println()
-		fmt.Println("💡 Next steps:")
-		fmt.Println("   1. Set your API token: coolifyme config profile set --token YOUR_API_TOKEN")
-		fmt.Println("   2. Or create a new profile: coolifyme config profile create production --token TOKEN --url URL")
+		theme.Println("💡 Next steps:")
+		fmt.Println("   1. Log in to your instance: coolifyme login https://coolify.example.com")
+		fmt.Println("   2. Or set your API token: coolifyme config profile set --token YOUR_API_TOKEN")
+
+		return nil
+	},
+}
+
+// configValidateCmd represents the config validate command
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate configuration file",
+	Long:  "Check the configuration file for unknown keys, invalid URLs, missing tokens and insecure permissions",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		issues, err := config.Validate()
+		if err != nil {
+			return fmt.Errorf("failed to validate configuration: %w", err)
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			data, err := json.MarshalIndent(issues, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(data))
+		} else {
+			printConfigIssues(issues)
+		}
 
+		if countErrors(issues) > 0 {
+			return fmt.Errorf("configuration has %d error(s)", countErrors(issues))
+		}
 		return nil
 	},
 }
 
+// configMigrateCmd represents the config migrate command
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade the configuration file to the current schema version",
+	Long: `Rewrite a configuration file written by an older coolifyme in the current schema version.
+Older files keep working, since they are upgraded in memory when they are loaded; 'config validate'
+reports when a migration is pending.`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		from, migrated, err := config.Migrate()
+		if err != nil {
+			return fmt.Errorf("failed to migrate configuration: %w", err)
+		}
+		if !migrated {
+			theme.Printf("✅ Configuration is already at schema version %d\n", from)
+			return nil
+		}
+		theme.Printf("✅ Migrated configuration from schema version %d to %d\n", from, config.CurrentSchemaVersion)
+		return nil
+	},
+}
+
+// configDoctorCmd represents the config doctor command
+var configDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose configuration problems",
+	Long: `Validate the configuration file, fix insecure file permissions and check that
+every profile can reach its Coolify instance with a valid API token.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		theme.Println("🩺 Configuration Doctor")
+		fmt.Println("======================")
+
+		fixed, err := config.FixPermissions()
+		if err != nil {
+			return err
+		}
+		if fixed {
+			theme.Println("🔧 Restricted config file permissions to 0600")
+		}
+
+		issues, err := config.Validate()
+		if err != nil {
+			return fmt.Errorf("failed to validate configuration: %w", err)
+		}
+		printConfigIssues(issues)
+
+		skipConnectivity, _ := cmd.Flags().GetBool("offline")
+		if skipConnectivity {
+			return nil
+		}
+
+		profiles, _, err := config.ListProfiles()
+		if err != nil {
+			return fmt.Errorf("failed to list profiles: %w", err)
+		}
+		sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+
+		fmt.Println()
+		theme.Println("🌐 Profile connectivity")
+		failed := 0
+		for _, p := range profiles {
+			if err := checkProfileConnectivity(cmd.Context(), p); err != nil {
+				failed++
+				theme.Printf("❌ %s (%s): %v\n", p.Name, p.BaseURL, err)
+				continue
+			}
+			theme.Printf("✅ %s (%s): reachable, token valid\n", p.Name, p.BaseURL)
+		}
+
+		if failed > 0 || countErrors(issues) > 0 {
+			return fmt.Errorf("found %d configuration error(s) and %d unreachable profile(s)", countErrors(issues), failed)
+		}
+		return nil
+	},
+}
+
+// configTokenInfoCmd represents the config token-info command
+var configTokenInfoCmd = &cobra.Command{
+	Use:   "token-info",
+	Short: "Show the team and permissions of the API token",
+	Long: `Show the team the API token belongs to and which permissions it has: read, read:sensitive,
+write and deploy (root tokens have all of them).
+
+Coolify has no endpoint describing tokens, so the permissions are probed with requests that
+cannot change anything. Use this to find out why write operations or deployments fail with
+"permission denied".`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		info, err := c.TokenInfo(context.Background())
+		if err != nil {
+			return err
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(info, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		theme.Println("🔑 API Token")
+		fmt.Println("============")
+		if info.TeamName != "" {
+			fmt.Printf("Team:  %s (ID %d)\n", info.TeamName, info.TeamID)
+		}
+		fmt.Printf("Scope: %s\n", info.Scope())
+		fmt.Println()
+
+		printAbilities(info)
+		return nil
+	},
+}
+
+// checkProfileConnectivity verifies that a profile's instance is reachable and accepts its token
+func checkProfileConnectivity(ctx context.Context, p config.Profile) error {
+	if p.APIToken == "" {
+		return fmt.Errorf("no API token configured")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	c, err := client.New(&config.Config{APIToken: p.APIToken, BaseURL: p.BaseURL, Profile: p.Name, Headers: p.Headers}, client.WithUserAgent("coolifyme/"+Version))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if _, err := c.System().Version(ctx); err != nil {
+		if strings.Contains(err.Error(), "401") || client.IsPermissionError(err) {
+			return fmt.Errorf("token rejected by the server")
+		}
+		return err
+	}
+	return nil
+}
+
+// printConfigIssues prints configuration issues, or a success message when there are none
+func printConfigIssues(issues []config.Issue) {
+	if len(issues) == 0 {
+		theme.Println("✅ Configuration is valid")
+		return
+	}
+
+	for _, issue := range issues {
+		if issue.Severity == config.SeverityError {
+			theme.Printf("❌ %s\n", issue)
+		} else {
+			theme.Printf("⚠️  %s\n", issue)
+		}
+	}
+}
+
+// countErrors returns the number of error-severity issues
+func countErrors(issues []config.Issue) int {
+	count := 0
+	for _, issue := range issues {
+		if issue.Severity == config.SeverityError {
+			count++
+		}
+	}
+	return count
+}
+
 // Profile management commands
 var configProfileCmd = &cobra.Command{
 	Use:   "profile",
@@ -248,11 +500,11 @@ var configProfileListCmd = &cobra.Command{
 		}
 
 		if len(profiles) == 0 {
-			fmt.Println("❌ No profiles found. Run 'coolifyme config init' to create default profile.")
+			theme.Println("❌ No profiles found. Run 'coolifyme config init' to create default profile.")
 			return nil
 		}
 
-		fmt.Printf("📋 Configuration Profiles\n")
+		theme.Printf("📋 Configuration Profiles\n")
 		fmt.Printf("=========================\n")
 
 		// Create a tabwriter for nicely formatted output
@@ -269,7 +521,7 @@ var configProfileListCmd = &cobra.Command{
 		for _, profile := range profiles {
 			active := ""
 			if profile.Name == defaultProfile {
-				active = StatusSuccess
+				active = theme.Glyphs(StatusSuccess)
 			}
 
 			tokenDisplay := "(not set)"
@@ -318,11 +570,11 @@ var configProfileCreateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create profile: %w", err)
 		}
 
-		fmt.Printf("✅ Profile '%s' created successfully\n", profileName)
-		fmt.Printf("   🌐 Base URL: %s\n", url)
-		fmt.Printf("   🔑 API Token: %s...\n", token[:minInt(8, len(token))])
+		theme.Printf("✅ Profile '%s' created successfully\n", profileName)
+		theme.Printf("   🌐 Base URL: %s\n", url)
+		theme.Printf("   🔑 API Token: %s...\n", token[:minInt(8, len(token))])
 		fmt.Println()
-		fmt.Printf("💡 To use this profile: coolifyme config profile use %s\n", profileName)
+		theme.Printf("💡 To use this profile: coolifyme config profile use %s\n", profileName)
 
 		return nil
 	},
@@ -340,7 +592,7 @@ var configProfileUseCmd = &cobra.Command{
 			return fmt.Errorf("failed to set default profile: %w", err)
 		}
 
-		fmt.Printf("✅ Default profile set to '%s'\n", profileName)
+		theme.Printf("✅ Default profile set to '%s'\n", profileName)
 		return nil
 	},
 }
@@ -352,23 +604,16 @@ var configProfileDeleteCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		profileName := args[0]
-		force, _ := cmd.Flags().GetBool("force")
-
-		if !force {
-			fmt.Printf("⚠️  Are you sure you want to delete profile '%s'? This action cannot be undone.\n", profileName)
-			fmt.Print("Type 'yes' to confirm: ")
-			var confirmation string
-			if _, err := fmt.Scanln(&confirmation); err != nil || confirmation != ConfirmationYes {
-				fmt.Println("❌ Deletion cancelled")
-				return nil
-			}
+		if !confirm.Delete(confirm.Target{Kind: "profile", Name: profileName}, skipConfirmation(cmd)) {
+			theme.Println("❌ Deletion cancelled")
+			return nil
 		}
 
 		if err := config.DeleteProfile(profileName); err != nil {
 			return fmt.Errorf("failed to delete profile: %w", err)
 		}
 
-		fmt.Printf("✅ Profile '%s' deleted successfully\n", profileName)
+		theme.Printf("✅ Profile '%s' deleted successfully\n", profileName)
 		return nil
 	},
 }
@@ -393,12 +638,12 @@ var configProfileSetCmd = &cobra.Command{
 		if token != "" {
 			cfg.APIToken = token
 			updated = true
-			fmt.Printf("✅ API token updated for profile '%s'\n", cfg.Profile)
+			theme.Printf("✅ API token updated for profile '%s'\n", cfg.Profile)
 		}
 		if url != "" {
 			cfg.BaseURL = url
 			updated = true
-			fmt.Printf("✅ Base URL updated to: %s\n", url)
+			theme.Printf("✅ Base URL updated to: %s\n", url)
 		}
 
 		if !updated {
@@ -409,7 +654,7 @@ var configProfileSetCmd = &cobra.Command{
 			return fmt.Errorf("failed to save configuration: %w", err)
 		}
 
-		fmt.Println("📁 Profile configuration saved successfully")
+		theme.Println("📁 Profile configuration saved successfully")
 		return nil
 	},
 }
@@ -419,6 +664,10 @@ func init() {
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configMigrateCmd)
+	configCmd.AddCommand(configDoctorCmd)
+	configCmd.AddCommand(configTokenInfoCmd)
 	configCmd.AddCommand(configProfileCmd)
 
 	// Add profile subcommands
@@ -432,10 +681,21 @@ func init() {
 	configSetCmd.Flags().String("output", "", "Set default output format (json, yaml, table)")
 	configSetCmd.Flags().String("log-level", "", "Set log level (debug, info, warn, error)")
 	configSetCmd.Flags().String("color", "", "Set color output (auto, always, never)")
+	configSetCmd.Flags().Bool("confirm-by-name", false, "Require typing the resource name to confirm deletes")
+	configSetCmd.Flags().String("update-channel", "", "Release channel for updates (stable, beta)")
+	configSetCmd.Flags().Bool("update-check", true, "Check for new versions once a day and print an upgrade hint")
+	configSetCmd.Flags().Bool("history", false, "Record the commands run for 'coolifyme history'")
 
 	// Flags for config show command
 	configShowCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
 
+	// Flags for config validate and doctor commands
+	configValidateCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+	configDoctorCmd.Flags().Bool("offline", false, "Skip connectivity checks")
+
+	// Flags for config token-info command
+	configTokenInfoCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+
 	// Flags for config init command
 	configInitCmd.Flags().Bool("force", false, "Force reinitialize existing configuration")
 
@@ -448,7 +708,7 @@ func init() {
 	_ = configProfileCreateCmd.MarkFlagRequired("token")
 
 	// Flags for profile delete command
-	configProfileDeleteCmd.Flags().Bool("force", false, "Force delete without confirmation")
+	addConfirmFlags(configProfileDeleteCmd, "Force delete without confirmation")
 
 	// Flags for profile set command
 	configProfileSetCmd.Flags().String("token", "", "Update API token")
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// configRotateTokenCmd represents the config rotate-token command
+var configRotateTokenCmd = &cobra.Command{
+	Use:   "rotate-token",
+	Short: "Replace the API token of the active profile",
+	Long: `Rotate the API token of the active profile with as few manual steps as possible:
+
+  1. the new token is checked with a test request
+  2. its team and permissions are compared with the current token's
+  3. the active profile is updated to use it
+  4. the old token is shown so it can be revoked
+
+Coolify's API cannot create or revoke API tokens, so create the new token in the Coolify UI
+(Keys & Tokens > API tokens) first and revoke the old one there afterwards; the command prints
+the page to open. The new token is read from --token, the COOLIFYME_NEW_API_TOKEN environment
+variable, or prompted for without echoing it.
+
+The rotation is refused when the new token belongs to another team or lacks permissions the old
+token had, unless --force is given.
+
+Examples:
+  coolifyme config rotate-token
+  coolifyme config rotate-token --profile production
+  COOLIFYME_NEW_API_TOKEN=... coolifyme config rotate-token`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		for _, name := range []string{"COOLIFYME_API_TOKEN", "COOLIFY_API_TOKEN"} {
+			if os.Getenv(name) != "" {
+				return fmt.Errorf("the API token is set by the %s environment variable, update it there instead", name)
+			}
+		}
+
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+		if profile != "" && profile != cfg.Profile {
+			p, err := config.LoadProfile(profile)
+			if err != nil {
+				return fmt.Errorf("failed to load profile '%s': %w", profile, err)
+			}
+			cfg.Profile, cfg.APIToken, cfg.BaseURL = profile, p.APIToken, p.BaseURL
+		}
+		if cfg.BaseURL == "" {
+			return fmt.Errorf("profile '%s' has no base URL", cfg.Profile)
+		}
+
+		newToken, _ := cmd.Flags().GetString("token")
+		if newToken == "" {
+			newToken = os.Getenv("COOLIFYME_NEW_API_TOKEN")
+		}
+		if newToken == "" {
+			if newToken, err = promptPassphrase("New API token: "); err != nil {
+				return err
+			}
+		}
+		newToken = strings.TrimSpace(newToken)
+		if newToken == "" {
+			return fmt.Errorf("the new API token cannot be empty")
+		}
+		if newToken == cfg.APIToken {
+			return fmt.Errorf("the new API token is the one the profile already uses")
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		// Step 1: the new token must work
+		theme.Printf("🔑 Checking the new token against %s...\n", cfg.BaseURL)
+		newInfo, err := probeToken(ctx, cfg, newToken)
+		if err != nil {
+			return fmt.Errorf("the new token does not work: %w", err)
+		}
+		theme.Printf("   ✅ Accepted%s\n", tokenTeamSuffix(newInfo))
+
+		// Step 2: it must not lose access the old token had
+		if cfg.APIToken == "" {
+			theme.Println("   ⚠️  The profile has no token yet, skipping the permission comparison")
+		} else if oldInfo, err := probeToken(ctx, cfg, cfg.APIToken); err != nil {
+			warn("token rotation", fmt.Errorf("could not check the current token, skipping the permission comparison: %w", err))
+		} else {
+			problems := compareTokens(oldInfo, newInfo)
+			for _, problem := range problems {
+				theme.Printf("   ❌ %s\n", problem)
+			}
+			if len(problems) > 0 && !force {
+				return fmt.Errorf("the new token is not equivalent to the current one, use --force to rotate anyway")
+			}
+			if len(problems) == 0 {
+				theme.Println("   ✅ Same team and permissions as the current token")
+			}
+		}
+
+		// Step 3: switch the profile over
+		oldToken := cfg.APIToken
+		cfg.APIToken = newToken
+		if err := config.SaveConfig(cfg); err != nil {
+			return fmt.Errorf("failed to save configuration: %w", err)
+		}
+		theme.Printf("✅ Profile '%s' now uses the new token\n", cfg.Profile)
+
+		// Step 4: the old token can only be revoked in the UI
+		if oldToken != "" {
+			theme.Printf("💡 Revoke the old token (%s...) at %s\n", oldToken[:minInt(8, len(oldToken))], apiTokensPage(cfg.BaseURL))
+		}
+		return nil
+	},
+}
+
+// probeToken verifies that a token is accepted and determines its team and abilities
+func probeToken(ctx context.Context, cfg *config.Config, token string) (*client.TokenInfo, error) {
+	c, err := tokenClient(ctx, cfg, token)
+	if err != nil {
+		return nil, err
+	}
+	return c.TokenInfo(ctx)
+}
+
+// tokenClient returns a client for a token of the instance of cfg after checking that the
+// server accepts the token
+func tokenClient(ctx context.Context, cfg *config.Config, token string) (*client.Client, error) {
+	c, err := client.New(&config.Config{APIToken: token, BaseURL: cfg.BaseURL, Profile: cfg.Profile, Headers: cfg.Headers}, client.WithUserAgent("coolifyme/"+Version))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.System().Version(ctx); err != nil {
+		if strings.Contains(err.Error(), "401") || client.IsPermissionError(err) {
+			return nil, fmt.Errorf("token rejected by the server")
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+// compareTokens lists the ways a new token grants less than the old one
+func compareTokens(oldInfo, newInfo *client.TokenInfo) []string {
+	var problems []string
+	if oldInfo.TeamID != newInfo.TeamID {
+		problems = append(problems, fmt.Sprintf("the new token belongs to team %s, the current one to team %s",
+			teamLabel(newInfo), teamLabel(oldInfo)))
+	}
+
+	granted := make(map[string]bool)
+	for _, check := range newInfo.Abilities {
+		if check.Granted != nil && *check.Granted {
+			granted[check.Ability] = true
+		}
+	}
+	for _, check := range oldInfo.Abilities {
+		if check.Granted != nil && *check.Granted && !granted[check.Ability] {
+			problems = append(problems, fmt.Sprintf("the new token lacks the %s permission", check.Ability))
+		}
+	}
+	return problems
+}
+
+// teamLabel names the team of a token, falling back to its ID
+func teamLabel(info *client.TokenInfo) string {
+	if info.TeamName != "" {
+		return fmt.Sprintf("'%s'", info.TeamName)
+	}
+	return fmt.Sprintf("ID %d", info.TeamID)
+}
+
+// tokenTeamSuffix describes the team of a token for the progress output
+func tokenTeamSuffix(info *client.TokenInfo) string {
+	if info.TeamName == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (team %s)", info.TeamName)
+}
+
+// apiTokensPage returns the Coolify UI page listing API tokens for an API base URL
+func apiTokensPage(apiBaseURL string) string {
+	base := strings.TrimRight(apiBaseURL, "/")
+	base = strings.TrimSuffix(base, "/api/v1")
+	base = strings.TrimSuffix(base, "/api")
+	return base + "/security/api-tokens"
+}
+
+func init() {
+	configCmd.AddCommand(configRotateTokenCmd)
+
+	// Flags for config rotate-token command
+	configRotateTokenCmd.Flags().String("token", "", "New API token (prompted for when not set)")
+	configRotateTokenCmd.Flags().BoolP("force", "f", false, "Rotate even when the new token has another team or fewer permissions")
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// pendingCreateMaxAge is how long an unfinished create is remembered
+const pendingCreateMaxAge = 7 * 24 * time.Hour
+
+// pendingCreate is a create request whose outcome is not known yet. Entries are removed when the
+// request completes, so an entry left behind means the request failed without a response.
+type pendingCreate struct {
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	Key       string    `json:"idempotency_key"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// createGuard sends a create request with an idempotency key and journals it, to warn about
+// possible duplicates when a create failed after a network error or timeout
+type createGuard struct {
+	entry pendingCreate
+}
+
+// beginCreate journals a create request for a resource of the given kind, whose name is taken
+// from the request body, and returns the context to send it with. It warns when an earlier create
+// of the same resource ended without a response and a resource with that name exists.
+func beginCreate(ctx context.Context, client clientpkg.API, kind string, req any) (context.Context, *createGuard) {
+	guard := &createGuard{entry: pendingCreate{
+		Kind:      kind,
+		Name:      requestName(req),
+		Key:       clientpkg.NewIdempotencyKey(),
+		StartedAt: time.Now(),
+	}}
+
+	entries := loadPendingCreates()
+	kept := entries[:0]
+	for _, entry := range entries {
+		if entry.Kind != kind || entry.Name != guard.entry.Name {
+			kept = append(kept, entry)
+			continue
+		}
+		if guard.entry.Name == "" {
+			continue
+		}
+		if count, err := countResourcesByName(ctx, client, kind, guard.entry.Name); err == nil && count > 0 {
+			warn("create "+string(kind), fmt.Errorf("an earlier create of %s '%s' at %s got no response, and %d %s(s) with that name exist; this may create a duplicate",
+				kind, entry.Name, entry.StartedAt.Local().Format(time.DateTime), count, kind))
+		}
+	}
+	savePendingCreates(append(kept, guard.entry))
+
+	return clientpkg.WithIdempotencyKey(ctx, guard.entry.Key), guard
+}
+
+// finish records the outcome of the create request. Requests that failed without a response stay
+// in the journal, so the next create of the resource can warn about it.
+func (g *createGuard) finish(err error) {
+	if err != nil && clientpkg.OutcomeUnknown(err) {
+		warn("create "+string(g.entry.Kind), fmt.Errorf("the %s may have been created although the request failed; check for '%s' before retrying", g.entry.Kind, g.entry.Name))
+		return
+	}
+
+	entries := loadPendingCreates()
+	kept := entries[:0]
+	for _, entry := range entries {
+		if entry.Key != g.entry.Key {
+			kept = append(kept, entry)
+		}
+	}
+	savePendingCreates(kept)
+}
+
+// addIfNotExistsFlag adds the flag making a create command idempotent for provisioning scripts
+func addIfNotExistsFlag(cmd *cobra.Command, kind clientpkg.ResourceKind) {
+	cmd.Flags().Bool("if-not-exists", false, fmt.Sprintf("Print the UUID of an existing %s with the same name instead of creating another one", kind))
+}
+
+// existingResource looks up a resource with the name of a create request when --if-not-exists
+// is given. It returns the UUID of the existing resource, or "" when there is none or the flag
+// is not set. Applications, services and databases are only looked up in the project and
+// environment of the request. Several resources with the name are an error, since it is unclear
+// which one the script means.
+func existingResource(ctx context.Context, cmd *cobra.Command, client *clientpkg.Client, kind clientpkg.ResourceKind, req any) (string, error) {
+	if ifNotExists, _ := cmd.Flags().GetBool("if-not-exists"); !ifNotExists {
+		return "", nil
+	}
+	name := requestName(req)
+	if strings.TrimSpace(name) == "" {
+		return "", fmt.Errorf("--if-not-exists requires a %s name", kind)
+	}
+
+	var refs []clientpkg.ResourceRef
+	var err error
+	switch kind {
+	case clientpkg.KindApplication, clientpkg.KindService, clientpkg.KindDatabase:
+		project, environment := requestEnvironment(req)
+		if project == "" || environment == "" {
+			return "", fmt.Errorf("--if-not-exists requires the project and environment of the %s", kind)
+		}
+		refs, err = client.FindByNameInEnvironment(ctx, kind, name, project, environment)
+	default:
+		refs, err = client.FindByName(ctx, kind, name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to look up existing %ss: %w", kind, err)
+	}
+	switch len(refs) {
+	case 0:
+		return "", nil
+	case 1:
+		return refs[0].UUID, nil
+	}
+	uuids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		uuids = append(uuids, ref.UUID)
+	}
+	return "", fmt.Errorf("%d %ss are named '%s' (%s), cannot tell which one to use", len(refs), kind, name, strings.Join(uuids, ", "))
+}
+
+// printExistingResource reports the resource found by --if-not-exists
+func printExistingResource(kind clientpkg.ResourceKind, name, uuid string) {
+	if printQuietUUID(uuid) {
+		return
+	}
+	theme.Statusf("✅ A %s named '%s' already exists, nothing was created\n", kind, name)
+	theme.Printf("   📦 UUID: %s\n", uuid)
+}
+
+// requestName returns the name field of a create request body
+func requestName(req any) string {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return ""
+	}
+	var fields struct {
+		Name string `json:"name"`
+	}
+	_ = json.Unmarshal(data, &fields)
+	return fields.Name
+}
+
+// requestEnvironment returns the project UUID and the environment UUID or name of a create
+// request body
+func requestEnvironment(req any) (project, environment string) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", ""
+	}
+	var fields struct {
+		ProjectUUID     string `json:"project_uuid"`
+		EnvironmentUUID string `json:"environment_uuid"`
+		EnvironmentName string `json:"environment_name"`
+	}
+	_ = json.Unmarshal(data, &fields)
+	if fields.EnvironmentUUID != "" {
+		return fields.ProjectUUID, fields.EnvironmentUUID
+	}
+	return fields.ProjectUUID, fields.EnvironmentName
+}
+
+// countResourcesByName returns the number of resources of a kind with the given name
+func countResourcesByName(ctx context.Context, client clientpkg.API, kind, name string) (int, error) {
+	var names []string
+	switch kind {
+	case "application":
+		apps, err := client.Applications().List(ctx)
+		if err != nil {
+			return 0, err
+		}
+		for _, app := range apps {
+			names = append(names, stringOrDash(app.Name))
+		}
+	case "database":
+		raw, err := client.Databases().List(ctx)
+		if err != nil {
+			return 0, err
+		}
+		databases, err := clientpkg.ParseDatabases(raw)
+		if err != nil {
+			return 0, err
+		}
+		for _, database := range databases {
+			names = append(names, database.Name)
+		}
+	case "project":
+		projects, err := client.Projects().List(ctx)
+		if err != nil {
+			return 0, err
+		}
+		for _, project := range projects {
+			names = append(names, stringOrDash(project.Name))
+		}
+	case "server":
+		servers, err := client.Servers().List(ctx)
+		if err != nil {
+			return 0, err
+		}
+		for _, server := range servers {
+			names = append(names, stringOrDash(server.Name))
+		}
+	case "service":
+		services, err := client.Services().List(ctx)
+		if err != nil {
+			return 0, err
+		}
+		for _, service := range services {
+			names = append(names, stringOrDash(service.Name))
+		}
+	case "private key":
+		keys, err := client.PrivateKeys().List(ctx)
+		if err != nil {
+			return 0, err
+		}
+		for _, key := range keys {
+			names = append(names, stringOrDash(key.Name))
+		}
+	}
+
+	count := 0
+	for _, existing := range names {
+		if strings.EqualFold(existing, name) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// pendingCreatesPath returns the file journaling create requests
+func pendingCreatesPath() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "pending-creates.json"), nil
+}
+
+// loadPendingCreates reads the journal, dropping entries too old to matter
+func loadPendingCreates() []pendingCreate {
+	path, err := pendingCreatesPath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path) // #nosec G304 - path is derived from the user's config directory
+	if err != nil {
+		return nil
+	}
+	var entries []pendingCreate
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil
+	}
+	recent := entries[:0]
+	for _, entry := range entries {
+		if time.Since(entry.StartedAt) < pendingCreateMaxAge {
+			recent = append(recent, entry)
+		}
+	}
+	return recent
+}
+
+// savePendingCreates writes the journal; the journal only produces warnings, so errors are ignored
+func savePendingCreates(entries []pendingCreate) {
+	path, err := pendingCreatesPath()
+	if err != nil {
+		return
+	}
+	if len(entries) == 0 {
+		_ = os.Remove(path)
+		return
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
@@ -3,8 +3,12 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
 	"github.com/spf13/cobra"
 )
 
@@ -44,9 +48,9 @@ var databasesListCmd = &cobra.Command{
 var databasesGetCmd = &cobra.Command{
 	Use:   "get <uuid>",
 	Short: "Get database details",
-	Long:  "Get detailed information about a specific database",
+	Long:  "Get detailed information about a specific database, including the type-specific user and database name",
 	Args:  cobra.ExactArgs(1),
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		client, err := createClient()
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
@@ -60,12 +64,108 @@ var databasesGetCmd = &cobra.Command{
 			return fmt.Errorf("failed to get database: %w", err)
 		}
 
-		// The database API currently returns a simple string
-		fmt.Printf("Database %s:\n%s\n", databaseUUID, result)
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		info, err := clientpkg.ParseDatabase(result)
+		if jsonOutput || err != nil {
+			// Fall back to the raw response when it cannot be decoded
+			fmt.Println(result)
+			return nil
+		}
+
+		fmt.Printf("Name:         %s\n", stringOrDash(&info.Name))
+		fmt.Printf("UUID:         %s\n", stringOrDash(&info.UUID))
+		fmt.Printf("Type:         %s\n", stringOrDash(&info.Type))
+		fmt.Printf("Status:       %s\n", stringOrDash(&info.Status))
+		fmt.Printf("Image:        %s\n", stringOrDash(&info.Image))
+		if info.User != "" {
+			fmt.Printf("User:         %s\n", info.User)
+		}
+		if info.Database != "" {
+			fmt.Printf("Database:     %s\n", info.Database)
+		}
+		if info.IsPublic {
+			fmt.Printf("Public port:  %d\n", info.PublicPort)
+		} else {
+			fmt.Printf("Public:       no\n")
+		}
+		if dsn, err := info.ConnectionString(false, ""); err == nil {
+			fmt.Printf("Internal URL: %s\n", maskDSNPassword(dsn))
+		}
+		return nil
+	},
+}
+
+// databasesConnectionStringCmd represents the databases connection-string command
+var databasesConnectionStringCmd = &cobra.Command{
+	Use:     "connection-string <uuid>",
+	Aliases: []string{"dsn", "url"},
+	Short:   "Print a ready-to-use connection string",
+	Long: `Print a connection string (postgres://, mysql://, redis://, mongodb://, clickhouse://) for a
+database, including its credentials.
+
+By default the internal URL is printed, which uses the container name and works from other
+resources on the same Coolify network. With --public the server address and public port are used;
+the database must be made public in Coolify first.
+
+Examples:
+  coolifyme databases connection-string <uuid>
+  coolifyme databases connection-string <uuid> --public
+  coolifyme databases connection-string <uuid> --format env >> .env`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		public, _ := cmd.Flags().GetBool("public")
+		format, _ := cmd.Flags().GetString("format")
+		variable, _ := cmd.Flags().GetString("var")
+		if format != "url" && format != "env" {
+			return fmt.Errorf("invalid format '%s' (expected url or env)", format)
+		}
+
+		result, err := client.Databases().Get(context.Background(), args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get database: %w", err)
+		}
+
+		info, err := clientpkg.ParseDatabase(result)
+		if err != nil {
+			return err
+		}
+
+		var fallbackHost string
+		if parsed, err := url.Parse(client.BaseURL()); err == nil {
+			fallbackHost = parsed.Hostname()
+		}
+
+		dsn, err := info.ConnectionString(public, fallbackHost)
+		if err != nil {
+			return err
+		}
+
+		if format == "env" {
+			fmt.Printf("%s=%s\n", variable, dsn)
+			return nil
+		}
+		fmt.Println(dsn)
 		return nil
 	},
 }
 
+// maskDSNPassword hides the password of a connection string for display
+func maskDSNPassword(dsn string) string {
+	parsed, err := url.Parse(dsn)
+	if err != nil || parsed.User == nil {
+		return dsn
+	}
+	if _, ok := parsed.User.Password(); ok {
+		parsed.User = url.UserPassword(parsed.User.Username(), "xxxxx")
+	}
+	return parsed.String()
+}
+
 // databasesStartCmd represents the databases start command
 var databasesStartCmd = &cobra.Command{
 	Use:   "start <uuid>",
@@ -86,7 +186,7 @@ var databasesStartCmd = &cobra.Command{
 			return fmt.Errorf("failed to start database: %w", err)
 		}
 
-		fmt.Printf("✅ Database %s start request queued successfully\n", databaseUUID)
+		theme.Printf("✅ Database %s start request queued successfully\n", databaseUUID)
 		return nil
 	},
 }
@@ -111,7 +211,7 @@ var databasesStopCmd = &cobra.Command{
 			return fmt.Errorf("failed to stop database: %w", err)
 		}
 
-		fmt.Printf("✅ Database %s stop request queued successfully\n", databaseUUID)
+		theme.Printf("✅ Database %s stop request queued successfully\n", databaseUUID)
 		return nil
 	},
 }
@@ -136,7 +236,7 @@ var databasesRestartCmd = &cobra.Command{
 			return fmt.Errorf("failed to restart database: %w", err)
 		}
 
-		fmt.Printf("✅ Database %s restart request queued successfully\n", databaseUUID)
+		theme.Printf("✅ Database %s restart request queued successfully\n", databaseUUID)
 		return nil
 	},
 }
@@ -152,10 +252,19 @@ var databasesDeleteCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
+		if err := checkTokenAbility(cmd, client, clientpkg.AbilityWrite); err != nil {
+			return err
+		}
 
 		deleteVolumes, _ := cmd.Flags().GetBool("delete-volumes")
 		deleteConfigs, _ := cmd.Flags().GetBool("delete-configurations")
 
+		target := confirm.Target{Kind: "database", ID: args[0], Dependents: deleteDependents(deleteVolumes, deleteConfigs)}
+		if !confirm.Delete(target, skipConfirmation(cmd)) {
+			theme.Println("❌ Deletion cancelled")
+			return nil
+		}
+
 		options := &coolify.DeleteDatabaseByUuidParams{
 			DeleteVolumes:        &deleteVolumes,
 			DeleteConfigurations: &deleteConfigs,
@@ -175,17 +284,28 @@ var databasesDeleteCmd = &cobra.Command{
 var databasesUpdateCmd = &cobra.Command{
 	Use:   "update <uuid>",
 	Short: "Update a database",
-	Long:  "Update a database by UUID",
-	Args:  cobra.ExactArgs(1),
-	RunE: func(_ *cobra.Command, args []string) error {
+	Long: `Update a database by UUID with the fields read from a YAML or JSON file. Field names are
+those of the Coolify API, and only the fields in the file are changed.
+
+Examples:
+  coolifyme databases update <uuid> --from-file database.yaml
+  echo 'limits_memory: 1g' | coolifyme databases update <uuid> --from-file -`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req := coolify.UpdateDatabaseByUuidJSONRequestBody{}
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
+		}
+		if !fromFile {
+			return fmt.Errorf("nothing to update, pass the fields to change with --from-file")
+		}
+
 		client, err := createClient()
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		// For now, this is a stub - you'd need to implement fields to update
-		req := coolify.UpdateDatabaseByUuidJSONRequestBody{}
-
 		err = client.Databases().Update(context.Background(), args[0], req)
 		if err != nil {
 			return fmt.Errorf("failed to update database: %w", err)
@@ -214,49 +334,65 @@ var databasesCreatePostgreSQLCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		// Get required parameters
-		envName, _ := cmd.Flags().GetString("environment")
-		envUUID, _ := cmd.Flags().GetString("environment-uuid")
-		projectUUID, _ := cmd.Flags().GetString("project")
-		serverUUID, _ := cmd.Flags().GetString("server")
-
-		if envName == "" && envUUID == "" {
-			return fmt.Errorf("either --environment or --environment-uuid is required")
-		}
-		if projectUUID == "" {
-			return fmt.Errorf("--project is required")
-		}
-		if serverUUID == "" {
-			return fmt.Errorf("--server is required")
-		}
-
-		req := coolify.CreateDatabasePostgresqlJSONRequestBody{
-			EnvironmentName: envName,
-			EnvironmentUuid: envUUID,
-			ProjectUuid:     projectUUID,
-			ServerUuid:      serverUUID,
+		req := coolify.CreateDatabasePostgresqlJSONRequestBody{}
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
+		}
+		if !fromFile {
+			// Get required parameters
+			envName, _ := cmd.Flags().GetString("environment")
+			envUUID, _ := cmd.Flags().GetString("environment-uuid")
+			projectUUID, _ := cmd.Flags().GetString("project")
+			serverUUID, _ := cmd.Flags().GetString("server")
+
+			if envName == "" && envUUID == "" {
+				return fmt.Errorf("either --environment or --environment-uuid is required")
+			}
+			if projectUUID == "" {
+				return fmt.Errorf("--project is required")
+			}
+			if serverUUID == "" {
+				return fmt.Errorf("--server is required")
+			}
+
+			req = coolify.CreateDatabasePostgresqlJSONRequestBody{
+				EnvironmentName: envName,
+				EnvironmentUuid: envUUID,
+				ProjectUuid:     projectUUID,
+				ServerUuid:      serverUUID,
+			}
+
+			// Optional parameters
+			if name, _ := cmd.Flags().GetString("name"); name != "" {
+				req.Name = &name
+			}
+			if desc, _ := cmd.Flags().GetString("description"); desc != "" {
+				req.Description = &desc
+			}
+			if image, _ := cmd.Flags().GetString("image"); image != "" {
+				req.Image = &image
+			}
+			if instant, _ := cmd.Flags().GetBool("instant-deploy"); instant {
+				req.InstantDeploy = &instant
+			}
+		}
+
+		ctx, guard := beginCreate(context.Background(), client, "database", req)
+		dbUUID, err := client.Databases().CreatePostgreSQL(ctx, req)
+		guard.finish(err)
+		if err != nil {
+			return fmt.Errorf("failed to create PostgreSQL database: %w", err)
 		}
 
-		// Optional parameters
-		if name, _ := cmd.Flags().GetString("name"); name != "" {
-			req.Name = &name
-		}
-		if desc, _ := cmd.Flags().GetString("description"); desc != "" {
-			req.Description = &desc
-		}
-		if image, _ := cmd.Flags().GetString("image"); image != "" {
-			req.Image = &image
-		}
-		if instant, _ := cmd.Flags().GetBool("instant-deploy"); instant {
-			req.InstantDeploy = &instant
+		if printQuietUUID(dbUUID) {
+			return nil
 		}
 
-		err = client.Databases().CreatePostgreSQL(context.Background(), req)
-		if err != nil {
-			return fmt.Errorf("failed to create PostgreSQL database: %w", err)
+		theme.Statusln("PostgreSQL database created successfully")
+		if dbUUID != "" {
+			theme.Printf("   📦 UUID: %s\n", dbUUID)
 		}
-
-		fmt.Println("PostgreSQL database created successfully")
 		return nil
 	},
 }
@@ -272,49 +408,65 @@ var databasesCreateMySQLCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		// Get required parameters
-		envName, _ := cmd.Flags().GetString("environment")
-		envUUID, _ := cmd.Flags().GetString("environment-uuid")
-		projectUUID, _ := cmd.Flags().GetString("project")
-		serverUUID, _ := cmd.Flags().GetString("server")
-
-		if envName == "" && envUUID == "" {
-			return fmt.Errorf("either --environment or --environment-uuid is required")
-		}
-		if projectUUID == "" {
-			return fmt.Errorf("--project is required")
-		}
-		if serverUUID == "" {
-			return fmt.Errorf("--server is required")
-		}
-
-		req := coolify.CreateDatabaseMysqlJSONRequestBody{
-			EnvironmentName: envName,
-			EnvironmentUuid: envUUID,
-			ProjectUuid:     projectUUID,
-			ServerUuid:      serverUUID,
+		req := coolify.CreateDatabaseMysqlJSONRequestBody{}
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
+		}
+		if !fromFile {
+			// Get required parameters
+			envName, _ := cmd.Flags().GetString("environment")
+			envUUID, _ := cmd.Flags().GetString("environment-uuid")
+			projectUUID, _ := cmd.Flags().GetString("project")
+			serverUUID, _ := cmd.Flags().GetString("server")
+
+			if envName == "" && envUUID == "" {
+				return fmt.Errorf("either --environment or --environment-uuid is required")
+			}
+			if projectUUID == "" {
+				return fmt.Errorf("--project is required")
+			}
+			if serverUUID == "" {
+				return fmt.Errorf("--server is required")
+			}
+
+			req = coolify.CreateDatabaseMysqlJSONRequestBody{
+				EnvironmentName: envName,
+				EnvironmentUuid: envUUID,
+				ProjectUuid:     projectUUID,
+				ServerUuid:      serverUUID,
+			}
+
+			// Optional parameters
+			if name, _ := cmd.Flags().GetString("name"); name != "" {
+				req.Name = &name
+			}
+			if desc, _ := cmd.Flags().GetString("description"); desc != "" {
+				req.Description = &desc
+			}
+			if image, _ := cmd.Flags().GetString("image"); image != "" {
+				req.Image = &image
+			}
+			if instant, _ := cmd.Flags().GetBool("instant-deploy"); instant {
+				req.InstantDeploy = &instant
+			}
+		}
+
+		ctx, guard := beginCreate(context.Background(), client, "database", req)
+		dbUUID, err := client.Databases().CreateMySQL(ctx, req)
+		guard.finish(err)
+		if err != nil {
+			return fmt.Errorf("failed to create MySQL database: %w", err)
 		}
 
-		// Optional parameters
-		if name, _ := cmd.Flags().GetString("name"); name != "" {
-			req.Name = &name
-		}
-		if desc, _ := cmd.Flags().GetString("description"); desc != "" {
-			req.Description = &desc
-		}
-		if image, _ := cmd.Flags().GetString("image"); image != "" {
-			req.Image = &image
-		}
-		if instant, _ := cmd.Flags().GetBool("instant-deploy"); instant {
-			req.InstantDeploy = &instant
+		if printQuietUUID(dbUUID) {
+			return nil
 		}
 
-		err = client.Databases().CreateMySQL(context.Background(), req)
-		if err != nil {
-			return fmt.Errorf("failed to create MySQL database: %w", err)
+		theme.Statusln("MySQL database created successfully")
+		if dbUUID != "" {
+			theme.Printf("   📦 UUID: %s\n", dbUUID)
 		}
-
-		fmt.Println("MySQL database created successfully")
 		return nil
 	},
 }
@@ -330,49 +482,65 @@ var databasesCreateRedisCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		// Get required parameters
-		envName, _ := cmd.Flags().GetString("environment")
-		envUUID, _ := cmd.Flags().GetString("environment-uuid")
-		projectUUID, _ := cmd.Flags().GetString("project")
-		serverUUID, _ := cmd.Flags().GetString("server")
-
-		if envName == "" && envUUID == "" {
-			return fmt.Errorf("either --environment or --environment-uuid is required")
-		}
-		if projectUUID == "" {
-			return fmt.Errorf("--project is required")
-		}
-		if serverUUID == "" {
-			return fmt.Errorf("--server is required")
-		}
-
-		req := coolify.CreateDatabaseRedisJSONRequestBody{
-			EnvironmentName: envName,
-			EnvironmentUuid: envUUID,
-			ProjectUuid:     projectUUID,
-			ServerUuid:      serverUUID,
+		req := coolify.CreateDatabaseRedisJSONRequestBody{}
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
+		}
+		if !fromFile {
+			// Get required parameters
+			envName, _ := cmd.Flags().GetString("environment")
+			envUUID, _ := cmd.Flags().GetString("environment-uuid")
+			projectUUID, _ := cmd.Flags().GetString("project")
+			serverUUID, _ := cmd.Flags().GetString("server")
+
+			if envName == "" && envUUID == "" {
+				return fmt.Errorf("either --environment or --environment-uuid is required")
+			}
+			if projectUUID == "" {
+				return fmt.Errorf("--project is required")
+			}
+			if serverUUID == "" {
+				return fmt.Errorf("--server is required")
+			}
+
+			req = coolify.CreateDatabaseRedisJSONRequestBody{
+				EnvironmentName: envName,
+				EnvironmentUuid: envUUID,
+				ProjectUuid:     projectUUID,
+				ServerUuid:      serverUUID,
+			}
+
+			// Optional parameters
+			if name, _ := cmd.Flags().GetString("name"); name != "" {
+				req.Name = &name
+			}
+			if desc, _ := cmd.Flags().GetString("description"); desc != "" {
+				req.Description = &desc
+			}
+			if image, _ := cmd.Flags().GetString("image"); image != "" {
+				req.Image = &image
+			}
+			if instant, _ := cmd.Flags().GetBool("instant-deploy"); instant {
+				req.InstantDeploy = &instant
+			}
+		}
+
+		ctx, guard := beginCreate(context.Background(), client, "database", req)
+		dbUUID, err := client.Databases().CreateRedis(ctx, req)
+		guard.finish(err)
+		if err != nil {
+			return fmt.Errorf("failed to create Redis database: %w", err)
 		}
 
-		// Optional parameters
-		if name, _ := cmd.Flags().GetString("name"); name != "" {
-			req.Name = &name
-		}
-		if desc, _ := cmd.Flags().GetString("description"); desc != "" {
-			req.Description = &desc
-		}
-		if image, _ := cmd.Flags().GetString("image"); image != "" {
-			req.Image = &image
-		}
-		if instant, _ := cmd.Flags().GetBool("instant-deploy"); instant {
-			req.InstantDeploy = &instant
+		if printQuietUUID(dbUUID) {
+			return nil
 		}
 
-		err = client.Databases().CreateRedis(context.Background(), req)
-		if err != nil {
-			return fmt.Errorf("failed to create Redis database: %w", err)
+		theme.Statusln("Redis database created successfully")
+		if dbUUID != "" {
+			theme.Printf("   📦 UUID: %s\n", dbUUID)
 		}
-
-		fmt.Println("Redis database created successfully")
 		return nil
 	},
 }
@@ -388,49 +556,65 @@ var databasesCreateMongoDBCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		// Get required parameters
-		envName, _ := cmd.Flags().GetString("environment")
-		envUUID, _ := cmd.Flags().GetString("environment-uuid")
-		projectUUID, _ := cmd.Flags().GetString("project")
-		serverUUID, _ := cmd.Flags().GetString("server")
-
-		if envName == "" && envUUID == "" {
-			return fmt.Errorf("either --environment or --environment-uuid is required")
-		}
-		if projectUUID == "" {
-			return fmt.Errorf("--project is required")
-		}
-		if serverUUID == "" {
-			return fmt.Errorf("--server is required")
+		req := coolify.CreateDatabaseMongodbJSONRequestBody{}
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
+		}
+		if !fromFile {
+			// Get required parameters
+			envName, _ := cmd.Flags().GetString("environment")
+			envUUID, _ := cmd.Flags().GetString("environment-uuid")
+			projectUUID, _ := cmd.Flags().GetString("project")
+			serverUUID, _ := cmd.Flags().GetString("server")
+
+			if envName == "" && envUUID == "" {
+				return fmt.Errorf("either --environment or --environment-uuid is required")
+			}
+			if projectUUID == "" {
+				return fmt.Errorf("--project is required")
+			}
+			if serverUUID == "" {
+				return fmt.Errorf("--server is required")
+			}
+
+			req = coolify.CreateDatabaseMongodbJSONRequestBody{
+				EnvironmentName: envName,
+				EnvironmentUuid: envUUID,
+				ProjectUuid:     projectUUID,
+				ServerUuid:      serverUUID,
+			}
+
+			// Optional parameters
+			if name, _ := cmd.Flags().GetString("name"); name != "" {
+				req.Name = &name
+			}
+			if desc, _ := cmd.Flags().GetString("description"); desc != "" {
+				req.Description = &desc
+			}
+			if image, _ := cmd.Flags().GetString("image"); image != "" {
+				req.Image = &image
+			}
+			if instant, _ := cmd.Flags().GetBool("instant-deploy"); instant {
+				req.InstantDeploy = &instant
+			}
+		}
+
+		ctx, guard := beginCreate(context.Background(), client, "database", req)
+		dbUUID, err := client.Databases().CreateMongoDB(ctx, req)
+		guard.finish(err)
+		if err != nil {
+			return fmt.Errorf("failed to create MongoDB database: %w", err)
 		}
 
-		req := coolify.CreateDatabaseMongodbJSONRequestBody{
-			EnvironmentName: envName,
-			EnvironmentUuid: envUUID,
-			ProjectUuid:     projectUUID,
-			ServerUuid:      serverUUID,
+		if printQuietUUID(dbUUID) {
+			return nil
 		}
 
-		// Optional parameters
-		if name, _ := cmd.Flags().GetString("name"); name != "" {
-			req.Name = &name
-		}
-		if desc, _ := cmd.Flags().GetString("description"); desc != "" {
-			req.Description = &desc
+		theme.Statusln("MongoDB database created successfully")
+		if dbUUID != "" {
+			theme.Printf("   📦 UUID: %s\n", dbUUID)
 		}
-		if image, _ := cmd.Flags().GetString("image"); image != "" {
-			req.Image = &image
-		}
-		if instant, _ := cmd.Flags().GetBool("instant-deploy"); instant {
-			req.InstantDeploy = &instant
-		}
-
-		err = client.Databases().CreateMongoDB(context.Background(), req)
-		if err != nil {
-			return fmt.Errorf("failed to create MongoDB database: %w", err)
-		}
-
-		fmt.Println("MongoDB database created successfully")
 		return nil
 	},
 }
@@ -446,55 +630,71 @@ var databasesCreateClickHouseCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		// Get required parameters
-		envName, _ := cmd.Flags().GetString("environment")
-		envUUID, _ := cmd.Flags().GetString("environment-uuid")
-		projectUUID, _ := cmd.Flags().GetString("project")
-		serverUUID, _ := cmd.Flags().GetString("server")
-
-		if envName == "" && envUUID == "" {
-			return fmt.Errorf("either --environment or --environment-uuid is required")
-		}
-		if projectUUID == "" {
-			return fmt.Errorf("--project is required")
-		}
-		if serverUUID == "" {
-			return fmt.Errorf("--server is required")
-		}
-
-		req := coolify.CreateDatabaseClickhouseJSONRequestBody{
-			EnvironmentName: envName,
-			EnvironmentUuid: envUUID,
-			ProjectUuid:     projectUUID,
-			ServerUuid:      serverUUID,
+		req := coolify.CreateDatabaseClickhouseJSONRequestBody{}
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
+		}
+		if !fromFile {
+			// Get required parameters
+			envName, _ := cmd.Flags().GetString("environment")
+			envUUID, _ := cmd.Flags().GetString("environment-uuid")
+			projectUUID, _ := cmd.Flags().GetString("project")
+			serverUUID, _ := cmd.Flags().GetString("server")
+
+			if envName == "" && envUUID == "" {
+				return fmt.Errorf("either --environment or --environment-uuid is required")
+			}
+			if projectUUID == "" {
+				return fmt.Errorf("--project is required")
+			}
+			if serverUUID == "" {
+				return fmt.Errorf("--server is required")
+			}
+
+			req = coolify.CreateDatabaseClickhouseJSONRequestBody{
+				EnvironmentName: envName,
+				EnvironmentUuid: envUUID,
+				ProjectUuid:     projectUUID,
+				ServerUuid:      serverUUID,
+			}
+
+			// Optional parameters
+			if name, _ := cmd.Flags().GetString("name"); name != "" {
+				req.Name = &name
+			}
+			if desc, _ := cmd.Flags().GetString("description"); desc != "" {
+				req.Description = &desc
+			}
+			if image, _ := cmd.Flags().GetString("image"); image != "" {
+				req.Image = &image
+			}
+			if instant, _ := cmd.Flags().GetBool("instant-deploy"); instant {
+				req.InstantDeploy = &instant
+			}
+			if adminUser, _ := cmd.Flags().GetString("admin-user"); adminUser != "" {
+				req.ClickhouseAdminUser = &adminUser
+			}
+			if adminPassword, _ := cmd.Flags().GetString("admin-password"); adminPassword != "" {
+				req.ClickhouseAdminPassword = &adminPassword
+			}
+		}
+
+		ctx, guard := beginCreate(context.Background(), client, "database", req)
+		dbUUID, err := client.Databases().CreateClickHouse(ctx, req)
+		guard.finish(err)
+		if err != nil {
+			return fmt.Errorf("failed to create ClickHouse database: %w", err)
 		}
 
-		// Optional parameters
-		if name, _ := cmd.Flags().GetString("name"); name != "" {
-			req.Name = &name
-		}
-		if desc, _ := cmd.Flags().GetString("description"); desc != "" {
-			req.Description = &desc
-		}
-		if image, _ := cmd.Flags().GetString("image"); image != "" {
-			req.Image = &image
-		}
-		if instant, _ := cmd.Flags().GetBool("instant-deploy"); instant {
-			req.InstantDeploy = &instant
-		}
-		if adminUser, _ := cmd.Flags().GetString("admin-user"); adminUser != "" {
-			req.ClickhouseAdminUser = &adminUser
-		}
-		if adminPassword, _ := cmd.Flags().GetString("admin-password"); adminPassword != "" {
-			req.ClickhouseAdminPassword = &adminPassword
+		if printQuietUUID(dbUUID) {
+			return nil
 		}
 
-		err = client.Databases().CreateClickHouse(context.Background(), req)
-		if err != nil {
-			return fmt.Errorf("failed to create ClickHouse database: %w", err)
+		theme.Statusln("✅ ClickHouse database created successfully")
+		if dbUUID != "" {
+			theme.Printf("   📦 UUID: %s\n", dbUUID)
 		}
-
-		fmt.Println("✅ ClickHouse database created successfully")
 		return nil
 	},
 }
@@ -510,52 +710,68 @@ var databasesCreateDragonflyCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		// Get required parameters
-		envName, _ := cmd.Flags().GetString("environment")
-		envUUID, _ := cmd.Flags().GetString("environment-uuid")
-		projectUUID, _ := cmd.Flags().GetString("project")
-		serverUUID, _ := cmd.Flags().GetString("server")
-
-		if envName == "" && envUUID == "" {
-			return fmt.Errorf("either --environment or --environment-uuid is required")
-		}
-		if projectUUID == "" {
-			return fmt.Errorf("--project is required")
-		}
-		if serverUUID == "" {
-			return fmt.Errorf("--server is required")
+		req := coolify.CreateDatabaseDragonflyJSONRequestBody{}
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
+		}
+		if !fromFile {
+			// Get required parameters
+			envName, _ := cmd.Flags().GetString("environment")
+			envUUID, _ := cmd.Flags().GetString("environment-uuid")
+			projectUUID, _ := cmd.Flags().GetString("project")
+			serverUUID, _ := cmd.Flags().GetString("server")
+
+			if envName == "" && envUUID == "" {
+				return fmt.Errorf("either --environment or --environment-uuid is required")
+			}
+			if projectUUID == "" {
+				return fmt.Errorf("--project is required")
+			}
+			if serverUUID == "" {
+				return fmt.Errorf("--server is required")
+			}
+
+			req = coolify.CreateDatabaseDragonflyJSONRequestBody{
+				EnvironmentName: envName,
+				EnvironmentUuid: envUUID,
+				ProjectUuid:     projectUUID,
+				ServerUuid:      serverUUID,
+			}
+
+			// Optional parameters
+			if name, _ := cmd.Flags().GetString("name"); name != "" {
+				req.Name = &name
+			}
+			if desc, _ := cmd.Flags().GetString("description"); desc != "" {
+				req.Description = &desc
+			}
+			if image, _ := cmd.Flags().GetString("image"); image != "" {
+				req.Image = &image
+			}
+			if instant, _ := cmd.Flags().GetBool("instant-deploy"); instant {
+				req.InstantDeploy = &instant
+			}
+			if password, _ := cmd.Flags().GetString("password"); password != "" {
+				req.DragonflyPassword = &password
+			}
+		}
+
+		ctx, guard := beginCreate(context.Background(), client, "database", req)
+		dbUUID, err := client.Databases().CreateDragonfly(ctx, req)
+		guard.finish(err)
+		if err != nil {
+			return fmt.Errorf("failed to create Dragonfly database: %w", err)
 		}
 
-		req := coolify.CreateDatabaseDragonflyJSONRequestBody{
-			EnvironmentName: envName,
-			EnvironmentUuid: envUUID,
-			ProjectUuid:     projectUUID,
-			ServerUuid:      serverUUID,
+		if printQuietUUID(dbUUID) {
+			return nil
 		}
 
-		// Optional parameters
-		if name, _ := cmd.Flags().GetString("name"); name != "" {
-			req.Name = &name
-		}
-		if desc, _ := cmd.Flags().GetString("description"); desc != "" {
-			req.Description = &desc
-		}
-		if image, _ := cmd.Flags().GetString("image"); image != "" {
-			req.Image = &image
-		}
-		if instant, _ := cmd.Flags().GetBool("instant-deploy"); instant {
-			req.InstantDeploy = &instant
-		}
-		if password, _ := cmd.Flags().GetString("password"); password != "" {
-			req.DragonflyPassword = &password
-		}
-
-		err = client.Databases().CreateDragonfly(context.Background(), req)
-		if err != nil {
-			return fmt.Errorf("failed to create Dragonfly database: %w", err)
+		theme.Statusln("✅ Dragonfly database created successfully")
+		if dbUUID != "" {
+			theme.Printf("   📦 UUID: %s\n", dbUUID)
 		}
-
-		fmt.Println("✅ Dragonfly database created successfully")
 		return nil
 	},
 }
@@ -571,55 +787,71 @@ var databasesCreateKeyDBCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		// Get required parameters
-		envName, _ := cmd.Flags().GetString("environment")
-		envUUID, _ := cmd.Flags().GetString("environment-uuid")
-		projectUUID, _ := cmd.Flags().GetString("project")
-		serverUUID, _ := cmd.Flags().GetString("server")
-
-		if envName == "" && envUUID == "" {
-			return fmt.Errorf("either --environment or --environment-uuid is required")
-		}
-		if projectUUID == "" {
-			return fmt.Errorf("--project is required")
-		}
-		if serverUUID == "" {
-			return fmt.Errorf("--server is required")
+		req := coolify.CreateDatabaseKeydbJSONRequestBody{}
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
+		}
+		if !fromFile {
+			// Get required parameters
+			envName, _ := cmd.Flags().GetString("environment")
+			envUUID, _ := cmd.Flags().GetString("environment-uuid")
+			projectUUID, _ := cmd.Flags().GetString("project")
+			serverUUID, _ := cmd.Flags().GetString("server")
+
+			if envName == "" && envUUID == "" {
+				return fmt.Errorf("either --environment or --environment-uuid is required")
+			}
+			if projectUUID == "" {
+				return fmt.Errorf("--project is required")
+			}
+			if serverUUID == "" {
+				return fmt.Errorf("--server is required")
+			}
+
+			req = coolify.CreateDatabaseKeydbJSONRequestBody{
+				EnvironmentName: envName,
+				EnvironmentUuid: envUUID,
+				ProjectUuid:     projectUUID,
+				ServerUuid:      serverUUID,
+			}
+
+			// Optional parameters
+			if name, _ := cmd.Flags().GetString("name"); name != "" {
+				req.Name = &name
+			}
+			if desc, _ := cmd.Flags().GetString("description"); desc != "" {
+				req.Description = &desc
+			}
+			if image, _ := cmd.Flags().GetString("image"); image != "" {
+				req.Image = &image
+			}
+			if instant, _ := cmd.Flags().GetBool("instant-deploy"); instant {
+				req.InstantDeploy = &instant
+			}
+			if password, _ := cmd.Flags().GetString("password"); password != "" {
+				req.KeydbPassword = &password
+			}
+			if conf, _ := cmd.Flags().GetString("keydb-conf"); conf != "" {
+				req.KeydbConf = &conf
+			}
+		}
+
+		ctx, guard := beginCreate(context.Background(), client, "database", req)
+		dbUUID, err := client.Databases().CreateKeyDB(ctx, req)
+		guard.finish(err)
+		if err != nil {
+			return fmt.Errorf("failed to create KeyDB database: %w", err)
 		}
 
-		req := coolify.CreateDatabaseKeydbJSONRequestBody{
-			EnvironmentName: envName,
-			EnvironmentUuid: envUUID,
-			ProjectUuid:     projectUUID,
-			ServerUuid:      serverUUID,
+		if printQuietUUID(dbUUID) {
+			return nil
 		}
 
-		// Optional parameters
-		if name, _ := cmd.Flags().GetString("name"); name != "" {
-			req.Name = &name
-		}
-		if desc, _ := cmd.Flags().GetString("description"); desc != "" {
-			req.Description = &desc
-		}
-		if image, _ := cmd.Flags().GetString("image"); image != "" {
-			req.Image = &image
-		}
-		if instant, _ := cmd.Flags().GetBool("instant-deploy"); instant {
-			req.InstantDeploy = &instant
-		}
-		if password, _ := cmd.Flags().GetString("password"); password != "" {
-			req.KeydbPassword = &password
-		}
-		if conf, _ := cmd.Flags().GetString("keydb-conf"); conf != "" {
-			req.KeydbConf = &conf
+		theme.Statusln("✅ KeyDB database created successfully")
+		if dbUUID != "" {
+			theme.Printf("   📦 UUID: %s\n", dbUUID)
 		}
-
-		err = client.Databases().CreateKeyDB(context.Background(), req)
-		if err != nil {
-			return fmt.Errorf("failed to create KeyDB database: %w", err)
-		}
-
-		fmt.Println("✅ KeyDB database created successfully")
 		return nil
 	},
 }
@@ -635,71 +867,86 @@ var databasesCreateMariaDBCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		// Get required parameters
-		envName, _ := cmd.Flags().GetString("environment")
-		envUUID, _ := cmd.Flags().GetString("environment-uuid")
-		projectUUID, _ := cmd.Flags().GetString("project")
-		serverUUID, _ := cmd.Flags().GetString("server")
-
-		if envName == "" && envUUID == "" {
-			return fmt.Errorf("either --environment or --environment-uuid is required")
-		}
-		if projectUUID == "" {
-			return fmt.Errorf("--project is required")
-		}
-		if serverUUID == "" {
-			return fmt.Errorf("--server is required")
-		}
-
-		req := coolify.CreateDatabaseMariadbJSONRequestBody{
-			EnvironmentName: envName,
-			EnvironmentUuid: envUUID,
-			ProjectUuid:     projectUUID,
-			ServerUuid:      serverUUID,
+		req := coolify.CreateDatabaseMariadbJSONRequestBody{}
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
+		}
+		if !fromFile {
+			// Get required parameters
+			envName, _ := cmd.Flags().GetString("environment")
+			envUUID, _ := cmd.Flags().GetString("environment-uuid")
+			projectUUID, _ := cmd.Flags().GetString("project")
+			serverUUID, _ := cmd.Flags().GetString("server")
+
+			if envName == "" && envUUID == "" {
+				return fmt.Errorf("either --environment or --environment-uuid is required")
+			}
+			if projectUUID == "" {
+				return fmt.Errorf("--project is required")
+			}
+			if serverUUID == "" {
+				return fmt.Errorf("--server is required")
+			}
+
+			req = coolify.CreateDatabaseMariadbJSONRequestBody{
+				EnvironmentName: envName,
+				EnvironmentUuid: envUUID,
+				ProjectUuid:     projectUUID,
+				ServerUuid:      serverUUID,
+			}
+
+			// Optional parameters
+			if name, _ := cmd.Flags().GetString("name"); name != "" {
+				req.Name = &name
+			}
+			if desc, _ := cmd.Flags().GetString("description"); desc != "" {
+				req.Description = &desc
+			}
+			if image, _ := cmd.Flags().GetString("image"); image != "" {
+				req.Image = &image
+			}
+			if instant, _ := cmd.Flags().GetBool("instant-deploy"); instant {
+				req.InstantDeploy = &instant
+			}
+			if rootPassword, _ := cmd.Flags().GetString("root-password"); rootPassword != "" {
+				req.MariadbRootPassword = &rootPassword
+			}
+			if database, _ := cmd.Flags().GetString("mariadb-database"); database != "" {
+				req.MariadbDatabase = &database
+			}
+			if user, _ := cmd.Flags().GetString("mariadb-user"); user != "" {
+				req.MariadbUser = &user
+			}
+			if userPassword, _ := cmd.Flags().GetString("mariadb-password"); userPassword != "" {
+				req.MariadbPassword = &userPassword
+			}
+			if conf, _ := cmd.Flags().GetString("mariadb-conf"); conf != "" {
+				req.MariadbConf = &conf
+			}
+		}
+
+		ctx, guard := beginCreate(context.Background(), client, "database", req)
+		dbUUID, err := client.Databases().CreateMariaDB(ctx, req)
+		guard.finish(err)
+		if err != nil {
+			return fmt.Errorf("failed to create MariaDB database: %w", err)
 		}
 
-		// Optional parameters
-		if name, _ := cmd.Flags().GetString("name"); name != "" {
-			req.Name = &name
-		}
-		if desc, _ := cmd.Flags().GetString("description"); desc != "" {
-			req.Description = &desc
-		}
-		if image, _ := cmd.Flags().GetString("image"); image != "" {
-			req.Image = &image
-		}
-		if instant, _ := cmd.Flags().GetBool("instant-deploy"); instant {
-			req.InstantDeploy = &instant
-		}
-		if rootPassword, _ := cmd.Flags().GetString("root-password"); rootPassword != "" {
-			req.MariadbRootPassword = &rootPassword
-		}
-		if database, _ := cmd.Flags().GetString("mariadb-database"); database != "" {
-			req.MariadbDatabase = &database
-		}
-		if user, _ := cmd.Flags().GetString("mariadb-user"); user != "" {
-			req.MariadbUser = &user
-		}
-		if userPassword, _ := cmd.Flags().GetString("mariadb-password"); userPassword != "" {
-			req.MariadbPassword = &userPassword
-		}
-		if conf, _ := cmd.Flags().GetString("mariadb-conf"); conf != "" {
-			req.MariadbConf = &conf
+		if printQuietUUID(dbUUID) {
+			return nil
 		}
 
-		err = client.Databases().CreateMariaDB(context.Background(), req)
-		if err != nil {
-			return fmt.Errorf("failed to create MariaDB database: %w", err)
+		theme.Statusln("✅ MariaDB database created successfully")
+		if dbUUID != "" {
+			theme.Printf("   📦 UUID: %s\n", dbUUID)
 		}
-
-		fmt.Println("✅ MariaDB database created successfully")
 		return nil
 	},
 }
 
 func init() {
-	// Common flags for all database create commands
-	for _, cmd := range []*cobra.Command{
+	createCmds := []*cobra.Command{
 		databasesCreatePostgreSQLCmd,
 		databasesCreateMySQLCmd,
 		databasesCreateRedisCmd,
@@ -708,7 +955,10 @@ func init() {
 		databasesCreateDragonflyCmd,
 		databasesCreateKeyDBCmd,
 		databasesCreateMariaDBCmd,
-	} {
+	}
+
+	// Common flags for all database create commands
+	for _, cmd := range createCmds {
 		cmd.Flags().String("environment", "", "Environment name")
 		cmd.Flags().String("environment-uuid", "", "Environment UUID")
 		cmd.Flags().String("project", "", "Project UUID (required)")
@@ -738,6 +988,11 @@ func init() {
 	databasesCreateMariaDBCmd.Flags().String("mariadb-password", "", "MariaDB user password")
 	databasesCreateMariaDBCmd.Flags().String("mariadb-conf", "", "MariaDB configuration")
 
+	// The request body of every create command can be read from a file instead
+	for _, cmd := range createCmds {
+		addFromFileFlag(cmd)
+	}
+
 	// Add create subcommands to databases create
 	databasesCreateCmd.AddCommand(databasesCreatePostgreSQLCmd)
 	databasesCreateCmd.AddCommand(databasesCreateMySQLCmd)
@@ -751,10 +1006,27 @@ func init() {
 	// Add subcommands to databases
 	databasesCmd.AddCommand(databasesListCmd)
 	databasesCmd.AddCommand(databasesGetCmd)
+	databasesCmd.AddCommand(databasesConnectionStringCmd)
 	databasesCmd.AddCommand(databasesStartCmd)
 	databasesCmd.AddCommand(databasesStopCmd)
 	databasesCmd.AddCommand(databasesRestartCmd)
 	databasesCmd.AddCommand(databasesDeleteCmd)
 	databasesCmd.AddCommand(databasesUpdateCmd)
 	databasesCmd.AddCommand(databasesCreateCmd)
+
+	// Flags for get command
+	databasesGetCmd.Flags().BoolP("json", "j", false, "Output the raw API response in JSON format")
+
+	// Flags for connection-string command
+	databasesConnectionStringCmd.Flags().Bool("public", false, "Use the public address and port instead of the internal one")
+	databasesConnectionStringCmd.Flags().String("format", "url", "Output format (url, env)")
+	databasesConnectionStringCmd.Flags().String("var", "DATABASE_URL", "Variable name for --format env")
+
+	// Flags for update command
+	addFromFileFlag(databasesUpdateCmd)
+
+	// Flags for delete command
+	addConfirmFlags(databasesDeleteCmd, "Delete without confirmation")
+	databasesDeleteCmd.Flags().Bool("delete-volumes", false, "Delete volumes")
+	databasesDeleteCmd.Flags().Bool("delete-configurations", false, "Delete configurations")
 }
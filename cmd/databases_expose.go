@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// databasesExposeCmd represents the databases expose command
+var databasesExposeCmd = &cobra.Command{
+	Use:   "expose <uuid>",
+	Short: "Make a database reachable from outside the server on a public port",
+	Long: `Make a database publicly accessible: Coolify starts a proxy that forwards a port of the server
+to the database. The external host, port and connection string are printed afterwards.
+
+Anyone who can reach the port can try to log in, so restrict it with a firewall (or the
+security group of your cloud provider) to the addresses that need it, and prefer an SSH tunnel
+('coolifyme port-forward') for occasional access.
+
+Without --port the current public port is kept, or the default port of the database type
+(e.g. 5432 for PostgreSQL) is used.
+
+Examples:
+  coolifyme databases expose <uuid> --port 5432
+  coolifyme databases unexpose <uuid>`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		if err := checkTokenAbility(cmd, client, clientpkg.AbilityWrite); err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		info, err := getDatabaseInfo(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+
+		port, _ := cmd.Flags().GetInt("port")
+		switch {
+		case cmd.Flags().Changed("port"):
+		case info.PublicPort != 0:
+			port = info.PublicPort
+		default:
+			port = info.InternalPort()
+		}
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("invalid port %d, use --port with a value from 1 to 65535", port)
+		}
+
+		if info.IsPublic && info.PublicPort == port {
+			theme.Printf("✅ Database %s is already public on port %d\n", info.Name, port)
+			printPublicDatabase(client, info)
+			return nil
+		}
+
+		theme.Printf("⚠️  Port %d of the server will forward to database %s (%s).\n", port, info.Name, info.Type)
+		fmt.Println("   Restrict it with a firewall to the addresses that need access.")
+		if !confirm.Action(fmt.Sprintf("Expose database %s on port %d?", info.Name, port), skipConfirmation(cmd)) {
+			theme.Println("❌ Cancelled")
+			return nil
+		}
+
+		public := true
+		if err := client.Databases().Update(ctx, args[0], coolify.UpdateDatabaseByUuidJSONRequestBody{IsPublic: &public, PublicPort: &port}); err != nil {
+			return fmt.Errorf("failed to expose database: %w", err)
+		}
+
+		// Read the database back for the address Coolify actually uses
+		if updated, err := getDatabaseInfo(ctx, client, args[0]); err == nil {
+			info = updated
+		} else {
+			info.IsPublic, info.PublicPort = true, port
+		}
+		theme.Printf("✅ Database %s is public on port %d\n", info.Name, port)
+		printPublicDatabase(client, info)
+		return nil
+	},
+}
+
+// databasesUnexposeCmd represents the databases unexpose command
+var databasesUnexposeCmd = &cobra.Command{
+	Use:   "unexpose <uuid>",
+	Short: "Stop making a database reachable on its public port",
+	Long: `Make a public database private again: Coolify stops the proxy forwarding its public port, so
+only resources on the same Coolify network can connect. Clients connecting through the public
+port lose access.
+
+Examples:
+  coolifyme databases unexpose <uuid>
+  coolifyme databases unexpose <uuid> --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		if err := checkTokenAbility(cmd, client, clientpkg.AbilityWrite); err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		info, err := getDatabaseInfo(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+		if !info.IsPublic {
+			theme.Printf("✅ Database %s is not public\n", info.Name)
+			return nil
+		}
+
+		message := fmt.Sprintf("Close public port %d of database %s? External clients lose access", info.PublicPort, info.Name)
+		if !confirm.Action(message, skipConfirmation(cmd)) {
+			theme.Println("❌ Cancelled")
+			return nil
+		}
+
+		public := false
+		if err := client.Databases().Update(ctx, args[0], coolify.UpdateDatabaseByUuidJSONRequestBody{IsPublic: &public}); err != nil {
+			return fmt.Errorf("failed to unexpose database: %w", err)
+		}
+
+		theme.Printf("✅ Database %s is no longer public\n", info.Name)
+		theme.Println("💡 Remove firewall rules you added for the port")
+		return nil
+	},
+}
+
+// getDatabaseInfo gets and parses a database
+func getDatabaseInfo(ctx context.Context, client *clientpkg.Client, uuid string) (*clientpkg.DatabaseInfo, error) {
+	raw, err := client.Databases().Get(ctx, uuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+	return clientpkg.ParseDatabase(raw)
+}
+
+// printPublicDatabase prints the external address of a public database and its connection
+// string without the password
+func printPublicDatabase(client *clientpkg.Client, info *clientpkg.DatabaseInfo) {
+	var fallbackHost string
+	if parsed, err := url.Parse(client.BaseURL()); err == nil {
+		fallbackHost = parsed.Hostname()
+	}
+
+	dsn, err := info.ConnectionString(true, fallbackHost)
+	if err != nil {
+		fmt.Printf("   Port: %d\n", info.PublicPort)
+		return
+	}
+	if parsed, err := url.Parse(dsn); err == nil {
+		fmt.Printf("   Address:    %s\n", net.JoinHostPort(parsed.Hostname(), strconv.Itoa(info.PublicPort)))
+	}
+	fmt.Printf("   Connection: %s\n", maskDSNPassword(dsn))
+	fmt.Printf("   Run 'coolifyme databases connection-string %s --public' for the full connection string\n", info.UUID)
+}
+
+func init() {
+	databasesCmd.AddCommand(databasesExposeCmd)
+	databasesCmd.AddCommand(databasesUnexposeCmd)
+
+	databasesExposeCmd.Flags().Int("port", 0, "Public port on the server (default: the current public port or the database's default port)")
+	addConfirmFlags(databasesExposeCmd, "Expose the database without confirmation")
+	addConfirmFlags(databasesUnexposeCmd, "Unexpose the database without confirmation")
+}
@@ -5,9 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
+	"time"
 
-	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/report"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
 	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
 	"github.com/spf13/cobra"
 )
@@ -17,7 +21,20 @@ func deployCmd() *cobra.Command {
 		Use:     "deploy",
 		Aliases: []string{"deployment", "deployments"},
 		Short:   "Deploy applications and services",
-		Long:    "Trigger deployments for applications and services in Coolify, and manage deployment history",
+		Long: `Trigger deployments for applications and services in Coolify, and manage deployment history.
+
+'deploy application' and 'deploy multiple' run the hooks configured in the config file:
+
+  hooks:
+    before-deploy:                  # a failing hook cancels the deployment
+      - ./scripts/test.sh
+    after-deploy:                   # after triggering, or after finishing with --wait
+      - curl -fsS https://example.com/health
+    on-failure:                     # a hook or the deployment failed
+      - ./scripts/notify.sh "$APP_UUID failed: $STATUS"
+
+Hooks run with the shell and receive APP_UUID, DEPLOYMENT_UUID, STATUS and COOLIFYME_HOOK
+in their environment. Use --no-hooks to skip them.`,
 	}
 
 	cmd.AddCommand(deployApplicationCmd())
@@ -28,6 +45,9 @@ func deployCmd() *cobra.Command {
 	cmd.AddCommand(deployWatchCmd())
 	cmd.AddCommand(deployLogsCmd())
 	cmd.AddCommand(deployMultipleCmd())
+	cmd.AddCommand(deployQueueCmd())
+	cmd.AddCommand(deployPreviewCmd())
+	cmd.AddCommand(deployExportCmd())
 
 	return cmd
 }
@@ -40,9 +60,33 @@ func deployApplicationCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "application [uuid]",
 		Short: "Deploy an application",
-		Long:  "Trigger a deployment for the specified application",
-		Args:  cobra.ExactArgs(1),
-		RunE: func(_ *cobra.Command, args []string) error {
+		Long: `Trigger a deployment for the specified application.
+
+Use --wait to block until the deployment finishes and exit with an error if it fails.
+Combine it with --report-file to write a JUnit XML or JSON report for CI systems.
+
+The before-deploy, after-deploy and on-failure hooks of the config file run around the
+deployment; see 'coolifyme deploy --help' for how to configure them.
+
+If a deployment of the application is already queued or running, a warning is printed and the
+new one is queued after it. Use --if-not-running to skip the deployment instead, which avoids
+double deploys when CI triggers twice; combined with --wait it waits for the running deployment.
+--force skips the check.
+
+--no-cache rebuilds the application without the build cache (Coolify's force rebuild). It is
+independent of --force, which only skips the running-deployment check.
+
+A finished deployment does not mean the application serves traffic yet. With --verify-http the
+application's domains are requested after --wait succeeds until each returns a 2xx status (or
+--expect-status) at --path, failing after --verify-timeout. The after-deploy hooks run once the
+domains respond; otherwise the on-failure hooks run with the status verify-failed.
+
+Examples:
+  coolifyme deploy application <uuid> --no-cache
+  coolifyme deploy application <uuid> --wait --verify-http
+  coolifyme deploy application <uuid> --wait --verify-http --path /healthz --expect-status 200`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := createClient()
 			if err != nil {
 				return fmt.Errorf("failed to create client: %w", err)
@@ -51,15 +95,17 @@ func deployApplicationCmd() *cobra.Command {
 			applicationUUID := args[0]
 			ctx := context.Background()
 
-			fmt.Printf("🚀 Starting application deployment for %s\n", applicationUUID)
-			if branch != "" {
-				fmt.Printf("   Branch: %s\n", branch)
-			}
-			if pr > 0 {
-				fmt.Printf("   Pull Request: #%d\n", pr)
-			}
-			if force {
-				fmt.Printf("   Force deployment: enabled\n")
+			templated := templateRequested(cmd)
+			noCache, _ := cmd.Flags().GetBool("no-cache")
+			if !templated {
+				theme.Printf("🚀 Starting application deployment for %s\n", applicationUUID)
+				if branch != "" {
+					fmt.Printf("   Branch: %s\n", branch)
+				}
+				if pr > 0 {
+					fmt.Printf("   Pull Request: #%d\n", pr)
+				}
+				printDeployForceFlags(force, noCache)
 			}
 
 			if branch != "" && pr > 0 {
@@ -68,40 +114,262 @@ func deployApplicationCmd() *cobra.Command {
 
 			// Use the enhanced client method that supports PR deployments
 			options := &clientpkg.DeployApplicationOptions{
-				Force:  force,
-				Branch: branch,
+				ForceRebuild: noCache,
+				Branch:       branch,
 			}
 			if pr > 0 {
 				options.PR = &pr
 			}
 
+			wait, _ := cmd.Flags().GetBool("wait")
+			verifyHTTP, _ := cmd.Flags().GetBool("verify-http")
+			if verifyHTTP && !wait {
+				return fmt.Errorf("--verify-http requires --wait")
+			}
+			deployReport := report.New("coolifyme.deploy")
+
+			if active, skip := guardDeployment(ctx, cmd, client, applicationUUID, force); skip {
+				if !wait {
+					return nil
+				}
+				return waitForActiveDeployments(ctx, cmd, client, deployReport, active)
+			}
+
+			hooks := loadDeployHooks(cmd)
+			if err := hooks.run(ctx, config.HookBeforeDeploy, hookRun{AppUUID: applicationUUID, Status: "pending"}); err != nil {
+				hooks.fail(ctx, hookRun{AppUUID: applicationUUID, Status: hookStatusHookFailed})
+				return err
+			}
+
+			started := time.Now()
 			deployResponse, err := client.Deployments().DeployApplicationWithOptions(ctx, applicationUUID, options)
 			if err != nil {
+				hooks.fail(ctx, hookRun{AppUUID: applicationUUID, Status: hookStatusError})
+				deployReport.Add(report.Result{
+					Name:     "deploy " + applicationUUID,
+					Resource: applicationUUID,
+					Status:   report.StatusFailed,
+					Message:  err.Error(),
+					Duration: time.Since(started),
+				})
+				if reportErr := writeReportFile(cmd, deployReport); reportErr != nil {
+					warn("report file", reportErr)
+				}
 				return fmt.Errorf("failed to deploy application: %w", err)
 			}
 
-			if deployResponse != nil && len(deployResponse.Deployments) > 0 {
-				fmt.Printf("✅ Application deployment triggered successfully for %s\n", applicationUUID)
-				for _, deployment := range deployResponse.Deployments {
-					fmt.Printf("   📦 Deployment UUID: %s\n", deployment.DeploymentUUID)
-					fmt.Printf("   🎯 Resource UUID:   %s\n", deployment.ResourceUUID)
+			var deployments []clientpkg.DeploymentResult
+			if deployResponse != nil {
+				deployments = deployResponse.Deployments
+			}
+
+			// A template renders one summary per deployment instead, once it is queued or finished
+			appName := applicationUUID
+			if templated {
+				if app, err := client.Applications().Get(ctx, applicationUUID); err == nil && app.Name != nil {
+					appName = *app.Name
+				}
+			} else {
+				theme.Printf("✅ Application deployment triggered successfully for %s\n", applicationUUID)
+				for _, deployment := range deployments {
+					theme.Printf("   📦 Deployment UUID: %s\n", deployment.DeploymentUUID)
+					theme.Printf("   🎯 Resource UUID:   %s\n", deployment.ResourceUUID)
 					if deployment.Message != "" {
-						fmt.Printf("   📝 Message:         %s\n", deployment.Message)
+						theme.Printf("   📝 Message:         %s\n", deployment.Message)
 					}
 				}
-			} else {
-				fmt.Printf("✅ Application deployment triggered successfully for %s\n", applicationUUID)
 			}
 
-			return nil
+			if !wait {
+				if err := writeReportFile(cmd, deployReport); err != nil {
+					return err
+				}
+				if templated {
+					summaries := make([]deploySummary, 0, len(deployments))
+					for _, deployment := range deployments {
+						summaries = append(summaries, newDeploySummary(appName, deployment, "queued", deployment.Message))
+					}
+					if err := outputTemplate(summaries, templateText(cmd)); err != nil {
+						return err
+					}
+				}
+				return runAfterDeployHooks(ctx, hooks, applicationUUID, deployments)
+			}
+
+			if len(deployments) == 0 {
+				return fmt.Errorf("cannot wait for deployment: the API did not return a deployment UUID")
+			}
+
+			timeout, _ := cmd.Flags().GetDuration("wait-timeout")
+			waitCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			var succeeded []hookRun
+			var summaries []deploySummary
+			for _, deployment := range deployments {
+				result, status := waitForDeployment(waitCtx, client, deployment, started)
+				deployReport.Add(result)
+				summaries = append(summaries, newDeploySummary(appName, deployment, status, result.Message))
+
+				run := hookRun{AppUUID: applicationUUID, DeploymentUUID: deployment.DeploymentUUID, Status: status}
+				if result.Status == report.StatusFailed {
+					hooks.fail(ctx, run)
+				} else {
+					succeeded = append(succeeded, run)
+				}
+			}
+
+			// Probe the domains before the after-deploy hooks, so a deployment that finished but
+			// does not serve traffic counts as failed
+			failures := deployReport.Failures()
+			var verifyErr error
+			if verifyHTTP && failures == 0 {
+				verifyErr = verifyDeploymentHTTP(ctx, cmd, client, applicationUUID, deployReport)
+			}
+
+			if templated {
+				// The domains are only verified when every deployment succeeded
+				for i := range summaries {
+					if verifyErr != nil {
+						summaries[i].Status = hookStatusVerifyFailed
+						summaries[i].Message = verifyErr.Error()
+					}
+				}
+				if err := outputTemplate(summaries, templateText(cmd)); err != nil {
+					return err
+				}
+			}
+
+			var hookErr error
+			for _, run := range succeeded {
+				if verifyErr != nil {
+					run.Status = hookStatusVerifyFailed
+					hooks.fail(ctx, run)
+				} else if err := hooks.run(ctx, config.HookAfterDeploy, run); err != nil && hookErr == nil {
+					hookErr = err
+				}
+			}
+
+			if err := writeReportFile(cmd, deployReport); err != nil {
+				return err
+			}
+			if failures > 0 {
+				return fmt.Errorf("%d of %d deployment(s) failed", failures, len(deployments))
+			}
+			if verifyErr != nil {
+				return verifyErr
+			}
+			return hookErr
 		},
 	}
 
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force deployment even if one is already running")
+	addDeployNoCacheFlag(cmd)
 	cmd.Flags().StringVarP(&branch, "branch", "b", "", "Deploy from specific branch/tag")
-	cmd.Flags().IntVarP(&pr, "pr", "p", 0, "Deploy specific Pull Request (cannot be used with --branch)")
+	cmd.Flags().IntVar(&pr, "pr", 0, "Deploy specific Pull Request (cannot be used with --branch)")
+	addDeployWaitFlags(cmd)
+	addDeployVerifyFlags(cmd)
+	addDeployHookFlags(cmd)
+	addDeployGuardFlags(cmd)
 
-	return cmd
+	return supportsTemplateOutput(cmd)
+}
+
+// addDeployWaitFlags adds the flags for waiting on a deployment and reporting its result
+func addDeployWaitFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("wait", false, "Wait for the deployment to finish and fail if it fails")
+	cmd.Flags().Duration("wait-timeout", 30*time.Minute, "Maximum time to wait for the deployment with --wait")
+	addReportFlags(cmd)
+}
+
+// waitForDeployment waits for a triggered deployment to finish and returns its report result
+// and final status
+// deploySummary is rendered by --output template=... for every deployment 'deploy application'
+// triggered, e.g. 'template={{.Name}} -> {{.Status}}'
+type deploySummary struct {
+	Name            string `json:"name"`
+	ApplicationUUID string `json:"application_uuid"`
+	DeploymentUUID  string `json:"deployment_uuid"`
+	// Status is queued without --wait, otherwise the final status of the deployment, error
+	// when waiting failed or verify-failed when --verify-http failed
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+func newDeploySummary(name string, deployment clientpkg.DeploymentResult, status, message string) deploySummary {
+	return deploySummary{
+		Name:            name,
+		ApplicationUUID: deployment.ResourceUUID,
+		DeploymentUUID:  deployment.DeploymentUUID,
+		Status:          status,
+		Message:         message,
+	}
+}
+
+func waitForDeployment(ctx context.Context, client *clientpkg.Client, deployment clientpkg.DeploymentResult, started time.Time) (report.Result, string) {
+	result := report.Result{
+		Name:     "deploy " + deployment.ResourceUUID,
+		Resource: deployment.ResourceUUID,
+	}
+
+	final, err := client.Deployments().Wait(ctx, deployment.DeploymentUUID, 5*time.Second, func(status string) {
+		theme.Printf("   📊 %s: %s\n", deployment.DeploymentUUID, status)
+	})
+	result.Duration = time.Since(started)
+
+	if err != nil {
+		result.Status = report.StatusFailed
+		result.Message = err.Error()
+		theme.Printf("❌ Deployment %s: %v\n", deployment.DeploymentUUID, err)
+		return result, hookStatusError
+	}
+
+	status := ""
+	if final.Status != nil {
+		status = *final.Status
+	}
+	if final.Logs != nil {
+		result.Details = tailLines(*final.Logs, 50)
+	}
+
+	if clientpkg.DeploymentFailed(status) {
+		result.Status = report.StatusFailed
+		result.Message = fmt.Sprintf("deployment %s finished with status %s", deployment.DeploymentUUID, status)
+		theme.Printf("❌ Deployment %s failed with status: %s\n", deployment.DeploymentUUID, status)
+		return result, status
+	}
+
+	result.Status = report.StatusPassed
+	result.Message = fmt.Sprintf("deployment %s finished with status %s", deployment.DeploymentUUID, status)
+	theme.Printf("✅ Deployment %s completed successfully\n", deployment.DeploymentUUID)
+	return result, status
+}
+
+// runAfterDeployHooks runs the after-deploy hooks for triggered deployments that were not waited
+// for, once per deployment or once without a deployment UUID when the API returned none
+func runAfterDeployHooks(ctx context.Context, hooks *deployHooks, appUUID string, deployments []clientpkg.DeploymentResult) error {
+	if len(deployments) == 0 {
+		return hooks.run(ctx, config.HookAfterDeploy, hookRun{AppUUID: appUUID, Status: "queued"})
+	}
+	for _, deployment := range deployments {
+		run := hookRun{AppUUID: deployment.ResourceUUID, DeploymentUUID: deployment.DeploymentUUID, Status: "queued"}
+		if run.AppUUID == "" {
+			run.AppUUID = appUUID
+		}
+		if err := hooks.run(ctx, config.HookAfterDeploy, run); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tailLines returns the last n lines of s
+func tailLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
 }
 
 func deployServiceCmd() *cobra.Command {
@@ -119,7 +387,7 @@ func deployServiceCmd() *cobra.Command {
 			serviceUUID := args[0]
 			ctx := context.Background()
 
-			fmt.Printf("🚀 Starting service deployment for %s\n", serviceUUID)
+			theme.Printf("🚀 Starting service deployment for %s\n", serviceUUID)
 
 			// Use the deployment client's method
 			err = client.Deployments().DeployService(ctx, serviceUUID)
@@ -127,7 +395,7 @@ func deployServiceCmd() *cobra.Command {
 				return fmt.Errorf("failed to deploy service: %w", err)
 			}
 
-			fmt.Printf("✅ Service deployment triggered successfully for %s\n", serviceUUID)
+			theme.Printf("✅ Service deployment triggered successfully for %s\n", serviceUUID)
 
 			return nil
 		},
@@ -140,8 +408,17 @@ func deployListCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list [app-uuid]",
 		Short: "List deployments for an application",
-		Long:  "List deployment history for a specific application",
-		Args:  cobra.ExactArgs(1),
+		Long: `List the deployment history of an application, newest first, with the short commit SHA, the
+time spent waiting in the queue and the duration of each deployment.
+
+The duration runs from the creation of a deployment to its last update once it finished; running
+deployments are timed until now and marked with +. The queue wait is the time until the first log
+line, when Coolify includes the logs. Sort with --sort-by duration or queue to spot slow builds.
+
+Examples:
+  coolifyme deploy list <app-uuid>
+  coolifyme deploy list <app-uuid> --take 50 --sort-by duration`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := createClient()
 			if err != nil {
@@ -153,20 +430,24 @@ func deployListCmd() *cobra.Command {
 
 			skip, _ := cmd.Flags().GetInt("skip")
 			take, _ := cmd.Flags().GetInt("take")
-
-			var deployments []coolify.Application
-			if skip > 0 || take > 0 {
-				deployments, err = client.Deployments().ListWithPagination(ctx, appUUID, skip, take)
-			} else {
-				deployments, err = client.Deployments().List(ctx, appUUID)
+			if take <= 0 {
+				take = clientpkg.DefaultPageSize
 			}
+
+			deployments, err := client.Deployments().Page(ctx, appUUID, skip, take)
 			if err != nil {
 				return fmt.Errorf("failed to list deployments: %w", err)
 			}
 
+			sortBy, _ := cmd.Flags().GetString("sort-by")
+			timed := timeDeployments(deployments, time.Now())
+			if err := sortTimedDeployments(timed, sortBy); err != nil {
+				return err
+			}
+
 			jsonOutput, _ := cmd.Flags().GetBool("json")
 			if jsonOutput {
-				output, err := json.MarshalIndent(deployments, "", "  ")
+				output, err := json.MarshalIndent(timed, "", "  ")
 				if err != nil {
 					return fmt.Errorf("failed to marshal JSON: %w", err)
 				}
@@ -174,7 +455,11 @@ func deployListCmd() *cobra.Command {
 				return nil
 			}
 
-			if len(deployments) == 0 {
+			if handled, err := outputWithTemplate(cmd, timed); handled {
+				return err
+			}
+
+			if len(timed) == 0 {
 				fmt.Printf("No deployments found for application %s\n", appUUID)
 				return nil
 			}
@@ -185,36 +470,17 @@ func deployListCmd() *cobra.Command {
 				_ = w.Flush()
 			}()
 
-			// Print header
-			_, _ = fmt.Fprintln(w, "UUID\tNAME\tSTATUS\tBRANCH\tDOMAINS")
-			_, _ = fmt.Fprintln(w, "----\t----\t------\t------\t-------")
-
-			// Print deployments (Note: this returns Application objects, not ApplicationDeploymentQueue)
-			for _, deployment := range deployments {
-				uuid := ""
-				name := ""
-				status := ""
-				branch := ""
-				domains := ""
-
-				if deployment.Uuid != nil {
-					uuid = *deployment.Uuid
-				}
-				if deployment.Name != nil {
-					name = *deployment.Name
-				}
-				if deployment.Status != nil {
-					status = *deployment.Status
-				}
-				if deployment.GitBranch != nil {
-					branch = *deployment.GitBranch
-				}
-				if deployment.Fqdn != nil {
-					domains = *deployment.Fqdn
-				}
-
-				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-					uuid, name, status, branch, domains)
+			_, _ = fmt.Fprintln(w, "UUID\tSTATUS\tCOMMIT\tCREATED\tQUEUED\tDURATION\tSERVER")
+			_, _ = fmt.Fprintln(w, "----\t------\t------\t-------\t------\t--------\t------")
+			for _, deployment := range timed {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+					stringOrDash(deployment.DeploymentUuid),
+					stringOrDash(deployment.Status),
+					shortCommit(stringOrDash(deployment.Commit)),
+					formatDeploymentTime(stringOrDash(deployment.CreatedAt)),
+					deployment.queueColumn(),
+					deployment.durationColumn(),
+					stringOrDash(deployment.ServerName))
 			}
 
 			return nil
@@ -224,8 +490,9 @@ func deployListCmd() *cobra.Command {
 	cmd.Flags().BoolP("json", "j", false, "Output in JSON format")
 	cmd.Flags().Int("skip", 0, "Number of records to skip (pagination)")
 	cmd.Flags().Int("take", 10, "Number of records to take (pagination)")
+	cmd.Flags().String("sort-by", "created", "Sort by created (newest first), duration or queue (longest first)")
 
-	return cmd
+	return supportsTemplateOutput(cmd)
 }
 
 func deployListAllCmd() *cobra.Command {
@@ -233,7 +500,19 @@ func deployListAllCmd() *cobra.Command {
 		Use:     "list-all",
 		Aliases: []string{"all"},
 		Short:   "List all running deployments",
-		Long:    "List all currently running deployments across all applications",
+		Long: `List all currently running deployments across all applications, with the short commit SHA, the
+time spent waiting in the queue and how long each deployment has been running (marked with +).
+
+Narrow the list down with --status, --server, --app and --since, e.g. to see what is building
+right now on one server. Servers and applications are given by UUID, UUID prefix or name.
+Coolify only lists running deployments across applications, so --status failed and finished
+require --app and read its deployment history instead (back to --since, or the last 100).
+
+Examples:
+  coolifyme deploy list-all
+  coolifyme deploy list-all --sort-by duration
+  coolifyme deploy list-all --status running --server build-1
+  coolifyme deploy list-all --status failed --app my-api --since 2h`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			client, err := createClient()
 			if err != nil {
@@ -242,14 +521,27 @@ func deployListAllCmd() *cobra.Command {
 
 			ctx := context.Background()
 
-			deployments, err := client.Deployments().ListAll(ctx)
+			now := time.Now()
+			filter, err := parseDeploymentFilter(ctx, cmd, client, now)
+			if err != nil {
+				return err
+			}
+
+			deployments, err := filter.list(ctx, client)
 			if err != nil {
 				return fmt.Errorf("failed to list deployments: %w", err)
 			}
+			deployments = filterDeployments(deployments, filter)
+
+			sortBy, _ := cmd.Flags().GetString("sort-by")
+			timed := timeDeployments(deployments, now)
+			if err := sortTimedDeployments(timed, sortBy); err != nil {
+				return err
+			}
 
 			jsonOutput, _ := cmd.Flags().GetBool("json")
 			if jsonOutput {
-				output, err := json.MarshalIndent(deployments, "", "  ")
+				output, err := json.MarshalIndent(timed, "", "  ")
 				if err != nil {
 					return fmt.Errorf("failed to marshal JSON: %w", err)
 				}
@@ -257,8 +549,16 @@ func deployListAllCmd() *cobra.Command {
 				return nil
 			}
 
-			if len(deployments) == 0 {
-				fmt.Println("No running deployments found")
+			if handled, err := outputWithTemplate(cmd, timed); handled {
+				return err
+			}
+
+			if len(timed) == 0 {
+				if filter.history {
+					fmt.Println("No matching deployments found")
+				} else {
+					fmt.Println("No running deployments found")
+				}
 				return nil
 			}
 
@@ -268,36 +568,23 @@ func deployListAllCmd() *cobra.Command {
 				_ = w.Flush()
 			}()
 
-			// Print header
-			_, _ = fmt.Fprintln(w, "ID\tAPP NAME\tSTATUS\tCREATED\tSERVER")
-			_, _ = fmt.Fprintln(w, "--\t--------\t------\t-------\t------")
-
-			// Print deployments - using correct ApplicationDeploymentQueue fields
-			for _, deployment := range deployments {
-				id := ""
-				appName := ""
-				status := ""
-				created := ""
-				server := ""
-
+			_, _ = fmt.Fprintln(w, "ID\tAPP NAME\tSTATUS\tCOMMIT\tCREATED\tQUEUED\tDURATION\tSERVER")
+			_, _ = fmt.Fprintln(w, "--\t--------\t------\t------\t-------\t------\t--------\t------")
+			for _, deployment := range timed {
+				id := "-"
 				if deployment.Id != nil {
 					id = fmt.Sprintf("%d", *deployment.Id)
 				}
-				if deployment.ApplicationName != nil {
-					appName = *deployment.ApplicationName
-				}
-				if deployment.Status != nil {
-					status = *deployment.Status
-				}
-				if deployment.CreatedAt != nil {
-					created = *deployment.CreatedAt
-				}
-				if deployment.ServerName != nil {
-					server = *deployment.ServerName
-				}
 
-				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-					id, appName, status, created, server)
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+					id,
+					stringOrDash(deployment.ApplicationName),
+					stringOrDash(deployment.Status),
+					shortCommit(stringOrDash(deployment.Commit)),
+					formatDeploymentTime(stringOrDash(deployment.CreatedAt)),
+					deployment.queueColumn(),
+					deployment.durationColumn(),
+					stringOrDash(deployment.ServerName))
 			}
 
 			return nil
@@ -306,8 +593,10 @@ func deployListAllCmd() *cobra.Command {
 
 	cmd.Flags().BoolP("json", "j", false, "Output in JSON format")
 	cmd.Flags().BoolP("logs", "l", false, "Show deployment logs")
+	cmd.Flags().String("sort-by", "created", "Sort by created (newest first), duration or queue (longest first)")
+	addDeploymentFilterFlags(cmd)
 
-	return cmd
+	return supportsTemplateOutput(cmd)
 }
 
 func deployGetCmd() *cobra.Command {
@@ -377,7 +666,7 @@ func deployGetCmd() *cobra.Command {
 				fmt.Printf("Deployment URL:     %s\n", *deployment.DeploymentUrl)
 			}
 			if deployment.ForceRebuild != nil {
-				fmt.Printf("Force Rebuild:      %t\n", *deployment.ForceRebuild)
+				fmt.Printf("Force Rebuild:      %s\n", forceRebuildLabel(*deployment.ForceRebuild))
 			}
 			if deployment.IsWebhook != nil {
 				fmt.Printf("Triggered by Webhook: %t\n", *deployment.IsWebhook)
@@ -410,31 +699,94 @@ func deployWatchCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "watch [deployment-uuid]",
 		Short: "Watch deployment logs",
-		Long:  "Watch the logs for a specific deployment",
-		Args:  cobra.ExactArgs(1),
-		RunE: func(_ *cobra.Command, args []string) error {
+		Long: `Stream the logs of a specific deployment until it finishes, reconnecting automatically after
+network errors. With --status-only just the status changes are printed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := createClient()
 			if err != nil {
 				return fmt.Errorf("failed to create client: %w", err)
 			}
 
 			deploymentUUID := args[0]
-			ctx := context.Background()
+			statusOnly, _ := cmd.Flags().GetBool("status-only")
+			interval, _ := cmd.Flags().GetInt("interval")
+			if interval < 1 {
+				interval = 5 // Default 5 seconds
+			}
 
-			fmt.Printf("Watching deployment logs for %s\n", deploymentUUID)
+			theme.Printf("🔄 Monitoring deployment %s...\n", deploymentUUID)
 
-			err = client.Deployments().Watch(ctx, deploymentUUID)
-			if err != nil {
-				return fmt.Errorf("failed to watch deployment logs: %w", err)
-			}
+			lastStatus := ""
+			printed := 0
+			return WatchLoop(context.Background(), getWatchConfig(cmd, time.Duration(interval)*time.Second), func(ctx context.Context) (bool, error) {
+				deployment, err := client.Deployments().GetByUUID(ctx, deploymentUUID)
+				if err != nil {
+					return false, err
+				}
+				if deployment.Status == nil {
+					return true, fmt.Errorf("deployment status is unknown")
+				}
 
-			return nil
+				status := *deployment.Status
+				if status != lastStatus {
+					theme.Printf("📊 Status: %s\n", status)
+					lastStatus = status
+				}
+				if !statusOnly {
+					lines := deploymentLogLines(deployment.Logs)
+					for _, line := range lines[min(printed, len(lines)):] {
+						fmt.Println(line)
+					}
+					printed = len(lines)
+				}
+
+				switch {
+				case clientpkg.DeploymentSucceeded(status):
+					theme.Printf("✅ Deployment completed successfully!\n")
+					return true, nil
+				case clientpkg.DeploymentFailed(status):
+					theme.Printf("❌ Deployment failed with status: %s\n", status)
+					if statusOnly && deployment.Logs != nil && *deployment.Logs != "" {
+						theme.Printf("📝 Recent logs:\n%s\n", strings.Join(deploymentLogLines(deployment.Logs), "\n"))
+					}
+					return true, fmt.Errorf("deployment failed")
+				}
+				return false, nil
+			})
 		},
 	}
 
+	cmd.Flags().IntP("interval", "i", 5, "Polling interval in seconds")
+	cmd.Flags().Bool("status-only", false, "Only print status changes instead of streaming the logs")
+	addWatchFlags(cmd)
+
 	return cmd
 }
 
+// deploymentLogLines returns the visible lines of deployment logs, which Coolify stores as a JSON
+// array of entries; logs in another format are split into lines as they are
+func deploymentLogLines(logs *string) []string {
+	if logs == nil || *logs == "" {
+		return nil
+	}
+	var entries []struct {
+		Output string `json:"output"`
+		Hidden bool   `json:"hidden"`
+	}
+	if err := json.Unmarshal([]byte(*logs), &entries); err != nil {
+		return strings.Split(strings.TrimRight(*logs, "\n"), "\n")
+	}
+
+	var lines []string
+	for _, entry := range entries {
+		if !entry.Hidden && entry.Output != "" {
+			lines = append(lines, strings.Split(strings.TrimRight(entry.Output, "\n"), "\n")...)
+		}
+	}
+	return lines
+}
+
 func deployLogsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "logs [deployment-uuid]",
@@ -487,9 +839,15 @@ func deployMultipleCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "multiple [uuid1] [uuid2]...",
 		Short: "Deploy multiple applications or services",
-		Long:  "Trigger deployments for multiple applications or services",
-		Args:  cobra.MinimumNArgs(1),
-		RunE: func(_ *cobra.Command, args []string) error {
+		Long: `Trigger deployments for multiple applications or services.
+
+The before-deploy hooks of the config file run for every UUID before anything is deployed;
+after-deploy hooks run for every triggered deployment.
+
+With --if-not-running, applications that already have a queued or running deployment are skipped;
+--force deploys them anyway. --no-cache rebuilds the applications without the build cache.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := createClient()
 			if err != nil {
 				return fmt.Errorf("failed to create client: %w", err)
@@ -497,44 +855,103 @@ func deployMultipleCmd() *cobra.Command {
 
 			ctx := context.Background()
 
-			fmt.Printf("🚀 Starting deployments for %d applications/services\n", len(args))
+			theme.Printf("🚀 Starting deployments for %d applications/services\n", len(args))
 			if branch != "" {
 				fmt.Printf("   Branch: %s\n", branch)
 			}
-			if force {
-				fmt.Printf("   Force deployment: enabled\n")
+			noCache, _ := cmd.Flags().GetBool("no-cache")
+			printDeployForceFlags(force, noCache)
+
+			// Drop applications that are already deploying when --if-not-running is set
+			var uuids []string
+			for _, uuid := range args {
+				if _, skip := guardDeployment(ctx, cmd, client, uuid, force); !skip {
+					uuids = append(uuids, uuid)
+				}
+			}
+			if len(uuids) == 0 {
+				theme.Println("✅ Nothing to deploy")
+				return nil
 			}
+			args = uuids
 
 			// Use the multiple deployment method which supports comma-separated UUIDs
 			options := &clientpkg.DeployApplicationOptions{
-				Force:  force,
-				Branch: branch,
+				ForceRebuild: noCache,
+				Branch:       branch,
+			}
+
+			hooks := loadDeployHooks(cmd)
+			for _, uuid := range args {
+				if err := hooks.run(ctx, config.HookBeforeDeploy, hookRun{AppUUID: uuid, Status: "pending"}); err != nil {
+					hooks.fail(ctx, hookRun{AppUUID: uuid, Status: hookStatusHookFailed})
+					return err
+				}
 			}
 
 			deployResponse, err := client.Deployments().DeployMultiple(ctx, args, options)
 			if err != nil {
+				for _, uuid := range args {
+					hooks.fail(ctx, hookRun{AppUUID: uuid, Status: hookStatusError})
+				}
 				return fmt.Errorf("failed to deploy multiple applications: %w", err)
 			}
 
 			if deployResponse != nil && len(deployResponse.Deployments) > 0 {
-				fmt.Printf("✅ Deployments triggered successfully for %d applications/services\n", len(args))
+				theme.Printf("✅ Deployments triggered successfully for %d applications/services\n", len(args))
 				for i, deployment := range deployResponse.Deployments {
-					fmt.Printf("   %d. 📦 Deployment UUID: %s\n", i+1, deployment.DeploymentUUID)
-					fmt.Printf("      🎯 Resource UUID:   %s\n", deployment.ResourceUUID)
+					theme.Printf("   %d. 📦 Deployment UUID: %s\n", i+1, deployment.DeploymentUUID)
+					theme.Printf("      🎯 Resource UUID:   %s\n", deployment.ResourceUUID)
 					if deployment.Message != "" {
-						fmt.Printf("      �� Message:         %s\n", deployment.Message)
+						theme.Printf("      💬 Message:         %s\n", deployment.Message)
 					}
 				}
 			} else {
-				fmt.Printf("✅ Deployments triggered successfully for %d applications/services\n", len(args))
+				theme.Printf("✅ Deployments triggered successfully for %d applications/services\n", len(args))
 			}
 
-			return nil
+			if deployResponse == nil || len(deployResponse.Deployments) == 0 {
+				for _, uuid := range args {
+					if err := runAfterDeployHooks(ctx, hooks, uuid, nil); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			return runAfterDeployHooks(ctx, hooks, "", deployResponse.Deployments)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force deployment even if one is already running")
+	addDeployNoCacheFlag(cmd)
 	cmd.Flags().StringVarP(&branch, "branch", "b", "", "Deploy from specific branch/tag")
+	addDeployHookFlags(cmd)
+	addDeployGuardFlags(cmd)
 
 	return cmd
 }
+
+// addDeployNoCacheFlag adds the --no-cache flag, read with cmd.Flags() so it also works when the
+// deploy-app alias runs the command
+func addDeployNoCacheFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("no-cache", false, "Rebuild without the build cache (Coolify force rebuild)")
+}
+
+// printDeployForceFlags shows which of --force and --no-cache apply to a deployment, since both
+// are easily mistaken for each other
+func printDeployForceFlags(force, noCache bool) {
+	if force {
+		fmt.Printf("   Running-deployment check: skipped (--force)\n")
+	}
+	if noCache {
+		fmt.Printf("   Build cache: disabled, forcing a rebuild (--no-cache)\n")
+	}
+}
+
+// forceRebuildLabel describes the force rebuild setting of a deployment
+func forceRebuildLabel(forceRebuild bool) string {
+	if forceRebuild {
+		return "yes (built without cache)"
+	}
+	return "no"
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// deploymentArchive is the metadata file written for every exported deployment
+type deploymentArchive struct {
+	ExportedAt time.Time `json:"exported_at"`
+	ExportedBy string    `json:"exported_by"`
+	Instance   string    `json:"instance"`
+	// LogFile is the name of the raw log file next to the metadata file
+	LogFile   string `json:"log_file"`
+	LogSHA256 string `json:"log_sha256"`
+	// Complete is false when the deployment was still queued or running, so its log may grow
+	Complete   bool                                `json:"complete"`
+	Deployment *coolify.ApplicationDeploymentQueue `json:"deployment"`
+}
+
+func deployExportCmd() *cobra.Command {
+	var dir, appUUID string
+	var last int
+
+	cmd := &cobra.Command{
+		Use:   "export [deployment-uuid...]",
+		Short: "Save deployment metadata and logs to disk",
+		Long: `Save the metadata and full logs of deployments to disk for archiving, e.g. to keep deployment
+records for compliance. Each deployment is written to its own directory:
+
+  <dir>/<application>/<created-at>_<deployment-uuid>/
+    deployment.json   metadata: status, commit, server, timestamps, and the SHA-256 of the log
+    deployment.log    the log as returned by Coolify
+
+Export specific deployments by UUID, or the most recent ones of an application with --app and
+--last. Exporting a deployment again overwrites its directory. Deployments that are still queued
+or running are exported with "complete": false.
+
+Examples:
+  coolifyme deploy export <deployment-uuid> --dir ./artifacts
+  coolifyme deploy export --app <app-uuid> --last 20 --dir ./artifacts`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) == 0 && appUUID == "" {
+				return fmt.Errorf("specify deployment UUIDs or --app")
+			}
+			if len(args) > 0 && appUUID != "" {
+				return fmt.Errorf("specify either deployment UUIDs or --app, not both")
+			}
+			if last < 1 {
+				return fmt.Errorf("--last must be at least 1")
+			}
+
+			client, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+
+			ctx := context.Background()
+			uuids := args
+			if appUUID != "" {
+				history, err := client.Deployments().History(ctx, appUUID, last)
+				if err != nil {
+					return fmt.Errorf("failed to list deployments: %w", err)
+				}
+				for _, deployment := range history {
+					if deployment.DeploymentUuid != nil {
+						uuids = append(uuids, *deployment.DeploymentUuid)
+					}
+				}
+				if len(uuids) == 0 {
+					fmt.Printf("Application %s has no deployments\n", appUUID)
+					return nil
+				}
+			}
+
+			failed := 0
+			for _, deploymentUUID := range uuids {
+				path, err := exportDeployment(ctx, client, deploymentUUID, dir)
+				if err != nil {
+					theme.Printf("❌ %s: %v\n", deploymentUUID, err)
+					failed++
+					continue
+				}
+				theme.Printf("📦 %s → %s\n", deploymentUUID, path)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("failed to export %d of %d deployment(s)", failed, len(uuids))
+			}
+			theme.Printf("✅ Exported %d deployment(s) to %s\n", len(uuids), dir)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", ".", "Directory to write the archive to")
+	cmd.Flags().StringVar(&appUUID, "app", "", "Export the most recent deployments of this application")
+	cmd.Flags().IntVar(&last, "last", 10, "Number of recent deployments to export with --app")
+
+	return cmd
+}
+
+// exportDeployment writes the metadata and log of a deployment below dir and returns the
+// directory it was written to
+func exportDeployment(ctx context.Context, client *clientpkg.Client, deploymentUUID, dir string) (string, error) {
+	deployment, err := client.Deployments().GetByUUID(ctx, deploymentUUID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	logs := ""
+	if deployment.Logs != nil {
+		logs = *deployment.Logs
+	}
+	metadata := *deployment
+	metadata.Logs = nil
+
+	archive := deploymentArchive{
+		ExportedAt: time.Now().UTC(),
+		ExportedBy: "coolifyme/" + Version,
+		Instance:   client.BaseURL(),
+		LogFile:    "deployment.log",
+		LogSHA256:  fmt.Sprintf("%x", sha256.Sum256([]byte(logs))),
+		Complete:   !clientpkg.DeploymentPending(stringOrDash(deployment.Status)),
+		Deployment: &metadata,
+	}
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	target := filepath.Join(dir, archiveApplicationDir(deployment), archiveDeploymentDir(deployment, deploymentUUID))
+	if err := os.MkdirAll(target, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "deployment.json"), append(data, '\n'), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write metadata: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(target, archive.LogFile), []byte(logs), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write log: %w", err)
+	}
+	return target, nil
+}
+
+// archiveApplicationDir names the directory grouping the deployments of an application
+func archiveApplicationDir(deployment *coolify.ApplicationDeploymentQueue) string {
+	switch {
+	case deployment.ApplicationName != nil && *deployment.ApplicationName != "":
+		return archivePathElement(*deployment.ApplicationName)
+	case deployment.ApplicationId != nil && *deployment.ApplicationId != "":
+		return "application-" + archivePathElement(*deployment.ApplicationId)
+	}
+	return "unknown-application"
+}
+
+// archiveDeploymentDir names the directory of a deployment so that directories sort by time
+func archiveDeploymentDir(deployment *coolify.ApplicationDeploymentQueue, deploymentUUID string) string {
+	name := archivePathElement(deploymentUUID)
+	if deployment.CreatedAt != nil {
+		if t, err := time.Parse(time.RFC3339Nano, *deployment.CreatedAt); err == nil {
+			name = t.UTC().Format("20060102T150405Z") + "_" + name
+		}
+	}
+	return name
+}
+
+// archivePathElement makes a name safe to use as a single path element
+func archivePathElement(name string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '-'
+	}, name)
+	if strings.Trim(safe, ".") == "" {
+		return "unnamed"
+	}
+	return safe
+}
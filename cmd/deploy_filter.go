@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// deploymentStatusFilters maps the --status values to the deployment statuses they match
+var deploymentStatusFilters = map[string]func(status string) bool{
+	"running":  func(status string) bool { return status == "in_progress" },
+	"queued":   func(status string) bool { return status == "queued" },
+	"failed":   clientpkg.DeploymentFailed,
+	"finished": clientpkg.DeploymentSucceeded,
+}
+
+// endedDeploymentStatuses are the --status values of deployments that have ended. The list of all
+// deployments only contains running ones, so these are looked up in the history of --app.
+var endedDeploymentStatuses = map[string]bool{"failed": true, "finished": true}
+
+// deploymentHistoryLimit bounds the history read for ended deployments when --since is not given
+const deploymentHistoryLimit = 100
+
+// deploymentFilter selects deployments by status, server, application and creation time. Empty
+// fields match every deployment.
+type deploymentFilter struct {
+	statuses []func(status string) bool
+
+	serverID   string
+	serverName string
+
+	appID   string
+	appName string
+	appUUID string
+
+	since time.Time
+
+	// history is set when ended deployments are asked for, which only the history of an
+	// application contains
+	history bool
+}
+
+// addDeploymentFilterFlags adds the flags narrowing down a list of deployments
+func addDeploymentFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("status", nil, "Only include deployments with this status (running, queued, failed, finished)")
+	cmd.Flags().String("server", "", "Only include deployments on this server (UUID, UUID prefix or name)")
+	cmd.Flags().String("app", "", "Only include deployments of this application (UUID, UUID prefix or name)")
+	cmd.Flags().String("since", "", "Only include deployments created after this time (e.g. 30m, 2h, 1d or 2024-01-15T10:30:00)")
+}
+
+// parseDeploymentFilter reads the filter flags of a command, resolving the server and
+// application to the IDs deployments refer to them by
+func parseDeploymentFilter(ctx context.Context, cmd *cobra.Command, client *clientpkg.Client, now time.Time) (*deploymentFilter, error) {
+	filter := &deploymentFilter{}
+
+	statuses, _ := cmd.Flags().GetStringSlice("status")
+	for _, status := range statuses {
+		match, ok := deploymentStatusFilters[strings.ToLower(strings.TrimSpace(status))]
+		if !ok {
+			return nil, fmt.Errorf("invalid --status '%s' (use running, queued, failed or finished)", status)
+		}
+		filter.statuses = append(filter.statuses, match)
+		filter.history = filter.history || endedDeploymentStatuses[strings.ToLower(strings.TrimSpace(status))]
+	}
+
+	if since, _ := cmd.Flags().GetString("since"); since != "" {
+		t, err := parseLogTime("since", since, now)
+		if err != nil {
+			return nil, err
+		}
+		filter.since = t
+	}
+
+	if server, _ := cmd.Flags().GetString("server"); server != "" {
+		ref, err := resolveResourceRef(ctx, client, clientpkg.KindServer, server)
+		if err != nil {
+			return nil, err
+		}
+		filter.serverName = ref.Name
+		details, err := client.Servers().Get(ctx, ref.UUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get server %s: %w", server, err)
+		}
+		if details != nil && details.Id != nil {
+			filter.serverID = strconv.Itoa(*details.Id)
+		}
+	}
+
+	if app, _ := cmd.Flags().GetString("app"); app != "" {
+		ref, err := resolveResourceRef(ctx, client, clientpkg.KindApplication, app)
+		if err != nil {
+			return nil, err
+		}
+		filter.appName = ref.Name
+		filter.appUUID = ref.UUID
+		details, err := client.Applications().Get(ctx, ref.UUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get application %s: %w", app, err)
+		}
+		if details != nil && details.Id != nil {
+			filter.appID = strconv.Itoa(*details.Id)
+		}
+	}
+
+	if filter.history && filter.appUUID == "" {
+		return nil, fmt.Errorf("--status failed and finished require --app: Coolify only lists running deployments across applications")
+	}
+	return filter, nil
+}
+
+// list returns the deployments the filter is applied to: the running deployments, or the
+// history of the --app application when ended deployments are asked for, back to --since or
+// the last deploymentHistoryLimit deployments
+func (f *deploymentFilter) list(ctx context.Context, client *clientpkg.Client) ([]coolify.ApplicationDeploymentQueue, error) {
+	if !f.history {
+		return client.Deployments().ListAll(ctx)
+	}
+
+	var deployments []coolify.ApplicationDeploymentQueue
+	for deployment, err := range client.Deployments().ListIter(f.appUUID, 0).All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		if f.since.IsZero() {
+			if len(deployments) == deploymentHistoryLimit {
+				break
+			}
+		} else if created, ok := parseAPITime(deployment.CreatedAt); ok && created.Before(f.since) {
+			break
+		}
+		deployments = append(deployments, deployment)
+	}
+	return deployments, nil
+}
+
+// matches reports whether a deployment passes the filter. Deployments refer to their server and
+// application by numeric ID; when the API leaves the ID out, the name is compared instead.
+// Deployments without a parseable creation time never match --since.
+func (f *deploymentFilter) matches(deployment coolify.ApplicationDeploymentQueue) bool {
+	if len(f.statuses) > 0 {
+		status := strings.ToLower(stringOrDash(deployment.Status))
+		matched := false
+		for _, match := range f.statuses {
+			if match(status) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if f.serverName != "" || f.serverID != "" {
+		if deployment.ServerId != nil && f.serverID != "" {
+			if strconv.Itoa(*deployment.ServerId) != f.serverID {
+				return false
+			}
+		} else if !strings.EqualFold(stringOrDash(deployment.ServerName), f.serverName) {
+			return false
+		}
+	}
+
+	if f.appName != "" || f.appID != "" {
+		if deployment.ApplicationId != nil && f.appID != "" {
+			if *deployment.ApplicationId != f.appID {
+				return false
+			}
+		} else if !strings.EqualFold(stringOrDash(deployment.ApplicationName), f.appName) {
+			return false
+		}
+	}
+
+	if !f.since.IsZero() {
+		created, ok := parseAPITime(deployment.CreatedAt)
+		if !ok || created.Before(f.since) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterDeployments returns the deployments passing the filter
+func filterDeployments(deployments []coolify.ApplicationDeploymentQueue, filter *deploymentFilter) []coolify.ApplicationDeploymentQueue {
+	filtered := make([]coolify.ApplicationDeploymentQueue, 0, len(deployments))
+	for _, deployment := range deployments {
+		if filter.matches(deployment) {
+			filtered = append(filtered, deployment)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/logger"
+	"github.com/hongkongkiwi/coolifyme/internal/report"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// deployGuardHistory is the number of recent deployments checked for queued or running ones
+const deployGuardHistory = 10
+
+// addDeployGuardFlags adds the flag skipping deployments of applications that are already deploying
+func addDeployGuardFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("if-not-running", false, "Skip the deployment when one is already queued or running for the application")
+}
+
+// guardDeployment checks whether an application already has queued or running deployments,
+// which happens when CI triggers the same deploy twice. Without --if-not-running it only warns;
+// with it the deployment is skipped and the active deployments are returned so callers can wait
+// for them instead. --force skips the check. Failing to check never blocks a deployment.
+func guardDeployment(ctx context.Context, cmd *cobra.Command, client *clientpkg.Client, appUUID string, force bool) ([]clientpkg.DeploymentResult, bool) {
+	if force {
+		return nil, false
+	}
+
+	history, err := client.Deployments().History(ctx, appUUID, deployGuardHistory)
+	if err != nil {
+		logger.Debug("Could not check for running deployments", "app", appUUID, "error", err)
+		return nil, false
+	}
+
+	var active []clientpkg.DeploymentResult
+	for _, deployment := range history {
+		status := stringOrDash(deployment.Status)
+		if !clientpkg.DeploymentPending(status) || deployment.DeploymentUuid == nil {
+			continue
+		}
+		if len(active) == 0 {
+			theme.Printf("⚠️  Application %s is already deploying:\n", appUUID)
+		}
+		age := ""
+		if deployment.CreatedAt != nil {
+			if t, err := time.Parse(time.RFC3339Nano, *deployment.CreatedAt); err == nil {
+				age = ", requested " + time.Since(t).Round(time.Second).String() + " ago"
+			}
+		}
+		theme.Printf("   📦 %s (%s%s)\n", *deployment.DeploymentUuid, status, age)
+		active = append(active, clientpkg.DeploymentResult{DeploymentUUID: *deployment.DeploymentUuid, ResourceUUID: appUUID})
+	}
+	if len(active) == 0 {
+		return nil, false
+	}
+
+	if ifNotRunning, _ := cmd.Flags().GetBool("if-not-running"); ifNotRunning {
+		theme.Println("⏭️  Skipping the deployment (--if-not-running)")
+		return active, true
+	}
+	theme.Println("   The new deployment is queued after it; use --if-not-running to skip instead")
+	return nil, false
+}
+
+// waitForActiveDeployments waits for deployments that were already running when a deployment was
+// skipped, then probes the application's domains with --verify-http. Deploy hooks do not run for
+// them since coolifyme did not trigger them.
+func waitForActiveDeployments(ctx context.Context, cmd *cobra.Command, client *clientpkg.Client, deployReport *report.Report, deployments []clientpkg.DeploymentResult) error {
+	timeout, _ := cmd.Flags().GetDuration("wait-timeout")
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	started := time.Now()
+	for _, deployment := range deployments {
+		result, _ := waitForDeployment(waitCtx, client, deployment, started)
+		deployReport.Add(result)
+	}
+
+	failures := deployReport.Failures()
+	var verifyErr error
+	if verifyHTTP, _ := cmd.Flags().GetBool("verify-http"); verifyHTTP && failures == 0 && len(deployments) > 0 {
+		verifyErr = verifyDeploymentHTTP(ctx, cmd, client, deployments[0].ResourceUUID, deployReport)
+	}
+
+	if err := writeReportFile(cmd, deployReport); err != nil {
+		return err
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d of %d deployment(s) failed", failures, len(deployments))
+	}
+	return verifyErr
+}
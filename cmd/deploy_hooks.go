@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+// Statuses passed to on-failure hooks when there is no deployment status
+const (
+	// hookStatusHookFailed means a before-deploy hook failed and nothing was deployed
+	hookStatusHookFailed = "hook-failed"
+	// hookStatusError means the deployment could not be triggered or waited for
+	hookStatusError = "error"
+	// hookStatusVerifyFailed means the deployment finished but its domains did not respond as
+	// expected to --verify-http
+	hookStatusVerifyFailed = "verify-failed"
+)
+
+// deployHooks runs the hooks configured for deployments in the config file
+type deployHooks struct {
+	hooks config.Hooks
+}
+
+// hookRun describes the deployment a hook runs for; it is passed in environment variables
+type hookRun struct {
+	AppUUID        string
+	DeploymentUUID string
+	Status         string
+}
+
+// addDeployHookFlags adds the flag disabling the configured deployment hooks
+func addDeployHookFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("no-hooks", false, "Do not run the deployment hooks configured in the config file")
+}
+
+// loadDeployHooks returns the configured deployment hooks, or none with --no-hooks
+func loadDeployHooks(cmd *cobra.Command) *deployHooks {
+	if noHooks, _ := cmd.Flags().GetBool("no-hooks"); noHooks {
+		return &deployHooks{}
+	}
+	// Without a config file there are no hooks to run
+	hooks, _ := config.GetHooks()
+	return &deployHooks{hooks: hooks}
+}
+
+// run runs the hooks of an event in order, stopping at the first failing one
+func (h *deployHooks) run(ctx context.Context, event string, run hookRun) error {
+	for _, command := range h.hooks.Commands(event) {
+		fmt.Fprint(os.Stderr, theme.Sprintf("🔌 Running %s hook for %s: %s\n", event, run.AppUUID, command))
+		env := []string{
+			"COOLIFYME_HOOK=" + event,
+			"APP_UUID=" + run.AppUUID,
+			"DEPLOYMENT_UUID=" + run.DeploymentUUID,
+			"STATUS=" + run.Status,
+		}
+		if err := runShellHook(ctx, command, env); err != nil {
+			return fmt.Errorf("%s %w", event, err)
+		}
+	}
+	return nil
+}
+
+// fail runs the on-failure hooks. Their errors are only reported, the deployment error that
+// triggered them is the one returned by the command.
+func (h *deployHooks) fail(ctx context.Context, run hookRun) {
+	if err := h.run(ctx, config.HookOnFailure, run); err != nil {
+		warn("on-failure hook", err)
+	}
+}
+
+// runShellHook runs a command from the config file with the shell, adding env to the environment.
+// Its output goes to standard error, so it cannot corrupt the output of the command.
+func runShellHook(ctx context.Context, command string, env []string) error {
+	var hook *exec.Cmd
+	if runtime.GOOS == "windows" {
+		hook = exec.CommandContext(ctx, "cmd", "/C", command) // #nosec G204 -- command comes from the user's config
+	} else {
+		hook = exec.CommandContext(ctx, "sh", "-c", command) // #nosec G204 -- command comes from the user's config
+	}
+	hook.Env = append(os.Environ(), env...)
+	hook.Stdout = os.Stderr
+	hook.Stderr = os.Stderr
+
+	if err := hook.Run(); err != nil {
+		return fmt.Errorf("hook '%s' failed: %w", command, err)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/githost"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// deploymentPreview describes what the next deployment of an application would include
+type deploymentPreview struct {
+	Application  string `json:"application"`
+	Repository   string `json:"repository"`
+	Branch       string `json:"branch"`
+	DeployedSHA  string `json:"deployed_sha"`
+	DeployedAt   string `json:"deployed_at,omitempty"`
+	Target       string `json:"target"`
+	PinnedCommit bool   `json:"pinned_commit"`
+	*githost.Comparison
+}
+
+func deployPreviewCmd() *cobra.Command {
+	var provider, gitToken string
+
+	cmd := &cobra.Command{
+		Use:   "preview <app-uuid>",
+		Short: "Show what the next deployment would include",
+		Long: `Compare the commit of the last successful deployment of an application with the tip of its
+configured branch (or its pinned commit) and list the commits and changed files the next
+deployment would include.
+
+The comparison uses the GitHub or GitLab API, detected from the repository URL. For self-hosted
+instances on other domains, set --provider. Private repositories need a token from --git-token,
+or the GITHUB_TOKEN or GITLAB_TOKEN environment variable. GitHub returns at most 250 commits and
+300 files per comparison.
+
+Examples:
+  coolifyme deploy preview <app-uuid>
+  coolifyme deploy preview <app-uuid> --provider gitlab --git-token $TOKEN
+  coolifyme deploy preview <app-uuid> --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+
+			ctx := context.Background()
+			app, err := client.Applications().Get(ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to get application: %w", err)
+			}
+			if app.GitRepository == nil || *app.GitRepository == "" {
+				return fmt.Errorf("application %s is not deployed from a git repository", stringOrDash(app.Name))
+			}
+
+			repo, err := githost.Parse(*app.GitRepository, githost.Provider(strings.ToLower(provider)))
+			if err != nil {
+				return err
+			}
+
+			preview, err := newDeploymentPreview(ctx, client, app, args[0])
+			if err != nil {
+				return err
+			}
+
+			if gitToken == "" {
+				if repo.Provider == githost.GitLab {
+					gitToken = os.Getenv("GITLAB_TOKEN")
+				} else {
+					gitToken = os.Getenv("GITHUB_TOKEN")
+				}
+			}
+			gitClient := &githost.Client{Token: gitToken}
+			preview.Comparison, err = gitClient.Compare(ctx, repo, preview.DeployedSHA, preview.Target)
+			if err != nil {
+				return fmt.Errorf("failed to compare %s with %s: %w", shortCommit(preview.DeployedSHA), preview.Target, err)
+			}
+
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			if jsonOutput {
+				output, err := json.MarshalIndent(preview, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", err)
+				}
+				fmt.Println(string(output))
+				return nil
+			}
+
+			printDeploymentPreview(preview)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&provider, "provider", "", "Git hosting service of the repository: github or gitlab (default: detected from the URL)")
+	cmd.Flags().StringVar(&gitToken, "git-token", "", "Token for the git hosting API (default: $GITHUB_TOKEN or $GITLAB_TOKEN)")
+	cmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+
+	return cmd
+}
+
+// newDeploymentPreview finds the deployed commit and the revision the next deployment would build
+func newDeploymentPreview(ctx context.Context, client *clientpkg.Client, app *coolify.Application, appUUID string) (*deploymentPreview, error) {
+	preview := &deploymentPreview{
+		Application: stringOrDash(app.Name),
+		Repository:  *app.GitRepository,
+		Branch:      stringOrDash(app.GitBranch),
+	}
+
+	// Applications pinned to a commit deploy that commit instead of the branch tip
+	preview.Target = preview.Branch
+	if app.GitCommitSha != nil && *app.GitCommitSha != "" && !strings.EqualFold(*app.GitCommitSha, "HEAD") {
+		preview.Target = *app.GitCommitSha
+		preview.PinnedCommit = true
+	}
+	if preview.Target == "-" {
+		return nil, fmt.Errorf("application %s has no git branch configured", preview.Application)
+	}
+
+	deployments, err := client.Deployments().History(ctx, appUUID, 50)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, d := range deployments {
+		if d.Status == nil || *d.Status != "finished" || d.Commit == nil || *d.Commit == "" || strings.EqualFold(*d.Commit, "HEAD") {
+			continue
+		}
+		// Pull request previews build other branches
+		if d.PullRequestId != nil && *d.PullRequestId > 0 {
+			continue
+		}
+		preview.DeployedSHA = *d.Commit
+		if d.CreatedAt != nil {
+			preview.DeployedAt = *d.CreatedAt
+		}
+		return preview, nil
+	}
+	return nil, fmt.Errorf("no successful deployment with a known commit found for %s", preview.Application)
+}
+
+// printDeploymentPreview prints the commits and files of a deployment preview
+func printDeploymentPreview(preview *deploymentPreview) {
+	theme.Printf("🔍 Next deployment of %s\n", preview.Application)
+	fmt.Printf("   Repository: %s\n", preview.Repository)
+	deployed := shortCommit(preview.DeployedSHA)
+	if preview.DeployedAt != "" {
+		deployed += " (" + formatDeploymentTime(preview.DeployedAt) + ")"
+	}
+	fmt.Printf("   Deployed:   %s\n", deployed)
+	if preview.PinnedCommit {
+		fmt.Printf("   Next:       pinned commit %s\n", shortCommit(preview.Target))
+	} else {
+		fmt.Printf("   Next:       tip of %s\n", preview.Target)
+	}
+	if preview.URL != "" {
+		fmt.Printf("   Compare:    %s\n", preview.URL)
+	}
+	fmt.Println()
+
+	if len(preview.Commits) == 0 {
+		theme.Println("✅ Already up to date, the next deployment would rebuild the deployed commit")
+		return
+	}
+
+	theme.Printf("📝 Commits (%d):\n", len(preview.Commits))
+	for _, commit := range preview.Commits {
+		message, _, _ := strings.Cut(commit.Message, "\n")
+		line := fmt.Sprintf("   %s %s", shortCommit(commit.SHA), message)
+		if commit.Author != "" {
+			line += " (" + commit.Author + ")"
+		}
+		fmt.Println(line)
+	}
+
+	if len(preview.Files) > 0 {
+		fmt.Println()
+		theme.Printf("📁 Files changed (%d):\n", len(preview.Files))
+		for _, file := range preview.Files {
+			line := fmt.Sprintf("   %s %s", fileStatusLetter(file.Status), file.Path)
+			if file.Additions > 0 || file.Deletions > 0 {
+				line += fmt.Sprintf("  +%d -%d", file.Additions, file.Deletions)
+			}
+			fmt.Println(line)
+		}
+	}
+}
+
+// fileStatusLetter abbreviates a file status like git --name-status
+func fileStatusLetter(status string) string {
+	switch status {
+	case "added":
+		return "A"
+	case "removed":
+		return "D"
+	case "renamed":
+		return "R"
+	case "copied":
+		return "C"
+	}
+	return "M"
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// queuedDeployment is a pending deployment with its position in the queue of its server
+type queuedDeployment struct {
+	Server         string `json:"server"`
+	Position       string `json:"position"`
+	Application    string `json:"application"`
+	DeploymentUUID string `json:"deployment_uuid"`
+	Status         string `json:"status"`
+	RequestedAt    string `json:"requested_at"`
+	Age            string `json:"age"`
+}
+
+func deployQueueCmd() *cobra.Command {
+	var server string
+
+	cmd := &cobra.Command{
+		Use:   "queue",
+		Short: "Show the pending deployment queue",
+		Long: `Show queued and running deployments grouped by server, in the order they will be processed.
+
+Running deployments are shown first with their age, so a stuck deployment blocking a busy build
+server is easy to spot and cancel with 'deploy queue cancel'. The Coolify API does not support
+reordering the queue; cancel and re-trigger deployments to change their order.
+
+Examples:
+  coolifyme deploy queue
+  coolifyme deploy queue --server build-1
+  coolifyme deploy queue cancel <deployment-uuid>`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			client, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+
+			queue, err := collectDeploymentQueue(context.Background(), client, server)
+			if err != nil {
+				return err
+			}
+
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			if jsonOutput {
+				output, err := json.MarshalIndent(queue, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", err)
+				}
+				fmt.Println(string(output))
+				return nil
+			}
+
+			if len(queue) == 0 {
+				fmt.Println("No queued or running deployments")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			defer func() {
+				_ = w.Flush()
+			}()
+
+			_, _ = fmt.Fprintln(w, "SERVER\tPOSITION\tAPPLICATION\tDEPLOYMENT UUID\tSTATUS\tREQUESTED\tAGE")
+			_, _ = fmt.Fprintln(w, "------\t--------\t-----------\t---------------\t------\t---------\t---")
+			for _, item := range queue {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+					item.Server, item.Position, item.Application, item.DeploymentUUID, item.Status, item.RequestedAt, item.Age)
+			}
+			return nil
+		},
+	}
+
+	cmd.AddCommand(deployQueueCancelCmd())
+
+	cmd.Flags().StringVar(&server, "server", "", "Only show the queue of this server (name)")
+	// The server of .coolifyme.yaml is usually a UUID, which does not match a name
+	_ = cmd.Flags().SetAnnotation("server", noLocalDefaultAnnotation, []string{"true"})
+	cmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+
+	return cmd
+}
+
+func deployQueueCancelCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel <deployment-uuid> [deployment-uuid...]",
+		Short: "Cancel queued or running deployments",
+		Long:  "Cancel queued or running deployments by UUID. Requires a Coolify version that supports cancelling deployments through the API.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+
+			message := fmt.Sprintf("Are you sure you want to cancel %d deployment(s)?", len(args))
+			if !confirm.Action(message, skipConfirmation(cmd)) {
+				theme.Println("❌ Cancel aborted")
+				return nil
+			}
+
+			ctx := context.Background()
+			failed := 0
+			for _, deploymentUUID := range args {
+				if err := client.Deployments().Cancel(ctx, deploymentUUID); err != nil {
+					failed++
+					theme.Printf("❌ %s: %v\n", deploymentUUID, err)
+					continue
+				}
+				theme.Printf("✅ Deployment %s cancelled\n", deploymentUUID)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("failed to cancel %d of %d deployment(s)", failed, len(args))
+			}
+			return nil
+		},
+	}
+
+	addConfirmFlags(cmd, "Cancel without confirmation")
+
+	return cmd
+}
+
+// collectDeploymentQueue lists pending deployments grouped by server, running ones first and
+// queued ones in the order they were requested
+func collectDeploymentQueue(ctx context.Context, client *clientpkg.Client, server string) ([]queuedDeployment, error) {
+	deployments, err := client.Deployments().ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	type pending struct {
+		item      queuedDeployment
+		running   bool
+		requested time.Time
+		id        int
+	}
+
+	var items []pending
+	for _, deployment := range deployments {
+		status := stringOrDash(deployment.Status)
+		if !clientpkg.DeploymentPending(status) {
+			continue
+		}
+
+		serverName := stringOrDash(deployment.ServerName)
+		if server != "" && !strings.EqualFold(server, serverName) {
+			continue
+		}
+
+		p := pending{
+			item: queuedDeployment{
+				Server:         serverName,
+				Application:    stringOrDash(deployment.ApplicationName),
+				DeploymentUUID: stringOrDash(deployment.DeploymentUuid),
+				Status:         status,
+				RequestedAt:    "-",
+				Age:            "-",
+			},
+			running: status == "in_progress",
+		}
+		if deployment.Id != nil {
+			p.id = *deployment.Id
+		}
+		if deployment.CreatedAt != nil {
+			p.item.RequestedAt = formatDeploymentTime(*deployment.CreatedAt)
+			if t, err := time.Parse(time.RFC3339Nano, *deployment.CreatedAt); err == nil {
+				p.requested = t
+				p.item.Age = time.Since(t).Round(time.Second).String()
+			}
+		}
+		items = append(items, p)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.item.Server != b.item.Server {
+			return a.item.Server < b.item.Server
+		}
+		if a.running != b.running {
+			return a.running
+		}
+		if !a.requested.Equal(b.requested) {
+			return a.requested.Before(b.requested)
+		}
+		return a.id < b.id
+	})
+
+	queue := make([]queuedDeployment, 0, len(items))
+	position := 0
+	for i, p := range items {
+		if i == 0 || p.item.Server != items[i-1].item.Server {
+			position = 0
+		}
+		if p.running {
+			p.item.Position = "running"
+		} else {
+			position++
+			p.item.Position = strconv.Itoa(position)
+		}
+		queue = append(queue, p.item)
+	}
+	return queue, nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+)
+
+// timedDeployment is a deployment with the durations derived from its timestamps; the JSON
+// output carries them next to the API fields
+type timedDeployment struct {
+	coolify.ApplicationDeploymentQueue
+	DurationSeconds  *float64 `json:"duration_seconds,omitempty"`
+	QueueWaitSeconds *float64 `json:"queue_wait_seconds,omitempty"`
+
+	// duration is how long the deployment took, or has been running until now when running is set
+	duration  time.Duration
+	running   bool
+	queueWait time.Duration
+	hasWait   bool
+}
+
+// timeDeployments derives the durations of deployments. A finished deployment took from its
+// creation to its last update; a running one is timed until now. The queue wait is the time from
+// the creation to the first log line, when the logs are included.
+func timeDeployments(deployments []coolify.ApplicationDeploymentQueue, now time.Time) []timedDeployment {
+	timed := make([]timedDeployment, 0, len(deployments))
+	for _, deployment := range deployments {
+		t := timedDeployment{ApplicationDeploymentQueue: deployment}
+		created, createdOK := parseAPITime(deployment.CreatedAt)
+		updated, updatedOK := parseAPITime(deployment.UpdatedAt)
+		status := stringOrDash(deployment.Status)
+
+		switch {
+		case !createdOK:
+		case (clientpkg.DeploymentSucceeded(status) || clientpkg.DeploymentFailed(status)) && updatedOK && !updated.Before(created):
+			t.duration = updated.Sub(created)
+		case status == "in_progress":
+			t.duration, t.running = now.Sub(created), true
+		}
+		if t.duration > 0 && !t.running {
+			seconds := t.duration.Seconds()
+			t.DurationSeconds = &seconds
+		}
+
+		if started, ok := firstDeploymentLogTime(deployment.Logs); ok && createdOK && !started.Before(created) {
+			t.queueWait, t.hasWait = started.Sub(created), true
+			seconds := t.queueWait.Seconds()
+			t.QueueWaitSeconds = &seconds
+		}
+		timed = append(timed, t)
+	}
+	return timed
+}
+
+// sortTimedDeployments sorts deployments by created (newest first, the API order), duration or
+// queue wait (longest first)
+func sortTimedDeployments(deployments []timedDeployment, sortBy string) error {
+	switch sortBy {
+	case "", "created":
+		return nil
+	case "duration":
+		slices.SortStableFunc(deployments, func(a, b timedDeployment) int { return cmp.Compare(b.duration, a.duration) })
+	case "queue":
+		slices.SortStableFunc(deployments, func(a, b timedDeployment) int { return cmp.Compare(b.queueWait, a.queueWait) })
+	default:
+		return fmt.Errorf("invalid --sort-by '%s' (use created, duration or queue)", sortBy)
+	}
+	return nil
+}
+
+// durationColumn formats the duration of a deployment; + marks deployments still running
+func (t timedDeployment) durationColumn() string {
+	if t.duration <= 0 {
+		return "-"
+	}
+	if t.running {
+		return formatDeploymentDuration(t.duration) + "+"
+	}
+	return formatDeploymentDuration(t.duration)
+}
+
+// queueColumn formats the queue wait of a deployment
+func (t timedDeployment) queueColumn() string {
+	if !t.hasWait {
+		return "-"
+	}
+	return formatDeploymentDuration(t.queueWait)
+}
+
+// formatDeploymentDuration formats a duration to the second, e.g. 4m12s
+func formatDeploymentDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
+
+// apiTimeLayouts are the timestamp formats returned by Coolify. Depending on the version and the
+// endpoint, timestamps come as RFC 3339 or as database timestamps without a zone, which are UTC.
+var apiTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// parseAPITime parses an optional API timestamp
+func parseAPITime(value *string) (time.Time, bool) {
+	if value == nil {
+		return time.Time{}, false
+	}
+	for _, layout := range apiTimeLayouts {
+		if t, err := time.ParseInLocation(layout, *value, time.UTC); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// firstDeploymentLogTime returns the timestamp of the first entry of deployment logs, which
+// Coolify stores as a JSON array of entries with a timestamp each
+func firstDeploymentLogTime(logs *string) (time.Time, bool) {
+	if logs == nil || *logs == "" {
+		return time.Time{}, false
+	}
+	var entries []struct {
+		Timestamp string `json:"timestamp"`
+	}
+	if err := json.Unmarshal([]byte(*logs), &entries); err != nil {
+		return time.Time{}, false
+	}
+	for _, entry := range entries {
+		if t, err := time.Parse(time.RFC3339Nano, entry.Timestamp); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/report"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// deployVerifyInterval is the time between two HTTP probes of the same URL
+const deployVerifyInterval = 5 * time.Second
+
+// deployVerifyRequestTimeout bounds a single HTTP probe, so a hanging proxy does not use up the
+// whole --verify-timeout in one request
+const deployVerifyRequestTimeout = 10 * time.Second
+
+// addDeployVerifyFlags adds the flags for probing the domains of an application after a deployment
+func addDeployVerifyFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("verify-http", false, "After --wait succeeds, probe the application's domains until they serve the expected status")
+	cmd.Flags().String("path", "/", "Path requested on each domain by --verify-http")
+	cmd.Flags().Int("expect-status", 0, "Status code --verify-http waits for (default any 2xx)")
+	cmd.Flags().Duration("verify-timeout", 5*time.Minute, "Maximum time --verify-http waits for the domains to respond")
+}
+
+// verifyURLs returns the URLs probed for the comma-separated domains of an application. Domains
+// without a scheme are probed over HTTPS.
+func verifyURLs(fqdn, path string) ([]string, error) {
+	var urls []string
+	for _, domain := range strings.Split(fqdn, ",") {
+		domain = strings.TrimSpace(domain)
+		if domain == "" {
+			continue
+		}
+		if !strings.Contains(domain, "://") {
+			domain = "https://" + domain
+		}
+		u, err := url.Parse(domain)
+		if err != nil {
+			return nil, fmt.Errorf("invalid domain '%s': %w", domain, err)
+		}
+		urls = append(urls, u.JoinPath(path).String())
+	}
+	return urls, nil
+}
+
+// verifyDeploymentHTTP probes the domains of an application until each returns the status
+// expected by --expect-status, or until --verify-timeout, and adds a result per domain to the
+// report. It returns an error when a domain did not respond as expected.
+func verifyDeploymentHTTP(ctx context.Context, cmd *cobra.Command, client *clientpkg.Client, appUUID string, deployReport *report.Report) error {
+	path, _ := cmd.Flags().GetString("path")
+	expect, _ := cmd.Flags().GetInt("expect-status")
+	timeout, _ := cmd.Flags().GetDuration("verify-timeout")
+
+	app, err := client.Applications().Get(ctx, appUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get application for --verify-http: %w", err)
+	}
+	if app.Fqdn == nil || strings.TrimSpace(*app.Fqdn) == "" {
+		return fmt.Errorf("cannot verify application %s over HTTP: it has no domains", appUUID)
+	}
+	urls, err := verifyURLs(*app.Fqdn, path)
+	if err != nil {
+		return err
+	}
+
+	expected := "a 2xx status"
+	if expect != 0 {
+		expected = fmt.Sprintf("status %d", expect)
+	}
+	theme.Printf("🌐 Waiting up to %s for %d domain(s) to return %s...\n", timeout, len(urls), expected)
+
+	verifyCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	results := make([]report.Result, len(urls))
+	var wg sync.WaitGroup
+	for i, target := range urls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = probeHTTP(verifyCtx, appUUID, target, expect, expected)
+		}()
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, result := range results {
+		deployReport.Add(result)
+		if result.Status == report.StatusFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d domain(s) did not return %s after the deployment", failed, len(urls), expected)
+	}
+	return nil
+}
+
+// probeHTTP requests a URL until it returns the expected status, any 2xx when expect is 0, or
+// until the context ends. Every change of the outcome is printed.
+func probeHTTP(ctx context.Context, appUUID, target string, expect int, expected string) report.Result {
+	result := report.Result{
+		Name:     "verify " + target,
+		Resource: appUUID,
+	}
+	started := time.Now()
+	httpClient := &http.Client{Timeout: deployVerifyRequestTimeout}
+
+	last := ""
+	for {
+		outcome := ""
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+		if err != nil {
+			result.Status = report.StatusFailed
+			result.Message = err.Error()
+			return result
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			outcome = err.Error()
+		} else {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			_ = resp.Body.Close()
+			outcome = resp.Status
+			if resp.StatusCode == expect || (expect == 0 && resp.StatusCode >= 200 && resp.StatusCode < 300) {
+				result.Duration = time.Since(started)
+				result.Status = report.StatusPassed
+				result.Message = fmt.Sprintf("%s returned %s", target, resp.Status)
+				theme.Printf("✅ %s returned %s after %s\n", target, resp.Status, result.Duration.Round(time.Second))
+				return result
+			}
+		}
+		if outcome != last && ctx.Err() == nil {
+			theme.Printf("   🌐 %s: %s\n", target, outcome)
+			last = outcome
+		}
+
+		select {
+		case <-ctx.Done():
+			result.Duration = time.Since(started)
+			result.Status = report.StatusFailed
+			result.Message = fmt.Sprintf("%s did not return %s within %s (last: %s)", target, expected, result.Duration.Round(time.Second), last)
+			theme.Printf("❌ %s\n", result.Message)
+			return result
+		case <-time.After(deployVerifyInterval):
+		}
+	}
+}
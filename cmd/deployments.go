@@ -46,6 +46,10 @@ var deploymentsListCmd = &cobra.Command{
 			return nil
 		}
 
+		if handled, err := outputWithTemplate(cmd, deployments); handled {
+			return err
+		}
+
 		if len(deployments) == 0 {
 			fmt.Println("No active deployments found")
 			return nil
@@ -177,6 +181,10 @@ var deploymentsListByAppCmd = &cobra.Command{
 			return nil
 		}
 
+		if handled, err := outputWithTemplate(cmd, deployments); handled {
+			return err
+		}
+
 		if len(deployments) == 0 {
 			fmt.Printf("No deployments found for application %s\n", appUUID)
 			return nil
@@ -217,9 +225,9 @@ var deploymentsListByAppCmd = &cobra.Command{
 
 func init() {
 	// Add subcommands to deployments
-	deploymentsCmd.AddCommand(deploymentsListCmd)
+	deploymentsCmd.AddCommand(supportsTemplateOutput(deploymentsListCmd))
 	deploymentsCmd.AddCommand(deploymentsGetCmd)
-	deploymentsCmd.AddCommand(deploymentsListByAppCmd)
+	deploymentsCmd.AddCommand(supportsTemplateOutput(deploymentsListByAppCmd))
 
 	// Flags for list command
 	deploymentsListCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
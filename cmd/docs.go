@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// docsCmd represents the docs command
+var docsCmd = &cobra.Command{
+	Use:    "docs",
+	Short:  "Generate reference documentation",
+	Long:   "Generate the command reference from the command definitions, for packaging and the documentation website",
+	Hidden: true,
+}
+
+// docsGenerateCmd represents the docs generate command
+var docsGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate man pages or markdown for every command",
+	Long: `Generate a man page or markdown file for every command and flag.
+
+Examples:
+  coolifyme docs generate --format man --dir ./docs/man
+  coolifyme docs generate --format markdown --dir ./docs/reference`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		dir, _ := cmd.Flags().GetString("dir")
+
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+
+		// Keep generated files reproducible between builds
+		rootCmd.DisableAutoGenTag = true
+
+		var err error
+		switch format {
+		case "man":
+			err = doc.GenManTree(rootCmd, &doc.GenManHeader{
+				Title:   "COOLIFYME",
+				Section: "1",
+				Source:  "coolifyme " + Version,
+				Manual:  "coolifyme Manual",
+			}, dir)
+		case "markdown", "md":
+			err = doc.GenMarkdownTree(rootCmd, dir)
+		default:
+			return fmt.Errorf("unsupported format '%s' (expected man or markdown)", format)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to generate documentation: %w", err)
+		}
+
+		theme.Printf("✅ Generated %s documentation in %s\n", format, dir)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(docsCmd)
+	docsCmd.AddCommand(docsGenerateCmd)
+
+	// Flags for docs generate command
+	docsGenerateCmd.Flags().String("format", "markdown", "Output format (man, markdown)")
+	docsGenerateCmd.Flags().String("dir", "./docs", "Output directory")
+}
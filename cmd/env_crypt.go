@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/envcrypt"
+	"github.com/spf13/cobra"
+)
+
+// envPassphraseVar is the environment variable holding the passphrase of encrypted .env files
+const envPassphraseVar = "COOLIFYME_ENV_PASSPHRASE"
+
+// envIdentityVar is the environment variable holding the path of the age identity file
+const envIdentityVar = "COOLIFYME_AGE_IDENTITY"
+
+// envFileEncryption describes how a .env file is encrypted, so sync can write it back the same way
+type envFileEncryption struct {
+	scheme     envcrypt.Scheme
+	passphrase string
+	recipients []string
+}
+
+// addEnvEncryptFlags adds the flags selecting how a written .env file is encrypted
+func addEnvEncryptFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray("encrypt", nil, "Encrypt the file for an age recipient (age:<recipient>, repeatable)")
+	cmd.Flags().Bool("encrypt-passphrase", false, "Encrypt the file with a passphrase (AES-256-GCM, from "+envPassphraseVar+" or prompted)")
+}
+
+// addEnvDecryptFlags adds the flags needed to read encrypted .env files
+func addEnvDecryptFlags(cmd *cobra.Command) {
+	cmd.Flags().String("identity", "", "age identity file for encrypted .env files (default $"+envIdentityVar+")")
+}
+
+// envEncryptionFromFlags returns the encryption requested with --encrypt or --encrypt-passphrase
+func envEncryptionFromFlags(cmd *cobra.Command) (envFileEncryption, error) {
+	targets, _ := cmd.Flags().GetStringArray("encrypt")
+	usePassphrase, _ := cmd.Flags().GetBool("encrypt-passphrase")
+
+	if len(targets) > 0 && usePassphrase {
+		return envFileEncryption{}, fmt.Errorf("--encrypt and --encrypt-passphrase cannot be combined")
+	}
+	if usePassphrase {
+		passphrase, err := envPassphrase(true)
+		if err != nil {
+			return envFileEncryption{}, err
+		}
+		return envFileEncryption{scheme: envcrypt.SchemePassphrase, passphrase: passphrase}, nil
+	}
+
+	var recipients []string
+	for _, target := range targets {
+		recipient, ok := strings.CutPrefix(target, "age:")
+		if !ok || recipient == "" {
+			return envFileEncryption{}, fmt.Errorf("invalid --encrypt value '%s', expected age:<recipient>", target)
+		}
+		recipients = append(recipients, recipient)
+	}
+	if len(recipients) > 0 {
+		return envFileEncryption{scheme: envcrypt.SchemeAge, recipients: recipients}, nil
+	}
+	return envFileEncryption{}, nil
+}
+
+// encrypt encrypts .env content, returning it unchanged for plaintext files
+func (e envFileEncryption) encrypt(content []byte) ([]byte, error) {
+	switch e.scheme {
+	case envcrypt.SchemePassphrase:
+		return envcrypt.EncryptPassphrase(content, e.passphrase)
+	case envcrypt.SchemeAge:
+		if len(e.recipients) == 0 {
+			return nil, fmt.Errorf("the .env file is age encrypted, pass --encrypt age:<recipient> to write it back")
+		}
+		return envcrypt.EncryptAge(context.Background(), content, e.recipients)
+	}
+	return content, nil
+}
+
+// readEnvFileContent reads a .env file, transparently decrypting it when it is encrypted
+func readEnvFileContent(cmd *cobra.Command, filename string) ([]byte, envFileEncryption, error) {
+	content, err := safeReadFile(filename)
+	if err != nil {
+		return nil, envFileEncryption{}, err
+	}
+
+	encryption := envFileEncryption{scheme: envcrypt.Detect(content)}
+	switch encryption.scheme {
+	case envcrypt.SchemePassphrase:
+		encryption.passphrase, err = envPassphrase(false)
+		if err != nil {
+			return nil, encryption, err
+		}
+		content, err = envcrypt.DecryptPassphrase(content, encryption.passphrase)
+	case envcrypt.SchemeAge:
+		identity, _ := cmd.Flags().GetString("identity")
+		if identity == "" {
+			identity = os.Getenv(envIdentityVar)
+		}
+		if identity == "" {
+			return nil, encryption, fmt.Errorf("%s is age encrypted, pass --identity or set %s", filename, envIdentityVar)
+		}
+		content, err = envcrypt.DecryptAge(context.Background(), content, expandHomePath(identity))
+	}
+	if err != nil {
+		return nil, encryption, fmt.Errorf("failed to decrypt %s: %w", filename, err)
+	}
+	return content, encryption, nil
+}
+
+// envPassphrase returns the .env passphrase from the environment or prompts for it, asking
+// twice when a new file is encrypted
+func envPassphrase(confirmNew bool) (string, error) {
+	if passphrase := os.Getenv(envPassphraseVar); passphrase != "" {
+		return passphrase, nil
+	}
+
+	passphrase, err := promptPassphrase("Passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase cannot be empty")
+	}
+	if confirmNew {
+		again, err := promptPassphrase("Confirm passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != passphrase {
+			return "", fmt.Errorf("passphrases do not match")
+		}
+	}
+	return passphrase, nil
+}
+
+// promptPassphrase reads a line from stdin, hiding the input on terminals that support it
+func promptPassphrase(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+
+	if runtime.GOOS != "windows" {
+		stty := exec.Command("stty", "-echo") // #nosec G204 -- fixed program
+		stty.Stdin = os.Stdin
+		if stty.Run() == nil {
+			defer func() {
+				restore := exec.Command("stty", "echo") // #nosec G204 -- fixed program
+				restore.Stdin = os.Stdin
+				_ = restore.Run()
+				fmt.Fprintln(os.Stderr)
+			}()
+		}
+	}
+
+	line, err := confirm.Input().ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/envsnapshot"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+// applicationsEnvEditCmd represents the applications env edit command
+var applicationsEnvEditCmd = &cobra.Command{
+	Use:   "edit <app-uuid>",
+	Short: "Edit environment variables in your editor",
+	Long: `Open the environment variables of an application in $VISUAL or $EDITOR as a .env file.
+
+When the editor is closed the file is compared with the current variables and the pending
+additions, updates and deletions are shown for confirmation before they are applied, with the
+values masked unless --show-values is given. The flags of updated variables, such as build time
+or literal, are kept. Removing a
+line deletes the variable. Values containing newlines, quotes or leading or trailing spaces are
+written double quoted with \n, \" and \\ escapes. Preview variables are not included.
+
+If the edited file cannot be parsed nothing is changed and the file is kept so your edits are
+not lost.
+
+Examples:
+  coolifyme applications env edit <app-uuid>
+  EDITOR="code --wait" coolifyme applications env edit <app-uuid>
+  coolifyme applications env edit <app-uuid> --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		appUUID := args[0]
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		envs, err := client.Applications().ListEnvs(ctx, appUUID)
+		if err != nil {
+			return fmt.Errorf("failed to list environment variables: %w", err)
+		}
+
+		current := make(map[string]string)
+		envUUIDs := make(map[string]string)
+		for _, env := range envs {
+			if env.Key == nil || (env.IsPreview != nil && *env.IsPreview) {
+				continue
+			}
+			current[*env.Key] = ""
+			if env.Value != nil {
+				current[*env.Key] = *env.Value
+			}
+			if env.Uuid != nil {
+				envUUIDs[*env.Key] = *env.Uuid
+			}
+		}
+
+		file, err := os.CreateTemp("", "coolifyme-env-*.env")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary file: %w", err)
+		}
+		path := file.Name()
+		keep := false
+		defer func() {
+			if !keep {
+				_ = os.Remove(path)
+			}
+		}()
+
+		if _, err := file.WriteString(formatEditableEnv(appUUID, current)); err != nil {
+			_ = file.Close()
+			return fmt.Errorf("failed to write temporary file: %w", err)
+		}
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("failed to write temporary file: %w", err)
+		}
+
+		if err := runEditor(path); err != nil {
+			return err
+		}
+
+		content, err := os.ReadFile(path) // #nosec G304 -- temporary file created above
+		if err != nil {
+			return fmt.Errorf("failed to read edited file: %w", err)
+		}
+		edited, err := parseEditableEnv(string(content))
+		if err != nil {
+			keep = true
+			return fmt.Errorf("%w (your edits were kept in %s)", err, path)
+		}
+
+		changes := diffEnv(current, edited)
+		if changes.empty() {
+			theme.Println("✅ No changes")
+			return nil
+		}
+
+		showValues, _ := cmd.Flags().GetBool("show-values")
+		theme.Printf("📝 Pending changes for application %s:\n", appUUID)
+		changes.print(current, edited, showValues)
+		if dryRun {
+			return nil
+		}
+		if !confirm.Action("Apply these changes?", skipConfirmation(cmd)) {
+			keep = true
+			theme.Printf("❌ Changes not applied (your edits were kept in %s)\n", path)
+			return nil
+		}
+		if err := saveEnvSnapshot(cmd, applicationEnvSnapshots, appUUID, envs); err != nil {
+			keep = true
+			return fmt.Errorf("%w (your edits were kept in %s)", err, path)
+		}
+
+		if upserts := append(append([]string{}, changes.adds...), changes.updates...); len(upserts) > 0 {
+			req := envUpsertRequest(upserts, edited, envs)
+			if _, err := client.Applications().UpdateEnvs(ctx, appUUID, req); err != nil {
+				keep = true
+				return fmt.Errorf("failed to update environment variables: %w (your edits were kept in %s)", err, path)
+			}
+		}
+
+		for _, key := range changes.deletes {
+			if _, err := client.Applications().DeleteEnv(ctx, appUUID, envUUIDs[key]); err != nil {
+				keep = true
+				return fmt.Errorf("failed to delete environment variable %s: %w (your edits were kept in %s)", key, err, path)
+			}
+		}
+
+		theme.Println("✅ Environment variables updated")
+		theme.Printf("   ➕ Added: %d  🔄 Updated: %d  ➖ Deleted: %d\n", len(changes.adds), len(changes.updates), len(changes.deletes))
+		return nil
+	},
+}
+
+// envChanges lists the keys added, updated and deleted by an edit, each sorted
+type envChanges struct {
+	adds    []string
+	updates []string
+	deletes []string
+}
+
+func (c envChanges) empty() bool {
+	return len(c.adds) == 0 && len(c.updates) == 0 && len(c.deletes) == 0
+}
+
+// print shows the changes like a diff. Values are masked unless showValues is set, since
+// environment variables often hold secrets.
+func (c envChanges) print(before, after map[string]string, showValues bool) {
+	for _, key := range c.adds {
+		if showValues {
+			fmt.Printf("  + %s=%s\n", key, encodeEnvValue(after[key]))
+		} else {
+			fmt.Printf("  + %s=%s\n", key, maskedEnvValue)
+		}
+	}
+	for _, key := range c.updates {
+		if showValues {
+			fmt.Printf("  ~ %s: %s -> %s\n", key, encodeEnvValue(before[key]), encodeEnvValue(after[key]))
+		} else {
+			fmt.Printf("  ~ %s: %s -> %s (changed)\n", key, maskedEnvValue, maskedEnvValue)
+		}
+	}
+	for _, key := range c.deletes {
+		fmt.Printf("  - %s\n", key)
+	}
+}
+
+// maskedEnvValue replaces the values of variables in diffs without --show-values
+const maskedEnvValue = envsnapshot.Masked
+
+// envUpsertRequest builds the bulk update of the given keys to their values. The flags of
+// existing variables are sent back, since the bulk update resets the flags it is not given.
+func envUpsertRequest(keys []string, values map[string]string, existing []coolify.EnvironmentVariable) coolify.UpdateEnvsByApplicationUuidJSONRequestBody {
+	flags := make(map[string]coolify.EnvironmentVariable)
+	for _, env := range existing {
+		if env.Key != nil && (env.IsPreview == nil || !*env.IsPreview) {
+			flags[*env.Key] = env
+		}
+	}
+
+	var req coolify.UpdateEnvsByApplicationUuidJSONRequestBody
+	for _, key := range keys {
+		k := key
+		v := values[key]
+		multiline := strings.Contains(v, "\n")
+		env := flags[key]
+		req.Data = append(req.Data, struct {
+			IsBuildTime *bool   `json:"is_build_time,omitempty"`
+			IsLiteral   *bool   `json:"is_literal,omitempty"`
+			IsMultiline *bool   `json:"is_multiline,omitempty"`
+			IsPreview   *bool   `json:"is_preview,omitempty"`
+			IsShownOnce *bool   `json:"is_shown_once,omitempty"`
+			Key         *string `json:"key,omitempty"`
+			Value       *string `json:"value,omitempty"`
+		}{
+			IsBuildTime: env.IsBuildTime,
+			IsLiteral:   env.IsLiteral,
+			IsMultiline: &multiline,
+			IsPreview:   env.IsPreview,
+			IsShownOnce: env.IsShownOnce,
+			Key:         &k,
+			Value:       &v,
+		})
+	}
+	return req
+}
+
+// diffEnv compares the variables before and after an edit
+func diffEnv(before, after map[string]string) envChanges {
+	var changes envChanges
+	for key, value := range after {
+		old, exists := before[key]
+		switch {
+		case !exists:
+			changes.adds = append(changes.adds, key)
+		case old != value:
+			changes.updates = append(changes.updates, key)
+		}
+	}
+	for key := range before {
+		if _, exists := after[key]; !exists {
+			changes.deletes = append(changes.deletes, key)
+		}
+	}
+	sort.Strings(changes.adds)
+	sort.Strings(changes.updates)
+	sort.Strings(changes.deletes)
+	return changes
+}
+
+// formatEditableEnv renders variables as a .env file, sorted by key
+func formatEditableEnv(appUUID string, envs map[string]string) string {
+	keys := make([]string, 0, len(envs))
+	for key := range envs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("# Environment variables of application " + appUUID + "\n")
+	b.WriteString("# Lines starting with '#' are ignored. Remove a line to delete the variable.\n\n")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s=%s\n", key, encodeEnvValue(envs[key]))
+	}
+	return b.String()
+}
+
+// encodeEnvValue quotes a value when it would not survive a round trip unquoted
+func encodeEnvValue(value string) string {
+	if value == "" || (!strings.ContainsAny(value, "\n\r\"'\\#") && strings.TrimSpace(value) == value) {
+		return value
+	}
+	replacer := strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n", "\r", "\\r")
+	return "\"" + replacer.Replace(value) + "\""
+}
+
+// parseEditableEnv parses a .env file written by formatEditableEnv. Unlike parseEnvFile it
+// rejects malformed lines and duplicate keys instead of skipping them, so a typo cannot delete
+// a variable.
+func parseEditableEnv(content string) (map[string]string, error) {
+	envs := make(map[string]string)
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(strings.TrimPrefix(key, "export "))
+		if !found || key == "" {
+			return nil, fmt.Errorf("line %d: expected KEY=value", i+1)
+		}
+		if _, exists := envs[key]; exists {
+			return nil, fmt.Errorf("line %d: duplicate key %s", i+1, key)
+		}
+
+		decoded, err := decodeEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		envs[key] = decoded
+	}
+	return envs, nil
+}
+
+// decodeEnvValue reverses encodeEnvValue. Single quoted values are taken literally.
+func decodeEnvValue(value string) (string, error) {
+	if strings.HasPrefix(value, "'") {
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return "", fmt.Errorf("unterminated quote")
+		}
+		return value[1 : len(value)-1], nil
+	}
+	if !strings.HasPrefix(value, "\"") {
+		return value, nil
+	}
+	if len(value) < 2 || !strings.HasSuffix(value, "\"") {
+		return "", fmt.Errorf("unterminated quote")
+	}
+
+	var b strings.Builder
+	inner := value[1 : len(value)-1]
+	for i := 0; i < len(inner); i++ {
+		c := inner[i]
+		if c == '"' {
+			return "", fmt.Errorf("unescaped quote inside value")
+		}
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		if i+1 == len(inner) {
+			return "", fmt.Errorf("unterminated quote")
+		}
+		i++
+		switch inner[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case '"', '\\':
+			b.WriteByte(inner[i])
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(inner[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// runEditor opens a file in $VISUAL or $EDITOR and waits for it to close
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	// Editors are often configured with arguments, e.g. "code --wait"
+	fields := strings.Fields(editor)
+	edit := exec.Command(fields[0], append(fields[1:], path)...) // #nosec G204 -- editor comes from the user's environment
+	edit.Stdin = os.Stdin
+	edit.Stdout = os.Stdout
+	edit.Stderr = os.Stderr
+	if err := edit.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %w", fields[0], err)
+	}
+	return nil
+}
+
+func init() {
+	applicationsEnvCmd.AddCommand(applicationsEnvEditCmd)
+
+	// Flags for env edit command
+	applicationsEnvEditCmd.Flags().Bool("dry-run", false, "Show the changes without applying them")
+	applicationsEnvEditCmd.Flags().Bool("show-values", false, "Show the old and new values in the pending changes instead of masking them")
+	addConfirmFlags(applicationsEnvEditCmd, "Apply the changes without confirmation")
+}
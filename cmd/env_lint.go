@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hongkongkiwi/coolifyme/internal/envlint"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+// applicationsEnvLintCmd represents the applications env lint command
+var applicationsEnvLintCmd = &cobra.Command{
+	Use:   "lint <app-uuid>",
+	Short: "Check environment variables for misconfigurations",
+	Long: `Check the environment variables of an application before deploying it.
+
+Always checked:
+  - duplicate keys (preview variables are checked separately)
+  - keys with whitespace or characters that are not valid in shell variable names
+  - values with leading or trailing whitespace
+  - values that are the literal "null", "undefined", "none" or "nil"
+  - values spanning several lines that are not marked multiline
+  - unterminated ${ references
+
+With --schema the variables are also validated against a JSON schema:
+
+  {
+    "required": ["DATABASE_URL", "APP_ENV"],
+    "properties": {
+      "PORT": {"pattern": "[0-9]+"},
+      "APP_ENV": {"enum": ["production", "staging"], "description": "deployment stage"}
+    },
+    "additionalProperties": true
+  }
+
+Patterns must match the whole value. The command fails when errors are found, or warnings
+too with --strict.
+
+Examples:
+  coolifyme applications env lint <uuid>
+  coolifyme applications env lint <uuid> --schema env.schema.json --strict
+  coolifyme applications env lint <uuid> -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var schema *envlint.Schema
+		if path, _ := cmd.Flags().GetString("schema"); path != "" {
+			var err error
+			if schema, err = envlint.LoadSchema(path); err != nil {
+				return err
+			}
+		}
+
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		envs, err := client.Applications().ListEnvs(context.Background(), args[0])
+		if err != nil {
+			return fmt.Errorf("failed to list environment variables: %w", err)
+		}
+
+		vars := make([]envlint.Variable, 0, len(envs))
+		for _, env := range envs {
+			if env.Key == nil {
+				continue
+			}
+			v := envlint.Variable{Key: *env.Key}
+			if env.Value != nil {
+				v.Value = *env.Value
+			}
+			v.Multiline = env.IsMultiline != nil && *env.IsMultiline
+			v.Preview = env.IsPreview != nil && *env.IsPreview
+			vars = append(vars, v)
+		}
+
+		issues := envlint.Lint(vars, schema)
+		errorCount := envlint.CountErrors(issues)
+		warnings := len(issues) - errorCount
+
+		if output, _ := cmd.Flags().GetString("output"); output == "json" {
+			if issues == nil {
+				issues = []envlint.Issue{}
+			}
+			if err := outputJSON(issues); err != nil {
+				return err
+			}
+		} else {
+			for _, issue := range issues {
+				if issue.Severity == envlint.SeverityError {
+					theme.Printf("❌ %s\n", issue)
+				} else {
+					theme.Printf("⚠️  %s\n", issue)
+				}
+			}
+			if len(issues) == 0 {
+				theme.Printf("✅ %d environment variable(s) look good\n", len(vars))
+			}
+		}
+
+		strict, _ := cmd.Flags().GetBool("strict")
+		if errorCount > 0 || (strict && warnings > 0) {
+			return fmt.Errorf("found %d error(s) and %d warning(s) in %d environment variable(s)", errorCount, warnings, len(vars))
+		}
+		return nil
+	},
+}
+
+func init() {
+	applicationsEnvCmd.AddCommand(applicationsEnvLintCmd)
+
+	// Flags for env lint command
+	applicationsEnvLintCmd.Flags().String("schema", "", "JSON schema with required keys, patterns and allowed values")
+	applicationsEnvLintCmd.Flags().Bool("strict", false, "Fail on warnings too")
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/envsnapshot"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// envSnapshotResource reads and writes the environment variables of a kind of resource for
+// snapshots
+type envSnapshotResource struct {
+	// kind is the resource kind in messages and the snapshot directory, e.g. "service"
+	kind string
+	// command is the snapshots command in messages and examples, e.g. "services env-snapshots"
+	command string
+	list    func(ctx context.Context, client *clientpkg.Client, uuid string) ([]coolify.EnvironmentVariable, error)
+	upsert  func(ctx context.Context, client *clientpkg.Client, uuid string, vars []envsnapshot.Variable) error
+	delete  func(ctx context.Context, client *clientpkg.Client, uuid, envUUID string) error
+}
+
+// applicationEnvSnapshots snapshots the environment variables of applications
+var applicationEnvSnapshots = envSnapshotResource{
+	kind:    "application",
+	command: "applications env snapshots",
+	list: func(ctx context.Context, client *clientpkg.Client, uuid string) ([]coolify.EnvironmentVariable, error) {
+		return client.Applications().ListEnvs(ctx, uuid)
+	},
+	upsert: func(ctx context.Context, client *clientpkg.Client, uuid string, vars []envsnapshot.Variable) error {
+		_, err := client.Applications().UpdateEnvs(ctx, uuid, coolify.UpdateEnvsByApplicationUuidJSONRequestBody{Data: bulkEnvData(vars)})
+		return err
+	},
+	delete: func(ctx context.Context, client *clientpkg.Client, uuid, envUUID string) error {
+		_, err := client.Applications().DeleteEnv(ctx, uuid, envUUID)
+		return err
+	},
+}
+
+// serviceEnvSnapshots snapshots the environment variables of services
+var serviceEnvSnapshots = envSnapshotResource{
+	kind:    "service",
+	command: "services env-snapshots",
+	list: func(ctx context.Context, client *clientpkg.Client, uuid string) ([]coolify.EnvironmentVariable, error) {
+		return client.Services().ListEnvs(ctx, uuid)
+	},
+	upsert: func(ctx context.Context, client *clientpkg.Client, uuid string, vars []envsnapshot.Variable) error {
+		_, err := client.Services().UpdateEnvs(ctx, uuid, coolify.UpdateEnvsByServiceUuidJSONRequestBody{Data: bulkEnvData(vars)})
+		return err
+	},
+	delete: func(ctx context.Context, client *clientpkg.Client, uuid, envUUID string) error {
+		_, err := client.Services().DeleteEnv(ctx, uuid, envUUID)
+		return err
+	},
+}
+
+// bulkEnvData converts snapshot variables to the data of bulk environment variable updates,
+// which is the same for applications and services
+func bulkEnvData(vars []envsnapshot.Variable) []struct {
+	IsBuildTime *bool   `json:"is_build_time,omitempty"`
+	IsLiteral   *bool   `json:"is_literal,omitempty"`
+	IsMultiline *bool   `json:"is_multiline,omitempty"`
+	IsPreview   *bool   `json:"is_preview,omitempty"`
+	IsShownOnce *bool   `json:"is_shown_once,omitempty"`
+	Key         *string `json:"key,omitempty"`
+	Value       *string `json:"value,omitempty"`
+} {
+	data := make([]struct {
+		IsBuildTime *bool   `json:"is_build_time,omitempty"`
+		IsLiteral   *bool   `json:"is_literal,omitempty"`
+		IsMultiline *bool   `json:"is_multiline,omitempty"`
+		IsPreview   *bool   `json:"is_preview,omitempty"`
+		IsShownOnce *bool   `json:"is_shown_once,omitempty"`
+		Key         *string `json:"key,omitempty"`
+		Value       *string `json:"value,omitempty"`
+	}, len(vars))
+	for i := range vars {
+		v := &vars[i]
+		data[i].IsBuildTime = &v.IsBuildTime
+		data[i].IsLiteral = &v.IsLiteral
+		data[i].IsMultiline = &v.IsMultiline
+		data[i].IsPreview = &v.IsPreview
+		data[i].IsShownOnce = &v.IsShownOnce
+		data[i].Key = &v.Key
+		data[i].Value = &v.Value
+	}
+	return data
+}
+
+// snapshotVariables converts environment variables returned by the API for a snapshot
+func snapshotVariables(envs []coolify.EnvironmentVariable) []envsnapshot.Variable {
+	flag := func(b *bool) bool { return b != nil && *b }
+	vars := make([]envsnapshot.Variable, 0, len(envs))
+	for _, env := range envs {
+		if env.Key == nil {
+			continue
+		}
+		vars = append(vars, envsnapshot.Variable{
+			UUID:        stringValue(env.Uuid),
+			Key:         *env.Key,
+			Value:       stringValue(env.Value),
+			IsBuildTime: flag(env.IsBuildTime),
+			IsLiteral:   flag(env.IsLiteral),
+			IsMultiline: flag(env.IsMultiline),
+			IsPreview:   flag(env.IsPreview),
+			IsShownOnce: flag(env.IsShownOnce),
+		})
+	}
+	return vars
+}
+
+// saveEnvSnapshot stores the environment variables of a resource before a command changes them.
+// Commands abort when the snapshot cannot be saved, so every bulk change can be rolled back.
+func saveEnvSnapshot(cmd *cobra.Command, resource envSnapshotResource, uuid string, envs []coolify.EnvironmentVariable) error {
+	dir, err := envsnapshot.Dir()
+	if err != nil {
+		return fmt.Errorf("failed to snapshot environment variables, nothing was changed: %w", err)
+	}
+	snapshot, err := envsnapshot.Save(dir, envsnapshot.Snapshot{
+		Kind:      resource.kind,
+		UUID:      uuid,
+		Reason:    cmd.CommandPath(),
+		Variables: snapshotVariables(envs),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to snapshot environment variables, nothing was changed: %w", err)
+	}
+	if !quiet {
+		theme.Printf("📸 Saved snapshot %s, undo with 'coolifyme %s restore %s %s'\n", snapshot.ID, resource.command, uuid, snapshot.ID)
+	}
+	return nil
+}
+
+// takeEnvSnapshot fetches and stores the environment variables of a resource before a command
+// changes them
+func takeEnvSnapshot(ctx context.Context, cmd *cobra.Command, client *clientpkg.Client, resource envSnapshotResource, uuid string) error {
+	envs, err := resource.list(ctx, client, uuid)
+	if err != nil {
+		return fmt.Errorf("failed to list environment variables: %w", err)
+	}
+	return saveEnvSnapshot(cmd, resource, uuid, envs)
+}
+
+// envSnapshotsCmd returns the snapshots command with list and restore subcommands for a kind of
+// resource
+func envSnapshotsCmd(resource envSnapshotResource, use string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: []string{strings.TrimSuffix(use, "s")},
+		Short:   fmt.Sprintf("List and restore snapshots of the environment variables of %ss", resource.kind),
+		Long: fmt.Sprintf(`Commands changing many environment variables at once save a snapshot of the previous
+variables of the %[1]s first, so a bad change can be rolled back. Snapshots are stored in
+$XDG_STATE_HOME/coolifyme/snapshots (~/.local/state/coolifyme/snapshots by default), readable
+only by you since they contain secrets; the last %[3]d snapshots of each %[1]s are kept.
+
+Examples:
+  coolifyme %[2]s list <uuid>
+  coolifyme %[2]s restore <uuid> --dry-run
+  coolifyme %[2]s restore <uuid> 20240601-030000`, resource.kind, resource.command, envsnapshot.MaxPerResource),
+	}
+	cmd.AddCommand(envSnapshotsListCmd(resource), envSnapshotsRestoreCmd(resource))
+	return cmd
+}
+
+func envSnapshotsListCmd(resource envSnapshotResource) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list <uuid>",
+		Aliases: []string{"ls"},
+		Short:   fmt.Sprintf("List the environment variable snapshots of the %s", resource.kind),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := envsnapshot.Dir()
+			if err != nil {
+				return err
+			}
+			snapshots, err := envsnapshot.List(dir, resource.kind, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to list snapshots: %w", err)
+			}
+
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			if jsonOutput {
+				output, err := json.MarshalIndent(snapshots, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", err)
+				}
+				fmt.Println(string(output))
+				return nil
+			}
+
+			if len(snapshots) == 0 {
+				fmt.Printf("No snapshots of %s %s\n", resource.kind, args[0])
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			defer func() {
+				_ = w.Flush()
+			}()
+
+			_, _ = fmt.Fprintln(w, "ID\tCREATED\tVARIABLES\tTAKEN BY")
+			_, _ = fmt.Fprintln(w, "--\t-------\t---------\t--------")
+			for _, snapshot := range snapshots {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", snapshot.ID,
+					snapshot.Created.Local().Format("2006-01-02 15:04:05"), len(snapshot.Variables), snapshot.Reason)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolP("json", "j", false, "Output in JSON format (includes the values)")
+	return cmd
+}
+
+func envSnapshotsRestoreCmd(resource envSnapshotResource) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore <uuid> [snapshot-id]",
+		Short: fmt.Sprintf("Restore the environment variables of the %s from a snapshot", resource.kind),
+		Long: fmt.Sprintf(`Restore the environment variables of the %[1]s from a snapshot, the newest one unless an ID is
+given. Variables changed since the snapshot are reset, variables added since are deleted and
+deleted ones are recreated. The pending changes are shown for confirmation, and the current
+variables are snapshotted first so the restore can be undone too. The values are masked in the
+pending changes unless --show-values is given. Redeploy the %[1]s to apply the restored
+variables.`, resource.kind),
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uuid := args[0]
+			id := ""
+			if len(args) == 2 {
+				id = args[1]
+			}
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+			dir, err := envsnapshot.Dir()
+			if err != nil {
+				return err
+			}
+			snapshot, err := envsnapshot.Get(dir, resource.kind, uuid, id)
+			if err != nil {
+				return err
+			}
+
+			client, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+
+			ctx := context.Background()
+			envs, err := resource.list(ctx, client, uuid)
+			if err != nil {
+				return fmt.Errorf("failed to list environment variables: %w", err)
+			}
+
+			plan := envsnapshot.Diff(snapshotVariables(envs), snapshot.Variables)
+			if plan.Empty() {
+				theme.Printf("✅ The environment variables of %s %s already match snapshot %s\n", resource.kind, uuid, snapshot.ID)
+				return nil
+			}
+
+			theme.Printf("📝 Pending changes to restore snapshot %s of %s %s:\n", snapshot.ID, resource.kind, uuid)
+			var value func(envsnapshot.Variable) string
+			if showValues, _ := cmd.Flags().GetBool("show-values"); showValues {
+				value = func(v envsnapshot.Variable) string { return encodeEnvValue(v.Value) }
+			}
+			if err := plan.Print(os.Stdout, value); err != nil {
+				return err
+			}
+			if dryRun {
+				return nil
+			}
+
+			if err := checkTokenAbility(cmd, client, clientpkg.AbilityWrite); err != nil {
+				return err
+			}
+			if !confirm.Action("Apply these changes?", skipConfirmation(cmd)) {
+				theme.Println("❌ Changes not applied")
+				return nil
+			}
+			if err := saveEnvSnapshot(cmd, resource, uuid, envs); err != nil {
+				return err
+			}
+
+			if upserts := append(append([]envsnapshot.Variable{}, plan.Adds...), plan.Updates...); len(upserts) > 0 {
+				if err := resource.upsert(ctx, client, uuid, upserts); err != nil {
+					return fmt.Errorf("failed to update environment variables: %w", err)
+				}
+			}
+			for _, v := range plan.Deletes {
+				if err := resource.delete(ctx, client, uuid, v.UUID); err != nil {
+					return fmt.Errorf("failed to delete environment variable %s: %w", v.Name(), err)
+				}
+			}
+
+			theme.Printf("✅ Restored snapshot %s of %s %s\n", snapshot.ID, resource.kind, uuid)
+			theme.Printf("   ➕ Added: %d  🔄 Updated: %d  ➖ Deleted: %d\n", len(plan.Adds), len(plan.Updates), len(plan.Deletes))
+			theme.Printf("💡 Redeploy the %s to apply them\n", resource.kind)
+			return nil
+		},
+	}
+	cmd.Flags().Bool("dry-run", false, "Show the changes without applying them")
+	cmd.Flags().Bool("show-values", false, "Show the restored values in the pending changes instead of masking them")
+	addConfirmFlags(cmd, "Restore without confirmation")
+	return cmd
+}
+
+func init() {
+	applicationsEnvCmd.AddCommand(envSnapshotsCmd(applicationEnvSnapshots, "snapshots"))
+	servicesCmd.AddCommand(envSnapshotsCmd(serviceEnvSnapshots, "env-snapshots"))
+}
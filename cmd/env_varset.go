@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/envtemplate"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+// applicationsEnvApplySetCmd represents the applications env apply-set command
+var applicationsEnvApplySetCmd = &cobra.Command{
+	Use:   "apply-set <app-uuid> <set-name>",
+	Short: "Merge a shared variable set into an application's environment variables",
+	Long: `Merge a named variable set into the environment variables of an application, to keep variables
+shared by many applications, such as database or logging settings, consistent.
+
+Variable sets are defined under 'varsets' in the config file, or as <name>.env files in the
+varsets directory of the config directory (~/.config/coolifyme/varsets/common-postgres.env):
+
+  varsets:
+    common-postgres:
+      description: Shared database settings
+      file: common-postgres.env      # relative to the config directory
+      vars:                          # override variables of the file
+        DB_HOST: db.internal
+        DB_NAME: ${APP:name}
+
+Values are templates: ${APP:uuid}, ${APP:name} and ${APP:fqdn} are replaced with details of
+the application, and secret references such as ${ENV:...}, ${FILE:...}, ${CMD:...} and
+${VAULT:...} are resolved as by 'env import'. Other ${...} references are kept for Coolify.
+
+Variables of the set are added, or updated when their value differs, keeping their flags such as
+build time or literal; variables of the application that are not in the set are never removed.
+The changes are shown for confirmation, with the values masked unless --show-values is given.
+
+Examples:
+  coolifyme applications env apply-set <app-uuid> common-postgres --dry-run
+  coolifyme applications env apply-set <app-uuid> common-postgres --keep-existing --yes`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		appUUID, setName := args[0], args[1]
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		keepExisting, _ := cmd.Flags().GetBool("keep-existing")
+		noResolve, _ := cmd.Flags().GetBool("no-resolve")
+
+		setVars, err := loadVarSet(setName)
+		if err != nil {
+			return err
+		}
+
+		app, err := client.Applications().Get(ctx, appUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get application: %w", err)
+		}
+
+		// Resolve templates, also on dry runs to catch unresolvable references
+		resolved := setVars
+		var resolvedKeys []string
+		if !noResolve {
+			template := envtemplate.New()
+			template.Register("APP", func(_ context.Context, field string) (string, error) {
+				switch field {
+				case "uuid":
+					return appUUID, nil
+				case "name":
+					return stringOrDash(app.Name), nil
+				case "fqdn":
+					return stringOrDash(app.Fqdn), nil
+				}
+				return "", fmt.Errorf("unknown application field '%s' (use uuid, name or fqdn)", field)
+			})
+			resolved, resolvedKeys, err = template.ExpandAll(ctx, setVars)
+			if err != nil {
+				return fmt.Errorf("failed to resolve variable set %s: %w", setName, err)
+			}
+		}
+
+		envs, err := client.Applications().ListEnvs(ctx, appUUID)
+		if err != nil {
+			return fmt.Errorf("failed to list environment variables: %w", err)
+		}
+		current := make(map[string]string)
+		for _, env := range envs {
+			if env.Key == nil || (env.IsPreview != nil && *env.IsPreview) {
+				continue
+			}
+			current[*env.Key] = ""
+			if env.Value != nil {
+				current[*env.Key] = *env.Value
+			}
+		}
+
+		merged := make(map[string]string, len(current)+len(resolved))
+		for key, value := range current {
+			merged[key] = value
+		}
+		for key, value := range resolved {
+			if _, exists := current[key]; exists && keepExisting {
+				continue
+			}
+			merged[key] = value
+		}
+
+		changes := diffEnv(current, merged)
+		if changes.empty() {
+			theme.Printf("✅ Application %s already has the variables of set %s\n", stringOrDash(app.Name), setName)
+			return nil
+		}
+
+		// Show resolved secrets by their reference
+		shown := make(map[string]string, len(merged))
+		for key, value := range merged {
+			shown[key] = value
+			if slices.Contains(resolvedKeys, key) && merged[key] == resolved[key] {
+				shown[key] = setVars[key] + " (resolved)"
+			}
+		}
+		showValues, _ := cmd.Flags().GetBool("show-values")
+		theme.Printf("📝 Pending changes from set %s for application %s:\n", setName, stringOrDash(app.Name))
+		changes.print(current, shown, showValues)
+		if dryRun {
+			return nil
+		}
+		if !confirm.Action("Apply these changes?", skipConfirmation(cmd)) {
+			theme.Println("❌ Changes not applied")
+			return nil
+		}
+		if err := saveEnvSnapshot(cmd, applicationEnvSnapshots, appUUID, envs); err != nil {
+			return err
+		}
+
+		req := envUpsertRequest(append(append([]string{}, changes.adds...), changes.updates...), merged, envs)
+		if _, err := client.Applications().UpdateEnvs(ctx, appUUID, req); err != nil {
+			return fmt.Errorf("failed to update environment variables: %w", err)
+		}
+
+		theme.Printf("✅ Applied set %s to application %s\n", setName, stringOrDash(app.Name))
+		theme.Printf("   ➕ Added: %d  🔄 Updated: %d\n", len(changes.adds), len(changes.updates))
+		return nil
+	},
+}
+
+// applicationsEnvListSetsCmd represents the applications env list-sets command
+var applicationsEnvListSetsCmd = &cobra.Command{
+	Use:   "list-sets",
+	Short: "List the shared variable sets",
+	Long:  "List the variable sets of the config file and the varsets directory that 'env apply-set' can apply",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		sets, err := config.GetVarSets()
+		if err != nil {
+			return fmt.Errorf("failed to load variable sets: %w", err)
+		}
+		if len(sets) == 0 {
+			fmt.Println("No variable sets defined (add them under 'varsets' in the config file)")
+			return nil
+		}
+
+		for _, name := range config.VarSetNames(sets) {
+			vars, err := loadVarSet(name)
+			if err != nil {
+				theme.Printf("❌ %s: %v\n", name, err)
+				continue
+			}
+			keys := make([]string, 0, len(vars))
+			for key := range vars {
+				keys = append(keys, key)
+			}
+			slices.Sort(keys)
+
+			theme.Printf("📦 %s (%d variable(s))\n", name, len(vars))
+			if description := sets[name].Description; description != "" {
+				fmt.Printf("   %s\n", description)
+			}
+			fmt.Printf("   %s\n", strings.Join(keys, ", "))
+		}
+		return nil
+	},
+}
+
+// loadVarSet returns the variables of a variable set, with the variables listed in the config
+// file overriding those of its .env file
+func loadVarSet(name string) (map[string]string, error) {
+	sets, err := config.GetVarSets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load variable sets: %w", err)
+	}
+	set, ok := sets[name]
+	if !ok {
+		available := config.VarSetNames(sets)
+		if len(available) == 0 {
+			return nil, fmt.Errorf("variable set '%s' not found (no sets are defined)", name)
+		}
+		return nil, fmt.Errorf("variable set '%s' not found (available: %s)", name, strings.Join(available, ", "))
+	}
+
+	vars := make(map[string]string)
+	if set.File != "" {
+		content, err := os.ReadFile(set.File) // #nosec G304 -- the path comes from the user's config
+		if err != nil {
+			return nil, fmt.Errorf("failed to read variable set %s: %w", name, err)
+		}
+		vars = parseEnvFile(string(content))
+	}
+	for key, value := range set.Vars {
+		vars[key] = value
+	}
+	if len(vars) == 0 {
+		return nil, fmt.Errorf("variable set '%s' has no variables", name)
+	}
+	return vars, nil
+}
+
+func init() {
+	applicationsEnvCmd.AddCommand(applicationsEnvApplySetCmd)
+	applicationsEnvCmd.AddCommand(applicationsEnvListSetsCmd)
+
+	applicationsEnvApplySetCmd.Flags().Bool("dry-run", false, "Show the changes without applying them")
+	applicationsEnvApplySetCmd.Flags().Bool("show-values", false, "Show the old and new values in the pending changes instead of masking them")
+	applicationsEnvApplySetCmd.Flags().Bool("keep-existing", false, "Only add missing variables, never change existing values")
+	applicationsEnvApplySetCmd.Flags().Bool("no-resolve", false, "Upload ${APP:...} and secret references unresolved")
+	addConfirmFlags(applicationsEnvApplySetCmd, "Apply the changes without confirmation")
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// environmentEmptyTimeout bounds the wait for Coolify to remove the resources of an environment
+	// deleted with --cascade, since resources are deleted by a background job
+	environmentEmptyTimeout = 2 * time.Minute
+	// environmentEmptyInterval is the polling interval of that wait
+	environmentEmptyInterval = 3 * time.Second
+)
+
+// environmentsCmd represents the environments command
+var environmentsCmd = &cobra.Command{
+	Use:     "environments",
+	Aliases: []string{"environment", "envs"},
+	Short:   "Manage project environments",
+	Long: `List, create and delete the environments of a project. Projects are given by UUID or name,
+environments by name or UUID.
+
+The Coolify API has no endpoint to rename an environment; rename it in the Coolify UI.
+
+Examples:
+  coolifyme environments list my-project
+  coolifyme environments create my-project staging
+  coolifyme environments delete my-project staging
+  coolifyme environments delete my-project preview --cascade`,
+}
+
+// environmentsListCmd represents the environments list command
+var environmentsListCmd = &cobra.Command{
+	Use:     "list <project>",
+	Aliases: []string{"ls"},
+	Short:   "List the environments of a project with their resource counts",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		project, err := client.Projects().Resolve(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		trees, err := buildProjectTrees(ctx, client, []coolify.Project{*project})
+		if err != nil {
+			return err
+		}
+		environments := []environmentTree{}
+		if len(trees) > 0 {
+			environments = trees[0].Environments
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(environments, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		if len(environments) == 0 {
+			theme.Printf("📭 Project %s has no environments\n", stringOrDash(project.Name))
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "ID\tNAME\tAPPLICATIONS\tSERVICES\tDATABASES")
+		_, _ = fmt.Fprintln(w, "--\t----\t------------\t--------\t---------")
+		for _, env := range environments {
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%d\n", env.ID, env.Name, len(env.Applications), len(env.Services), len(env.Databases))
+		}
+		return w.Flush()
+	},
+}
+
+// environmentsCreateCmd represents the environments create command
+var environmentsCreateCmd = &cobra.Command{
+	Use:   "create <project> <name>",
+	Short: "Create an environment in a project",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(_ *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		project, err := client.Projects().Resolve(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		name := args[1]
+		uuid, err := client.Projects().CreateEnvironment(ctx, *project.Uuid, name)
+		if err != nil {
+			return err
+		}
+
+		if printQuietUUID(uuid) {
+			return nil
+		}
+		theme.Statusf("✅ Environment %s created in project %s\n", name, stringOrDash(project.Name))
+		if uuid != "" {
+			fmt.Printf("   UUID: %s\n", uuid)
+		}
+		return nil
+	},
+}
+
+// environmentsDeleteCmd represents the environments delete command
+var environmentsDeleteCmd = &cobra.Command{
+	Use:   "delete <project> <environment>",
+	Short: "Delete an environment of a project",
+	Long: `Delete an environment of a project. An environment that still contains applications, services
+or databases is refused unless --cascade is given, which deletes those resources first and waits
+for Coolify to remove them.
+
+Creating and deleting environments relies on endpoints that are not part of the Coolify API
+specification. With --cascade the server is checked for the endpoint before any resource is
+deleted; servers without it report "Deleting project environments is unsupported by this server".`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		if err := checkTokenAbility(cmd, client, clientpkg.AbilityWrite); err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		project, err := client.Projects().Resolve(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		environment, err := projectEnvironment(ctx, client, project, args[1])
+		if err != nil {
+			return err
+		}
+
+		cascade, _ := cmd.Flags().GetBool("cascade")
+		resources := environmentResources(environment)
+		if len(resources) > 0 && !cascade {
+			return fmt.Errorf("environment %s of project %s still contains %s; delete them first or pass --cascade",
+				environment.Name, stringOrDash(project.Name), environmentContents(environment))
+		}
+
+		force := skipConfirmation(cmd)
+		target := confirm.Target{Kind: "environment", Name: environment.Name, ID: fmt.Sprintf("%s/%s", stringOrDash(project.Name), environment.Name)}
+		for _, resource := range resources {
+			target.Dependents = append(target.Dependents, fmt.Sprintf("%s %s (%s)", resource.kind, resource.Name, resource.UUID))
+		}
+		if !confirm.Delete(target, force) {
+			theme.Println("❌ Deletion cancelled")
+			return nil
+		}
+
+		if len(resources) > 0 {
+			// The environment endpoints are not part of the Coolify API, so the deletion is tried
+			// before any resource is deleted: a server without the endpoint reports it unsupported
+			// and nothing is lost, one with it refuses because the environment is not empty yet.
+			err := client.Projects().DeleteEnvironment(ctx, *project.Uuid, environment.Name)
+			if err == nil {
+				theme.Printf("✅ Environment %s of project %s deleted\n", environment.Name, stringOrDash(project.Name))
+				return nil
+			}
+			if clientpkg.IsUnsupportedEndpointError(err) || clientpkg.IsPermissionError(err) {
+				return err
+			}
+
+			for _, resource := range resources {
+				if err := resource.delete(ctx, client); err != nil {
+					return fmt.Errorf("failed to delete %s %s: %w", resource.kind, resource.Name, err)
+				}
+				theme.Printf("🗑️  Deleted %s %s\n", resource.kind, resource.Name)
+			}
+			theme.Printf("⏳ Waiting for Coolify to remove the resources of %s...\n", environment.Name)
+			if err := waitEnvironmentEmpty(ctx, client, project, environment.ID); err != nil {
+				return err
+			}
+		}
+
+		if err := client.Projects().DeleteEnvironment(ctx, *project.Uuid, environment.Name); err != nil {
+			return err
+		}
+		theme.Printf("✅ Environment %s of project %s deleted\n", environment.Name, stringOrDash(project.Name))
+		return nil
+	},
+}
+
+// environmentResource is an application, service or database inside an environment
+type environmentResource struct {
+	treeResource
+	kind string
+}
+
+// delete deletes the resource with the default options of Coolify
+func (r environmentResource) delete(ctx context.Context, client clientpkg.API) error {
+	switch r.kind {
+	case "application":
+		return client.Applications().Delete(ctx, r.UUID, nil)
+	case "service":
+		return client.Services().Delete(ctx, r.UUID, nil)
+	default:
+		return client.Databases().Delete(ctx, r.UUID, nil)
+	}
+}
+
+// projectEnvironment returns an environment of a project with its resources
+func projectEnvironment(ctx context.Context, client *clientpkg.Client, project *coolify.Project, nameOrUUID string) (environmentTree, error) {
+	environment, err := client.Projects().GetEnvironment(ctx, *project.Uuid, nameOrUUID)
+	if err != nil {
+		return environmentTree{}, fmt.Errorf("failed to get environment '%s': %w", nameOrUUID, err)
+	}
+	if environment.Id == nil {
+		return environmentTree{}, fmt.Errorf("environment '%s' has no ID", nameOrUUID)
+	}
+	return environmentByID(ctx, client, project, *environment.Id)
+}
+
+// environmentByID returns the environment of a project with the given ID, with its resources
+func environmentByID(ctx context.Context, client *clientpkg.Client, project *coolify.Project, id int) (environmentTree, error) {
+	trees, err := buildProjectTrees(ctx, client, []coolify.Project{*project})
+	if err != nil {
+		return environmentTree{}, err
+	}
+	for _, tree := range trees {
+		for _, env := range tree.Environments {
+			if env.ID == id {
+				return env, nil
+			}
+		}
+	}
+	return environmentTree{}, fmt.Errorf("environment %d not found in project %s", id, stringOrDash(project.Name))
+}
+
+// environmentResources returns the applications, services and databases of an environment
+func environmentResources(env environmentTree) []environmentResource {
+	var resources []environmentResource
+	for _, app := range env.Applications {
+		resources = append(resources, environmentResource{treeResource: app, kind: "application"})
+	}
+	for _, service := range env.Services {
+		resources = append(resources, environmentResource{treeResource: service, kind: "service"})
+	}
+	for _, db := range env.Databases {
+		resources = append(resources, environmentResource{treeResource: db, kind: "database"})
+	}
+	return resources
+}
+
+// environmentContents describes the resources of an environment, e.g. "2 application(s), 1 database(s)"
+func environmentContents(env environmentTree) string {
+	var parts []string
+	for _, count := range []struct {
+		kind string
+		n    int
+	}{{"application", len(env.Applications)}, {"service", len(env.Services)}, {"database", len(env.Databases)}} {
+		if count.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s(s)", count.n, count.kind))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// waitEnvironmentEmpty polls an environment until Coolify has removed all of its resources
+func waitEnvironmentEmpty(ctx context.Context, client *clientpkg.Client, project *coolify.Project, id int) error {
+	deadline := time.Now().Add(environmentEmptyTimeout)
+	for {
+		env, err := environmentByID(ctx, client, project, id)
+		if err != nil {
+			return err
+		}
+		if len(environmentResources(env)) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("environment %s still contains %s after %s; delete it again once Coolify has removed them",
+				env.Name, environmentContents(env), environmentEmptyTimeout)
+		}
+		time.Sleep(environmentEmptyInterval)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(environmentsCmd)
+	environmentsCmd.AddCommand(environmentsListCmd)
+	environmentsCmd.AddCommand(environmentsCreateCmd)
+	environmentsCmd.AddCommand(environmentsDeleteCmd)
+
+	environmentsListCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+
+	addConfirmFlags(environmentsDeleteCmd, "Delete without confirmation")
+	environmentsDeleteCmd.Flags().Bool("cascade", false, "Delete the applications, services and databases of the environment first")
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+// mutatingVerbs lists subcommand names that change state. foreach-profile refuses them early
+// with a clear error; the commands it runs are read-only through --read-only either way.
+var mutatingVerbs = map[string]bool{
+	"create": true, "delete": true, "update": true, "update-bulk": true,
+	"start": true, "stop": true, "restart": true, "deploy": true,
+	"start-all": true, "stop-all": true, "restart-all": true, "deploy-all": true,
+	"import": true, "sync": true, "cleanup": true, "upgrade": true,
+	"set": true, "enable": true, "disable": true, "exec": true, "rollback": true,
+	"expose": true, "unexpose": true, "move": true, "edit": true, "apply-set": true,
+	"restore": true, "rotate": true, "bootstrap": true, "link": true, "rerun": true,
+}
+
+// childArgs returns the arguments of the command foreach-profile runs for a profile. --read-only
+// makes the child refuse every change, whatever the command.
+func childArgs(args []string) []string {
+	return append([]string{"--read-only", "--color", "never"}, args...)
+}
+
+// profileOverrideEnv lists the environment variables that override the settings of a profile;
+// they are removed for the commands run by foreach-profile, so each uses its own profile
+var profileOverrideEnv = []string{
+	"COOLIFYME_API_TOKEN", "COOLIFY_API_TOKEN", "COOLIFY_TOKEN", "COOLIFYME_TOKEN",
+	"COOLIFYME_BASE_URL", "COOLIFY_BASE_URL", "COOLIFY_URL",
+	"COOLIFYME_PROFILE", "COOLIFY_PROFILE",
+}
+
+// profileEnv returns environ without the profile overrides, selecting the profile name instead
+func profileEnv(environ []string, name string) []string {
+	env := make([]string, 0, len(environ)+1)
+	for _, entry := range environ {
+		key, _, _ := strings.Cut(entry, "=")
+		if !slices.Contains(profileOverrideEnv, key) {
+			env = append(env, entry)
+		}
+	}
+	return append(env, "COOLIFYME_PROFILE="+name)
+}
+
+// columnSeparator matches the padding tabwriter puts between table columns
+var columnSeparator = regexp.MustCompile(`\s{2,}`)
+
+// profileResult holds the captured output of a command run against a single profile
+type profileResult struct {
+	Profile string
+	Stdout  []byte
+	Stderr  []byte
+	Err     error
+}
+
+// foreachProfileCmd represents the foreach-profile command
+var foreachProfileCmd = &cobra.Command{
+	Use:     "foreach-profile -- <command> [args...]",
+	Aliases: []string{"all-profiles"},
+	Short:   "Run a read-only command against every profile",
+	Long: `Run a read-only command against every configured profile concurrently and merge the results.
+The commands run with --read-only, so any change they would make to a profile is refused.
+
+Table output gets an extra PROFILE column. JSON output (--json or -o json) is merged into
+a single array where every item carries a "profile" field.
+
+Examples:
+  coolifyme foreach-profile -- applications list
+  coolifyme foreach-profile -- servers list --json
+  coolifyme foreach-profile --profiles prod,staging -- services list`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, arg := range args {
+			if mutatingVerbs[arg] {
+				return fmt.Errorf("foreach-profile only runs read-only commands, refusing %q", arg)
+			}
+		}
+
+		profiles, _, err := config.ListProfiles()
+		if err != nil {
+			return fmt.Errorf("failed to list profiles: %w", err)
+		}
+
+		names := make([]string, 0, len(profiles))
+		for _, p := range profiles {
+			names = append(names, p.Name)
+		}
+
+		if selected, _ := cmd.Flags().GetStringSlice("profiles"); len(selected) > 0 {
+			names = filterProfileNames(names, selected)
+		}
+		sort.Strings(names)
+
+		if len(names) == 0 {
+			return fmt.Errorf("no profiles configured")
+		}
+
+		concurrent, _ := cmd.Flags().GetInt("concurrent")
+		results := runForProfiles(cmd.Context(), names, args, concurrent)
+
+		failed := 0
+		for _, result := range results {
+			if result.Err != nil {
+				failed++
+				theme.Printf("❌ %s: %v\n", result.Profile, result.Err)
+				if stderr := strings.TrimSpace(string(result.Stderr)); stderr != "" {
+					fmt.Fprintln(os.Stderr, stderr)
+				}
+			}
+		}
+
+		if wantsJSON(args) {
+			if err := printMergedJSON(results); err != nil {
+				return err
+			}
+		} else {
+			printMergedTable(results)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("command failed for %d of %d profiles", failed, len(results))
+		}
+		return nil
+	},
+}
+
+// filterProfileNames keeps only the profiles whose names were selected
+func filterProfileNames(names, selected []string) []string {
+	wanted := make(map[string]bool, len(selected))
+	for _, name := range selected {
+		wanted[strings.TrimSpace(name)] = true
+	}
+
+	var filtered []string
+	for _, name := range names {
+		if wanted[name] {
+			filtered = append(filtered, name)
+		}
+	}
+	return filtered
+}
+
+// runForProfiles executes the given coolifyme arguments once per profile with limited concurrency
+func runForProfiles(ctx context.Context, names []string, args []string, concurrent int) []profileResult {
+	if concurrent <= 0 {
+		concurrent = 5 // Default concurrency
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		executable = os.Args[0]
+	}
+
+	sem := make(chan struct{}, concurrent)
+	var wg sync.WaitGroup
+	results := make([]profileResult, len(names))
+
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}        // Acquire semaphore
+			defer func() { <-sem }() // Release semaphore
+
+			child := exec.CommandContext(ctx, executable, childArgs(args)...) // #nosec G204 -- re-executes this binary
+			child.Env = profileEnv(os.Environ(), name)
+
+			var stdout, stderr bytes.Buffer
+			child.Stdout = &stdout
+			child.Stderr = &stderr
+
+			results[i] = profileResult{
+				Profile: name,
+				Err:     child.Run(),
+				Stdout:  stdout.Bytes(),
+				Stderr:  stderr.Bytes(),
+			}
+		}(i, name)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// wantsJSON reports whether the forwarded arguments request JSON output
+func wantsJSON(args []string) bool {
+	for i, arg := range args {
+		switch arg {
+		case "--json", "-j", "-o=json", "--output=json":
+			return true
+		case "-o", "--output":
+			if i+1 < len(args) && args[i+1] == "json" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// printMergedJSON merges JSON output from all profiles into a single array tagged with the profile
+func printMergedJSON(results []profileResult) error {
+	merged := make([]interface{}, 0)
+	for _, result := range results {
+		if result.Err != nil {
+			continue
+		}
+
+		var data interface{}
+		if err := json.Unmarshal(result.Stdout, &data); err != nil {
+			return fmt.Errorf("profile %s did not return valid JSON: %w", result.Profile, err)
+		}
+
+		items, ok := data.([]interface{})
+		if !ok {
+			items = []interface{}{data}
+		}
+		for _, item := range items {
+			if obj, ok := item.(map[string]interface{}); ok {
+				obj["profile"] = result.Profile
+				merged = append(merged, obj)
+			} else {
+				merged = append(merged, map[string]interface{}{"profile": result.Profile, "value": item})
+			}
+		}
+	}
+
+	output, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
+// printMergedTable merges table output from all profiles, adding a PROFILE column
+func printMergedTable(results []profileResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	headerPrinted := false
+
+	for _, result := range results {
+		if result.Err != nil {
+			continue
+		}
+
+		lines := strings.Split(strings.TrimRight(string(result.Stdout), "\n"), "\n")
+		if len(lines) == 1 && lines[0] == "" {
+			continue
+		}
+
+		// Tables have a header line followed by a dashed separator line; any banner
+		// lines before the header are dropped so the merged table stays aligned
+		if h := tableHeaderIndex(lines); h >= 0 {
+			if !headerPrinted {
+				_, _ = fmt.Fprintf(w, "PROFILE\t%s\n", columnSeparator.ReplaceAllString(lines[h], "\t"))
+				_, _ = fmt.Fprintf(w, "-------\t%s\n", columnSeparator.ReplaceAllString(lines[h+1], "\t"))
+				headerPrinted = true
+			}
+			lines = lines[h+2:]
+		}
+
+		for _, line := range lines {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\n", result.Profile, columnSeparator.ReplaceAllString(line, "\t"))
+		}
+	}
+
+	_ = w.Flush()
+}
+
+// tableHeaderIndex returns the index of the table header line, or -1 if the output is not a table
+func tableHeaderIndex(lines []string) int {
+	for i := 0; i+1 < len(lines); i++ {
+		separator := strings.TrimSpace(lines[i+1])
+		if separator != "" && strings.Trim(separator, "- ") == "" {
+			return i
+		}
+	}
+	return -1
+}
+
+func init() {
+	foreachProfileCmd.Flags().StringSlice("profiles", nil, "Only run against these profiles (comma-separated)")
+	foreachProfileCmd.Flags().Int("concurrent", 5, "Number of concurrent profiles")
+}
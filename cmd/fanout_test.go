@@ -0,0 +1,14 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestChildArgsAreReadOnly(t *testing.T) {
+	got := childArgs([]string{"applications", "expose", "abc"})
+	want := []string{"--read-only", "--color", "never", "applications", "expose", "abc"}
+	if !slices.Equal(got, want) {
+		t.Errorf("childArgs() = %v, want %v", got, want)
+	}
+}
@@ -1,17 +1,20 @@
 package main
 
 import (
+	"bytes"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"os"
 	"reflect"
+	"slices"
 	"sort"
-	"strconv"
 	"strings"
-	"text/tabwriter"
-	"time"
+	"text/template"
 
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/output"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
@@ -50,6 +53,8 @@ type FormatOptions struct {
 	SortReverse  bool
 	ShowKind     bool
 	CustomFormat string
+	// PageSize splits tables into pages of this many rows, see pagedTable
+	PageSize int
 }
 
 // ParseFormatOptions parses format options from command flags
@@ -70,6 +75,9 @@ func ParseFormatOptions(cmd *cobra.Command) *FormatOptions {
 		} else if strings.HasPrefix(format, "custom(") && strings.HasSuffix(format, ")") {
 			options.Format = FormatCustom
 			options.CustomFormat = strings.TrimSuffix(strings.TrimPrefix(format, "custom("), ")")
+		} else if tmpl, ok := strings.CutPrefix(format, templatePrefix); ok {
+			options.Format = FormatTemplate
+			options.Template = tmpl
 		} else {
 			options.Format = OutputFormat(format)
 		}
@@ -100,6 +108,8 @@ func ParseFormatOptions(cmd *cobra.Command) *FormatOptions {
 		options.ShowKind = showKind
 	}
 
+	options.PageSize = pageSize(cmd)
+
 	return options
 }
 
@@ -120,6 +130,8 @@ func FormatOutput(data interface{}, options *FormatOptions) error {
 		return outputNameOnly(data)
 	case FormatCustom:
 		return outputCustom(data, options)
+	case FormatTemplate:
+		return outputTemplate(data, options.Template)
 	default:
 		return outputTable(data, options)
 	}
@@ -135,75 +147,53 @@ func outputYAML(data interface{}) error {
 	encoder := yaml.NewEncoder(os.Stdout)
 	defer func() {
 		if err := encoder.Close(); err != nil {
-			// Log error but don't fail the operation since output may already be displayed
-			fmt.Fprintf(os.Stderr, "Warning: failed to close YAML encoder: %v\n", err)
+			// Warn but don't fail the operation since output may already be displayed
+			warn("yaml output", fmt.Errorf("failed to close YAML encoder: %w", err))
 		}
 	}()
 	return encoder.Encode(data)
 }
 
 func outputTable(data interface{}, options *FormatOptions) error {
-	rows, headers := extractTableData(data, options)
-
-	if len(rows) == 0 {
-		fmt.Println("No data to display")
-		return nil
-	}
+	headers, rows := output.Rows(data, options.Columns)
 
-	// Sort data if requested
+	// Sorting needs all rows; otherwise rows are written as they are built
 	if options.SortBy != "" {
-		sortTableData(rows, headers, options.SortBy, options.SortReverse)
+		collected := slices.Collect(rows)
+		sortTableData(collected, headers, options.SortBy, options.SortReverse)
+		rows = slices.Values(collected)
 	}
 
-	// Create table writer
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	defer func() {
-		if err := w.Flush(); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to flush table writer: %v\n", err)
-		}
-	}()
-
-	// Print headers if not disabled
-	if !options.NoHeaders {
-		if _, err := fmt.Fprintln(w, strings.Join(headers, "\t")); err != nil {
-			return fmt.Errorf("failed to write table headers: %w", err)
-		}
-
-		// Print separator line
-		separators := make([]string, len(headers))
-		for i, header := range headers {
-			separators[i] = strings.Repeat("-", len(header))
-		}
-		if _, err := fmt.Fprintln(w, strings.Join(separators, "\t")); err != nil {
-			return fmt.Errorf("failed to write table separators: %w", err)
+	table := output.NewTableWriter(os.Stdout, headers, pagedTable(options.NoHeaders, options.PageSize))
+	empty := true
+	for row := range rows {
+		empty = false
+		if err := table.Row(row...); err != nil {
+			return ignorePagingStopped(err)
 		}
 	}
-
-	// Print data rows
-	for _, row := range rows {
-		if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
-			return fmt.Errorf("failed to write table row: %w", err)
-		}
+	if empty {
+		fmt.Println("No data to display")
+		return nil
 	}
-
-	return nil
+	return table.Flush()
 }
 
 func outputCSV(data interface{}, options *FormatOptions) error {
-	rows, headers := extractTableData(data, options)
+	headers, rows := output.Rows(data, options.Columns)
 
 	writer := csv.NewWriter(os.Stdout)
 	defer writer.Flush()
 
 	// Write headers if not disabled
-	if !options.NoHeaders {
+	if !options.NoHeaders && len(headers) > 0 {
 		if err := writer.Write(headers); err != nil {
 			return fmt.Errorf("failed to write CSV headers: %w", err)
 		}
 	}
 
 	// Write data rows
-	for _, row := range rows {
+	for row := range rows {
 		if err := writer.Write(row); err != nil {
 			return fmt.Errorf("failed to write CSV row: %w", err)
 		}
@@ -221,12 +211,10 @@ func outputWide(data interface{}, options *FormatOptions) error {
 
 func outputNameOnly(data interface{}) error {
 	// Extract just the name field from each item
-	items := reflectToSlice(data)
-	for _, item := range items {
-		if name := extractField(item, "name"); name != "" {
-			fmt.Println(name)
-		} else if name := extractField(item, "Name"); name != "" {
-			fmt.Println(name)
+	_, rows := output.Rows(data, []string{"name"})
+	for row := range rows {
+		if row[0] != "" {
+			fmt.Println(row[0])
 		}
 	}
 	return nil
@@ -283,158 +271,111 @@ func outputCustom(data interface{}, options *FormatOptions) error {
 	return nil
 }
 
-func extractTableData(data interface{}, options *FormatOptions) ([][]string, []string) {
-	items := reflectToSlice(data)
-	if len(items) == 0 {
-		return nil, nil
-	}
+// templatePrefix starts --output values rendering a Go template, e.g. template={{.Name}}, or
+// template=@name for a template stored under 'templates' in the config file
+const templatePrefix = "template="
 
-	// Get headers from the first item
-	headers := getHeaders(items[0], options)
+// templateOutputAnnotation marks the commands that render --output template=...; every other
+// command rejects a template instead of ignoring it
+const templateOutputAnnotation = "coolifyme_template_output"
 
-	// Extract data rows
-	var rows [][]string
-	for _, item := range items {
-		row := extractRow(item, headers, options)
-		rows = append(rows, row)
+// supportsTemplateOutput marks cmd as rendering --output template=... and returns it
+func supportsTemplateOutput(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
 	}
-
-	return rows, headers
+	cmd.Annotations[templateOutputAnnotation] = "true"
+	return cmd
 }
 
-func reflectToSlice(data interface{}) []interface{} {
-	v := reflect.ValueOf(data)
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
+// checkTemplateOutput fails when --output asks for a template and cmd cannot render one
+func checkTemplateOutput(cmd *cobra.Command) error {
+	if !templateRequested(cmd) || cmd.Annotations[templateOutputAnnotation] != "" {
+		return nil
 	}
 
-	var items []interface{}
-
-	switch v.Kind() {
-	case reflect.Slice, reflect.Array:
-		for i := 0; i < v.Len(); i++ {
-			items = append(items, v.Index(i).Interface())
+	var supported []string
+	var collect func(*cobra.Command)
+	collect = func(c *cobra.Command) {
+		if c.Annotations[templateOutputAnnotation] != "" {
+			supported = append(supported, strings.TrimPrefix(c.CommandPath(), c.Root().Name()+" "))
+		}
+		for _, child := range c.Commands() {
+			collect(child)
 		}
-	default:
-		// Single item
-		items = append(items, data)
 	}
-
-	return items
+	collect(cmd.Root())
+	return fmt.Errorf("'%s' does not support --output template=...; it is supported by: %s", cmd.CommandPath(), strings.Join(supported, ", "))
 }
 
-func getHeaders(item interface{}, options *FormatOptions) []string {
-	if len(options.Columns) > 0 {
-		// Use specified columns
-		headers := make([]string, len(options.Columns))
-		copy(headers, options.Columns)
-		return headers
+// outputWithTemplate renders data with the template given by --output template=..., for commands
+// with their own output handling. It reports whether --output asked for a template.
+func outputWithTemplate(cmd *cobra.Command, data interface{}) (bool, error) {
+	if !templateRequested(cmd) {
+		return false, nil
 	}
+	return true, outputTemplate(data, templateText(cmd))
+}
 
-	// Auto-detect headers from struct fields
-	var headers []string
-	v := reflect.ValueOf(item)
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
-	}
+// templateRequested reports whether --output asks for a template
+func templateRequested(cmd *cobra.Command) bool {
+	format, _ := cmd.Flags().GetString("output")
+	return strings.HasPrefix(format, templatePrefix)
+}
 
-	if v.Kind() == reflect.Struct {
-		t := v.Type()
-		for i := 0; i < v.NumField(); i++ {
-			field := t.Field(i)
-			// Skip unexported fields
-			if field.PkgPath != "" {
-				continue
-			}
+// templateText returns the template given by --output template=...
+func templateText(cmd *cobra.Command) string {
+	format, _ := cmd.Flags().GetString("output")
+	return strings.TrimPrefix(format, templatePrefix)
+}
 
-			// Use json tag name if available, otherwise field name
-			tag := field.Tag.Get("json")
-			if tag != "" && tag != "-" {
-				// Remove omitempty and other options
-				if idx := strings.Index(tag, ","); idx != -1 {
-					tag = tag[:idx]
-				}
-				headers = append(headers, strings.ToUpper(tag))
-			} else {
-				headers = append(headers, strings.ToUpper(field.Name))
-			}
+// outputTemplate executes a Go template for every item of data and prints one line per item.
+// Templates starting with @ are looked up by name in the config file.
+func outputTemplate(data interface{}, text string) error {
+	if name, ok := strings.CutPrefix(text, "@"); ok {
+		stored, err := config.GetOutputTemplate(name)
+		if err != nil {
+			return err
 		}
+		text = stored
 	}
-
-	return headers
-}
-
-func extractRow(item interface{}, headers []string, _ *FormatOptions) []string {
-	row := make([]string, len(headers))
-
-	for i, header := range headers {
-		row[i] = extractField(item, header)
+	if text == "" {
+		return fmt.Errorf("output template is required, e.g. --output 'template={{.Name}}'")
 	}
 
-	return row
+	tmpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid output template: %w", err)
+	}
+	for _, item := range reflectToSlice(data) {
+		var line bytes.Buffer
+		if err := tmpl.Execute(&line, item); err != nil {
+			return fmt.Errorf("failed to render output template: %w", err)
+		}
+		fmt.Println(strings.TrimSuffix(line.String(), "\n"))
+	}
+	return nil
 }
 
-func extractField(item interface{}, fieldName string) string {
-	v := reflect.ValueOf(item)
+func reflectToSlice(data interface{}) []interface{} {
+	v := reflect.ValueOf(data)
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
 	}
 
-	if v.Kind() != reflect.Struct {
-		return fmt.Sprintf("%v", item)
-	}
-
-	t := v.Type()
-	for i := 0; i < v.NumField(); i++ {
-		field := t.Field(i)
-
-		// Check field name (case-insensitive)
-		if strings.EqualFold(field.Name, fieldName) {
-			return formatFieldValue(v.Field(i))
-		}
-
-		// Check json tag
-		tag := field.Tag.Get("json")
-		if tag != "" && tag != "-" {
-			if idx := strings.Index(tag, ","); idx != -1 {
-				tag = tag[:idx]
-			}
-			if strings.EqualFold(tag, fieldName) {
-				return formatFieldValue(v.Field(i))
-			}
-		}
-	}
-
-	return ""
-}
+	var items []interface{}
 
-func formatFieldValue(value reflect.Value) string {
-	if value.Kind() == reflect.Ptr {
-		if value.IsNil() {
-			return ""
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, v.Index(i).Interface())
 		}
-		value = value.Elem()
-	}
-
-	switch value.Kind() {
-	case reflect.String:
-		return value.String()
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return strconv.FormatInt(value.Int(), 10)
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return strconv.FormatUint(value.Uint(), 10)
-	case reflect.Float32, reflect.Float64:
-		return strconv.FormatFloat(value.Float(), 'f', -1, 64)
-	case reflect.Bool:
-		return strconv.FormatBool(value.Bool())
 	default:
-		// Try to format as time
-		if value.Type() == reflect.TypeOf(time.Time{}) {
-			t := value.Interface().(time.Time)
-			return t.Format("2006-01-02 15:04:05")
-		}
-		return fmt.Sprintf("%v", value.Interface())
+		// Single item
+		items = append(items, data)
 	}
+
+	return items
 }
 
 func sortTableData(rows [][]string, headers []string, sortBy string, reverse bool) {
@@ -466,12 +407,14 @@ func sortTableData(rows [][]string, headers []string, sortBy string, reverse boo
 
 // AddFormatFlags adds formatting flags to a command
 func AddFormatFlags(cmd *cobra.Command) {
-	cmd.Flags().StringP("output", "o", "", "Output format (json, yaml, table, csv, wide, name, custom(format))")
+	supportsTemplateOutput(cmd)
+	cmd.Flags().StringP("output", "o", "", "Output format (json, yaml, table, csv, wide, name, custom(format), template=TEMPLATE or template=@name)")
 	cmd.Flags().String("columns", "", "Comma-separated list of columns to display")
 	cmd.Flags().Bool("no-headers", false, "Don't print headers")
 	cmd.Flags().String("sort-by", "", "Sort by column name")
 	cmd.Flags().Bool("sort-reverse", false, "Reverse sort order")
 	cmd.Flags().Bool("show-kind", false, "Show resource kind/type")
+	addPageSizeFlag(cmd)
 }
 
 // formatCmd demonstrates format options
@@ -486,7 +429,7 @@ var formatExamplesCmd = &cobra.Command{
 	Short: "Show format examples",
 	Long:  "Display examples of different output formats",
 	RunE: func(_ *cobra.Command, _ []string) error {
-		fmt.Println("🎨 Output Format Examples")
+		theme.Println("🎨 Output Format Examples")
 		fmt.Println("========================")
 		fmt.Println()
 
@@ -508,6 +451,11 @@ var formatExamplesCmd = &cobra.Command{
 		fmt.Println("  --output \"custom({name} is {status})\"")
 		fmt.Println()
 
+		fmt.Println("Go templates (one line per item; @name uses 'templates' from the config file):")
+		fmt.Println("  --output 'template={{.Name}} -> {{.Status}}'")
+		fmt.Println("  --output template=@deploy-summary")
+		fmt.Println()
+
 		fmt.Println("Sorting:")
 		fmt.Println("  --sort-by name                # Sort by name column")
 		fmt.Println("  --sort-reverse                # Reverse sort order")
@@ -516,6 +464,7 @@ var formatExamplesCmd = &cobra.Command{
 		fmt.Println("Other options:")
 		fmt.Println("  --no-headers                  # Don't show column headers")
 		fmt.Println("  --show-kind                   # Include resource type")
+		fmt.Println("  --page-size 50                # Pause after every 50 rows on a terminal")
 
 		return nil
 	},
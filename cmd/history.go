@@ -0,0 +1,254 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/history"
+	"github.com/hongkongkiwi/coolifyme/internal/logger"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+// historySecretArgsAnnotation lists the comma-separated indexes of the positional arguments of a
+// command that are redacted in the history, such as the value of an environment variable
+const historySecretArgsAnnotation = "coolifyme_history_secret_args"
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the commands run with coolifyme",
+	Long: `Show the commands run with coolifyme, with their time, profile and result, for example to audit
+what was run against production or to run a complex invocation again with 'history rerun'.
+
+Recording is off by default; enable it with 'coolifyme config set --history'. Entries are kept
+in history.jsonl in the config directory, up to the last 1000 commands. Values of secret flags
+(--token, --header, --password, --value, ...) and environment variable values given as arguments
+are recorded as ***; such entries cannot be rerun.
+
+Examples:
+  coolifyme history
+  coolifyme history --failed --limit 10
+  coolifyme history rerun 42`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		failed, _ := cmd.Flags().GetBool("failed")
+		limit, _ := cmd.Flags().GetInt("limit")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+
+		path, err := historyPath()
+		if err != nil {
+			return err
+		}
+		entries, err := history.Load(path)
+		if err != nil {
+			return fmt.Errorf("failed to read history: %w", err)
+		}
+
+		var shown []history.Entry
+		for _, entry := range entries {
+			if !failed || !entry.Success {
+				shown = append(shown, entry)
+			}
+		}
+		if limit > 0 && len(shown) > limit {
+			shown = shown[len(shown)-limit:]
+		}
+
+		if jsonOutput {
+			if shown == nil {
+				shown = []history.Entry{}
+			}
+			output, err := json.MarshalIndent(shown, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		if len(shown) == 0 {
+			if cfg, err := config.LoadConfig(); err == nil && !cfg.History {
+				fmt.Println("No commands recorded (enable recording with 'coolifyme config set --history')")
+				return nil
+			}
+			fmt.Println("No commands recorded")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "ID\tTIME\tPROFILE\tRESULT\tDURATION\tCOMMAND")
+		_, _ = fmt.Fprintln(w, "--\t----\t-------\t------\t--------\t-------")
+		for _, entry := range shown {
+			result := "ok"
+			if !entry.Success {
+				result = "failed"
+			}
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n",
+				entry.ID,
+				entry.Time.Local().Format(time.DateTime),
+				stringOrDash(&entry.Profile),
+				result,
+				(time.Duration(entry.Duration * float64(time.Second))).Round(time.Millisecond),
+				historyCommandLine(entry.Args))
+		}
+		return w.Flush()
+	},
+}
+
+// historyRerunCmd represents the history rerun command
+var historyRerunCmd = &cobra.Command{
+	Use:   "rerun <id>",
+	Short: "Run a command of the history again",
+	Long: `Run a command of the history again, with the profile it was run with unless --profile is given.
+The command is shown for confirmation first.
+
+Examples:
+  coolifyme history rerun 42
+  coolifyme history rerun 42 --profile staging --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid history ID %q", args[0])
+		}
+
+		path, err := historyPath()
+		if err != nil {
+			return err
+		}
+		entries, err := history.Load(path)
+		if err != nil {
+			return fmt.Errorf("failed to read history: %w", err)
+		}
+		entry, ok := history.Find(entries, id)
+		if !ok {
+			return fmt.Errorf("history entry %d not found (see 'coolifyme history')", id)
+		}
+		if entry.Redacted {
+			return fmt.Errorf("history entry %d contains redacted secrets and cannot be rerun; run it again by hand", id)
+		}
+
+		targetProfile := entry.Profile
+		if cmd.Flags().Changed("profile") {
+			targetProfile = profile
+		}
+
+		theme.Printf("🔁 Rerunning #%d from %s", entry.ID, entry.Time.Local().Format(time.DateTime))
+		if targetProfile != "" {
+			fmt.Printf(" on profile %s", targetProfile)
+		}
+		fmt.Println()
+		fmt.Printf("   %s\n", historyCommandLine(entry.Args))
+		if !confirm.Action("Run this command?", skipConfirmation(cmd)) {
+			theme.Println("❌ Cancelled")
+			return nil
+		}
+
+		executable, err := os.Executable()
+		if err != nil {
+			executable = os.Args[0]
+		}
+		child := exec.CommandContext(cmd.Context(), executable, entry.Args...) // #nosec G204 -- re-executes this binary
+		child.Env = os.Environ()
+		if targetProfile != "" {
+			child.Env = append(child.Env, "COOLIFYME_PROFILE="+targetProfile)
+		}
+		child.Stdin, child.Stdout, child.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := child.Run(); err != nil {
+			return fmt.Errorf("command failed: %w", err)
+		}
+		return nil
+	},
+}
+
+// historyPath returns the file the command history is recorded in
+func historyPath() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "history.jsonl"), nil
+}
+
+// historyCommandLine formats recorded arguments as a command line, quoting where needed
+func historyCommandLine(args []string) string {
+	parts := []string{"coolifyme"}
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"\\$`|&;<>()*?[]{}~#!") {
+			arg = shellQuote(arg)
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
+
+// recordHistory appends a finished command to the history when recording is enabled. args are
+// the arguments the command was run with, after alias expansion. Failures to record are only logged.
+func recordHistory(cmd *cobra.Command, args []string, started time.Time, runErr error) {
+	if cmd == nil || cmd == cmd.Root() || !cmd.Runnable() {
+		return
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == historyCmd || c == completionCmd {
+			return
+		}
+		switch c.Name() {
+		case cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd, "help":
+			return
+		}
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil || !cfg.History {
+		return
+	}
+
+	var secretArgs []int
+	for _, index := range strings.Split(cmd.Annotations[historySecretArgsAnnotation], ",") {
+		if i, err := strconv.Atoi(strings.TrimSpace(index)); err == nil {
+			secretArgs = append(secretArgs, i)
+		}
+	}
+	commandPath := strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()))
+	sanitized, redacted := history.Sanitize(args, cmd.Flags(), len(strings.Fields(commandPath)), secretArgs)
+
+	entry := history.Entry{
+		Time:     started.UTC(),
+		Profile:  activeProfileName(),
+		Command:  commandPath,
+		Args:     sanitized,
+		Success:  runErr == nil,
+		Duration: time.Since(started).Round(time.Millisecond).Seconds(),
+		Redacted: redacted,
+	}
+	if runErr != nil {
+		entry.Error = runErr.Error()
+	}
+
+	path, err := historyPath()
+	if err == nil {
+		_, err = history.Append(path, entry)
+	}
+	if err != nil {
+		logger.Debug("Failed to record command history", "error", err)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.AddCommand(historyRerunCmd)
+
+	historyCmd.Flags().Bool("failed", false, "Only show commands that failed")
+	historyCmd.Flags().Int("limit", 50, "Number of most recent entries to show (0 for all)")
+	historyCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+
+	addConfirmFlags(historyRerunCmd, "Rerun without confirmation")
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// instanceCmd represents the instance command
+var instanceCmd = &cobra.Command{
+	Use:   "instance",
+	Short: "Manage the Coolify instance",
+	Long:  "Maintenance commands for self-hosted Coolify instances - upgrade, settings and cleanup",
+}
+
+// instanceUpgradeCmd represents the instance upgrade command
+var instanceUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Upgrade the Coolify instance",
+	Long:  "Upgrade the Coolify instance to the latest available version",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		if !confirm.Action("Are you sure you want to upgrade the Coolify instance? The instance may be unavailable during the upgrade.", skipConfirmation(cmd)) {
+			theme.Println("❌ Upgrade cancelled")
+			return nil
+		}
+
+		result, err := client.System().Upgrade(ctx)
+		if err != nil {
+			return err
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		theme.Printf("✅ Instance upgrade started\n")
+		if result != "" {
+			theme.Printf("   📝 Response: %s\n", result)
+		}
+		return nil
+	},
+}
+
+// instanceSettingsCmd represents the instance settings command
+var instanceSettingsCmd = &cobra.Command{
+	Use:   "settings",
+	Short: "Manage instance settings",
+	Long:  "Get and set instance-wide settings of the Coolify instance",
+}
+
+// instanceSettingsGetCmd represents the instance settings get command
+var instanceSettingsGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Get instance settings",
+	Long:  "Get all instance settings, or a single setting when a key is given",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		settings, err := client.System().GetSettings(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 1 {
+			value, ok := settings[args[0]]
+			if !ok {
+				return fmt.Errorf("unknown setting: %s", args[0])
+			}
+			settings = clientpkg.InstanceSettings{args[0]: value}
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(settings, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		keys := make([]string, 0, len(settings))
+		for key := range settings {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "KEY\tVALUE")
+		_, _ = fmt.Fprintln(w, "---\t-----")
+		for _, key := range keys {
+			_, _ = fmt.Fprintf(w, "%s\t%v\n", key, settings[key])
+		}
+		_ = w.Flush()
+		return nil
+	},
+}
+
+// instanceSettingsSetCmd represents the instance settings set command
+var instanceSettingsSetCmd = &cobra.Command{
+	Use:   "set <key=value> [key=value...]",
+	Short: "Set instance settings",
+	Long: `Set one or more instance settings.
+
+Values are interpreted as JSON where possible, so booleans and numbers keep their type:
+  coolifyme instance settings set is_auto_update_enabled=false
+  coolifyme instance settings set fqdn=https://coolify.example.com`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		settings, err := parseSettingsArgs(args)
+		if err != nil {
+			return err
+		}
+
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		result, err := client.System().UpdateSettings(ctx, settings)
+		if err != nil {
+			return err
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		theme.Printf("✅ Updated %d instance setting(s)\n", len(settings))
+		if result != "" {
+			theme.Printf("   📝 Response: %s\n", result)
+		}
+		return nil
+	},
+}
+
+// instanceCleanupCmd represents the instance cleanup command
+var instanceCleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Clean up unused resources",
+	Long:  "Remove unused docker images, containers and build caches on all servers of the instance",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		if !confirm.Action("Are you sure you want to clean up unused resources on all servers?", skipConfirmation(cmd)) {
+			theme.Println("❌ Cleanup cancelled")
+			return nil
+		}
+
+		result, err := client.System().Cleanup(ctx)
+		if err != nil {
+			return err
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		theme.Printf("✅ Instance cleanup started\n")
+		if result != "" {
+			theme.Printf("   📝 Response: %s\n", result)
+		}
+		return nil
+	},
+}
+
+// parseSettingsArgs parses key=value arguments into instance settings
+func parseSettingsArgs(args []string) (clientpkg.InstanceSettings, error) {
+	settings := make(clientpkg.InstanceSettings, len(args))
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid setting format: %s (expected key=value)", arg)
+		}
+
+		var value interface{}
+		if err := json.Unmarshal([]byte(parts[1]), &value); err != nil {
+			value = parts[1]
+		}
+		settings[parts[0]] = value
+	}
+	return settings, nil
+}
+
+func init() {
+	// Add subcommands to instance
+	instanceCmd.AddCommand(instanceUpgradeCmd)
+	instanceCmd.AddCommand(instanceSettingsCmd)
+	instanceCmd.AddCommand(instanceCleanupCmd)
+	instanceSettingsCmd.AddCommand(instanceSettingsGetCmd)
+	instanceSettingsCmd.AddCommand(instanceSettingsSetCmd)
+
+	// Flags for upgrade command
+	addConfirmFlags(instanceUpgradeCmd, "Upgrade without confirmation")
+	instanceUpgradeCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+
+	// Flags for settings commands
+	instanceSettingsGetCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+	instanceSettingsSetCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+
+	// Flags for cleanup command
+	addConfirmFlags(instanceCleanupCmd, "Clean up without confirmation")
+	instanceCleanupCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+}
@@ -1,13 +1,12 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-	"strconv"
 	"strings"
 
 	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
 	"github.com/spf13/cobra"
 )
 
@@ -17,14 +16,14 @@ var initInteractiveCmd = &cobra.Command{
 	Short: "Interactive setup wizard",
 	Long:  "Guided setup wizard to configure coolifyme for first-time use",
 	RunE: func(_ *cobra.Command, _ []string) error {
-		fmt.Println("🚀 Welcome to coolifyme interactive setup!")
+		theme.Println("🚀 Welcome to coolifyme interactive setup!")
 		fmt.Println("=====================================")
 		fmt.Println()
 
-		reader := bufio.NewReader(os.Stdin)
+		reader := confirm.Input()
 
 		// Profile name
-		fmt.Print("📛 Profile name [default]: ")
+		theme.Print("📛 Profile name [default]: ")
 		profileName, _ := reader.ReadString('\n')
 		profileName = strings.TrimSpace(profileName)
 		if profileName == "" {
@@ -32,7 +31,7 @@ var initInteractiveCmd = &cobra.Command{
 		}
 
 		// API Token
-		fmt.Print("🔑 Coolify API Token: ")
+		theme.Print("🔑 Coolify API Token: ")
 		apiToken, _ := reader.ReadString('\n')
 		apiToken = strings.TrimSpace(apiToken)
 		if apiToken == "" {
@@ -40,7 +39,7 @@ var initInteractiveCmd = &cobra.Command{
 		}
 
 		// Base URL
-		fmt.Print("🌐 Coolify URL [https://app.coolify.io/api/v1]: ")
+		theme.Print("🌐 Coolify URL [https://app.coolify.io/api/v1]: ")
 		baseURL, _ := reader.ReadString('\n')
 		baseURL = strings.TrimSpace(baseURL)
 		if baseURL == "" {
@@ -48,7 +47,7 @@ var initInteractiveCmd = &cobra.Command{
 		}
 
 		// Output format
-		fmt.Print("📄 Default output format (table/json/yaml) [table]: ")
+		theme.Print("📄 Default output format (table/json/yaml) [table]: ")
 		outputFormat, _ := reader.ReadString('\n')
 		outputFormat = strings.TrimSpace(outputFormat)
 		if outputFormat == "" {
@@ -56,7 +55,7 @@ var initInteractiveCmd = &cobra.Command{
 		}
 
 		// Log level
-		fmt.Print("📝 Log level (debug/info/warn/error) [info]: ")
+		theme.Print("📝 Log level (debug/info/warn/error) [info]: ")
 		logLevel, _ := reader.ReadString('\n')
 		logLevel = strings.TrimSpace(logLevel)
 		if logLevel == "" {
@@ -64,7 +63,7 @@ var initInteractiveCmd = &cobra.Command{
 		}
 
 		// Create profile
-		fmt.Println("\n⚙️  Creating profile...")
+		theme.Println("\n⚙️  Creating profile...")
 
 		cfg := &config.Config{
 			APIToken:     apiToken,
@@ -86,13 +85,13 @@ var initInteractiveCmd = &cobra.Command{
 			return fmt.Errorf("failed to save configuration: %w", err)
 		}
 
-		fmt.Println("✅ Setup completed successfully!")
-		fmt.Printf("   📛 Profile: %s\n", profileName)
-		fmt.Printf("   🌐 URL: %s\n", baseURL)
-		fmt.Printf("   📄 Output: %s\n", outputFormat)
-		fmt.Printf("   📝 Log Level: %s\n", logLevel)
+		theme.Println("✅ Setup completed successfully!")
+		theme.Printf("   📛 Profile: %s\n", profileName)
+		theme.Printf("   🌐 URL: %s\n", baseURL)
+		theme.Printf("   📄 Output: %s\n", outputFormat)
+		theme.Printf("   📝 Log Level: %s\n", logLevel)
 		fmt.Println()
-		fmt.Println("🎉 You can now use coolifyme! Try: coolifyme apps list")
+		theme.Println("🎉 You can now use coolifyme! Try: coolifyme apps list")
 
 		return nil
 	},
@@ -104,18 +103,18 @@ var appCreateWizardCmd = &cobra.Command{
 	Short: "Interactive application creation wizard",
 	Long:  "Guided wizard to create a new application with all necessary configuration",
 	RunE: func(_ *cobra.Command, _ []string) error {
-		fmt.Println("🚀 Application Creation Wizard")
+		theme.Println("🚀 Application Creation Wizard")
 		fmt.Println("=============================")
 		fmt.Println()
 
-		reader := bufio.NewReader(os.Stdin)
+		reader := confirm.Input()
 
-		fmt.Println("📦 Loading projects and servers...")
+		theme.Println("📦 Loading projects and servers...")
 		// This would require API calls to list projects and servers
 		// For now, we'll ask for UUIDs directly
 
 		// Repository URL
-		fmt.Print("📁 Git repository URL: ")
+		theme.Print("📁 Git repository URL: ")
 		repo, _ := reader.ReadString('\n')
 		repo = strings.TrimSpace(repo)
 		if repo == "" {
@@ -123,7 +122,7 @@ var appCreateWizardCmd = &cobra.Command{
 		}
 
 		// Branch
-		fmt.Print("🌿 Git branch [main]: ")
+		theme.Print("🌿 Git branch [main]: ")
 		branch, _ := reader.ReadString('\n')
 		branch = strings.TrimSpace(branch)
 		if branch == "" {
@@ -131,7 +130,7 @@ var appCreateWizardCmd = &cobra.Command{
 		}
 
 		// Build pack
-		fmt.Print("🏗️  Build pack (nixpacks/static/dockerfile/dockercompose) [nixpacks]: ")
+		theme.Print("🏗️  Build pack (nixpacks/static/dockerfile/dockercompose) [nixpacks]: ")
 		buildPack, _ := reader.ReadString('\n')
 		buildPack = strings.TrimSpace(buildPack)
 		if buildPack == "" {
@@ -139,7 +138,7 @@ var appCreateWizardCmd = &cobra.Command{
 		}
 
 		// Project UUID
-		fmt.Print("📦 Project UUID: ")
+		theme.Print("📦 Project UUID: ")
 		project, _ := reader.ReadString('\n')
 		project = strings.TrimSpace(project)
 		if project == "" {
@@ -147,7 +146,7 @@ var appCreateWizardCmd = &cobra.Command{
 		}
 
 		// Server UUID
-		fmt.Print("🖥️  Server UUID: ")
+		theme.Print("🖥️  Server UUID: ")
 		server, _ := reader.ReadString('\n')
 		server = strings.TrimSpace(server)
 		if server == "" {
@@ -155,149 +154,36 @@ var appCreateWizardCmd = &cobra.Command{
 		}
 
 		// Environment
-		fmt.Print("🌍 Environment [production]: ")
+		theme.Print("🌍 Environment [production]: ")
 		environment, _ := reader.ReadString('\n')
 		environment = strings.TrimSpace(environment)
 		if environment == "" {
 			environment = "production"
 		}
 
-		fmt.Println("\n📋 Configuration Summary:")
-		fmt.Printf("   📁 Repository: %s\n", repo)
-		fmt.Printf("   🌿 Branch: %s\n", branch)
-		fmt.Printf("   🏗️  Build Pack: %s\n", buildPack)
-		fmt.Printf("   📦 Project: %s\n", project)
-		fmt.Printf("   🖥️  Server: %s\n", server)
-		fmt.Printf("   🌍 Environment: %s\n", environment)
+		theme.Println("\n📋 Configuration Summary:")
+		theme.Printf("   📁 Repository: %s\n", repo)
+		theme.Printf("   🌿 Branch: %s\n", branch)
+		theme.Printf("   🏗️  Build Pack: %s\n", buildPack)
+		theme.Printf("   📦 Project: %s\n", project)
+		theme.Printf("   🖥️  Server: %s\n", server)
+		theme.Printf("   🌍 Environment: %s\n", environment)
 		fmt.Println()
 
-		fmt.Print("✅ Create application? (y/N): ")
+		theme.Print("✅ Create application? (y/N): ")
 		confirm, _ := reader.ReadString('\n')
 		confirm = strings.TrimSpace(strings.ToLower(confirm))
 
 		if confirm != "y" && confirm != ConfirmationYes {
-			fmt.Println("❌ Application creation cancelled")
+			theme.Println("❌ Application creation cancelled")
 			return nil
 		}
 
-		fmt.Println("🚀 Creating application...")
+		theme.Println("🚀 Creating application...")
 		// This would use the actual create application API
-		fmt.Println("⚠️  Application creation wizard is not fully implemented yet")
+		theme.Println("⚠️  Application creation wizard is not fully implemented yet")
 		fmt.Println("   Use: coolifyme apps create --repo URL --project UUID --server UUID --environment ENV")
 
 		return nil
 	},
 }
-
-// Interactive server setup wizard
-var serverAddWizardCmd = &cobra.Command{
-	Use:   "add-wizard",
-	Short: "Interactive server setup wizard",
-	Long:  "Guided wizard to add a new server with all necessary configuration",
-	RunE: func(_ *cobra.Command, _ []string) error {
-		fmt.Println("🖥️  Server Setup Wizard")
-		fmt.Println("======================")
-		fmt.Println()
-
-		reader := bufio.NewReader(os.Stdin)
-
-		// Server name
-		fmt.Print("📛 Server name: ")
-		name, _ := reader.ReadString('\n')
-		name = strings.TrimSpace(name)
-		if name == "" {
-			return fmt.Errorf("server name is required")
-		}
-
-		// Server IP
-		fmt.Print("🌐 Server IP address: ")
-		ip, _ := reader.ReadString('\n')
-		ip = strings.TrimSpace(ip)
-		if ip == "" {
-			return fmt.Errorf("server IP is required")
-		}
-
-		// SSH user
-		fmt.Print("👤 SSH user [root]: ")
-		user, _ := reader.ReadString('\n')
-		user = strings.TrimSpace(user)
-		if user == "" {
-			user = "root"
-		}
-
-		// SSH port
-		fmt.Print("🔌 SSH port [22]: ")
-		portStr, _ := reader.ReadString('\n')
-		portStr = strings.TrimSpace(portStr)
-		port := 22
-		if portStr != "" {
-			if p, err := strconv.Atoi(portStr); err == nil {
-				port = p
-			}
-		}
-
-		// Private key UUID
-		fmt.Print("🔑 Private key UUID: ")
-		privateKey, _ := reader.ReadString('\n')
-		privateKey = strings.TrimSpace(privateKey)
-		if privateKey == "" {
-			return fmt.Errorf("private key UUID is required")
-		}
-
-		// Proxy type
-		fmt.Print("🔧 Proxy type (traefik/caddy/none) [traefik]: ")
-		proxy, _ := reader.ReadString('\n')
-		proxy = strings.TrimSpace(proxy)
-		if proxy == "" {
-			proxy = "traefik"
-		}
-
-		// Build server
-		fmt.Print("🏗️  Is build server? (y/N): ")
-		buildServerStr, _ := reader.ReadString('\n')
-		buildServerStr = strings.TrimSpace(strings.ToLower(buildServerStr))
-		buildServer := buildServerStr == "y" || buildServerStr == "yes"
-
-		// Description
-		fmt.Print("📝 Description (optional): ")
-		description, _ := reader.ReadString('\n')
-		description = strings.TrimSpace(description)
-
-		fmt.Println("\n📋 Server Configuration Summary:")
-		fmt.Printf("   📛 Name: %s\n", name)
-		fmt.Printf("   🌐 IP: %s:%d\n", ip, port)
-		fmt.Printf("   👤 User: %s\n", user)
-		fmt.Printf("   🔑 Private Key: %s\n", privateKey)
-		fmt.Printf("   🔧 Proxy: %s\n", proxy)
-		fmt.Printf("   🏗️  Build Server: %t\n", buildServer)
-		if description != "" {
-			fmt.Printf("   📝 Description: %s\n", description)
-		}
-		fmt.Println()
-
-		fmt.Print("✅ Add server? (y/N): ")
-		confirm, _ := reader.ReadString('\n')
-		confirm = strings.TrimSpace(strings.ToLower(confirm))
-
-		if confirm != "y" && confirm != "yes" {
-			fmt.Println("❌ Server setup cancelled")
-			return nil
-		}
-
-		fmt.Println("🚀 Adding server...")
-		// This would use the actual create server API
-		fmt.Printf("coolifyme servers create --name \"%s\" --ip \"%s\" --user \"%s\" --port %d --private-key-uuid \"%s\" --proxy-type \"%s\"",
-			name, ip, user, port, privateKey, proxy)
-		if buildServer {
-			fmt.Print(" --is-build-server")
-		}
-		if description != "" {
-			fmt.Printf(" --description \"%s\"", description)
-		}
-		fmt.Println()
-		fmt.Println("⚠️  Server setup wizard is not fully implemented yet")
-		fmt.Println("   Use the command above to create the server")
-
-		return nil
-	},
-}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/link"
+	"github.com/hongkongkiwi/coolifyme/internal/logger"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// linkCandidate is an application deployed from one of the git remotes of the working directory
+type linkCandidate struct {
+	app    coolify.Application
+	remote string
+}
+
+// linkCmd represents the link command
+var linkCmd = &cobra.Command{
+	Use:   "link [app-uuid]",
+	Short: "Link the current repository to a Coolify application",
+	Long: `Link the git repository in the current directory to a Coolify application, so commands
+taking an application UUID, like 'deploy application', 'logs' and 'applications env list', can be
+run without it from anywhere inside the repository.
+
+Without a UUID the application is found by comparing the git remotes of the repository with the
+repositories Coolify deploys from; when several applications match, the one deploying the checked
+out branch is chosen. The link is stored in a .coolifyme file at the root of the repository. It
+contains no credentials and can be committed to share it with the team.
+
+Examples:
+  coolifyme link
+  coolifyme link --remote upstream
+  coolifyme link <app-uuid>`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		root := link.Root(cwd)
+		ctx := context.Background()
+
+		var candidate *linkCandidate
+		if len(args) == 1 {
+			app, err := client.Applications().Get(ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to get application: %w", err)
+			}
+			candidate = &linkCandidate{app: *app}
+			if remotes, err := link.Remotes(root); err == nil && app.GitRepository != nil {
+				for _, name := range link.RemoteNames(remotes) {
+					if link.Matches(remotes[name], *app.GitRepository) {
+						candidate.remote = remotes[name]
+						break
+					}
+				}
+			}
+		} else {
+			remoteName, _ := cmd.Flags().GetString("remote")
+			if candidate, err = findLinkCandidate(ctx, client, root, remoteName); err != nil {
+				return err
+			}
+		}
+
+		active := profile
+		if active == "" {
+			if cfg, err := config.LoadConfig(); err == nil {
+				active = cfg.Profile
+			}
+		}
+		path, err := link.Save(root, &link.Link{
+			Application: stringOrDash(candidate.app.Uuid),
+			Name:        stringOrDash(candidate.app.Name),
+			Profile:     active,
+			Repository:  candidate.remote,
+		})
+		if err != nil {
+			return err
+		}
+		theme.Printf("🔗 Linked to application %s (%s)\n", stringOrDash(candidate.app.Name), stringOrDash(candidate.app.Uuid))
+		theme.Printf("📄 Wrote %s\n", path)
+		return nil
+	},
+}
+
+// findLinkCandidate finds the application deployed from the git remotes of a repository, or only
+// from the given remote
+func findLinkCandidate(ctx context.Context, client *clientpkg.Client, root, remoteName string) (*linkCandidate, error) {
+	remotes, err := link.Remotes(root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the git remotes, run 'coolifyme link <app-uuid>' outside a git repository: %w", err)
+	}
+	names := link.RemoteNames(remotes)
+	if remoteName != "" {
+		if _, ok := remotes[remoteName]; !ok {
+			return nil, fmt.Errorf("git remote '%s' not found", remoteName)
+		}
+		names = []string{remoteName}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("the repository has no git remotes, run 'coolifyme link <app-uuid>'")
+	}
+
+	apps, err := client.Applications().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	var candidates []linkCandidate
+	for _, app := range apps {
+		if app.Uuid == nil || app.GitRepository == nil {
+			continue
+		}
+		for _, name := range names {
+			if link.Matches(remotes[name], *app.GitRepository) {
+				candidates = append(candidates, linkCandidate{app: app, remote: remotes[name]})
+				break
+			}
+		}
+	}
+
+	// Prefer the applications deploying the checked out branch
+	if branch := link.Branch(root); len(candidates) > 1 && branch != "" {
+		var onBranch []linkCandidate
+		for _, candidate := range candidates {
+			if candidate.app.GitBranch != nil && *candidate.app.GitBranch == branch {
+				onBranch = append(onBranch, candidate)
+			}
+		}
+		if len(onBranch) > 0 {
+			candidates = onBranch
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		urls := make([]string, 0, len(names))
+		for _, name := range names {
+			urls = append(urls, remotes[name])
+		}
+		return nil, fmt.Errorf("no application is deployed from %s", strings.Join(urls, ", "))
+	case 1:
+		return &candidates[0], nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "UUID\tNAME\tBRANCH\tREPOSITORY")
+	_, _ = fmt.Fprintln(w, "----\t----\t------\t----------")
+	for _, candidate := range candidates {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", stringOrDash(candidate.app.Uuid), stringOrDash(candidate.app.Name),
+			stringOrDash(candidate.app.GitBranch), stringOrDash(candidate.app.GitRepository))
+	}
+	_ = w.Flush()
+	return nil, fmt.Errorf("%d applications are deployed from this repository, run 'coolifyme link <app-uuid>' with one of them", len(candidates))
+}
+
+// unlinkCmd represents the unlink command
+var unlinkCmd = &cobra.Command{
+	Use:   "unlink",
+	Short: "Remove the link of the current repository to a Coolify application",
+	Long:  "Remove the .coolifyme file written by 'coolifyme link' from the repository containing the current directory",
+	Args:  cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		l, path, err := link.Find(".")
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove link file: %w", err)
+		}
+		theme.Printf("✅ Unlinked from application %s (%s)\n", l.Name, l.Application)
+		return nil
+	},
+}
+
+// linkedApplication returns the link of the working directory, or nil when it is not linked.
+// The link file is read once per run.
+var linkedApplication = sync.OnceValue(func() *link.Link {
+	l, path, err := link.Find(".")
+	if err != nil {
+		if !errors.Is(err, link.ErrNotLinked) {
+			logger.Warn("Ignoring link file", "error", err)
+		}
+		return nil
+	}
+	logger.Debug("Found linked application", "file", path, "application", l.Application)
+	return l
+})
+
+// linkedApplicationUUID returns the UUID of the linked application, warning when it was linked
+// with another profile than the active one
+func linkedApplicationUUID() (string, bool) {
+	l := linkedApplication()
+	if l == nil {
+		return "", false
+	}
+	active := profile
+	if active == "" {
+		if cfg, err := config.LoadConfig(); err == nil {
+			active = cfg.Profile
+		}
+	}
+	if l.Profile != "" && active != "" && l.Profile != active {
+		logger.Warn("The linked application belongs to another profile", "linked", l.Profile, "active", active)
+	}
+	logger.Debug("Using linked application", "application", l.Application, "name", l.Name)
+	return l.Application, true
+}
+
+// enableLinkedApplication lets every command whose first argument is an application UUID run
+// without arguments inside a linked repository, using the linked application instead. Only
+// commands requiring arguments are changed, so commands acting on all applications without
+// arguments keep doing so, and deletes always need an explicit UUID.
+func enableLinkedApplication(cmd *cobra.Command) {
+	for _, child := range cmd.Commands() {
+		enableLinkedApplication(child)
+	}
+	validate := cmd.Args
+	if cmd == linkCmd || cmd.Name() == "delete" || validate == nil || !takesApplicationUUID(cmd) {
+		return
+	}
+
+	withLinked := func(c *cobra.Command, args []string, warn bool) []string {
+		if len(args) > 0 || validate(c, args) == nil {
+			return args
+		}
+		if warn {
+			if uuid, ok := linkedApplicationUUID(); ok {
+				return []string{uuid}
+			}
+		} else if l := linkedApplication(); l != nil {
+			return []string{l.Application}
+		}
+		return args
+	}
+	cmd.Args = func(c *cobra.Command, args []string) error {
+		return validate(c, withLinked(c, args, false))
+	}
+	if runE := cmd.RunE; runE != nil {
+		cmd.RunE = func(c *cobra.Command, args []string) error {
+			return runE(c, withLinked(c, args, true))
+		}
+	}
+	if run := cmd.Run; run != nil {
+		cmd.Run = func(c *cobra.Command, args []string) {
+			run(c, withLinked(c, args, true))
+		}
+	}
+}
+
+// takesApplicationUUID reports whether the first argument of a command is an application UUID
+func takesApplicationUUID(cmd *cobra.Command) bool {
+	fields := strings.Fields(cmd.Use)
+	if len(fields) < 2 || !strings.HasPrefix(fields[1], "<") && !strings.HasPrefix(fields[1], "[") {
+		return false
+	}
+	kinds, _ := uuidArgKinds(cmd)
+	return len(kinds) > 0 && kinds[0] == clientpkg.KindApplication
+}
+
+func init() {
+	rootCmd.AddCommand(linkCmd)
+	rootCmd.AddCommand(unlinkCmd)
+
+	linkCmd.Flags().String("remote", "", "Only match this git remote (default all remotes, origin first)")
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// noLocalDefaultAnnotation marks --project, --environment and --server flags that do not take the
+// defaults of .coolifyme.yaml, e.g. the target of a move
+const noLocalDefaultAnnotation = "coolifyme_no_local_default"
+
+// unsafeLocalDefaults are flags .coolifyme.yaml cannot set, since a cloned repository must not
+// be able to skip confirmations
+var unsafeLocalDefaults = map[string]bool{"force": true, "yes": true}
+
+// localCommandDefaults returns the flag defaults of the directory's overlay that apply to cmd.
+// The overlay comes with the repository, so unlike the global config file it can only set the
+// command's own flags, not global ones such as --server or --token that could send the token to
+// another host.
+func localCommandDefaults(cmd *cobra.Command, commandPath string, local *config.Local) map[string]string {
+	defaults := make(map[string]string)
+	flags := cmd.LocalNonPersistentFlags()
+	for name, value := range local.ResourceDefaults() {
+		if flag := flags.Lookup(name); flag != nil {
+			if _, ok := flag.Annotations[noLocalDefaultAnnotation]; !ok {
+				defaults[name] = value
+			}
+		}
+	}
+	for name, value := range local.CommandDefaults(commandPath) {
+		if flags.Lookup(name) == nil || unsafeLocalDefaults[name] {
+			warn("local config", fmt.Errorf("%s cannot set --%s of '%s', ignoring it", local.Path, name, commandPath))
+			continue
+		}
+		defaults[name] = value
+	}
+	return defaults
+}
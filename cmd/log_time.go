@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// logTimeLayouts are the absolute time formats accepted by --since and --until
+var logTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// lineTimeLayouts are the application log timestamp formats recognised at the start of a line
+var lineTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// parseLogTime parses a --since or --until value: an absolute time in local time unless it has
+// a zone, a duration ago such as 30m, 2h or 1d, or a number of seconds ago
+func parseLogTime(flag, value string, now time.Time) (time.Time, error) {
+	for _, layout := range logTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return now.Add(-time.Duration(seconds) * time.Second), nil
+	}
+	if ago, err := parseSince(value); err == nil {
+		return now.Add(-ago), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --%s '%s' (use e.g. 30m, 2h, 1d or 2024-01-15T10:30:00)", flag, value)
+}
+
+// splitLogTimestamp finds the timestamp at the start of a log line. ok is false when the line
+// does not start with a timestamp; message is the line without the timestamp when it was added
+// by Docker, and the unchanged line when it is part of the application's own log format.
+func splitLogTimestamp(line string) (ts time.Time, message string, ok bool) {
+	// Docker prefixes lines with a single RFC 3339 token when logs are requested with timestamps
+	token, rest, _ := strings.Cut(line, " ")
+	if t, err := time.Parse(time.RFC3339Nano, token); err == nil {
+		return t, rest, true
+	}
+
+	// Application log formats such as "2024-01-15 10:30:45,123 INFO" or "[2024-01-15T10:30:45]"
+	candidate := strings.TrimPrefix(line, "[")
+	for _, fields := range []int{1, 2} {
+		parts := strings.SplitN(candidate, " ", fields+1)
+		if len(parts) < fields {
+			continue
+		}
+		token := strings.TrimSuffix(strings.Join(parts[:fields], " "), "]")
+		token = strings.Replace(token, ",", ".", 1)
+		for _, layout := range lineTimeLayouts {
+			if t, err := time.ParseInLocation(layout, token, time.Local); err == nil {
+				return t, line, true
+			}
+		}
+	}
+	return time.Time{}, line, false
+}
+
+// logTimeFilter selects and formats log lines by their timestamps. Lines without a timestamp,
+// such as stack traces, belong to the closest timestamped line before them.
+type logTimeFilter struct {
+	since, until time.Time
+	timestamps   bool
+
+	// current is the timestamp of the last timestamped line seen
+	current time.Time
+	// found reports whether any line had a timestamp
+	found bool
+}
+
+// logTimeFilterFromFlags creates a filter from the --since, --until and --timestamps flags of a
+// command
+func logTimeFilterFromFlags(cmd *cobra.Command, now time.Time) (*logTimeFilter, error) {
+	filter := &logTimeFilter{}
+	filter.timestamps, _ = cmd.Flags().GetBool("timestamps")
+	var err error
+	if since, _ := cmd.Flags().GetString("since"); since != "" {
+		if filter.since, err = parseLogTime("since", since, now); err != nil {
+			return nil, err
+		}
+	}
+	if until, _ := cmd.Flags().GetString("until"); until != "" {
+		if filter.until, err = parseLogTime("until", until, now); err != nil {
+			return nil, err
+		}
+	}
+	if !filter.since.IsZero() && !filter.until.IsZero() && filter.until.Before(filter.since) {
+		return nil, fmt.Errorf("--until must not be before --since")
+	}
+	return filter, nil
+}
+
+// active reports whether lines are filtered by time
+func (f *logTimeFilter) active() bool {
+	return !f.since.IsZero() || !f.until.IsZero()
+}
+
+// apply returns the line as it should be printed and whether it falls in the time range.
+// Docker timestamps are only kept with --timestamps, like docker logs does.
+func (f *logTimeFilter) apply(line string) (string, bool) {
+	ts, message, ok := splitLogTimestamp(line)
+	if ok {
+		f.current, f.found = ts, true
+		if !f.timestamps {
+			line = message
+		}
+	}
+
+	if !f.active() {
+		return line, true
+	}
+	if f.current.IsZero() {
+		// Lines before the first timestamp cannot be placed in time
+		return line, false
+	}
+	if !f.since.IsZero() && f.current.Before(f.since) {
+		return line, false
+	}
+	if !f.until.IsZero() && f.current.After(f.until) {
+		return line, false
+	}
+	return line, true
+}
+
+// filter applies the filter to a list of lines
+func (f *logTimeFilter) filter(lines []string) []string {
+	selected := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if out, ok := f.apply(line); ok {
+			selected = append(selected, out)
+		}
+	}
+	return selected
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+// loginCmd represents the login command
+var loginCmd = &cobra.Command{
+	Use:   "login <url>",
+	Short: "Create an API token and save it in a profile",
+	Long: `Set up coolifyme for a Coolify instance in one step: open the API token page of the instance in
+the browser, read the token you create there, check it against the API and save it in a profile.
+
+The URL is the address of the instance, e.g. https://coolify.example.com; the API path is added
+when missing. The token is read from --token, from standard input when it is not a terminal, or
+prompted for without echoing it. Create it in the Coolify UI with the permissions coolifyme
+needs (read, write and deploy for most commands).
+
+The token is checked with the version and current team endpoints only; --check-abilities also
+probes its permissions as whoami does.
+
+The profile is created, or its token and URL are replaced when it exists. Replacing a profile
+that points to another instance is confirmed first unless --force is given. The first profile
+becomes the default profile; pass --default to switch to the new one later.
+
+Examples:
+  coolifyme login https://coolify.example.com
+  coolifyme login https://coolify.example.com --name production --default
+  echo "$COOLIFY_TOKEN" | coolifyme login https://coolify.example.com --name ci`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiURL, err := loginAPIURL(args[0])
+		if err != nil {
+			return err
+		}
+
+		name, _ := cmd.Flags().GetString("name")
+		if err := config.ValidateProfileName(name); err != nil {
+			return err
+		}
+		if existing, err := config.LoadProfile(name); err == nil && existing.BaseURL != "" && existing.BaseURL != apiURL {
+			message := fmt.Sprintf("Profile '%s' points to %s. Replace it with %s?", name, existing.BaseURL, apiURL)
+			if !confirm.Action(message, skipConfirmation(cmd)) {
+				theme.Println("❌ Login cancelled")
+				return nil
+			}
+		}
+
+		token := strings.TrimSpace(apiToken)
+		if token == "" {
+			token, err = readLoginToken(cmd, apiURL)
+			if err != nil {
+				return err
+			}
+		}
+		if token == "" {
+			return fmt.Errorf("the API token cannot be empty")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		theme.Printf("🔑 Checking the token against %s...\n", apiURL)
+		c, err := tokenClient(ctx, &config.Config{BaseURL: apiURL, Profile: name}, token)
+		if err != nil {
+			return fmt.Errorf("the token does not work: %w", err)
+		}
+		team, err := c.Teams().GetCurrent(ctx)
+		if err != nil {
+			return fmt.Errorf("the token does not work: %w", err)
+		}
+		if team.Name != nil && *team.Name != "" {
+			theme.Printf("   ✅ Accepted (team %s)\n", *team.Name)
+		} else {
+			theme.Println("   ✅ Accepted")
+		}
+		if checkAbilities, _ := cmd.Flags().GetBool("check-abilities"); checkAbilities {
+			info, err := c.TokenInfo(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to check the token permissions: %w", err)
+			}
+			theme.Printf("   🔐 Permissions: %s\n", info.Scope())
+		}
+
+		created, err := config.SaveProfile(name, token, apiURL)
+		if err != nil {
+			return fmt.Errorf("failed to save profile: %w", err)
+		}
+		if makeDefault, _ := cmd.Flags().GetBool("default"); makeDefault {
+			if err := config.SetDefaultProfile(name); err != nil {
+				return fmt.Errorf("failed to set default profile: %w", err)
+			}
+		}
+
+		if created {
+			theme.Printf("✅ Profile '%s' created\n", name)
+		} else {
+			theme.Printf("✅ Profile '%s' updated\n", name)
+		}
+		theme.Printf("   🌐 Base URL: %s\n", apiURL)
+		if _, defaultProfile, err := config.ListProfiles(); err == nil && defaultProfile != name {
+			theme.Printf("💡 To use this profile: coolifyme config profile use %s\n", name)
+		} else {
+			theme.Println("🎉 You can now use coolifyme! Try: coolifyme apps list")
+		}
+		return nil
+	},
+}
+
+// loginAPIURL turns the address of a Coolify instance into the base URL of its API, adding
+// https:// and /api/v1 when they are missing
+func loginAPIURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid Coolify URL: %s", raw)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid Coolify URL: %s, use http or https", raw)
+	}
+
+	path := strings.TrimRight(u.Path, "/")
+	switch {
+	case strings.HasSuffix(path, "/api/v1"):
+	case strings.HasSuffix(path, "/api"):
+		path += "/v1"
+	default:
+		path += "/api/v1"
+	}
+	u.Path, u.RawQuery, u.Fragment = path, "", ""
+	return u.String(), nil
+}
+
+// readLoginToken reads the API token from standard input when it is piped, or opens the token
+// page and prompts for the token otherwise
+func readLoginToken(cmd *cobra.Command, apiURL string) (string, error) {
+	if !theme.IsTerminal(os.Stdin) {
+		line, err := confirm.Input().ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", fmt.Errorf("failed to read the API token: %w", err)
+		}
+		return strings.TrimSpace(line), nil
+	}
+
+	page := apiTokensPage(apiURL)
+	theme.Printf("🌐 Create an API token at %s\n", page)
+	if noBrowser, _ := cmd.Flags().GetBool("no-browser"); !noBrowser {
+		if err := openBrowser(page); err != nil {
+			theme.Println("   Open the page above in your browser")
+		}
+	}
+	token, err := promptPassphrase("Paste the API token: ")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(token), nil
+}
+
+// openBrowser opens a URL in the default browser without waiting for it
+func openBrowser(target string) error {
+	var browser *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		browser = exec.Command("open", target) // #nosec G204 -- fixed program, the URL is an argument
+	case "windows":
+		browser = exec.Command("rundll32", "url.dll,FileProtocolHandler", target) // #nosec G204 -- fixed program, the URL is an argument
+	default:
+		browser = exec.Command("xdg-open", target) // #nosec G204 -- fixed program, the URL is an argument
+	}
+	if err := browser.Start(); err != nil {
+		return err
+	}
+	go func() { _ = browser.Wait() }()
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(loginCmd)
+
+	loginCmd.Flags().String("name", config.DefaultProfileName, "Name of the profile to create or update")
+	loginCmd.Flags().Bool("default", false, "Make the profile the default profile")
+	loginCmd.Flags().Bool("no-browser", false, "Only print the API token page instead of opening it")
+	loginCmd.Flags().Bool("check-abilities", false, "Also probe which permissions the token has (sends harmless requests needing write and deploy)")
+	addConfirmFlags(loginCmd, "Replace a profile pointing to another instance without confirmation")
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/logmux"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// logsCmd represents the logs command
+var logsCmd = &cobra.Command{
+	Use:   "logs <app-uuid>...",
+	Short: "Show or follow the logs of several applications at once",
+	Long: `Show the logs of one or more applications, interleaved and prefixed with the name of the
+application each line comes from, like docker compose logs. Prefixes are colored per application
+when colors are enabled.
+
+With --follow every application is polled on its own and reconnected after network errors, so
+an application that cannot be reached does not stop the logs of the others. The same --lines,
+--since, --until and --timestamps handling as 'applications logs' applies to each application.
+
+Examples:
+  coolifyme logs <app-uuid> <app-uuid> --follow
+  coolifyme logs <app-uuid> <app-uuid> <app-uuid> --since 15m`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		now := time.Now()
+		follow, _ := cmd.Flags().GetBool("follow")
+
+		labels, err := applicationLogLabels(ctx, client, args)
+		if err != nil {
+			return err
+		}
+
+		sources := make([]logmux.Source, len(args))
+		for i, appUUID := range args {
+			filter, err := logTimeFilterFromFlags(cmd, now)
+			if err != nil {
+				return err
+			}
+			params := applicationLogsParams(cmd, filter)
+
+			var previous []string
+			sources[i] = logmux.Source{
+				Label: labels[i],
+				Poll: func(ctx context.Context) ([]string, error) {
+					logs, err := client.Applications().GetLogs(ctx, appUUID, params)
+					if err != nil {
+						return nil, err
+					}
+					logs = strings.TrimRight(logs, "\n")
+					if logs == "" {
+						return nil, nil
+					}
+					current := strings.Split(logs, "\n")
+					lines := newLogLines(previous, current)
+					previous = current
+					return filter.filter(lines), nil
+				},
+			}
+		}
+
+		interval, _ := cmd.Flags().GetInt("interval")
+		if interval < 1 {
+			interval = 2 // Default 2 seconds
+		}
+		watch := getWatchConfig(cmd, time.Duration(interval)*time.Second)
+
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+		err = logmux.Run(ctx, os.Stdout, sources, logmux.Options{
+			Follow:     follow,
+			Interval:   watch.Interval,
+			MaxRetries: watch.MaxRetries,
+			RetryDelay: watch.RetryDelay,
+			MaxBackoff: watch.MaxBackoff,
+			Color:      theme.ColorEnabled(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get application logs: %w", err)
+		}
+		return nil
+	},
+}
+
+// applicationLogLabels returns the line prefixes of the logs of applications: their names, or
+// their UUIDs for applications without a name or sharing it with another one
+func applicationLogLabels(ctx context.Context, client *clientpkg.Client, uuids []string) ([]string, error) {
+	labels := make([]string, len(uuids))
+	count := make(map[string]int, len(uuids))
+	for i, appUUID := range uuids {
+		app, err := client.Applications().Get(ctx, appUUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get application %s: %w", appUUID, err)
+		}
+		labels[i] = appUUID
+		if app != nil && app.Name != nil && *app.Name != "" {
+			labels[i] = *app.Name
+		}
+		count[labels[i]]++
+	}
+	for i, label := range labels {
+		if count[label] > 1 {
+			labels[i] = uuids[i]
+		}
+	}
+	return labels, nil
+}
+
+func init() {
+	rootCmd.AddCommand(logsCmd)
+	addApplicationLogsFlags(logsCmd)
+}
@@ -5,9 +5,15 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
 	"github.com/hongkongkiwi/coolifyme/internal/logger"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
 	"github.com/hongkongkiwi/coolifyme/pkg/client"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -23,6 +29,16 @@ var (
 	verbose      bool
 	debug        bool
 	quiet        bool
+	noEmoji      bool
+	strictDecode bool
+	// readOnly refuses every API request that would change something, like a read_only profile
+	readOnly bool
+	// requestHeaders are the extra "Name: value" headers given with --header
+	requestHeaders []string
+	// maxResponseMB is the response size limit in MiB given with --max-response-mb, -1 when unset
+	maxResponseMB int64
+	// colorMode is the color mode resolved from the flag, environment and config file
+	colorMode theme.ColorMode
 
 	// Version information - set by build process
 	Version = "dev"
@@ -41,11 +57,11 @@ It provides easy access to deploy and manage your applications,
 services, and infrastructure through Coolify.
 
 Examples:
-  # Initialize configuration
-  coolifyme config init
+  # Log in to your Coolify instance, creating the default profile
+  coolifyme login https://coolify.yourdomain.com
 
-  # Set up a profile for your Coolify instance
-  coolifyme config profile create production --token YOUR_TOKEN --url https://coolify.yourdomain.com/api/v1
+  # Set up another profile for your Coolify instance
+  coolifyme login https://coolify.yourdomain.com --name production
 
   # Switch between profiles
   coolifyme config profile use production
@@ -59,16 +75,68 @@ Examples:
 Created by Andy Savage <andy@savage.hk>
 Source: https://github.com/hongkongkiwi/coolifyme`,
 	Version: getVersionString(),
-	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// --profile wins over the profile of .coolifyme.yaml wherever the config is loaded
+		if cmd.Flags().Changed("profile") {
+			_ = os.Setenv("COOLIFYME_PROFILE", profile)
+		}
+		local, err := config.LoadLocal()
+		if err != nil {
+			return err
+		}
+		applyCommandDefaults(cmd, local)
 		setupLogging()
+		versionCheckExempt = exemptFromVersionCheck(cmd)
+		if err := setupColor(cmd); err != nil {
+			return err
+		}
+		if err := checkTemplateOutput(cmd); err != nil {
+			return err
+		}
+		if err := openOutputFile(cmd); err != nil {
+			return err
+		}
+		if cfg, err := config.LoadConfig(); err == nil {
+			confirm.SetRequireName(cfg.ConfirmByName)
+		}
+		return resolveUUIDArgs(cmd, args)
 	},
 }
 
 func main() {
-	if err := rootCmd.Execute(); err != nil {
+	// Expand user-defined aliases before cobra dispatches the command
+	args := os.Args[1:]
+	if aliases, err := config.GetAliases(); err == nil && len(aliases) > 0 {
+		args, err = expandUserAlias(args, aliases)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		rootCmd.SetArgs(args)
+	}
+
+	// Commands taking an application UUID default to the application linked with 'coolifyme link'
+	enableLinkedApplication(rootCmd)
+
+	newerVersion := startUpdateCheck()
+	started := time.Now()
+	cmd, err := rootCmd.ExecuteC()
+	recordHistory(cmd, args, started, err)
+	if closeErr := closeOutputFile(); err == nil {
+		err = closeErr
+	}
+	printWarnings()
+	if err == nil {
+		err = warningsError()
+	}
+	if err != nil {
 		logger.Error("Command failed", "error", err)
+		if client.IsPermissionError(err) {
+			fmt.Fprint(os.Stderr, theme.Sprintf("💡 Run 'coolifyme config token-info' to see the permissions of your API token\n"))
+		}
 		os.Exit(1)
 	}
+	printUpdateHint(newerVersion, cmd)
 }
 
 func init() {
@@ -98,6 +166,10 @@ func init() {
 	rootCmd.AddCommand(timeoutCmd)
 	rootCmd.AddCommand(formatCmd)
 	rootCmd.AddCommand(rollbackCmd)
+	rootCmd.AddCommand(instanceCmd)
+	rootCmd.AddCommand(foreachProfileCmd)
+	rootCmd.AddCommand(sourcesCmd)
+	rootCmd.AddCommand(bootstrapCmd)
 
 	// Add alias commands at root level for convenience
 	rootCmd.AddCommand(deployAppCmd)
@@ -120,18 +192,70 @@ func init() {
 	rootCmd.PersistentFlags().StringP("server", "s", "", "Coolify server URL")
 	rootCmd.PersistentFlags().StringP("token", "t", "", "API token")
 	rootCmd.PersistentFlags().StringP("profile", "p", "", "configuration profile to use")
-	rootCmd.PersistentFlags().StringP("output", "o", "", "output format (json, yaml, table)")
-	rootCmd.PersistentFlags().String("color", "auto", "colorize output (auto, always, never)")
+	rootCmd.PersistentFlags().StringP("output", "o", "", "output format (json, yaml, table, template=TEMPLATE or template=@name)")
+	rootCmd.PersistentFlags().String("output-file", "", "write the command output to a file instead of standard output")
+	rootCmd.PersistentFlags().Bool("append", false, "append to --output-file instead of replacing it")
+	rootCmd.PersistentFlags().StringVar(&colorOutput, "color", "auto", "colorize output (auto, always, never); NO_COLOR and CLICOLOR_FORCE apply when not given")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "debug output (shows API calls)")
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "quiet output (errors only)")
+	rootCmd.PersistentFlags().BoolVar(&failOnWarn, "fail-on-warn", false, "exit with an error when the command reports warnings, e.g. a resource type a search could not list")
+	rootCmd.PersistentFlags().BoolVar(&noEmoji, "no-emoji", false, "replace emoji with plain ASCII in output")
+	rootCmd.PersistentFlags().String("theme", "dark", "color theme (dark, light, none)")
+	rootCmd.PersistentFlags().Bool("exact", false, "require full UUIDs instead of accepting unique prefixes")
+	rootCmd.PersistentFlags().StringArrayVarP(&requestHeaders, "header", "H", nil, "extra HTTP header sent with every API request as 'Name: value' (repeatable, overrides profile headers)")
+	rootCmd.PersistentFlags().BoolVar(&skipVersionCheck, "skip-version-check", false, "do not warn about Coolify releases outside the tested range")
+	rootCmd.PersistentFlags().BoolVar(&readOnly, "read-only", false, "refuse every API request that would change something (also the read_only profile setting)")
+	rootCmd.PersistentFlags().Int64Var(&maxResponseMB, "max-response-mb", -1, "largest API response body accepted in MiB, 0 for no limit (default 256, or max_response_mb in the config file)")
+	rootCmd.PersistentFlags().BoolVar(&strictDecode, "strict-decode", false, "fail reads whose responses contain fields unknown to this version, warn for changes (logged with --debug otherwise)")
 
 	// Bind flags to viper
 	_ = viper.BindPFlag("server_url", rootCmd.PersistentFlags().Lookup("server"))
 	_ = viper.BindPFlag("api_token", rootCmd.PersistentFlags().Lookup("token"))
 	_ = viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
 	_ = viper.BindPFlag("output_format", rootCmd.PersistentFlags().Lookup("output"))
-	_ = viper.BindPFlag("color_output", rootCmd.PersistentFlags().Lookup("color"))
+	_ = viper.BindPFlag("theme", rootCmd.PersistentFlags().Lookup("theme"))
+	_ = viper.BindPFlag("no_emoji", rootCmd.PersistentFlags().Lookup("no-emoji"))
+}
+
+// applyCommandDefaults sets flags that were not given on the command line to the
+// defaults configured for the command in the config file, overridden by those of the
+// directory's .coolifyme.yaml
+func applyCommandDefaults(cmd *cobra.Command, local *config.Local) {
+	commandPath := strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()))
+	defaults, err := config.CommandDefaults(commandPath)
+	if err != nil || defaults == nil {
+		defaults = make(map[string]string)
+	}
+	if local != nil {
+		for name, value := range localCommandDefaults(cmd, commandPath, local) {
+			defaults[name] = value
+		}
+	}
+	if len(defaults) == 0 {
+		return
+	}
+
+	for name, value := range defaults {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			warn("command defaults", fmt.Errorf("ignoring default for unknown flag --%s of '%s'", name, commandPath))
+			continue
+		}
+		if flag.Changed {
+			continue
+		}
+		// The request file replaces the flags setting request fields
+		if _, ok := flag.Annotations[requestFlagAnnotation]; ok && cmd.Flags().Changed("from-file") {
+			continue
+		}
+		if err := cmd.Flags().Set(name, value); err != nil {
+			warn("command defaults", fmt.Errorf("invalid default for --%s of '%s': %w", name, commandPath, err))
+		}
+	}
+
+	// Global flags bound to viper may have changed
+	outputFormat = viper.GetString("output_format")
 }
 
 // setupLogging configures the logging system based on flags and config
@@ -173,29 +297,35 @@ func setupLogging() {
 		logger.SetJSONOutput()
 	}
 
-	// Configure color output based on setting
-	shouldUseColor := shouldEnableColor()
-	logger.SetColorOutput(shouldUseColor)
+	// Configure the output theme
+	if err := theme.Set(viper.GetString("theme")); err != nil {
+		logger.Warn("Invalid theme, falling back to default", "error", err)
+	}
+	theme.SetEmoji(!viper.GetBool("no_emoji") && theme.TerminalSupportsUTF8())
 
-	logger.Debug("Logging initialized",
-		"level", logLevel.String(),
-		"color", shouldUseColor,
-	)
+	logger.Debug("Logging initialized", "level", logLevel.String())
 }
 
-// shouldEnableColor determines if color output should be enabled
-func shouldEnableColor() bool {
-	switch colorOutput {
-	case "always":
-		return true
-	case "never":
-		return false
-	case "auto":
-		fallthrough
-	default:
-		// Auto-detect based on TTY
-		return logger.IsTerminal()
+// setupColor resolves the color mode from the --color flag, NO_COLOR and CLICOLOR_FORCE, the
+// config file and terminal detection, in that order. In auto mode, logs are colored when
+// standard error is a terminal and command output when standard output is one.
+func setupColor(cmd *cobra.Command) error {
+	var configured *bool
+	if cfg, err := config.LoadConfig(); err == nil {
+		configured = cfg.ColorOutput
+	}
+
+	flag := cmd.Flags().Lookup("color")
+	mode, err := theme.ResolveColorMode(colorOutput, flag != nil && flag.Changed, configured, os.Getenv)
+	if err != nil {
+		return err
 	}
+	colorMode = mode
+
+	logger.SetColorOutput(mode.Enabled(theme.IsTerminal(os.Stderr)))
+	theme.SetColor(mode.Enabled(theme.IsTerminal(os.Stdout)))
+	logger.Debug("Color output configured", "mode", mode, "stdout", theme.IsTerminal(os.Stdout))
+	return nil
 }
 
 // initConfig reads in config file and ENV variables if set
@@ -228,12 +358,12 @@ func initConfig() {
 
 	// Store global flag values for use in other functions
 	outputFormat = viper.GetString("output_format")
-	colorOutput = viper.GetString("color_output")
 	profile = viper.GetString("profile")
 }
 
-// Helper function to create a client from configuration
-func createClient() (*client.Client, error) {
+// createClient creates an API client from the config and global flags. Extra options are applied
+// after the defaults, e.g. to add transport middleware.
+func createClient(opts ...client.Option) (*client.Client, error) {
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
@@ -249,6 +379,14 @@ func createClient() (*client.Client, error) {
 	if profile != "" {
 		cfg.Profile = profile
 	}
+	if readOnly {
+		cfg.ReadOnly = true
+	}
+
+	// Surface actionable configuration problems before talking to the API
+	for _, issue := range config.ValidateProfile(cfg.Profile, config.Profile{APIToken: cfg.APIToken, BaseURL: cfg.BaseURL}) {
+		logger.Warn("Configuration issue", "issue", issue.String())
+	}
 
 	logger.Debug("Creating client",
 		"baseURL", cfg.BaseURL,
@@ -256,7 +394,44 @@ func createClient() (*client.Client, error) {
 		"hasToken", cfg.APIToken != "",
 	)
 
-	return client.New(cfg)
+	options := []client.Option{
+		client.WithUserAgent("coolifyme/" + Version),
+		client.WithStrictDecoding(strictDecode),
+	}
+	for _, header := range requestHeaders {
+		name, value, ok := strings.Cut(header, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid --header %q: use 'Name: value'", header)
+		}
+		options = append(options, client.WithHeader(strings.TrimSpace(name), strings.TrimSpace(value)))
+	}
+
+	// --max-response-mb, COOLIFYME_MAX_RESPONSE_MB and max_response_mb in the config file raise or
+	// lower the response size limit, 0 disables it
+	limit := cfg.MaxResponseMB
+	if env := os.Getenv("COOLIFYME_MAX_RESPONSE_MB"); env != "" {
+		mb, err := strconv.ParseInt(env, 10, 64)
+		if err != nil || mb < 0 {
+			return nil, fmt.Errorf("invalid COOLIFYME_MAX_RESPONSE_MB %q: use a number of MiB", env)
+		}
+		limit = &mb
+	}
+	if maxResponseMB >= 0 {
+		limit = &maxResponseMB
+	}
+	if limit != nil {
+		if *limit < 0 {
+			return nil, fmt.Errorf("invalid max_response_mb %d: use a number of MiB", *limit)
+		}
+		options = append(options, client.WithMaxResponseSize(*limit<<20))
+	}
+
+	c, err := client.New(cfg, append(options, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	checkServerVersion(c)
+	return c, nil
 }
 
 // Enhanced version command
@@ -272,6 +447,9 @@ var versionCmd = &cobra.Command{
 				"version":   Version,
 				"gitCommit": GitCommit,
 				"buildDate": BuildDate,
+				"os":        runtime.GOOS,
+				"arch":      runtime.GOARCH,
+				"goVersion": runtime.Version(),
 			}
 			fmt.Println(mustMarshalJSON(versionInfo))
 			return
@@ -280,8 +458,9 @@ var versionCmd = &cobra.Command{
 		fmt.Printf("coolifyme %s\n", getVersionString())
 		fmt.Printf("Git commit: %s\n", GitCommit)
 		fmt.Printf("Build date: %s\n", BuildDate)
+		fmt.Printf("Platform:   %s/%s (%s)\n", runtime.GOOS, runtime.GOARCH, runtime.Version())
 		fmt.Println()
-		fmt.Println("Built with ❤️ for the Coolify community")
+		theme.Println("Built with ❤️ for the Coolify community")
 		fmt.Println("Source: https://github.com/hongkongkiwi/coolifyme")
 	},
 }
@@ -290,7 +469,11 @@ var versionCmd = &cobra.Command{
 var completionCmd = &cobra.Command{
 	Use:   "completion [bash|zsh|fish|powershell]",
 	Short: "Generate completion script",
-	Long: `To load completions:
+	Long: `To install completions for your shell in one step:
+
+  $ coolifyme completion install
+
+To load completions:
 
 Bash:
   $ source <(coolifyme completion bash)
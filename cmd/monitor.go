@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hongkongkiwi/coolifyme/internal/report"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
 	"github.com/spf13/cobra"
 )
 
@@ -12,7 +14,29 @@ import (
 var monitorCmd = &cobra.Command{
 	Use:   "monitor",
 	Short: "Monitor Coolify resources",
-	Long:  "Monitor applications, services, and infrastructure health",
+	Long: `Monitor applications, services, and infrastructure health.
+
+With --prometheus coolifyme runs as a Prometheus exporter: it polls Coolify every --interval
+seconds and serves these metrics at /metrics on the given address:
+
+  coolifyme_up                             whether the last poll succeeded
+  coolifyme_application_status             status of each application (value 1, status label)
+  coolifyme_application_running            1 when an application is running, else 0
+  coolifyme_deployments_total              deployments finished since start, by result (success/failure)
+  coolifyme_server_reachable               1 when Coolify can reach a server, else 0
+  coolifyme_api_request_duration_seconds   histogram of API latency by method, resource and code
+
+Examples:
+  coolifyme monitor --prometheus :9123
+  coolifyme monitor --prometheus 127.0.0.1:9123 --interval 60`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		address, _ := cmd.Flags().GetString("prometheus")
+		if address == "" {
+			return cmd.Help()
+		}
+		return runPrometheusExporter(cmd, address)
+	},
 }
 
 // Health check command
@@ -30,55 +54,91 @@ var healthCmd = &cobra.Command{
 		}
 
 		verbose, _ := cmd.Flags().GetBool("verbose")
+		healthReport := report.New("coolifyme.health")
 
-		fmt.Println("🏥 Coolify Health Check")
+		theme.Println("🏥 Coolify Health Check")
 		fmt.Println("======================")
 
 		// Test API connectivity
-		fmt.Print("📡 API Connection... ")
+		theme.Print("📡 API Connection... ")
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
 		// Use a simple API call to test connectivity
+		started := time.Now()
 		_, err = client.Teams().List(ctx)
+		healthReport.Add(healthResult("api", "API connection", started, 0, err))
 		if err != nil {
-			fmt.Printf("❌ FAILED: %v\n", err)
+			theme.Printf("❌ FAILED: %v\n", err)
+			if reportErr := writeReportFile(cmd, healthReport); reportErr != nil {
+				warn("report file", reportErr)
+			}
 			return fmt.Errorf("API health check failed")
 		}
-		fmt.Println("✅ OK")
+		theme.Println("✅ OK")
 
 		if verbose {
 			// Additional checks in verbose mode
-			fmt.Print("📦 Applications... ")
+			theme.Print("📦 Applications... ")
+			started = time.Now()
 			apps, err := client.Applications().List(ctx)
+			healthReport.Add(healthResult("applications", "list applications", started, len(apps), err))
 			if err != nil {
-				fmt.Printf("❌ FAILED: %v\n", err)
+				theme.Printf("❌ FAILED: %v\n", err)
 			} else {
-				fmt.Printf("✅ OK (%d found)\n", len(apps))
+				theme.Printf("✅ OK (%d found)\n", len(apps))
 			}
 
-			fmt.Print("🖥️  Servers... ")
+			theme.Print("🖥️  Servers... ")
+			started = time.Now()
 			servers, err := client.Servers().List(ctx)
+			healthReport.Add(healthResult("servers", "list servers", started, len(servers), err))
 			if err != nil {
-				fmt.Printf("❌ FAILED: %v\n", err)
+				theme.Printf("❌ FAILED: %v\n", err)
 			} else {
-				fmt.Printf("✅ OK (%d found)\n", len(servers))
+				theme.Printf("✅ OK (%d found)\n", len(servers))
 			}
 
-			fmt.Print("🔧 Services... ")
+			theme.Print("🔧 Services... ")
+			started = time.Now()
 			services, err := client.Services().List(ctx)
+			healthReport.Add(healthResult("services", "list services", started, len(services), err))
 			if err != nil {
-				fmt.Printf("❌ FAILED: %v\n", err)
+				theme.Printf("❌ FAILED: %v\n", err)
 			} else {
-				fmt.Printf("✅ OK (%d found)\n", len(services))
+				theme.Printf("✅ OK (%d found)\n", len(services))
 			}
 		}
 
-		fmt.Println("\n🎉 All health checks passed!")
+		if err := writeReportFile(cmd, healthReport); err != nil {
+			return err
+		}
+		if failures := healthReport.Failures(); failures > 0 {
+			return fmt.Errorf("%d health check(s) failed", failures)
+		}
+
+		theme.Println("\n🎉 All health checks passed!")
 		return nil
 	},
 }
 
+// healthResult converts the outcome of a health check into a report result
+func healthResult(resource, name string, started time.Time, count int, err error) report.Result {
+	result := report.Result{
+		Name:     name,
+		Resource: resource,
+		Status:   report.StatusPassed,
+		Duration: time.Since(started),
+	}
+	if err != nil {
+		result.Status = report.StatusFailed
+		result.Message = err.Error()
+	} else if resource != "api" {
+		result.Message = fmt.Sprintf("%d found", count)
+	}
+	return result
+}
+
 // Status command for quick overview
 var statusCmd = &cobra.Command{
 	Use:   "status",
@@ -92,7 +152,7 @@ var statusCmd = &cobra.Command{
 
 		ctx := context.Background()
 
-		fmt.Println("📊 Coolify Status Overview")
+		theme.Println("📊 Coolify Status Overview")
 		fmt.Println("=========================")
 
 		// Applications status
@@ -117,30 +177,34 @@ var statusCmd = &cobra.Command{
 				}
 			}
 
-			fmt.Printf("📱 Applications: %d total\n", len(apps))
+			theme.Printf("📱 Applications: %d total\n", len(apps))
 			if running > 0 {
-				fmt.Printf("   ✅ Running: %d\n", running)
+				theme.Printf("   ✅ Running: %d\n", running)
 			}
 			if stopped > 0 {
-				fmt.Printf("   ⏹️  Stopped: %d\n", stopped)
+				theme.Printf("   ⏹️  Stopped: %d\n", stopped)
 			}
 			if unknown > 0 {
-				fmt.Printf("   ❓ Unknown: %d\n", unknown)
+				theme.Printf("   ❓ Unknown: %d\n", unknown)
 			}
 		}
 
 		// Servers status
-		servers, err := client.Servers().List(ctx)
-		if err == nil {
-			fmt.Printf("🖥️  Servers: %d total\n", len(servers))
+		servers, serversErr := client.Servers().List(ctx)
+		if serversErr == nil {
+			theme.Printf("🖥️  Servers: %d total\n", len(servers))
 		}
 
 		// Services status
-		services, err := client.Services().List(ctx)
-		if err == nil {
-			fmt.Printf("🔧 Services: %d total\n", len(services))
+		services, servicesErr := client.Services().List(ctx)
+		if servicesErr == nil {
+			theme.Printf("🔧 Services: %d total\n", len(services))
 		}
 
+		// Only fail when Coolify could not be reached at all, so watch can reconnect
+		if err != nil && serversErr != nil && servicesErr != nil {
+			return fmt.Errorf("failed to reach Coolify: %w", err)
+		}
 		return nil
 	},
 }
@@ -156,24 +220,18 @@ var watchCmd = &cobra.Command{
 			interval = 30 // Default 30 seconds
 		}
 
-		fmt.Printf("🔄 Watching Coolify status (refresh every %ds, Ctrl+C to stop)...\n\n", interval)
+		theme.Printf("🔄 Watching Coolify status (refresh every %ds, Ctrl+C to stop)...\n\n", interval)
 
-		for {
+		return WatchLoop(context.Background(), getWatchConfig(cmd, time.Duration(interval)*time.Second), func(_ context.Context) (bool, error) {
 			// Clear screen (works on most terminals)
 			fmt.Print("\033[2J\033[H")
 
 			// Show timestamp
-			fmt.Printf("🕒 Last updated: %s\n\n", time.Now().Format("2006-01-02 15:04:05"))
+			theme.Printf("🕒 Last updated: %s\n\n", time.Now().Format("2006-01-02 15:04:05"))
 
 			// Run status command
-			err := statusCmd.RunE(cmd, []string{})
-			if err != nil {
-				fmt.Printf("❌ Error: %v\n", err)
-			}
-
-			// Wait for next refresh
-			time.Sleep(time.Duration(interval) * time.Second)
-		}
+			return false, statusCmd.RunE(cmd, []string{})
+		})
 	},
 }
 
@@ -183,9 +241,15 @@ func init() {
 	monitorCmd.AddCommand(statusCmd)
 	monitorCmd.AddCommand(watchCmd)
 
+	// Monitor command flags
+	monitorCmd.Flags().String("prometheus", "", "Serve Prometheus metrics on this address, e.g. :9123")
+	monitorCmd.Flags().Int("interval", 30, "Poll interval in seconds for --prometheus")
+
 	// Health command flags
 	healthCmd.Flags().BoolP("verbose", "v", false, "Verbose health check output")
+	addReportFlags(healthCmd)
 
 	// Watch command flags
 	watchCmd.Flags().IntP("interval", "i", 30, "Refresh interval in seconds")
+	addWatchFlags(watchCmd)
 }
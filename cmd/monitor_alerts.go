@@ -0,0 +1,377 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/events"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// alertTracker counts for how many consecutive intervals each rule has matched each resource
+type alertTracker struct {
+	counts map[string]int
+}
+
+// observe records whether a rule matches a resource in the current interval and reports whether
+// the rule fires now, or is resolved because the condition cleared after the rule fired. A rule
+// fires once when it reaches its threshold and is re-armed after the condition clears.
+func (t *alertTracker) observe(rule, resource string, active bool, threshold int) (fired, resolved bool) {
+	key := rule + "/" + resource
+	if !active {
+		resolved = t.counts[key] >= threshold
+		delete(t.counts, key)
+		return false, resolved
+	}
+	t.counts[key]++
+	return t.counts[key] == threshold, false
+}
+
+// monitorRunCmd represents the monitor run command
+var monitorRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run the monitoring loop with alert rules",
+	Long: `Periodically evaluate the alert rules from the configuration file and run their actions.
+
+Rules are configured under 'alerts' in the config file:
+
+  alerts:
+    - name: app-down
+      condition: app_status        # app_status, service_status, deployment_failed or server_unreachable
+      status: running              # expected status for app_status/service_status (default running)
+      resource: my-app             # optional application/service/server UUID or name
+      for: 2                       # consecutive intervals before firing (default 1)
+      actions:
+        - exec: ./restart.sh       # alert details are passed in COOLIFYME_ALERT_* variables
+        - webhook: https://hooks.example.com/coolify
+        - notify: true             # desktop notification
+
+A rule fires once when its condition has held for 'for' intervals, and again only after the
+condition cleared. With --once the rules are evaluated a single time (ignoring 'for') and the
+command fails when any rule fired, which suits cron jobs.
+
+Examples:
+  coolifyme monitor run
+  coolifyme monitor run --interval 30
+  coolifyme monitor run --once`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		rules, err := config.GetAlertRules()
+		if err != nil {
+			return fmt.Errorf("failed to load alert rules: %w", err)
+		}
+		if len(rules) == 0 {
+			return fmt.Errorf("no alert rules configured, add them under 'alerts' in the config file")
+		}
+		for _, rule := range rules {
+			if err := config.ValidateAlertRule(rule); err != nil {
+				return fmt.Errorf("invalid alert rule '%s': %w", rule.Name, err)
+			}
+		}
+
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		tracker := &alertTracker{counts: make(map[string]int)}
+
+		once, _ := cmd.Flags().GetBool("once")
+		if once {
+			fired, err := runAlertRules(context.Background(), client, rules, tracker, true)
+			if err != nil {
+				return err
+			}
+			if fired > 0 {
+				return fmt.Errorf("%d alert(s) fired", fired)
+			}
+			theme.Printf("✅ No alerts\n")
+			return nil
+		}
+
+		interval, _ := cmd.Flags().GetInt("interval")
+		if interval < 1 {
+			interval = 60 // Default 60 seconds
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		ticker := time.NewTicker(time.Duration(interval) * time.Second)
+		defer ticker.Stop()
+
+		theme.Printf("🔄 Monitoring %d alert rule(s) every %ds (Ctrl+C to stop)...\n", len(rules), interval)
+		for {
+			if _, err := runAlertRules(ctx, client, rules, tracker, false); err != nil && ctx.Err() == nil {
+				theme.Printf("❌ Error: %v\n", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+// runAlertRules evaluates all rules once, runs the actions of the rules that fired and returns their count
+func runAlertRules(ctx context.Context, client *clientpkg.Client, rules []config.AlertRule, tracker *alertTracker, once bool) (int, error) {
+	fired, err := evaluateAlertRules(ctx, client, rules, tracker, once)
+	if err != nil {
+		return 0, err
+	}
+
+	fired = slices.DeleteFunc(fired, func(event events.Alert) bool { return event.Resolved })
+	for _, event := range fired {
+		theme.Printf("🚨 %s [%s] %s\n", event.Time.Format("2006-01-02 15:04:05"), event.Rule, event.Message)
+		for _, rule := range rules {
+			if rule.Name != event.Rule {
+				continue
+			}
+			for _, action := range rule.Actions {
+				if err := runAlertAction(ctx, action, event); err != nil {
+					theme.Printf("   ❌ Action failed: %v\n", err)
+				}
+			}
+		}
+	}
+	return len(fired), nil
+}
+
+// evaluateAlertRules checks every rule against the current state of applications, services and
+// servers, and returns the alerts that fired and were resolved
+func evaluateAlertRules(ctx context.Context, client *clientpkg.Client, rules []config.AlertRule, tracker *alertTracker, once bool) ([]events.Alert, error) {
+	need := make(map[string]bool)
+	for _, rule := range rules {
+		need[rule.Condition] = true
+	}
+
+	var apps []applicationStatus
+	if need[config.ConditionAppStatus] || need[config.ConditionDeploymentFailed] {
+		var err error
+		apps, err = collectApplicationStatus(ctx, client, 10)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var services []serviceState
+	if need[config.ConditionServiceStatus] {
+		list, err := client.Services().List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list services: %w", err)
+		}
+		for _, service := range list {
+			if service.Uuid == nil {
+				continue
+			}
+			status := "unknown"
+			if components, err := client.Services().Components(ctx, *service.Uuid); err == nil {
+				status = serviceComponentsStatus(components)
+			}
+			services = append(services, serviceState{Name: stringOrDash(service.Name), UUID: *service.Uuid, Status: status})
+		}
+	}
+
+	var servers []serverReachability
+	if need[config.ConditionServerUnreachable] {
+		list, err := client.Servers().List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list servers: %w", err)
+		}
+		for _, server := range list {
+			notReachable := server.Settings != nil && server.Settings.IsReachable != nil && !*server.Settings.IsReachable
+			servers = append(servers, serverReachability{
+				Name:        stringOrDash(server.Name),
+				UUID:        stringOrDash(server.Uuid),
+				Unreachable: notReachable || (server.UnreachableCount != nil && *server.UnreachableCount > 0),
+			})
+		}
+	}
+
+	// Statuses that could not be read neither fire nor resolve alerts
+	known := func(status string) bool { return status != "" && status != "-" && status != "unknown" }
+
+	var alerts []events.Alert
+	now := time.Now()
+	for _, rule := range rules {
+		threshold := rule.For
+		if threshold < 1 || once {
+			threshold = 1
+		}
+
+		check := func(name, uuid string, active bool, message, resolvedMessage string) {
+			fired, resolved := tracker.observe(rule.Name, uuid, active, threshold)
+			switch {
+			case fired:
+				alerts = append(alerts, events.NewAlert(rule.Name, rule.Condition, name, uuid, message, now))
+			case resolved:
+				alert := events.NewAlert(rule.Name, rule.Condition, name, uuid, resolvedMessage, now)
+				alert.Resolved = true
+				alerts = append(alerts, alert)
+			}
+		}
+
+		expected := rule.Status
+		if expected == "" {
+			expected = "running"
+		}
+		switch rule.Condition {
+		case config.ConditionAppStatus:
+			for _, app := range apps {
+				if matchesResource(rule.Resource, app.Name, app.UUID) && known(app.Status) {
+					check(app.Name, app.UUID, !strings.HasPrefix(app.Status, expected),
+						fmt.Sprintf("application %s is %s (expected %s)", app.Name, app.Status, expected),
+						fmt.Sprintf("application %s is %s again", app.Name, app.Status))
+				}
+			}
+		case config.ConditionServiceStatus:
+			for _, service := range services {
+				if matchesResource(rule.Resource, service.Name, service.UUID) && known(service.Status) {
+					check(service.Name, service.UUID, !strings.HasPrefix(service.Status, expected),
+						fmt.Sprintf("service %s is %s (expected %s)", service.Name, service.Status, expected),
+						fmt.Sprintf("service %s is %s again", service.Name, service.Status))
+				}
+			}
+		case config.ConditionDeploymentFailed:
+			for _, app := range apps {
+				if matchesResource(rule.Resource, app.Name, app.UUID) && known(app.LastDeployment) {
+					check(app.Name, app.UUID, clientpkg.DeploymentFailed(app.LastDeployment),
+						fmt.Sprintf("latest deployment of application %s is %s", app.Name, app.LastDeployment),
+						fmt.Sprintf("latest deployment of application %s is %s", app.Name, app.LastDeployment))
+				}
+			}
+		case config.ConditionServerUnreachable:
+			for _, server := range servers {
+				if matchesResource(rule.Resource, server.Name, server.UUID) {
+					check(server.Name, server.UUID, server.Unreachable,
+						fmt.Sprintf("server %s is unreachable", server.Name),
+						fmt.Sprintf("server %s is reachable again", server.Name))
+				}
+			}
+		}
+	}
+	return alerts, nil
+}
+
+// serviceState is the status of a single service, summed up from its containers
+type serviceState struct {
+	Name   string
+	UUID   string
+	Status string
+}
+
+// serviceComponentsStatus sums up the status of the containers of a service: running when all of
+// them are, otherwise the status of the first one that is not
+func serviceComponentsStatus(components []clientpkg.ServiceComponent) string {
+	if len(components) == 0 {
+		return "unknown"
+	}
+	for _, component := range components {
+		if !strings.HasPrefix(component.Status, "running") {
+			return fmt.Sprintf("%s (%s)", stringOrDash(&component.Status), component.Name)
+		}
+	}
+	return "running"
+}
+
+// serverReachability is the reachability of a single server as reported by Coolify
+type serverReachability struct {
+	Name        string
+	UUID        string
+	Unreachable bool
+}
+
+// matchesResource reports whether a resource matches the UUID or name filter of a rule
+func matchesResource(filter, name, uuid string) bool {
+	return filter == "" || filter == uuid || strings.EqualFold(filter, name)
+}
+
+// runAlertAction runs a single alert action for a fired alert
+func runAlertAction(ctx context.Context, action config.AlertAction, event events.Alert) error {
+	switch {
+	case action.Exec != "":
+		return runAlertHook(ctx, action.Exec, event)
+	case action.Webhook != "":
+		return postAlertWebhook(ctx, action.Webhook, event)
+	case action.Notify:
+		return showDesktopNotification(ctx, "coolifyme: "+event.Rule, event.Message)
+	}
+	return nil
+}
+
+// runAlertHook runs a shell command with the alert details in COOLIFYME_ALERT_* environment variables
+func runAlertHook(ctx context.Context, command string, event events.Alert) error {
+	return runShellHook(ctx, command, []string{
+		"COOLIFYME_ALERT_RULE=" + event.Rule,
+		"COOLIFYME_ALERT_CONDITION=" + event.Condition,
+		"COOLIFYME_ALERT_RESOURCE=" + event.Resource,
+		"COOLIFYME_ALERT_UUID=" + event.UUID,
+		"COOLIFYME_ALERT_MESSAGE=" + event.Message,
+	})
+}
+
+// postAlertWebhook sends the alert as JSON to a webhook URL
+func postAlertWebhook(ctx context.Context, webhookURL string, event events.Alert) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// showDesktopNotification shows a desktop notification, printing the alert when none is available
+func showDesktopNotification(ctx context.Context, title, message string) error {
+	var notifier *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		notifier = exec.CommandContext(ctx, "osascript", "-e", fmt.Sprintf("display notification %q with title %q", message, title)) // #nosec G204 -- fixed program
+	case "linux", "freebsd", "openbsd":
+		notifier = exec.CommandContext(ctx, "notify-send", title, message) // #nosec G204 -- fixed program
+	}
+
+	if notifier == nil || notifier.Run() != nil {
+		theme.Printf("🔔 %s: %s\n", title, message)
+	}
+	return nil
+}
+
+func init() {
+	monitorCmd.AddCommand(monitorRunCmd)
+
+	// Run command flags
+	monitorRunCmd.Flags().IntP("interval", "i", 60, "Evaluation interval in seconds")
+	monitorRunCmd.Flags().Bool("once", false, "Evaluate the rules once and exit")
+}
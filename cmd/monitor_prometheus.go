@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/metrics"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// prometheusPollConcurrency limits the latest deployment requests made per poll
+const prometheusPollConcurrency = 5
+
+// prometheusExporter polls Coolify and keeps the exported metrics up to date
+type prometheusExporter struct {
+	registry    *metrics.Registry
+	up          *metrics.Gauge
+	lastPoll    *metrics.Gauge
+	pollErrors  *metrics.Counter
+	appStatus   *metrics.Gauge
+	appRunning  *metrics.Gauge
+	deployments *metrics.Counter
+	reachable   *metrics.Gauge
+	latency     *metrics.Histogram
+
+	// seen holds the finished deployments already counted; seeded is false until the first poll,
+	// whose deployments are recorded without counting them
+	seen   map[string]bool
+	seeded bool
+}
+
+// newPrometheusExporter registers the coolifyme metrics
+func newPrometheusExporter() *prometheusExporter {
+	r := metrics.NewRegistry()
+	return &prometheusExporter{
+		registry:    r,
+		up:          r.Gauge("coolifyme_up", "Whether the last poll of the Coolify API succeeded"),
+		lastPoll:    r.Gauge("coolifyme_last_poll_timestamp_seconds", "Unix time of the last successful poll"),
+		pollErrors:  r.Counter("coolifyme_poll_errors_total", "Polls of the Coolify API that failed"),
+		appStatus:   r.Gauge("coolifyme_application_status", "Current status of an application, always 1", "uuid", "name", "status"),
+		appRunning:  r.Gauge("coolifyme_application_running", "Whether an application is running", "uuid", "name"),
+		deployments: r.Counter("coolifyme_deployments_total", "Deployments finished since the exporter started, by result", "uuid", "name", "result"),
+		reachable:   r.Gauge("coolifyme_server_reachable", "Whether Coolify can reach a server", "uuid", "name"),
+		latency:     r.Histogram("coolifyme_api_request_duration_seconds", "Latency of Coolify API requests", metrics.DefaultBuckets, "method", "resource", "code"),
+		seen:        make(map[string]bool),
+	}
+}
+
+// runPrometheusExporter serves /metrics on address and polls Coolify every interval until Ctrl+C
+func runPrometheusExporter(cmd *cobra.Command, address string) error {
+	exporter := newPrometheusExporter()
+	client, err := createClient(clientpkg.WithMiddleware(exporter.middleware))
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	interval, _ := cmd.Flags().GetInt("interval")
+	if interval < 1 {
+		interval = 30 // Default 30 seconds
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exporter.registry.Handler())
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprintln(w, "coolifyme exporter: metrics are served at /metrics")
+	})
+	server := &http.Server{Addr: address, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	theme.Printf("📈 Serving Prometheus metrics on http://%s/metrics (poll every %ds, Ctrl+C to stop)\n", displayAddress(address), interval)
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+	for {
+		if err := exporter.poll(ctx, client); err != nil && ctx.Err() == nil {
+			theme.Printf("❌ Error: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-serveErr:
+			if errors.Is(err, http.ErrServerClosed) {
+				return nil
+			}
+			return fmt.Errorf("metrics server failed: %w", err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll refreshes the application, deployment and server metrics
+func (e *prometheusExporter) poll(ctx context.Context, client *clientpkg.Client) error {
+	err := e.collect(ctx, client)
+	if err != nil {
+		e.up.Set(0)
+		e.pollErrors.Inc()
+		return err
+	}
+	e.up.Set(1)
+	e.lastPoll.Set(float64(time.Now().Unix()))
+	return nil
+}
+
+func (e *prometheusExporter) collect(ctx context.Context, client *clientpkg.Client) error {
+	apps, err := client.Applications().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list applications: %w", err)
+	}
+	servers, err := client.Servers().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list servers: %w", err)
+	}
+
+	// Reset the gauges so deleted resources disappear from the output
+	e.appStatus.Reset()
+	e.appRunning.Reset()
+	for _, app := range apps {
+		uuid, name, status := stringOrDash(app.Uuid), stringOrDash(app.Name), stringOrDash(app.Status)
+		e.appStatus.Set(1, uuid, name, status)
+		running := 0.0
+		if strings.HasPrefix(status, "running") {
+			running = 1
+		}
+		e.appRunning.Set(running, uuid, name)
+	}
+
+	e.reachable.Reset()
+	for _, server := range servers {
+		reachable := 1.0
+		if server.Settings != nil && server.Settings.IsReachable != nil && !*server.Settings.IsReachable {
+			reachable = 0
+		}
+		e.reachable.Set(reachable, stringOrDash(server.Uuid), stringOrDash(server.Name))
+	}
+
+	e.countDeployments(ctx, client, apps)
+	return nil
+}
+
+// countDeployments increments the deployment counters for latest deployments that finished since
+// the previous poll. Deployments that finished between two polls and were superseded by a newer
+// one before the second poll are not seen.
+func (e *prometheusExporter) countDeployments(ctx context.Context, client *clientpkg.Client, apps []coolify.Application) {
+	// Create semaphore for concurrency control
+	sem := make(chan struct{}, prometheusPollConcurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	for _, app := range apps {
+		if app.Uuid == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(uuid, name string) {
+			defer wg.Done()
+			sem <- struct{}{}        // Acquire semaphore
+			defer func() { <-sem }() // Release semaphore
+
+			deployment, err := client.Deployments().Latest(ctx, uuid)
+			if err != nil || deployment == nil || deployment.DeploymentUuid == nil || deployment.Status == nil {
+				return
+			}
+
+			result := ""
+			switch {
+			case clientpkg.DeploymentSucceeded(*deployment.Status):
+				result = "success"
+			case clientpkg.DeploymentFailed(*deployment.Status):
+				result = "failure"
+			default:
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if e.seen[*deployment.DeploymentUuid] {
+				return
+			}
+			e.seen[*deployment.DeploymentUuid] = true
+			if e.seeded {
+				e.deployments.Inc(uuid, name, result)
+			} else {
+				// Expose the series with 0 so rate() works from the first increment
+				e.deployments.Add(0, uuid, name, result)
+			}
+		}(*app.Uuid, stringOrDash(app.Name))
+	}
+	wg.Wait()
+	e.seeded = true
+}
+
+// middleware records the latency of every API request
+func (e *prometheusExporter) middleware(next http.RoundTripper) http.RoundTripper {
+	return &latencyTransport{next: next, histogram: e.latency}
+}
+
+// latencyTransport observes request durations by method, API resource and status code
+type latencyTransport struct {
+	next      http.RoundTripper
+	histogram *metrics.Histogram
+}
+
+func (t *latencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	started := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	code := "error"
+	if err == nil {
+		code = fmt.Sprintf("%d", resp.StatusCode)
+	}
+	t.histogram.Observe(time.Since(started).Seconds(), req.Method, apiResource(req.URL.Path), code)
+	return resp, err
+}
+
+// apiResource returns the first path segment after the API version, e.g. "applications" for
+// /api/v1/applications/<uuid>/envs, so UUIDs do not end up in metric labels
+func apiResource(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if segment == "v1" && i+1 < len(segments) {
+			return segments[i+1]
+		}
+	}
+	if segments[0] == "" {
+		return "-"
+	}
+	return segments[0]
+}
+
+// displayAddress turns a listen address like :9123 into one that can be opened in a browser
+func displayAddress(address string) string {
+	if strings.HasPrefix(address, ":") {
+		return "localhost" + address
+	}
+	return address
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// movableResource reads and moves one kind of resource
+type movableResource struct {
+	kind string
+	// article is "a" or "an" for the kind
+	article string
+	// current returns the name and environment ID of a resource
+	current func(ctx context.Context, client *clientpkg.Client, uuid string) (string, int, error)
+	move    func(ctx context.Context, client *clientpkg.Client, uuid string, target clientpkg.MoveTarget) error
+}
+
+var (
+	movableApplication = movableResource{
+		kind:    "application",
+		article: "an",
+		current: func(ctx context.Context, client *clientpkg.Client, uuid string) (string, int, error) {
+			app, err := client.Applications().Get(ctx, uuid)
+			if err != nil {
+				return "", 0, fmt.Errorf("failed to get application: %w", err)
+			}
+			return stringOrDash(app.Name), intOrZero(app.EnvironmentId), nil
+		},
+		move: func(ctx context.Context, client *clientpkg.Client, uuid string, target clientpkg.MoveTarget) error {
+			return client.Applications().Move(ctx, uuid, target)
+		},
+	}
+	movableService = movableResource{
+		kind:    "service",
+		article: "a",
+		current: func(ctx context.Context, client *clientpkg.Client, uuid string) (string, int, error) {
+			service, err := client.Services().Get(ctx, uuid)
+			if err != nil {
+				return "", 0, fmt.Errorf("failed to get service: %w", err)
+			}
+			return stringOrDash(service.Name), intOrZero(service.EnvironmentId), nil
+		},
+		move: func(ctx context.Context, client *clientpkg.Client, uuid string, target clientpkg.MoveTarget) error {
+			return client.Services().Move(ctx, uuid, target)
+		},
+	}
+	movableDatabase = movableResource{
+		kind:    "database",
+		article: "a",
+		current: func(ctx context.Context, client *clientpkg.Client, uuid string) (string, int, error) {
+			raw, err := client.Databases().Get(ctx, uuid)
+			if err != nil {
+				return "", 0, fmt.Errorf("failed to get database: %w", err)
+			}
+			db, err := clientpkg.ParseDatabase(raw)
+			if err != nil {
+				return "", 0, err
+			}
+			return db.Name, db.EnvironmentID, nil
+		},
+		move: func(ctx context.Context, client *clientpkg.Client, uuid string, target clientpkg.MoveTarget) error {
+			return client.Databases().Move(ctx, uuid, target)
+		},
+	}
+)
+
+// newMoveCmd creates the move command for a kind of resource
+func newMoveCmd(resource movableResource) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "move <uuid>",
+		Short: fmt.Sprintf("Move %s %s to another project or environment", resource.article, resource.kind),
+		Long: fmt.Sprintf(`Move %[3]s %[1]s to another project and/or environment. The target project (name or UUID)
+and environment (name) are checked before anything is changed, and the %[1]s is read back
+afterwards to confirm Coolify moved it, since Coolify versions that do not support moving a
+resource through the API accept the request without changing anything. Use 'Move resource'
+in the Coolify UI in that case.
+
+The %[1]s keeps running; its domains, environment variables and volumes are not changed.
+
+Examples:
+  coolifyme %[2]s move <uuid> --project shop --environment staging
+  coolifyme %[2]s move <uuid> --project shop --environment staging --dry-run`,
+			resource.kind, resource.kind+"s", resource.article),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+
+			ctx := context.Background()
+			uuid := args[0]
+			projectRef, _ := cmd.Flags().GetString("project")
+			environmentRef, _ := cmd.Flags().GetString("environment")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+			name, environmentID, err := resource.current(ctx, client, uuid)
+			if err != nil {
+				return err
+			}
+
+			project, err := client.Projects().Resolve(ctx, projectRef)
+			if err != nil {
+				return err
+			}
+			environment, err := client.Projects().GetEnvironment(ctx, *project.Uuid, environmentRef)
+			if err != nil {
+				return fmt.Errorf("environment '%s' not found in project %s: %w", environmentRef, stringOrDash(project.Name), err)
+			}
+			if environment.Id == nil || environment.Name == nil {
+				return fmt.Errorf("environment '%s' of project %s has no ID", environmentRef, stringOrDash(project.Name))
+			}
+
+			target := clientpkg.MoveTarget{
+				ProjectUUID:     *project.Uuid,
+				EnvironmentName: *environment.Name,
+				EnvironmentID:   *environment.Id,
+			}
+			destination := stringOrDash(project.Name) + "/" + target.EnvironmentName
+			if environmentID == target.EnvironmentID {
+				theme.Printf("✅ %s %s is already in %s\n", resource.kind, name, destination)
+				return nil
+			}
+
+			if dryRun {
+				theme.Printf("🔍 Dry run: would move %s %s to %s\n", resource.kind, name, destination)
+				return nil
+			}
+
+			if err := resource.move(ctx, client, uuid, target); err != nil {
+				if errors.Is(err, clientpkg.ErrMoveNotApplied) {
+					return fmt.Errorf("%w; use 'Move resource' in the %s settings of the Coolify UI", err, resource.kind)
+				}
+				return err
+			}
+
+			theme.Printf("✅ Moved %s %s to %s\n", resource.kind, name, destination)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("project", "", "Target project name or UUID")
+	cmd.Flags().String("environment", "", "Target environment name")
+	cmd.Flags().Bool("dry-run", false, "Check the target and show the move without making changes")
+	_ = cmd.MarkFlagRequired("project")
+	_ = cmd.MarkFlagRequired("environment")
+	_ = cmd.Flags().SetAnnotation("project", noLocalDefaultAnnotation, []string{"true"})
+	_ = cmd.Flags().SetAnnotation("environment", noLocalDefaultAnnotation, []string{"true"})
+	return cmd
+}
+
+// intOrZero returns the value of an optional integer, or 0 when it is not set
+func intOrZero(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+func init() {
+	applicationsCmd.AddCommand(newMoveCmd(movableApplication))
+	servicesCmd.AddCommand(newMoveCmd(movableService))
+	databasesCmd.AddCommand(newMoveCmd(movableDatabase))
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// namespacePrefetchConcurrency limits the project and server requests made for -o wide
+const namespacePrefetchConcurrency = 5
+
+// environmentNamespace is the project and environment an environment ID belongs to
+type environmentNamespace struct {
+	Project     string
+	Environment string
+}
+
+// namespaces maps resources to their project, environment and server for the wide list
+// output. It is filled once per command by prefetching projects and servers concurrently.
+type namespaces struct {
+	environments map[int]environmentNamespace
+	// serverNames maps server IDs to names; serverOf maps resource UUIDs to server names
+	serverNames map[int]string
+	serverOf    map[string]string
+}
+
+// wideOutput reports whether a list command should show the project, environment and server columns
+func wideOutput(cmd *cobra.Command) bool {
+	format, _ := cmd.Flags().GetString("output")
+	return format == string(FormatWide)
+}
+
+// loadNamespaces prefetches the environments of all projects and the resources of all servers.
+// Resources of servers that cannot be read are shown without a server rather than failing the list.
+func loadNamespaces(ctx context.Context, client clientpkg.API) (*namespaces, error) {
+	var projects []coolify.Project
+	var servers []coolify.Server
+	var projectsErr, serversErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		projects, projectsErr = client.Projects().List(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		servers, serversErr = client.Servers().List(ctx)
+	}()
+	wg.Wait()
+	if projectsErr != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", projectsErr)
+	}
+	if serversErr != nil {
+		return nil, fmt.Errorf("failed to list servers: %w", serversErr)
+	}
+
+	ns := &namespaces{
+		environments: make(map[int]environmentNamespace),
+		serverNames:  make(map[int]string),
+		serverOf:     make(map[string]string),
+	}
+
+	// Create semaphore for concurrency control
+	sem := make(chan struct{}, namespacePrefetchConcurrency)
+	var mu sync.Mutex
+	var firstErr error
+
+	for _, project := range projects {
+		if project.Uuid == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(project coolify.Project) {
+			defer wg.Done()
+			sem <- struct{}{}        // Acquire semaphore
+			defer func() { <-sem }() // Release semaphore
+
+			// The project list does not include environments, so fetch each project
+			full, err := client.Projects().Get(ctx, *project.Uuid)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to get project %s: %w", stringOrDash(project.Name), err)
+				}
+				return
+			}
+			if full.Environments == nil {
+				return
+			}
+			for _, env := range *full.Environments {
+				if env.Id != nil {
+					ns.environments[*env.Id] = environmentNamespace{Project: stringOrDash(project.Name), Environment: stringOrDash(env.Name)}
+				}
+			}
+		}(project)
+	}
+
+	for _, server := range servers {
+		if server.Id != nil {
+			ns.serverNames[*server.Id] = stringOrDash(server.Name)
+		}
+		if server.Uuid == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(serverUUID, serverName string) {
+			defer wg.Done()
+			sem <- struct{}{}        // Acquire semaphore
+			defer func() { <-sem }() // Release semaphore
+
+			resources, err := client.Servers().GetResources(ctx, serverUUID)
+			if err != nil {
+				return
+			}
+			var items []struct {
+				UUID string `json:"uuid"`
+			}
+			if err := json.Unmarshal([]byte(resources), &items); err != nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, item := range items {
+				ns.serverOf[item.UUID] = serverName
+			}
+		}(*server.Uuid, stringOrDash(server.Name))
+	}
+
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return ns, nil
+}
+
+// environment returns the project and environment names of an environment ID, or dashes
+func (ns *namespaces) environment(environmentID *int) (string, string) {
+	if environmentID != nil {
+		if env, ok := ns.environments[*environmentID]; ok {
+			return env.Project, env.Environment
+		}
+	}
+	return "-", "-"
+}
+
+// server returns the name of the server a resource runs on, or a dash
+func (ns *namespaces) server(resourceUUID *string, serverID *int) string {
+	if serverID != nil {
+		if name, ok := ns.serverNames[*serverID]; ok {
+			return name
+		}
+	}
+	if resourceUUID != nil {
+		if name, ok := ns.serverOf[*resourceUUID]; ok {
+			return name
+		}
+	}
+	return "-"
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hongkongkiwi/coolifyme/internal/output"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// outputFile receives standard output when --output-file is set
+	outputFile *os.File
+	// originalStdout is restored when the output file is closed
+	originalStdout *os.File
+)
+
+// openOutputFile redirects standard output to the file given with --output-file, so tables,
+// JSON and status messages are written there while warnings and logs stay on standard error.
+// Colors are turned off for the file unless --color=always or CLICOLOR_FORCE ask for them.
+func openOutputFile(cmd *cobra.Command) error {
+	path, _ := cmd.Flags().GetString("output-file")
+	appendMode, _ := cmd.Flags().GetBool("append")
+	if path == "" {
+		if appendMode {
+			return fmt.Errorf("--append requires --output-file")
+		}
+		return nil
+	}
+
+	file, err := output.OpenFile(expandHomePath(path), appendMode)
+	if err != nil {
+		return err
+	}
+
+	outputFile, originalStdout = file, os.Stdout
+	os.Stdout = file
+	theme.SetWriter(file)
+	theme.SetColor(colorMode.Enabled(false))
+	return nil
+}
+
+// closeOutputFile restores standard output and closes the output file, if any
+func closeOutputFile() error {
+	if outputFile == nil {
+		return nil
+	}
+
+	os.Stdout = originalStdout
+	theme.SetWriter(originalStdout)
+	file := outputFile
+	outputFile = nil
+	return output.CloseFile(file)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/output"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+// addPageSizeFlag adds --page-size to a command printing tables
+func addPageSizeFlag(cmd *cobra.Command) {
+	cmd.Flags().Int("page-size", 0, "Print tables in pages of this many rows, pausing after each page on a terminal (0 prints one table)")
+}
+
+// pageSize returns the --page-size of a command
+func pageSize(cmd *cobra.Command) int {
+	size, _ := cmd.Flags().GetInt("page-size")
+	return max(size, 0)
+}
+
+// pagedTable returns the table options for a page size. On a terminal every page waits for
+// Enter before the next one is printed; otherwise the pages follow each other, each aligned and
+// flushed on its own, so large tables start printing before all rows are rendered.
+func pagedTable(noHeaders bool, size int) output.TableOptions {
+	options := output.TableOptions{NoHeaders: noHeaders, PageSize: size}
+	if size > 0 && theme.IsTerminal(os.Stdout) && theme.IsTerminal(os.Stdin) {
+		options.Pager = promptNextPage
+	}
+	return options
+}
+
+// promptNextPage asks whether to print the next page; q or the end of input stops the table
+func promptNextPage() bool {
+	fmt.Fprint(os.Stderr, "-- More: Enter for the next page, q to quit -- ")
+	answer, err := confirm.Input().ReadString('\n')
+	if err != nil {
+		fmt.Fprintln(os.Stderr)
+		return false
+	}
+	return !strings.EqualFold(strings.TrimSpace(answer), "q")
+}
+
+// ignorePagingStopped treats quitting the pager as success
+func ignorePagingStopped(err error) error {
+	if errors.Is(err, output.ErrPagingStopped) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// portForwardTarget is the container a port-forward connects to
+type portForwardTarget struct {
+	Kind       string
+	Name       string
+	ServerUUID string
+	// Container is the name filter of the container on the server
+	Container  string
+	RemotePort int
+	Database   *clientpkg.DatabaseInfo
+}
+
+// portForwardCmd represents the port-forward command
+var portForwardCmd = &cobra.Command{
+	Use:   "port-forward <database-or-service-uuid> [local-port]",
+	Short: "Forward a local port to a private database or service",
+	Long: `Open an SSH tunnel to the server hosting a database or service and forward a local port to
+the container's internal port, so local tools like psql or redis-cli can connect to databases
+that are not public.
+
+The tunnel uses the server's SSH address, user and port from Coolify with the keys of your SSH
+agent and configuration, or the local key given with --identity. --use-server-key instead
+fetches the private key attached to the server through the API. Host keys are checked against
+known_hosts. The local port defaults to the container port. The tunnel stays open until Ctrl+C.
+
+For services, give the port with --remote-port and, when the service runs several
+containers, pick one with --container (e.g. the service name in the compose file).
+
+Examples:
+  coolifyme port-forward <postgres-uuid> 15432
+  coolifyme port-forward <redis-uuid>
+  coolifyme port-forward <service-uuid> 8080 --container minio --remote-port 9001
+  coolifyme port-forward <db-uuid> --identity ~/.ssh/id_ed25519`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		target, err := resolvePortForwardTarget(ctx, cmd, client, args[0])
+		if err != nil {
+			return err
+		}
+
+		localPort := target.RemotePort
+		if len(args) > 1 {
+			if localPort, err = strconv.Atoi(args[1]); err != nil || localPort < 1 || localPort > 65535 {
+				return fmt.Errorf("invalid local port '%s'", args[1])
+			}
+		}
+		address, _ := cmd.Flags().GetString("address")
+
+		ssh, err := openServerSSH(ctx, client, target.ServerUUID, getSSHKeyOptions(cmd),
+			"-o", "ExitOnForwardFailure=yes",
+			"-o", "ServerAliveInterval=30",
+		)
+		if err != nil {
+			return err
+		}
+		defer ssh.Close()
+
+		// Container names only resolve inside Docker networks, so forward to the container IP
+		containerIP, err := containerIPViaSSH(ctx, ssh, target.Container)
+		if err != nil {
+			return err
+		}
+
+		theme.Printf("🔌 Forwarding %s:%d -> %s %s port %d via %s\n",
+			address, localPort, target.Kind, target.Name, target.RemotePort, ssh.Target.Host)
+		if target.Database != nil {
+			if dsn, err := localDatabaseDSN(target.Database, address, localPort); err == nil {
+				showPassword, _ := cmd.Flags().GetBool("show-password")
+				if !showPassword {
+					dsn = maskDSNPassword(dsn)
+				}
+				fmt.Printf("   Connect with: %s\n", dsn)
+			}
+		}
+		fmt.Println("   Press Ctrl+C to stop")
+
+		forward := fmt.Sprintf("%s:%d:%s:%d", address, localPort, containerIP, target.RemotePort)
+		tunnel := exec.CommandContext(ctx, "ssh", append(ssh.Args, "-N", "-L", forward, ssh.Host)...) // #nosec G204 -- arguments are passed to ssh, not a local shell
+		tunnel.Stdout = os.Stdout
+		tunnel.Stderr = os.Stderr
+		if err := tunnel.Run(); err != nil {
+			if ctx.Err() != nil {
+				theme.Println("\n✅ Port forward closed")
+				return nil
+			}
+			return fmt.Errorf("ssh tunnel failed: %w", err)
+		}
+		return nil
+	},
+}
+
+// resolvePortForwardTarget finds the database or service with the given UUID and its server
+func resolvePortForwardTarget(ctx context.Context, cmd *cobra.Command, client *clientpkg.Client, uuid string) (*portForwardTarget, error) {
+	remotePort, _ := cmd.Flags().GetInt("remote-port")
+	container, _ := cmd.Flags().GetString("container")
+
+	if raw, err := client.Databases().Get(ctx, uuid); err == nil {
+		db, err := clientpkg.ParseDatabase(raw)
+		if err != nil {
+			return nil, err
+		}
+		if remotePort == 0 {
+			remotePort = db.InternalPort()
+		}
+		if remotePort == 0 {
+			return nil, fmt.Errorf("unknown port for database type '%s', set it with --remote-port", db.Type)
+		}
+		if db.ServerUUID == "" {
+			return nil, fmt.Errorf("cannot determine the server of database %s", db.Name)
+		}
+		return &portForwardTarget{
+			Kind:       "database",
+			Name:       db.Name,
+			ServerUUID: db.ServerUUID,
+			Container:  db.UUID,
+			RemotePort: remotePort,
+			Database:   db,
+		}, nil
+	}
+
+	service, err := client.Services().Get(ctx, uuid)
+	if err != nil {
+		return nil, fmt.Errorf("no database or service found with UUID %s", uuid)
+	}
+	if remotePort == 0 {
+		return nil, fmt.Errorf("services can expose several ports, set the container port with --remote-port")
+	}
+	serverUUID, err := serviceServerUUID(ctx, client, service)
+	if err != nil {
+		return nil, err
+	}
+	target := &portForwardTarget{Kind: "service", Name: stringOrDash(service.Name), ServerUUID: serverUUID, RemotePort: remotePort}
+
+	// Coolify names service containers <name>-<service uuid>
+	target.Container = uuid
+	if container != "" {
+		target.Container = container + "-" + uuid
+	}
+	return target, nil
+}
+
+// writeServerPrivateKey stores the private key of a server in a temporary file for ssh
+func writeServerPrivateKey(ctx context.Context, client *clientpkg.Client, ssh *clientpkg.SSHTarget) (string, func(), error) {
+	if ssh.PrivateKeyUUID == "" {
+		return "", nil, fmt.Errorf("cannot determine the private key of the server, use --identity instead of --use-server-key")
+	}
+	key, err := client.PrivateKeys().Get(ctx, ssh.PrivateKeyUUID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get private key: %w", err)
+	}
+	if key.PrivateKey == nil || *key.PrivateKey == "" {
+		return "", nil, fmt.Errorf("the API did not return the private key of the server, use --identity instead of --use-server-key")
+	}
+
+	dir, err := os.MkdirTemp("", "coolifyme-tunnel-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	cleanup := func() { _ = os.RemoveAll(dir) }
+
+	path := filepath.Join(dir, "id")
+	content := strings.TrimRight(*key.PrivateKey, "\n") + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write private key: %w", err)
+	}
+	return path, cleanup, nil
+}
+
+// containerIPViaSSH returns the IP address of the first container matching a name filter
+func containerIPViaSSH(ctx context.Context, ssh *serverSSH, container string) (string, error) {
+	remote := fmt.Sprintf("docker inspect -f '{{range .NetworkSettings.Networks}}{{.IPAddress}} {{end}}' $(docker ps -q --filter name=%s | head -n 1)", shellQuote(container))
+	output, err := ssh.Output(ctx, remote)
+	if err != nil {
+		return "", fmt.Errorf("failed to find container %s: %w", container, err)
+	}
+
+	for _, field := range strings.Fields(string(output)) {
+		if net.ParseIP(field) != nil {
+			return field, nil
+		}
+	}
+	return "", fmt.Errorf("container %s is not running or has no IP address", container)
+}
+
+// localDatabaseDSN returns the connection string of a database reached through a local forward
+func localDatabaseDSN(db *clientpkg.DatabaseInfo, address string, port int) (string, error) {
+	local := *db
+	local.IsPublic = true
+	local.PublicPort = port
+	local.ServerIP = address
+	return local.ConnectionString(true, address)
+}
+
+func init() {
+	rootCmd.AddCommand(portForwardCmd)
+
+	// Flags for port-forward command
+	portForwardCmd.Flags().Int("remote-port", 0, "Container port to forward (default: the database port)")
+	portForwardCmd.Flags().String("container", "", "Service container to forward to, for services with several containers")
+	portForwardCmd.Flags().String("address", "127.0.0.1", "Local address to listen on")
+	addSSHKeyFlags(portForwardCmd)
+	portForwardCmd.Flags().Bool("show-password", false, "Show the password in the printed connection string")
+}
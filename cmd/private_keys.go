@@ -8,6 +8,9 @@ import (
 	"text/tabwriter"
 
 	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
 	"github.com/spf13/cobra"
 )
 
@@ -153,26 +156,39 @@ var privateKeysCreateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		name, _ := cmd.Flags().GetString("name")
-		description, _ := cmd.Flags().GetString("description")
-		privateKey, _ := cmd.Flags().GetString("private-key")
-
-		if privateKey == "" {
-			return fmt.Errorf("private key content is required")
+		req := coolify.CreatePrivateKeyJSONRequestBody{}
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
 		}
+		if !fromFile {
+			name, _ := cmd.Flags().GetString("name")
+			description, _ := cmd.Flags().GetString("description")
+			privateKey, _ := cmd.Flags().GetString("private-key")
+
+			if privateKey == "" {
+				return fmt.Errorf("private key content is required")
+			}
 
-		req := coolify.CreatePrivateKeyJSONRequestBody{
-			Name:        &name,
-			Description: &description,
-			PrivateKey:  privateKey,
+			req = coolify.CreatePrivateKeyJSONRequestBody{
+				Name:        &name,
+				Description: &description,
+				PrivateKey:  privateKey,
+			}
 		}
 
-		result, err := client.PrivateKeys().Create(context.Background(), req)
+		ctx, guard := beginCreate(context.Background(), client, "private key", req)
+		result, err := client.PrivateKeys().Create(ctx, req)
+		guard.finish(err)
 		if err != nil {
 			return fmt.Errorf("failed to create private key: %w", err)
 		}
 
-		fmt.Printf("✅ Private key created successfully\n")
+		if printQuietUUID(result) {
+			return nil
+		}
+
+		theme.Statusf("✅ Private key created successfully\n")
 		fmt.Printf("   UUID: %s\n", result)
 
 		return nil
@@ -190,18 +206,25 @@ var privateKeysUpdateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		name, _ := cmd.Flags().GetString("name")
-		description, _ := cmd.Flags().GetString("description")
-		privateKey, _ := cmd.Flags().GetString("private-key")
-
-		if privateKey == "" {
-			return fmt.Errorf("private key content is required")
+		req := coolify.UpdatePrivateKeyJSONRequestBody{}
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
 		}
+		if !fromFile {
+			name, _ := cmd.Flags().GetString("name")
+			description, _ := cmd.Flags().GetString("description")
+			privateKey, _ := cmd.Flags().GetString("private-key")
 
-		req := coolify.UpdatePrivateKeyJSONRequestBody{
-			Name:        &name,
-			Description: &description,
-			PrivateKey:  privateKey,
+			if privateKey == "" {
+				return fmt.Errorf("private key content is required")
+			}
+
+			req = coolify.UpdatePrivateKeyJSONRequestBody{
+				Name:        &name,
+				Description: &description,
+				PrivateKey:  privateKey,
+			}
 		}
 
 		result, err := client.PrivateKeys().Update(context.Background(), req)
@@ -209,7 +232,7 @@ var privateKeysUpdateCmd = &cobra.Command{
 			return fmt.Errorf("failed to update private key: %w", err)
 		}
 
-		fmt.Printf("✅ Private key updated successfully\n")
+		theme.Printf("✅ Private key updated successfully\n")
 		fmt.Printf("   UUID: %s\n", result)
 
 		return nil
@@ -222,21 +245,36 @@ var privateKeysDeleteCmd = &cobra.Command{
 	Short: "Delete private key",
 	Long:  "Delete a private key by UUID",
 	Args:  cobra.ExactArgs(1),
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		client, err := createClient()
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
+		if err := checkTokenAbility(cmd, client, clientpkg.AbilityWrite); err != nil {
+			return err
+		}
 
 		ctx := context.Background()
 		keyUUID := args[0]
+		force := skipConfirmation(cmd)
+
+		target := confirm.Target{Kind: "private key", ID: keyUUID}
+		if !force {
+			if key, err := client.PrivateKeys().Get(ctx, keyUUID); err == nil && key.Name != nil {
+				target.Name = *key.Name
+			}
+		}
+		if !confirm.Delete(target, force) {
+			theme.Println("❌ Deletion cancelled")
+			return nil
+		}
 
 		err = client.PrivateKeys().Delete(ctx, keyUUID)
 		if err != nil {
 			return fmt.Errorf("failed to delete private key: %w", err)
 		}
 
-		fmt.Printf("✅ Private key %s deleted successfully\n", keyUUID)
+		theme.Printf("✅ Private key %s deleted successfully\n", keyUUID)
 		return nil
 	},
 }
@@ -255,15 +293,20 @@ func init() {
 	// Flags for get command
 	privateKeysGetCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
 
+	// Flags for delete command
+	addConfirmFlags(privateKeysDeleteCmd, "Delete without confirmation")
+
 	// Flags for create command
 	privateKeysCreateCmd.Flags().StringP("name", "n", "", "Name of the private key")
 	privateKeysCreateCmd.Flags().StringP("description", "d", "", "Description of the private key")
 	privateKeysCreateCmd.Flags().StringP("private-key", "k", "", "Private key content (required)")
 	_ = privateKeysCreateCmd.MarkFlagRequired("private-key")
+	addFromFileFlag(privateKeysCreateCmd)
 
 	// Flags for update command
 	privateKeysUpdateCmd.Flags().StringP("name", "n", "", "Name of the private key")
 	privateKeysUpdateCmd.Flags().StringP("description", "d", "", "Description of the private key")
 	privateKeysUpdateCmd.Flags().StringP("private-key", "k", "", "Private key content (required)")
 	_ = privateKeysUpdateCmd.MarkFlagRequired("private-key")
+	addFromFileFlag(privateKeysUpdateCmd)
 }
@@ -8,6 +8,9 @@ import (
 	"text/tabwriter"
 
 	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
 	"github.com/spf13/cobra"
 )
 
@@ -47,6 +50,10 @@ var projectsListCmd = &cobra.Command{
 			return nil
 		}
 
+		if handled, err := outputWithTemplate(cmd, projects); handled {
+			return err
+		}
+
 		if len(projects) == 0 {
 			fmt.Println("No projects found")
 			return nil
@@ -143,24 +150,46 @@ var projectsCreateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		name, _ := cmd.Flags().GetString("name")
-		description, _ := cmd.Flags().GetString("description")
+		req := coolify.CreateProjectJSONRequestBody{}
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
+		}
+		if !fromFile {
+			name, _ := cmd.Flags().GetString("name")
+			description, _ := cmd.Flags().GetString("description")
+
+			if name == "" {
+				return fmt.Errorf("project name is required")
+			}
 
-		if name == "" {
-			return fmt.Errorf("project name is required")
+			req = coolify.CreateProjectJSONRequestBody{
+				Name:        &name,
+				Description: &description,
+			}
 		}
 
-		req := coolify.CreateProjectJSONRequestBody{
-			Name:        &name,
-			Description: &description,
+		existing, err := existingResource(context.Background(), cmd, client, clientpkg.KindProject, req)
+		if err != nil {
+			return err
+		}
+		if existing != "" {
+			printExistingResource(clientpkg.KindProject, requestName(req), existing)
+			return nil
 		}
 
-		result, err := client.Projects().Create(context.Background(), req)
+		ctx, guard := beginCreate(context.Background(), client, "project", req)
+		result, err := client.Projects().Create(ctx, req)
+		guard.finish(err)
 		if err != nil {
 			return fmt.Errorf("failed to create project: %w", err)
 		}
 
-		fmt.Printf("✅ Project created successfully\n")
+		if printQuietUUID(result) {
+			return nil
+		}
+
+		theme.Statusf("✅ Project created successfully\n")
 		fmt.Printf("   UUID: %s\n", result)
 
 		return nil
@@ -180,15 +209,21 @@ var projectsUpdateCmd = &cobra.Command{
 		}
 
 		projectUUID := args[0]
-		name, _ := cmd.Flags().GetString("name")
-		description, _ := cmd.Flags().GetString("description")
-
 		req := coolify.UpdateProjectByUuidJSONRequestBody{}
-		if name != "" {
-			req.Name = &name
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
 		}
-		if description != "" {
-			req.Description = &description
+		if !fromFile {
+			name, _ := cmd.Flags().GetString("name")
+			description, _ := cmd.Flags().GetString("description")
+
+			if name != "" {
+				req.Name = &name
+			}
+			if description != "" {
+				req.Description = &description
+			}
 		}
 
 		result, err := client.Projects().Update(context.Background(), projectUUID, req)
@@ -196,7 +231,7 @@ var projectsUpdateCmd = &cobra.Command{
 			return fmt.Errorf("failed to update project: %w", err)
 		}
 
-		fmt.Printf("✅ Project updated successfully\n")
+		theme.Printf("✅ Project updated successfully\n")
 		if result.Uuid != nil {
 			fmt.Printf("   UUID: %s\n", *result.Uuid)
 		}
@@ -211,21 +246,45 @@ var projectsDeleteCmd = &cobra.Command{
 	Short: "Delete project",
 	Long:  "Delete a project by UUID",
 	Args:  cobra.ExactArgs(1),
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		client, err := createClient()
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
+		if err := checkTokenAbility(cmd, client, clientpkg.AbilityWrite); err != nil {
+			return err
+		}
 
 		ctx := context.Background()
 		projectUUID := args[0]
+		force := skipConfirmation(cmd)
+
+		target := confirm.Target{Kind: "project", ID: projectUUID}
+		if !force {
+			if project, err := client.Projects().Get(ctx, projectUUID); err == nil {
+				if project.Name != nil {
+					target.Name = *project.Name
+				}
+				if project.Environments != nil {
+					for _, env := range *project.Environments {
+						if env.Name != nil {
+							target.Dependents = append(target.Dependents, "environment "+*env.Name)
+						}
+					}
+				}
+			}
+		}
+		if !confirm.Delete(target, force) {
+			theme.Println("❌ Deletion cancelled")
+			return nil
+		}
 
 		err = client.Projects().Delete(ctx, projectUUID)
 		if err != nil {
 			return fmt.Errorf("failed to delete project: %w", err)
 		}
 
-		fmt.Printf("✅ Project %s deleted successfully\n", projectUUID)
+		theme.Printf("✅ Project %s deleted successfully\n", projectUUID)
 		return nil
 	},
 }
@@ -281,9 +340,55 @@ var projectsGetEnvironmentCmd = &cobra.Command{
 	},
 }
 
+// scopeEnvironmentIDs resolves the --project and --environment filters of a list command into
+// the set of matching environment IDs. It returns nil when no filter was given.
+func scopeEnvironmentIDs(ctx context.Context, cmd *cobra.Command, client *clientpkg.Client) (map[int]bool, error) {
+	projectRef, _ := cmd.Flags().GetString("project")
+	environment, _ := cmd.Flags().GetString("environment")
+
+	if projectRef == "" {
+		if environment != "" {
+			return nil, fmt.Errorf("--environment requires --project")
+		}
+		return nil, nil
+	}
+
+	project, err := client.Projects().Resolve(ctx, projectRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve project: %w", err)
+	}
+
+	ids := make(map[int]bool)
+	if environment != "" {
+		env, err := client.Projects().GetEnvironment(ctx, *project.Uuid, environment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get environment '%s': %w", environment, err)
+		}
+		if env.Id != nil {
+			ids[*env.Id] = true
+		}
+		return ids, nil
+	}
+
+	if project.Environments != nil {
+		for _, env := range *project.Environments {
+			if env.Id != nil {
+				ids[*env.Id] = true
+			}
+		}
+	}
+	return ids, nil
+}
+
+// addScopeFlags adds the --project and --environment filters to a list command
+func addScopeFlags(cmd *cobra.Command) {
+	cmd.Flags().String("project", "", "Only show resources in this project (UUID or name)")
+	cmd.Flags().String("environment", "", "Only show resources in this environment of the project (name or UUID)")
+}
+
 func init() {
 	// Add subcommands to projects
-	projectsCmd.AddCommand(projectsListCmd)
+	projectsCmd.AddCommand(supportsTemplateOutput(projectsListCmd))
 	projectsCmd.AddCommand(projectsGetCmd)
 	projectsCmd.AddCommand(projectsCreateCmd)
 	projectsCmd.AddCommand(projectsUpdateCmd)
@@ -300,10 +405,16 @@ func init() {
 	projectsCreateCmd.Flags().StringP("name", "n", "", "Name of the project (required)")
 	projectsCreateCmd.Flags().StringP("description", "d", "", "Description of the project")
 	_ = projectsCreateCmd.MarkFlagRequired("name")
+	addFromFileFlag(projectsCreateCmd)
+	addIfNotExistsFlag(projectsCreateCmd, clientpkg.KindProject)
 
 	// Flags for update command
 	projectsUpdateCmd.Flags().StringP("name", "n", "", "Name of the project")
 	projectsUpdateCmd.Flags().StringP("description", "d", "", "Description of the project")
+	addFromFileFlag(projectsUpdateCmd)
+
+	// Flags for delete command
+	addConfirmFlags(projectsDeleteCmd, "Delete without confirmation")
 
 	// Flags for get-environment command
 	projectsGetEnvironmentCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
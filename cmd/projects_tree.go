@@ -0,0 +1,282 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// projectTree is a project with its environments and their resources
+type projectTree struct {
+	UUID         string            `json:"uuid" yaml:"uuid"`
+	Name         string            `json:"name" yaml:"name"`
+	Description  string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Environments []environmentTree `json:"environments" yaml:"environments"`
+}
+
+// environmentTree is an environment with the resources deployed in it
+type environmentTree struct {
+	ID           int            `json:"id" yaml:"id"`
+	Name         string         `json:"name" yaml:"name"`
+	Applications []treeResource `json:"applications" yaml:"applications"`
+	Services     []treeResource `json:"services" yaml:"services"`
+	Databases    []treeResource `json:"databases" yaml:"databases"`
+}
+
+// treeResource is a single application, service or database in the tree
+type treeResource struct {
+	UUID   string `json:"uuid" yaml:"uuid"`
+	Name   string `json:"name" yaml:"name"`
+	Type   string `json:"type,omitempty" yaml:"type,omitempty"`
+	Status string `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// projectsTreeCmd represents the projects tree command
+var projectsTreeCmd = &cobra.Command{
+	Use:   "tree [project-uuid-or-name]",
+	Short: "Show projects, environments and resources as a tree",
+	Long: `Show projects with their environments and the applications, services and databases in each
+environment as an indented tree, with the status of applications and databases.
+
+Give a project UUID or name to show only that project. Use -o json or -o yaml for the nested
+structure.
+
+Examples:
+  coolifyme projects tree
+  coolifyme projects tree my-project
+  coolifyme projects tree -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("output")
+		switch format {
+		case "", "table", "json", "yaml":
+		default:
+			return fmt.Errorf("unsupported output format '%s' (expected table, json or yaml)", format)
+		}
+
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		var projects []coolify.Project
+		if len(args) == 1 {
+			project, err := client.Projects().Resolve(ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to resolve project: %w", err)
+			}
+			projects = []coolify.Project{*project}
+		} else {
+			if projects, err = client.Projects().List(ctx); err != nil {
+				return fmt.Errorf("failed to list projects: %w", err)
+			}
+		}
+
+		trees, err := buildProjectTrees(ctx, client, projects)
+		if err != nil {
+			return err
+		}
+
+		switch format {
+		case "json":
+			return outputJSON(trees)
+		case "yaml":
+			return outputYAML(trees)
+		}
+
+		if len(trees) == 0 {
+			fmt.Println("No projects found")
+			return nil
+		}
+		for i, tree := range trees {
+			if i > 0 {
+				fmt.Println()
+			}
+			printProjectTree(tree)
+		}
+		return nil
+	},
+}
+
+// buildProjectTrees groups all resources under the environments of the given projects
+func buildProjectTrees(ctx context.Context, client *clientpkg.Client, projects []coolify.Project) ([]projectTree, error) {
+	apps, err := client.Applications().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	services, err := client.Services().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	rawDatabases, err := client.Databases().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list databases: %w", err)
+	}
+	databases, err := clientpkg.ParseDatabases(rawDatabases)
+	if err != nil {
+		return nil, err
+	}
+
+	trees := make([]projectTree, 0, len(projects))
+	for _, project := range projects {
+		if project.Uuid == nil {
+			continue
+		}
+		// The project list does not include environments, so fetch each project
+		if project.Environments == nil {
+			full, err := client.Projects().Get(ctx, *project.Uuid)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get project %s: %w", stringOrDash(project.Name), err)
+			}
+			project = *full
+		}
+
+		tree := projectTree{UUID: *project.Uuid, Name: stringOrDash(project.Name), Environments: []environmentTree{}}
+		if project.Description != nil {
+			tree.Description = *project.Description
+		}
+		if project.Environments != nil {
+			for _, env := range *project.Environments {
+				if env.Id == nil {
+					continue
+				}
+				tree.Environments = append(tree.Environments, environmentTree{
+					ID:           *env.Id,
+					Name:         stringOrDash(env.Name),
+					Applications: environmentApplications(apps, *env.Id),
+					Services:     environmentServices(services, *env.Id),
+					Databases:    environmentDatabases(databases, *env.Id),
+				})
+			}
+		}
+		trees = append(trees, tree)
+	}
+	return trees, nil
+}
+
+// environmentApplications returns the applications in an environment
+func environmentApplications(apps []coolify.Application, environmentID int) []treeResource {
+	resources := []treeResource{}
+	for _, app := range apps {
+		if app.EnvironmentId != nil && *app.EnvironmentId == environmentID {
+			resource := treeResource{UUID: stringOrDash(app.Uuid), Name: stringOrDash(app.Name), Status: stringOrDash(app.Status)}
+			if app.BuildPack != nil {
+				resource.Type = string(*app.BuildPack)
+			}
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
+
+// environmentServices returns the services in an environment
+func environmentServices(services []coolify.Service, environmentID int) []treeResource {
+	resources := []treeResource{}
+	for _, service := range services {
+		if service.EnvironmentId != nil && *service.EnvironmentId == environmentID {
+			resource := treeResource{UUID: stringOrDash(service.Uuid), Name: stringOrDash(service.Name)}
+			if service.ServiceType != nil {
+				resource.Type = *service.ServiceType
+			}
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
+
+// environmentDatabases returns the databases in an environment
+func environmentDatabases(databases []clientpkg.DatabaseInfo, environmentID int) []treeResource {
+	resources := []treeResource{}
+	for _, db := range databases {
+		if db.EnvironmentID == environmentID {
+			resources = append(resources, treeResource{UUID: db.UUID, Name: db.Name, Type: db.Type, Status: db.Status})
+		}
+	}
+	return resources
+}
+
+// printProjectTree prints a project and its environments as an indented tree
+func printProjectTree(tree projectTree) {
+	branch, last, pipe := "├── ", "└── ", "│   "
+	if !theme.EmojiEnabled() {
+		branch, last, pipe = "|-- ", "`-- ", "|   "
+	}
+
+	theme.Printf("📁 %s (%s)\n", tree.Name, tree.UUID)
+	if len(tree.Environments) == 0 {
+		fmt.Println(last + "(no environments)")
+		return
+	}
+
+	for i, env := range tree.Environments {
+		prefix, indent := branch, pipe
+		if i == len(tree.Environments)-1 {
+			prefix, indent = last, "    "
+		}
+		theme.Printf("%s🌍 %s\n", prefix, env.Name)
+
+		var lines []string
+		for _, app := range env.Applications {
+			lines = append(lines, treeResourceLine("application", app))
+		}
+		for _, service := range env.Services {
+			lines = append(lines, treeResourceLine("service", service))
+		}
+		for _, db := range env.Databases {
+			lines = append(lines, treeResourceLine("database", db))
+		}
+		if len(lines) == 0 {
+			fmt.Println(indent + last + "(empty)")
+		}
+		for j, line := range lines {
+			if j == len(lines)-1 {
+				theme.Println(indent + last + line)
+			} else {
+				theme.Println(indent + branch + line)
+			}
+		}
+	}
+}
+
+// treeResourceLine renders a resource as "<glyph> <kind> <name> [type] (<uuid>) <status>"
+func treeResourceLine(kind string, resource treeResource) string {
+	var b strings.Builder
+	if resource.Status != "" {
+		b.WriteString(resourceStatusGlyph(resource.Status) + " ")
+	}
+	b.WriteString(kind + " " + resource.Name)
+	if resource.Type != "" {
+		b.WriteString(" [" + resource.Type + "]")
+	}
+	b.WriteString(" (" + resource.UUID + ")")
+	if resource.Status != "" {
+		b.WriteString(" " + resource.Status)
+	}
+	return b.String()
+}
+
+// resourceStatusGlyph maps a container status such as "running:healthy" to a status glyph
+func resourceStatusGlyph(status string) string {
+	state, health, _ := strings.Cut(strings.ToLower(status), ":")
+	switch {
+	case strings.HasPrefix(state, "running") && health == "unhealthy":
+		return "⚠️"
+	case strings.HasPrefix(state, "running"):
+		return "✅"
+	case strings.HasPrefix(state, "exited"), strings.HasPrefix(state, "stopped"):
+		return "⏹️"
+	case strings.HasPrefix(state, "restarting"), strings.HasPrefix(state, "starting"), strings.HasPrefix(state, "degraded"):
+		return "⚠️"
+	}
+	return "❓"
+}
+
+func init() {
+	projectsCmd.AddCommand(projectsTreeCmd)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hongkongkiwi/coolifyme/internal/release"
+	"github.com/hongkongkiwi/coolifyme/internal/selfupdate"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+// releaseManifestFiles are the files written by 'release manifest --dir', per format
+var releaseManifestFiles = map[string]string{
+	"homebrew": "coolifyme.rb",
+	"scoop":    "coolifyme.json",
+	"install":  "install-snippet.sh",
+}
+
+// releaseCmd represents the release command
+var releaseCmd = &cobra.Command{
+	Use:    "release",
+	Short:  "Release tooling for maintainers",
+	Long:   "Generate packaging files for a coolifyme release from the artifacts built by 'task release-build'",
+	Hidden: true,
+}
+
+// releaseManifestCmd represents the release manifest command
+var releaseManifestCmd = &cobra.Command{
+	Use:   "manifest",
+	Short: "Generate the Homebrew formula, Scoop manifest and install snippet of a release",
+	Long: `Generate the Homebrew formula, Scoop manifest and a shell install snippet for a release from
+its checksums.txt, so package manifests are reproducible from the code. Only platforms listed in
+the checksums are included.
+
+Without --dir the manifest of --format is printed; with --dir every format is written to
+coolifyme.rb, coolifyme.json and install-snippet.sh in that directory.
+
+Examples:
+  coolifyme release manifest --version v1.2.3 --format homebrew > Formula/coolifyme.rb
+  coolifyme release manifest --version v1.2.3 --checksums dist/checksums.txt --dir dist`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		version, _ := cmd.Flags().GetString("version")
+		checksumsFile, _ := cmd.Flags().GetString("checksums")
+		repository, _ := cmd.Flags().GetString("repo")
+		format, _ := cmd.Flags().GetString("format")
+		dir, _ := cmd.Flags().GetString("dir")
+
+		if version == "" {
+			version = Version
+		}
+		checksums, err := safeReadFile(checksumsFile)
+		if err != nil {
+			return fmt.Errorf("failed to read checksums: %w", err)
+		}
+		manifest, err := release.New(version, repository, checksums)
+		if err != nil {
+			return fmt.Errorf("cannot generate manifests for version '%s', pass a release version with --version: %w", version, err)
+		}
+
+		render := map[string]func() (string, error){
+			"homebrew": manifest.Homebrew,
+			"scoop":    manifest.Scoop,
+			"install":  manifest.InstallScript,
+		}
+
+		if dir == "" {
+			renderFormat, ok := render[format]
+			if !ok {
+				return fmt.Errorf("unsupported format '%s' (expected homebrew, scoop or install)", format)
+			}
+			content, err := renderFormat()
+			if err != nil {
+				return err
+			}
+			fmt.Print(content)
+			return nil
+		}
+
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		for _, name := range []string{"homebrew", "scoop", "install"} {
+			content, err := render[name]()
+			if err != nil {
+				return fmt.Errorf("failed to generate %s manifest: %w", name, err)
+			}
+			path := filepath.Join(dir, releaseManifestFiles[name])
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+			theme.Printf("✅ Wrote %s\n", path)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(releaseCmd)
+	releaseCmd.AddCommand(releaseManifestCmd)
+
+	releaseManifestCmd.Flags().String("version", "", "Release version (default the version of this build)")
+	releaseManifestCmd.Flags().String("checksums", "dist/"+selfupdate.ChecksumsAsset, "checksums.txt of the release")
+	releaseManifestCmd.Flags().String("repo", selfupdate.DefaultRepository, "GitHub repository the release is published in")
+	releaseManifestCmd.Flags().String("format", "homebrew", "Manifest printed without --dir (homebrew, scoop, install)")
+	releaseManifestCmd.Flags().String("dir", "", "Write every manifest into this directory")
+}
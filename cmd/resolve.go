@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hongkongkiwi/coolifyme/internal/logger"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// uuidPlaceholderKinds maps UUID placeholders used in command usage lines to the resource kind
+// they refer to. A plain "uuid" placeholder takes its kind from the command, see uuidKindForCommand.
+var uuidPlaceholderKinds = map[string]clientpkg.ResourceKind{
+	"app-uuid":     clientpkg.KindApplication,
+	"service-uuid": clientpkg.KindService,
+	"project-uuid": clientpkg.KindProject,
+}
+
+// uuidCommandKinds maps command and command group names to the kind of their plain UUID arguments
+var uuidCommandKinds = map[string]clientpkg.ResourceKind{
+	"application":  clientpkg.KindApplication,
+	"applications": clientpkg.KindApplication,
+	"app":          clientpkg.KindApplication,
+	"deploy-app":   clientpkg.KindApplication,
+	"multiple":     clientpkg.KindApplication,
+	"history":      clientpkg.KindApplication,
+	"service":      clientpkg.KindService,
+	"services":     clientpkg.KindService,
+	"databases":    clientpkg.KindDatabase,
+	"servers":      clientpkg.KindServer,
+	"projects":     clientpkg.KindProject,
+	"keys":         clientpkg.KindPrivateKey,
+	"sources":      clientpkg.KindSource,
+}
+
+// uuidKindForCommand returns the resource kind of plain UUID arguments of a command, looking at
+// the command itself first and then at its parents
+func uuidKindForCommand(cmd *cobra.Command) (clientpkg.ResourceKind, bool) {
+	for c := cmd; c != nil; c = c.Parent() {
+		if kind, ok := uuidCommandKinds[c.Name()]; ok {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+// uuidArgKinds returns the resource kind of each positional argument declared in the usage line
+// of a command ("" for arguments that are not resource UUIDs) and whether the last one repeats
+func uuidArgKinds(cmd *cobra.Command) ([]clientpkg.ResourceKind, bool) {
+	fields := strings.Fields(cmd.Use)
+	if len(fields) < 2 {
+		return nil, false
+	}
+
+	var kinds []clientpkg.ResourceKind
+	variadic := false
+	for _, field := range fields[1:] {
+		if !strings.HasPrefix(field, "<") && !strings.HasPrefix(field, "[") {
+			continue
+		}
+		variadic = strings.HasSuffix(field, "...")
+		name := strings.Trim(field, "<>[].")
+		name = strings.TrimRight(name, "0123456789")
+
+		var kind clientpkg.ResourceKind
+		if name == "uuid" {
+			kind, _ = uuidKindForCommand(cmd)
+		} else {
+			kind = uuidPlaceholderKinds[name]
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds, variadic
+}
+
+// resolveUUIDArgs expands unique UUID prefixes in the positional arguments of a command to full
+// UUIDs in place, so every command accepting a UUID also accepts a prefix. It is a no-op with
+// --exact and for commands without UUID arguments.
+func resolveUUIDArgs(cmd *cobra.Command, args []string) error {
+	if exact, _ := cmd.Flags().GetBool("exact"); exact {
+		return nil
+	}
+
+	kinds, variadic := uuidArgKinds(cmd)
+	kindAt := func(i int) clientpkg.ResourceKind {
+		if i < len(kinds) {
+			return kinds[i]
+		}
+		if variadic && len(kinds) > 0 {
+			return kinds[len(kinds)-1]
+		}
+		return ""
+	}
+
+	var client *clientpkg.Client
+	for i, arg := range args {
+		kind := kindAt(i)
+		if kind == "" || len(arg) < clientpkg.MinUUIDPrefix {
+			continue
+		}
+
+		if client == nil {
+			var err error
+			client, err = createClient()
+			if err != nil {
+				// Leave the arguments alone, the command reports the configuration problem itself
+				return nil
+			}
+		}
+
+		resolved, err := client.ResolveUUID(context.Background(), kind, arg)
+		if err != nil {
+			return err
+		}
+		if resolved != arg {
+			logger.Debug("Resolved UUID prefix", "kind", kind, "prefix", arg, "uuid", resolved)
+			args[i] = resolved
+		}
+	}
+	return nil
+}
@@ -7,6 +7,9 @@ import (
 	"time"
 
 	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
 	"github.com/spf13/cobra"
 )
 
@@ -33,7 +36,7 @@ var rollbackAppCmd = &cobra.Command{
 		toVersion, _ := cmd.Flags().GetString("to-version")
 		toCommit, _ := cmd.Flags().GetString("to-commit")
 		listOnly, _ := cmd.Flags().GetBool("list")
-		force, _ := cmd.Flags().GetBool("force")
+		force := skipConfirmation(cmd)
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 
 		ctx := context.Background()
@@ -55,7 +58,7 @@ var rollbackAppCmd = &cobra.Command{
 		}
 
 		// Show rollback plan
-		fmt.Printf("🔄 Rollback Plan\n")
+		theme.Printf("🔄 Rollback Plan\n")
 		fmt.Printf("================\n")
 		fmt.Printf("Application: %s (%s)\n", getAppName(app), appUUID)
 		if toVersion != "" {
@@ -68,19 +71,14 @@ var rollbackAppCmd = &cobra.Command{
 		fmt.Println()
 
 		if dryRun {
-			fmt.Println("✅ Dry run completed - no changes made")
+			theme.Println("✅ Dry run completed - no changes made")
 			return nil
 		}
 
 		// Confirm rollback unless force flag is set
-		if !force {
-			fmt.Printf("⚠️  Are you sure you want to rollback this application? This action cannot be undone.\n")
-			fmt.Print("Type 'yes' to confirm: ")
-			var confirmation string
-			if _, err := fmt.Scanln(&confirmation); err != nil || confirmation != ConfirmationYes {
-				fmt.Println("❌ Rollback cancelled")
-				return nil
-			}
+		if !confirm.Action("Are you sure you want to rollback this application? This action cannot be undone.", force) {
+			theme.Println("❌ Rollback cancelled")
+			return nil
 		}
 
 		// Perform rollback
@@ -102,19 +100,19 @@ var rollbackServiceCmd = &cobra.Command{
 		toVersion, _ := cmd.Flags().GetString("to-version")
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 
-		fmt.Printf("🔄 Service Rollback\n")
+		theme.Printf("🔄 Service Rollback\n")
 		fmt.Printf("===================\n")
 		fmt.Printf("Service: %s\n", serviceUUID)
 		fmt.Printf("Target Version: %s\n", toVersion)
 		fmt.Printf("Dry Run: %v\n", dryRun)
 
 		if dryRun {
-			fmt.Println("✅ Dry run completed - service rollback would be performed")
+			theme.Println("✅ Dry run completed - service rollback would be performed")
 			return nil
 		}
 
 		// Note: Service rollback requires additional API endpoints
-		fmt.Println("⚠️  Service rollback is not yet supported by the Coolify API")
+		theme.Println("⚠️  Service rollback is not yet supported by the Coolify API")
 		fmt.Println("   Please use the Coolify web interface for service rollbacks")
 		return nil
 	},
@@ -148,15 +146,15 @@ var rollbackHistoryCmd = &cobra.Command{
 }
 
 func listAvailableVersions(ctx context.Context, client interface{}, appUUID string) error {
-	fmt.Printf("📋 Available Versions for Application: %s\n", appUUID)
+	theme.Printf("📋 Available Versions for Application: %s\n", appUUID)
 	fmt.Printf("=========================================\n")
 
 	// Get deployment history (if available)
 	deployments, err := getDeploymentHistory(ctx, client, appUUID)
 	if err != nil {
-		fmt.Printf("⚠️  Could not fetch deployment history: %v\n", err)
+		warn("deployment history", err)
 	} else {
-		fmt.Printf("\n🚀 Recent Deployments:\n")
+		theme.Printf("\n🚀 Recent Deployments:\n")
 		for i, deployment := range deployments {
 			if i >= 10 { // Limit to last 10 deployments
 				break
@@ -168,9 +166,9 @@ func listAvailableVersions(ctx context.Context, client interface{}, appUUID stri
 	// Get git commits (if it's a git-based application)
 	commits, err := getGitCommits(ctx, client, appUUID)
 	if err != nil {
-		fmt.Printf("⚠️  Could not fetch git commits: %v\n", err)
+		warn("git commits", err)
 	} else {
-		fmt.Printf("\n📝 Recent Git Commits:\n")
+		theme.Printf("\n📝 Recent Git Commits:\n")
 		for i, commit := range commits {
 			if i >= 10 { // Limit to last 10 commits
 				break
@@ -187,12 +185,12 @@ func listAvailableVersions(ctx context.Context, client interface{}, appUUID stri
 }
 
 func rollbackToCommit(_ context.Context, _ interface{}, _, commitHash string) error {
-	fmt.Printf("🔄 Rolling back to commit: %s\n", commitHash)
+	theme.Printf("🔄 Rolling back to commit: %s\n", commitHash)
 
 	// This would require specific API endpoints for git-based rollbacks
 	// For now, we'll simulate the process and suggest manual steps
 
-	fmt.Printf("⚠️  Direct commit rollback is not yet supported by the Coolify API\n")
+	theme.Printf("⚠️  Direct commit rollback is not yet supported by the Coolify API\n")
 	fmt.Printf("   Suggested manual steps:\n")
 	fmt.Printf("   1. Go to your git repository\n")
 	fmt.Printf("   2. Reset or create a new commit with the desired state\n")
@@ -203,40 +201,28 @@ func rollbackToCommit(_ context.Context, _ interface{}, _, commitHash string) er
 }
 
 func rollbackToVersion(_ context.Context, _ interface{}, _, version string) error {
-	fmt.Printf("🔄 Rolling back to version: %s\n", version)
+	theme.Printf("🔄 Rolling back to version: %s\n", version)
 
 	// This would require deployment history and rollback API endpoints
-	fmt.Printf("⚠️  Version-based rollback is not yet supported by the Coolify API\n")
+	theme.Printf("⚠️  Version-based rollback is not yet supported by the Coolify API\n")
 	fmt.Printf("   Please use the Coolify web interface to rollback to a previous deployment\n")
 
 	return nil
 }
 
-func getApplicationInfo(ctx context.Context, client interface{}, appUUID string) (interface{}, error) {
-	// Type assertion to get the actual client
-	c, ok := client.(interface {
-		Applications() interface {
-			Get(context.Context, string) (coolify.Application, error)
-		}
-	})
-	if !ok {
-		return nil, fmt.Errorf("invalid client type")
-	}
-
-	return c.Applications().Get(ctx, appUUID)
+func getApplicationInfo(ctx context.Context, client clientpkg.API, appUUID string) (*coolify.Application, error) {
+	return client.Applications().Get(ctx, appUUID)
 }
 
-func getAppName(app interface{}) string {
-	if coolifyApp, ok := app.(coolify.Application); ok {
-		if coolifyApp.Name != nil {
-			return *coolifyApp.Name
-		}
+func getAppName(app *coolify.Application) string {
+	if app != nil && app.Name != nil {
+		return *app.Name
 	}
 	return "Unknown"
 }
 
 func showApplicationHistory(_ context.Context, _ interface{}, appUUID string, limit int, jsonOutput bool) error {
-	fmt.Printf("📜 Application History: %s\n", appUUID)
+	theme.Printf("📜 Application History: %s\n", appUUID)
 	fmt.Printf("=========================\n")
 
 	// Mock deployment history for demonstration
@@ -301,7 +287,7 @@ func showApplicationHistory(_ context.Context, _ interface{}, appUUID string, li
 			status = "🔄 " + status
 		}
 
-		fmt.Printf("%s\t%s\t%s\t%s\t%s\t%s\n",
+		theme.Printf("%s\t%s\t%s\t%s\t%s\t%s\n",
 			record.ID,
 			record.Version,
 			record.Commit[:8],
@@ -365,7 +351,7 @@ func init() {
 	rollbackAppCmd.Flags().String("to-version", "", "Rollback to specific version")
 	rollbackAppCmd.Flags().String("to-commit", "", "Rollback to specific git commit")
 	rollbackAppCmd.Flags().BoolP("list", "l", false, "List available versions/commits")
-	rollbackAppCmd.Flags().BoolP("force", "f", false, "Force rollback without confirmation")
+	addConfirmFlags(rollbackAppCmd, "Force rollback without confirmation")
 	rollbackAppCmd.Flags().Bool("dry-run", false, "Show what would be done without making changes")
 
 	// Flags for rollback service command
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/logger"
+	"github.com/hongkongkiwi/coolifyme/internal/schedule"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+// schedulerCmd represents the scheduler command
+var schedulerCmd = &cobra.Command{
+	Use:   "scheduler",
+	Short: "Run queued actions such as maintenance window restarts",
+	Long: `Queue actions for later and run them with a local scheduler, for maintenance windows without
+setting up cron. Coolify's own scheduled tasks run commands inside containers and are not exposed
+by the API, so actions are queued in schedule.json in the config directory and run by
+'coolifyme scheduler run', which keeps running and executes each action when it is due.
+
+Actions are queued by commands with --at or --cron:
+  coolifyme applications restart <uuid> --at 03:00
+  coolifyme applications restart <uuid> --cron "0 3 * * 0"
+  coolifyme applications restart <uuid> --at 03:00 --cron "0 3 * * 0"
+
+Examples:
+  coolifyme scheduler list
+  coolifyme scheduler run
+  coolifyme scheduler remove 3`,
+}
+
+// schedulerListCmd represents the scheduler list command
+var schedulerListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List queued actions",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		path, err := schedulePath()
+		if err != nil {
+			return err
+		}
+		actions, err := schedule.Load(path)
+		if err != nil {
+			return fmt.Errorf("failed to read the schedule: %w", err)
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			if actions == nil {
+				actions = []schedule.Action{}
+			}
+			output, err := json.MarshalIndent(actions, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		if len(actions) == 0 {
+			fmt.Println("No actions scheduled")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "ID\tACTION\tPROFILE\tNEXT RUN\tREPEAT\tLAST RUN")
+		_, _ = fmt.Fprintln(w, "--\t------\t-------\t--------\t------\t--------")
+		for _, action := range actions {
+			lastRun := "-"
+			if action.LastRun != nil {
+				lastRun = action.LastRun.Local().Format(time.DateTime)
+				if action.LastError != "" {
+					lastRun += " (failed)"
+				}
+			}
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n",
+				action.ID,
+				action.Description,
+				stringOrDash(&action.Profile),
+				action.NextRun.Local().Format(time.DateTime),
+				stringOrDash(&action.Cron),
+				lastRun)
+		}
+		return w.Flush()
+	},
+}
+
+// schedulerRemoveCmd represents the scheduler remove command
+var schedulerRemoveCmd = &cobra.Command{
+	Use:     "remove <id>",
+	Aliases: []string{"rm", "cancel"},
+	Short:   "Remove a queued action",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid action ID %q", args[0])
+		}
+		path, err := schedulePath()
+		if err != nil {
+			return err
+		}
+		removed, err := schedule.Remove(path, id)
+		if err != nil {
+			return fmt.Errorf("failed to update the schedule: %w", err)
+		}
+		if !removed {
+			return fmt.Errorf("action %d not found (see 'coolifyme scheduler list')", id)
+		}
+		theme.Printf("🗑️  Removed scheduled action %d\n", id)
+		return nil
+	},
+}
+
+// schedulerRunCmd represents the scheduler run command
+var schedulerRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run queued actions when they are due",
+	Long: `Run queued actions when they are due, checking the schedule every --interval until Ctrl+C.
+Run it in the background, e.g. as a systemd service or in tmux. Actions missed while the scheduler
+was not running are run once when it starts. One-off actions are removed after running; actions
+with a cron expression are queued again for their next time.
+
+Each action runs as a separate coolifyme command with the profile it was queued with.
+
+Examples:
+  coolifyme scheduler run
+  coolifyme scheduler run --once`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		once, _ := cmd.Flags().GetBool("once")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		if interval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+
+		path, err := schedulePath()
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		if !once {
+			theme.Printf("⏰ Scheduler running, checking %s every %s (Ctrl+C to stop)\n", path, interval)
+		}
+		for {
+			if err := runDueActions(ctx, path, time.Now()); err != nil {
+				if once {
+					return err
+				}
+				logger.Warn("Scheduled actions failed", "error", err)
+			}
+			if once {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(interval):
+			}
+		}
+	},
+}
+
+// schedulePath returns the file queued actions are kept in
+func schedulePath() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "schedule.json"), nil
+}
+
+// scheduleRequested reports whether --at or --cron was given to a command that can be scheduled
+func scheduleRequested(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("at") || cmd.Flags().Changed("cron")
+}
+
+// addScheduleFlags adds the flags queueing a command for 'coolifyme scheduler run'
+func addScheduleFlags(cmd *cobra.Command) {
+	cmd.Flags().String("at", "", "Queue for 'coolifyme scheduler run' at a time (03:00, '2024-06-01 03:00') instead of running now")
+	cmd.Flags().String("cron", "", "Queue for 'coolifyme scheduler run' repeating on a cron expression (e.g. '0 3 * * 0')")
+}
+
+// scheduleAction queues coolifyme args for the times of the --at and --cron flags of cmd. With
+// both flags the action first runs at --at and then repeats on --cron.
+func scheduleAction(cmd *cobra.Command, description string, args []string) error {
+	at, _ := cmd.Flags().GetString("at")
+	cronExpr, _ := cmd.Flags().GetString("cron")
+	now := time.Now()
+
+	action := schedule.Action{
+		Description: description,
+		Args:        args,
+		Profile:     activeProfileName(),
+		Created:     now.UTC(),
+	}
+	if cronExpr != "" {
+		cron, err := schedule.ParseCron(cronExpr)
+		if err != nil {
+			return err
+		}
+		action.Cron = cron.String()
+		if action.NextRun = cron.Next(now); action.NextRun.IsZero() {
+			return fmt.Errorf("cron expression %q never matches", cronExpr)
+		}
+	}
+	if at != "" {
+		next, err := schedule.ParseAt(at, now)
+		if err != nil {
+			return err
+		}
+		if !next.After(now) {
+			return fmt.Errorf("--at %s is in the past", at)
+		}
+		action.NextRun = next
+	}
+
+	path, err := schedulePath()
+	if err != nil {
+		return err
+	}
+	action, err = schedule.Add(path, action)
+	if err != nil {
+		return fmt.Errorf("failed to update the schedule: %w", err)
+	}
+
+	theme.Printf("⏰ Scheduled %s (#%d) for %s", description, action.ID, action.NextRun.Local().Format(time.DateTime))
+	if action.Cron != "" {
+		fmt.Printf(", repeating on '%s'", action.Cron)
+	}
+	fmt.Println()
+	fmt.Println("   Actions run while 'coolifyme scheduler run' is running")
+	return nil
+}
+
+// runDueActions runs the actions of the schedule that are due at now and queues repeating
+// actions for their next time
+func runDueActions(ctx context.Context, path string, now time.Time) error {
+	actions, err := schedule.Load(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the schedule: %w", err)
+	}
+
+	var done []schedule.Action
+	for _, action := range actions {
+		if !action.Due(now) || ctx.Err() != nil {
+			continue
+		}
+
+		theme.Printf("▶️  Running #%d: %s\n", action.ID, action.Description)
+		ran := time.Now().UTC()
+		action.LastRun = &ran
+		action.LastError = ""
+		if err := runScheduledCommand(ctx, action); err != nil {
+			action.LastError = err.Error()
+			theme.Printf("❌ #%d failed: %v\n", action.ID, err)
+		} else {
+			theme.Printf("✅ #%d done\n", action.ID)
+		}
+
+		action.NextRun = time.Time{}
+		if action.Cron != "" {
+			if cron, err := schedule.ParseCron(action.Cron); err == nil {
+				action.NextRun = cron.Next(time.Now())
+			}
+		}
+		done = append(done, action)
+	}
+
+	if len(done) == 0 {
+		return nil
+	}
+	if err := schedule.Update(path, done); err != nil {
+		return fmt.Errorf("failed to update the schedule: %w", err)
+	}
+	return nil
+}
+
+// runScheduledCommand runs the coolifyme command of an action with the profile it was queued with
+func runScheduledCommand(ctx context.Context, action schedule.Action) error {
+	executable, err := os.Executable()
+	if err != nil {
+		executable = os.Args[0]
+	}
+	child := exec.CommandContext(ctx, executable, action.Args...) // #nosec G204 -- re-executes this binary
+	child.Env = os.Environ()
+	if action.Profile != "" {
+		child.Env = append(child.Env, "COOLIFYME_PROFILE="+action.Profile)
+	}
+	child.Stdout, child.Stderr = os.Stdout, os.Stderr
+	return child.Run()
+}
+
+func init() {
+	rootCmd.AddCommand(schedulerCmd)
+	schedulerCmd.AddCommand(schedulerListCmd)
+	schedulerCmd.AddCommand(schedulerRemoveCmd)
+	schedulerCmd.AddCommand(schedulerRunCmd)
+
+	schedulerListCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+
+	schedulerRunCmd.Flags().Bool("once", false, "Run the actions that are due and exit")
+	schedulerRunCmd.Flags().Duration("interval", 30*time.Second, "Time between two checks of the schedule")
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hongkongkiwi/coolifyme/internal/events"
+	"github.com/spf13/cobra"
+)
+
+// schemaCmd represents the schema command
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print JSON schemas of coolifyme output",
+	Long:  "Print the JSON schemas of output that other programs consume, so they can validate it or generate types from it",
+}
+
+// schemaEventsCmd represents the schema events command
+var schemaEventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Print the JSON schema of watch and monitor events",
+	Long: fmt.Sprintf(`Print the JSON schema (draft 2020-12) of the events printed by 'watch resources --json' and
+posted to the webhooks of 'monitor run'.
+
+Every event carries a schemaVersion, currently %d, and a type, currently always alert. Within a
+schema version fields are only added, never renamed, removed or given another meaning, and
+enums such as the event type or alert condition only gain values, so consumers should ignore
+unknown fields and values. The schema lists the known values of such enums next to a plain
+string and accepts events of other types, so validating against it keeps working when values
+are added. Other changes increase the schema version.
+
+Examples:
+  coolifyme schema events > coolifyme-events.schema.json`, events.SchemaVersion),
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		schema, err := events.Schema()
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(schema))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+	schemaCmd.AddCommand(schemaEventsCmd)
+}
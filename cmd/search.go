@@ -10,6 +10,9 @@ import (
 	"text/tabwriter"
 
 	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/hongkongkiwi/coolifyme/internal/warnings"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
 	"github.com/spf13/cobra"
 )
 
@@ -36,28 +39,34 @@ var searchCmd = &cobra.Command{
 		ctx := context.Background()
 		results := &SearchResults{}
 
+		tagged, err := taggedUUIDs(ctx, client, tag)
+		if err != nil {
+			return err
+		}
+
 		// Search based on resource type filter
 		if resourceType == "" || resourceType == "applications" || resourceType == "apps" {
-			if err := searchApplications(ctx, client, query, status, tag, caseSensitive, results); err != nil {
-				fmt.Printf("⚠️  Failed to search applications: %v\n", err)
+			if err := searchApplications(ctx, client, query, status, tagged, caseSensitive, results); err != nil {
+				warn("search applications", err)
 			}
 		}
 
 		if resourceType == "" || resourceType == "services" || resourceType == "svc" {
-			if err := searchServices(ctx, client, query, status, tag, caseSensitive, results); err != nil {
-				fmt.Printf("⚠️  Failed to search services: %v\n", err)
+			if err := searchServices(ctx, client, query, status, tagged, caseSensitive, results); err != nil {
+				warn("search services", err)
 			}
 		}
 
 		if resourceType == "" || resourceType == "servers" || resourceType == "srv" {
-			if err := searchServers(ctx, client, query, status, tag, caseSensitive, results); err != nil {
-				fmt.Printf("⚠️  Failed to search servers: %v\n", err)
+			if err := searchServers(ctx, client, query, status, tagged, caseSensitive, results); err != nil {
+				warn("search servers", err)
 			}
 		}
 
-		if resourceType == "" || resourceType == "databases" || resourceType == "db" {
-			if err := searchDatabases(ctx, client, query, status, tag, caseSensitive, results); err != nil {
-				fmt.Printf("⚠️  Failed to search databases: %v\n", err)
+		// Database search is not implemented, so only an explicit --type reports it
+		if resourceType == "databases" || resourceType == "db" {
+			if err := searchDatabases(ctx, client, query, status, tagged, caseSensitive, results); err != nil {
+				warn("search databases", err)
 			}
 		}
 
@@ -68,6 +77,7 @@ var searchCmd = &cobra.Command{
 
 		// Output results
 		if jsonOutput {
+			results.Warnings = commandWarnings.Take()
 			output, err := json.MarshalIndent(results, "", "  ")
 			if err != nil {
 				return fmt.Errorf("failed to marshal JSON: %w", err)
@@ -105,27 +115,33 @@ var findCmd = &cobra.Command{
 		ctx := context.Background()
 		results := &SearchResults{}
 
+		tagged, err := taggedUUIDs(ctx, client, tag)
+		if err != nil {
+			return err
+		}
+
 		// Search based on resource type filter
 		if resourceType == "" || resourceType == "applications" || resourceType == "apps" {
-			if err := findApplications(ctx, client, name, status, tag, results); err != nil {
-				fmt.Printf("⚠️  Failed to find applications: %v\n", err)
+			if err := findApplications(ctx, client, name, status, tagged, results); err != nil {
+				warn("find applications", err)
 			}
 		}
 
 		if resourceType == "" || resourceType == "services" || resourceType == "svc" {
-			if err := findServices(ctx, client, name, status, tag, results); err != nil {
-				fmt.Printf("⚠️  Failed to find services: %v\n", err)
+			if err := findServices(ctx, client, name, status, tagged, results); err != nil {
+				warn("find services", err)
 			}
 		}
 
 		if resourceType == "" || resourceType == "servers" || resourceType == "srv" {
-			if err := findServers(ctx, client, name, status, tag, results); err != nil {
-				fmt.Printf("⚠️  Failed to find servers: %v\n", err)
+			if err := findServers(ctx, client, name, status, tagged, results); err != nil {
+				warn("find servers", err)
 			}
 		}
 
 		// Output results
 		if jsonOutput {
+			results.Warnings = commandWarnings.Take()
 			output, err := json.MarshalIndent(results, "", "  ")
 			if err != nil {
 				return fmt.Errorf("failed to marshal JSON: %w", err)
@@ -146,6 +162,8 @@ type SearchResults struct {
 	Servers      []SearchResultServer `json:"servers"`
 	Databases    []SearchResultDB     `json:"databases"`
 	TotalCount   int                  `json:"total_count"`
+	// Warnings are the resource types that could not be searched
+	Warnings []warnings.Warning `json:"warnings"`
 }
 
 // SearchResultApp represents an application in search results
@@ -183,24 +201,14 @@ type SearchResultDB struct {
 	Type   string `json:"type"`
 }
 
-func searchApplications(ctx context.Context, client interface{}, query, status, tag string, caseSensitive bool, results *SearchResults) error {
-	// Type assertion to get the actual client
-	c, ok := client.(interface {
-		Applications() interface {
-			List(context.Context) ([]coolify.Application, error)
-		}
-	})
-	if !ok {
-		return fmt.Errorf("invalid client type")
-	}
-
-	apps, err := c.Applications().List(ctx)
+func searchApplications(ctx context.Context, client clientpkg.API, query, status string, tagged map[string]bool, caseSensitive bool, results *SearchResults) error {
+	apps, err := client.Applications().List(ctx)
 	if err != nil {
 		return err
 	}
 
 	for _, app := range apps {
-		if matchesSearch(app, query, status, tag, caseSensitive) {
+		if matchesSearch(app, query, status, tagged, caseSensitive) {
 			result := SearchResultApp{
 				Type: "application",
 			}
@@ -222,23 +230,14 @@ func searchApplications(ctx context.Context, client interface{}, query, status,
 	return nil
 }
 
-func searchServices(ctx context.Context, client interface{}, query, status, tag string, caseSensitive bool, results *SearchResults) error {
-	c, ok := client.(interface {
-		Services() interface {
-			List(context.Context) ([]coolify.Service, error)
-		}
-	})
-	if !ok {
-		return fmt.Errorf("invalid client type")
-	}
-
-	services, err := c.Services().List(ctx)
+func searchServices(ctx context.Context, client clientpkg.API, query, status string, tagged map[string]bool, caseSensitive bool, results *SearchResults) error {
+	services, err := client.Services().List(ctx)
 	if err != nil {
 		return err
 	}
 
 	for _, svc := range services {
-		if matchesSearchService(svc, query, status, tag, caseSensitive) {
+		if matchesSearchService(svc, query, status, tagged, caseSensitive) {
 			result := SearchResultSvc{
 				Type: "service",
 			}
@@ -256,23 +255,14 @@ func searchServices(ctx context.Context, client interface{}, query, status, tag
 	return nil
 }
 
-func searchServers(ctx context.Context, client interface{}, query, status, tag string, caseSensitive bool, results *SearchResults) error {
-	c, ok := client.(interface {
-		Servers() interface {
-			List(context.Context) ([]coolify.Server, error)
-		}
-	})
-	if !ok {
-		return fmt.Errorf("invalid client type")
-	}
-
-	servers, err := c.Servers().List(ctx)
+func searchServers(ctx context.Context, client clientpkg.API, query, status string, tagged map[string]bool, caseSensitive bool, results *SearchResults) error {
+	servers, err := client.Servers().List(ctx)
 	if err != nil {
 		return err
 	}
 
 	for _, srv := range servers {
-		if matchesSearchServer(srv, query, status, tag, caseSensitive) {
+		if matchesSearchServer(srv, query, status, tagged, caseSensitive) {
 			result := SearchResultServer{
 				Type: "server",
 			}
@@ -300,23 +290,23 @@ func searchServers(ctx context.Context, client interface{}, query, status, tag s
 	return nil
 }
 
-func searchDatabases(_ context.Context, _ interface{}, _, _, _ string, _ bool, _ *SearchResults) error {
+func searchDatabases(_ context.Context, _ clientpkg.API, _, _ string, _ map[string]bool, _ bool, _ *SearchResults) error {
 	return fmt.Errorf("database search not yet implemented")
 }
 
-func findApplications(ctx context.Context, client interface{}, name, status, tag string, results *SearchResults) error {
-	return searchApplications(ctx, client, name, status, tag, false, results)
+func findApplications(ctx context.Context, client clientpkg.API, name, status string, tagged map[string]bool, results *SearchResults) error {
+	return searchApplications(ctx, client, name, status, tagged, false, results)
 }
 
-func findServices(ctx context.Context, client interface{}, name, status, tag string, results *SearchResults) error {
-	return searchServices(ctx, client, name, status, tag, false, results)
+func findServices(ctx context.Context, client clientpkg.API, name, status string, tagged map[string]bool, results *SearchResults) error {
+	return searchServices(ctx, client, name, status, tagged, false, results)
 }
 
-func findServers(ctx context.Context, client interface{}, name, status, tag string, results *SearchResults) error {
-	return searchServers(ctx, client, name, status, tag, false, results)
+func findServers(ctx context.Context, client clientpkg.API, name, status string, tagged map[string]bool, results *SearchResults) error {
+	return searchServers(ctx, client, name, status, tagged, false, results)
 }
 
-func matchesSearch(app coolify.Application, query, status, tag string, caseSensitive bool) bool {
+func matchesSearch(app coolify.Application, query, status string, tagged map[string]bool, caseSensitive bool) bool {
 	// Search in name, description, and other fields
 	searchFields := []string{}
 
@@ -339,13 +329,12 @@ func matchesSearch(app coolify.Application, query, status, tag string, caseSensi
 	// Check status filter
 	statusMatches := status == "" || (app.Status != nil && *app.Status == status)
 
-	// Note: Tag filtering would require additional API support
-	tagMatches := tag == ""
+	tagMatches := tagged == nil || (app.Uuid != nil && tagged[*app.Uuid])
 
 	return queryMatches && statusMatches && tagMatches
 }
 
-func matchesSearchService(svc coolify.Service, query, status, tag string, caseSensitive bool) bool {
+func matchesSearchService(svc coolify.Service, query, status string, tagged map[string]bool, caseSensitive bool) bool {
 	searchFields := []string{}
 
 	if svc.Name != nil {
@@ -358,12 +347,12 @@ func matchesSearchService(svc coolify.Service, query, status, tag string, caseSe
 	queryMatches := query == "" || containsText(strings.Join(searchFields, " "), query, caseSensitive)
 	// Services don't have a status field, so status filtering is not supported
 	statusMatches := status == ""
-	tagMatches := tag == ""
+	tagMatches := tagged == nil || (svc.Uuid != nil && tagged[*svc.Uuid])
 
 	return queryMatches && statusMatches && tagMatches
 }
 
-func matchesSearchServer(srv coolify.Server, query, status, tag string, caseSensitive bool) bool {
+func matchesSearchServer(srv coolify.Server, query, status string, tagged map[string]bool, caseSensitive bool) bool {
 	searchFields := []string{}
 
 	if srv.Name != nil {
@@ -384,11 +373,28 @@ func matchesSearchServer(srv coolify.Server, query, status, tag string, caseSens
 		serverStatus = StatusValidated
 	}
 	statusMatches := status == "" || serverStatus == status
-	tagMatches := tag == ""
+	// Servers cannot be tagged
+	tagMatches := tagged == nil
 
 	return queryMatches && statusMatches && tagMatches
 }
 
+// taggedUUIDs returns the UUIDs of the resources carrying a tag, or nil when tag is empty
+func taggedUUIDs(ctx context.Context, client clientpkg.API, tag string) (map[string]bool, error) {
+	if tag == "" {
+		return nil, nil
+	}
+	resources, err := client.Tags().Resources(ctx, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter by tag: %w", err)
+	}
+	tagged := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		tagged[resource.UUID] = true
+	}
+	return tagged, nil
+}
+
 func containsText(text, query string, caseSensitive bool) bool {
 	if !caseSensitive {
 		text = strings.ToLower(text)
@@ -451,17 +457,17 @@ func (sr *SearchResults) ApplyLimit(limit int) {
 func displaySearchResults(results *SearchResults, query string) {
 	totalResults := len(results.Applications) + len(results.Services) + len(results.Servers) + len(results.Databases)
 
-	fmt.Printf("🔍 Search Results for: %s\n", query)
+	theme.Printf("🔍 Search Results for: %s\n", query)
 	fmt.Printf("====================================\n\n")
 
 	if totalResults == 0 {
-		fmt.Println("📭 No results found")
+		theme.Println("📭 No results found")
 		return
 	}
 
 	// Display Applications
 	if len(results.Applications) > 0 {
-		fmt.Printf("📱 Applications (%d)\n", len(results.Applications))
+		theme.Printf("📱 Applications (%d)\n", len(results.Applications))
 		fmt.Println("-------------------")
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 		if _, err := fmt.Fprintln(w, "UUID\tNAME\tSTATUS\tURL"); err != nil {
@@ -484,7 +490,7 @@ func displaySearchResults(results *SearchResults, query string) {
 
 	// Display Services
 	if len(results.Services) > 0 {
-		fmt.Printf("🔧 Services (%d)\n", len(results.Services))
+		theme.Printf("🔧 Services (%d)\n", len(results.Services))
 		fmt.Println("---------------")
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 		if _, err := fmt.Fprintln(w, "UUID\tNAME\tSTATUS"); err != nil {
@@ -507,7 +513,7 @@ func displaySearchResults(results *SearchResults, query string) {
 
 	// Display Servers
 	if len(results.Servers) > 0 {
-		fmt.Printf("🖥️  Servers (%d)\n", len(results.Servers))
+		theme.Printf("🖥️  Servers (%d)\n", len(results.Servers))
 		fmt.Println("-------------")
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 		if _, err := fmt.Fprintln(w, "UUID\tNAME\tIP\tSTATUS\tDESCRIPTION"); err != nil {
@@ -528,14 +534,14 @@ func displaySearchResults(results *SearchResults, query string) {
 		fmt.Println()
 	}
 
-	fmt.Printf("📊 Total: %d results\n", totalResults)
+	theme.Printf("📊 Total: %d results\n", totalResults)
 }
 
 func init() {
 	// Search command flags
 	searchCmd.Flags().StringP("type", "T", "", "Resource type to search (applications, services, servers, databases)")
 	searchCmd.Flags().String("status", "", "Filter by status")
-	searchCmd.Flags().String("tag", "", "Filter by tag")
+	searchCmd.Flags().String("tag", "", "Only include applications and services with this tag")
 	searchCmd.Flags().BoolP("case-sensitive", "c", false, "Case sensitive search")
 	searchCmd.Flags().IntP("limit", "L", 0, "Limit number of results (0 = no limit)")
 	searchCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
@@ -543,7 +549,7 @@ func init() {
 	// Find command flags
 	findCmd.Flags().StringP("name", "n", "", "Filter by name pattern (supports wildcards)")
 	findCmd.Flags().String("status", "", "Filter by status")
-	findCmd.Flags().String("tag", "", "Filter by tag")
+	findCmd.Flags().String("tag", "", "Only include applications and services with this tag")
 	findCmd.Flags().StringP("type", "T", "", "Resource type to search (applications, services, servers)")
 	findCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
 }
@@ -0,0 +1,570 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"html"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// serverProvisionSpec describes a server to provision, entered interactively or read from --from-file
+type serverProvisionSpec struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	IP          string `yaml:"ip"`
+	Port        int    `yaml:"port"`
+	User        string `yaml:"user"`
+	Proxy       string `yaml:"proxy"`
+	BuildServer bool   `yaml:"build_server"`
+	// PrivateKey is the name or UUID of an existing private key
+	PrivateKey string `yaml:"private_key"`
+	// PrivateKeyFile is a local private key uploaded to Coolify
+	PrivateKeyFile string `yaml:"private_key_file"`
+	// GenerateKey creates a new ed25519 key pair with ssh-keygen
+	GenerateKey bool `yaml:"generate_key"`
+	// CloudInitFile receives the cloud-init snippet instead of printing it
+	CloudInitFile string `yaml:"cloud_init_file"`
+	// Timeout is how long to wait for the server to become reachable (default 5m)
+	Timeout string `yaml:"timeout"`
+}
+
+// provisionedKey is the private key attached to a provisioned server
+type provisionedKey struct {
+	UUID      string
+	Name      string
+	PublicKey string
+}
+
+// serverAddWizardCmd represents the servers add-wizard command
+var serverAddWizardCmd = &cobra.Command{
+	Use:   "add-wizard",
+	Short: "Interactive server provisioning wizard",
+	Long: `Guided flow to provision a new server in one go:
+
+  1. create, upload or select the private key used to connect to the server
+  2. print a cloud-init snippet and an install command that authorize the key on the target VM
+  3. create the server record in Coolify
+  4. validate the server and wait until it is reachable and usable
+  5. print a readiness report
+
+With --from-file the same flow runs without prompts, which suits automation:
+
+  name: web-1
+  ip: 203.0.113.10
+  user: root                  # default root
+  port: 22                    # default 22
+  proxy: traefik              # traefik, caddy or none
+  build_server: false
+  private_key: deploy-key     # existing key name or UUID, or:
+  # private_key_file: ~/.ssh/id_ed25519
+  # generate_key: true
+  cloud_init_file: web-1.yaml # optional, write the cloud-init snippet to a file
+  timeout: 5m
+
+Examples:
+  coolifyme servers add-wizard
+  coolifyme servers add-wizard --from-file server.yaml`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		fromFile, _ := cmd.Flags().GetString("from-file")
+
+		var spec *serverProvisionSpec
+		var reader *bufio.Reader
+		var err error
+		if fromFile != "" {
+			spec, err = loadServerProvisionSpec(fromFile)
+		} else {
+			reader = confirm.Input()
+			spec, err = promptServerProvisionSpec(reader)
+		}
+		if err != nil {
+			return err
+		}
+		if err := normalizeServerProvisionSpec(spec); err != nil {
+			return err
+		}
+
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		if reader != nil {
+			if err := promptPrivateKeyChoice(ctx, client, reader, spec); err != nil {
+				return err
+			}
+		}
+
+		// Step 1: private key
+		theme.Println("\n🔑 Preparing private key...")
+		key, err := provisionPrivateKey(ctx, client, spec)
+		if err != nil {
+			return err
+		}
+		theme.Printf("   ✅ Using private key %s (%s)\n", key.Name, key.UUID)
+
+		// Step 2: cloud-init and install command
+		if key.PublicKey != "" {
+			if err := printServerBootstrapInstructions(spec, key.PublicKey); err != nil {
+				return err
+			}
+		} else {
+			warn("server provisioning", fmt.Errorf("the public key of %s is unknown, make sure it is authorized on the server", key.Name))
+		}
+
+		if reader != nil {
+			theme.Print("\n⏳ Press Enter once the server is running with the key authorized...")
+			_, _ = reader.ReadString('\n')
+		}
+
+		// Step 3: server record
+		theme.Println("\n🖥️  Creating server record...")
+		serverUUID, created, err := provisionServerRecord(ctx, client, spec, key.UUID)
+		if err != nil {
+			return err
+		}
+		if created {
+			theme.Printf("   ✅ Server %s created (%s)\n", spec.Name, serverUUID)
+		} else {
+			theme.Printf("   ✅ Server %s already exists (%s)\n", spec.Name, serverUUID)
+		}
+
+		// Step 4: validation
+		timeout, _ := time.ParseDuration(spec.Timeout)
+		theme.Printf("\n🩺 Validating server (waiting up to %v)...\n", timeout)
+		if _, err := client.Servers().Validate(ctx, serverUUID); err != nil {
+			return fmt.Errorf("failed to start server validation: %w", err)
+		}
+		server, waitErr := waitForServerReady(ctx, cmd, client, serverUUID, 10*time.Second, timeout, printValidationLine)
+
+		// Step 5: readiness report
+		printServerReadinessReport(spec, key, server)
+		return waitErr
+	},
+}
+
+// loadServerProvisionSpec reads a server description for non-interactive provisioning
+func loadServerProvisionSpec(path string) (*serverProvisionSpec, error) {
+	data, err := safeReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read server file: %w", err)
+	}
+
+	var spec serverProvisionSpec
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(data))), &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse server file: %w", err)
+	}
+	return &spec, nil
+}
+
+// promptServerProvisionSpec asks for the server details
+func promptServerProvisionSpec(reader *bufio.Reader) (*serverProvisionSpec, error) {
+	theme.Println("🖥️  Server Provisioning Wizard")
+	fmt.Println("=============================")
+	fmt.Println()
+
+	ask := func(prompt string) string {
+		theme.Print(prompt)
+		value, _ := reader.ReadString('\n')
+		return strings.TrimSpace(value)
+	}
+
+	spec := &serverProvisionSpec{}
+	spec.Name = ask("📛 Server name: ")
+	spec.IP = ask("🌐 Server IP address: ")
+	spec.User = ask("👤 SSH user [root]: ")
+	if port := ask("🔌 SSH port [22]: "); port != "" {
+		p, err := strconv.Atoi(port)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SSH port '%s'", port)
+		}
+		spec.Port = p
+	}
+	spec.Proxy = ask("🔧 Proxy type (traefik/caddy/none) [traefik]: ")
+	buildServer := strings.ToLower(ask("🏗️  Is build server? (y/N): "))
+	spec.BuildServer = buildServer == "y" || buildServer == ConfirmationYes
+	spec.Description = ask("📝 Description (optional): ")
+	return spec, nil
+}
+
+// promptPrivateKeyChoice lets the user pick an existing key, upload a key file or generate a new key
+func promptPrivateKeyChoice(ctx context.Context, client *clientpkg.Client, reader *bufio.Reader, spec *serverProvisionSpec) error {
+	keys, err := client.PrivateKeys().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list private keys: %w", err)
+	}
+
+	theme.Println("\n🔑 Private key:")
+	for i, key := range keys {
+		fmt.Printf("   %d) %s (%s)\n", i+1, stringOrDash(key.Name), stringOrDash(key.Uuid))
+	}
+	fmt.Println("   g) Generate a new key")
+	fmt.Println("   f) Upload a key file")
+	theme.Print("Choice [g]: ")
+
+	choice, _ := reader.ReadString('\n')
+	choice = strings.TrimSpace(strings.ToLower(choice))
+	switch choice {
+	case "", "g":
+		spec.GenerateKey = true
+	case "f":
+		theme.Print("📁 Private key file [~/.ssh/id_ed25519]: ")
+		path, _ := reader.ReadString('\n')
+		spec.PrivateKeyFile = strings.TrimSpace(path)
+		if spec.PrivateKeyFile == "" {
+			spec.PrivateKeyFile = "~/.ssh/id_ed25519"
+		}
+	default:
+		index, err := strconv.Atoi(choice)
+		if err != nil || index < 1 || index > len(keys) || keys[index-1].Uuid == nil {
+			return fmt.Errorf("invalid choice '%s'", choice)
+		}
+		spec.PrivateKey = *keys[index-1].Uuid
+	}
+	return nil
+}
+
+// normalizeServerProvisionSpec applies defaults and checks the required fields
+func normalizeServerProvisionSpec(spec *serverProvisionSpec) error {
+	if spec.Name == "" {
+		return fmt.Errorf("server name is required")
+	}
+	if spec.IP == "" {
+		return fmt.Errorf("server IP is required")
+	}
+	if spec.User == "" {
+		spec.User = "root"
+	}
+	if spec.Port == 0 {
+		spec.Port = 22
+	}
+	if spec.Proxy == "" {
+		spec.Proxy = "traefik"
+	}
+	if spec.Timeout == "" {
+		spec.Timeout = "5m"
+	}
+	if _, err := time.ParseDuration(spec.Timeout); err != nil {
+		return fmt.Errorf("invalid timeout '%s': %w", spec.Timeout, err)
+	}
+
+	sources := 0
+	for _, set := range []bool{spec.PrivateKey != "", spec.PrivateKeyFile != "", spec.GenerateKey} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Errorf("set only one of private_key, private_key_file or generate_key")
+	}
+	return nil
+}
+
+// provisionPrivateKey selects, uploads or generates the private key of the server
+func provisionPrivateKey(ctx context.Context, client *clientpkg.Client, spec *serverProvisionSpec) (*provisionedKey, error) {
+	if spec.PrivateKey != "" {
+		keys, err := client.PrivateKeys().List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list private keys: %w", err)
+		}
+		for _, key := range keys {
+			if matchesResource(spec.PrivateKey, stringOrDash(key.Name), stringOrDash(key.Uuid)) {
+				found := &provisionedKey{UUID: stringOrDash(key.Uuid), Name: stringOrDash(key.Name)}
+				if key.PublicKey != nil {
+					found.PublicKey = strings.TrimSpace(*key.PublicKey)
+				}
+				return found, nil
+			}
+		}
+		return nil, fmt.Errorf("private key '%s' not found", spec.PrivateKey)
+	}
+
+	var privateKey, publicKey string
+	switch {
+	case spec.PrivateKeyFile != "":
+		path := expandHomePath(spec.PrivateKeyFile)
+		data, err := os.ReadFile(path) // #nosec G304 - user-provided key file
+		if err != nil {
+			return nil, fmt.Errorf("failed to read private key: %w", err)
+		}
+		privateKey = string(data)
+		if data, err := os.ReadFile(path + ".pub"); err == nil { // #nosec G304 - derived from user-provided key file
+			publicKey = strings.TrimSpace(string(data))
+		}
+	case spec.GenerateKey:
+		var err error
+		privateKey, publicKey, err = generateSSHKey("coolify-" + spec.Name)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("a private key is required (private_key, private_key_file or generate_key)")
+	}
+
+	name := "coolify-" + spec.Name
+	description := "Created by coolifyme for server " + spec.Name
+	uuid, err := client.PrivateKeys().Create(ctx, coolify.CreatePrivateKeyJSONRequestBody{
+		Name:        &name,
+		Description: &description,
+		PrivateKey:  privateKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create private key: %w", err)
+	}
+
+	key := &provisionedKey{UUID: uuid, Name: name, PublicKey: publicKey}
+	if key.PublicKey == "" {
+		// Coolify derives the public key when the key is stored
+		if stored, err := client.PrivateKeys().Get(ctx, uuid); err == nil && stored.PublicKey != nil {
+			key.PublicKey = strings.TrimSpace(*stored.PublicKey)
+		}
+	}
+	return key, nil
+}
+
+// generateSSHKey creates an ed25519 key pair with ssh-keygen and returns both halves
+func generateSSHKey(comment string) (string, string, error) {
+	dir, err := os.MkdirTemp("", "coolifyme-key-")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "id_ed25519")
+	keygen := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", comment, "-f", path) // #nosec G204 -- fixed program
+	if output, err := keygen.CombinedOutput(); err != nil {
+		return "", "", fmt.Errorf("ssh-keygen failed: %v: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	privateKey, err := os.ReadFile(path) // #nosec G304 - file created above
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read generated key: %w", err)
+	}
+	publicKey, err := os.ReadFile(path + ".pub") // #nosec G304 - file created above
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read generated key: %w", err)
+	}
+	return string(privateKey), strings.TrimSpace(string(publicKey)), nil
+}
+
+// serverCloudInit returns a cloud-init snippet authorizing the public key for the SSH user
+func serverCloudInit(user, publicKey string) string {
+	var b strings.Builder
+	b.WriteString("#cloud-config\n")
+	b.WriteString("package_update: true\n")
+	b.WriteString("packages:\n  - curl\n  - wget\n  - git\n  - jq\n  - openssl\n")
+	if user == "root" {
+		b.WriteString("disable_root: false\n")
+		b.WriteString("users:\n  - name: root\n")
+	} else {
+		b.WriteString("users:\n  - default\n")
+		fmt.Fprintf(&b, "  - name: %s\n", user)
+		b.WriteString("    sudo: ALL=(ALL) NOPASSWD:ALL\n")
+		b.WriteString("    groups: [docker]\n")
+		b.WriteString("    shell: /bin/bash\n")
+	}
+	b.WriteString("    ssh_authorized_keys:\n")
+	fmt.Fprintf(&b, "      - %s\n", publicKey)
+	return b.String()
+}
+
+// printServerBootstrapInstructions prints or writes the cloud-init snippet and the install command
+func printServerBootstrapInstructions(spec *serverProvisionSpec, publicKey string) error {
+	cloudInit := serverCloudInit(spec.User, publicKey)
+
+	if spec.CloudInitFile != "" {
+		if err := os.WriteFile(spec.CloudInitFile, []byte(cloudInit), 0o600); err != nil {
+			return fmt.Errorf("failed to write cloud-init file: %w", err)
+		}
+		theme.Printf("\n📄 Cloud-init snippet written to %s, use it as user data for a new VM\n", spec.CloudInitFile)
+	} else {
+		theme.Println("\n📄 Cloud-init snippet for a new VM (use it as user data):")
+		fmt.Println(cloudInit)
+	}
+
+	theme.Println("📋 Or authorize the key on an existing server:")
+	fmt.Printf("   ssh -p %d %s@%s \"mkdir -p ~/.ssh && chmod 700 ~/.ssh && echo '%s' >> ~/.ssh/authorized_keys && chmod 600 ~/.ssh/authorized_keys\"\n",
+		spec.Port, spec.User, spec.IP, publicKey)
+	return nil
+}
+
+// provisionServerRecord creates the server in Coolify, reusing an existing server with the same name
+func provisionServerRecord(ctx context.Context, client *clientpkg.Client, spec *serverProvisionSpec, keyUUID string) (string, bool, error) {
+	servers, err := client.Servers().List(ctx)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to list servers: %w", err)
+	}
+	for _, server := range servers {
+		if server.Name != nil && *server.Name == spec.Name && server.Uuid != nil {
+			return *server.Uuid, false, nil
+		}
+	}
+
+	name, description, ip, user, port := spec.Name, spec.Description, spec.IP, spec.User, spec.Port
+	req := coolify.CreateServerJSONRequestBody{
+		Name:           &name,
+		Description:    &description,
+		Ip:             &ip,
+		User:           &user,
+		Port:           &port,
+		PrivateKeyUuid: &keyUUID,
+	}
+	if spec.BuildServer {
+		req.IsBuildServer = &spec.BuildServer
+	}
+	if spec.Proxy != "none" {
+		proxyType := coolify.CreateServerJSONBodyProxyType(spec.Proxy)
+		req.ProxyType = &proxyType
+	}
+
+	uuid, err := client.Servers().Create(ctx, req)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create server: %w", err)
+	}
+	return uuid, true, nil
+}
+
+// validationSettlePolls is the number of polls with unchanged validation logs after which an
+// unreachable server is considered to have failed validation, and the number of polls a server
+// that was already ready must stay ready to pass
+const validationSettlePolls = 3
+
+// waitForServerReady polls the server until Coolify reports it reachable and usable, passing
+// new validation log lines to onLine when it is set. It gives up when the timeout expires or when an unreachable server's
+// validation logs stop changing, and fails at once when the response lacks the validation flags.
+func waitForServerReady(ctx context.Context, cmd *cobra.Command, client *clientpkg.Client, serverUUID string, interval, timeout time.Duration, onLine func(string)) (*coolify.Server, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var server *coolify.Server
+	var previous []string
+	ready, settled, stable := false, false, 0
+	// Flags left over from an earlier validation only count once they held for a few polls,
+	// unless they were seen turning ready during this validation
+	readyPolls, sawNotReady := 0, false
+	err := WatchLoop(ctx, getWatchConfig(cmd, interval), func(ctx context.Context) (bool, error) {
+		current, err := client.Servers().Get(ctx, serverUUID)
+		if err != nil {
+			return false, err
+		}
+		server = current
+		// Without the flags the outcome is unknown, which must not pass as a validated server
+		if !serverValidationFlags(current) {
+			return true, fmt.Errorf("the server response has no settings.is_reachable and settings.is_usable flags, so the validation result is unknown")
+		}
+
+		lines := validationLogLines(current)
+		added := newLogLines(previous, lines)
+		if onLine != nil {
+			for _, line := range added {
+				onLine(line)
+			}
+		}
+		if len(added) == 0 && len(lines) > 0 {
+			stable++
+		} else {
+			stable = 0
+		}
+		previous = lines
+
+		ready = serverReachable(current) && serverUsable(current)
+		if ready {
+			readyPolls++
+		} else {
+			readyPolls, sawNotReady = 0, true
+		}
+		settled = !ready && !serverReachable(current) && stable >= validationSettlePolls
+		return (ready && (sawNotReady || readyPolls >= validationSettlePolls)) || settled, nil
+	})
+	switch {
+	case err != nil:
+		return server, err
+	case ready:
+		return server, nil
+	case settled:
+		return server, fmt.Errorf("server validation failed: server is not reachable")
+	}
+	return server, fmt.Errorf("server did not become ready within %v", timeout)
+}
+
+// printValidationLine prints a streamed validation log line
+func printValidationLine(line string) {
+	fmt.Printf("   %s\n", line)
+}
+
+// validationTags matches the HTML tags Coolify puts in validation logs
+var validationTags = regexp.MustCompile(`<[^>]+>`)
+
+// validationLogLines returns the validation logs of a server as plain text lines
+func validationLogLines(server *coolify.Server) []string {
+	if server == nil || server.ValidationLogs == nil {
+		return nil
+	}
+	text := strings.NewReplacer("<br>", "\n", "<br/>", "\n", "<br />", "\n").Replace(*server.ValidationLogs)
+	text = html.UnescapeString(validationTags.ReplaceAllString(text, ""))
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// serverReachable reports whether Coolify can reach a server over SSH
+func serverReachable(server *coolify.Server) bool {
+	return server != nil && server.Settings != nil && server.Settings.IsReachable != nil && *server.Settings.IsReachable
+}
+
+// serverValidationFlags reports whether a server response includes the flags set by validation
+func serverValidationFlags(server *coolify.Server) bool {
+	return server != nil && server.Settings != nil && server.Settings.IsReachable != nil && server.Settings.IsUsable != nil
+}
+
+// serverUsable reports whether a server passed validation, including its Docker installation
+func serverUsable(server *coolify.Server) bool {
+	return server != nil && server.Settings != nil && server.Settings.IsUsable != nil && *server.Settings.IsUsable
+}
+
+// printServerReadinessReport prints the final state of a provisioned server
+func printServerReadinessReport(spec *serverProvisionSpec, key *provisionedKey, server *coolify.Server) {
+	check := func(ok bool) string {
+		if ok {
+			return "✅"
+		}
+		return "❌"
+	}
+
+	theme.Println("\n📋 Readiness Report")
+	fmt.Println("==================")
+	theme.Printf("   %s Private key: %s (%s)\n", check(key.UUID != ""), key.Name, key.UUID)
+	theme.Printf("   %s Server record: %s (%s:%d as %s)\n", check(server != nil), spec.Name, spec.IP, spec.Port, spec.User)
+	theme.Printf("   %s Reachable over SSH\n", check(serverReachable(server)))
+	theme.Printf("   %s Usable (Docker installed and validated)\n", check(serverUsable(server)))
+	theme.Printf("   🔧 Proxy: %s\n", spec.Proxy)
+
+	if serverReachable(server) && serverUsable(server) {
+		theme.Printf("\n🎉 Server %s is ready for deployments\n", spec.Name)
+	}
+}
+
+func init() {
+	// Flags for add-wizard command
+	serverAddWizardCmd.Flags().String("from-file", "", "Provision non-interactively from a YAML server description")
+	addWatchFlags(serverAddWizardCmd)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// serverSSH runs ssh against a server with the address and user Coolify has for it
+type serverSSH struct {
+	Target *clientpkg.SSHTarget
+	// Host is the user@address argument for ssh
+	Host string
+	// Args are the ssh options selecting the key and port
+	Args    []string
+	cleanup func()
+}
+
+// sshKeyOptions select the private key used to connect to a server
+type sshKeyOptions struct {
+	// Identity is a local private key file
+	Identity string
+	// UseServerKey fetches the private key attached to the server in Coolify
+	UseServerKey bool
+}
+
+// addSSHKeyFlags adds the flags selecting the private key of commands connecting to servers
+func addSSHKeyFlags(cmd *cobra.Command) {
+	cmd.Flags().String("identity", "", "Local SSH private key (default: the keys of your SSH agent and configuration)")
+	cmd.Flags().Bool("use-server-key", false, "Fetch the private key attached to the server in Coolify through the API and connect with it")
+	cmd.MarkFlagsMutuallyExclusive("identity", "use-server-key")
+}
+
+// getSSHKeyOptions returns the private key selected by the flags of addSSHKeyFlags
+func getSSHKeyOptions(cmd *cobra.Command) sshKeyOptions {
+	identity, _ := cmd.Flags().GetString("identity")
+	useServerKey, _ := cmd.Flags().GetBool("use-server-key")
+	return sshKeyOptions{Identity: identity, UseServerKey: useServerKey}
+}
+
+// openServerSSH prepares ssh access to a server. With key.UseServerKey the private key attached
+// to the server is fetched through the API and written to a temporary file; otherwise ssh uses
+// key.Identity or the keys of the local SSH agent and configuration. Host keys are checked
+// against known_hosts as ssh is configured to. Extra ssh options are appended to the defaults.
+// Close removes the temporary key.
+func openServerSSH(ctx context.Context, client *clientpkg.Client, serverUUID string, key sshKeyOptions, options ...string) (*serverSSH, error) {
+	target, err := client.Servers().SSHTarget(ctx, serverUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &serverSSH{Target: target, Host: target.User + "@" + target.Host, cleanup: func() {}}
+	identity := ""
+	switch {
+	case key.Identity != "":
+		identity = expandHomePath(key.Identity)
+	case key.UseServerKey:
+		path, cleanup, err := writeServerPrivateKey(ctx, client, target)
+		if err != nil {
+			return nil, err
+		}
+		identity, conn.cleanup = path, cleanup
+	}
+
+	conn.Args = []string{"-p", strconv.Itoa(target.Port)}
+	if identity != "" {
+		conn.Args = append(conn.Args, "-i", identity, "-o", "IdentitiesOnly=yes")
+	}
+	conn.Args = append(conn.Args, options...)
+	return conn, nil
+}
+
+// Close removes the temporary private key
+func (s *serverSSH) Close() {
+	s.cleanup()
+}
+
+// Command returns an ssh command running remote on the server
+func (s *serverSSH) Command(ctx context.Context, remote string) *exec.Cmd {
+	return exec.CommandContext(ctx, "ssh", append(append([]string{}, s.Args...), s.Host, remote)...) // #nosec G204 -- arguments are passed to ssh, not a local shell
+}
+
+// Output runs remote on the server and returns its stdout. Failures carry the remote stderr.
+func (s *serverSSH) Output(ctx context.Context, remote string) ([]byte, error) {
+	output, err := s.Command(ctx, remote).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, errors.New(strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+	return output, nil
+}
+
+// serviceServerUUID returns the UUID of the server a service runs on
+func serviceServerUUID(ctx context.Context, client *clientpkg.Client, service *coolify.Service) (string, error) {
+	if service.ServerId == nil {
+		return "", fmt.Errorf("cannot determine the server of service %s", stringOrDash(service.Name))
+	}
+
+	servers, err := client.Servers().List(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list servers: %w", err)
+	}
+	for _, server := range servers {
+		if server.Id != nil && *server.Id == *service.ServerId && server.Uuid != nil {
+			return *server.Uuid, nil
+		}
+	}
+	return "", fmt.Errorf("cannot determine the server of service %s", stringOrDash(service.Name))
+}
+
+// refuseReadOnly returns an error for a change made outside the API, e.g. over SSH, when the
+// client is read-only
+func refuseReadOnly(client *clientpkg.Client, action string) error {
+	if client.ReadOnly() {
+		return &clientpkg.ReadOnlyError{Method: "SSH", Path: action}
+	}
+	return nil
+}
@@ -7,8 +7,12 @@ import (
 	"os"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
 	"github.com/spf13/cobra"
 )
 
@@ -48,6 +52,10 @@ var serversListCmd = &cobra.Command{
 			return nil
 		}
 
+		if handled, err := outputWithTemplate(cmd, servers); handled {
+			return err
+		}
+
 		if len(servers) == 0 {
 			fmt.Println("No servers found")
 			return nil
@@ -121,98 +129,124 @@ var serversCreateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		// Get flag values
-		name, _ := cmd.Flags().GetString("name")
-		description, _ := cmd.Flags().GetString("description")
-		ip, _ := cmd.Flags().GetString("ip")
-		user, _ := cmd.Flags().GetString("user")
-		port, _ := cmd.Flags().GetInt32("port")
-		privateKeyUUID, _ := cmd.Flags().GetString("private-key-uuid")
-		isBuildServer, _ := cmd.Flags().GetBool("is-build-server")
-		instantValidate, _ := cmd.Flags().GetBool("instant-validate")
-		proxyType, _ := cmd.Flags().GetString("proxy-type")
-
-		// Validate required fields
-		if name == "" {
-			return fmt.Errorf("server name is required (--name)")
-		}
-		if ip == "" {
-			return fmt.Errorf("server IP is required (--ip)")
-		}
-		if user == "" {
-			return fmt.Errorf("server user is required (--user)")
-		}
-		if privateKeyUUID == "" {
-			return fmt.Errorf("private key UUID is required (--private-key-uuid)")
-		}
+		req := coolify.CreateServerJSONRequestBody{}
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
+		}
+		if !fromFile {
+			// Get flag values
+			name, _ := cmd.Flags().GetString("name")
+			description, _ := cmd.Flags().GetString("description")
+			ip, _ := cmd.Flags().GetString("ip")
+			user, _ := cmd.Flags().GetString("user")
+			port, _ := cmd.Flags().GetInt32("port")
+			privateKeyUUID, _ := cmd.Flags().GetString("private-key-uuid")
+			isBuildServer, _ := cmd.Flags().GetBool("is-build-server")
+			instantValidate, _ := cmd.Flags().GetBool("instant-validate")
+			proxyType, _ := cmd.Flags().GetString("proxy-type")
+
+			// Validate required fields
+			if name == "" {
+				return fmt.Errorf("server name is required (--name)")
+			}
+			if ip == "" {
+				return fmt.Errorf("server IP is required (--ip)")
+			}
+			if user == "" {
+				return fmt.Errorf("server user is required (--user)")
+			}
+			if privateKeyUUID == "" {
+				return fmt.Errorf("private key UUID is required (--private-key-uuid)")
+			}
 
-		// Validate proxy type if provided
-		if proxyType != "" {
-			validProxyTypes := []string{"traefik", "caddy", "none"}
-			isValid := false
-			for _, valid := range validProxyTypes {
-				if proxyType == valid {
-					isValid = true
-					break
+			// Validate proxy type if provided
+			if proxyType != "" {
+				validProxyTypes := []string{"traefik", "caddy", "none"}
+				isValid := false
+				for _, valid := range validProxyTypes {
+					if proxyType == valid {
+						isValid = true
+						break
+					}
+				}
+				if !isValid {
+					return fmt.Errorf("invalid proxy type: %s. Valid options: %s", proxyType, strings.Join(validProxyTypes, ", "))
 				}
 			}
-			if !isValid {
-				return fmt.Errorf("invalid proxy type: %s. Valid options: %s", proxyType, strings.Join(validProxyTypes, ", "))
-			}
-		}
 
-		// Create request body
-		portInt := int(port)
-		req := coolify.CreateServerJSONRequestBody{
-			Name:           &name,
-			Description:    &description,
-			Ip:             &ip,
-			User:           &user,
-			Port:           &portInt,
-			PrivateKeyUuid: &privateKeyUUID,
-		}
+			// Create request body
+			portInt := int(port)
+			req = coolify.CreateServerJSONRequestBody{
+				Name:           &name,
+				Description:    &description,
+				Ip:             &ip,
+				User:           &user,
+				Port:           &portInt,
+				PrivateKeyUuid: &privateKeyUUID,
+			}
 
-		// Add optional fields if they have specific values
-		if isBuildServer {
-			req.IsBuildServer = &isBuildServer
-		}
-		if instantValidate {
-			req.InstantValidate = &instantValidate
-		}
-		if proxyType != "" {
-			// Convert string to proper enum type
-			var proxyTypeEnum coolify.CreateServerJSONBodyProxyType
-			switch proxyType {
-			case ProxyTraefik:
-				proxyTypeEnum = coolify.CreateServerJSONBodyProxyTypeTraefik
-			case "caddy":
-				proxyTypeEnum = coolify.CreateServerJSONBodyProxyTypeCaddy
-			case "none":
-				proxyTypeEnum = coolify.CreateServerJSONBodyProxyTypeNone
+			// Add optional fields if they have specific values
+			if isBuildServer {
+				req.IsBuildServer = &isBuildServer
+			}
+			if instantValidate {
+				req.InstantValidate = &instantValidate
+			}
+			if proxyType != "" {
+				// Convert string to proper enum type
+				var proxyTypeEnum coolify.CreateServerJSONBodyProxyType
+				switch proxyType {
+				case ProxyTraefik:
+					proxyTypeEnum = coolify.CreateServerJSONBodyProxyTypeTraefik
+				case "caddy":
+					proxyTypeEnum = coolify.CreateServerJSONBodyProxyTypeCaddy
+				case "none":
+					proxyTypeEnum = coolify.CreateServerJSONBodyProxyTypeNone
+				}
+				req.ProxyType = &proxyTypeEnum
 			}
-			req.ProxyType = &proxyTypeEnum
 		}
 
 		ctx := context.Background()
 
+		existing, err := existingResource(ctx, cmd, client, clientpkg.KindServer, req)
+		if err != nil {
+			return err
+		}
+		if existing != "" {
+			printExistingResource(clientpkg.KindServer, requestName(req), existing)
+			return nil
+		}
+
+		ctx, guard := beginCreate(ctx, client, "server", req)
 		uuid, err := client.Servers().Create(ctx, req)
+		guard.finish(err)
 		if err != nil {
 			return fmt.Errorf("failed to create server: %w", err)
 		}
 
-		fmt.Printf("✅ Server created successfully\n")
-		fmt.Printf("   📛 Name: %s\n", name)
-		fmt.Printf("   📦 UUID: %s\n", uuid)
-		fmt.Printf("   🌐 IP: %s:%d\n", ip, port)
-		fmt.Printf("   👤 User: %s\n", user)
-		if proxyType != "" {
-			fmt.Printf("   🔧 Proxy: %s\n", proxyType)
+		if printQuietUUID(uuid) {
+			return nil
+		}
+
+		port := 22
+		if req.Port != nil {
+			port = *req.Port
 		}
-		if isBuildServer {
-			fmt.Printf("   🏗️  Build Server: Yes\n")
+		theme.Statusf("✅ Server created successfully\n")
+		theme.Statusf("   📛 Name: %s\n", stringOrDash(req.Name))
+		theme.Printf("   📦 UUID: %s\n", uuid)
+		theme.Statusf("   🌐 IP: %s:%d\n", stringOrDash(req.Ip), port)
+		theme.Statusf("   👤 User: %s\n", stringOrDash(req.User))
+		if req.ProxyType != nil {
+			theme.Statusf("   🔧 Proxy: %s\n", *req.ProxyType)
 		}
-		if instantValidate {
-			fmt.Printf("   ⚡ Instant Validate: Yes\n")
+		if req.IsBuildServer != nil && *req.IsBuildServer {
+			theme.Statusf("   🏗️  Build Server: Yes\n")
+		}
+		if req.InstantValidate != nil && *req.InstantValidate {
+			theme.Statusf("   ⚡ Instant Validate: Yes\n")
 		}
 		return nil
 	},
@@ -249,59 +283,59 @@ var serversGetCmd = &cobra.Command{
 		}
 
 		// Display server details
-		fmt.Printf("📄 Server Details\n")
+		theme.Printf("📄 Server Details\n")
 		fmt.Printf("================\n")
 
 		if server.Uuid != nil {
-			fmt.Printf("📦 UUID: %s\n", *server.Uuid)
+			theme.Printf("📦 UUID: %s\n", *server.Uuid)
 		}
 		if server.Name != nil {
-			fmt.Printf("📛 Name: %s\n", *server.Name)
+			theme.Printf("📛 Name: %s\n", *server.Name)
 		}
 		if server.Description != nil && *server.Description != "" {
-			fmt.Printf("📝 Description: %s\n", *server.Description)
+			theme.Printf("📝 Description: %s\n", *server.Description)
 		}
 		if server.Ip != nil {
-			fmt.Printf("🌐 IP: %s\n", *server.Ip)
+			theme.Printf("🌐 IP: %s\n", *server.Ip)
 		}
 		if server.User != nil {
-			fmt.Printf("👤 User: %s\n", *server.User)
+			theme.Printf("👤 User: %s\n", *server.User)
 		}
 		if server.Port != nil {
-			fmt.Printf("🔌 Port: %d\n", *server.Port)
+			theme.Printf("🔌 Port: %d\n", *server.Port)
 		}
 
 		// Display proxy type from the direct field
 		if server.ProxyType != nil {
-			fmt.Printf("🔧 Proxy Type: %s\n", string(*server.ProxyType))
+			theme.Printf("🔧 Proxy Type: %s\n", string(*server.ProxyType))
 		}
 
 		// Display build server setting from the Settings field
 		if server.Settings != nil && server.Settings.IsBuildServer != nil && *server.Settings.IsBuildServer {
-			fmt.Printf("🏗️  Build Server: Yes\n")
+			theme.Printf("🏗️  Build Server: Yes\n")
 		}
 
 		// Display validation status
 		if server.ValidationLogs != nil {
-			fmt.Printf("✅ Status: Validated\n")
+			theme.Printf("✅ Status: Validated\n")
 		} else {
-			fmt.Printf("⚠️  Status: Not validated\n")
+			theme.Printf("⚠️  Status: Not validated\n")
 		}
 
 		// Display additional server information
 		if server.Settings != nil {
 			if server.Settings.IsReachable != nil {
 				if *server.Settings.IsReachable {
-					fmt.Printf("📡 Reachable: Yes\n")
+					theme.Printf("📡 Reachable: Yes\n")
 				} else {
-					fmt.Printf("📡 Reachable: No\n")
+					theme.Printf("📡 Reachable: No\n")
 				}
 			}
 			if server.Settings.IsUsable != nil {
 				if *server.Settings.IsUsable {
-					fmt.Printf("⚡ Usable: Yes\n")
+					theme.Printf("⚡ Usable: Yes\n")
 				} else {
-					fmt.Printf("⚡ Usable: No\n")
+					theme.Printf("⚡ Usable: No\n")
 				}
 			}
 		}
@@ -324,72 +358,77 @@ var serversUpdateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		// Get flag values
-		name, _ := cmd.Flags().GetString("name")
-		description, _ := cmd.Flags().GetString("description")
-		ip, _ := cmd.Flags().GetString("ip")
-		user, _ := cmd.Flags().GetString("user")
-		port, _ := cmd.Flags().GetInt32("port")
-		privateKeyUUID, _ := cmd.Flags().GetString("private-key-uuid")
-		isBuildServer, _ := cmd.Flags().GetBool("is-build-server")
-		instantValidate, _ := cmd.Flags().GetBool("instant-validate")
-		proxyType, _ := cmd.Flags().GetString("proxy-type")
-
-		// Validate proxy type if provided
-		if cmd.Flags().Changed("proxy-type") && proxyType != "" {
-			validProxyTypes := []string{"traefik", "caddy", "none"}
-			isValid := false
-			for _, valid := range validProxyTypes {
-				if proxyType == valid {
-					isValid = true
-					break
+		req := coolify.UpdateServerByUuidJSONRequestBody{}
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
+		}
+		if !fromFile {
+			// Get flag values
+			name, _ := cmd.Flags().GetString("name")
+			description, _ := cmd.Flags().GetString("description")
+			ip, _ := cmd.Flags().GetString("ip")
+			user, _ := cmd.Flags().GetString("user")
+			port, _ := cmd.Flags().GetInt32("port")
+			privateKeyUUID, _ := cmd.Flags().GetString("private-key-uuid")
+			isBuildServer, _ := cmd.Flags().GetBool("is-build-server")
+			instantValidate, _ := cmd.Flags().GetBool("instant-validate")
+			proxyType, _ := cmd.Flags().GetString("proxy-type")
+
+			// Validate proxy type if provided
+			if cmd.Flags().Changed("proxy-type") && proxyType != "" {
+				validProxyTypes := []string{"traefik", "caddy", "none"}
+				isValid := false
+				for _, valid := range validProxyTypes {
+					if proxyType == valid {
+						isValid = true
+						break
+					}
+				}
+				if !isValid {
+					return fmt.Errorf("invalid proxy type: %s. Valid options: %s", proxyType, strings.Join(validProxyTypes, ", "))
 				}
 			}
-			if !isValid {
-				return fmt.Errorf("invalid proxy type: %s. Valid options: %s", proxyType, strings.Join(validProxyTypes, ", "))
-			}
-		}
-
-		// Create request body with only provided values
-		req := coolify.UpdateServerByUuidJSONRequestBody{}
 
-		if name != "" {
-			req.Name = &name
-		}
-		if description != "" {
-			req.Description = &description
-		}
-		if ip != "" {
-			req.Ip = &ip
-		}
-		if user != "" {
-			req.User = &user
-		}
-		if cmd.Flags().Changed("port") {
-			portInt := int(port)
-			req.Port = &portInt
-		}
-		if privateKeyUUID != "" {
-			req.PrivateKeyUuid = &privateKeyUUID
-		}
-		if cmd.Flags().Changed("is-build-server") {
-			req.IsBuildServer = &isBuildServer
-		}
-		if cmd.Flags().Changed("instant-validate") {
-			req.InstantValidate = &instantValidate
-		}
-		if cmd.Flags().Changed("proxy-type") && proxyType != "" {
-			// Convert string to proper enum type
-			var proxyTypeEnum coolify.UpdateServerByUuidJSONBodyProxyType
-			switch proxyType {
-			case "traefik":
-				proxyTypeEnum = coolify.Traefik
-			case "caddy":
-				proxyTypeEnum = coolify.Caddy
-			case "none":
-				proxyTypeEnum = coolify.None
+			// Set only the provided values
+			if name != "" {
+				req.Name = &name
+			}
+			if description != "" {
+				req.Description = &description
+			}
+			if ip != "" {
+				req.Ip = &ip
+			}
+			if user != "" {
+				req.User = &user
+			}
+			if cmd.Flags().Changed("port") {
+				portInt := int(port)
+				req.Port = &portInt
+			}
+			if privateKeyUUID != "" {
+				req.PrivateKeyUuid = &privateKeyUUID
+			}
+			if cmd.Flags().Changed("is-build-server") {
+				req.IsBuildServer = &isBuildServer
+			}
+			if cmd.Flags().Changed("instant-validate") {
+				req.InstantValidate = &instantValidate
+			}
+			if cmd.Flags().Changed("proxy-type") && proxyType != "" {
+				// Convert string to proper enum type
+				var proxyTypeEnum coolify.UpdateServerByUuidJSONBodyProxyType
+				switch proxyType {
+				case "traefik":
+					proxyTypeEnum = coolify.Traefik
+				case "caddy":
+					proxyTypeEnum = coolify.Caddy
+				case "none":
+					proxyTypeEnum = coolify.None
+				}
+				req.ProxyType = &proxyTypeEnum
 			}
-			req.ProxyType = &proxyTypeEnum
 		}
 
 		ctx := context.Background()
@@ -400,15 +439,15 @@ var serversUpdateCmd = &cobra.Command{
 			return fmt.Errorf("failed to update server: %w", err)
 		}
 
-		fmt.Printf("✅ Server updated successfully\n")
+		theme.Printf("✅ Server updated successfully\n")
 		if server.Uuid != nil {
-			fmt.Printf("   📦 UUID: %s\n", *server.Uuid)
+			theme.Printf("   📦 UUID: %s\n", *server.Uuid)
 		}
 		if server.Name != nil {
-			fmt.Printf("   📛 Name: %s\n", *server.Name)
+			theme.Printf("   📛 Name: %s\n", *server.Name)
 		}
 		if server.Ip != nil && server.Port != nil {
-			fmt.Printf("   🌐 IP: %s:%d\n", *server.Ip, *server.Port)
+			theme.Printf("   🌐 IP: %s:%d\n", *server.Ip, *server.Port)
 		}
 		return nil
 	},
@@ -425,27 +464,34 @@ var serversDeleteCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
+		if err := checkTokenAbility(cmd, client, clientpkg.AbilityWrite); err != nil {
+			return err
+		}
 
 		ctx := context.Background()
 		serverUUID := args[0]
-		force, _ := cmd.Flags().GetBool("force")
+		force := skipConfirmation(cmd)
 
+		target := confirm.Target{Kind: "server", ID: serverUUID}
 		if !force {
-			fmt.Printf("⚠️  Are you sure you want to delete server %s? This action cannot be undone.\n", serverUUID)
-			fmt.Print("Type 'yes' to confirm: ")
-			var confirmation string
-			if _, err := fmt.Scanln(&confirmation); err != nil || confirmation != ConfirmationYes {
-				fmt.Println("❌ Deletion cancelled")
-				return nil
+			if server, err := client.Servers().Get(ctx, serverUUID); err == nil && server.Name != nil {
+				target.Name = *server.Name
+			}
+			if resources, err := client.Servers().GetResources(ctx, serverUUID); err == nil {
+				target.Dependents = serverResourceNames(resources)
 			}
 		}
+		if !confirm.Delete(target, force) {
+			theme.Println("❌ Deletion cancelled")
+			return nil
+		}
 
 		err = client.Servers().Delete(ctx, serverUUID)
 		if err != nil {
 			return fmt.Errorf("failed to delete server: %w", err)
 		}
 
-		fmt.Printf("✅ Server %s deleted successfully\n", serverUUID)
+		theme.Printf("✅ Server %s deleted successfully\n", serverUUID)
 		return nil
 	},
 }
@@ -480,7 +526,7 @@ var serversGetResourcesCmd = &cobra.Command{
 		var resourceData interface{}
 		if err := json.Unmarshal([]byte(resources), &resourceData); err != nil {
 			// If parsing fails, just display the raw response
-			fmt.Printf("📊 Server Resources\n")
+			theme.Printf("📊 Server Resources\n")
 			fmt.Printf("==================\n")
 			fmt.Printf("%s\n", resources)
 			return nil
@@ -489,19 +535,41 @@ var serversGetResourcesCmd = &cobra.Command{
 		// Pretty print the JSON
 		prettyJSON, err := json.MarshalIndent(resourceData, "", "  ")
 		if err != nil {
-			fmt.Printf("📊 Server Resources\n")
+			theme.Printf("📊 Server Resources\n")
 			fmt.Printf("==================\n")
 			fmt.Printf("%s\n", resources)
 			return nil
 		}
 
-		fmt.Printf("📊 Server Resources\n")
+		theme.Printf("📊 Server Resources\n")
 		fmt.Printf("==================\n")
 		fmt.Printf("%s\n", string(prettyJSON))
 		return nil
 	},
 }
 
+// serverResourceNames lists the resources running on a server from its resources JSON
+func serverResourceNames(resources string) []string {
+	var items []map[string]interface{}
+	if err := json.Unmarshal([]byte(resources), &items); err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		kind, _ := item["type"].(string)
+		name, _ := item["name"].(string)
+		if name == "" {
+			name, _ = item["uuid"].(string)
+		}
+		if kind == "" {
+			kind = "resource"
+		}
+		names = append(names, fmt.Sprintf("%s '%s'", kind, name))
+	}
+	return names
+}
+
 // serversGetDomainsCmd represents the servers get-domains command
 var serversGetDomainsCmd = &cobra.Command{
 	Use:   "get-domains <uuid>",
@@ -532,7 +600,7 @@ var serversGetDomainsCmd = &cobra.Command{
 		var domainData interface{}
 		if err := json.Unmarshal([]byte(domains), &domainData); err != nil {
 			// If parsing fails, just display the raw response
-			fmt.Printf("🌐 Server Domains\n")
+			theme.Printf("🌐 Server Domains\n")
 			fmt.Printf("================\n")
 			fmt.Printf("%s\n", domains)
 			return nil
@@ -541,13 +609,13 @@ var serversGetDomainsCmd = &cobra.Command{
 		// Pretty print the JSON
 		prettyJSON, err := json.MarshalIndent(domainData, "", "  ")
 		if err != nil {
-			fmt.Printf("🌐 Server Domains\n")
+			theme.Printf("🌐 Server Domains\n")
 			fmt.Printf("================\n")
 			fmt.Printf("%s\n", domains)
 			return nil
 		}
 
-		fmt.Printf("🌐 Server Domains\n")
+		theme.Printf("🌐 Server Domains\n")
 		fmt.Printf("================\n")
 		fmt.Printf("%s\n", string(prettyJSON))
 		return nil
@@ -556,16 +624,40 @@ var serversGetDomainsCmd = &cobra.Command{
 
 // serversValidateCmd represents the servers validate command
 var serversValidateCmd = &cobra.Command{
-	Use:   "validate <uuid>",
+	Use:   "validate [uuid]",
 	Short: "Validate server",
-	Long:  "Validate server connection, configuration, and readiness for deployment",
-	Args:  cobra.ExactArgs(1),
+	Long: `Validate server connection, configuration, and readiness for deployment.
+
+Validation runs in the background on the Coolify instance. Use --wait to follow it: new
+validation log lines are streamed as they appear, and the command exits successfully once the
+server is reachable and usable, or with an error when validation fails or --wait-timeout expires.
+A server whose response lacks the reachable and usable flags fails instead of passing.
+
+With --all, every server is validated concurrently and waited for, e.g. after network changes.
+Each server is reported as soon as its validation finishes, followed by a summary table with
+reachable and usable columns. The command fails when any server is not reachable and usable.
+
+Examples:
+  coolifyme servers validate <uuid>
+  coolifyme servers validate <uuid> --wait
+  coolifyme servers validate <uuid> --wait --wait-timeout 10m --json
+  coolifyme servers validate --all --concurrent 10`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client, err := createClient()
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			return validateAllServers(cmd, client)
+		}
+
 		ctx := context.Background()
 		serverUUID := args[0]
 
@@ -575,6 +667,38 @@ var serversValidateCmd = &cobra.Command{
 		}
 
 		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if wait, _ := cmd.Flags().GetBool("wait"); wait {
+			interval, _ := cmd.Flags().GetDuration("interval")
+			timeout, _ := cmd.Flags().GetDuration("wait-timeout")
+			if !jsonOutput {
+				theme.Printf("🩺 Validating server %s (waiting up to %v)...\n", serverUUID, timeout)
+			}
+
+			onLine := printValidationLine
+			if jsonOutput {
+				onLine = nil
+			}
+			server, waitErr := waitForServerReady(ctx, cmd, client, serverUUID, interval, timeout, onLine)
+			if jsonOutput {
+				output := map[string]interface{}{
+					"server_uuid":     serverUUID,
+					"reachable":       serverReachable(server),
+					"usable":          serverUsable(server),
+					"validation_logs": validationLogLines(server),
+				}
+				if err := outputJSON(output); err != nil {
+					return err
+				}
+				return waitErr
+			}
+			if waitErr != nil {
+				theme.Printf("❌ Server validation failed (reachable: %t, usable: %t)\n", serverReachable(server), serverUsable(server))
+				return waitErr
+			}
+			theme.Printf("✅ Server %s is reachable and usable\n", serverUUID)
+			return nil
+		}
+
 		if jsonOutput {
 			output := map[string]interface{}{
 				"message":     result,
@@ -588,7 +712,7 @@ var serversValidateCmd = &cobra.Command{
 			return nil
 		}
 
-		fmt.Printf("✅ Server Validation\n")
+		theme.Printf("✅ Server Validation\n")
 		fmt.Printf("===================\n")
 		fmt.Printf("Server: %s\n", serverUUID)
 		fmt.Printf("Status: %s\n", result)
@@ -598,7 +722,7 @@ var serversValidateCmd = &cobra.Command{
 
 func init() {
 	// Add subcommands to servers
-	serversCmd.AddCommand(serversListCmd)
+	serversCmd.AddCommand(supportsTemplateOutput(serversListCmd))
 	serversCmd.AddCommand(serversCreateCmd)
 	serversCmd.AddCommand(serversGetCmd)
 	serversCmd.AddCommand(serversUpdateCmd)
@@ -615,7 +739,7 @@ func init() {
 	serversCreateCmd.Flags().StringP("description", "d", "", "Server description")
 	serversCreateCmd.Flags().StringP("ip", "i", "", "Server IP address (required)")
 	serversCreateCmd.Flags().StringP("user", "u", "", "SSH user (required)")
-	serversCreateCmd.Flags().Int32P("port", "p", 22, "SSH port")
+	serversCreateCmd.Flags().Int32("port", 22, "SSH port")
 	serversCreateCmd.Flags().StringP("private-key-uuid", "k", "", "Private key UUID (required)")
 	serversCreateCmd.Flags().Bool("is-build-server", false, "Configure as build server")
 	serversCreateCmd.Flags().Bool("instant-validate", false, "Validate server immediately after creation")
@@ -624,6 +748,8 @@ func init() {
 	_ = serversCreateCmd.MarkFlagRequired("ip")
 	_ = serversCreateCmd.MarkFlagRequired("user")
 	_ = serversCreateCmd.MarkFlagRequired("private-key-uuid")
+	addFromFileFlag(serversCreateCmd)
+	addIfNotExistsFlag(serversCreateCmd, clientpkg.KindServer)
 
 	// Flags for servers get command
 	serversGetCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
@@ -633,14 +759,15 @@ func init() {
 	serversUpdateCmd.Flags().StringP("description", "d", "", "Server description")
 	serversUpdateCmd.Flags().StringP("ip", "i", "", "Server IP address")
 	serversUpdateCmd.Flags().StringP("user", "u", "", "SSH user")
-	serversUpdateCmd.Flags().Int32P("port", "p", 22, "SSH port")
+	serversUpdateCmd.Flags().Int32("port", 22, "SSH port")
 	serversUpdateCmd.Flags().StringP("private-key-uuid", "k", "", "Private key UUID")
 	serversUpdateCmd.Flags().Bool("is-build-server", false, "Configure as build server")
 	serversUpdateCmd.Flags().Bool("instant-validate", false, "Validate server after update")
 	serversUpdateCmd.Flags().String("proxy-type", "", "Proxy type (traefik, caddy, none)")
+	addFromFileFlag(serversUpdateCmd)
 
 	// Flags for servers delete command
-	serversDeleteCmd.Flags().BoolP("force", "f", false, "Force deletion without confirmation")
+	addConfirmFlags(serversDeleteCmd, "Force deletion without confirmation")
 
 	// Flags for servers get-resources command
 	serversGetResourcesCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
@@ -650,4 +777,10 @@ func init() {
 
 	// Flags for servers validate command
 	serversValidateCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+	serversValidateCmd.Flags().Bool("wait", false, "Wait for validation to finish, streaming the validation logs")
+	serversValidateCmd.Flags().Duration("wait-timeout", 5*time.Minute, "Maximum time to wait for validation with --wait")
+	serversValidateCmd.Flags().DurationP("interval", "i", 5*time.Second, "Polling interval with --wait")
+	serversValidateCmd.Flags().Bool("all", false, "Validate all servers concurrently and wait for the results")
+	serversValidateCmd.Flags().Int("concurrent", 5, "Number of servers validated at the same time with --all")
+	addWatchFlags(serversValidateCmd)
 }
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+// proxyContainer is the name Coolify gives the proxy container of every server
+const proxyContainer = "coolify-proxy"
+
+// proxyTypes are the proxy types a server can use
+var proxyTypes = []string{ProxyTraefik, "caddy", "none"}
+
+// serverProxy is the proxy configuration of a server
+type serverProxy struct {
+	Server string `json:"server"`
+	UUID   string `json:"uuid"`
+	Type   string `json:"type"`
+	// Status is the proxy status Coolify recorded at its last check
+	Status string `json:"status"`
+	// Settings are the other proxy settings Coolify stores, e.g. redirect_url
+	Settings map[string]any `json:"settings,omitempty"`
+}
+
+// proxyOf returns the proxy configuration of a server
+func proxyOf(server *coolify.Server) serverProxy {
+	proxy := serverProxy{
+		Server: stringOrDash(server.Name),
+		UUID:   stringOrDash(server.Uuid),
+		Type:   "-",
+		Status: "-",
+	}
+	if server.ProxyType != nil {
+		proxy.Type = string(*server.ProxyType)
+	}
+	if server.Proxy != nil {
+		proxy.Settings = make(map[string]any)
+		for key, value := range *server.Proxy {
+			switch key {
+			case "type":
+				if proxy.Type == "-" {
+					proxy.Type = fmt.Sprint(value)
+				}
+			case "status":
+				proxy.Status = fmt.Sprint(value)
+			default:
+				proxy.Settings[key] = value
+			}
+		}
+	}
+	return proxy
+}
+
+// serversProxyCmd represents the servers proxy command
+var serversProxyCmd = &cobra.Command{
+	Use:   "proxy",
+	Short: "Manage the reverse proxy of a server",
+	Long: `Show and change the reverse proxy (Traefik, Caddy or none) that routes the domains of the
+applications on a server.
+
+The Coolify API only stores the proxy type and reports the proxy status of its last check, so
+'status --live' and 'restart' use docker over SSH on the '` + proxyContainer + `' container, with the address
+and user Coolify has for the server and the keys of your SSH agent, --identity or, with
+--use-server-key, the private key attached to the server in Coolify.`,
+}
+
+// serversProxyGetCmd represents the servers proxy get command
+var serversProxyGetCmd = &cobra.Command{
+	Use:   "get <uuid>",
+	Short: "Show the proxy configuration of a server",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		server, err := client.Servers().Get(context.Background(), args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get server: %w", err)
+		}
+		proxy := proxyOf(server)
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(proxy, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		theme.Printf("🖥️  Server: %s (%s)\n", proxy.Server, proxy.UUID)
+		theme.Printf("🔧 Proxy Type: %s\n", proxy.Type)
+		theme.Printf("📊 Status: %s\n", proxy.Status)
+		if len(proxy.Settings) > 0 {
+			keys := make([]string, 0, len(proxy.Settings))
+			for key := range proxy.Settings {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			fmt.Println("\nSettings:")
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			for _, key := range keys {
+				_, _ = fmt.Fprintf(w, "  %s\t%v\n", key, proxy.Settings[key])
+			}
+			_ = w.Flush()
+		}
+		return nil
+	},
+}
+
+// serversProxySetCmd represents the servers proxy set command
+var serversProxySetCmd = &cobra.Command{
+	Use:   "set <uuid>",
+	Short: "Change the proxy type of a server",
+	Long: `Change the proxy type of a server to traefik, caddy or none.
+
+Coolify applies the new type the next time it starts the proxy. Stop the running proxy in the
+Coolify UI first when switching between Traefik and Caddy, since both need ports 80 and 443.
+
+Examples:
+  coolifyme servers proxy set <uuid> --type caddy
+  coolifyme servers proxy set <uuid> --type none`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		proxyType, _ := cmd.Flags().GetString("type")
+		if !slices.Contains(proxyTypes, proxyType) {
+			return fmt.Errorf("invalid proxy type: %s. Valid options: %s", proxyType, strings.Join(proxyTypes, ", "))
+		}
+
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		serverUUID := args[0]
+		server, err := client.Servers().Get(ctx, serverUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get server: %w", err)
+		}
+		current := proxyOf(server)
+		if current.Type == proxyType {
+			theme.Printf("✅ Server %s already uses %s\n", current.Server, proxyType)
+			return nil
+		}
+
+		newType := coolify.UpdateServerByUuidJSONBodyProxyType(proxyType)
+		if _, err := client.Servers().Update(ctx, serverUUID, coolify.UpdateServerByUuidJSONRequestBody{ProxyType: &newType}); err != nil {
+			return fmt.Errorf("failed to update server: %w", err)
+		}
+
+		theme.Printf("✅ Proxy of server %s changed: %s → %s\n", current.Server, current.Type, proxyType)
+		if current.Status == "running" && current.Type != "none" {
+			theme.Printf("💡 The %s proxy is still running; stop it in the Coolify UI so Coolify starts %s\n", current.Type, proxyType)
+		}
+		return nil
+	},
+}
+
+// serversProxyStatusCmd represents the servers proxy status command
+var serversProxyStatusCmd = &cobra.Command{
+	Use:   "status <uuid>",
+	Short: "Show whether the proxy of a server is running",
+	Long: `Show the proxy status Coolify recorded at its last check. With --live the proxy container is
+inspected over SSH instead.
+
+Examples:
+  coolifyme servers proxy status <uuid>
+  coolifyme servers proxy status <uuid> --live`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := context.Background()
+		serverUUID := args[0]
+		server, err := client.Servers().Get(ctx, serverUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get server: %w", err)
+		}
+		proxy := proxyOf(server)
+
+		if live, _ := cmd.Flags().GetBool("live"); live {
+			ssh, err := openServerSSH(ctx, client, serverUUID, getSSHKeyOptions(cmd), "-o", "BatchMode=yes")
+			if err != nil {
+				return err
+			}
+			defer ssh.Close()
+
+			output, err := ssh.Output(ctx, "docker inspect --format '{{.State.Status}}' "+proxyContainer)
+			if err != nil {
+				return fmt.Errorf("failed to inspect %s: %w", proxyContainer, err)
+			}
+			proxy.Status = strings.TrimSpace(string(output))
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(map[string]string{"server": proxy.Server, "uuid": proxy.UUID, "type": proxy.Type, "status": proxy.Status}, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		icon := "🔴"
+		if proxy.Status == "running" {
+			icon = "🟢"
+		}
+		theme.Printf("%s %s proxy of server %s is %s\n", icon, proxy.Type, proxy.Server, proxy.Status)
+		return nil
+	},
+}
+
+// serversProxyRestartCmd represents the servers proxy restart command
+var serversProxyRestartCmd = &cobra.Command{
+	Use:   "restart <uuid>",
+	Short: "Restart the proxy of a server",
+	Long: `Restart the proxy container of a server over SSH. Requests to every application on the server
+fail while the proxy restarts, so the restart is confirmed first unless --force is given.
+
+Examples:
+  coolifyme servers proxy restart <uuid>
+  coolifyme servers proxy restart <uuid> --force --identity ~/.ssh/id_ed25519`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		if err := refuseReadOnly(client, "docker restart "+proxyContainer); err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		serverUUID := args[0]
+		server, err := client.Servers().Get(ctx, serverUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get server: %w", err)
+		}
+		proxy := proxyOf(server)
+		if proxy.Type == "none" {
+			return fmt.Errorf("server %s has no proxy", proxy.Server)
+		}
+
+		if !confirm.Action(fmt.Sprintf("Restart the %s proxy of server %s? Its applications are unreachable until it is back.", proxy.Type, proxy.Server), skipConfirmation(cmd)) {
+			theme.Println("❌ Restart cancelled")
+			return nil
+		}
+
+		ssh, err := openServerSSH(ctx, client, serverUUID, getSSHKeyOptions(cmd), "-o", "BatchMode=yes")
+		if err != nil {
+			return err
+		}
+		defer ssh.Close()
+
+		if _, err := ssh.Output(ctx, "docker restart "+proxyContainer); err != nil {
+			return fmt.Errorf("failed to restart %s: %w", proxyContainer, err)
+		}
+		theme.Printf("✅ Restarted the %s proxy of server %s\n", proxy.Type, proxy.Server)
+		return nil
+	},
+}
+
+func init() {
+	serversCmd.AddCommand(serversProxyCmd)
+	serversProxyCmd.AddCommand(serversProxyGetCmd)
+	serversProxyCmd.AddCommand(serversProxySetCmd)
+	serversProxyCmd.AddCommand(serversProxyStatusCmd)
+	serversProxyCmd.AddCommand(serversProxyRestartCmd)
+
+	serversProxyGetCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+
+	serversProxySetCmd.Flags().String("type", "", "Proxy type (traefik, caddy or none)")
+	_ = serversProxySetCmd.MarkFlagRequired("type")
+
+	serversProxyStatusCmd.Flags().Bool("live", false, "Inspect the proxy container over SSH instead of using the status of Coolify's last check")
+	addSSHKeyFlags(serversProxyStatusCmd)
+	serversProxyStatusCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+
+	addConfirmFlags(serversProxyRestartCmd, "Restart without confirmation")
+	addSSHKeyFlags(serversProxyRestartCmd)
+}
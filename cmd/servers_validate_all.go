@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// serverValidationResult is the outcome of validating one server with servers validate --all
+type serverValidationResult struct {
+	UUID      string `json:"uuid"`
+	Name      string `json:"name"`
+	Reachable bool   `json:"reachable"`
+	Usable    bool   `json:"usable"`
+	Duration  string `json:"duration"`
+	Error     string `json:"error,omitempty"`
+}
+
+// validateAllServers validates every server concurrently, printing each result as it finishes
+// followed by a summary table
+func validateAllServers(cmd *cobra.Command, client *clientpkg.Client) error {
+	concurrent, _ := cmd.Flags().GetInt("concurrent")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	timeout, _ := cmd.Flags().GetDuration("wait-timeout")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	if concurrent <= 0 {
+		concurrent = 5 // Default concurrency
+	}
+
+	ctx := context.Background()
+	servers, err := client.Servers().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list servers: %w", err)
+	}
+	if len(servers) == 0 {
+		theme.Println("📭 No servers found")
+		return nil
+	}
+
+	if !jsonOutput {
+		theme.Printf("🩺 Validating %d servers (%d at a time, waiting up to %v each)...\n", len(servers), concurrent, timeout)
+	}
+
+	// Create semaphore for concurrency control
+	sem := make(chan struct{}, concurrent)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	results := make([]serverValidationResult, 0, len(servers))
+
+	for _, server := range servers {
+		if server.Uuid == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(serverUUID, serverName string) {
+			defer wg.Done()
+			sem <- struct{}{}        // Acquire semaphore
+			defer func() { <-sem }() // Release semaphore
+
+			result := validateServer(ctx, cmd, client, serverUUID, interval, timeout)
+			result.Name = serverName
+
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, result)
+			if !jsonOutput {
+				printServerValidationProgress(result, len(results), len(servers))
+			}
+		}(*server.Uuid, stringOrDash(server.Name))
+	}
+
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+	failed := 0
+	for _, result := range results {
+		if !result.Reachable || !result.Usable {
+			failed++
+		}
+	}
+
+	if jsonOutput {
+		if err := outputJSON(results); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println()
+		printServerValidationSummary(results)
+		fmt.Println()
+		theme.Printf("📈 Summary: %d/%d servers are reachable and usable\n", len(results)-failed, len(results))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d servers failed validation", failed, len(results))
+	}
+	return nil
+}
+
+// validateServer starts the validation of a server and waits for its outcome
+func validateServer(ctx context.Context, cmd *cobra.Command, client *clientpkg.Client, serverUUID string, interval, timeout time.Duration) serverValidationResult {
+	start := time.Now()
+	result := serverValidationResult{UUID: serverUUID}
+
+	if _, err := client.Servers().Validate(ctx, serverUUID); err != nil {
+		result.Error = err.Error()
+	} else {
+		server, err := waitForServerReady(ctx, cmd, client, serverUUID, interval, timeout, nil)
+		result.Reachable = serverReachable(server)
+		result.Usable = serverUsable(server)
+		if err != nil {
+			result.Error = err.Error()
+		}
+	}
+
+	result.Duration = time.Since(start).Round(time.Second).String()
+	return result
+}
+
+// printServerValidationProgress prints the result of one server as soon as it is known
+func printServerValidationProgress(result serverValidationResult, done, total int) {
+	switch {
+	case result.Reachable && result.Usable:
+		theme.Printf("✅ [%d/%d] %s: reachable and usable (%s)\n", done, total, result.Name, result.Duration)
+	case result.Reachable:
+		theme.Printf("⚠️  [%d/%d] %s: reachable but not usable (%s)\n", done, total, result.Name, result.Duration)
+	default:
+		theme.Printf("❌ [%d/%d] %s: %s (%s)\n", done, total, result.Name, stringOrDash(&result.Error), result.Duration)
+	}
+}
+
+// printServerValidationSummary prints the validation results as a table
+func printServerValidationSummary(results []serverValidationResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer func() {
+		_ = w.Flush()
+	}()
+
+	yesNo := func(ok bool) string {
+		if ok {
+			return "yes"
+		}
+		return "no"
+	}
+
+	_, _ = fmt.Fprintln(w, "UUID\tNAME\tREACHABLE\tUSABLE\tDURATION\tERROR")
+	_, _ = fmt.Fprintln(w, "----\t----\t---------\t------\t--------\t-----")
+	for _, result := range results {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			result.UUID, result.Name, yesNo(result.Reachable), yesNo(result.Usable), result.Duration, result.Error)
+	}
+}
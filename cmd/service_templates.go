@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// servicesTemplatesCmd represents the services templates command
+var servicesTemplatesCmd = &cobra.Command{
+	Use:     "templates",
+	Aliases: []string{"template"},
+	Short:   "Browse one-click service templates",
+	Long:    "Browse the catalog of one-click service templates that can be used with 'services create --type'",
+}
+
+// servicesTemplatesListCmd represents the services templates list command
+var servicesTemplatesListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List service templates",
+	Long:    "List all available one-click service templates",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		templates, err := loadServiceTemplates()
+		if err != nil {
+			return err
+		}
+
+		if tag, _ := cmd.Flags().GetString("tag"); tag != "" {
+			templates = filterTemplates(templates, func(t clientpkg.ServiceTemplate) bool {
+				return containsFold(t.Tags, tag)
+			})
+		}
+
+		return printServiceTemplates(cmd, templates)
+	},
+}
+
+// servicesTemplatesSearchCmd represents the services templates search command
+var servicesTemplatesSearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search service templates",
+	Long:  "Search service templates by name, description and tags",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		templates, err := loadServiceTemplates()
+		if err != nil {
+			return err
+		}
+
+		query := strings.ToLower(args[0])
+		templates = filterTemplates(templates, func(t clientpkg.ServiceTemplate) bool {
+			return strings.Contains(strings.ToLower(t.Name), query) ||
+				strings.Contains(strings.ToLower(t.Slogan), query) ||
+				containsFold(t.Tags, query)
+		})
+
+		return printServiceTemplates(cmd, templates)
+	},
+}
+
+// servicesTemplatesShowCmd represents the services templates show command
+var servicesTemplatesShowCmd = &cobra.Command{
+	Use:               "show <name>",
+	Short:             "Show service template details",
+	Long:              "Show details of a service template including its port and environment variables",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeServiceTypes,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		template, err := clientpkg.GetServiceTemplate(context.Background(), args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get service template: %w", err)
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(template, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		showCompose, _ := cmd.Flags().GetBool("compose")
+		if showCompose {
+			fmt.Print(template.Compose)
+			return nil
+		}
+
+		fmt.Printf("Service Template: %s\n", template.Name)
+		fmt.Printf("==================%s\n", strings.Repeat("=", len(template.Name)))
+		if template.Slogan != "" {
+			fmt.Printf("Description:    %s\n", template.Slogan)
+		}
+		if template.Documentation != "" {
+			fmt.Printf("Documentation:  %s\n", template.Documentation)
+		}
+		if template.Port != "" {
+			fmt.Printf("Port:           %s\n", template.Port)
+		}
+		if len(template.Tags) > 0 {
+			fmt.Printf("Tags:           %s\n", strings.Join(template.Tags, ", "))
+		}
+		if template.MinVersion != "" && template.MinVersion != "0.0.0" {
+			fmt.Printf("Min Version:    %s\n", template.MinVersion)
+		}
+
+		if len(template.EnvVars) > 0 {
+			fmt.Println()
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "VARIABLE\tDEFAULT\tSOURCE")
+			_, _ = fmt.Fprintln(w, "--------\t-------\t------")
+			for _, env := range template.EnvVars {
+				source := "user"
+				if env.Generated {
+					source = "generated"
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", env.Name, env.Default, source)
+			}
+			_ = w.Flush()
+		}
+
+		fmt.Println()
+		theme.Printf("💡 Create it with: coolifyme services create --type %s --project <uuid> --server <uuid> --environment <name>\n", template.Name)
+		return nil
+	},
+}
+
+// loadServiceTemplates fetches the template catalog, falling back to the built-in service types when offline
+func loadServiceTemplates() ([]clientpkg.ServiceTemplate, error) {
+	templates, err := clientpkg.ListServiceTemplates(context.Background())
+	if err == nil {
+		return templates, nil
+	}
+
+	commandWarnings.Addf("service templates", "could not fetch the template catalog (%v), showing built-in service types only", err)
+	templates = make([]clientpkg.ServiceTemplate, 0, len(clientpkg.ServiceTypes))
+	for _, serviceType := range clientpkg.ServiceTypes {
+		templates = append(templates, clientpkg.ServiceTemplate{Name: serviceType})
+	}
+	return templates, nil
+}
+
+// filterTemplates returns the templates matching the given predicate
+func filterTemplates(templates []clientpkg.ServiceTemplate, keep func(clientpkg.ServiceTemplate) bool) []clientpkg.ServiceTemplate {
+	var filtered []clientpkg.ServiceTemplate
+	for _, t := range templates {
+		if keep(t) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// containsFold reports whether any value contains the query, ignoring case
+func containsFold(values []string, query string) bool {
+	query = strings.ToLower(query)
+	for _, v := range values {
+		if strings.Contains(strings.ToLower(v), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// printServiceTemplates prints service templates as a table or JSON
+func printServiceTemplates(cmd *cobra.Command, templates []clientpkg.ServiceTemplate) error {
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	if jsonOutput {
+		output, err := json.MarshalIndent(templates, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if len(templates) == 0 {
+		fmt.Println("No service templates found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer func() {
+		_ = w.Flush()
+	}()
+
+	_, _ = fmt.Fprintln(w, "NAME\tPORT\tTAGS\tDESCRIPTION")
+	_, _ = fmt.Fprintln(w, "----\t----\t----\t-----------")
+	for _, t := range templates {
+		tags := strings.Join(t.Tags, ",")
+		if len(tags) > 30 {
+			tags = tags[:27] + "..."
+		}
+		description := t.Slogan
+		if len(description) > 60 {
+			description = description[:57] + "..."
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", t.Name, t.Port, tags, description)
+	}
+	return nil
+}
+
+// completeServiceTypes provides shell completion for service template names
+func completeServiceTypes(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return clientpkg.ServiceTypes, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	servicesCmd.AddCommand(servicesTemplatesCmd)
+	servicesTemplatesCmd.AddCommand(servicesTemplatesListCmd)
+	servicesTemplatesCmd.AddCommand(servicesTemplatesSearchCmd)
+	servicesTemplatesCmd.AddCommand(servicesTemplatesShowCmd)
+
+	// Flags for templates list command
+	servicesTemplatesListCmd.Flags().String("tag", "", "Only show templates with this tag")
+	servicesTemplatesListCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+
+	// Flags for templates search command
+	servicesTemplatesSearchCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+
+	// Flags for templates show command
+	servicesTemplatesShowCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+	servicesTemplatesShowCmd.Flags().Bool("compose", false, "Print the raw docker compose file of the template")
+}
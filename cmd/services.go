@@ -5,9 +5,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sync"
 	"text/tabwriter"
 
 	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/internal/confirm"
+	"github.com/hongkongkiwi/coolifyme/internal/logger"
+	"github.com/hongkongkiwi/coolifyme/internal/output"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
 	"github.com/spf13/cobra"
 )
 
@@ -24,7 +30,15 @@ var servicesListCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls"},
 	Short:   "List services",
-	Long:    "List all services in your Coolify instance",
+	Long: `List all services in your Coolify instance.
+
+The STATUS column sums up the containers of each service, e.g. "running (3/3)" or
+"degraded (2/3 running, 1 exited)". Services have no status of their own, so every service is
+read to get it; --no-status skips this on large instances. The JSON and template output carry
+the same information as "state" and the "health" container counts (running, exited, other and
+unhealthy).
+
+Use -o wide to add the project, environment and server of each service.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		client, err := createClient()
 		if err != nil {
@@ -37,32 +51,54 @@ var servicesListCmd = &cobra.Command{
 			return fmt.Errorf("failed to list services: %w", err)
 		}
 
+		environmentIDs, err := scopeEnvironmentIDs(ctx, cmd, client)
+		if err != nil {
+			return err
+		}
+		if environmentIDs != nil {
+			scoped := services[:0]
+			for _, service := range services {
+				if service.EnvironmentId != nil && environmentIDs[*service.EnvironmentId] {
+					scoped = append(scoped, service)
+				}
+			}
+			services = scoped
+		}
+
+		var health map[string]clientpkg.ServiceHealth
+		if noStatus, _ := cmd.Flags().GetBool("no-status"); !noStatus {
+			health = serviceHealth(ctx, client, services)
+		}
+
 		jsonOutput, _ := cmd.Flags().GetBool("json")
 		if jsonOutput {
-			output, err := json.MarshalIndent(services, "", "  ")
+			output, err := json.MarshalIndent(servicesWithHealth(services, health), "", "  ")
 			if err != nil {
 				return fmt.Errorf("failed to marshal JSON: %w", err)
 			}
 			fmt.Println(string(output))
 			return nil
 		}
+		if handled, err := outputWithTemplate(cmd, servicesWithHealth(services, health)); handled {
+			return err
+		}
 
 		if len(services) == 0 {
 			fmt.Println("No services found")
 			return nil
 		}
 
-		// Create a tabwriter for nicely formatted output
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		defer func() {
-			_ = w.Flush()
-		}()
-
-		// Print header
-		_, _ = fmt.Fprintln(w, "UUID\tNAME\tTYPE")
-		_, _ = fmt.Fprintln(w, "----\t----\t----")
+		var ns *namespaces
+		headers := []string{"UUID", "NAME", "TYPE", "STATUS"}
+		if wideOutput(cmd) {
+			if ns, err = loadNamespaces(ctx, client); err != nil {
+				return err
+			}
+			headers = []string{"UUID", "NAME", "TYPE", "STATUS", "PROJECT", "ENVIRONMENT", "SERVER"}
+		}
 
-		// Print services
+		// Print services, paused every --page-size rows on a terminal
+		table := output.NewTableWriter(os.Stdout, headers, pagedTable(false, pageSize(cmd)))
 		for _, service := range services {
 			uuid := ""
 			name := ""
@@ -77,12 +113,24 @@ var servicesListCmd = &cobra.Command{
 			if service.ServiceType != nil {
 				serviceType = *service.ServiceType
 			}
+			status := "-"
+			if h, ok := health[uuid]; ok {
+				status = h.String()
+			} else if health != nil {
+				status = "unknown"
+			}
 
-			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n",
-				uuid, name, serviceType)
+			row := []string{uuid, name, serviceType, status}
+			if ns != nil {
+				project, environment := ns.environment(service.EnvironmentId)
+				row = append(row, project, environment, ns.server(service.Uuid, service.ServerId))
+			}
+			if err := table.Row(row...); err != nil {
+				return ignorePagingStopped(err)
+			}
 		}
 
-		return nil
+		return table.Flush()
 	},
 }
 
@@ -132,10 +180,92 @@ var servicesGetCmd = &cobra.Command{
 			fmt.Printf("Description:    %s\n", *service.Description)
 		}
 
+		components, err := client.Services().Components(ctx, serviceUUID)
+		if err != nil {
+			fmt.Printf("Status:         unknown\n")
+			logger.Debug("Could not read the service containers", "service", serviceUUID, "error", err)
+			return nil
+		}
+		fmt.Printf("Status:         %s\n", clientpkg.Health(components))
+		if len(components) > 0 {
+			fmt.Printf("\nContainers:\n")
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "  NAME\tKIND\tIMAGE\tSTATUS")
+			for _, component := range components {
+				_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", component.Name, component.Kind,
+					stringOrDash(&component.Image), stringOrDash(&component.Status))
+			}
+			_ = w.Flush()
+		}
+
 		return nil
 	},
 }
 
+// serviceWithHealth is a service with the states of its containers; the JSON and template
+// output carry them next to the API fields
+type serviceWithHealth struct {
+	coolify.Service
+	State  string                   `json:"state,omitempty"`
+	Health *clientpkg.ServiceHealth `json:"health,omitempty"`
+}
+
+// servicesWithHealth adds the container states read by serviceHealth to services. The state is
+// unknown for services whose containers could not be read, and left out with a nil health map.
+func servicesWithHealth(services []coolify.Service, health map[string]clientpkg.ServiceHealth) []serviceWithHealth {
+	withHealth := make([]serviceWithHealth, 0, len(services))
+	for _, service := range services {
+		entry := serviceWithHealth{Service: service}
+		if health != nil {
+			entry.State = "unknown"
+			if service.Uuid != nil {
+				if h, ok := health[*service.Uuid]; ok {
+					entry.State = h.State()
+					entry.Health = &h
+				}
+			}
+		}
+		withHealth = append(withHealth, entry)
+	}
+	return withHealth
+}
+
+// serviceStatusConcurrency is the number of services whose containers are read at once
+const serviceStatusConcurrency = 10
+
+// serviceHealth reads the containers of services concurrently and sums up their states by
+// service UUID. Services whose containers could not be read are left out.
+func serviceHealth(ctx context.Context, client clientpkg.API, services []coolify.Service) map[string]clientpkg.ServiceHealth {
+	health := make(map[string]clientpkg.ServiceHealth, len(services))
+	sem := make(chan struct{}, serviceStatusConcurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	for _, service := range services {
+		if service.Uuid == nil {
+			continue
+		}
+		uuid := *service.Uuid
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}        // Acquire semaphore
+			defer func() { <-sem }() // Release semaphore
+
+			components, err := client.Services().Components(ctx, uuid)
+			if err != nil {
+				logger.Debug("Could not read the service containers", "service", uuid, "error", err)
+				return
+			}
+			mu.Lock()
+			health[uuid] = clientpkg.Health(components)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return health
+}
+
 // servicesStartCmd represents the services start command
 var servicesStartCmd = &cobra.Command{
 	Use:   "start <uuid>",
@@ -156,7 +286,7 @@ var servicesStartCmd = &cobra.Command{
 			return fmt.Errorf("failed to start service: %w", err)
 		}
 
-		fmt.Printf("✅ Service %s start request queued successfully\n", serviceUUID)
+		theme.Printf("✅ Service %s start request queued successfully\n", serviceUUID)
 		return nil
 	},
 }
@@ -181,7 +311,7 @@ var servicesStopCmd = &cobra.Command{
 			return fmt.Errorf("failed to stop service: %w", err)
 		}
 
-		fmt.Printf("✅ Service %s stop request queued successfully\n", serviceUUID)
+		theme.Printf("✅ Service %s stop request queued successfully\n", serviceUUID)
 		return nil
 	},
 }
@@ -206,7 +336,7 @@ var servicesRestartCmd = &cobra.Command{
 			return fmt.Errorf("failed to restart service: %w", err)
 		}
 
-		fmt.Printf("✅ Service %s restart request queued successfully\n", serviceUUID)
+		theme.Printf("✅ Service %s restart request queued successfully\n", serviceUUID)
 		return nil
 	},
 }
@@ -222,57 +352,79 @@ var servicesCreateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		// Get flag values
-		serviceType, _ := cmd.Flags().GetString("type")
-		name, _ := cmd.Flags().GetString("name")
-		description, _ := cmd.Flags().GetString("description")
-		project, _ := cmd.Flags().GetString("project")
-		environment, _ := cmd.Flags().GetString("environment")
-		server, _ := cmd.Flags().GetString("server")
-		dockerCompose, _ := cmd.Flags().GetString("docker-compose")
-		instantDeploy, _ := cmd.Flags().GetBool("instant-deploy")
+		req := coolify.CreateServiceJSONRequestBody{}
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
+		}
+		if !fromFile {
+			// Get flag values
+			serviceType, _ := cmd.Flags().GetString("type")
+			name, _ := cmd.Flags().GetString("name")
+			description, _ := cmd.Flags().GetString("description")
+			project, _ := cmd.Flags().GetString("project")
+			environment, _ := cmd.Flags().GetString("environment")
+			server, _ := cmd.Flags().GetString("server")
+			dockerCompose, _ := cmd.Flags().GetString("docker-compose")
+			instantDeploy, _ := cmd.Flags().GetBool("instant-deploy")
+
+			// Validate required fields
+			if project == "" {
+				return fmt.Errorf("project UUID is required (--project)")
+			}
+			if server == "" {
+				return fmt.Errorf("server UUID is required (--server)")
+			}
+			if environment == "" {
+				return fmt.Errorf("environment name is required (--environment)")
+			}
 
-		// Validate required fields
-		if project == "" {
-			return fmt.Errorf("project UUID is required (--project)")
-		}
-		if server == "" {
-			return fmt.Errorf("server UUID is required (--server)")
-		}
-		if environment == "" {
-			return fmt.Errorf("environment name is required (--environment)")
-		}
+			// Create request body
+			req = coolify.CreateServiceJSONRequestBody{
+				ProjectUuid:     project,
+				ServerUuid:      server,
+				EnvironmentName: environment,
+				InstantDeploy:   &instantDeploy,
+			}
 
-		// Create request body
-		req := coolify.CreateServiceJSONRequestBody{
-			ProjectUuid:     project,
-			ServerUuid:      server,
-			EnvironmentName: environment,
-			InstantDeploy:   &instantDeploy,
+			if serviceType != "" {
+				serviceTypeEnum := coolify.CreateServiceJSONBodyType(serviceType)
+				req.Type = &serviceTypeEnum
+			}
+			if name != "" {
+				req.Name = &name
+			}
+			if description != "" {
+				req.Description = &description
+			}
+			if dockerCompose != "" {
+				req.DockerComposeRaw = &dockerCompose
+			}
 		}
 
-		if serviceType != "" {
-			serviceTypeEnum := coolify.CreateServiceJSONBodyType(serviceType)
-			req.Type = &serviceTypeEnum
-		}
-		if name != "" {
-			req.Name = &name
-		}
-		if description != "" {
-			req.Description = &description
+		ctx := context.Background()
+		existing, err := existingResource(ctx, cmd, client, clientpkg.KindService, req)
+		if err != nil {
+			return err
 		}
-		if dockerCompose != "" {
-			req.DockerComposeRaw = &dockerCompose
+		if existing != "" {
+			printExistingResource(clientpkg.KindService, requestName(req), existing)
+			return nil
 		}
 
-		ctx := context.Background()
+		ctx, guard := beginCreate(ctx, client, "service", req)
 		uuid, err := client.Services().Create(ctx, req)
+		guard.finish(err)
 		if err != nil {
 			return fmt.Errorf("failed to create service: %w", err)
 		}
 
-		fmt.Printf("✅ Service created successfully\n")
-		fmt.Printf("   📦 UUID: %s\n", uuid)
+		if printQuietUUID(uuid) {
+			return nil
+		}
+
+		theme.Statusf("✅ Service created successfully\n")
+		theme.Printf("   📦 UUID: %s\n", uuid)
 		return nil
 	},
 }
@@ -288,12 +440,34 @@ var servicesDeleteCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
+		if err := checkTokenAbility(cmd, client, clientpkg.AbilityWrite); err != nil {
+			return err
+		}
 
 		deleteConfigurations, _ := cmd.Flags().GetBool("delete-configurations")
 		deleteVolumes, _ := cmd.Flags().GetBool("delete-volumes")
 		dockerCleanup, _ := cmd.Flags().GetBool("docker-cleanup")
 		deleteConnectedNetworks, _ := cmd.Flags().GetBool("delete-connected-networks")
 
+		ctx := context.Background()
+		serviceUUID := args[0]
+
+		force := skipConfirmation(cmd)
+		dependents := deleteDependents(deleteVolumes, deleteConfigurations)
+		if deleteConnectedNetworks {
+			dependents = append(dependents, "connected docker networks")
+		}
+		target := confirm.Target{Kind: "service", ID: serviceUUID, Dependents: dependents}
+		if !force {
+			if service, err := client.Services().Get(ctx, serviceUUID); err == nil && service.Name != nil {
+				target.Name = *service.Name
+			}
+		}
+		if !confirm.Delete(target, force) {
+			theme.Println("❌ Deletion cancelled")
+			return nil
+		}
+
 		options := &coolify.DeleteServiceByUuidParams{
 			DeleteConfigurations:    &deleteConfigurations,
 			DeleteVolumes:           &deleteVolumes,
@@ -301,15 +475,12 @@ var servicesDeleteCmd = &cobra.Command{
 			DeleteConnectedNetworks: &deleteConnectedNetworks,
 		}
 
-		ctx := context.Background()
-		serviceUUID := args[0]
-
 		err = client.Services().Delete(ctx, serviceUUID, options)
 		if err != nil {
 			return fmt.Errorf("failed to delete service: %w", err)
 		}
 
-		fmt.Printf("✅ Service %s deleted successfully\n", serviceUUID)
+		theme.Printf("✅ Service %s deleted successfully\n", serviceUUID)
 		return nil
 	},
 }
@@ -326,23 +497,27 @@ var servicesUpdateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		// Get flag values - only set fields that were explicitly provided
-		name, _ := cmd.Flags().GetString("name")
-		description, _ := cmd.Flags().GetString("description")
-		dockerCompose, _ := cmd.Flags().GetString("docker-compose")
-
-		// Create request body with only provided fields
 		req := coolify.UpdateServiceByUuidJSONRequestBody{}
-
-		// Only set fields if they were provided
-		if cmd.Flags().Changed("name") {
-			req.Name = &name
-		}
-		if cmd.Flags().Changed("description") {
-			req.Description = &description
+		fromFile, err := readRequestFile(cmd, &req)
+		if err != nil {
+			return err
 		}
-		if cmd.Flags().Changed("docker-compose") {
-			req.DockerComposeRaw = dockerCompose
+		if !fromFile {
+			// Get flag values - only set fields that were explicitly provided
+			name, _ := cmd.Flags().GetString("name")
+			description, _ := cmd.Flags().GetString("description")
+			dockerCompose, _ := cmd.Flags().GetString("docker-compose")
+
+			// Only set fields if they were provided
+			if cmd.Flags().Changed("name") {
+				req.Name = &name
+			}
+			if cmd.Flags().Changed("description") {
+				req.Description = &description
+			}
+			if cmd.Flags().Changed("docker-compose") {
+				req.DockerComposeRaw = dockerCompose
+			}
 		}
 
 		ctx := context.Background()
@@ -353,8 +528,8 @@ var servicesUpdateCmd = &cobra.Command{
 			return fmt.Errorf("failed to update service: %w", err)
 		}
 
-		fmt.Printf("✅ Service updated successfully\n")
-		fmt.Printf("   📦 UUID: %s\n", uuid)
+		theme.Printf("✅ Service updated successfully\n")
+		theme.Printf("   📦 UUID: %s\n", uuid)
 		return nil
 	},
 }
@@ -471,9 +646,13 @@ var servicesCreateEnvCmd = &cobra.Command{
 			return fmt.Errorf("failed to create environment variable: %w", err)
 		}
 
-		fmt.Printf("✅ Environment variable created successfully\n")
-		fmt.Printf("   🔑 Key: %s\n", key)
-		fmt.Printf("   📦 UUID: %s\n", uuid)
+		if printQuietUUID(uuid) {
+			return nil
+		}
+
+		theme.Statusf("✅ Environment variable created successfully\n")
+		theme.Statusf("   🔑 Key: %s\n", key)
+		theme.Printf("   📦 UUID: %s\n", uuid)
 		return nil
 	},
 }
@@ -526,9 +705,9 @@ var servicesUpdateEnvCmd = &cobra.Command{
 			return fmt.Errorf("failed to update environment variable: %w", err)
 		}
 
-		fmt.Printf("✅ Environment variable updated successfully\n")
-		fmt.Printf("   🔑 Key: %s\n", key)
-		fmt.Printf("   📦 UUID: %s\n", uuid)
+		theme.Printf("✅ Environment variable updated successfully\n")
+		theme.Printf("   🔑 Key: %s\n", key)
+		theme.Printf("   📦 UUID: %s\n", uuid)
 		return nil
 	},
 }
@@ -606,13 +785,17 @@ var servicesUpdateEnvsCmd = &cobra.Command{
 		ctx := context.Background()
 		serviceUUID := args[0]
 
+		if err := takeEnvSnapshot(ctx, cmd, client, serviceEnvSnapshots, serviceUUID); err != nil {
+			return err
+		}
+
 		message, err := client.Services().UpdateEnvs(ctx, serviceUUID, req)
 		if err != nil {
 			return fmt.Errorf("failed to bulk update environment variables: %w", err)
 		}
 
-		fmt.Printf("✅ Environment variables updated successfully\n")
-		fmt.Printf("   💬 Message: %s\n", message)
+		theme.Printf("✅ Environment variables updated successfully\n")
+		theme.Printf("   💬 Message: %s\n", message)
 		return nil
 	},
 }
@@ -623,30 +806,39 @@ var servicesDeleteEnvCmd = &cobra.Command{
 	Short: "Delete environment variable",
 	Long:  "Delete an environment variable from a service",
 	Args:  cobra.ExactArgs(2),
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		client, err := createClient()
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
+		if err := checkTokenAbility(cmd, client, clientpkg.AbilityWrite); err != nil {
+			return err
+		}
 
 		ctx := context.Background()
 		serviceUUID := args[0]
 		envUUID := args[1]
 
+		target := confirm.Target{Kind: "environment variable", ID: envUUID, Dependents: []string{"service " + serviceUUID}}
+		if !confirm.Delete(target, skipConfirmation(cmd)) {
+			theme.Println("❌ Deletion cancelled")
+			return nil
+		}
+
 		uuid, err := client.Services().DeleteEnv(ctx, serviceUUID, envUUID)
 		if err != nil {
 			return fmt.Errorf("failed to delete environment variable: %w", err)
 		}
 
-		fmt.Printf("✅ Environment variable deleted successfully\n")
-		fmt.Printf("   📦 UUID: %s\n", uuid)
+		theme.Printf("✅ Environment variable deleted successfully\n")
+		theme.Printf("   📦 UUID: %s\n", uuid)
 		return nil
 	},
 }
 
 func init() {
 	// Add subcommands to services
-	servicesCmd.AddCommand(servicesListCmd)
+	servicesCmd.AddCommand(supportsTemplateOutput(servicesListCmd))
 	servicesCmd.AddCommand(servicesGetCmd)
 	servicesCmd.AddCommand(servicesCreateCmd)
 	servicesCmd.AddCommand(servicesUpdateCmd)
@@ -662,15 +854,18 @@ func init() {
 
 	// Flags for services list command
 	servicesListCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+	servicesListCmd.Flags().Bool("no-status", false, "Do not read the containers of each service for the STATUS column")
+	addScopeFlags(servicesListCmd)
+	addPageSizeFlag(servicesListCmd)
 
 	// Flags for services get command
 	servicesGetCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
 
 	// Flags for services create command
-	servicesCreateCmd.Flags().StringP("project", "p", "", "Project UUID (required)")
-	servicesCreateCmd.Flags().StringP("server", "s", "", "Server UUID (required)")
+	servicesCreateCmd.Flags().String("project", "", "Project UUID (required)")
+	servicesCreateCmd.Flags().String("server", "", "Server UUID (required)")
 	servicesCreateCmd.Flags().StringP("environment", "e", "", "Environment name (required)")
-	servicesCreateCmd.Flags().StringP("type", "t", "", "Service type")
+	servicesCreateCmd.Flags().String("type", "", "Service type (see 'services templates list')")
 	servicesCreateCmd.Flags().StringP("name", "n", "", "Service name")
 	servicesCreateCmd.Flags().StringP("description", "d", "", "Service description")
 	servicesCreateCmd.Flags().StringP("docker-compose", "c", "", "Docker compose file content")
@@ -678,41 +873,54 @@ func init() {
 	_ = servicesCreateCmd.MarkFlagRequired("project")
 	_ = servicesCreateCmd.MarkFlagRequired("server")
 	_ = servicesCreateCmd.MarkFlagRequired("environment")
+	_ = servicesCreateCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return completeServiceTypes(cmd, nil, toComplete)
+	})
+	addFromFileFlag(servicesCreateCmd)
+	addIfNotExistsFlag(servicesCreateCmd, clientpkg.KindService)
 
 	// Flags for services update command
 	servicesUpdateCmd.Flags().StringP("name", "n", "", "Service name")
 	servicesUpdateCmd.Flags().StringP("description", "d", "", "Service description")
 	servicesUpdateCmd.Flags().StringP("docker-compose", "c", "", "Docker compose file content")
 	servicesUpdateCmd.Flags().BoolP("instant-deploy", "i", false, "Deploy service immediately after update")
+	addFromFileFlag(servicesUpdateCmd)
 
 	// Flags for services delete command
-	servicesDeleteCmd.Flags().BoolP("force", "f", false, "Force deletion without confirmation")
+	addConfirmFlags(servicesDeleteCmd, "Force deletion without confirmation")
+	servicesDeleteCmd.Flags().Bool("delete-configurations", false, "Delete configurations")
+	servicesDeleteCmd.Flags().Bool("delete-volumes", false, "Delete volumes")
+	servicesDeleteCmd.Flags().Bool("docker-cleanup", false, "Run docker cleanup after deletion")
+	servicesDeleteCmd.Flags().Bool("delete-connected-networks", false, "Delete connected networks")
 
 	// Flags for environment variable list command
 	servicesListEnvsCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
 
 	// Flags for environment variable create command
 	servicesCreateEnvCmd.Flags().StringP("key", "k", "", "Environment variable key (required)")
-	servicesCreateEnvCmd.Flags().StringP("value", "v", "", "Environment variable value (required)")
-	servicesCreateEnvCmd.Flags().BoolP("is-preview", "p", false, "Is preview environment variable")
+	servicesCreateEnvCmd.Flags().String("value", "", "Environment variable value (required)")
+	servicesCreateEnvCmd.Flags().Bool("is-preview", false, "Is preview environment variable")
 	servicesCreateEnvCmd.Flags().BoolP("is-build-time", "b", false, "Is build time environment variable")
 	servicesCreateEnvCmd.Flags().BoolP("is-literal", "l", false, "Is literal environment variable")
 	servicesCreateEnvCmd.Flags().BoolP("is-multiline", "m", false, "Is multiline environment variable")
-	servicesCreateEnvCmd.Flags().BoolP("is-shown-once", "o", false, "Is shown once environment variable")
+	servicesCreateEnvCmd.Flags().Bool("is-shown-once", false, "Is shown once environment variable")
 	_ = servicesCreateEnvCmd.MarkFlagRequired("key")
 	_ = servicesCreateEnvCmd.MarkFlagRequired("value")
 
 	// Flags for environment variable update command
 	servicesUpdateEnvCmd.Flags().StringP("key", "k", "", "Environment variable key (required)")
-	servicesUpdateEnvCmd.Flags().StringP("value", "v", "", "Environment variable value (required)")
-	servicesUpdateEnvCmd.Flags().BoolP("is-preview", "p", false, "Is preview environment variable")
+	servicesUpdateEnvCmd.Flags().String("value", "", "Environment variable value (required)")
+	servicesUpdateEnvCmd.Flags().Bool("is-preview", false, "Is preview environment variable")
 	servicesUpdateEnvCmd.Flags().BoolP("is-build-time", "b", false, "Is build time environment variable")
 	servicesUpdateEnvCmd.Flags().BoolP("is-literal", "l", false, "Is literal environment variable")
 	servicesUpdateEnvCmd.Flags().BoolP("is-multiline", "m", false, "Is multiline environment variable")
-	servicesUpdateEnvCmd.Flags().BoolP("is-shown-once", "o", false, "Is shown once environment variable")
+	servicesUpdateEnvCmd.Flags().Bool("is-shown-once", false, "Is shown once environment variable")
 	_ = servicesUpdateEnvCmd.MarkFlagRequired("key")
 	_ = servicesUpdateEnvCmd.MarkFlagRequired("value")
 
+	// Flags for environment variable delete command
+	addConfirmFlags(servicesDeleteEnvCmd, "Delete without confirmation")
+
 	// Flags for bulk environment variable update command
 	servicesUpdateEnvsCmd.Flags().StringP("env-data", "d", "", "JSON string containing environment variables")
 	servicesUpdateEnvsCmd.Flags().StringP("env-file", "f", "", "File containing environment variables in JSON format")
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// servicesAppsCmd represents the services apps command
+var servicesAppsCmd = &cobra.Command{
+	Use:     "apps",
+	Aliases: []string{"components"},
+	Short:   "Manage the applications and databases inside a service",
+	Long: `A service is a compose stack that can contain several applications and databases, e.g. a
+blog and its MySQL database. These commands list them and start, stop or restart a single one
+instead of the whole service.
+
+The Coolify API only starts and stops whole services, so single containers are controlled with
+docker over SSH, using the address and user Coolify has for the server with the keys of your
+SSH agent, --identity or, with --use-server-key, the private key attached to the server in
+Coolify. Coolify picks up the new container state with its next status check.`,
+}
+
+// servicesAppsListCmd represents the services apps list command
+var servicesAppsListCmd = &cobra.Command{
+	Use:     "list <service-uuid>",
+	Aliases: []string{"ls"},
+	Short:   "List the applications and databases of a service",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		components, err := client.Services().Components(context.Background(), args[0])
+		if err != nil {
+			return err
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(components, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		if len(components) == 0 {
+			fmt.Println("No applications or databases found")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		defer func() {
+			_ = w.Flush()
+		}()
+		_, _ = fmt.Fprintln(w, "NAME\tKIND\tIMAGE\tSTATUS\tURL\tUUID")
+		_, _ = fmt.Fprintln(w, "----\t----\t-----\t------\t---\t----")
+		for _, c := range components {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				c.Name, c.Kind, stringOrDash(&c.Image), stringOrDash(&c.Status), stringOrDash(&c.FQDN), c.UUID)
+		}
+		return nil
+	},
+}
+
+// newServicesAppsActionCmd creates a command running a docker action on a single service container
+func newServicesAppsActionCmd(action, title, past string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   action + " <service-uuid> <name>",
+		Short: title + " one application or database of a service",
+		Long: fmt.Sprintf(`%s one application or database of a service by its compose service name (as shown
+by 'services apps list') or UUID, leaving the other containers of the service untouched.
+
+Examples:
+  coolifyme services apps %s <service-uuid> mysql
+  coolifyme services apps %s <service-uuid> ghost --identity ~/.ssh/id_ed25519`,
+			title, action, action),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := createClient()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			if err := refuseReadOnly(client, "docker "+action); err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			serviceUUID := args[0]
+			components, err := client.Services().Components(ctx, serviceUUID)
+			if err != nil {
+				return err
+			}
+			component, err := clientpkg.FindComponent(components, args[1])
+			if err != nil {
+				return err
+			}
+
+			service, err := client.Services().Get(ctx, serviceUUID)
+			if err != nil {
+				return fmt.Errorf("failed to get service: %w", err)
+			}
+			serverUUID, err := serviceServerUUID(ctx, client, service)
+			if err != nil {
+				return err
+			}
+
+			ssh, err := openServerSSH(ctx, client, serverUUID, getSSHKeyOptions(cmd), "-o", "BatchMode=yes")
+			if err != nil {
+				return err
+			}
+			defer ssh.Close()
+
+			container := component.ContainerName(serviceUUID)
+			if _, err := ssh.Output(ctx, fmt.Sprintf("docker %s %s", action, shellQuote(container))); err != nil {
+				return fmt.Errorf("failed to %s %s: %w", action, container, err)
+			}
+
+			theme.Printf("✅ %s (%s) %s in service %s\n", component.Name, component.Kind, past, stringOrDash(service.Name))
+			return nil
+		},
+	}
+	addSSHKeyFlags(cmd)
+	return cmd
+}
+
+func init() {
+	servicesCmd.AddCommand(servicesAppsCmd)
+	servicesAppsCmd.AddCommand(servicesAppsListCmd)
+	servicesAppsCmd.AddCommand(newServicesAppsActionCmd("start", "Start", "started"))
+	servicesAppsCmd.AddCommand(newServicesAppsActionCmd("stop", "Stop", "stopped"))
+	servicesAppsCmd.AddCommand(newServicesAppsActionCmd("restart", "Restart", "restarted"))
+
+	// Flags for services apps list command
+	servicesAppsListCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+// sourcesCmd represents the sources command
+var sourcesCmd = &cobra.Command{
+	Use:     "sources",
+	Aliases: []string{"source", "github-apps"},
+	Short:   "Manage git sources",
+	Long:    "Manage git sources (GitHub Apps) - list sources to find the UUIDs needed to create applications from private repositories",
+}
+
+// sourcesListCmd represents the sources list command
+var sourcesListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List sources",
+	Long:    "List all GitHub App sources in your Coolify instance",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		sources, err := client.Sources().List(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to list sources: %w", err)
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(sources, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		if len(sources) == 0 {
+			fmt.Println("No sources found")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		defer func() {
+			_ = w.Flush()
+		}()
+
+		_, _ = fmt.Fprintln(w, "UUID\tNAME\tORGANIZATION\tPUBLIC\tSYSTEM WIDE")
+		_, _ = fmt.Fprintln(w, "----\t----\t------------\t------\t-----------")
+		for _, source := range sources {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%t\n",
+				source.UUID, source.Name, source.Organization, source.IsPublic, source.IsSystemWide)
+		}
+		return nil
+	},
+}
+
+// sourcesGetCmd represents the sources get command
+var sourcesGetCmd = &cobra.Command{
+	Use:   "get <uuid>",
+	Short: "Get source details",
+	Long:  "Get detailed information about a GitHub App source by UUID",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		source, err := client.Sources().Get(context.Background(), args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get source: %w", err)
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(source, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		fmt.Printf("Source Details:\n")
+		fmt.Printf("===============\n")
+		fmt.Printf("UUID:             %s\n", source.UUID)
+		fmt.Printf("Name:             %s\n", source.Name)
+		if source.Organization != "" {
+			fmt.Printf("Organization:     %s\n", source.Organization)
+		}
+		if source.HTMLURL != "" {
+			fmt.Printf("HTML URL:         %s\n", source.HTMLURL)
+		}
+		if source.APIURL != "" {
+			fmt.Printf("API URL:          %s\n", source.APIURL)
+		}
+		if source.AppID != 0 {
+			fmt.Printf("App ID:           %d\n", source.AppID)
+		}
+		if source.InstallationID != 0 {
+			fmt.Printf("Installation ID:  %d\n", source.InstallationID)
+		}
+		fmt.Printf("Public:           %t\n", source.IsPublic)
+		fmt.Printf("System Wide:      %t\n", source.IsSystemWide)
+		fmt.Println()
+		theme.Printf("💡 Use this UUID as github_app_uuid when creating applications from private repositories\n")
+		return nil
+	},
+}
+
+func init() {
+	// Add subcommands to sources
+	sourcesCmd.AddCommand(sourcesListCmd)
+	sourcesCmd.AddCommand(sourcesGetCmd)
+
+	// Flags for all commands
+	sourcesListCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+	sourcesGetCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// applicationStatus is a consolidated status row for a single application
+type applicationStatus struct {
+	Name           string `json:"name" yaml:"name"`
+	UUID           string `json:"uuid" yaml:"uuid"`
+	Status         string `json:"status" yaml:"status"`
+	Server         string `json:"server" yaml:"server"`
+	LastDeployment string `json:"last_deployment" yaml:"last_deployment"`
+	DeployedAt     string `json:"deployed_at" yaml:"deployed_at"`
+}
+
+// quickStatusCmd represents the status command
+var quickStatusCmd = &cobra.Command{
+	Use:     "status",
+	Aliases: []string{"st", "stat"},
+	Short:   "Show consolidated application status",
+	Long: `Show a consolidated view of all applications with their status, server and last deployment.
+
+Deployment details are fetched concurrently. Use --watch to refresh the view periodically,
+or --summary for the resource count overview of 'monitor status'.
+
+Inside a repository linked with 'coolifyme link' only the linked application is shown; use --all
+to show every application.
+
+Examples:
+  coolifyme status
+  coolifyme status --watch --interval 10
+  coolifyme status -o json
+  coolifyme status --sort-by status
+  coolifyme status --all`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if summary, _ := cmd.Flags().GetBool("summary"); summary {
+			// Forward to the monitor status command
+			return statusCmd.RunE(cmd, args)
+		}
+
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		concurrent, _ := cmd.Flags().GetInt("concurrent")
+		options := ParseFormatOptions(cmd)
+		if options.SortBy == "" {
+			options.SortBy = "name"
+		}
+
+		collect := func(ctx context.Context) ([]applicationStatus, error) {
+			return collectApplicationStatus(ctx, client, concurrent)
+		}
+		if all, _ := cmd.Flags().GetBool("all"); !all {
+			if l := linkedApplication(); l != nil {
+				fmt.Fprint(os.Stderr, theme.Sprintf("🔗 Showing the linked application %s, use --all for every application\n", l.Name))
+				collect = func(ctx context.Context) ([]applicationStatus, error) {
+					return collectLinkedApplicationStatus(ctx, client, l.Application)
+				}
+			}
+		}
+
+		watch, _ := cmd.Flags().GetBool("watch")
+		if !watch {
+			statuses, err := collect(context.Background())
+			if err != nil {
+				return err
+			}
+			return FormatOutput(statuses, options)
+		}
+
+		interval, _ := cmd.Flags().GetInt("interval")
+		if interval < 1 {
+			interval = 5 // Default 5 seconds
+		}
+
+		return WatchLoop(context.Background(), getWatchConfig(cmd, time.Duration(interval)*time.Second), func(ctx context.Context) (bool, error) {
+			statuses, err := collect(ctx)
+			if err != nil {
+				return false, err
+			}
+
+			// Clear screen (works on most terminals)
+			fmt.Print("\033[2J\033[H")
+			theme.Printf("🔄 Every %ds: coolifyme status    %s\n\n", interval, time.Now().Format("2006-01-02 15:04:05"))
+			if err := FormatOutput(statuses, options); err != nil {
+				return true, err
+			}
+			return false, nil
+		})
+	},
+}
+
+// collectApplicationStatus fetches all applications and their latest deployment using a worker pool
+func collectApplicationStatus(ctx context.Context, c *client.Client, concurrent int) ([]applicationStatus, error) {
+	if concurrent <= 0 {
+		concurrent = 5 // Default concurrency
+	}
+
+	apps, err := c.Applications().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	statuses := make([]applicationStatus, len(apps))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrent && w < len(apps); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				statuses[i] = applicationStatusRow(ctx, c, apps[i].Uuid, apps[i].Name, apps[i].Status)
+			}
+		}()
+	}
+
+	for i := range apps {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.SliceStable(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses, nil
+}
+
+// collectLinkedApplicationStatus fetches the status of the application linked to the working
+// directory
+func collectLinkedApplicationStatus(ctx context.Context, c *client.Client, uuid string) ([]applicationStatus, error) {
+	app, err := c.Applications().Get(ctx, uuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get linked application %s: %w", uuid, err)
+	}
+	return []applicationStatus{applicationStatusRow(ctx, c, app.Uuid, app.Name, app.Status)}, nil
+}
+
+// applicationStatusRow builds the status row for a single application
+func applicationStatusRow(ctx context.Context, c *client.Client, uuid, name, status *string) applicationStatus {
+	row := applicationStatus{
+		Name:           stringOrDash(name),
+		UUID:           stringOrDash(uuid),
+		Status:         stringOrDash(status),
+		Server:         "-",
+		LastDeployment: "-",
+		DeployedAt:     "-",
+	}
+	if uuid == nil {
+		return row
+	}
+
+	deployment, err := c.Deployments().Latest(ctx, *uuid)
+	if err != nil {
+		row.LastDeployment = "unknown"
+		return row
+	}
+	if deployment == nil {
+		row.LastDeployment = "never"
+		return row
+	}
+
+	row.Server = stringOrDash(deployment.ServerName)
+	row.LastDeployment = stringOrDash(deployment.Status)
+	if deployment.CreatedAt != nil {
+		row.DeployedAt = formatDeploymentTime(*deployment.CreatedAt)
+	}
+	return row
+}
+
+// formatDeploymentTime renders an API timestamp in local time, falling back to the raw value
+func formatDeploymentTime(value string) string {
+	t, ok := parseAPITime(&value)
+	if !ok {
+		return value
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// stringOrDash dereferences a string pointer, returning "-" for nil or empty values
+func stringOrDash(s *string) string {
+	if s == nil || *s == "" {
+		return "-"
+	}
+	return *s
+}
+
+func init() {
+	AddFormatFlags(quickStatusCmd)
+	quickStatusCmd.Flags().BoolP("watch", "w", false, "Refresh the view periodically")
+	addWatchFlags(quickStatusCmd)
+	quickStatusCmd.Flags().IntP("interval", "i", 5, "Refresh interval in seconds for --watch")
+	quickStatusCmd.Flags().Int("concurrent", 10, "Number of applications to query concurrently")
+	quickStatusCmd.Flags().Bool("summary", false, "Show the resource count overview instead")
+	quickStatusCmd.Flags().Bool("all", false, "Show every application inside a repository linked with 'coolifyme link'")
+}
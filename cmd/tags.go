@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// tagsCmd represents the tags command
+var tagsCmd = &cobra.Command{
+	Use:     "tags",
+	Aliases: []string{"tag"},
+	Short:   "List tags and tagged resources",
+	Long: `List the tags of your team and the applications and services carrying them, for tag-driven
+workflows such as deploying or grouping resources by team.
+
+Examples:
+  coolifyme tags list
+  coolifyme tags resources backend
+  coolifyme search api --tag backend
+  coolifyme applications restart-all --tag backend`,
+}
+
+// tagsListCmd represents the tags list command
+var tagsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List tags",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		tags, err := client.Tags().List(context.Background())
+		if err != nil {
+			return err
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(tags, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		if len(tags) == 0 {
+			fmt.Println("No tags found")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		defer func() {
+			_ = w.Flush()
+		}()
+
+		_, _ = fmt.Fprintln(w, "ID\tNAME")
+		_, _ = fmt.Fprintln(w, "--\t----")
+		for _, tag := range tags {
+			_, _ = fmt.Fprintf(w, "%d\t%s\n", tag.ID, tag.Name)
+		}
+		return nil
+	},
+}
+
+// tagsResourcesCmd represents the tags resources command
+var tagsResourcesCmd = &cobra.Command{
+	Use:   "resources <tag>",
+	Short: "List the resources carrying a tag",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		resources, err := client.Tags().Resources(context.Background(), args[0])
+		if err != nil {
+			return err
+		}
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(resources, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		if len(resources) == 0 {
+			fmt.Printf("No resources with tag %s\n", args[0])
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		defer func() {
+			_ = w.Flush()
+		}()
+
+		_, _ = fmt.Fprintln(w, "UUID\tNAME\tTYPE\tSTATUS")
+		_, _ = fmt.Fprintln(w, "----\t----\t----\t------")
+		for _, resource := range resources {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+				resource.UUID, resource.Name, resource.Type, stringOrDash(&resource.Status))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tagsCmd)
+	tagsCmd.AddCommand(tagsListCmd)
+	tagsCmd.AddCommand(tagsResourcesCmd)
+
+	tagsListCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+	tagsResourcesCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
 	"github.com/spf13/cobra"
 )
 
@@ -63,7 +64,7 @@ func WithTimeout[T any](ctx context.Context, config *TimeoutConfig, operation fu
 			delay = config.MaxBackoff
 		}
 
-		fmt.Printf("⚠️  Attempt %d failed: %v. Retrying in %v...\n", attempt+1, err, delay)
+		theme.Printf("⚠️  Attempt %d failed: %v. Retrying in %v...\n", attempt+1, err, delay)
 
 		// Wait before retrying
 		select {
@@ -110,10 +111,10 @@ var timeoutSetCmd = &cobra.Command{
 		globalRetryCount = retryCount
 		globalRetryDelay = retryDelay
 
-		fmt.Printf("✅ Timeout configuration updated:\n")
-		fmt.Printf("   ⏱️  Timeout: %v\n", timeout)
-		fmt.Printf("   🔄 Retry Count: %d\n", retryCount)
-		fmt.Printf("   ⏳ Retry Delay: %v\n", retryDelay)
+		theme.Printf("✅ Timeout configuration updated:\n")
+		theme.Printf("   ⏱️  Timeout: %v\n", timeout)
+		theme.Printf("   🔄 Retry Count: %d\n", retryCount)
+		theme.Printf("   ⏳ Retry Delay: %v\n", retryDelay)
 
 		return nil
 	},
@@ -127,12 +128,12 @@ var timeoutShowCmd = &cobra.Command{
 	RunE: func(_ *cobra.Command, _ []string) error {
 		config := getTimeoutConfig()
 
-		fmt.Printf("🕐 Current Timeout Configuration\n")
+		theme.Printf("🕐 Current Timeout Configuration\n")
 		fmt.Printf("===============================\n")
-		fmt.Printf("⏱️  Timeout: %v\n", config.Timeout)
-		fmt.Printf("🔄 Retry Count: %d\n", config.RetryCount)
-		fmt.Printf("⏳ Retry Delay: %v\n", config.RetryDelay)
-		fmt.Printf("📈 Max Backoff: %v\n", config.MaxBackoff)
+		theme.Printf("⏱️  Timeout: %v\n", config.Timeout)
+		theme.Printf("🔄 Retry Count: %d\n", config.RetryCount)
+		theme.Printf("⏳ Retry Delay: %v\n", config.RetryDelay)
+		theme.Printf("📈 Max Backoff: %v\n", config.MaxBackoff)
 
 		return nil
 	},
@@ -1,33 +1,152 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/exec"
+	"runtime"
 	"strings"
+	"time"
 
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/selfupdate"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
 	"github.com/spf13/cobra"
 )
 
+// updateAvailableExitCode is the exit status of 'update --check' when a newer version exists
+const updateAvailableExitCode = 2
+
 // updateCmd represents the update command
 var updateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update coolifyme to the latest version",
 	Long: `Update coolifyme to the latest version.
 
-If installed via Homebrew, uses 'brew upgrade coolifyme'.
-Otherwise, provides instructions for manual update.`,
+If installed via Homebrew, uses 'brew upgrade coolifyme'. Otherwise the binary for this
+platform is downloaded from the GitHub release, verified against the SHA-256 checksums
+published with the release, and replaces the running executable.
+
+The stable channel only considers full releases; the beta channel also considers
+pre-releases. The default channel is set with 'coolifyme config set --update-channel'.
+
+With --check nothing is installed: the command exits with status 2 when a newer
+version is available, which makes it usable in scripts.`,
+	Example: `  # Update to the latest stable release
+  coolifyme update
+
+  # Check for a new beta release without installing it
+  coolifyme update --check --channel beta`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		force, _ := cmd.Flags().GetBool("force")
+		check, _ := cmd.Flags().GetBool("check")
+		skipVerify, _ := cmd.Flags().GetBool("skip-verify")
+		channelName, _ := cmd.Flags().GetString("channel")
+		if !cmd.Flags().Changed("channel") {
+			if cfg, err := config.LoadConfig(); err == nil {
+				channelName = updateChannel(cfg)
+			}
+		}
+		channel, err := selfupdate.ParseChannel(channelName)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		updater := selfupdate.New(&http.Client{Timeout: 2 * time.Minute})
+		release, err := updater.Latest(ctx, channel)
+		if err != nil {
+			return fmt.Errorf("failed to check for updates: %w", err)
+		}
+
+		_, versionErr := selfupdate.ParseVersion(Version)
+		outdated := versionErr == nil && selfupdate.CompareVersions(release.TagName, Version) > 0
+		theme.Printf("📦 Current version: %s\n", Version)
+		theme.Printf("🚀 Latest %s release: %s\n", channel, release.TagName)
+
+		if check {
+			switch {
+			case versionErr != nil:
+				theme.Println("⚠️  This is a development build, it cannot be compared with releases")
+			case outdated:
+				theme.Printf("💡 Run 'coolifyme update%s' to upgrade\n", channelFlagHint(channel))
+				os.Exit(updateAvailableExitCode)
+			default:
+				theme.Println("✅ coolifyme is up to date")
+			}
+			return nil
+		}
 
 		if isInstalledViaHomebrew() {
+			if channel == selfupdate.Beta {
+				warn("update", fmt.Errorf("homebrew only provides stable releases, ignoring the beta channel"))
+			}
 			return updateViaHomebrew(force)
 		}
 
-		return showManualUpdateInstructions()
+		if !outdated && !force {
+			if versionErr != nil {
+				return fmt.Errorf("this is a development build; use --force to replace it with %s", release.TagName)
+			}
+			theme.Println("✅ coolifyme is up to date")
+			return nil
+		}
+
+		return updateBinary(ctx, updater, release, skipVerify)
 	},
 }
 
+// updateBinary replaces the running executable with the binary of a release
+func updateBinary(ctx context.Context, updater *selfupdate.Updater, release *selfupdate.Release, skipVerify bool) error {
+	execPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the coolifyme executable: %w", err)
+	}
+
+	theme.Printf("🔄 Downloading coolifyme %s for %s/%s...\n", release.TagName, runtime.GOOS, runtime.GOARCH)
+	binary, err := updater.Download(ctx, release, runtime.GOOS, runtime.GOARCH, skipVerify)
+	if err != nil {
+		return err
+	}
+	if skipVerify {
+		warn("update", fmt.Errorf("checksum verification of %s skipped", release.TagName))
+	} else {
+		theme.Println("🔍 Checksum verified")
+	}
+
+	if err := selfupdate.Replace(execPath, binary); err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			_ = showManualUpdateInstructions()
+			return fmt.Errorf("no permission to replace %s; run the update with sudo or install manually: %w", execPath, err)
+		}
+		return err
+	}
+
+	theme.Printf("✅ coolifyme updated to %s\n", release.TagName)
+	return nil
+}
+
+// updateChannel returns the configured update channel
+func updateChannel(cfg *config.Config) string {
+	if cfg.UpdateChannel == "" {
+		return string(selfupdate.Stable)
+	}
+	return cfg.UpdateChannel
+}
+
+// channelFlagHint returns the --channel flag to repeat in hints for non-default channels
+func channelFlagHint(channel selfupdate.Channel) string {
+	if channel == selfupdate.Stable {
+		return ""
+	}
+	return " --channel " + string(channel)
+}
+
 // isInstalledViaHomebrew checks if coolifyme was installed via Homebrew
 func isInstalledViaHomebrew() bool {
 	// Get the path of the current executable
@@ -42,14 +161,14 @@ func isInstalledViaHomebrew() bool {
 
 // updateViaHomebrew updates coolifyme using Homebrew
 func updateViaHomebrew(force bool) error {
-	fmt.Println("🍺 Detected Homebrew installation")
+	theme.Println("🍺 Detected Homebrew installation")
 
 	var cmd *exec.Cmd
 	if force {
-		fmt.Println("🔄 Force updating coolifyme via Homebrew...")
+		theme.Println("🔄 Force updating coolifyme via Homebrew...")
 		cmd = exec.Command("brew", "upgrade", "coolifyme", "--force")
 	} else {
-		fmt.Println("🔄 Updating coolifyme via Homebrew...")
+		theme.Println("🔄 Updating coolifyme via Homebrew...")
 		cmd = exec.Command("brew", "upgrade", "coolifyme")
 	}
 
@@ -60,13 +179,13 @@ func updateViaHomebrew(force bool) error {
 		return fmt.Errorf("failed to update via Homebrew: %w", err)
 	}
 
-	fmt.Println("✅ coolifyme updated successfully via Homebrew!")
+	theme.Println("✅ coolifyme updated successfully via Homebrew!")
 	return nil
 }
 
 // showManualUpdateInstructions shows instructions for manual update
 func showManualUpdateInstructions() error {
-	fmt.Println("📦 Manual Installation Detected")
+	theme.Println("📦 Manual Installation")
 	fmt.Println("")
 	fmt.Println("To update coolifyme manually:")
 	fmt.Println("")
@@ -94,5 +213,8 @@ func showManualUpdateInstructions() error {
 }
 
 func init() {
-	updateCmd.Flags().BoolP("force", "f", false, "Force update even if already up to date (Homebrew only)")
+	updateCmd.Flags().BoolP("force", "f", false, "Force update even if already up to date")
+	updateCmd.Flags().String("channel", "", "Release channel: stable or beta (default from config, stable)")
+	updateCmd.Flags().Bool("check", false, "Only check for a newer version; exit with status 2 when one is available")
+	updateCmd.Flags().Bool("skip-verify", false, "Install without verifying the release checksums (not recommended)")
 }
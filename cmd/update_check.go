@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/selfupdate"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+// ciEnvironmentVariables are set by CI systems, where the background update check is disabled
+var ciEnvironmentVariables = []string{"CI", "GITHUB_ACTIONS", "GITLAB_CI", "BUILDKITE", "CIRCLECI", "JENKINS_URL", "TF_BUILD", "TEAMCITY_VERSION"}
+
+// updateHintWait is how long a finished command waits for a running update check
+const updateHintWait = time.Second
+
+// startUpdateCheck checks for a newer release in the background, at most once a day. The
+// returned channel receives the newer version, or is closed without one; it is nil when no
+// check runs. The check is disabled with 'config set --update-check=false',
+// COOLIFYME_NO_UPDATE_CHECK, in CI, for development builds and when stderr is not a terminal.
+func startUpdateCheck() <-chan string {
+	if _, err := selfupdate.ParseVersion(Version); err != nil || os.Getenv("COOLIFYME_NO_UPDATE_CHECK") != "" || runningInCI() || !stderrIsTerminal() {
+		return nil
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil || (cfg.UpdateCheck != nil && !*cfg.UpdateCheck) {
+		return nil
+	}
+	channel, err := selfupdate.ParseChannel(updateChannel(cfg))
+	if err != nil {
+		return nil
+	}
+	statePath, err := updateCheckStatePath()
+	if err != nil {
+		return nil
+	}
+	state := selfupdate.LoadState(statePath)
+	if !state.Due(time.Now()) {
+		return nil
+	}
+
+	newer := make(chan string, 1)
+	go func() {
+		defer close(newer)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		// A failed check is not retried before the next day either, so offline use stays fast
+		release, err := selfupdate.New(&http.Client{}).Latest(ctx, channel)
+		state.CheckedAt, state.Channel = time.Now(), channel
+		if err == nil {
+			state.Latest = release.TagName
+		}
+		_ = selfupdate.SaveState(statePath, state)
+		if err == nil && selfupdate.CompareVersions(release.TagName, Version) > 0 {
+			newer <- release.TagName
+		}
+	}()
+	return newer
+}
+
+// printUpdateHint prints an upgrade hint to stderr when the background check found a newer
+// version, unless the command was quiet or already concerned with versions
+func printUpdateHint(newer <-chan string, cmd *cobra.Command) {
+	if newer == nil || quiet || cmd == nil {
+		return
+	}
+	switch cmd.Name() {
+	case "update", "version", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return
+	}
+
+	select {
+	case latest, ok := <-newer:
+		if ok {
+			fmt.Fprint(os.Stderr, theme.Sprintf("\n💡 coolifyme %s is available (you have %s), run 'coolifyme update' to upgrade\n", latest, Version))
+		}
+	case <-time.After(updateHintWait):
+	}
+}
+
+// updateCheckStatePath returns the file recording the last background update check
+func updateCheckStatePath() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "update-check.json"), nil
+}
+
+// runningInCI reports whether coolifyme runs in a CI system
+func runningInCI() bool {
+	for _, name := range ciEnvironmentVariables {
+		if value := os.Getenv(name); value != "" && value != "false" {
+			return true
+		}
+	}
+	return false
+}
+
+// stderrIsTerminal reports whether stderr, where the update hint is printed, is a terminal
+func stderrIsTerminal() bool {
+	stat, err := os.Stderr.Stat()
+	if err != nil {
+		return false
+	}
+	return (stat.Mode() & os.ModeCharDevice) != 0
+}
@@ -5,6 +5,10 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/hongkongkiwi/coolifyme/internal/report"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
 )
 
 // safeReadFile reads a file with path validation to prevent security issues
@@ -20,3 +24,65 @@ func safeReadFile(filename string) ([]byte, error) {
 	// Read the file
 	return os.ReadFile(filename) // #nosec G304 - path is validated above
 }
+
+// printQuietUUID prints only the UUID of a newly created resource when quiet mode is enabled,
+// so create commands can be used in shell pipelines. It reports whether the output was handled.
+func printQuietUUID(uuid string) bool {
+	if !quiet {
+		return false
+	}
+	fmt.Println(uuid)
+	return true
+}
+
+// addConfirmFlags adds the uniform --force/--yes flags to a destructive command
+func addConfirmFlags(cmd *cobra.Command, usage string) {
+	cmd.Flags().BoolP("force", "f", false, usage)
+	cmd.Flags().BoolP("yes", "y", false, "Same as --force")
+}
+
+// skipConfirmation reports whether --force or --yes was given
+func skipConfirmation(cmd *cobra.Command) bool {
+	force, _ := cmd.Flags().GetBool("force")
+	yes, _ := cmd.Flags().GetBool("yes")
+	return force || yes
+}
+
+// deleteDependents describes the data removed together with a resource by the delete flags
+func deleteDependents(deleteVolumes, deleteConfigurations bool) []string {
+	var dependents []string
+	if deleteVolumes {
+		dependents = append(dependents, "all persistent volumes and their data")
+	}
+	if deleteConfigurations {
+		dependents = append(dependents, "all configuration files")
+	}
+	return dependents
+}
+
+// addReportFlags adds the flags for writing a machine-readable report file
+func addReportFlags(cmd *cobra.Command) {
+	cmd.Flags().String("report-file", "", "Write a report of the results to this file for CI systems")
+	cmd.Flags().String("report-format", "", "Report format (junit, json); inferred from the file extension by default")
+}
+
+// writeReportFile writes the report to the file given by --report-file, if any
+func writeReportFile(cmd *cobra.Command, r *report.Report) error {
+	path, _ := cmd.Flags().GetString("report-file")
+	if path == "" {
+		return nil
+	}
+
+	formatName, _ := cmd.Flags().GetString("report-format")
+	format, err := report.ParseFormat(formatName, path)
+	if err != nil {
+		return err
+	}
+
+	if err := r.WriteFile(path, format); err != nil {
+		return err
+	}
+
+	theme.Printf("📄 Report written to %s\n", path)
+	return nil
+}
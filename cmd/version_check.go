@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/logger"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// serverVersionCacheTTL is how long the version of a server is reused by the version check
+const serverVersionCacheTTL = 24 * time.Hour
+
+var (
+	// skipVersionCheck is set by --skip-version-check
+	skipVersionCheck bool
+	// versionCheckExempt is set for commands that must work on any server version, such as
+	// upgrading the instance
+	versionCheckExempt bool
+	// checkedServers holds the base URLs already checked by this process
+	checkedServers = map[string]bool{}
+)
+
+// cachedServerVersion is the version of a server recorded by the version check
+type cachedServerVersion struct {
+	Version   string    `json:"version"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// exemptFromVersionCheck reports whether a command runs regardless of the server version: the
+// api commands that inspect the server, whoami and instance upgrade
+func exemptFromVersionCheck(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == apiCmd || c == whoamiCmd || c == instanceUpgradeCmd {
+			return true
+		}
+	}
+	return false
+}
+
+// checkServerVersion compares the version of the client's server with the tested range the
+// first time a client for the server is created. The version is looked up at most once a day.
+// Servers outside the range only get a warning: every command warns about servers older than
+// the oldest supported release, whose missing endpoints are reported by the capability checks,
+// and newer servers are pointed out when the version is looked up. Lookup failures are ignored,
+// the command reports its own errors.
+func checkServerVersion(client *clientpkg.Client) {
+	if skipVersionCheck || versionCheckExempt || checkedServers[client.BaseURL()] {
+		return
+	}
+	checkedServers[client.BaseURL()] = true
+
+	cache := loadServerVersionCache()
+	cached, ok := cache[client.BaseURL()]
+	fresh := !ok || time.Since(cached.CheckedAt) > serverVersionCacheTTL
+	if fresh {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		version, err := client.System().Version(ctx)
+		if err != nil {
+			logger.Debug("Skipping the server version check", "error", err)
+			return
+		}
+		cached = cachedServerVersion{Version: version, CheckedAt: time.Now()}
+		cache[client.BaseURL()] = cached
+		saveServerVersionCache(cache)
+	}
+
+	var rangeErr *clientpkg.VersionRangeError
+	if err := clientpkg.CheckTestedVersion(cached.Version); !errors.As(err, &rangeErr) {
+		return
+	}
+	if rangeErr.TooOld {
+		warn("server version", fmt.Errorf("%w; some commands may fail, upgrade Coolify (coolifyme instance upgrade)", rangeErr))
+		return
+	}
+	if fresh {
+		warn("server version", fmt.Errorf("%w; some commands may fail, check for a newer coolifyme with 'coolifyme update --check'", rangeErr))
+	}
+}
+
+// serverVersionCachePath returns the file caching the versions of recently used servers
+func serverVersionCachePath() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "server-versions.json"), nil
+}
+
+// loadServerVersionCache reads the cache, treating unreadable caches as empty
+func loadServerVersionCache() map[string]cachedServerVersion {
+	cache := make(map[string]cachedServerVersion)
+	path, err := serverVersionCachePath()
+	if err != nil {
+		return cache
+	}
+	data, err := os.ReadFile(path) // #nosec G304 - path is derived from the user's config directory
+	if err != nil {
+		return cache
+	}
+	_ = json.Unmarshal(data, &cache)
+	return cache
+}
+
+// saveServerVersionCache writes the cache; it only saves requests, so errors are ignored
+func saveServerVersionCache(cache map[string]cachedServerVersion) {
+	path, err := serverVersionCachePath()
+	if err != nil {
+		return
+	}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
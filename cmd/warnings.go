@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/hongkongkiwi/coolifyme/internal/warnings"
+)
+
+var (
+	// commandWarnings collects the non-fatal problems of the running command
+	commandWarnings warnings.Collector
+	// failOnWarn makes a command that recorded warnings exit with an error
+	failOnWarn bool
+)
+
+// warn records a non-fatal problem of the command. It is printed after the command output, or
+// included in JSON output by commands that call commandWarnings.Take.
+func warn(source string, err error) {
+	commandWarnings.Add(source, err)
+}
+
+// printWarnings writes the warnings not included in the command output to standard error. Quiet
+// output leaves them out unless they fail the command.
+func printWarnings() {
+	pending := commandWarnings.Take()
+	if len(pending) == 0 || (quiet && !failOnWarn) {
+		return
+	}
+	fmt.Fprint(os.Stderr, theme.Sprintf("\n⚠️  %d warning(s):\n", len(pending)))
+	for _, w := range pending {
+		fmt.Fprintf(os.Stderr, "   - %s\n", w)
+	}
+}
+
+// warningsError returns an error when the command recorded warnings and --fail-on-warn is set
+func warningsError() error {
+	if n := commandWarnings.Len(); failOnWarn && n > 0 {
+		return fmt.Errorf("%d warning(s) with --fail-on-warn", n)
+	}
+	return nil
+}
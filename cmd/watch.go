@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+// defaultWatchRules are watched when the config file has no alert rules: every application,
+// service and server
+var defaultWatchRules = []config.AlertRule{
+	{Name: "app-down", Condition: config.ConditionAppStatus},
+	{Name: "service-down", Condition: config.ConditionServiceStatus},
+	{Name: "deployment-failed", Condition: config.ConditionDeploymentFailed},
+	{Name: "server-unreachable", Condition: config.ConditionServerUnreachable},
+}
+
+// watchParentCmd represents the watch command
+var watchParentCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch resources for status changes",
+	Long:  "Watch Coolify resources and report changes of their status as they happen",
+}
+
+// watchResourcesCmd represents the watch resources command
+var watchResourcesCmd = &cobra.Command{
+	Use:   "resources",
+	Short: "Report alerts of applications, services and servers as they fire and clear",
+	Long: `Poll applications, services and servers and report the alert rules configured for
+'monitor run' as they fire and clear: an application or service going down or coming back up,
+a new deployment that failed and a server becoming unreachable or reachable again. The first
+poll records the current state, so only changes after the start are reported. With --notify
+every report is also shown as a desktop notification (osascript on macOS, notify-send on Linux).
+
+The rules come from 'alerts' in the config file (see 'coolifyme monitor run --help'); their
+actions are only run by 'monitor run'. Without rules every application, service and server is
+watched.
+
+--json prints one event per line in the format described by 'coolifyme schema events'.
+
+Examples:
+  coolifyme watch resources --notify
+  coolifyme watch resources --rule app-down --interval 60
+  coolifyme watch resources --json >> alerts.log`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		notify, _ := cmd.Flags().GetBool("notify")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		selected, _ := cmd.Flags().GetStringSlice("rule")
+		interval, _ := cmd.Flags().GetInt("interval")
+		if interval < 1 {
+			interval = 30 // Default 30 seconds
+		}
+
+		rules, err := config.GetAlertRules()
+		if err != nil {
+			return fmt.Errorf("failed to load alert rules: %w", err)
+		}
+		for _, rule := range rules {
+			if err := config.ValidateAlertRule(rule); err != nil {
+				return fmt.Errorf("invalid alert rule '%s': %w", rule.Name, err)
+			}
+		}
+		if len(selected) > 0 {
+			for _, name := range selected {
+				if !slices.ContainsFunc(rules, func(rule config.AlertRule) bool { return rule.Name == name }) {
+					return fmt.Errorf("no alert rule named '%s' in the config file", name)
+				}
+			}
+			rules = slices.DeleteFunc(rules, func(rule config.AlertRule) bool { return !slices.Contains(selected, rule.Name) })
+		}
+		if len(rules) == 0 {
+			rules = defaultWatchRules
+		}
+
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		tracker := &alertTracker{counts: make(map[string]int)}
+		started := false
+		return WatchLoop(context.Background(), getWatchConfig(cmd, time.Duration(interval)*time.Second), func(ctx context.Context) (bool, error) {
+			alerts, err := evaluateAlertRules(ctx, client, rules, tracker, false)
+			if err != nil {
+				return false, err
+			}
+			if !started {
+				started = true
+				if !jsonOutput {
+					theme.Printf("👀 Watching %d alert rule(s) every %ds (Ctrl+C to stop)...\n", len(rules), interval)
+				}
+				return false, nil
+			}
+
+			for _, alert := range alerts {
+				if jsonOutput {
+					line, err := json.Marshal(alert)
+					if err != nil {
+						return true, fmt.Errorf("failed to marshal JSON: %w", err)
+					}
+					fmt.Println(string(line))
+				} else {
+					emoji := "🚨"
+					if alert.Resolved {
+						emoji = "✅"
+					}
+					theme.Printf("%s %s [%s] %s\n", alert.Time.Format("2006-01-02 15:04:05"), emoji, alert.Rule, alert.Message)
+				}
+				if notify {
+					_ = showDesktopNotification(ctx, "coolifyme: "+alert.Rule, alert.Message)
+				}
+			}
+			return false, nil
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(watchParentCmd)
+	watchParentCmd.AddCommand(watchResourcesCmd)
+
+	watchResourcesCmd.Flags().Bool("notify", false, "Show a desktop notification for every alert that fires or clears")
+	watchResourcesCmd.Flags().StringSlice("rule", nil, "Only watch the alert rules with these names from the config file")
+	watchResourcesCmd.Flags().IntP("interval", "i", 30, "Poll interval in seconds")
+	watchResourcesCmd.Flags().BoolP("json", "j", false, "Print alerts as JSON lines")
+	addWatchFlags(watchResourcesCmd)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// WatchConfig controls the polling loop shared by all watch and follow commands
+type WatchConfig struct {
+	// Interval is the time between two polls
+	Interval time.Duration
+	// MaxRetries is the number of consecutive failed polls tolerated before giving up
+	MaxRetries int
+	// RetryDelay is the wait before the first reconnection attempt; it doubles with every failure
+	RetryDelay time.Duration
+	// MaxBackoff caps the wait between reconnection attempts
+	MaxBackoff time.Duration
+}
+
+// getWatchConfig returns the watch configuration of a command with the given default interval
+func getWatchConfig(cmd *cobra.Command, interval time.Duration) *WatchConfig {
+	config := &WatchConfig{
+		Interval:   interval,
+		MaxRetries: 10,
+		RetryDelay: 1 * time.Second,
+		MaxBackoff: 30 * time.Second,
+	}
+	if maxRetries, err := cmd.Flags().GetInt("max-retries"); err == nil && maxRetries >= 0 {
+		config.MaxRetries = maxRetries
+	}
+	return config
+}
+
+// addWatchFlags adds the reconnection flags to a watch or follow command
+func addWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("max-retries", 10, "Consecutive connection failures tolerated before giving up")
+}
+
+// WatchLoop calls poll every interval until it reports done or the user presses Ctrl+C. When poll
+// fails without being done, the loop shows a reconnecting status and retries with exponential
+// backoff, giving up after MaxRetries consecutive failures. Errors that retrying cannot fix, API
+// responses with a 4xx status other than 429, end the loop at once. The error of a poll that is
+// done is returned as is, so pollers can end the loop with a final result.
+func WatchLoop(ctx context.Context, config *WatchConfig, poll func(context.Context) (bool, error)) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	failures := 0
+	for {
+		done, err := poll(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if done {
+			return err
+		}
+
+		wait := config.Interval
+		if err != nil {
+			if permanentWatchError(err) {
+				return err
+			}
+			failures++
+			if failures > config.MaxRetries {
+				return fmt.Errorf("giving up after %d failed attempts: %w", failures, err)
+			}
+
+			wait = config.RetryDelay * time.Duration(1<<min(failures-1, 16)) // Exponential backoff
+			if wait > config.MaxBackoff {
+				wait = config.MaxBackoff
+			}
+			theme.Printf("🔌 Connection lost: %v\n", err)
+			theme.Printf("🔄 Reconnecting in %v (attempt %d/%d)...\n", wait, failures, config.MaxRetries)
+		} else if failures > 0 {
+			theme.Printf("✅ Reconnected\n")
+			failures = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// permanentWatchError reports whether a failed poll cannot succeed when retried: the API rejected
+// the request with a client error such as 401 or 404, other than 429 Too Many Requests
+func permanentWatchError(err error) bool {
+	status := clientpkg.StatusCode(err)
+	return status >= 400 && status < 500 && status != http.StatusTooManyRequests
+}
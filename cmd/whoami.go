@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+	clientpkg "github.com/hongkongkiwi/coolifyme/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// whoamiCacheTTL is how long the identity of a token is reused by permission checks
+const whoamiCacheTTL = time.Hour
+
+// identity is what whoami reports about the current profile and token
+type identity struct {
+	Profile    string               `json:"profile,omitempty"`
+	BaseURL    string               `json:"base_url"`
+	APIVersion string               `json:"api_version,omitempty"`
+	Scope      string               `json:"scope"`
+	Token      *clientpkg.TokenInfo `json:"token"`
+	CheckedAt  time.Time            `json:"checked_at"`
+}
+
+// whoamiCmd represents the whoami command
+var whoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Show the team, token permissions and API version of the current profile",
+	Long: `Show which Coolify instance and team the current profile talks to, what kind of token it
+uses (root, read-only or a list of permissions) and the API version of the server.
+
+The result is cached for an hour and used by destructive commands such as 'servers delete' to
+fail early with a clear message when the token lacks the permission, instead of a 403 from the
+API. Running whoami always checks again and refreshes the cache.
+
+Examples:
+  coolifyme whoami
+  coolifyme whoami --profile production --json`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		client, err := createClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		id, err := lookupIdentity(context.Background(), client)
+		if err != nil {
+			return err
+		}
+		saveCachedIdentity(client, id)
+
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if jsonOutput {
+			output, err := json.MarshalIndent(id, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		theme.Println("👤 Who Am I")
+		fmt.Println("===========")
+		if id.Profile != "" {
+			fmt.Printf("Profile:     %s\n", id.Profile)
+		}
+		fmt.Printf("API URL:     %s\n", id.BaseURL)
+		if id.Token.TeamName != "" {
+			fmt.Printf("Team:        %s (ID %d)\n", id.Token.TeamName, id.Token.TeamID)
+		}
+		fmt.Printf("Token:       %s\n", id.Scope)
+		fmt.Printf("API version: %s\n", stringOrDash(&id.APIVersion))
+		fmt.Println()
+		printAbilities(id.Token)
+		return nil
+	},
+}
+
+// lookupIdentity asks the server about the client's token and version
+func lookupIdentity(ctx context.Context, client *clientpkg.Client) (*identity, error) {
+	info, err := client.TokenInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id := &identity{
+		Profile:   activeProfileName(),
+		BaseURL:   client.BaseURL(),
+		Scope:     info.Scope(),
+		Token:     info,
+		CheckedAt: time.Now(),
+	}
+	// Tokens without the read permission cannot read the version either
+	if version, err := client.System().Version(ctx); err == nil {
+		id.APIVersion = version
+	}
+	return id, nil
+}
+
+// checkTokenAbility fails when the token is known to lack an ability the command needs, so
+// destructive commands explain the problem before asking for confirmation. Only the identity
+// cached by whoami is consulted, so no probe requests are sent; without a cached identity the
+// command runs and a 403 from the API is reported as a permission error.
+func checkTokenAbility(cmd *cobra.Command, client *clientpkg.Client, ability string) error {
+	id := loadCachedIdentity(client)
+	if id == nil || !id.Token.Lacks(ability) {
+		return nil
+	}
+
+	action := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	return fmt.Errorf("your token is %s; %s requires a token with the '%s' permission, such as a root token (run 'coolifyme whoami' to check again after changing it)",
+		describeScope(id.Scope), action, ability)
+}
+
+// describeScope phrases a token scope for error messages
+func describeScope(scope string) string {
+	switch scope {
+	case "read-only":
+		return scope
+	case "none":
+		return "not allowed to do anything"
+	}
+	return "limited to " + scope
+}
+
+// activeProfileName returns the name of the profile commands use
+func activeProfileName() string {
+	if profile != "" {
+		return profile
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return ""
+	}
+	return cfg.Profile
+}
+
+// printAbilities prints whether the token has each ability
+func printAbilities(info *clientpkg.TokenInfo) {
+	for _, check := range info.Abilities {
+		switch {
+		case check.Granted == nil:
+			line := fmt.Sprintf("❓ %-15s unknown", check.Ability)
+			if check.Detail != "" {
+				line += " (" + check.Detail + ")"
+			}
+			theme.Println(line)
+		case *check.Granted:
+			theme.Printf("✅ %-15s granted\n", check.Ability)
+		default:
+			theme.Printf("❌ %-15s missing\n", check.Ability)
+		}
+	}
+}
+
+// whoamiCachePath returns the file caching the identity of recently used tokens
+func whoamiCachePath() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "whoami-cache.json"), nil
+}
+
+// whoamiCacheKey identifies a token on an instance; the token itself is never stored
+func whoamiCacheKey(client *clientpkg.Client) string {
+	return client.BaseURL() + "#" + client.TokenFingerprint()
+}
+
+// loadWhoamiCache reads the cache, treating unreadable caches as empty
+func loadWhoamiCache() map[string]*identity {
+	cache := make(map[string]*identity)
+	path, err := whoamiCachePath()
+	if err != nil {
+		return cache
+	}
+	data, err := os.ReadFile(path) // #nosec G304 - path is derived from the user's config directory
+	if err != nil {
+		return cache
+	}
+	_ = json.Unmarshal(data, &cache)
+	return cache
+}
+
+// loadCachedIdentity returns the cached identity of the client's token, or nil when there is
+// none or it expired
+func loadCachedIdentity(client *clientpkg.Client) *identity {
+	id := loadWhoamiCache()[whoamiCacheKey(client)]
+	if id == nil || id.Token == nil || time.Since(id.CheckedAt) > whoamiCacheTTL {
+		return nil
+	}
+	return id
+}
+
+// saveCachedIdentity stores the identity of the client's token and drops expired entries; the
+// cache only saves requests, so errors are ignored
+func saveCachedIdentity(client *clientpkg.Client, id *identity) {
+	path, err := whoamiCachePath()
+	if err != nil {
+		return
+	}
+	cache := loadWhoamiCache()
+	for key, cached := range cache {
+		if cached == nil || time.Since(cached.CheckedAt) > whoamiCacheTTL {
+			delete(cache, key)
+		}
+	}
+	cache[whoamiCacheKey(client)] = id
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
+
+func init() {
+	rootCmd.AddCommand(whoamiCmd)
+	whoamiCmd.Flags().BoolP("json", "j", false, "Output in JSON format")
+}
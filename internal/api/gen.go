@@ -0,0 +1,11 @@
+// Package coolify is the Coolify API client generated by oapi-codegen from the OpenAPI
+// specification in spec/coolify-openapi.yaml.
+//
+// The generated code in coolify_client.go must not be edited by hand. To update it, fetch the
+// latest specification with 'task update-spec' and regenerate with 'task generate' (or
+// 'go generate ./internal/api'). Behavior that the generated code does not cover, such as
+// extra headers or response handling, belongs in pkg/client, which wraps this package and
+// exposes request and response hooks.
+package coolify
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.5.1 -config oapi-codegen.yaml ../../spec/coolify-openapi.yaml
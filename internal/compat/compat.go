@@ -0,0 +1,184 @@
+// Package compat compares Coolify OpenAPI specifications to detect API drift between the CLI and a server.
+package compat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kind represents what part of the API changed
+type Kind string
+
+const (
+	// KindEndpoint marks a difference in the available endpoints
+	KindEndpoint Kind = "endpoint"
+	// KindField marks a difference in the fields of a schema
+	KindField Kind = "field"
+)
+
+// Change represents how the server differs from the CLI
+type Change string
+
+const (
+	// ChangeMissing marks something the CLI knows about that the server does not provide
+	ChangeMissing Change = "missing"
+	// ChangeNewer marks something the server provides that the CLI does not know about
+	ChangeNewer Change = "newer"
+)
+
+// httpMethods lists the operation keys of an OpenAPI path item
+var httpMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true, "patch": true, "head": true, "options": true,
+}
+
+// Spec is the subset of an OpenAPI document needed to compare API surfaces
+type Spec struct {
+	Version   string
+	Endpoints map[string]bool
+	Fields    map[string]bool
+}
+
+// Difference describes a single endpoint or field that differs between two specifications
+type Difference struct {
+	Kind   Kind   `json:"kind"`
+	Change Change `json:"change"`
+	Name   string `json:"name"`
+}
+
+// Parse reads an OpenAPI document in YAML or JSON format
+func Parse(data []byte) (*Spec, error) {
+	var doc struct {
+		Info struct {
+			Version string `yaml:"version"`
+		} `yaml:"info"`
+		Paths      map[string]map[string]interface{} `yaml:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]interface{} `yaml:"properties"`
+			} `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+	if len(doc.Paths) == 0 {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: no paths found")
+	}
+
+	spec := &Spec{
+		Version:   doc.Info.Version,
+		Endpoints: make(map[string]bool),
+		Fields:    make(map[string]bool),
+	}
+	for path, item := range doc.Paths {
+		for method := range item {
+			if httpMethods[strings.ToLower(method)] {
+				spec.Endpoints[strings.ToUpper(method)+" "+path] = true
+			}
+		}
+	}
+	for schema, definition := range doc.Components.Schemas {
+		for property := range definition.Properties {
+			spec.Fields[schema+"."+property] = true
+		}
+	}
+	return spec, nil
+}
+
+// Compare reports the endpoints and fields of the client specification that are missing on the
+// server and those the server provides that the client does not know about
+func Compare(client, server *Spec) []Difference {
+	var diffs []Difference
+	diffs = append(diffs, diffSets(KindEndpoint, client.Endpoints, server.Endpoints)...)
+	diffs = append(diffs, diffSets(KindField, client.Fields, server.Fields)...)
+	return diffs
+}
+
+// Count returns the number of differences of the given change
+func Count(diffs []Difference, change Change) int {
+	count := 0
+	for _, diff := range diffs {
+		if diff.Change == change {
+			count++
+		}
+	}
+	return count
+}
+
+// diffSets compares two sets of names of the same kind
+func diffSets(kind Kind, client, server map[string]bool) []Difference {
+	var diffs []Difference
+	for _, name := range sortedNames(client) {
+		if !server[name] {
+			diffs = append(diffs, Difference{Kind: kind, Change: ChangeMissing, Name: name})
+		}
+	}
+	for _, name := range sortedNames(server) {
+		if !client[name] {
+			diffs = append(diffs, Difference{Kind: kind, Change: ChangeNewer, Name: name})
+		}
+	}
+	return diffs
+}
+
+func sortedNames(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Operation is an operation of an OpenAPI document
+type Operation struct {
+	// ID is the operationId, which the generated client method is named after
+	ID      string `json:"id"`
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	Summary string `json:"summary,omitempty"`
+}
+
+// ParseOperations returns the operations of an OpenAPI document in YAML or JSON format, sorted by
+// path and method
+func ParseOperations(data []byte) ([]Operation, error) {
+	// Path items also hold non-operation keys such as parameters, so operations are decoded one by one
+	var doc struct {
+		Paths map[string]map[string]yaml.Node `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+
+	var operations []Operation
+	for path, item := range doc.Paths {
+		for method, node := range item {
+			if !httpMethods[strings.ToLower(method)] {
+				continue
+			}
+			var operation struct {
+				OperationID string `yaml:"operationId"`
+				Summary     string `yaml:"summary"`
+			}
+			if err := node.Decode(&operation); err != nil {
+				return nil, fmt.Errorf("failed to parse %s %s: %w", strings.ToUpper(method), path, err)
+			}
+			operations = append(operations, Operation{
+				ID:      operation.OperationID,
+				Method:  strings.ToUpper(method),
+				Path:    path,
+				Summary: operation.Summary,
+			})
+		}
+	}
+	sort.Slice(operations, func(i, j int) bool {
+		if operations[i].Path != operations[j].Path {
+			return operations[i].Path < operations[j].Path
+		}
+		return operations[i].Method < operations[j].Method
+	})
+	return operations, nil
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Alert rule conditions understood by 'coolifyme monitor run' and 'coolifyme watch resources'
+const (
+	// ConditionAppStatus fires when an application status does not start with the expected status
+	ConditionAppStatus = "app_status"
+	// ConditionServiceStatus fires when a container of a service does not have the expected status
+	ConditionServiceStatus = "service_status"
+	// ConditionDeploymentFailed fires when the latest deployment of an application failed
+	ConditionDeploymentFailed = "deployment_failed"
+	// ConditionServerUnreachable fires when Coolify reports a server as unreachable
+	ConditionServerUnreachable = "server_unreachable"
+)
+
+// AlertConditions are the conditions an alert rule can check
+var AlertConditions = []string{ConditionAppStatus, ConditionServiceStatus, ConditionDeploymentFailed, ConditionServerUnreachable}
+
+// AlertRule describes a condition checked by the monitoring loop and the actions to take when it fires
+type AlertRule struct {
+	Name      string `yaml:"name" mapstructure:"name"`
+	Condition string `yaml:"condition" mapstructure:"condition"`
+	// Resource limits the rule to an application, service or server by UUID or name; empty matches all
+	Resource string `yaml:"resource,omitempty" mapstructure:"resource"`
+	// Status is the expected status for app_status and service_status rules (default "running")
+	Status string `yaml:"status,omitempty" mapstructure:"status"`
+	// For is the number of consecutive intervals the condition must hold before firing (default 1)
+	For     int           `yaml:"for,omitempty" mapstructure:"for"`
+	Actions []AlertAction `yaml:"actions" mapstructure:"actions"`
+}
+
+// AlertAction is an action taken when an alert rule fires. Exactly one field should be set.
+type AlertAction struct {
+	// Exec is a shell command to run; alert details are passed in COOLIFYME_ALERT_* variables
+	Exec string `yaml:"exec,omitempty" mapstructure:"exec"`
+	// Webhook is a URL that receives the alert as a JSON POST request
+	Webhook string `yaml:"webhook,omitempty" mapstructure:"webhook"`
+	// Notify shows a desktop notification
+	Notify bool `yaml:"notify,omitempty" mapstructure:"notify"`
+}
+
+// GetAlertRules returns the alert rules of the configuration file
+func GetAlertRules() ([]AlertRule, error) {
+	configFile, err := loadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	return configFile.Alerts, nil
+}
+
+// ValidateAlertRule checks that an alert rule has a known condition and usable actions
+func ValidateAlertRule(rule AlertRule) error {
+	if rule.Name == "" {
+		return fmt.Errorf("alert rule name cannot be empty")
+	}
+
+	if !slices.Contains(AlertConditions, rule.Condition) {
+		return fmt.Errorf("unknown condition '%s' (expected %s)", rule.Condition, strings.Join(AlertConditions, ", "))
+	}
+
+	if rule.For < 0 {
+		return fmt.Errorf("'for' cannot be negative")
+	}
+	if len(rule.Actions) == 0 {
+		return fmt.Errorf("at least one action is required")
+	}
+
+	for _, action := range rule.Actions {
+		set := 0
+		if action.Exec != "" {
+			set++
+		}
+		if action.Webhook != "" {
+			set++
+			parsed, err := url.Parse(action.Webhook)
+			if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+				return fmt.Errorf("webhook %q is not a valid http(s) URL", action.Webhook)
+			}
+		}
+		if action.Notify {
+			set++
+		}
+		if set != 1 {
+			return fmt.Errorf("each action must set exactly one of exec, webhook or notify")
+		}
+	}
+	return nil
+}
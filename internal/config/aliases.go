@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// aliasNamePattern matches valid user-defined alias names
+var aliasNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+// ValidateAliasName validates a user-defined alias name
+func ValidateAliasName(name string) error {
+	if name == "" {
+		return fmt.Errorf("alias name cannot be empty")
+	}
+	if !aliasNamePattern.MatchString(name) {
+		return fmt.Errorf("alias name must start with a letter and contain only letters, digits, '-' and '_'")
+	}
+	return nil
+}
+
+// GetAliases returns the user-defined command aliases
+func GetAliases() (map[string]string, error) {
+	configFile, err := loadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if configFile.Aliases == nil {
+		return map[string]string{}, nil
+	}
+	return configFile.Aliases, nil
+}
+
+// SetAlias creates or replaces a user-defined command alias
+func SetAlias(name, expansion string) error {
+	if err := ValidateAliasName(name); err != nil {
+		return err
+	}
+	if strings.TrimSpace(expansion) == "" {
+		return fmt.Errorf("alias expansion cannot be empty")
+	}
+
+	configFile, err := loadConfigFile()
+	if err != nil {
+		return fmt.Errorf("no configuration file found")
+	}
+
+	if configFile.Aliases == nil {
+		configFile.Aliases = make(map[string]string)
+	}
+	configFile.Aliases[strings.ToLower(name)] = expansion
+
+	return saveConfigFile(configFile)
+}
+
+// RemoveAlias deletes a user-defined command alias
+func RemoveAlias(name string) error {
+	configFile, err := loadConfigFile()
+	if err != nil {
+		return fmt.Errorf("no configuration file found")
+	}
+
+	name = strings.ToLower(name)
+	if _, exists := configFile.Aliases[name]; !exists {
+		return fmt.Errorf("alias '%s' does not exist", name)
+	}
+
+	delete(configFile.Aliases, name)
+	return saveConfigFile(configFile)
+}
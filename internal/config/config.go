@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 // Config holds the application configuration
@@ -15,10 +16,24 @@ type Config struct {
 	APIToken string `mapstructure:"api_token"`
 	BaseURL  string `mapstructure:"base_url"`
 	Profile  string `mapstructure:"profile"`
+	// Headers are extra HTTP headers sent with every API request
+	Headers map[string]string `mapstructure:"headers"`
+	// ReadOnly rejects every API request that would change something
+	ReadOnly bool `mapstructure:"read_only"`
 	// Output format preferences
 	OutputFormat string `mapstructure:"output_format"` // json, yaml, table
 	ColorOutput  *bool  `mapstructure:"color_output"`
 	LogLevel     string `mapstructure:"log_level"` // debug, info, warn, error
+	// ConfirmByName requires typing the resource name instead of "yes" to confirm deletes
+	ConfirmByName bool `mapstructure:"confirm_by_name"`
+	// UpdateChannel is the release channel used by 'coolifyme update' (stable or beta)
+	UpdateChannel string `mapstructure:"update_channel"`
+	// UpdateCheck enables the daily background check for new versions (default on)
+	UpdateCheck *bool `mapstructure:"update_check"`
+	// History records the commands run in the history file for 'coolifyme history' (default off)
+	History bool `mapstructure:"history"`
+	// MaxResponseMB is the largest API response body accepted in MiB, 0 for no limit (default 256)
+	MaxResponseMB *int64 `mapstructure:"max_response_mb"`
 }
 
 // Profile represents a configuration profile
@@ -26,17 +41,41 @@ type Profile struct {
 	Name     string `yaml:"name" mapstructure:"name"`
 	APIToken string `yaml:"api_token" mapstructure:"api_token"`
 	BaseURL  string `yaml:"base_url" mapstructure:"base_url"`
+	// Headers are extra HTTP headers sent with every API request, e.g. the
+	// CF-Access-Client-Id and CF-Access-Client-Secret of a Cloudflare Access proxy
+	Headers map[string]string `yaml:"headers,omitempty" mapstructure:"headers"`
+	// ReadOnly makes the CLI refuse every API request that would change something, so the
+	// profile can be handed out for production without risk of destructive commands
+	ReadOnly bool `yaml:"read_only,omitempty" mapstructure:"read_only"`
 }
 
 // File represents the entire configuration file structure
 type File struct {
+	Version        int                `yaml:"version" mapstructure:"version"`
 	DefaultProfile string             `yaml:"default_profile" mapstructure:"default_profile"`
 	Profiles       map[string]Profile `yaml:"profiles" mapstructure:"profiles"`
 	GlobalSettings struct {
-		OutputFormat string `yaml:"output_format,omitempty" mapstructure:"output_format"`
-		ColorOutput  *bool  `yaml:"color_output,omitempty" mapstructure:"color_output"`
-		LogLevel     string `yaml:"log_level,omitempty" mapstructure:"log_level"`
+		OutputFormat  string `yaml:"output_format,omitempty" mapstructure:"output_format"`
+		ColorOutput   *bool  `yaml:"color_output,omitempty" mapstructure:"color_output"`
+		LogLevel      string `yaml:"log_level,omitempty" mapstructure:"log_level"`
+		ConfirmByName bool   `yaml:"confirm_by_name,omitempty" mapstructure:"confirm_by_name"`
+		UpdateChannel string `yaml:"update_channel,omitempty" mapstructure:"update_channel"`
+		UpdateCheck   *bool  `yaml:"update_check,omitempty" mapstructure:"update_check"`
+		History       bool   `yaml:"history,omitempty" mapstructure:"history"`
+		MaxResponseMB *int64 `yaml:"max_response_mb,omitempty" mapstructure:"max_response_mb"`
 	} `yaml:"global_settings,omitempty" mapstructure:"global_settings"`
+	// Defaults maps a command path (e.g. "applications list") to default flag values
+	Defaults map[string]map[string]interface{} `yaml:"defaults,omitempty" mapstructure:"defaults"`
+	// Aliases maps a user-defined alias name to the command line it expands to
+	Aliases map[string]string `yaml:"aliases,omitempty" mapstructure:"aliases"`
+	// Alerts are the rules evaluated by 'coolifyme monitor run' and 'coolifyme watch resources'
+	Alerts []AlertRule `yaml:"alerts,omitempty" mapstructure:"alerts"`
+	// Hooks are shell commands run around deployments
+	Hooks Hooks `yaml:"hooks,omitempty" mapstructure:"hooks"`
+	// VarSets are named sets of environment variables shared by several applications
+	VarSets map[string]VarSet `yaml:"varsets,omitempty" mapstructure:"varsets"`
+	// Templates are named Go templates used with --output template=@name
+	Templates map[string]string `yaml:"templates,omitempty" mapstructure:"templates"`
 }
 
 const (
@@ -76,6 +115,13 @@ func LoadConfig() (*Config, error) {
 	// Get the active profile name from environment or default
 	profileName := v.GetString("profile")
 
+	// The overlay of the working directory selects the profile unless the environment does
+	if os.Getenv("COOLIFYME_PROFILE") == "" && os.Getenv("COOLIFY_PROFILE") == "" {
+		if local, err := LoadLocal(); err == nil && local != nil && local.Profile != "" {
+			profileName = local.Profile
+		}
+	}
+
 	// Try to load the config file to get the default profile
 	configFile, configFileErr := loadConfigFile()
 	if configFileErr == nil {
@@ -113,6 +159,8 @@ func LoadConfig() (*Config, error) {
 		if profileConfig, err := LoadProfile(profileName); err == nil {
 			config.APIToken = profileConfig.APIToken
 			config.BaseURL = profileConfig.BaseURL
+			config.Headers = profileConfig.Headers
+			config.ReadOnly = profileConfig.ReadOnly
 		}
 
 		// Load global settings from config file
@@ -127,6 +175,11 @@ func LoadConfig() (*Config, error) {
 		if configFile.GlobalSettings.ColorOutput != nil {
 			config.ColorOutput = configFile.GlobalSettings.ColorOutput
 		}
+		config.ConfirmByName = configFile.GlobalSettings.ConfirmByName
+		config.UpdateChannel = configFile.GlobalSettings.UpdateChannel
+		config.UpdateCheck = configFile.GlobalSettings.UpdateCheck
+		config.History = configFile.GlobalSettings.History
+		config.MaxResponseMB = configFile.GlobalSettings.MaxResponseMB
 	}
 
 	// Command-line flags and environment variables override profile settings
@@ -178,11 +231,17 @@ func SaveConfig(config *Config) error {
 		config.Profile = profileName
 	}
 
-	// Update or create the profile
+	// Update or create the profile, keeping its headers unless new ones are given. Read-only mode
+	// is only changed by editing the file, so it cannot be lifted by a command.
 	profile := Profile{
 		Name:     profileName,
 		APIToken: config.APIToken,
 		BaseURL:  config.BaseURL,
+		Headers:  configFile.Profiles[profileName].Headers,
+		ReadOnly: configFile.Profiles[profileName].ReadOnly,
+	}
+	if config.Headers != nil {
+		profile.Headers = config.Headers
 	}
 
 	if configFile.Profiles == nil {
@@ -194,6 +253,11 @@ func SaveConfig(config *Config) error {
 	configFile.GlobalSettings.OutputFormat = config.OutputFormat
 	configFile.GlobalSettings.ColorOutput = config.ColorOutput
 	configFile.GlobalSettings.LogLevel = config.LogLevel
+	configFile.GlobalSettings.ConfirmByName = config.ConfirmByName
+	configFile.GlobalSettings.UpdateChannel = config.UpdateChannel
+	configFile.GlobalSettings.UpdateCheck = config.UpdateCheck
+	configFile.GlobalSettings.History = config.History
+	configFile.GlobalSettings.MaxResponseMB = config.MaxResponseMB
 
 	// Set as default profile if it's the only one or if we're saving the default profile
 	if len(configFile.Profiles) == 1 || configFile.DefaultProfile == "" || profileName == DefaultProfileName {
@@ -247,6 +311,43 @@ func CreateProfile(name, apiToken, baseURL string) error {
 	return saveConfigFile(configFile)
 }
 
+// SaveProfile creates a profile or replaces the token and base URL of an existing one, keeping
+// its other settings such as headers and read-only mode. It reports whether the profile was
+// created. The first profile of a new configuration becomes the default profile.
+func SaveProfile(name, apiToken, baseURL string) (bool, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return false, err
+	}
+
+	configFile, err := loadConfigFile()
+	if err != nil {
+		// Start a new file only when there is none, never replace one that failed to load
+		configPath, pathErr := getConfigFilePath()
+		if pathErr != nil {
+			return false, pathErr
+		}
+		if _, statErr := os.Stat(configPath); !os.IsNotExist(statErr) {
+			return false, err
+		}
+		configFile = &File{}
+	}
+	if configFile.Profiles == nil {
+		configFile.Profiles = make(map[string]Profile)
+	}
+
+	profile, exists := configFile.Profiles[name]
+	profile.Name = name
+	profile.APIToken = apiToken
+	profile.BaseURL = baseURL
+	configFile.Profiles[name] = profile
+
+	if _, ok := configFile.Profiles[configFile.DefaultProfile]; !ok {
+		configFile.DefaultProfile = name
+	}
+
+	return !exists, saveConfigFile(configFile)
+}
+
 // DeleteProfile deletes a profile
 func DeleteProfile(name string) error {
 	if name == "default" {
@@ -314,6 +415,19 @@ func SetDefaultProfile(name string) error {
 
 // loadConfigFile loads the configuration file structure
 func loadConfigFile() (*File, error) {
+	configFile, err := readConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	// Upgrade older configuration files in memory; the file itself is only rewritten by the next
+	// change of a setting or by 'config migrate'
+	migrateConfigFile(configFile)
+	return configFile, nil
+}
+
+// readConfigFile reads the configuration file in the schema version it was written in
+func readConfigFile() (*File, error) {
 	configPath, err := getConfigFilePath()
 	if err != nil {
 		return nil, err
@@ -335,6 +449,8 @@ func loadConfigFile() (*File, error) {
 	if err := v.Unmarshal(&configFile); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config file: %w", err)
 	}
+	// Viper lowercases keys, but environment variable names are case sensitive
+	configFile.VarSets = readVarSets(configPath)
 
 	return &configFile, nil
 }
@@ -356,8 +472,15 @@ func saveConfigFile(configFile *File) error {
 	v := viper.New()
 	v.SetConfigFile(configPath)
 	v.SetConfigType("yaml")
+	v.SetConfigPermissions(0o600)
+
+	// Preserve settings that are not part of the File structure, such as defaults for global flags
+	for key, value := range readExtraKeys(configPath) {
+		v.Set(key, value)
+	}
 
 	// Set all the values
+	v.Set("version", CurrentSchemaVersion)
 	v.Set("default_profile", configFile.DefaultProfile)
 	v.Set("profiles", configFile.Profiles)
 	if configFile.GlobalSettings.OutputFormat != "" {
@@ -369,14 +492,68 @@ func saveConfigFile(configFile *File) error {
 	if configFile.GlobalSettings.LogLevel != "" {
 		v.Set("global_settings.log_level", configFile.GlobalSettings.LogLevel)
 	}
+	if configFile.GlobalSettings.ConfirmByName {
+		v.Set("global_settings.confirm_by_name", true)
+	}
+	if configFile.GlobalSettings.UpdateChannel != "" {
+		v.Set("global_settings.update_channel", configFile.GlobalSettings.UpdateChannel)
+	}
+	if configFile.GlobalSettings.UpdateCheck != nil {
+		v.Set("global_settings.update_check", *configFile.GlobalSettings.UpdateCheck)
+	}
+	if configFile.GlobalSettings.History {
+		v.Set("global_settings.history", true)
+	}
+	if configFile.GlobalSettings.MaxResponseMB != nil {
+		v.Set("global_settings.max_response_mb", *configFile.GlobalSettings.MaxResponseMB)
+	}
+
+	if len(configFile.Defaults) > 0 {
+		v.Set("defaults", configFile.Defaults)
+	}
+	if len(configFile.Aliases) > 0 {
+		v.Set("aliases", configFile.Aliases)
+	}
+	if len(configFile.Alerts) > 0 {
+		v.Set("alerts", configFile.Alerts)
+	}
+	if len(configFile.Hooks.BeforeDeploy)+len(configFile.Hooks.AfterDeploy)+len(configFile.Hooks.OnFailure) > 0 {
+		v.Set("hooks", configFile.Hooks)
+	}
+	if len(configFile.VarSets) > 0 {
+		v.Set("varsets", configFile.VarSets)
+	}
 
 	if err := v.WriteConfig(); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
+	// The file contains API tokens, so keep existing files private as well
+	if err := os.Chmod(configPath, 0o600); err != nil {
+		return fmt.Errorf("failed to set config file permissions: %w", err)
+	}
+
 	return nil
 }
 
+// readExtraKeys returns the top-level keys of an existing configuration file that are not managed by File
+func readExtraKeys(configPath string) map[string]interface{} {
+	data, err := os.ReadFile(configPath) // #nosec G304 - path is derived from the user's home directory
+	if err != nil {
+		return nil
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+
+	for _, key := range []string{"version", "default_profile", "profiles", "global_settings", "defaults", "aliases", "alerts", "hooks", "varsets"} {
+		delete(raw, key)
+	}
+	return raw
+}
+
 // getConfigFilePath returns the path to the configuration file
 func getConfigFilePath() (string, error) {
 	configDir, err := GetConfigDir()
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -113,3 +114,527 @@ func TestGetConfigDir(t *testing.T) {
 		t.Errorf("Expected config dir %s, got %s", expected, configDir)
 	}
 }
+
+func TestMigrateLegacyConfigFile(t *testing.T) {
+	// Create a temporary directory for testing
+	tmpDir, err := os.MkdirTemp("", "coolifyme-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	// Set HOME to our temp directory
+	originalHome := os.Getenv("HOME")
+	_ = os.Setenv("HOME", tmpDir)
+	defer func() {
+		_ = os.Setenv("HOME", originalHome)
+	}()
+
+	// Write a config file without a schema version
+	configDir := filepath.Join(tmpDir, ".config", "coolifyme")
+	if err := os.MkdirAll(configDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	legacy := "default_profile: default\nprofiles:\n  default:\n    api_token: test-token\n    base_url: https://test.example.com/api/v1/\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(legacy), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	configFile, err := loadConfigFile()
+	if err != nil {
+		t.Fatalf("Failed to load config file: %v", err)
+	}
+
+	if configFile.Version != CurrentSchemaVersion {
+		t.Errorf("Expected version %d, got %d", CurrentSchemaVersion, configFile.Version)
+	}
+
+	profile := configFile.Profiles[DefaultProfile]
+	if profile.Name != DefaultProfile {
+		t.Errorf("Expected profile name %s, got %s", DefaultProfile, profile.Name)
+	}
+
+	if profile.BaseURL != "https://test.example.com/api/v1" {
+		t.Errorf("Expected trailing slash to be trimmed, got %s", profile.BaseURL)
+	}
+
+	// Loading and validating leave the file alone; only Migrate rewrites it
+	issues, err := Validate()
+	if err != nil {
+		t.Fatalf("Failed to validate config: %v", err)
+	}
+	found := false
+	for _, issue := range issues {
+		if strings.Contains(issue.Fix, "config migrate") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected a pending migration issue, got %v", issues)
+	}
+	data, err := os.ReadFile(filepath.Join(configDir, "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != legacy {
+		t.Errorf("Expected the config file to be unchanged, got %q", data)
+	}
+
+	from, migrated, err := Migrate()
+	if err != nil {
+		t.Fatalf("Failed to migrate config: %v", err)
+	}
+	if from != 0 || !migrated {
+		t.Errorf("Expected a migration from version 0, got %d (migrated %v)", from, migrated)
+	}
+	if _, migrated, _ := Migrate(); migrated {
+		t.Error("Expected a migrated file to stay unchanged")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	// Create a temporary directory for testing
+	tmpDir, err := os.MkdirTemp("", "coolifyme-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	// Set HOME to our temp directory
+	originalHome := os.Getenv("HOME")
+	_ = os.Setenv("HOME", tmpDir)
+	defer func() {
+		_ = os.Setenv("HOME", originalHome)
+	}()
+
+	configDir := filepath.Join(tmpDir, ".config", "coolifyme")
+	if err := os.MkdirAll(configDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(configDir, "config.yaml")
+	content := "version: 1\ndefault_profile: default\nserver_urls: typo\nprofiles:\n  default:\n    name: default\n    base_url: not-a-url\n"
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// #nosec G302 - deliberately insecure permissions for the test
+	if err := os.Chmod(configPath, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := Validate()
+	if err != nil {
+		t.Fatalf("Failed to validate config: %v", err)
+	}
+
+	expected := []string{"unknown key 'server_urls'", "API token is not set", "is not a valid http(s) URL", "readable by other users"}
+	for _, want := range expected {
+		found := false
+		for _, issue := range issues {
+			if strings.Contains(issue.Message, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Expected an issue containing %q, got %v", want, issues)
+		}
+	}
+
+	fixed, err := FixPermissions()
+	if err != nil {
+		t.Fatalf("Failed to fix permissions: %v", err)
+	}
+	if !fixed {
+		t.Error("Expected permissions to be fixed")
+	}
+
+	info, err := os.Stat(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected mode 0600, got %04o", info.Mode().Perm())
+	}
+}
+
+func TestCommandDefaults(t *testing.T) {
+	// Create a temporary directory for testing
+	tmpDir, err := os.MkdirTemp("", "coolifyme-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	// Set HOME to our temp directory
+	originalHome := os.Getenv("HOME")
+	_ = os.Setenv("HOME", tmpDir)
+	defer func() {
+		_ = os.Setenv("HOME", originalHome)
+	}()
+
+	configDir := filepath.Join(tmpDir, ".config", "coolifyme")
+	if err := os.MkdirAll(configDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	content := "version: 1\ndefaults:\n  applications list:\n    output: wide\n    tags: [a, b]\n  deploy application:\n    wait: true\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	defaults, err := CommandDefaults("applications  list")
+	if err != nil {
+		t.Fatalf("Failed to load command defaults: %v", err)
+	}
+	if defaults["output"] != "wide" || defaults["tags"] != "a,b" {
+		t.Errorf("Unexpected defaults for applications list: %v", defaults)
+	}
+
+	defaults, err = CommandDefaults("deploy application")
+	if err != nil {
+		t.Fatalf("Failed to load command defaults: %v", err)
+	}
+	if defaults["wait"] != "true" {
+		t.Errorf("Expected wait=true, got %v", defaults)
+	}
+
+	defaults, err = CommandDefaults("servers list")
+	if err != nil {
+		t.Fatalf("Failed to load command defaults: %v", err)
+	}
+	if len(defaults) != 0 {
+		t.Errorf("Expected no defaults, got %v", defaults)
+	}
+}
+
+func TestSetAndRemoveAlias(t *testing.T) {
+	// Create a temporary directory for testing
+	tmpDir, err := os.MkdirTemp("", "coolifyme-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	// Set HOME to our temp directory
+	originalHome := os.Getenv("HOME")
+	_ = os.Setenv("HOME", tmpDir)
+	defer func() {
+		_ = os.Setenv("HOME", originalHome)
+	}()
+
+	if err := CreateProfile(DefaultProfile, "test-token", ""); err != nil {
+		t.Fatalf("Failed to create profile: %v", err)
+	}
+
+	if err := SetAlias("bad.name", "version"); err == nil {
+		t.Error("Expected error for invalid alias name")
+	}
+
+	if err := SetAlias("dp", "deploy application --wait"); err != nil {
+		t.Fatalf("Failed to set alias: %v", err)
+	}
+
+	aliases, err := GetAliases()
+	if err != nil {
+		t.Fatalf("Failed to get aliases: %v", err)
+	}
+	if aliases["dp"] != "deploy application --wait" {
+		t.Errorf("Expected alias expansion, got %v", aliases)
+	}
+
+	if err := RemoveAlias("dp"); err != nil {
+		t.Fatalf("Failed to remove alias: %v", err)
+	}
+
+	aliases, err = GetAliases()
+	if err != nil {
+		t.Fatalf("Failed to get aliases: %v", err)
+	}
+	if len(aliases) != 0 {
+		t.Errorf("Expected no aliases, got %v", aliases)
+	}
+}
+
+func TestValidateAlertRule(t *testing.T) {
+	valid := AlertRule{
+		Name:      "app-down",
+		Condition: ConditionAppStatus,
+		Actions:   []AlertAction{{Notify: true}, {Webhook: "https://hooks.example.com/coolify"}},
+	}
+	if err := ValidateAlertRule(valid); err != nil {
+		t.Errorf("Expected valid rule, got %v", err)
+	}
+
+	tests := map[string]AlertRule{
+		"unknown condition":  {Name: "x", Condition: "cpu_high", Actions: []AlertAction{{Notify: true}}},
+		"no actions":         {Name: "x", Condition: ConditionDeploymentFailed},
+		"invalid webhook":    {Name: "x", Condition: ConditionServerUnreachable, Actions: []AlertAction{{Webhook: "not a url"}}},
+		"two actions in one": {Name: "x", Condition: ConditionAppStatus, Actions: []AlertAction{{Exec: "true", Notify: true}}},
+	}
+	for name, rule := range tests {
+		if err := ValidateAlertRule(rule); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestGetHooks(t *testing.T) {
+	// Create a temporary directory for testing
+	tmpDir, err := os.MkdirTemp("", "coolifyme-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	// Set HOME to our temp directory
+	originalHome := os.Getenv("HOME")
+	_ = os.Setenv("HOME", tmpDir)
+	defer func() {
+		_ = os.Setenv("HOME", originalHome)
+	}()
+
+	configDir := filepath.Join(tmpDir, ".config", "coolifyme")
+	if err := os.MkdirAll(configDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	content := "version: 1\nprofiles:\n  default:\n    name: default\n    api_token: x\n    base_url: https://c.example.com/api/v1\n" +
+		"hooks:\n  before-deploy:\n    - ./test.sh\n  on-failure:\n    - ./notify.sh\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	hooks, err := GetHooks()
+	if err != nil {
+		t.Fatalf("Failed to load hooks: %v", err)
+	}
+	if got := hooks.Commands(HookBeforeDeploy); len(got) != 1 || got[0] != "./test.sh" {
+		t.Errorf("Unexpected before-deploy hooks: %v", got)
+	}
+	if got := hooks.Commands(HookAfterDeploy); len(got) != 0 {
+		t.Errorf("Expected no after-deploy hooks, got %v", got)
+	}
+
+	// Hooks survive rewriting the config file
+	if err := SetAlias("dl", "deploy list"); err != nil {
+		t.Fatalf("Failed to set alias: %v", err)
+	}
+	hooks, err = GetHooks()
+	if err != nil {
+		t.Fatalf("Failed to load hooks: %v", err)
+	}
+	if got := hooks.Commands(HookOnFailure); len(got) != 1 || got[0] != "./notify.sh" {
+		t.Errorf("Unexpected on-failure hooks after save: %v", got)
+	}
+}
+
+func TestGetVarSets(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "coolifyme-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	originalHome := os.Getenv("HOME")
+	_ = os.Setenv("HOME", tmpDir)
+	defer func() {
+		_ = os.Setenv("HOME", originalHome)
+	}()
+
+	configDir := filepath.Join(tmpDir, ".config", "coolifyme")
+	if err := os.MkdirAll(filepath.Join(configDir, "varsets"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	content := "version: 1\nprofiles:\n  default:\n    name: default\n    api_token: x\n    base_url: https://c.example.com/api/v1\n" +
+		"varsets:\n  common-postgres:\n    file: postgres.env\n    vars:\n      DB_HOST: db.internal\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "varsets", "logging.env"), []byte("LOG_LEVEL=info\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func() {
+		t.Helper()
+		sets, err := GetVarSets()
+		if err != nil {
+			t.Fatalf("Failed to load variable sets: %v", err)
+		}
+		if names := VarSetNames(sets); len(names) != 2 || names[0] != "common-postgres" || names[1] != "logging" {
+			t.Fatalf("Unexpected variable sets: %v", names)
+		}
+		postgres := sets["common-postgres"]
+		if postgres.Vars["DB_HOST"] != "db.internal" {
+			t.Errorf("Variable names should keep their case, got %v", postgres.Vars)
+		}
+		if postgres.File != filepath.Join(configDir, "postgres.env") {
+			t.Errorf("Unexpected file %s", postgres.File)
+		}
+		if sets["logging"].File != filepath.Join(configDir, "varsets", "logging.env") {
+			t.Errorf("Unexpected file %s", sets["logging"].File)
+		}
+	}
+	check()
+
+	// Variable sets survive rewriting the config file
+	if err := SetAlias("dl", "deploy list"); err != nil {
+		t.Fatalf("Failed to set alias: %v", err)
+	}
+	check()
+}
+
+func TestGetOutputTemplate(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "coolifyme-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	originalHome := os.Getenv("HOME")
+	_ = os.Setenv("HOME", tmpDir)
+	defer func() {
+		_ = os.Setenv("HOME", originalHome)
+	}()
+
+	configDir := filepath.Join(tmpDir, ".config", "coolifyme")
+	if err := os.MkdirAll(configDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	content := "version: 1\nprofiles:\n  default:\n    name: default\n    api_token: x\n    base_url: https://c.example.com/api/v1\n" +
+		"templates:\n  deploy-summary: \"{{.Name}} -> {{.Status}}\"\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func() {
+		t.Helper()
+		template, err := GetOutputTemplate("Deploy-Summary")
+		if err != nil {
+			t.Fatalf("Failed to get template: %v", err)
+		}
+		if template != "{{.Name}} -> {{.Status}}" {
+			t.Errorf("Unexpected template %q", template)
+		}
+	}
+	check()
+
+	if _, err := GetOutputTemplate("missing"); err == nil || !strings.Contains(err.Error(), "available: deploy-summary") {
+		t.Errorf("Expected an error listing the templates, got %v", err)
+	}
+
+	// Templates survive rewriting the config file
+	if err := SetAlias("dl", "deploy list"); err != nil {
+		t.Fatalf("Failed to set alias: %v", err)
+	}
+	check()
+}
+
+func TestReadOnlyProfile(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "coolifyme-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	originalHome := os.Getenv("HOME")
+	_ = os.Setenv("HOME", tmpDir)
+	defer func() {
+		_ = os.Setenv("HOME", originalHome)
+	}()
+
+	configDir := filepath.Join(tmpDir, ".config", "coolifyme")
+	if err := os.MkdirAll(configDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	content := "version: 1\ndefault_profile: production\nprofiles:\n  production:\n    name: production\n    api_token: x\n    base_url: https://c.example.com/api/v1\n    read_only: true\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if !cfg.ReadOnly {
+		t.Fatal("Expected the read_only profile setting to be loaded")
+	}
+
+	// Saving the profile cannot lift read-only mode
+	cfg.ReadOnly = false
+	cfg.APIToken = "y"
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+	profile, err := LoadProfile("production")
+	if err != nil {
+		t.Fatalf("Failed to load profile: %v", err)
+	}
+	if !profile.ReadOnly || profile.APIToken != "y" {
+		t.Errorf("Unexpected profile after saving: %+v", profile)
+	}
+}
+
+func TestSaveProfile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	created, err := SaveProfile("production", "token-1", "https://c.example.com/api/v1")
+	if err != nil || !created {
+		t.Fatalf("SaveProfile() = %v, %v, want a new profile", created, err)
+	}
+	profiles, defaultProfile, err := ListProfiles()
+	if err != nil || len(profiles) != 1 || defaultProfile != "production" {
+		t.Fatalf("ListProfiles() = %+v, %s, %v, want production as the only and default profile", profiles, defaultProfile, err)
+	}
+
+	// Updating keeps the settings of the profile and the default profile
+	if _, err := SaveProfile("staging", "token-2", "https://s.example.com/api/v1"); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(home, ".config", "coolifyme", "config.yaml")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = []byte(strings.Replace(string(data), "name: production", "name: production\n        read_only: true", 1))
+	if err := os.WriteFile(configPath, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	created, err = SaveProfile("production", "token-3", "https://c.example.com/api/v1")
+	if err != nil || created {
+		t.Fatalf("SaveProfile() of an existing profile = %v, %v", created, err)
+	}
+	profile, err := LoadProfile("production")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.APIToken != "token-3" || !profile.ReadOnly {
+		t.Errorf("LoadProfile() = %+v, want the new token and read-only mode kept", profile)
+	}
+	if _, defaultProfile, _ := ListProfiles(); defaultProfile != "production" {
+		t.Errorf("default profile = %s, want production", defaultProfile)
+	}
+
+	// A file that cannot be read is never replaced
+	if err := os.WriteFile(configPath, []byte("profiles: [\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SaveProfile("production", "token-4", "https://c.example.com/api/v1"); err == nil {
+		t.Error("SaveProfile() replaced an unreadable config file")
+	}
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommandDefaults returns the default flag values configured for a command.
+// The command is identified by its path without the program name, e.g. "applications list".
+// Values are returned as strings suitable for pflag's Set.
+func CommandDefaults(command string) (map[string]string, error) {
+	configFile, err := loadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	flags, ok := configFile.Defaults[strings.Join(strings.Fields(command), " ")]
+	if !ok {
+		return nil, nil
+	}
+
+	defaults := make(map[string]string, len(flags))
+	for name, value := range flags {
+		defaults[name] = flagValueString(value)
+	}
+	return defaults, nil
+}
+
+// flagValueString converts a YAML value into the string form accepted by a flag
+func flagValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, flagValueString(item))
+		}
+		return strings.Join(parts, ",")
+	case map[string]interface{}:
+		parts := make([]string, 0, len(v))
+		for _, key := range sortedKeys(v) {
+			parts = append(parts, fmt.Sprintf("%s=%s", key, flagValueString(v[key])))
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,41 @@
+package config
+
+// Hook events run around 'coolifyme deploy application' and 'coolifyme deploy multiple'
+const (
+	// HookBeforeDeploy runs before a deployment is triggered; a failing hook cancels the deployment
+	HookBeforeDeploy = "before-deploy"
+	// HookAfterDeploy runs after a deployment was triggered, or finished with --wait
+	HookAfterDeploy = "after-deploy"
+	// HookOnFailure runs when a before-deploy hook or the deployment fails
+	HookOnFailure = "on-failure"
+)
+
+// Hooks are shell commands run around deployments, with the deployment details in APP_UUID,
+// DEPLOYMENT_UUID and STATUS environment variables
+type Hooks struct {
+	BeforeDeploy []string `yaml:"before-deploy,omitempty" mapstructure:"before-deploy"`
+	AfterDeploy  []string `yaml:"after-deploy,omitempty" mapstructure:"after-deploy"`
+	OnFailure    []string `yaml:"on-failure,omitempty" mapstructure:"on-failure"`
+}
+
+// Commands returns the commands of a hook event
+func (h Hooks) Commands(event string) []string {
+	switch event {
+	case HookBeforeDeploy:
+		return h.BeforeDeploy
+	case HookAfterDeploy:
+		return h.AfterDeploy
+	case HookOnFailure:
+		return h.OnFailure
+	}
+	return nil
+}
+
+// GetHooks returns the deployment hooks of the configuration file
+func GetHooks() (Hooks, error) {
+	configFile, err := loadConfigFile()
+	if err != nil {
+		return Hooks{}, err
+	}
+	return configFile.Hooks, nil
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LocalFileName is the name of the per-directory configuration overlay, usually committed to the
+// root of a repository
+const LocalFileName = ".coolifyme.yaml"
+
+// noLocalConfigEnv disables the overlay when set, e.g. in CI jobs that must use the global config
+const noLocalConfigEnv = "COOLIFYME_NO_LOCAL_CONFIG"
+
+// Local is a configuration overlay found in the working directory or one of its parents. It
+// selects a profile and sets flag defaults for commands run inside the directory, overriding the
+// global config file; command-line flags and environment variables still override it.
+type Local struct {
+	// Profile is the profile used instead of the default profile
+	Profile string `yaml:"profile,omitempty"`
+	// Project, Environment and Server are the defaults of the --project, --environment and
+	// --server flags of every command that has them
+	Project     string `yaml:"project,omitempty"`
+	Environment string `yaml:"environment,omitempty"`
+	Server      string `yaml:"server,omitempty"`
+	// Defaults maps a command path to default flag values, like defaults in the global config
+	Defaults map[string]map[string]interface{} `yaml:"defaults,omitempty"`
+
+	// Path is the file the overlay was read from
+	Path string `yaml:"-"`
+}
+
+// FindLocal looks for the overlay in dir and its parent directories and returns the first one
+// found, or nil if there is none. The overlay is ignored when COOLIFYME_NO_LOCAL_CONFIG is set.
+func FindLocal(dir string) (*Local, error) {
+	if os.Getenv(noLocalConfigEnv) != "" {
+		return nil, nil
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		path := filepath.Join(dir, LocalFileName)
+		data, err := os.ReadFile(path) // #nosec G304 -- the overlay of the working directory
+		switch {
+		case err == nil:
+			local, err := parseLocal(data)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", path, err)
+			}
+			local.Path = path
+			return local, nil
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// LoadLocal returns the overlay of the working directory, or nil if there is none
+func LoadLocal() (*Local, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	return FindLocal(dir)
+}
+
+// parseLocal decodes an overlay, rejecting unknown keys since a typo would silently be ignored
+func parseLocal(data []byte) (*Local, error) {
+	var local Local
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&local); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return &local, nil
+}
+
+// CommandDefaults returns the default flag values the overlay sets for a command, in the form of
+// the global CommandDefaults
+func (l *Local) CommandDefaults(command string) map[string]string {
+	flags, ok := l.Defaults[strings.Join(strings.Fields(command), " ")]
+	if !ok {
+		return nil
+	}
+	defaults := make(map[string]string, len(flags))
+	for name, value := range flags {
+		defaults[name] = flagValueString(value)
+	}
+	return defaults
+}
+
+// ResourceDefaults returns the values of the --project, --environment and --server flags set by
+// the overlay
+func (l *Local) ResourceDefaults() map[string]string {
+	defaults := make(map[string]string)
+	for name, value := range map[string]string{"project": l.Project, "environment": l.Environment, "server": l.Server} {
+		if value != "" {
+			defaults[name] = value
+		}
+	}
+	return defaults
+}
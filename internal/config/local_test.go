@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFindLocal(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "services", "api")
+	if err := os.MkdirAll(nested, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	local, err := FindLocal(nested)
+	if err != nil || local != nil {
+		t.Fatalf("FindLocal() without overlay = %+v, %v", local, err)
+	}
+
+	content := "profile: staging\nproject: proj-uuid\nenvironment: production\ndefaults:\n  deploy application:\n    wait: true\n"
+	if err := os.WriteFile(filepath.Join(root, LocalFileName), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	local, err = FindLocal(nested)
+	if err != nil {
+		t.Fatalf("FindLocal() error = %v", err)
+	}
+	if local.Path != filepath.Join(root, LocalFileName) || local.Profile != "staging" {
+		t.Errorf("FindLocal() = %+v", local)
+	}
+	resources := local.ResourceDefaults()
+	if len(resources) != 2 || resources["project"] != "proj-uuid" || resources["environment"] != "production" {
+		t.Errorf("ResourceDefaults() = %v", resources)
+	}
+	if defaults := local.CommandDefaults("deploy  application"); defaults["wait"] != "true" {
+		t.Errorf("CommandDefaults() = %v", defaults)
+	}
+
+	t.Setenv(noLocalConfigEnv, "1")
+	if local, err := FindLocal(nested); err != nil || local != nil {
+		t.Errorf("FindLocal() with %s = %+v, %v", noLocalConfigEnv, local, err)
+	}
+}
+
+func TestFindLocalUnknownKey(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, LocalFileName), []byte("profil: staging\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FindLocal(dir); err == nil || !strings.Contains(err.Error(), "profil") {
+		t.Errorf("FindLocal() error = %v, want an unknown key error", err)
+	}
+}
+
+func TestLoadConfigLocalProfile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("COOLIFYME_PROFILE", "")
+	t.Setenv("COOLIFY_PROFILE", "")
+	configDir := filepath.Join(home, ".config", "coolifyme")
+	if err := os.MkdirAll(configDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	content := `version: 1
+default_profile: default
+profiles:
+  default:
+    name: default
+    api_token: default-token
+    base_url: https://default.example.com/api/v1
+  staging:
+    name: staging
+    api_token: staging-token
+    base_url: https://staging.example.com/api/v1
+`
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repo, LocalFileName), []byte("profile: staging\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.Profile != "staging" || cfg.APIToken != "staging-token" {
+		t.Errorf("LoadConfig() used profile %s with token %s, want the local profile staging", cfg.Profile, cfg.APIToken)
+	}
+
+	// The environment overrides the overlay
+	t.Setenv("COOLIFYME_PROFILE", "default")
+	if cfg, err = LoadConfig(); err != nil || cfg.APIToken != "default-token" {
+		t.Errorf("LoadConfig() with COOLIFYME_PROFILE = %+v, %v", cfg, err)
+	}
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GetOutputTemplates returns the named output templates of the configuration file
+func GetOutputTemplates() (map[string]string, error) {
+	configFile, err := loadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	return configFile.Templates, nil
+}
+
+// GetOutputTemplate returns the output template with the given name. Names are case-insensitive
+// since viper lowercases the keys of the configuration file.
+func GetOutputTemplate(name string) (string, error) {
+	templates, err := GetOutputTemplates()
+	if err != nil {
+		return "", err
+	}
+	if template, ok := templates[strings.ToLower(name)]; ok {
+		return template, nil
+	}
+
+	names := make([]string, 0, len(templates))
+	for known := range templates {
+		names = append(names, known)
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("no output template named '%s': the config file has no templates", name)
+	}
+	sort.Strings(names)
+	return "", fmt.Errorf("no output template named '%s' (available: %s)", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,294 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentSchemaVersion is the version of the configuration file layout written by this build
+const CurrentSchemaVersion = 1
+
+// Severity represents how serious a configuration issue is
+type Severity string
+
+const (
+	// SeverityError marks issues that prevent coolifyme from working correctly
+	SeverityError Severity = "error"
+	// SeverityWarning marks issues that are likely mistakes
+	SeverityWarning Severity = "warning"
+)
+
+// Issue describes a problem found while validating the configuration file
+type Issue struct {
+	Severity Severity `json:"severity"`
+	Profile  string   `json:"profile,omitempty"`
+	Message  string   `json:"message"`
+	Fix      string   `json:"fix,omitempty"`
+}
+
+// String returns a human-readable representation of the issue
+func (i Issue) String() string {
+	msg := i.Message
+	if i.Profile != "" {
+		msg = fmt.Sprintf("profile '%s': %s", i.Profile, msg)
+	}
+	if i.Fix != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, i.Fix)
+	}
+	return msg
+}
+
+var (
+	knownTopLevelKeys = map[string]bool{
+		"version": true, "default_profile": true, "profiles": true, "global_settings": true, "defaults": true, "aliases": true, "alerts": true, "hooks": true, "varsets": true, "templates": true,
+		// Keys that may be set in the file to provide defaults for global flags
+		"server_url": true, "api_token": true, "profile": true, "output_format": true,
+		"color_output": true, "theme": true, "no_emoji": true,
+	}
+	knownProfileKeys        = map[string]bool{"name": true, "api_token": true, "base_url": true, "headers": true, "read_only": true}
+	knownGlobalSettingsKeys = map[string]bool{
+		"output_format": true, "color_output": true, "log_level": true, "confirm_by_name": true,
+		"update_channel": true, "update_check": true, "history": true, "max_response_mb": true,
+	}
+)
+
+// migrations upgrade a configuration file from the version at their index to the next one
+var migrations = []func(*File){
+	// 0 -> 1: fill in missing profile names and normalize base URLs
+	func(f *File) {
+		for key, profile := range f.Profiles {
+			if profile.Name == "" {
+				profile.Name = key
+			}
+			profile.BaseURL = strings.TrimRight(profile.BaseURL, "/")
+			f.Profiles[key] = profile
+		}
+	},
+}
+
+// migrateConfigFile upgrades the configuration file to the current schema version.
+// It reports whether any migration was applied.
+func migrateConfigFile(f *File) bool {
+	if f.Version >= CurrentSchemaVersion {
+		return false
+	}
+
+	for version := f.Version; version < CurrentSchemaVersion && version < len(migrations); version++ {
+		migrations[version](f)
+	}
+	f.Version = CurrentSchemaVersion
+	return true
+}
+
+// Migrate upgrades the configuration file to the current schema version and saves it. It returns
+// the version the file had and whether it was migrated.
+func Migrate() (int, bool, error) {
+	configFile, err := readConfigFile()
+	if err != nil {
+		return 0, false, err
+	}
+
+	from := configFile.Version
+	if !migrateConfigFile(configFile) {
+		return from, false, nil
+	}
+	if err := saveConfigFile(configFile); err != nil {
+		return from, false, err
+	}
+	return from, true, nil
+}
+
+// FilePath returns the path to the configuration file
+func FilePath() (string, error) {
+	return getConfigFilePath()
+}
+
+// Validate checks the configuration file for unknown keys, invalid URLs, missing tokens
+// and insecure file permissions. A missing configuration file is reported as an error.
+func Validate() ([]Issue, error) {
+	configPath, err := getConfigFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(configPath) // #nosec G304 - path is derived from the user's home directory
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var issues []Issue
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return []Issue{{Severity: SeverityError, Message: fmt.Sprintf("invalid YAML: %v", err)}}, nil
+	}
+	issues = append(issues, unknownKeyIssues(raw)...)
+
+	configFile, err := readConfigFile()
+	if err != nil {
+		return append(issues, Issue{Severity: SeverityError, Message: err.Error()}), nil
+	}
+
+	// The remaining checks run on the migrated settings, which are not saved
+	if version := configFile.Version; migrateConfigFile(configFile) {
+		issues = append(issues, Issue{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("config schema version %d is older than the current version %d", version, CurrentSchemaVersion),
+			Fix:      "run 'coolifyme config migrate'",
+		})
+	} else if configFile.Version > CurrentSchemaVersion {
+		issues = append(issues, Issue{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("config schema version %d is newer than supported version %d", configFile.Version, CurrentSchemaVersion),
+			Fix:      "upgrade coolifyme",
+		})
+	}
+
+	if len(configFile.Profiles) == 0 {
+		issues = append(issues, Issue{
+			Severity: SeverityError,
+			Message:  "no profiles configured",
+			Fix:      "run 'coolifyme login <url>'",
+		})
+	} else if _, ok := configFile.Profiles[configFile.DefaultProfile]; !ok {
+		issues = append(issues, Issue{
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("default profile '%s' does not exist", configFile.DefaultProfile),
+			Fix:      "run 'coolifyme config profile use <name>'",
+		})
+	}
+
+	names := make([]string, 0, len(configFile.Profiles))
+	for name := range configFile.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		issues = append(issues, ValidateProfile(name, configFile.Profiles[name])...)
+	}
+
+	for _, rule := range configFile.Alerts {
+		if err := ValidateAlertRule(rule); err != nil {
+			issues = append(issues, Issue{Severity: SeverityError, Message: fmt.Sprintf("alert rule '%s': %v", rule.Name, err)})
+		}
+	}
+
+	if info, err := os.Stat(configPath); err == nil && info.Mode().Perm()&0o077 != 0 {
+		issues = append(issues, Issue{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("config file %s is readable by other users (mode %04o) and contains API tokens", configPath, info.Mode().Perm()),
+			Fix:      "run 'coolifyme config doctor' or chmod 600 the file",
+		})
+	}
+
+	return issues, nil
+}
+
+// ValidateProfile checks a single profile for a missing token and an invalid base URL
+func ValidateProfile(name string, profile Profile) []Issue {
+	var issues []Issue
+
+	if profile.APIToken == "" {
+		issues = append(issues, Issue{
+			Severity: SeverityWarning,
+			Profile:  name,
+			Message:  "API token is not set",
+			Fix:      fmt.Sprintf("run 'coolifyme config profile use %s' and 'coolifyme config profile set --token TOKEN'", name),
+		})
+	}
+
+	parsed, err := url.Parse(profile.BaseURL)
+	switch {
+	case profile.BaseURL == "":
+		issues = append(issues, Issue{Severity: SeverityError, Profile: name, Message: "base URL is not set"})
+	case err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https"):
+		issues = append(issues, Issue{
+			Severity: SeverityError,
+			Profile:  name,
+			Message:  fmt.Sprintf("base URL %q is not a valid http(s) URL", profile.BaseURL),
+			Fix:      "use a URL like https://coolify.example.com/api/v1",
+		})
+	case !strings.HasSuffix(strings.TrimRight(parsed.Path, "/"), "/api/v1"):
+		issues = append(issues, Issue{
+			Severity: SeverityWarning,
+			Profile:  name,
+			Message:  fmt.Sprintf("base URL %q does not end with /api/v1", profile.BaseURL),
+			Fix:      "Coolify API URLs normally end with /api/v1",
+		})
+	}
+
+	return issues
+}
+
+// unknownKeyIssues reports keys in the raw configuration that coolifyme does not understand
+func unknownKeyIssues(raw map[string]interface{}) []Issue {
+	var issues []Issue
+
+	for _, key := range sortedKeys(raw) {
+		if !knownTopLevelKeys[key] {
+			issues = append(issues, Issue{Severity: SeverityWarning, Message: fmt.Sprintf("unknown key '%s'", key), Fix: "check for typos"})
+		}
+	}
+
+	if profiles, ok := raw["profiles"].(map[string]interface{}); ok {
+		for _, name := range sortedKeys(profiles) {
+			profile, ok := profiles[name].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, key := range sortedKeys(profile) {
+				if !knownProfileKeys[key] {
+					issues = append(issues, Issue{Severity: SeverityWarning, Profile: name, Message: fmt.Sprintf("unknown key '%s'", key), Fix: "check for typos"})
+				}
+			}
+		}
+	}
+
+	if settings, ok := raw["global_settings"].(map[string]interface{}); ok {
+		for _, key := range sortedKeys(settings) {
+			if !knownGlobalSettingsKeys[key] {
+				issues = append(issues, Issue{Severity: SeverityWarning, Message: fmt.Sprintf("unknown key 'global_settings.%s'", key), Fix: "check for typos"})
+			}
+		}
+	}
+
+	return issues
+}
+
+// FixPermissions restricts the configuration file to be readable only by its owner.
+// It reports whether the permissions were changed.
+func FixPermissions() (bool, error) {
+	configPath, err := getConfigFilePath()
+	if err != nil {
+		return false, err
+	}
+
+	info, err := os.Stat(configPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat config file: %w", err)
+	}
+
+	if info.Mode().Perm()&0o077 == 0 {
+		return false, nil
+	}
+
+	if err := os.Chmod(configPath, 0o600); err != nil {
+		return false, fmt.Errorf("failed to fix config file permissions: %w", err)
+	}
+	return true, nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
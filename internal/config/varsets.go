@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// VarSet is a named set of environment variables shared by several applications, applied with
+// 'coolifyme applications env apply-set'
+type VarSet struct {
+	Description string `yaml:"description,omitempty" mapstructure:"description"`
+	// File is a .env file with the variables, relative to the config directory unless absolute
+	File string `yaml:"file,omitempty" mapstructure:"file"`
+	// Vars are variables of the set; they override variables of the same name in File
+	Vars map[string]string `yaml:"vars,omitempty" mapstructure:"vars"`
+}
+
+// varSetDir is the directory below the config directory holding <name>.env variable sets
+const varSetDir = "varsets"
+
+// GetVarSets returns the variable sets defined in the configuration file and the <name>.env
+// files of the varsets directory in the config directory. Sets in the configuration file take
+// precedence over files of the same name. File paths are made absolute.
+func GetVarSets() (map[string]VarSet, error) {
+	configFile, err := loadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	sets := make(map[string]VarSet)
+	entries, _ := os.ReadDir(filepath.Join(configDir, varSetDir))
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".env"); ok && !entry.IsDir() && name != "" {
+			sets[name] = VarSet{File: filepath.Join(configDir, varSetDir, entry.Name())}
+		}
+	}
+
+	for name, set := range configFile.VarSets {
+		if set.File != "" {
+			set.File = resolveConfigPath(configDir, set.File)
+		}
+		sets[name] = set
+	}
+	return sets, nil
+}
+
+// VarSetNames returns the sorted names of the variable sets
+func VarSetNames(sets map[string]VarSet) []string {
+	names := make([]string, 0, len(sets))
+	for name := range sets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolveConfigPath expands ~ and makes a path relative to the config directory absolute
+func resolveConfigPath(configDir, path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[2:])
+		}
+	}
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(configDir, path)
+}
+
+// readVarSets reads the variable sets of a configuration file without changing the case of
+// variable names
+func readVarSets(configPath string) map[string]VarSet {
+	data, err := os.ReadFile(configPath) // #nosec G304 - path is derived from the user's home directory
+	if err != nil {
+		return nil
+	}
+	var raw struct {
+		VarSets map[string]VarSet `yaml:"varsets"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+	return raw.VarSets
+}
@@ -0,0 +1,131 @@
+// Package confirm provides confirmation prompts for destructive operations.
+package confirm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/hongkongkiwi/coolifyme/internal/theme"
+)
+
+// Yes is the answer that confirms an operation when typing the resource name is not required
+const Yes = "yes"
+
+var (
+	mu sync.Mutex
+	// input is shared by all prompts, so answers typed ahead are not lost in a discarded buffer
+	input       = bufio.NewReader(os.Stdin)
+	requireName bool
+)
+
+// Target describes the resource affected by a destructive operation
+type Target struct {
+	// Kind is the resource type, e.g. "application"
+	Kind string
+	// Name is the human-readable resource name, if known
+	Name string
+	// ID is the UUID or other identifier of the resource
+	ID string
+	// Dependents lists related resources or data that are affected as well
+	Dependents []string
+}
+
+// label returns the target as it is shown in prompts
+func (t Target) label() string {
+	switch {
+	case t.Name != "" && t.ID != "" && t.Name != t.ID:
+		return fmt.Sprintf("%s '%s' (%s)", t.Kind, t.Name, t.ID)
+	case t.Name != "":
+		return fmt.Sprintf("%s '%s'", t.Kind, t.Name)
+	default:
+		return fmt.Sprintf("%s %s", t.Kind, t.ID)
+	}
+}
+
+// SetInput sets the reader answers are read from
+func SetInput(r io.Reader) {
+	mu.Lock()
+	defer mu.Unlock()
+	if buffered, ok := r.(*bufio.Reader); ok {
+		input = buffered
+		return
+	}
+	input = bufio.NewReader(r)
+}
+
+// Input returns the reader answers are read from, for other prompts reading standard input
+func Input() *bufio.Reader {
+	mu.Lock()
+	defer mu.Unlock()
+	return input
+}
+
+// SetRequireName controls whether deletes must be confirmed by typing the resource name
+func SetRequireName(enabled bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	requireName = enabled
+}
+
+// Delete asks the user to confirm deleting the target. It returns true without prompting when force is set.
+func Delete(target Target, force bool) bool {
+	if force {
+		return true
+	}
+
+	mu.Lock()
+	byName := requireName
+	mu.Unlock()
+
+	expected := Yes
+	if byName {
+		expected = target.Name
+		if expected == "" {
+			expected = target.ID
+		}
+	}
+
+	theme.Printf("⚠️  You are about to delete %s\n", target.label())
+	return ask("This action cannot be undone.", target.Dependents, expected)
+}
+
+// Action asks the user to confirm a destructive action described by message.
+// It returns true without prompting when force is set.
+func Action(message string, force bool) bool {
+	if force {
+		return true
+	}
+
+	theme.Printf("⚠️  %s\n", message)
+	return ask("", nil, Yes)
+}
+
+// ask prints the details of the operation and reads the confirmation answer
+func ask(warning string, dependents []string, expected string) bool {
+	if len(dependents) > 0 {
+		fmt.Println("   This will also affect:")
+		for _, dependent := range dependents {
+			fmt.Printf("     - %s\n", dependent)
+		}
+	}
+	if warning != "" {
+		fmt.Printf("   %s\n", warning)
+	}
+
+	if expected == Yes {
+		fmt.Print("Type 'yes' to confirm: ")
+	} else {
+		fmt.Printf("Type '%s' to confirm: ", expected)
+	}
+
+	answer, err := Input().ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Println()
+		return false
+	}
+	return strings.TrimSpace(answer) == expected
+}
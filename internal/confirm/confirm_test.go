@@ -0,0 +1,38 @@
+package confirm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPromptsShareInput(t *testing.T) {
+	SetInput(strings.NewReader("yes\nyes\nno\n"))
+	defer SetInput(strings.NewReader(""))
+
+	for i, want := range []bool{true, true, false} {
+		if got := Action("Continue?", false); got != want {
+			t.Errorf("Action() #%d = %v, want %v", i+1, got, want)
+		}
+	}
+	if Action("Continue?", false) {
+		t.Error("Action() at the end of input = true, want false")
+	}
+}
+
+func TestDeleteRequireName(t *testing.T) {
+	SetRequireName(true)
+	defer SetRequireName(false)
+	SetInput(strings.NewReader("yes\napi\n"))
+	defer SetInput(strings.NewReader(""))
+
+	target := Target{Kind: "application", Name: "api", ID: "abc"}
+	if Delete(target, false) {
+		t.Error("Delete() confirmed with 'yes' although the name is required")
+	}
+	if !Delete(target, false) {
+		t.Error("Delete() refused the typed name")
+	}
+	if !Delete(target, true) {
+		t.Error("Delete() with force = false")
+	}
+}
@@ -0,0 +1,214 @@
+// Package envcrypt encrypts exported .env files at rest, either with a passphrase (AES-256-GCM)
+// or for age recipients using the age command line tool.
+package envcrypt
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Scheme identifies how a file is encrypted
+type Scheme string
+
+const (
+	// SchemeNone marks a plaintext file
+	SchemeNone Scheme = ""
+	// SchemePassphrase marks a file encrypted with a passphrase
+	SchemePassphrase Scheme = "passphrase"
+	// SchemeAge marks a file encrypted with age
+	SchemeAge Scheme = "age"
+)
+
+// passphraseHeader starts the first line of passphrase-encrypted files. The line continues with
+// the PBKDF2 iteration count and salt; the base64 encoded nonce and ciphertext follow it.
+const passphraseHeader = "coolifyme-env/v1 aes-256-gcm pbkdf2-sha256"
+
+// pbkdf2Iterations is the PBKDF2-HMAC-SHA256 work factor used for new files
+const pbkdf2Iterations = 600000
+
+// Markers identifying age encrypted files, armored and binary
+var ageMarkers = []string{"-----BEGIN AGE ENCRYPTED FILE-----", "age-encryption.org/v1"}
+
+// Detect reports how data is encrypted
+func Detect(data []byte) Scheme {
+	if bytes.HasPrefix(data, []byte(passphraseHeader)) {
+		return SchemePassphrase
+	}
+	for _, marker := range ageMarkers {
+		if bytes.HasPrefix(data, []byte(marker)) {
+			return SchemeAge
+		}
+	}
+	return SchemeNone
+}
+
+// EncryptPassphrase encrypts data with AES-256-GCM using a key derived from a passphrase
+func EncryptPassphrase(data []byte, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("passphrase cannot be empty")
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	gcm, err := newGCM(passphrase, salt, pbkdf2Iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	header := fmt.Sprintf("%s %d %s", passphraseHeader, pbkdf2Iterations, base64.StdEncoding.EncodeToString(salt))
+	sealed := gcm.Seal(nonce, nonce, data, []byte(header))
+
+	var out bytes.Buffer
+	out.WriteString(header + "\n")
+	encoded := base64.StdEncoding.EncodeToString(sealed)
+	for len(encoded) > 64 {
+		out.WriteString(encoded[:64] + "\n")
+		encoded = encoded[64:]
+	}
+	out.WriteString(encoded + "\n")
+	return out.Bytes(), nil
+}
+
+// DecryptPassphrase decrypts data written by EncryptPassphrase
+func DecryptPassphrase(data []byte, passphrase string) ([]byte, error) {
+	header, body, _ := strings.Cut(string(data), "\n")
+	fields := strings.Fields(strings.TrimPrefix(header, passphraseHeader))
+	if Detect(data) != SchemePassphrase || len(fields) != 2 {
+		return nil, fmt.Errorf("not a passphrase encrypted file")
+	}
+
+	iterations, err := strconv.Atoi(fields[0])
+	if err != nil || iterations < 1 {
+		return nil, fmt.Errorf("invalid iteration count '%s'", fields[0])
+	}
+	salt, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: %w", err)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(body), ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ciphertext: %w", err)
+	}
+
+	gcm, err := newGCM(passphrase, salt, iterations)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext is too short")
+	}
+
+	plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], []byte(header))
+	if err != nil {
+		return nil, fmt.Errorf("decryption failed, wrong passphrase or corrupted file")
+	}
+	return plain, nil
+}
+
+// EncryptAge encrypts data for one or more age recipients with the age command line tool,
+// producing an ASCII armored file
+func EncryptAge(ctx context.Context, data []byte, recipients []string) ([]byte, error) {
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("at least one age recipient is required")
+	}
+
+	args := []string{"--encrypt", "--armor"}
+	for _, recipient := range recipients {
+		args = append(args, "--recipient", recipient)
+	}
+	return runAge(ctx, data, args)
+}
+
+// DecryptAge decrypts an age encrypted file with the identities in identityFile
+func DecryptAge(ctx context.Context, data []byte, identityFile string) ([]byte, error) {
+	if identityFile == "" {
+		return nil, fmt.Errorf("an age identity file is required to decrypt")
+	}
+	return runAge(ctx, data, []string{"--decrypt", "--identity", identityFile})
+}
+
+// runAge runs the age binary with data on stdin and returns its output
+func runAge(ctx context.Context, data []byte, args []string) ([]byte, error) {
+	path, err := exec.LookPath("age")
+	if err != nil {
+		return nil, fmt.Errorf("age is not installed, see https://github.com/FiloSottile/age")
+	}
+
+	var stdout, stderr bytes.Buffer
+	ageCmd := exec.CommandContext(ctx, path, args...) // #nosec G204 -- fixed program, arguments are not interpreted by a shell
+	ageCmd.Stdin = bytes.NewReader(data)
+	ageCmd.Stdout = &stdout
+	ageCmd.Stderr = &stderr
+	ageCmd.Env = os.Environ()
+
+	if err := ageCmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("age failed: %s", message)
+		}
+		return nil, fmt.Errorf("age failed: %w", err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// newGCM derives an AES-256 key from a passphrase and returns an AES-GCM cipher using it
+func newGCM(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key := pbkdf2SHA256([]byte(passphrase), salt, iterations, 32)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return gcm, nil
+}
+
+// pbkdf2SHA256 implements PBKDF2 (RFC 8018) with HMAC-SHA256
+func pbkdf2SHA256(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLen := prf.Size()
+	blocks := (keyLen + hashLen - 1) / hashLen
+
+	key := make([]byte, 0, blocks*hashLen)
+	var counter [4]byte
+	for block := 1; block <= blocks; block++ {
+		binary.BigEndian.PutUint32(counter[:], uint32(block)) // #nosec G115 -- block count is tiny
+
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(counter[:])
+		u := prf.Sum(nil)
+		t := append([]byte(nil), u...)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLen]
+}
@@ -0,0 +1,50 @@
+package envcrypt
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestPBKDF2SHA256(t *testing.T) {
+	tests := []struct {
+		iterations int
+		want       string
+	}{
+		{1, "120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"},
+		{2, "ae4d0c95af6b46d32d0adff928f06dd02a303f8ef3c251dfd6e2d85a95474c43"},
+	}
+
+	for _, tt := range tests {
+		got := hex.EncodeToString(pbkdf2SHA256([]byte("password"), []byte("salt"), tt.iterations, 32))
+		if got != tt.want {
+			t.Errorf("pbkdf2SHA256(%d iterations) = %s, want %s", tt.iterations, got, tt.want)
+		}
+	}
+}
+
+func TestPassphraseRoundTrip(t *testing.T) {
+	plain := []byte("DATABASE_URL=postgres://user:secret@db/app\nAPI_KEY=abc==\n")
+
+	encrypted, err := EncryptPassphrase(plain, "correct horse")
+	if err != nil {
+		t.Fatalf("EncryptPassphrase() error = %v", err)
+	}
+	if Detect(encrypted) != SchemePassphrase {
+		t.Fatalf("Detect() = %q, want %q", Detect(encrypted), SchemePassphrase)
+	}
+	if Detect(plain) != SchemeNone {
+		t.Errorf("Detect(plaintext) = %q, want none", Detect(plain))
+	}
+
+	decrypted, err := DecryptPassphrase(encrypted, "correct horse")
+	if err != nil {
+		t.Fatalf("DecryptPassphrase() error = %v", err)
+	}
+	if string(decrypted) != string(plain) {
+		t.Errorf("DecryptPassphrase() = %q, want %q", decrypted, plain)
+	}
+
+	if _, err := DecryptPassphrase(encrypted, "wrong"); err == nil {
+		t.Error("DecryptPassphrase() with wrong passphrase succeeded")
+	}
+}
@@ -0,0 +1,212 @@
+// Package envlint checks environment variables for common misconfigurations and validates them
+// against a user-provided schema.
+package envlint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Severity represents how serious a lint issue is
+type Severity string
+
+const (
+	// SeverityError marks issues that will likely break the application
+	SeverityError Severity = "error"
+	// SeverityWarning marks values that are likely mistakes
+	SeverityWarning Severity = "warning"
+)
+
+// Issue describes a problem found in an environment variable
+type Issue struct {
+	Severity Severity `json:"severity"`
+	Key      string   `json:"key,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// String returns a human-readable representation of the issue
+func (i Issue) String() string {
+	if i.Key == "" {
+		return i.Message
+	}
+	return fmt.Sprintf("%s: %s", i.Key, i.Message)
+}
+
+// Variable is an environment variable to lint
+type Variable struct {
+	Key       string
+	Value     string
+	Multiline bool
+	Preview   bool
+}
+
+// Rule constrains the value of a single variable
+type Rule struct {
+	// Pattern is a regular expression the whole value must match
+	Pattern string `json:"pattern,omitempty"`
+	// Enum lists the allowed values
+	Enum []string `json:"enum,omitempty"`
+	// Description is shown with issues about the variable
+	Description string `json:"description,omitempty"`
+}
+
+// Schema describes the expected environment variables of an application, using a subset of
+// JSON Schema keywords so existing schema tooling can edit it
+type Schema struct {
+	Required   []string        `json:"required,omitempty"`
+	Properties map[string]Rule `json:"properties,omitempty"`
+	// AdditionalProperties allows variables not listed in Properties or Required (default true)
+	AdditionalProperties *bool `json:"additionalProperties,omitempty"`
+
+	patterns map[string]*regexp.Regexp
+}
+
+var keyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// LoadSchema reads and compiles a schema file
+func LoadSchema(path string) (*Schema, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - user-provided schema file
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+
+	schema.patterns = make(map[string]*regexp.Regexp)
+	for key, rule := range schema.Properties {
+		if rule.Pattern == "" {
+			continue
+		}
+		pattern, err := regexp.Compile("^(?:" + rule.Pattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern for %s: %w", key, err)
+		}
+		schema.patterns[key] = pattern
+	}
+	return &schema, nil
+}
+
+// Lint checks variables for duplicates, whitespace, suspicious values and, when schema is
+// non-nil, schema violations. Issues are sorted by key.
+func Lint(vars []Variable, schema *Schema) []Issue {
+	var issues []Issue
+	add := func(severity Severity, key, format string, args ...any) {
+		issues = append(issues, Issue{Severity: severity, Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	seen := make(map[string]int)
+	for _, v := range vars {
+		scope := v.Key
+		if v.Preview {
+			scope += " (preview)"
+		}
+		seen[scope]++
+		if seen[scope] == 2 {
+			add(SeverityError, scope, "duplicate key")
+		}
+
+		key := strings.TrimSpace(v.Key)
+		switch {
+		case key != v.Key:
+			add(SeverityError, v.Key, "key has leading or trailing whitespace")
+		case !keyPattern.MatchString(key):
+			add(SeverityWarning, v.Key, "key is not a valid shell variable name")
+		}
+
+		value := v.Value
+		if unquoted, ok := unquote(value); ok {
+			value = unquoted
+		}
+		if strings.TrimSpace(value) != value && !v.Multiline {
+			add(SeverityWarning, v.Key, "value has leading or trailing whitespace")
+		}
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "null", "undefined", "none", "nil":
+			add(SeverityWarning, v.Key, "value is the literal %q, the variable is probably meant to be unset or empty", value)
+		}
+		if strings.Contains(value, "\n") && !v.Multiline {
+			add(SeverityWarning, v.Key, "value spans several lines but is not marked multiline")
+		}
+		if strings.Contains(value, "${") && !strings.Contains(value, "}") {
+			add(SeverityWarning, v.Key, "value contains an unterminated ${ reference")
+		}
+	}
+
+	if schema != nil {
+		issues = append(issues, schema.check(vars)...)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Key < issues[j].Key })
+	return issues
+}
+
+// check validates variables against the schema
+func (s *Schema) check(vars []Variable) []Issue {
+	var issues []Issue
+	present := make(map[string]bool)
+	for _, v := range vars {
+		if v.Preview {
+			continue
+		}
+		present[v.Key] = true
+
+		rule, known := s.Properties[v.Key]
+		if !known && !slices.Contains(s.Required, v.Key) && s.AdditionalProperties != nil && !*s.AdditionalProperties {
+			issues = append(issues, Issue{Severity: SeverityError, Key: v.Key, Message: "variable is not allowed by the schema"})
+			continue
+		}
+
+		value := v.Value
+		if unquoted, ok := unquote(value); ok {
+			value = unquoted
+		}
+		if pattern, ok := s.patterns[v.Key]; ok && !pattern.MatchString(value) {
+			issues = append(issues, Issue{Severity: SeverityError, Key: v.Key, Message: ruleMessage(rule, fmt.Sprintf("value does not match pattern %s", rule.Pattern))})
+		}
+		if len(rule.Enum) > 0 && !slices.Contains(rule.Enum, value) {
+			issues = append(issues, Issue{Severity: SeverityError, Key: v.Key, Message: ruleMessage(rule, fmt.Sprintf("value must be one of %s", strings.Join(rule.Enum, ", ")))})
+		}
+	}
+
+	for _, key := range s.Required {
+		if !present[key] {
+			issues = append(issues, Issue{Severity: SeverityError, Key: key, Message: ruleMessage(s.Properties[key], "required variable is missing")})
+		}
+	}
+	return issues
+}
+
+// ruleMessage appends the description of a rule to a message
+func ruleMessage(rule Rule, message string) string {
+	if rule.Description == "" {
+		return message
+	}
+	return fmt.Sprintf("%s (%s)", message, rule.Description)
+}
+
+// unquote strips matching single or double quotes around a value
+func unquote(value string) (string, bool) {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1], true
+	}
+	return value, false
+}
+
+// CountErrors returns the number of error-severity issues
+func CountErrors(issues []Issue) int {
+	count := 0
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
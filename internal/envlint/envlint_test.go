@@ -0,0 +1,81 @@
+package envlint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	vars := []Variable{
+		{Key: "PORT", Value: "3000"},
+		{Key: "PORT", Value: "3001"},
+		{Key: "PORT", Value: "3000", Preview: true},
+		{Key: "API_URL", Value: "https://api.example.com "},
+		{Key: "TOKEN", Value: "null"},
+		{Key: "CERT", Value: "line1\nline2"},
+		{Key: "KEY", Value: "line1\nline2", Multiline: true},
+		{Key: "BAD-KEY", Value: "x"},
+	}
+
+	want := map[string]Severity{
+		"API_URL": SeverityWarning,
+		"BAD-KEY": SeverityWarning,
+		"CERT":    SeverityWarning,
+		"PORT":    SeverityError,
+		"TOKEN":   SeverityWarning,
+	}
+
+	issues := Lint(vars, nil)
+	if len(issues) != len(want) {
+		t.Fatalf("Lint() returned %d issues, want %d: %v", len(issues), len(want), issues)
+	}
+	for _, issue := range issues {
+		if severity, ok := want[issue.Key]; !ok || severity != issue.Severity {
+			t.Errorf("unexpected issue %q (%s)", issue, issue.Severity)
+		}
+	}
+}
+
+func TestLintSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	schema := `{
+  "required": ["DATABASE_URL", "APP_ENV"],
+  "properties": {
+    "PORT": {"pattern": "[0-9]+"},
+    "APP_ENV": {"enum": ["production", "staging"]}
+  },
+  "additionalProperties": false
+}`
+	if err := os.WriteFile(path, []byte(schema), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := LoadSchema(path)
+	if err != nil {
+		t.Fatalf("LoadSchema() error = %v", err)
+	}
+
+	vars := []Variable{
+		{Key: "PORT", Value: "80a"},
+		{Key: "APP_ENV", Value: `"production"`},
+		{Key: "EXTRA", Value: "x"},
+	}
+	issues := Lint(vars, s)
+
+	keys := make(map[string]bool)
+	for _, issue := range issues {
+		keys[issue.Key] = true
+	}
+	for _, key := range []string{"PORT", "EXTRA", "DATABASE_URL"} {
+		if !keys[key] {
+			t.Errorf("expected an issue for %s, got %v", key, issues)
+		}
+	}
+	if keys["APP_ENV"] {
+		t.Errorf("unexpected issue for quoted enum value: %v", issues)
+	}
+	if CountErrors(issues) != 3 {
+		t.Errorf("CountErrors() = %d, want 3", CountErrors(issues))
+	}
+}
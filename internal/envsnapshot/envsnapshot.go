@@ -0,0 +1,292 @@
+// Package envsnapshot keeps copies of the environment variables of applications and services taken
+// before bulk changes, so that a bad change can be rolled back with 'env snapshots restore'.
+package envsnapshot
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxPerResource is the number of snapshots kept for each resource; older snapshots are dropped
+const MaxPerResource = 20
+
+// idLayout formats the creation time of a snapshot as its ID
+const idLayout = "20060102-150405"
+
+// Variable is an environment variable as stored in a snapshot
+type Variable struct {
+	// UUID identifies the variable on the server when the snapshot was taken
+	UUID        string `json:"uuid,omitempty"`
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	IsBuildTime bool   `json:"is_build_time,omitempty"`
+	IsLiteral   bool   `json:"is_literal,omitempty"`
+	IsMultiline bool   `json:"is_multiline,omitempty"`
+	IsPreview   bool   `json:"is_preview,omitempty"`
+	IsShownOnce bool   `json:"is_shown_once,omitempty"`
+}
+
+// Name returns the key of the variable, marking preview variables
+func (v Variable) Name() string {
+	if v.IsPreview {
+		return v.Key + " (preview)"
+	}
+	return v.Key
+}
+
+// Snapshot is the set of environment variables of a resource at a point in time
+type Snapshot struct {
+	ID string `json:"id"`
+	// Kind is the kind of resource, "application" or "service"
+	Kind string `json:"kind"`
+	UUID string `json:"uuid"`
+	// Reason is the command that took the snapshot, e.g. "coolifyme applications env import"
+	Reason    string     `json:"reason"`
+	Created   time.Time  `json:"created"`
+	Variables []Variable `json:"variables"`
+}
+
+// Dir returns the directory holding snapshots: $XDG_STATE_HOME/coolifyme/snapshots, or
+// ~/.local/state/coolifyme/snapshots when XDG_STATE_HOME is not set
+func Dir() (string, error) {
+	if state := os.Getenv("XDG_STATE_HOME"); state != "" {
+		return filepath.Join(state, "coolifyme", "snapshots"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", "coolifyme", "snapshots"), nil
+}
+
+// resourceDir returns the directory of the snapshots of a resource
+func resourceDir(dir, kind, uuid string) (string, error) {
+	if uuid == "" || strings.ContainsAny(uuid, `/\`) || uuid == "." || uuid == ".." {
+		return "", fmt.Errorf("invalid resource UUID %q", uuid)
+	}
+	return filepath.Join(dir, kind, uuid), nil
+}
+
+// Save stores a snapshot, named after its creation time, and drops the oldest snapshots of the
+// resource beyond MaxPerResource. It returns the snapshot with its ID set.
+func Save(dir string, snapshot Snapshot) (Snapshot, error) {
+	path, err := resourceDir(dir, snapshot.Kind, snapshot.UUID)
+	if err != nil {
+		return snapshot, err
+	}
+	// Snapshots hold secrets, so only the user can read them
+	if err := os.MkdirAll(path, 0o700); err != nil {
+		return snapshot, err
+	}
+	if snapshot.Created.IsZero() {
+		snapshot.Created = time.Now()
+	}
+	if snapshot.Variables == nil {
+		snapshot.Variables = []Variable{}
+	}
+
+	base := snapshot.Created.UTC().Format(idLayout)
+	snapshot.ID = base
+	for n := 2; ; n++ {
+		if _, err := os.Stat(filepath.Join(path, snapshot.ID+".json")); errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		snapshot.ID = fmt.Sprintf("%s-%d", base, n)
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return snapshot, err
+	}
+	if err := os.WriteFile(filepath.Join(path, snapshot.ID+".json"), append(data, '\n'), 0o600); err != nil {
+		return snapshot, err
+	}
+	return snapshot, prune(path)
+}
+
+// prune removes the oldest snapshots of a resource directory beyond MaxPerResource
+func prune(path string) error {
+	ids, err := snapshotIDs(path)
+	if err != nil {
+		return err
+	}
+	for len(ids) > MaxPerResource {
+		if err := os.Remove(filepath.Join(path, ids[0]+".json")); err != nil {
+			return err
+		}
+		ids = ids[1:]
+	}
+	return nil
+}
+
+// snapshotIDs returns the IDs of the snapshots in a resource directory, oldest first
+func snapshotIDs(path string) ([]string, error) {
+	entries, err := os.ReadDir(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, entry := range entries {
+		if id, ok := strings.CutSuffix(entry.Name(), ".json"); ok && !entry.IsDir() {
+			ids = append(ids, id)
+		}
+	}
+	// IDs sort by time, then by the suffix of snapshots taken in the same second
+	slices.SortFunc(ids, func(a, b string) int {
+		return cmp.Or(
+			strings.Compare(a[:min(len(a), len(idLayout))], b[:min(len(b), len(idLayout))]),
+			cmp.Compare(len(a), len(b)),
+			strings.Compare(a, b))
+	})
+	return ids, nil
+}
+
+// List returns the snapshots of a resource, newest first
+func List(dir, kind, uuid string) ([]Snapshot, error) {
+	path, err := resourceDir(dir, kind, uuid)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := snapshotIDs(path)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]Snapshot, 0, len(ids))
+	for i := len(ids) - 1; i >= 0; i-- {
+		snapshot, err := load(filepath.Join(path, ids[i]+".json"))
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// Get returns a snapshot of a resource by ID, or the newest snapshot when id is empty
+func Get(dir, kind, uuid, id string) (Snapshot, error) {
+	path, err := resourceDir(dir, kind, uuid)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if id == "" {
+		ids, err := snapshotIDs(path)
+		if err != nil {
+			return Snapshot{}, err
+		}
+		if len(ids) == 0 {
+			return Snapshot{}, fmt.Errorf("no snapshots of %s %s", kind, uuid)
+		}
+		id = ids[len(ids)-1]
+	}
+	if strings.ContainsAny(id, `/\`) {
+		return Snapshot{}, fmt.Errorf("invalid snapshot ID %q", id)
+	}
+	snapshot, err := load(filepath.Join(path, id+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return Snapshot{}, fmt.Errorf("no snapshot %s of %s %s", id, kind, uuid)
+	}
+	return snapshot, err
+}
+
+// load reads a snapshot file
+func load(path string) (Snapshot, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path is derived from the user's state directory
+	if err != nil {
+		return Snapshot{}, err
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return Snapshot{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return snapshot, nil
+}
+
+// Plan lists the changes restoring a snapshot makes to the current variables, each sorted by key.
+// Adds and Updates hold the variables of the snapshot, Deletes the current variables, with
+// their UUIDs, that the snapshot does not have.
+type Plan struct {
+	Adds    []Variable
+	Updates []Variable
+	Deletes []Variable
+}
+
+// Empty reports whether the variables already match the snapshot
+func (p Plan) Empty() bool {
+	return len(p.Adds) == 0 && len(p.Updates) == 0 && len(p.Deletes) == 0
+}
+
+// Masked replaces the values of variables in printed plans unless they are asked for
+const Masked = "********"
+
+// Print writes the plan as one line per change, "+ KEY=value" for additions, "~ KEY=value" for
+// updates and "- KEY" for deletions. Values are masked unless value is given to format them.
+func (p Plan) Print(w io.Writer, value func(Variable) string) error {
+	if value == nil {
+		value = func(Variable) string { return Masked }
+	}
+	for _, v := range p.Adds {
+		if _, err := fmt.Fprintf(w, "  + %s=%s\n", v.Name(), value(v)); err != nil {
+			return err
+		}
+	}
+	for _, v := range p.Updates {
+		if _, err := fmt.Fprintf(w, "  ~ %s=%s\n", v.Name(), value(v)); err != nil {
+			return err
+		}
+	}
+	for _, v := range p.Deletes {
+		if _, err := fmt.Fprintf(w, "  - %s\n", v.Name()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Diff plans restoring the snapshot variables over the current ones. Variables are matched by
+// key, with preview variables separate from the others.
+func Diff(current, snapshot []Variable) Plan {
+	type match struct {
+		key     string
+		preview bool
+	}
+	existing := make(map[match]Variable, len(current))
+	for _, v := range current {
+		existing[match{v.Key, v.IsPreview}] = v
+	}
+	wanted := make(map[match]bool, len(snapshot))
+
+	var plan Plan
+	for _, v := range snapshot {
+		m := match{v.Key, v.IsPreview}
+		wanted[m] = true
+		old, exists := existing[m]
+		old.UUID = v.UUID
+		switch {
+		case !exists:
+			plan.Adds = append(plan.Adds, v)
+		case old != v:
+			plan.Updates = append(plan.Updates, v)
+		}
+	}
+	for _, v := range current {
+		if !wanted[match{v.Key, v.IsPreview}] {
+			plan.Deletes = append(plan.Deletes, v)
+		}
+	}
+	for _, vars := range [][]Variable{plan.Adds, plan.Updates, plan.Deletes} {
+		sort.Slice(vars, func(i, j int) bool { return vars[i].Name() < vars[j].Name() })
+	}
+	return plan
+}
@@ -0,0 +1,167 @@
+package envsnapshot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSaveListGet(t *testing.T) {
+	dir := t.TempDir()
+	created := time.Date(2024, 6, 1, 3, 0, 0, 0, time.UTC)
+
+	first, err := Save(dir, Snapshot{Kind: "application", UUID: "app", Created: created,
+		Variables: []Variable{{Key: "A", Value: "1"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := Save(dir, Snapshot{Kind: "application", UUID: "app", Created: created,
+		Variables: []Variable{{Key: "A", Value: "2"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.ID != "20240601-030000" || second.ID != "20240601-030000-2" {
+		t.Fatalf("IDs = %q, %q", first.ID, second.ID)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, "application", "app", first.ID+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("snapshot permissions = %o, want 600", perm)
+	}
+
+	snapshots, err := List(dir, "application", "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 2 || snapshots[0].ID != second.ID {
+		t.Fatalf("List() = %+v, want newest first", snapshots)
+	}
+
+	latest, err := Get(dir, "application", "app", "")
+	if err != nil || latest.Variables[0].Value != "2" {
+		t.Fatalf("Get(latest) = %+v, %v", latest, err)
+	}
+	if _, err := Get(dir, "application", "app", "missing"); err == nil {
+		t.Error("Get(missing) succeeded")
+	}
+	if _, err := Get(dir, "service", "app", ""); err == nil {
+		t.Error("Get() of a resource without snapshots succeeded")
+	}
+	if _, err := Save(dir, Snapshot{Kind: "application", UUID: "../app"}); err == nil {
+		t.Error("Save() accepted a UUID with a path separator")
+	}
+}
+
+func TestSavePrunes(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2024, 6, 1, 3, 0, 0, 0, time.UTC)
+	for i := 0; i < MaxPerResource+5; i++ {
+		if _, err := Save(dir, Snapshot{Kind: "service", UUID: "svc", Created: start.Add(time.Duration(i) * time.Minute)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshots, err := List(dir, "service", "svc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != MaxPerResource {
+		t.Fatalf("kept %d snapshots, want %d", len(snapshots), MaxPerResource)
+	}
+	if oldest := snapshots[len(snapshots)-1].Created; !oldest.Equal(start.Add(5 * time.Minute)) {
+		t.Errorf("oldest kept snapshot from %v, want the oldest ones dropped", oldest)
+	}
+}
+
+func TestSnapshotIDsOrder(t *testing.T) {
+	dir := t.TempDir()
+	ids := []string{"20240601-030000-10", "20240601-030000", "20240601-030000-2", "20240531-235959"}
+	for _, id := range ids {
+		if err := os.WriteFile(filepath.Join(dir, id+".json"), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := snapshotIDs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"20240531-235959", "20240601-030000", "20240601-030000-2", "20240601-030000-10"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("snapshotIDs() = %v, want %v", got, want)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	current := []Variable{
+		{UUID: "1", Key: "KEEP", Value: "same"},
+		{UUID: "2", Key: "CHANGED", Value: "new"},
+		{UUID: "3", Key: "ADDED_LATER", Value: "x"},
+		{UUID: "4", Key: "FLAGS", Value: "v", IsBuildTime: true},
+		{UUID: "5", Key: "KEEP", Value: "preview", IsPreview: true},
+	}
+	snapshot := []Variable{
+		{UUID: "1", Key: "KEEP", Value: "same"},
+		{UUID: "2", Key: "CHANGED", Value: "old"},
+		{UUID: "6", Key: "REMOVED", Value: "back"},
+		{UUID: "4", Key: "FLAGS", Value: "v"},
+	}
+
+	plan := Diff(current, snapshot)
+	names := func(vars []Variable) []string {
+		var out []string
+		for _, v := range vars {
+			out = append(out, fmt.Sprintf("%s=%s", v.Name(), v.Value))
+		}
+		return out
+	}
+	if got := names(plan.Adds); !reflect.DeepEqual(got, []string{"REMOVED=back"}) {
+		t.Errorf("Adds = %v", got)
+	}
+	if got := names(plan.Updates); !reflect.DeepEqual(got, []string{"CHANGED=old", "FLAGS=v"}) {
+		t.Errorf("Updates = %v", got)
+	}
+	if got := names(plan.Deletes); !reflect.DeepEqual(got, []string{"ADDED_LATER=x", "KEEP (preview)=preview"}) {
+		t.Errorf("Deletes = %v", got)
+	}
+	if plan.Deletes[0].UUID != "3" {
+		t.Errorf("Deletes carry UUID %q, want the current variable's", plan.Deletes[0].UUID)
+	}
+
+	if !Diff(snapshot, snapshot).Empty() {
+		t.Error("Diff() of identical variables is not empty")
+	}
+}
+
+func TestPlanPrintMasksValues(t *testing.T) {
+	plan := Diff(
+		[]Variable{{UUID: "1", Key: "TOKEN", Value: "current-secret"}, {UUID: "2", Key: "OLD", Value: "old-secret"}},
+		[]Variable{{UUID: "1", Key: "TOKEN", Value: "restored-secret"}, {UUID: "3", Key: "API_KEY", Value: "added-secret"}},
+	)
+
+	var out strings.Builder
+	if err := plan.Print(&out, nil); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+	want := "  + API_KEY=" + Masked + "\n  ~ TOKEN=" + Masked + "\n  - OLD\n"
+	if out.String() != want {
+		t.Errorf("Print() = %q, want %q", out.String(), want)
+	}
+	if strings.Contains(out.String(), "secret") {
+		t.Errorf("Print() leaked a value: %q", out.String())
+	}
+
+	out.Reset()
+	if err := plan.Print(&out, func(v Variable) string { return v.Value }); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "API_KEY=added-secret") || !strings.Contains(out.String(), "TOKEN=restored-secret") {
+		t.Errorf("Print() with values = %q", out.String())
+	}
+}
@@ -0,0 +1,215 @@
+// Package envtemplate resolves references to secrets in environment variable values, such as
+// ${ENV:LOCAL_VAR} or ${VAULT:secret/app#password}, so that .env files can be committed without
+// the secrets themselves.
+package envtemplate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Resolver returns the value a reference points to, e.g. the contents of a file for FILE
+type Resolver func(ctx context.Context, ref string) (string, error)
+
+// Template expands ${NAME:ref} references with the resolver registered for NAME. References
+// to unknown resolvers, plain ${VAR} references and shell defaults such as ${VAR:-x} are kept
+// as they are, since Coolify resolves those itself. $${NAME:ref} produces a literal ${NAME:ref}.
+// Braces inside a reference must be balanced, as in ${CMD:awk '{print $1}' file}, or escaped
+// as \{ and \}.
+type Template struct {
+	resolvers map[string]Resolver
+	cache     map[string]string
+}
+
+// New returns a Template with the built-in resolvers:
+//
+//	${ENV:NAME}           the local environment variable NAME
+//	${FILE:path}          the contents of a file, without the trailing newline
+//	${CMD:command}        the output of a shell command, without the trailing newline
+//	${VAULT:path#field}   a field of a HashiCorp Vault KV secret read with the vault CLI
+//	                      (the field defaults to "value")
+func New() *Template {
+	t := &Template{resolvers: make(map[string]Resolver), cache: make(map[string]string)}
+	t.Register("ENV", resolveEnv)
+	t.Register("FILE", resolveFile)
+	t.Register("CMD", resolveCommand)
+	t.Register("VAULT", resolveVault)
+	return t
+}
+
+// Register adds or replaces the resolver for ${name:ref} references. Names are case sensitive.
+func (t *Template) Register(name string, resolver Resolver) {
+	t.resolvers[name] = resolver
+}
+
+// Expand resolves the references in a value and reports whether it contained any. Every
+// reference is resolved only once per Template, so repeated references run commands once.
+func (t *Template) Expand(ctx context.Context, value string) (string, bool, error) {
+	var out strings.Builder
+	expanded := false
+
+	for {
+		start := strings.Index(value, "${")
+		if start == -1 {
+			break
+		}
+		end := closingBrace(value, start+2)
+		if end == -1 {
+			break
+		}
+
+		name, ref, ok := strings.Cut(value[start+2:end], ":")
+		resolver, known := t.resolvers[name]
+		if !ok || !known {
+			out.WriteString(value[:end+1])
+			value = value[end+1:]
+			continue
+		}
+
+		// $${NAME:ref} escapes the reference
+		if start > 0 && value[start-1] == '$' {
+			out.WriteString(value[:start-1])
+			out.WriteString(value[start : end+1])
+			value = value[end+1:]
+			continue
+		}
+
+		ref = braceUnescaper.Replace(ref)
+		key := name + ":" + ref
+		resolved, cached := t.cache[key]
+		if !cached {
+			var err error
+			resolved, err = resolver(ctx, ref)
+			if err != nil {
+				return "", false, fmt.Errorf("cannot resolve ${%s}: %w", key, err)
+			}
+			t.cache[key] = resolved
+		}
+
+		out.WriteString(value[:start])
+		out.WriteString(resolved)
+		value = value[end+1:]
+		expanded = true
+	}
+
+	out.WriteString(value)
+	return out.String(), expanded, nil
+}
+
+// braceUnescaper removes the escapes of braces in references
+var braceUnescaper = strings.NewReplacer(`\{`, "{", `\}`, "}")
+
+// closingBrace returns the index of the } closing a reference whose body starts at from, skipping
+// balanced pairs of braces and escaped braces, or -1 when the reference is not closed
+func closingBrace(value string, from int) int {
+	depth := 0
+	for i := from; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			if i+1 < len(value) && (value[i+1] == '{' || value[i+1] == '}') {
+				i++
+			}
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// ExpandAll resolves the references in all values. It returns the expanded variables and the
+// sorted keys whose values contained references.
+func (t *Template) ExpandAll(ctx context.Context, vars map[string]string) (map[string]string, []string, error) {
+	keys := make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make(map[string]string, len(vars))
+	var expandedKeys []string
+	for _, key := range keys {
+		value, expanded, err := t.Expand(ctx, vars[key])
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", key, err)
+		}
+		result[key] = value
+		if expanded {
+			expandedKeys = append(expandedKeys, key)
+		}
+	}
+	return result, expandedKeys, nil
+}
+
+func resolveEnv(_ context.Context, ref string) (string, error) {
+	value, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", ref)
+	}
+	return value, nil
+}
+
+func resolveFile(_ context.Context, ref string) (string, error) {
+	path := ref
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[2:])
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- the path comes from the user's .env file
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+func resolveCommand(ctx context.Context, ref string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", ref) // #nosec G204 -- command comes from the user's .env file
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", ref) // #nosec G204 -- command comes from the user's .env file
+	}
+	return output(cmd)
+}
+
+func resolveVault(ctx context.Context, ref string) (string, error) {
+	path, field, _ := strings.Cut(ref, "#")
+	if field == "" {
+		field = "value"
+	}
+	if path == "" {
+		return "", errors.New("missing secret path")
+	}
+	return output(exec.CommandContext(ctx, "vault", "kv", "get", "-field="+field, path)) // #nosec G204 -- arguments are passed to vault, not a shell
+}
+
+// output runs a command and returns its stdout without the trailing newline. Failures carry
+// the command's stderr.
+func output(cmd *exec.Cmd) (string, error) {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
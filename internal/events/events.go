@@ -0,0 +1,136 @@
+// Package events defines the JSON events coolifyme emits for other programs: the alerts printed
+// by 'watch resources --json' and posted to webhooks by 'monitor run'.
+//
+// The format is versioned by SchemaVersion. Within a version fields are only added, never
+// renamed, removed or given another meaning, and enums only gain values, so consumers should
+// ignore unknown fields and values. Schema therefore lists enum values as known values of an
+// open string and accepts events of unknown types. Anything else is a new version.
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+)
+
+// SchemaVersion is the version of the event format, carried by every event
+const SchemaVersion = 1
+
+// Event types, carried in the type field of every event
+const (
+	// TypeAlert is an alert rule that fired or was resolved
+	TypeAlert = "alert"
+)
+
+// Types are the event types
+var Types = []string{TypeAlert}
+
+// Alert is an alert rule that fired or was resolved for a single resource
+type Alert struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Type          string `json:"type"`
+	Rule          string `json:"rule"`
+	// Condition is the condition of the rule, one of config.AlertConditions
+	Condition string `json:"condition"`
+	Resource  string `json:"resource"`
+	UUID      string `json:"uuid"`
+	Message   string `json:"message"`
+	// Resolved marks an alert whose condition cleared; only 'watch resources' reports these
+	Resolved bool      `json:"resolved,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// NewAlert returns an alert event of the current schema version
+func NewAlert(rule, condition, resource, uuid, message string, t time.Time) Alert {
+	return Alert{
+		SchemaVersion: SchemaVersion,
+		Type:          TypeAlert,
+		Rule:          rule,
+		Condition:     condition,
+		Resource:      resource,
+		UUID:          uuid,
+		Message:       message,
+		Time:          t,
+	}
+}
+
+// Schema returns the JSON schema (draft 2020-12) of the events
+func Schema() ([]byte, error) {
+	str := func(description string) map[string]any {
+		return map[string]any{"type": "string", "description": description}
+	}
+	// open lists the known values of an enum without rejecting the ones later releases add
+	open := func(description string, values []string) map[string]any {
+		return map[string]any{
+			"anyOf": []any{
+				map[string]any{"enum": values},
+				map[string]any{"type": "string"},
+			},
+			"description": description + "; other values may be added within a schema version",
+		}
+	}
+	common := func(eventType string) map[string]any {
+		return map[string]any{
+			"schemaVersion": map[string]any{
+				"const":       SchemaVersion,
+				"description": "Version of the event format",
+			},
+			"type": map[string]any{
+				"const":       eventType,
+				"description": "Event type",
+			},
+			"resource": str("Name of the resource"),
+			"uuid":     str("UUID of the resource"),
+			"message":  str("Human readable description of the event"),
+			"time": map[string]any{
+				"type":        "string",
+				"format":      "date-time",
+				"description": "When the event was detected",
+			},
+		}
+	}
+
+	alert := common(TypeAlert)
+	alert["rule"] = str("Name of the alert rule")
+	alert["condition"] = open("Condition of the alert rule", config.AlertConditions)
+	alert["resolved"] = map[string]any{
+		"type":        "boolean",
+		"description": "The condition of the rule cleared; absent when the alert fired",
+	}
+
+	definition := func(description string, properties map[string]any, required []string) map[string]any {
+		return map[string]any{
+			"type":                 "object",
+			"description":          description,
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": true,
+		}
+	}
+
+	// Events of types added later only have the common envelope to validate
+	otherEvent := definition("Event of a type added after this schema", map[string]any{
+		"schemaVersion": map[string]any{"const": SchemaVersion, "description": "Version of the event format"},
+		"type":          open("Event type", Types),
+	}, []string{"schemaVersion", "type"})
+	otherEvent["not"] = map[string]any{
+		"properties": map[string]any{"type": map[string]any{"enum": Types}},
+	}
+
+	schema := map[string]any{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"title":       "coolifyme events",
+		"description": "Events printed by 'coolifyme watch resources --json' and posted by 'coolifyme monitor run' webhooks. Fields are only added within a schema version; ignore unknown fields and enum values.",
+		"oneOf": []any{
+			map[string]any{"$ref": "#/$defs/alert"},
+			map[string]any{"$ref": "#/$defs/other"},
+		},
+		"$defs": map[string]any{
+			"alert": definition("Alert rule that fired or was resolved for a resource", alert,
+				[]string{"schemaVersion", "type", "rule", "condition", "resource", "uuid", "message", "time"}),
+			"other": otherEvent,
+		},
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
@@ -0,0 +1,101 @@
+package events
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSchemaMatchesTypes keeps the schema in sync with the event types: every field is
+// documented, and fields without omitempty are required
+func TestSchemaMatchesTypes(t *testing.T) {
+	data, err := Schema()
+	if err != nil {
+		t.Fatalf("Schema() error = %v", err)
+	}
+	var schema struct {
+		Defs map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+			Required   []string                   `json:"required"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Schema() is not valid JSON: %v", err)
+	}
+
+	for name, event := range map[string]any{TypeAlert: Alert{}} {
+		def, ok := schema.Defs[name]
+		if !ok {
+			t.Errorf("schema has no definition for %s", name)
+			continue
+		}
+
+		var fields, required []string
+		eventType := reflect.TypeOf(event)
+		for i := range eventType.NumField() {
+			tag, options, _ := strings.Cut(eventType.Field(i).Tag.Get("json"), ",")
+			fields = append(fields, tag)
+			if options != "omitempty" {
+				required = append(required, tag)
+			}
+		}
+
+		properties := make([]string, 0, len(def.Properties))
+		for property := range def.Properties {
+			properties = append(properties, property)
+		}
+		slices.Sort(fields)
+		slices.Sort(properties)
+		slices.Sort(required)
+		slices.Sort(def.Required)
+		if !slices.Equal(fields, properties) {
+			t.Errorf("%s: schema properties %v, want %v", name, properties, fields)
+		}
+		if !slices.Equal(required, def.Required) {
+			t.Errorf("%s: schema requires %v, want %v", name, def.Required, required)
+		}
+	}
+}
+
+// TestSchemaEnumsAreOpen makes sure consumers validating against the schema accept the enum
+// values added within a schema version
+func TestSchemaEnumsAreOpen(t *testing.T) {
+	data, err := Schema()
+	if err != nil {
+		t.Fatalf("Schema() error = %v", err)
+	}
+	var schema struct {
+		Defs map[string]struct {
+			Properties map[string]map[string]any `json:"properties"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Schema() is not valid JSON: %v", err)
+	}
+
+	for name, def := range schema.Defs {
+		for property, value := range def.Properties {
+			if _, closed := value["enum"]; closed {
+				t.Errorf("%s.%s is a closed enum, want anyOf [enum, string]", name, property)
+			}
+		}
+	}
+	if _, ok := schema.Defs["other"]; !ok {
+		t.Error("schema has no definition for event types added later")
+	}
+}
+
+func TestEventJSON(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	data, err := json.Marshal(NewAlert("app-down", "app_status", "api", "abc", "application api is exited", now))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"schemaVersion":1,"type":"alert","rule":"app-down","condition":"app_status","resource":"api","uuid":"abc","message":"application api is exited","time":"2024-01-15T10:30:00Z"}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+}
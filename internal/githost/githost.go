@@ -0,0 +1,305 @@
+// Package githost compares commits through the APIs of git hosting services (GitHub and GitLab,
+// including self-hosted instances).
+package githost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Provider identifies a git hosting service
+type Provider string
+
+const (
+	// GitHub is github.com or a GitHub Enterprise server
+	GitHub Provider = "github"
+	// GitLab is gitlab.com or a self-hosted GitLab instance
+	GitLab Provider = "gitlab"
+)
+
+// Repository is a repository on a git hosting service
+type Repository struct {
+	Provider Provider
+	Host     string
+	// Path is the full repository path, e.g. "owner/repo" or "group/subgroup/repo" on GitLab
+	Path string
+}
+
+// Commit is a commit included in a comparison
+type Commit struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	Author  string `json:"author,omitempty"`
+	Date    string `json:"date,omitempty"`
+}
+
+// File is a file changed between two commits
+type File struct {
+	Path      string `json:"path"`
+	Status    string `json:"status"`
+	Additions int    `json:"additions,omitempty"`
+	Deletions int    `json:"deletions,omitempty"`
+}
+
+// Comparison lists the commits and changed files between a base and a head revision
+type Comparison struct {
+	Base    string   `json:"base"`
+	Head    string   `json:"head"`
+	URL     string   `json:"url,omitempty"`
+	Commits []Commit `json:"commits"`
+	Files   []File   `json:"files"`
+}
+
+// Parse parses a repository URL as stored by Coolify: https://host/owner/repo(.git),
+// git@host:owner/repo.git, ssh://git@host[:port]/owner/repo, or a bare owner/repo on GitHub.
+// The provider is detected from the host unless one is given.
+func Parse(raw string, provider Provider) (*Repository, error) {
+	host, path, err := split(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if provider == "" {
+		switch {
+		case strings.Contains(host, "github"):
+			provider = GitHub
+		case strings.Contains(host, "gitlab"):
+			provider = GitLab
+		default:
+			return nil, fmt.Errorf("cannot detect the git hosting service of %s, specify github or gitlab", host)
+		}
+	}
+	if provider != GitHub && provider != GitLab {
+		return nil, fmt.Errorf("unsupported git hosting service '%s' (expected github or gitlab)", provider)
+	}
+	if provider == GitHub && strings.Count(path, "/") != 1 {
+		return nil, fmt.Errorf("invalid GitHub repository path '%s'", path)
+	}
+
+	return &Repository{Provider: provider, Host: host, Path: path}, nil
+}
+
+// split returns the host and the repository path of a repository URL
+func split(raw string) (host, path string, err error) {
+	raw = strings.TrimSpace(raw)
+	switch {
+	case strings.Contains(raw, "://"):
+		u, err := url.Parse(raw)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid repository URL '%s': %w", raw, err)
+		}
+		host, path = u.Hostname(), u.Path
+	case strings.Contains(raw, "@") && strings.Contains(raw, ":"):
+		// scp-like syntax: git@host:owner/repo.git
+		userHost, rest, _ := strings.Cut(raw, ":")
+		_, host, _ = strings.Cut(userHost, "@")
+		path = rest
+	case strings.Count(raw, "/") == 1:
+		host, path = "github.com", raw
+	default:
+		return "", "", fmt.Errorf("unsupported repository '%s'", raw)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || strings.Count(path, "/") < 1 {
+		return "", "", fmt.Errorf("cannot determine owner and name of repository '%s'", raw)
+	}
+	return host, path, nil
+}
+
+// RepositoryKey returns host/path of a repository URL in lower case, so the HTTPS and SSH URLs of
+// a repository, e.g. a git remote and the repository stored by Coolify, compare equal. It works
+// for any git host, not only GitHub and GitLab.
+func RepositoryKey(raw string) (string, error) {
+	host, path, err := split(raw)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(host + "/" + path), nil
+}
+
+// APIURL returns the base URL of the hosting service API for the repository
+func (r *Repository) APIURL() string {
+	switch {
+	case r.Provider == GitHub && r.Host == "github.com":
+		return "https://api.github.com"
+	case r.Provider == GitHub:
+		return "https://" + r.Host + "/api/v3"
+	default:
+		return "https://" + r.Host + "/api/v4"
+	}
+}
+
+// DeployKeysURL returns the repository settings page where deploy keys are added
+func (r *Repository) DeployKeysURL() string {
+	if r.Provider == GitHub {
+		return "https://" + r.Host + "/" + r.Path + "/settings/keys"
+	}
+	return "https://" + r.Host + "/" + r.Path + "/-/settings/repository#js-deploy-keys-settings"
+}
+
+// Client calls the git hosting APIs
+type Client struct {
+	HTTPClient *http.Client
+	// Token authenticates requests, required for private repositories
+	Token string
+	// BaseURL overrides the API URL derived from the repository
+	BaseURL string
+}
+
+// Compare returns the commits and files changed from base to head, where head may be a branch name
+func (c *Client) Compare(ctx context.Context, repo *Repository, base, head string) (*Comparison, error) {
+	if repo.Provider == GitLab {
+		return c.compareGitLab(ctx, repo, base, head)
+	}
+	return c.compareGitHub(ctx, repo, base, head)
+}
+
+// compareGitHub uses GET /repos/{owner}/{repo}/compare/{base}...{head}
+func (c *Client) compareGitHub(ctx context.Context, repo *Repository, base, head string) (*Comparison, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/compare/%s...%s", c.apiURL(repo), repo.Path, url.PathEscape(base), url.PathEscape(head))
+
+	var result struct {
+		HTMLURL string `json:"html_url"`
+		Commits []struct {
+			SHA    string `json:"sha"`
+			Commit struct {
+				Message string `json:"message"`
+				Author  struct {
+					Name string `json:"name"`
+					Date string `json:"date"`
+				} `json:"author"`
+			} `json:"commit"`
+		} `json:"commits"`
+		Files []struct {
+			Filename  string `json:"filename"`
+			Status    string `json:"status"`
+			Additions int    `json:"additions"`
+			Deletions int    `json:"deletions"`
+		} `json:"files"`
+	}
+	if err := c.get(ctx, endpoint, map[string]string{"Accept": "application/vnd.github+json"}, "Bearer", &result); err != nil {
+		return nil, err
+	}
+
+	comparison := &Comparison{Base: base, Head: head, URL: result.HTMLURL, Commits: []Commit{}, Files: []File{}}
+	for _, commit := range result.Commits {
+		comparison.Commits = append(comparison.Commits, Commit{
+			SHA:     commit.SHA,
+			Message: commit.Commit.Message,
+			Author:  commit.Commit.Author.Name,
+			Date:    commit.Commit.Author.Date,
+		})
+	}
+	for _, file := range result.Files {
+		comparison.Files = append(comparison.Files, File{Path: file.Filename, Status: file.Status, Additions: file.Additions, Deletions: file.Deletions})
+	}
+	return comparison, nil
+}
+
+// compareGitLab uses GET /projects/{path}/repository/compare?from={base}&to={head}
+func (c *Client) compareGitLab(ctx context.Context, repo *Repository, base, head string) (*Comparison, error) {
+	query := url.Values{"from": {base}, "to": {head}}
+	endpoint := fmt.Sprintf("%s/projects/%s/repository/compare?%s", c.apiURL(repo), url.PathEscape(repo.Path), query.Encode())
+
+	var result struct {
+		WebURL  string `json:"web_url"`
+		Commits []struct {
+			ID         string `json:"id"`
+			Message    string `json:"message"`
+			AuthorName string `json:"author_name"`
+			CreatedAt  string `json:"created_at"`
+		} `json:"commits"`
+		Diffs []struct {
+			NewPath     string `json:"new_path"`
+			NewFile     bool   `json:"new_file"`
+			RenamedFile bool   `json:"renamed_file"`
+			DeletedFile bool   `json:"deleted_file"`
+		} `json:"diffs"`
+	}
+	if err := c.get(ctx, endpoint, nil, "", &result); err != nil {
+		return nil, err
+	}
+
+	comparison := &Comparison{Base: base, Head: head, URL: result.WebURL, Commits: []Commit{}, Files: []File{}}
+	for _, commit := range result.Commits {
+		comparison.Commits = append(comparison.Commits, Commit{
+			SHA:     commit.ID,
+			Message: commit.Message,
+			Author:  commit.AuthorName,
+			Date:    commit.CreatedAt,
+		})
+	}
+	for _, diff := range result.Diffs {
+		status := "modified"
+		switch {
+		case diff.NewFile:
+			status = "added"
+		case diff.DeletedFile:
+			status = "removed"
+		case diff.RenamedFile:
+			status = "renamed"
+		}
+		comparison.Files = append(comparison.Files, File{Path: diff.NewPath, Status: status})
+	}
+	return comparison, nil
+}
+
+// apiURL returns the API base URL to use for a repository
+func (c *Client) apiURL(repo *Repository) string {
+	if c.BaseURL != "" {
+		return strings.TrimRight(c.BaseURL, "/")
+	}
+	return repo.APIURL()
+}
+
+// get performs an authenticated GET request and decodes the JSON response. GitHub takes the
+// token as "Authorization: Bearer", GitLab as a PRIVATE-TOKEN header (authScheme "").
+func (c *Client) get(ctx context.Context, endpoint string, headers map[string]string, authScheme string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	if c.Token != "" {
+		if authScheme != "" {
+			req.Header.Set("Authorization", authScheme+" "+c.Token)
+		} else {
+			req.Header.Set("PRIVATE-TOKEN", c.Token)
+		}
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound && c.Token == "":
+		return fmt.Errorf("repository or commit not found (private repositories need a token)")
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("repository or commit not found")
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("access denied by the git hosting API (%s), check the token", resp.Status)
+	case resp.StatusCode != http.StatusOK:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("git hosting API error: %s %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
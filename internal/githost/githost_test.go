@@ -0,0 +1,127 @@
+package githost
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		raw      string
+		provider Provider
+		want     Repository
+		wantErr  bool
+	}{
+		{raw: "https://github.com/acme/shop.git", want: Repository{GitHub, "github.com", "acme/shop"}},
+		{raw: "git@github.com:acme/shop.git", want: Repository{GitHub, "github.com", "acme/shop"}},
+		{raw: "acme/shop", want: Repository{GitHub, "github.com", "acme/shop"}},
+		{raw: "ssh://git@gitlab.example.com:2222/group/sub/app.git", want: Repository{GitLab, "gitlab.example.com", "group/sub/app"}},
+		{raw: "https://git.example.com/team/app", provider: GitLab, want: Repository{GitLab, "git.example.com", "team/app"}},
+		{raw: "https://git.example.com/team/app", wantErr: true},
+		{raw: "https://github.com/acme", wantErr: true},
+		{raw: "https://github.com/a/b/c", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.raw, tt.provider)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Parse(%q) = %+v, want error", tt.raw, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tt.raw, err)
+			continue
+		}
+		if *got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.raw, *got, tt.want)
+		}
+	}
+}
+
+func TestRepositoryKey(t *testing.T) {
+	for _, raw := range []string{
+		"https://github.com/Acme/Shop.git",
+		"git@github.com:acme/shop.git",
+		"ssh://git@github.com/acme/shop",
+		"acme/shop",
+	} {
+		if got, err := RepositoryKey(raw); err != nil || got != "github.com/acme/shop" {
+			t.Errorf("RepositoryKey(%q) = %q, %v, want github.com/acme/shop", raw, got, err)
+		}
+	}
+	if got, err := RepositoryKey("https://git.example.com/team/app"); err != nil || got != "git.example.com/team/app" {
+		t.Errorf("RepositoryKey() of a self-hosted repository = %q, %v", got, err)
+	}
+	if _, err := RepositoryKey("not a repository"); err == nil {
+		t.Error("RepositoryKey() of an invalid URL succeeded, want an error")
+	}
+}
+
+func TestDeployKeysURL(t *testing.T) {
+	github := Repository{GitHub, "github.com", "acme/shop"}
+	if got := github.DeployKeysURL(); got != "https://github.com/acme/shop/settings/keys" {
+		t.Errorf("DeployKeysURL() = %s", got)
+	}
+	gitlab := Repository{GitLab, "gitlab.example.com", "group/sub/app"}
+	if got := gitlab.DeployKeysURL(); got != "https://gitlab.example.com/group/sub/app/-/settings/repository#js-deploy-keys-settings" {
+		t.Errorf("DeployKeysURL() = %s", got)
+	}
+}
+
+func TestCompareGitHub(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/shop/compare/abc123...main" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		_, _ = w.Write([]byte(`{
+			"html_url": "https://github.com/acme/shop/compare/abc123...main",
+			"commits": [{"sha": "def456", "commit": {"message": "Fix cart", "author": {"name": "Sam"}}}],
+			"files": [{"filename": "cart.go", "status": "modified", "additions": 3, "deletions": 1}]
+		}`))
+	}))
+	defer server.Close()
+
+	client := &Client{Token: "secret", BaseURL: server.URL}
+	comparison, err := client.Compare(context.Background(), &Repository{GitHub, "github.com", "acme/shop"}, "abc123", "main")
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	if len(comparison.Commits) != 1 || comparison.Commits[0].Author != "Sam" {
+		t.Errorf("Commits = %+v", comparison.Commits)
+	}
+	if len(comparison.Files) != 1 || comparison.Files[0].Additions != 3 {
+		t.Errorf("Files = %+v", comparison.Files)
+	}
+}
+
+func TestCompareGitLab(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/projects/group%2Fapp/repository/compare" || r.URL.Query().Get("from") != "abc123" {
+			t.Errorf("unexpected request %s", r.URL.String())
+		}
+		if got := r.Header.Get("PRIVATE-TOKEN"); got != "secret" {
+			t.Errorf("PRIVATE-TOKEN = %q", got)
+		}
+		_, _ = w.Write([]byte(`{
+			"commits": [{"id": "def456", "message": "Add page", "author_name": "Kim"}],
+			"diffs": [{"new_path": "page.go", "new_file": true}]
+		}`))
+	}))
+	defer server.Close()
+
+	client := &Client{Token: "secret", BaseURL: server.URL}
+	comparison, err := client.Compare(context.Background(), &Repository{GitLab, "gitlab.com", "group/app"}, "abc123", "main")
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	if len(comparison.Files) != 1 || comparison.Files[0].Status != "added" {
+		t.Errorf("Files = %+v", comparison.Files)
+	}
+}
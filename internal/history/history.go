@@ -0,0 +1,205 @@
+// Package history records the commands run with coolifyme, with secret arguments redacted, for
+// 'coolifyme history'.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// MaxEntries is the number of entries kept; older entries are dropped
+const MaxEntries = 1000
+
+// Redacted replaces secret values in recorded arguments
+const Redacted = "***"
+
+// Entry is a command recorded in the history
+type Entry struct {
+	ID       int       `json:"id"`
+	Time     time.Time `json:"time"`
+	Profile  string    `json:"profile,omitempty"`
+	Command  string    `json:"command"`
+	Args     []string  `json:"args"`
+	Success  bool      `json:"success"`
+	Error    string    `json:"error,omitempty"`
+	Duration float64   `json:"duration_seconds"`
+	// Redacted is set when secret values were removed from Args, so the entry cannot be rerun
+	Redacted bool `json:"redacted,omitempty"`
+}
+
+// Load reads the history file, oldest entry first. A missing file is an empty history.
+func Load(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path is derived from the user's config directory
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		// Skip lines damaged by an interrupted write rather than losing the history
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// Append adds an entry to the history file with the next ID, keeping the last MaxEntries entries,
+// and returns the entry as recorded
+func Append(path string, entry Entry) (Entry, error) {
+	entries, err := Load(path)
+	if err != nil {
+		return entry, err
+	}
+
+	entry.ID = 1
+	if len(entries) > 0 {
+		entry.ID = entries[len(entries)-1].ID + 1
+	}
+	entries = append(entries, entry)
+	if len(entries) > MaxEntries {
+		entries = entries[len(entries)-MaxEntries:]
+	}
+
+	var b bytes.Buffer
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return entry, err
+		}
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return entry, err
+	}
+	return entry, os.WriteFile(path, b.Bytes(), 0o600)
+}
+
+// Find returns the entry with the given ID
+func Find(entries []Entry, id int) (Entry, bool) {
+	for _, entry := range entries {
+		if entry.ID == id {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// IsSecretFlag reports whether the value of a flag is redacted in the history
+func IsSecretFlag(name string) bool {
+	switch name {
+	case "header", "value", "env", "env-data", "private-key":
+		return true
+	}
+	return strings.Contains(name, "token") || strings.Contains(name, "password") || strings.Contains(name, "secret")
+}
+
+// Sanitize returns the arguments of a command line with the values of secret flags and of the
+// positional arguments at the secretArgs indexes replaced by Redacted. flags are the parsed flags
+// of the command, used to tell flags that take a value from boolean ones, and commandDepth is the
+// number of leading arguments naming the command. The second result reports whether anything was
+// redacted.
+func Sanitize(args []string, flags *pflag.FlagSet, commandDepth int, secretArgs []int) ([]string, bool) {
+	sanitized := make([]string, 0, len(args))
+	redacted := false
+	secretArg := make(map[int]bool, len(secretArgs))
+	for _, index := range secretArgs {
+		secretArg[index] = true
+	}
+
+	words := 0
+	addWord := func(arg string) {
+		if words >= commandDepth && secretArg[words-commandDepth] {
+			arg = Redacted
+			redacted = true
+		}
+		sanitized = append(sanitized, arg)
+		words++
+	}
+
+	// value appends the value of a flag given as a separate argument
+	value := func(i int, secret bool) {
+		if secret {
+			sanitized = append(sanitized, Redacted)
+			redacted = true
+			return
+		}
+		sanitized = append(sanitized, args[i])
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			sanitized = append(sanitized, arg)
+			for _, rest := range args[i+1:] {
+				addWord(rest)
+			}
+			return sanitized, redacted
+
+		case strings.HasPrefix(arg, "--"):
+			name, _, hasValue := strings.Cut(arg[2:], "=")
+			secret := IsSecretFlag(name)
+			if hasValue && secret {
+				arg = "--" + name + "=" + Redacted
+				redacted = true
+			}
+			sanitized = append(sanitized, arg)
+			if flag := flags.Lookup(name); !hasValue && flag != nil && flag.NoOptDefVal == "" && i+1 < len(args) {
+				i++
+				value(i, secret)
+			}
+
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			// Shorthands may be combined (-vq); the first one taking a value takes the rest of the
+			// argument (-pprod) or the next argument (-p prod)
+			index, flag := valueShorthand(arg, flags)
+			switch {
+			case flag == nil:
+				sanitized = append(sanitized, arg)
+			case index+1 < len(arg):
+				if IsSecretFlag(flag.Name) {
+					arg = arg[:index+1] + Redacted
+					redacted = true
+				}
+				sanitized = append(sanitized, arg)
+			default:
+				sanitized = append(sanitized, arg)
+				if i+1 < len(args) {
+					i++
+					value(i, IsSecretFlag(flag.Name))
+				}
+			}
+
+		default:
+			addWord(arg)
+		}
+	}
+	return sanitized, redacted
+}
+
+// valueShorthand returns the index and flag of the first shorthand in a group of shorthands (-vq)
+// that takes a value, or nil when none does
+func valueShorthand(arg string, flags *pflag.FlagSet) (int, *pflag.Flag) {
+	for i := 1; i < len(arg); i++ {
+		if flag := flags.ShorthandLookup(arg[i : i+1]); flag != nil && flag.NoOptDefVal == "" {
+			return i, flag
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,81 @@
+package history
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestSanitize(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringP("token", "t", "", "")
+	flags.StringP("profile", "p", "", "")
+	flags.StringP("value", "V", "", "")
+	flags.BoolP("verbose", "v", false, "")
+	flags.BoolP("force", "f", false, "")
+
+	tests := []struct {
+		name       string
+		args       []string
+		secretArgs []int
+		want       []string
+		redacted   bool
+	}{
+		{"no secrets", []string{"apps", "get", "abc", "-p", "prod", "--verbose"}, nil,
+			[]string{"apps", "get", "abc", "-p", "prod", "--verbose"}, false},
+		{"long flag", []string{"--token", "secret", "apps", "list"}, nil,
+			[]string{"--token", Redacted, "apps", "list"}, true},
+		{"long flag with =", []string{"apps", "list", "--token=secret"}, nil,
+			[]string{"apps", "list", "--token=" + Redacted}, true},
+		{"shorthand", []string{"-vt", "secret", "apps"}, nil,
+			[]string{"-vt", Redacted, "apps"}, true},
+		{"inline shorthand", []string{"-tsecret", "-pprod"}, nil,
+			[]string{"-t" + Redacted, "-pprod"}, true},
+		{"boolean before positional", []string{"env", "create", "-f", "app", "KEY", "value"}, []int{2},
+			[]string{"env", "create", "-f", "app", "KEY", Redacted}, true},
+		{"positional after --", []string{"env", "create", "--", "app", "KEY", "-value"}, []int{2},
+			[]string{"env", "create", "--", "app", "KEY", Redacted}, true},
+	}
+
+	for _, tt := range tests {
+		got, redacted := Sanitize(tt.args, flags, 2, tt.secretArgs)
+		if !slices.Equal(got, tt.want) || redacted != tt.redacted {
+			t.Errorf("%s: Sanitize() = %q, %t, want %q, %t", tt.name, got, redacted, tt.want, tt.redacted)
+		}
+	}
+}
+
+func TestAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	entries, err := Load(path)
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("Load() of a missing file = %v, %v, want no entries", entries, err)
+	}
+
+	for i := 0; i < MaxEntries+2; i++ {
+		entry, err := Append(path, Entry{Command: "apps list", Success: i%2 == 0})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entry.ID != i+1 {
+			t.Fatalf("Append() ID = %d, want %d", entry.ID, i+1)
+		}
+	}
+
+	entries, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != MaxEntries || entries[0].ID != 3 {
+		t.Errorf("Load() = %d entries starting at %d, want %d starting at 3", len(entries), entries[0].ID, MaxEntries)
+	}
+	if entry, ok := Find(entries, MaxEntries+2); !ok || entry.Success {
+		t.Errorf("Find() = %+v, %t", entry, ok)
+	}
+	if _, ok := Find(entries, 1); ok {
+		t.Error("Find() returned a dropped entry")
+	}
+}
@@ -0,0 +1,170 @@
+// Package link stores the Coolify application a working directory is linked to, so commands run
+// inside a repository can default to its application instead of requiring a UUID.
+package link
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hongkongkiwi/coolifyme/internal/githost"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the link file, written to the root of the repository
+const FileName = ".coolifyme"
+
+// ErrNotLinked is returned when no link file is found
+var ErrNotLinked = errors.New("not linked to a Coolify application, run 'coolifyme link' in the repository")
+
+// Link is the content of a link file
+type Link struct {
+	// Application is the UUID of the linked application
+	Application string `yaml:"application"`
+	// Name is the name of the application when it was linked
+	Name string `yaml:"name,omitempty"`
+	// Profile is the configuration profile the application was found with
+	Profile string `yaml:"profile,omitempty"`
+	// Repository is the git remote the application was matched with
+	Repository string `yaml:"repository,omitempty"`
+}
+
+// Find looks for a link file in dir and its parent directories. It returns the link and the
+// path of its file, or ErrNotLinked.
+func Find(dir string) (*Link, string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, "", err
+	}
+	for {
+		path := filepath.Join(dir, FileName)
+		data, err := os.ReadFile(path) // #nosec G304 -- the link file of the working directory
+		switch {
+		case err == nil:
+			var l Link
+			if err := yaml.Unmarshal(data, &l); err != nil {
+				return nil, "", fmt.Errorf("invalid link file %s: %w", path, err)
+			}
+			if l.Application == "" {
+				return nil, "", fmt.Errorf("invalid link file %s: no application", path)
+			}
+			return &l, path, nil
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, "", fmt.Errorf("failed to read link file: %w", err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, "", ErrNotLinked
+		}
+		dir = parent
+	}
+}
+
+// Save writes the link file to dir and returns its path
+func Save(dir string, l *Link) (string, error) {
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return "", err
+	}
+	data = append([]byte("# Written by 'coolifyme link', remove with 'coolifyme unlink'\n"), data...)
+	path := filepath.Join(dir, FileName)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write link file: %w", err)
+	}
+	return path, nil
+}
+
+// Root returns the top-level directory of the git repository containing dir, or dir itself when
+// it is not inside a repository
+func Root(dir string) string {
+	out, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil || out == "" {
+		return dir
+	}
+	return out
+}
+
+// Branch returns the checked out branch of the repository containing dir, or "" when HEAD is
+// detached or dir is not inside a repository
+func Branch(dir string) string {
+	out, err := git(dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil || out == "HEAD" {
+		return ""
+	}
+	return out
+}
+
+// Remotes returns the fetch URLs of the git remotes of the repository containing dir by remote
+// name
+func Remotes(dir string) (map[string]string, error) {
+	out, err := git(dir, "remote", "-v")
+	if err != nil {
+		return nil, err
+	}
+	remotes := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 3 && fields[2] == "(fetch)" {
+			remotes[fields[0]] = fields[1]
+		}
+	}
+	return remotes, nil
+}
+
+// RemoteNames returns the names of remotes with origin first, followed by the others sorted
+func RemoteNames(remotes map[string]string) []string {
+	names := make([]string, 0, len(remotes))
+	for name := range remotes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == "origin") != (names[j] == "origin") {
+			return names[i] == "origin"
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// Matches reports whether a git remote URL refers to the repository stored by Coolify for an
+// application. Coolify stores repositories of GitHub App sources as a bare owner/repo, which
+// matches a remote with that path on any host.
+func Matches(remote, repository string) bool {
+	remoteKey, err := githost.RepositoryKey(remote)
+	if err != nil {
+		return false
+	}
+	repoKey, err := githost.RepositoryKey(repository)
+	if err != nil {
+		return false
+	}
+	if remoteKey == repoKey {
+		return true
+	}
+	repository = strings.TrimSuffix(strings.Trim(strings.TrimSpace(repository), "/"), ".git")
+	if !strings.Contains(repository, ":") && strings.Count(repository, "/") == 1 {
+		return strings.HasSuffix(remoteKey, "/"+strings.ToLower(repository))
+	}
+	return false
+}
+
+// git runs a git command in dir and returns its trimmed output
+func git(dir string, args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...) // #nosec G204 -- fixed git subcommands
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
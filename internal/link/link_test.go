@@ -0,0 +1,96 @@
+package link
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveAndFind(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "src", "app")
+	if err := os.MkdirAll(sub, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := Find(sub); !errors.Is(err, ErrNotLinked) {
+		t.Fatalf("Find() error = %v, want ErrNotLinked", err)
+	}
+
+	want := &Link{Application: "abc123", Name: "shop", Profile: "production", Repository: "git@github.com:acme/shop.git"}
+	path, err := Save(dir, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, found, err := Find(sub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found != path || *got != *want {
+		t.Errorf("Find() = %+v, %s, want %+v, %s", got, found, want, path)
+	}
+
+	if err := os.WriteFile(path, []byte("name: shop\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Find(dir); err == nil {
+		t.Error("Find() of a link file without application succeeded, want an error")
+	}
+}
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		remote, repository string
+		want               bool
+	}{
+		{"git@github.com:acme/shop.git", "https://github.com/acme/shop", true},
+		{"https://github.com/Acme/Shop.git", "acme/shop", true},
+		{"git@git.example.com:acme/shop.git", "acme/shop", true},
+		{"git@github.com:acme/shop.git", "https://gitlab.com/acme/shop", false},
+		{"git@github.com:acme/shop.git", "acme/shop-api", false},
+		{"/srv/git/shop", "acme/shop", false},
+	}
+	for _, tt := range tests {
+		if got := Matches(tt.remote, tt.repository); got != tt.want {
+			t.Errorf("Matches(%q, %q) = %v, want %v", tt.remote, tt.repository, got, tt.want)
+		}
+	}
+}
+
+func TestRemotes(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"remote", "add", "upstream", "https://github.com/acme/shop.git"},
+		{"remote", "add", "origin", "git@github.com:me/shop.git"},
+	} {
+		if _, err := git(dir, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	remotes, err := Remotes(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remotes) != 2 || remotes["origin"] != "git@github.com:me/shop.git" {
+		t.Errorf("Remotes() = %v", remotes)
+	}
+	if names := RemoteNames(remotes); names[0] != "origin" || names[1] != "upstream" {
+		t.Errorf("RemoteNames() = %v, want origin first", names)
+	}
+
+	sub := filepath.Join(dir, "pkg")
+	if err := os.Mkdir(sub, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	root, _ := filepath.EvalSymlinks(dir)
+	if got, _ := filepath.EvalSymlinks(Root(sub)); got != root {
+		t.Errorf("Root() = %s, want %s", got, root)
+	}
+}
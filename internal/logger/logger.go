@@ -2,6 +2,7 @@
 package logger
 
 import (
+	"context"
 	"log/slog"
 	"os"
 )
@@ -51,6 +52,11 @@ func IsTerminal() bool {
 	return (stat.Mode() & os.ModeCharDevice) != 0
 }
 
+// Enabled reports whether messages of the given level are logged
+func Enabled(level slog.Level) bool {
+	return defaultLogger.Enabled(context.Background(), level)
+}
+
 // Debug logs a debug message
 func Debug(msg string, args ...any) {
 	defaultLogger.Debug(msg, args...)
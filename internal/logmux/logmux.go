@@ -0,0 +1,154 @@
+// Package logmux interleaves the log streams of several resources into one output, prefixing
+// every line with the resource it came from like docker compose logs does. Each stream is polled
+// and reconnected on its own, so a resource that cannot be reached does not stop the others.
+package logmux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// prefixColors are the ANSI colors assigned to streams in turn, in the order docker compose uses
+var prefixColors = []string{
+	"\033[36m", "\033[33m", "\033[32m", "\033[35m", "\033[34m",
+	"\033[96m", "\033[93m", "\033[92m", "\033[95m", "\033[94m",
+}
+
+const ansiReset = "\033[0m"
+
+// Source is a log stream of one resource
+type Source struct {
+	// Label identifies the resource in the line prefix, e.g. its name or UUID
+	Label string
+	// Poll returns the lines logged since the previous call
+	Poll func(ctx context.Context) ([]string, error)
+}
+
+// Options controls how sources are polled
+type Options struct {
+	// Follow keeps polling the sources until the context ends; without it every source is
+	// polled once
+	Follow bool
+	// Interval is the time between two polls of a source
+	Interval time.Duration
+	// MaxRetries is the number of consecutive failed polls of a source tolerated before the
+	// source is given up
+	MaxRetries int
+	// RetryDelay is the wait before the first reconnection attempt; it doubles with every failure
+	RetryDelay time.Duration
+	// MaxBackoff caps the wait between reconnection attempts
+	MaxBackoff time.Duration
+	// Color colors the line prefixes
+	Color bool
+}
+
+// Mux writes the lines of several streams to one writer. Lines are written whole, so the lines
+// of different streams never mix.
+type Mux struct {
+	mu       sync.Mutex
+	out      io.Writer
+	prefixes []string
+}
+
+// New creates a multiplexer for streams with the given labels. Labels are padded to the same
+// width so the log lines line up.
+func New(out io.Writer, labels []string, color bool) *Mux {
+	width := 0
+	for _, label := range labels {
+		width = max(width, len(label))
+	}
+	prefixes := make([]string, len(labels))
+	for i, label := range labels {
+		prefix := label + strings.Repeat(" ", width-len(label)) + " | "
+		if color {
+			prefix = prefixColors[i%len(prefixColors)] + prefix + ansiReset
+		}
+		prefixes[i] = prefix
+	}
+	return &Mux{out: out, prefixes: prefixes}
+}
+
+// Write writes lines of the stream with the given index, each with the stream's prefix
+func (m *Mux) Write(stream int, lines ...string) {
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(m.prefixes[stream])
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, _ = io.WriteString(m.out, b.String())
+}
+
+// Run polls the sources concurrently and writes their lines to out until every source is done:
+// after one poll without Options.Follow, or when the context ends or the source is given up
+// with Follow. Failed polls are retried with exponential backoff, reporting the lost and
+// restored connection in the stream. The returned error joins the errors of the sources that
+// failed.
+func Run(ctx context.Context, out io.Writer, sources []Source, opts Options) error {
+	labels := make([]string, len(sources))
+	for i, source := range sources {
+		labels[i] = source.Label
+	}
+	mux := New(out, labels, opts.Color)
+
+	errs := make([]error, len(sources))
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := mux.follow(ctx, i, source, opts); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", source.Label, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// follow polls one source, reconnecting after failures
+func (m *Mux) follow(ctx context.Context, stream int, source Source, opts Options) error {
+	failures := 0
+	for {
+		lines, err := source.Poll(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err == nil && len(lines) > 0 {
+			m.Write(stream, lines...)
+		}
+		if !opts.Follow {
+			return err
+		}
+
+		wait := opts.Interval
+		if err != nil {
+			failures++
+			if failures > opts.MaxRetries {
+				m.Write(stream, fmt.Sprintf("giving up after %d failed attempts: %v", failures, err))
+				return fmt.Errorf("giving up after %d failed attempts: %w", failures, err)
+			}
+			wait = opts.RetryDelay * time.Duration(1<<min(failures-1, 16))
+			if opts.MaxBackoff > 0 && wait > opts.MaxBackoff {
+				wait = opts.MaxBackoff
+			}
+			m.Write(stream, fmt.Sprintf("connection lost: %v, reconnecting in %v (attempt %d/%d)", err, wait, failures, opts.MaxRetries))
+		} else if failures > 0 {
+			m.Write(stream, "reconnected")
+			failures = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
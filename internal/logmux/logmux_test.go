@@ -0,0 +1,102 @@
+package logmux
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMuxWrite(t *testing.T) {
+	var out bytes.Buffer
+	mux := New(&out, []string{"api", "worker"}, false)
+	mux.Write(0, "started", "listening")
+	mux.Write(1, "ready")
+
+	want := "api    | started\napi    | listening\nworker | ready\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	New(&out, []string{"api"}, true).Write(0, "x")
+	if !strings.HasPrefix(out.String(), prefixColors[0]+"api | "+ansiReset) {
+		t.Errorf("colored output = %q", out.String())
+	}
+}
+
+func TestRunOnce(t *testing.T) {
+	var out bytes.Buffer
+	err := Run(context.Background(), &out, []Source{
+		{Label: "a", Poll: func(context.Context) ([]string, error) { return []string{"one"}, nil }},
+		{Label: "b", Poll: func(context.Context) ([]string, error) { return nil, errors.New("not found") }},
+	}, Options{})
+	if err == nil || !strings.Contains(err.Error(), "b: not found") {
+		t.Errorf("Run() error = %v, want the error of b", err)
+	}
+	if out.String() != "a | one\n" {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+// syncBuffer is a buffer safe for concurrent writes and reads
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestRunFollowReconnects(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	polls := 0
+	flaky := func(context.Context) ([]string, error) {
+		polls++
+		switch polls {
+		case 1:
+			return []string{"first"}, nil
+		case 2:
+			return nil, errors.New("timeout")
+		case 3:
+			return []string{"second"}, nil
+		}
+		cancel()
+		return nil, nil
+	}
+	broken := func(context.Context) ([]string, error) { return nil, errors.New("refused") }
+
+	var out syncBuffer
+	err := Run(ctx, &out, []Source{{Label: "flaky", Poll: flaky}, {Label: "broken", Poll: broken}},
+		Options{Follow: true, Interval: time.Millisecond, RetryDelay: time.Millisecond, MaxRetries: 1})
+	if err == nil || !strings.Contains(err.Error(), "broken: giving up after 2 failed attempts") {
+		t.Errorf("Run() error = %v, want broken to be given up", err)
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		"flaky  | first\n",
+		"flaky  | connection lost: timeout",
+		"flaky  | reconnected\n",
+		"flaky  | second\n",
+		"broken | giving up after 2 failed attempts: refused\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output does not contain %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+// Package metrics keeps gauges, counters and histograms and writes them in the Prometheus text
+// exposition format, so coolifyme can be scraped without pulling in the Prometheus client library.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the content type of the Prometheus text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are histogram buckets in seconds suited to API request latencies
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Registry holds metric families in the order they were registered
+type Registry struct {
+	mu       sync.Mutex
+	families []*family
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// family is a named metric with one series per combination of label values
+type family struct {
+	name    string
+	help    string
+	kind    string
+	labels  []string
+	buckets []float64
+	series  map[string]*series
+}
+
+// series is the state of a single label combination
+type series struct {
+	values []string
+	value  float64
+	// counts holds the cumulative bucket counts of a histogram; sum and count its totals
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// Gauge is a value that can go up and down
+type Gauge struct {
+	r *Registry
+	f *family
+}
+
+// Counter is a value that only goes up
+type Counter struct {
+	r *Registry
+	f *family
+}
+
+// Histogram counts observations in buckets
+type Histogram struct {
+	r *Registry
+	f *family
+}
+
+// Gauge registers a gauge with the given label names
+func (r *Registry) Gauge(name, help string, labels ...string) *Gauge {
+	return &Gauge{r: r, f: r.register(name, help, "gauge", nil, labels)}
+}
+
+// Counter registers a counter with the given label names
+func (r *Registry) Counter(name, help string, labels ...string) *Counter {
+	return &Counter{r: r, f: r.register(name, help, "counter", nil, labels)}
+}
+
+// Histogram registers a histogram with the given upper bucket bounds and label names
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return &Histogram{r: r, f: r.register(name, help, "histogram", sorted, labels)}
+}
+
+func (r *Registry) register(name, help, kind string, buckets []float64, labels []string) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f := &family{name: name, help: help, kind: kind, labels: labels, buckets: buckets, series: make(map[string]*series)}
+	r.families = append(r.families, f)
+	return f
+}
+
+// get returns the series for the label values, creating it when needed. The registry lock must be held.
+func (f *family) get(values []string) *series {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", f.name, len(f.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{values: append([]string(nil), values...)}
+		if f.kind == "histogram" {
+			s.counts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// Set sets the gauge for the label values
+func (g *Gauge) Set(value float64, labelValues ...string) {
+	g.r.mu.Lock()
+	defer g.r.mu.Unlock()
+	g.f.get(labelValues).value = value
+}
+
+// Reset removes all series, e.g. before setting the gauges of resources that may have been deleted
+func (g *Gauge) Reset() {
+	g.r.mu.Lock()
+	defer g.r.mu.Unlock()
+	g.f.series = make(map[string]*series)
+}
+
+// Inc adds one to the counter for the label values
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds a non-negative value to the counter for the label values
+func (c *Counter) Add(value float64, labelValues ...string) {
+	if value < 0 {
+		return
+	}
+	c.r.mu.Lock()
+	defer c.r.mu.Unlock()
+	c.f.get(labelValues).value += value
+}
+
+// Observe records a value in the histogram for the label values
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	h.r.mu.Lock()
+	defer h.r.mu.Unlock()
+	s := h.f.get(labelValues)
+	for i, bound := range h.f.buckets {
+		if value <= bound {
+			s.counts[i]++
+		}
+	}
+	s.sum += value
+	s.count++
+}
+
+// Write writes all metrics in the Prometheus text exposition format, series sorted by labels
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b strings.Builder
+	for _, f := range r.families {
+		fmt.Fprintf(&b, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+		fmt.Fprintf(&b, "# TYPE %s %s\n", f.name, f.kind)
+
+		keys := make([]string, 0, len(f.series))
+		for key := range f.series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			s := f.series[key]
+			if f.kind != "histogram" {
+				fmt.Fprintf(&b, "%s%s %s\n", f.name, formatLabels(f.labels, s.values, "", ""), formatValue(s.value))
+				continue
+			}
+			for i, bound := range f.buckets {
+				fmt.Fprintf(&b, "%s_bucket%s %d\n", f.name, formatLabels(f.labels, s.values, "le", formatValue(bound)), s.counts[i])
+			}
+			fmt.Fprintf(&b, "%s_bucket%s %d\n", f.name, formatLabels(f.labels, s.values, "le", "+Inf"), s.count)
+			fmt.Fprintf(&b, "%s_sum%s %s\n", f.name, formatLabels(f.labels, s.values, "", ""), formatValue(s.sum))
+			fmt.Fprintf(&b, "%s_count%s %d\n", f.name, formatLabels(f.labels, s.values, "", ""), s.count)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Handler serves the metrics of the registry
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		_ = r.Write(w)
+	})
+}
+
+// formatLabels renders label pairs, appending an extra pair such as the histogram bucket bound
+func formatLabels(names, values []string, extraName, extraValue string) string {
+	pairs := make([]string, 0, len(names)+1)
+	for i, name := range names {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, name, escapeLabel(values[i])))
+	}
+	if extraName != "" {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, extraName, extraValue))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	r := NewRegistry()
+	up := r.Gauge("coolifyme_up", "Whether the last poll succeeded")
+	status := r.Gauge("app_status", "Application status", "name", "status")
+	deployments := r.Counter("deployments_total", "Deployments", "status")
+	latency := r.Histogram("latency_seconds", "Latency", []float64{1, 0.5}, "method")
+
+	up.Set(1)
+	status.Set(1, `my "app"`, "running")
+	deployments.Inc("failed")
+	deployments.Add(2, "finished")
+	deployments.Add(-1, "finished")
+	latency.Observe(0.2, "GET")
+	latency.Observe(0.7, "GET")
+	latency.Observe(3, "GET")
+
+	var b strings.Builder
+	if err := r.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP coolifyme_up Whether the last poll succeeded
+# TYPE coolifyme_up gauge
+coolifyme_up 1
+# HELP app_status Application status
+# TYPE app_status gauge
+app_status{name="my \"app\"",status="running"} 1
+# HELP deployments_total Deployments
+# TYPE deployments_total counter
+deployments_total{status="failed"} 1
+deployments_total{status="finished"} 2
+# HELP latency_seconds Latency
+# TYPE latency_seconds histogram
+latency_seconds_bucket{method="GET",le="0.5"} 1
+latency_seconds_bucket{method="GET",le="1"} 2
+latency_seconds_bucket{method="GET",le="+Inf"} 3
+latency_seconds_sum{method="GET"} 3.9
+latency_seconds_count{method="GET"} 3
+`
+	if got := b.String(); got != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGaugeReset(t *testing.T) {
+	r := NewRegistry()
+	g := r.Gauge("server_reachable", "Reachability", "name")
+	g.Set(1, "old")
+	g.Reset()
+	g.Set(0, "new")
+
+	var b strings.Builder
+	if err := r.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(b.String(), "old") || !strings.Contains(b.String(), `server_reachable{name="new"} 0`) {
+		t.Errorf("unexpected output after reset:\n%s", b.String())
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r := NewRegistry()
+	r.Counter("polls_total", "Polls").Inc()
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Header().Get("Content-Type") != ContentType {
+		t.Errorf("unexpected content type %q", rec.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(rec.Body.String(), "polls_total 1\n") {
+		t.Errorf("unexpected body:\n%s", rec.Body.String())
+	}
+}
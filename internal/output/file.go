@@ -0,0 +1,41 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// OpenFile opens a file for command output, replacing its contents or, with appendMode,
+// appending to it. Appends use O_APPEND, so several coolifyme processes writing to the same
+// file, e.g. parallel CI jobs, add their output after each other instead of overwriting it.
+func OpenFile(path string, appendMode bool) (*os.File, error) {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if appendMode {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	file, err := os.OpenFile(path, flags, 0o600) // #nosec G304 -- the path is given by the user
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("cannot open output file %s: the directory does not exist", path)
+		}
+		return nil, fmt.Errorf("cannot open output file %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// CloseFile flushes and closes an output file, reporting errors such as a full disk that
+// were not visible to the individual writes. Devices such as /dev/stderr are not flushed.
+func CloseFile(file *os.File) error {
+	if info, err := file.Stat(); err == nil && info.Mode().IsRegular() {
+		if err := file.Sync(); err != nil {
+			_ = file.Close()
+			return fmt.Errorf("failed to write output file %s: %w", file.Name(), err)
+		}
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write output file %s: %w", file.Name(), err)
+	}
+	return nil
+}
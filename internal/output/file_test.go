@@ -0,0 +1,43 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOpenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.txt")
+
+	write := func(appendMode bool, text string) {
+		t.Helper()
+		file, err := OpenFile(path, appendMode)
+		if err != nil {
+			t.Fatalf("OpenFile() error = %v", err)
+		}
+		_, _ = fmt.Fprintln(file, text)
+		if err := CloseFile(file); err != nil {
+			t.Fatalf("CloseFile() error = %v", err)
+		}
+	}
+
+	write(false, "first")
+	write(true, "second")
+	data, _ := os.ReadFile(path)
+	if string(data) != "first\nsecond\n" {
+		t.Errorf("appended file = %q", data)
+	}
+
+	write(false, "third")
+	data, _ = os.ReadFile(path)
+	if string(data) != "third\n" {
+		t.Errorf("truncated file = %q", data)
+	}
+
+	_, err := OpenFile(filepath.Join(t.TempDir(), "missing", "out.txt"), false)
+	if err == nil || !strings.Contains(err.Error(), "directory does not exist") {
+		t.Errorf("OpenFile() in a missing directory error = %v", err)
+	}
+}
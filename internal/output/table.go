@@ -0,0 +1,302 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// ErrPagingStopped is returned by TableWriter.Row once the pager declined the next page
+var ErrPagingStopped = errors.New("paging stopped")
+
+// TableOptions configures a TableWriter
+type TableOptions struct {
+	// NoHeaders leaves out the header and separator rows
+	NoHeaders bool
+	// PageSize is the number of rows after which the table is flushed and Pager is asked
+	// whether to continue; 0 writes all rows as one table
+	PageSize int
+	// Pager is called after every full page; returning false stops the output. Without a pager,
+	// PageSize only flushes the table in chunks.
+	Pager func() bool
+}
+
+// TableWriter writes rows to a tabwriter as they are produced instead of collecting them first.
+// With a page size every page is aligned and flushed on its own, with the headers repeated, so
+// the first rows appear before the rest are rendered.
+type TableWriter struct {
+	out     io.Writer
+	w       *tabwriter.Writer
+	headers []string
+	options TableOptions
+	// rows is the number of rows on the current page
+	rows    int
+	stopped bool
+	// line is reused to build each line, so a row is a single write
+	line []byte
+}
+
+// NewTableWriter creates a table writer for the given columns
+func NewTableWriter(out io.Writer, headers []string, options TableOptions) *TableWriter {
+	return &TableWriter{out: out, headers: headers, options: options}
+}
+
+// Row writes one row, starting a new page when the current one is full
+func (t *TableWriter) Row(cells ...string) error {
+	if t.stopped {
+		return ErrPagingStopped
+	}
+	if t.w != nil && t.options.PageSize > 0 && t.rows >= t.options.PageSize {
+		if err := t.Flush(); err != nil {
+			return err
+		}
+		if t.options.Pager != nil && !t.options.Pager() {
+			t.stopped = true
+			return ErrPagingStopped
+		}
+	}
+	if t.w == nil {
+		if err := t.startPage(); err != nil {
+			return err
+		}
+	}
+
+	t.rows++
+	return t.writeLine(cells)
+}
+
+// Flush writes the rows of the current page
+func (t *TableWriter) Flush() error {
+	if t.w == nil {
+		return nil
+	}
+	w := t.w
+	t.w, t.rows = nil, 0
+	return w.Flush()
+}
+
+func (t *TableWriter) startPage() error {
+	t.w = tabwriter.NewWriter(t.out, 0, 0, 2, ' ', 0)
+	if t.options.NoHeaders || len(t.headers) == 0 {
+		return nil
+	}
+	if err := t.writeLine(t.headers); err != nil {
+		return fmt.Errorf("failed to write table headers: %w", err)
+	}
+	separators := make([]string, len(t.headers))
+	for i, header := range t.headers {
+		separators[i] = strings.Repeat("-", len(header))
+	}
+	if err := t.writeLine(separators); err != nil {
+		return fmt.Errorf("failed to write table separators: %w", err)
+	}
+	return nil
+}
+
+func (t *TableWriter) writeLine(cells []string) error {
+	t.line = t.line[:0]
+	for i, cell := range cells {
+		if i > 0 {
+			t.line = append(t.line, '\t')
+		}
+		t.line = append(t.line, cell...)
+	}
+	t.line = append(t.line, '\n')
+	_, err := t.w.Write(t.line)
+	return err
+}
+
+// structField is an exported field of a struct type
+type structField struct {
+	index  int
+	header string
+}
+
+// StructFields are the precomputed accessors of the exported fields of a struct type, so rows
+// of many items are built without looking fields up by name for every cell
+type StructFields struct {
+	fields []structField
+	// byName maps lower-case field names and json tags to positions in fields
+	byName map[string]int
+}
+
+// structFieldsCache holds the StructFields of every type rendered so far
+var structFieldsCache sync.Map // map[reflect.Type]*StructFields
+
+// FieldsOf returns the fields of a struct type, computing them once per type
+func FieldsOf(t reflect.Type) *StructFields {
+	if cached, ok := structFieldsCache.Load(t); ok {
+		return cached.(*StructFields)
+	}
+
+	fields := &StructFields{byName: make(map[string]int)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		header := field.Name
+		tag := field.Tag.Get("json")
+		if idx := strings.Index(tag, ","); idx != -1 {
+			tag = tag[:idx]
+		}
+		if tag != "" && tag != "-" {
+			header = tag
+		} else {
+			tag = ""
+		}
+
+		pos := len(fields.fields)
+		fields.fields = append(fields.fields, structField{index: i, header: strings.ToUpper(header)})
+		// The first field matching a name wins, by field name before json tag
+		for _, name := range []string{field.Name, tag} {
+			if key := strings.ToLower(name); key != "" {
+				if _, exists := fields.byName[key]; !exists {
+					fields.byName[key] = pos
+				}
+			}
+		}
+	}
+
+	actual, _ := structFieldsCache.LoadOrStore(t, fields)
+	return actual.(*StructFields)
+}
+
+// Headers returns the column headers of all fields: the upper-case json tag or field name
+func (f *StructFields) Headers() []string {
+	headers := make([]string, len(f.fields))
+	for i, field := range f.fields {
+		headers[i] = field.header
+	}
+	return headers
+}
+
+// Lookup returns the position of the field with the given name or json tag, ignoring case
+func (f *StructFields) Lookup(name string) (int, bool) {
+	pos, ok := f.byName[strings.ToLower(name)]
+	return pos, ok
+}
+
+// Value formats the field at a position of a struct value
+func (f *StructFields) Value(v reflect.Value, pos int) string {
+	return FormatValue(v.Field(f.fields[pos].index))
+}
+
+// Rows returns the headers and a row iterator for a slice of items, or a single item. Without
+// columns, the headers are all fields of the first item; with columns, the fields of each
+// item are matched by name or json tag, ignoring case.
+func Rows(data any, columns []string) ([]string, iter.Seq[[]string]) {
+	items := reflect.ValueOf(data)
+	if items.Kind() == reflect.Ptr {
+		items = items.Elem()
+	}
+	if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+		items = reflect.ValueOf([]any{data})
+	}
+	if items.Len() == 0 {
+		return nil, func(func([]string) bool) {}
+	}
+
+	headers := columns
+	if len(headers) == 0 {
+		if first := structValue(items.Index(0)); first.IsValid() {
+			headers = FieldsOf(first.Type()).Headers()
+		}
+	}
+
+	return headers, func(yield func([]string) bool) {
+		var (
+			lastType reflect.Type
+			fields   *StructFields
+			// positions are the field positions of the headers for lastType, -1 when missing
+			positions = make([]int, len(headers))
+		)
+		for i := 0; i < items.Len(); i++ {
+			item := items.Index(i)
+			v := structValue(item)
+			row := make([]string, len(headers))
+			if !v.IsValid() {
+				for j := range row {
+					row[j] = fmt.Sprintf("%v", item.Interface())
+				}
+				if !yield(row) {
+					return
+				}
+				continue
+			}
+
+			if v.Type() != lastType {
+				lastType, fields = v.Type(), FieldsOf(v.Type())
+				for j, header := range headers {
+					if pos, ok := fields.Lookup(header); ok {
+						positions[j] = pos
+					} else {
+						positions[j] = -1
+					}
+				}
+			}
+			for j, pos := range positions {
+				if pos >= 0 {
+					row[j] = fields.Value(v, pos)
+				}
+			}
+			if !yield(row) {
+				return
+			}
+		}
+	}
+}
+
+// structValue dereferences interfaces and pointers, returning an invalid value for items that
+// are not structs
+func structValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return v
+}
+
+// timeType is formatted as a date and time rather than with its String method
+var timeType = reflect.TypeOf(time.Time{})
+
+// FormatValue formats a field value for a table cell; nil pointers are empty
+func FormatValue(value reflect.Value) string {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+	default:
+		if value.Type() == timeType {
+			return value.Interface().(time.Time).Format("2006-01-02 15:04:05")
+		}
+		return fmt.Sprintf("%v", value.Interface())
+	}
+}
@@ -0,0 +1,187 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"text/tabwriter"
+)
+
+type tableItem struct {
+	UUID    *string `json:"uuid,omitempty"`
+	Name    string  `json:"name"`
+	Status  string
+	Port    int    `json:"port"`
+	Ignored string `json:"-"`
+	private string
+}
+
+func TestRows(t *testing.T) {
+	uuid := "abc"
+	items := []tableItem{
+		{UUID: &uuid, Name: "web", Status: "running", Port: 80, private: "x"},
+		{Name: "db", Status: "exited", Port: 5432},
+	}
+
+	headers, rows := Rows(items, nil)
+	if want := []string{"UUID", "NAME", "STATUS", "PORT", "IGNORED"}; !slices.Equal(headers, want) {
+		t.Errorf("headers = %v, want %v", headers, want)
+	}
+	var got [][]string
+	for row := range rows {
+		got = append(got, row)
+	}
+	want := [][]string{{"abc", "web", "running", "80", ""}, {"", "db", "exited", "5432", ""}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %v, want %v", got, want)
+	}
+
+	// Columns match field names and json tags ignoring case; unknown columns stay empty
+	headers, rows = Rows(&items, []string{"name", "status", "Port", "missing"})
+	if !slices.Equal(headers, []string{"name", "status", "Port", "missing"}) {
+		t.Errorf("headers with columns = %v", headers)
+	}
+	for row := range rows {
+		if want := []string{"web", "running", "80", ""}; !slices.Equal(row, want) {
+			t.Errorf("row with columns = %v, want %v", row, want)
+		}
+		break
+	}
+
+	// A single item is a table of one row
+	_, rows = Rows(items[1], []string{"name"})
+	var count int
+	for range rows {
+		count++
+	}
+	if count != 1 {
+		t.Errorf("single item rows = %d, want 1", count)
+	}
+
+	if headers, _ := Rows([]tableItem{}, nil); headers != nil {
+		t.Errorf("headers of no items = %v, want nil", headers)
+	}
+}
+
+func TestTableWriter(t *testing.T) {
+	var out bytes.Buffer
+	table := NewTableWriter(&out, []string{"NAME", "STATUS"}, TableOptions{})
+	for _, row := range [][]string{{"web", "running"}, {"database", "exited"}} {
+		if err := table.Row(row...); err != nil {
+			t.Fatalf("Row() error = %v", err)
+		}
+	}
+	if err := table.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	want := "NAME      STATUS\n----      ------\nweb       running\ndatabase  exited\n"
+	if out.String() != want {
+		t.Errorf("table =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestTableWriterPaging(t *testing.T) {
+	var out bytes.Buffer
+	var pages int
+	table := NewTableWriter(&out, []string{"N"}, TableOptions{
+		PageSize: 2,
+		Pager: func() bool {
+			pages++
+			return pages < 2
+		},
+	})
+
+	var err error
+	written := 0
+	for i := 0; i < 10 && err == nil; i++ {
+		if err = table.Row(fmt.Sprint(i)); err == nil {
+			written++
+		}
+	}
+	if !errors.Is(err, ErrPagingStopped) {
+		t.Fatalf("Row() error = %v, want ErrPagingStopped", err)
+	}
+	if written != 4 || pages != 2 {
+		t.Errorf("written = %d, pages = %d; want 4 rows on 2 pages", written, pages)
+	}
+	if err := table.Row("late"); !errors.Is(err, ErrPagingStopped) {
+		t.Errorf("Row() after stop error = %v", err)
+	}
+	_ = table.Flush()
+	if got := strings.Count(out.String(), "N\n-\n"); got != 2 {
+		t.Errorf("headers printed %d times, want once per page:\n%s", got, out.String())
+	}
+}
+
+// benchmarkItems returns n items shaped like the applications of a large instance
+func benchmarkItems(n int) []tableItem {
+	items := make([]tableItem, n)
+	for i := range items {
+		uuid := fmt.Sprintf("uuid-%05d", i)
+		items[i] = tableItem{UUID: &uuid, Name: fmt.Sprintf("app-%d", i), Status: "running:healthy", Port: 3000 + i%100}
+	}
+	return items
+}
+
+// BenchmarkTable renders 5000 rows with the cached field accessors and streaming writer, and
+// with the former per-cell field lookup collected into a slice first. Run it with 'task bench'.
+func BenchmarkTable(b *testing.B) {
+	items := benchmarkItems(5000)
+	columns := []string{"uuid", "name", "status", "port"}
+
+	b.Run("cached-streaming", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			headers, rows := Rows(items, columns)
+			table := NewTableWriter(io.Discard, headers, TableOptions{})
+			for row := range rows {
+				if err := table.Row(row...); err != nil {
+					b.Fatal(err)
+				}
+			}
+			if err := table.Flush(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("uncached-collected", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var collected [][]string
+			for _, item := range items {
+				row := make([]string, len(columns))
+				for j, column := range columns {
+					row[j] = lookupField(item, column)
+				}
+				collected = append(collected, row)
+			}
+			w := tabwriter.NewWriter(io.Discard, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, strings.Join(columns, "\t"))
+			for _, row := range collected {
+				_, _ = fmt.Fprintln(w, strings.Join(row, "\t"))
+			}
+			_ = w.Flush()
+		}
+	})
+}
+
+// lookupField finds a field by scanning the struct type for every cell, as table output did
+// before field accessors were cached
+func lookupField(item any, name string) string {
+	v := reflect.ValueOf(item)
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := t.Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if strings.EqualFold(field.Name, name) || (tag != "" && tag != "-" && strings.EqualFold(tag, name)) {
+			return FormatValue(v.Field(i))
+		}
+	}
+	return ""
+}
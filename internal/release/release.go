@@ -0,0 +1,236 @@
+// Package release renders package manager manifests for coolifyme releases from the published
+// checksums, so the Homebrew formula, Scoop manifest and install snippets are reproducible from
+// the code instead of being edited by hand.
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hongkongkiwi/coolifyme/internal/selfupdate"
+)
+
+// Description is the one-line summary used by package managers
+const Description = "A powerful command-line interface for the Coolify API"
+
+// Platform is an operating system and architecture coolifyme is released for
+type Platform struct {
+	OS   string
+	Arch string
+}
+
+// Platforms are the targets built by 'task release-build'
+var Platforms = []Platform{
+	{"linux", "amd64"},
+	{"linux", "arm64"},
+	{"linux", "arm"},
+	{"darwin", "amd64"},
+	{"darwin", "arm64"},
+	{"windows", "amd64"},
+	{"windows", "arm64"},
+}
+
+// Binary returns the name of the release binary of the platform
+func (p Platform) Binary() string {
+	return selfupdate.AssetName(p.OS, p.Arch)
+}
+
+// Archive returns the name of the tar.gz archive holding the release binary of the platform
+func (p Platform) Archive() string {
+	return p.Binary() + ".tar.gz"
+}
+
+// Manifest describes a published release
+type Manifest struct {
+	// Version is the release version without the leading v
+	Version    string
+	Repository string
+	// Checksums maps release asset names to their SHA-256 checksums
+	Checksums map[string]string
+}
+
+// New creates the manifest of a release from its version and checksums.txt listing
+func New(version, repository string, checksums []byte) (*Manifest, error) {
+	if _, err := selfupdate.ParseVersion(version); err != nil {
+		return nil, err
+	}
+	if repository == "" {
+		repository = selfupdate.DefaultRepository
+	}
+	return &Manifest{
+		Version:    strings.TrimPrefix(strings.TrimSpace(version), "v"),
+		Repository: repository,
+		Checksums:  selfupdate.ParseChecksums(checksums),
+	}, nil
+}
+
+// Homepage returns the project page
+func (m *Manifest) Homepage() string {
+	return "https://github.com/" + m.Repository
+}
+
+// URL returns the download URL of a release asset
+func (m *Manifest) URL(asset string) string {
+	return fmt.Sprintf("%s/releases/download/v%s/%s", m.Homepage(), m.Version, asset)
+}
+
+// available returns the platforms of an operating system with a checksum for the asset
+func (m *Manifest) available(goos string, asset func(Platform) string) []Platform {
+	var platforms []Platform
+	for _, platform := range Platforms {
+		if platform.OS == goos {
+			if _, ok := m.Checksums[asset(platform)]; ok {
+				platforms = append(platforms, platform)
+			}
+		}
+	}
+	return platforms
+}
+
+// homebrewArch maps Go architectures to Homebrew's on_<arch> blocks
+var homebrewArch = map[string]string{"amd64": "intel", "arm64": "arm"}
+
+// Homebrew renders a Homebrew formula installing the macOS and Linux archives listed in the
+// checksums
+func (m *Manifest) Homebrew() (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "class Coolifyme < Formula\n")
+	fmt.Fprintf(&b, "  desc %q\n", Description)
+	fmt.Fprintf(&b, "  homepage %q\n", m.Homepage())
+	fmt.Fprintf(&b, "  version %q\n", m.Version)
+	fmt.Fprintf(&b, "  license \"MIT\"\n")
+
+	found := false
+	for _, system := range []struct{ goos, block string }{{"darwin", "macos"}, {"linux", "linux"}} {
+		var platforms []Platform
+		for _, platform := range m.available(system.goos, Platform.Archive) {
+			if homebrewArch[platform.Arch] != "" {
+				platforms = append(platforms, platform)
+			}
+		}
+		if len(platforms) == 0 {
+			continue
+		}
+		found = true
+		fmt.Fprintf(&b, "\n  on_%s do\n", system.block)
+		for _, platform := range platforms {
+			fmt.Fprintf(&b, "    on_%s do\n", homebrewArch[platform.Arch])
+			fmt.Fprintf(&b, "      url %q\n", m.URL(platform.Archive()))
+			fmt.Fprintf(&b, "      sha256 %q\n", m.Checksums[platform.Archive()])
+			fmt.Fprintf(&b, "    end\n")
+		}
+		fmt.Fprintf(&b, "  end\n")
+	}
+	if !found {
+		return "", fmt.Errorf("no checksums for macOS or Linux archives (%s)", Platform{"darwin", "arm64"}.Archive())
+	}
+
+	b.WriteString(`
+  def install
+    bin.install Dir["coolifyme-*"].first => "coolifyme"
+    generate_completions_from_executable(bin/"coolifyme", "completion")
+  end
+
+  test do
+    assert_match "coolifyme version", shell_output("#{bin}/coolifyme --version")
+  end
+end
+`)
+	return b.String(), nil
+}
+
+// scoopArch maps Go architectures to Scoop's architecture keys
+var scoopArch = map[string]string{"amd64": "64bit", "arm64": "arm64"}
+
+type scoopArchitecture struct {
+	URL  string     `json:"url"`
+	Hash string     `json:"hash,omitempty"`
+	Bin  [][]string `json:"bin,omitempty"`
+}
+
+type scoopManifest struct {
+	Version      string                       `json:"version"`
+	Description  string                       `json:"description"`
+	Homepage     string                       `json:"homepage"`
+	License      string                       `json:"license"`
+	Architecture map[string]scoopArchitecture `json:"architecture"`
+	CheckVer     map[string]string            `json:"checkver"`
+	AutoUpdate   map[string]any               `json:"autoupdate"`
+}
+
+// Scoop renders a Scoop manifest installing the Windows binaries listed in the checksums. Its
+// autoupdate section lets Scoop's tooling pick up later releases from checksums.txt.
+func (m *Manifest) Scoop() (string, error) {
+	manifest := scoopManifest{
+		Version:      m.Version,
+		Description:  Description,
+		Homepage:     m.Homepage(),
+		License:      "MIT",
+		Architecture: make(map[string]scoopArchitecture),
+		CheckVer:     map[string]string{"github": m.Homepage()},
+	}
+	autoUpdate := make(map[string]scoopArchitecture)
+	for _, platform := range m.available("windows", Platform.Binary) {
+		key := scoopArch[platform.Arch]
+		if key == "" {
+			continue
+		}
+		manifest.Architecture[key] = scoopArchitecture{
+			URL:  m.URL(platform.Binary()),
+			Hash: m.Checksums[platform.Binary()],
+			Bin:  [][]string{{platform.Binary(), "coolifyme"}},
+		}
+		autoUpdate[key] = scoopArchitecture{
+			URL: fmt.Sprintf("%s/releases/download/v$version/%s", m.Homepage(), platform.Binary()),
+		}
+	}
+	if len(manifest.Architecture) == 0 {
+		return "", fmt.Errorf("no checksums for Windows binaries (%s)", Platform{"windows", "amd64"}.Binary())
+	}
+	manifest.AutoUpdate = map[string]any{
+		"architecture": autoUpdate,
+		"hash":         map[string]string{"url": "$baseurl/" + selfupdate.ChecksumsAsset},
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// unamePatterns maps platforms to the case patterns matching `uname -s`-`uname -m`
+var unamePatterns = map[Platform]string{
+	{"linux", "amd64"}:  "Linux-x86_64",
+	{"linux", "arm64"}:  "Linux-aarch64 | Linux-arm64",
+	{"linux", "arm"}:    "Linux-armv7l",
+	{"darwin", "amd64"}: "Darwin-x86_64",
+	{"darwin", "arm64"}: "Darwin-arm64",
+}
+
+// InstallScript renders a POSIX shell snippet downloading the binary for the current macOS or
+// Linux machine, verifying its checksum and installing it to /usr/local/bin
+func (m *Manifest) InstallScript() (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Install coolifyme v%s\n", m.Version)
+	b.WriteString("set -e\n")
+	b.WriteString("case \"$(uname -s)-$(uname -m)\" in\n")
+	found := false
+	for _, goos := range []string{"linux", "darwin"} {
+		for _, platform := range m.available(goos, Platform.Binary) {
+			found = true
+			fmt.Fprintf(&b, "  %s) asset=%s sum=%s ;;\n", unamePatterns[platform], platform.Binary(), m.Checksums[platform.Binary()])
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("no checksums for macOS or Linux binaries (%s)", Platform{"linux", "amd64"}.Binary())
+	}
+	b.WriteString("  *) echo \"coolifyme is not released for $(uname -s)-$(uname -m)\" >&2; exit 1 ;;\n")
+	b.WriteString("esac\n")
+	fmt.Fprintf(&b, "curl -fsSL -o coolifyme \"%s/releases/download/v%s/$asset\"\n", m.Homepage(), m.Version)
+	b.WriteString("echo \"$sum  coolifyme\" | { sha256sum -c - 2>/dev/null || shasum -a 256 -c -; }\n")
+	b.WriteString("chmod +x coolifyme\n")
+	b.WriteString("sudo mv coolifyme /usr/local/bin/coolifyme\n")
+	return b.String(), nil
+}
@@ -0,0 +1,99 @@
+package release
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const testChecksums = `1111111111111111111111111111111111111111111111111111111111111111  ./coolifyme-darwin-arm64.tar.gz
+2222222222222222222222222222222222222222222222222222222222222222  ./coolifyme-linux-amd64.tar.gz
+3333333333333333333333333333333333333333333333333333333333333333  coolifyme-linux-amd64
+4444444444444444444444444444444444444444444444444444444444444444  coolifyme-linux-arm
+5555555555555555555555555555555555555555555555555555555555555555  coolifyme-windows-amd64.exe
+`
+
+func TestNew(t *testing.T) {
+	m, err := New("v1.2.3", "", []byte(testChecksums))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Version != "1.2.3" || m.Repository != "hongkongkiwi/coolifyme" {
+		t.Errorf("New() = %+v", m)
+	}
+	if got, want := m.URL("coolifyme-linux-amd64"), "https://github.com/hongkongkiwi/coolifyme/releases/download/v1.2.3/coolifyme-linux-amd64"; got != want {
+		t.Errorf("URL() = %q, want %q", got, want)
+	}
+	if _, err := New("dev", "", nil); err == nil {
+		t.Error("New(dev) succeeded, want an error")
+	}
+}
+
+func TestHomebrew(t *testing.T) {
+	m, _ := New("1.2.3", "", []byte(testChecksums))
+	formula, err := m.Homebrew()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`version "1.2.3"`,
+		"  on_macos do\n    on_arm do\n      url \"https://github.com/hongkongkiwi/coolifyme/releases/download/v1.2.3/coolifyme-darwin-arm64.tar.gz\"\n      sha256 \"1111",
+		"  on_linux do\n    on_intel do\n",
+		`sha256 "2222`,
+	} {
+		if !strings.Contains(formula, want) {
+			t.Errorf("formula does not contain %q:\n%s", want, formula)
+		}
+	}
+	// Homebrew has no 32-bit ARM builds
+	if strings.Contains(formula, "linux-arm.") {
+		t.Errorf("formula contains the linux/arm archive:\n%s", formula)
+	}
+
+	empty, _ := New("1.2.3", "", nil)
+	if _, err := empty.Homebrew(); err == nil {
+		t.Error("Homebrew() without checksums succeeded, want an error")
+	}
+}
+
+func TestScoop(t *testing.T) {
+	m, _ := New("1.2.3", "", []byte(testChecksums))
+	data, err := m.Scoop()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest scoopManifest
+	if err := json.Unmarshal([]byte(data), &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Architecture) != 1 {
+		t.Fatalf("architectures = %v, want only 64bit", manifest.Architecture)
+	}
+	arch := manifest.Architecture["64bit"]
+	if !strings.HasSuffix(arch.URL, "/v1.2.3/coolifyme-windows-amd64.exe") || !strings.HasPrefix(arch.Hash, "5555") {
+		t.Errorf("64bit = %+v", arch)
+	}
+	if len(arch.Bin) != 1 || arch.Bin[0][1] != "coolifyme" {
+		t.Errorf("bin = %v", arch.Bin)
+	}
+}
+
+func TestInstallScript(t *testing.T) {
+	m, _ := New("1.2.3", "", []byte(testChecksums))
+	script, err := m.InstallScript()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"  Linux-x86_64) asset=coolifyme-linux-amd64 sum=3333",
+		"  Linux-armv7l) asset=coolifyme-linux-arm sum=4444",
+		"/releases/download/v1.2.3/$asset",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script does not contain %q:\n%s", want, script)
+		}
+	}
+	if strings.Contains(script, "Darwin") {
+		t.Errorf("script lists macOS without a checksum for its binary:\n%s", script)
+	}
+}
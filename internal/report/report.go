@@ -0,0 +1,192 @@
+// Package report writes machine-readable results of coolifyme checks for CI systems.
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Format represents a report file format
+type Format string
+
+const (
+	// FormatJUnit represents JUnit XML reports
+	FormatJUnit Format = "junit"
+	// FormatJSON represents JSON reports
+	FormatJSON Format = "json"
+)
+
+// Status represents the outcome of a single check
+type Status string
+
+const (
+	// StatusPassed marks a successful check
+	StatusPassed Status = "passed"
+	// StatusFailed marks a failed check
+	StatusFailed Status = "failed"
+)
+
+// Result is the outcome of a check against a single resource
+type Result struct {
+	Name     string        `json:"name"`
+	Resource string        `json:"resource,omitempty"`
+	Status   Status        `json:"status"`
+	Message  string        `json:"message,omitempty"`
+	Details  string        `json:"details,omitempty"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// Report collects check results
+type Report struct {
+	Name      string    `json:"name"`
+	Timestamp time.Time `json:"timestamp"`
+	Results   []Result  `json:"results"`
+}
+
+// New creates an empty report
+func New(name string) *Report {
+	return &Report{
+		Name:      name,
+		Timestamp: time.Now(),
+		Results:   []Result{},
+	}
+}
+
+// Add appends a result to the report
+func (r *Report) Add(result Result) {
+	r.Results = append(r.Results, result)
+}
+
+// Failures returns the number of failed results
+func (r *Report) Failures() int {
+	failures := 0
+	for _, result := range r.Results {
+		if result.Status == StatusFailed {
+			failures++
+		}
+	}
+	return failures
+}
+
+// ParseFormat parses a format name, inferring it from the file extension when empty
+func ParseFormat(format, path string) (Format, error) {
+	switch strings.ToLower(format) {
+	case "junit", "xml":
+		return FormatJUnit, nil
+	case "json":
+		return FormatJSON, nil
+	case "":
+		if strings.EqualFold(filepath.Ext(path), ".json") {
+			return FormatJSON, nil
+		}
+		return FormatJUnit, nil
+	default:
+		return "", fmt.Errorf("unsupported report format: %s (valid options: junit, json)", format)
+	}
+}
+
+// WriteFile writes the report to path in the given format
+func (r *Report) WriteFile(path string, format Format) error {
+	var (
+		data []byte
+		err  error
+	)
+
+	switch format {
+	case FormatJSON:
+		data, err = json.MarshalIndent(r, "", "  ")
+	case FormatJUnit:
+		data, err = r.junit()
+	default:
+		return fmt.Errorf("unsupported report format: %s", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+	return nil
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// junit encodes the report as JUnit XML
+func (r *Report) junit() ([]byte, error) {
+	var total time.Duration
+	suite := junitTestSuite{
+		Name:      r.Name,
+		Tests:     len(r.Results),
+		Failures:  r.Failures(),
+		Timestamp: r.Timestamp.UTC().Format(time.RFC3339),
+	}
+
+	for _, result := range r.Results {
+		total += result.Duration
+		testCase := junitTestCase{
+			Name:      result.Name,
+			ClassName: r.Name,
+			Time:      seconds(result.Duration),
+		}
+		if result.Resource != "" {
+			testCase.ClassName = r.Name + "." + result.Resource
+		}
+		if result.Status == StatusFailed {
+			testCase.Failure = &junitFailure{Message: result.Message, Type: string(StatusFailed), Body: result.Details}
+		} else if result.Details != "" {
+			testCase.SystemOut = result.Details
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	suite.Time = seconds(total)
+
+	data, err := xml.MarshalIndent(junitTestSuites{
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
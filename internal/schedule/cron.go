@@ -0,0 +1,146 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed five-field cron expression: minute, hour, day of month, month and day of week
+type Cron struct {
+	expr   string
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	anyDom bool
+	anyDow bool
+}
+
+// cronField describes the range of a cron field
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = [5]cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// ParseCron parses a cron expression with five fields. Each field is *, a number, a range (1-5) or
+// a list of those (1,3,5), optionally with a step (*/15, 0-30/10). Day of week 0 and 7 are Sunday.
+// When both day of month and day of week are restricted, either matching is enough, as in cron.
+func ParseCron(expr string) (*Cron, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: want 5 fields (minute hour day-of-month month day-of-week), got %d", expr, len(fields))
+	}
+
+	var sets [5]uint64
+	for i, field := range fields {
+		set, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		sets[i] = set
+	}
+	// Sunday is both 0 and 7
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+
+	return &Cron{
+		expr:   strings.Join(fields, " "),
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		anyDom: fields[2] == "*",
+		anyDow: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField parses one field of a cron expression into a bit set of the allowed values
+func parseCronField(field string, spec cronField) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepPart, spec.name)
+			}
+			step = n
+		}
+
+		low, high := spec.min, spec.max
+		if rangePart != "*" {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = strconv.Atoi(lowPart); err != nil {
+				return 0, fmt.Errorf("invalid value %q in %s field", lowPart, spec.name)
+			}
+			high = low
+			if isRange {
+				if high, err = strconv.Atoi(highPart); err != nil {
+					return 0, fmt.Errorf("invalid value %q in %s field", highPart, spec.name)
+				}
+			} else if hasStep {
+				high = spec.max
+			}
+		}
+		if low < spec.min || high > spec.max || low > high {
+			return 0, fmt.Errorf("%s field %q out of range %d-%d", spec.name, part, spec.min, spec.max)
+		}
+
+		for v := low; v <= high; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+// String returns the cron expression
+func (c *Cron) String() string {
+	return c.expr
+}
+
+// Next returns the first time after t matching the expression, in the location of t, or the zero
+// time when nothing matches within five years (such as February 30)
+func (c *Cron) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether the day of t matches the day of month and day of week fields
+func (c *Cron) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.anyDom || c.anyDow {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,137 @@
+// Package schedule keeps the actions queued for 'coolifyme scheduler run', such as restarts in a
+// maintenance window, with the cron expressions that repeat them.
+package schedule
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Action is a coolifyme command queued to run at a later time
+type Action struct {
+	ID int `json:"id"`
+	// Description is a short summary shown in listings, e.g. "restart application abc"
+	Description string `json:"description"`
+	// Args are the coolifyme arguments run, e.g. applications restart <uuid>
+	Args    []string `json:"args"`
+	Profile string   `json:"profile,omitempty"`
+	// Cron repeats the action; without it the action runs once and is removed
+	Cron      string     `json:"cron,omitempty"`
+	NextRun   time.Time  `json:"next_run"`
+	Created   time.Time  `json:"created"`
+	LastRun   *time.Time `json:"last_run,omitempty"`
+	LastError string     `json:"last_error,omitempty"`
+}
+
+// Due reports whether the action should run at now
+func (a Action) Due(now time.Time) bool {
+	return !a.NextRun.IsZero() && !a.NextRun.After(now)
+}
+
+// Load reads the queued actions, ordered by ID. A missing file is an empty queue.
+func Load(path string) ([]Action, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path is derived from the user's config directory
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []Action
+	if err := json.Unmarshal(data, &actions); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return actions, nil
+}
+
+// Save writes the queued actions
+func Save(path string, actions []Action) error {
+	if actions == nil {
+		actions = []Action{}
+	}
+	data, err := json.MarshalIndent(actions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// Add queues an action with the next ID and returns it as saved
+func Add(path string, action Action) (Action, error) {
+	actions, err := Load(path)
+	if err != nil {
+		return action, err
+	}
+	action.ID = 1
+	for _, a := range actions {
+		action.ID = max(action.ID, a.ID+1)
+	}
+	actions = append(actions, action)
+	return action, Save(path, actions)
+}
+
+// Remove deletes the action with the given ID, reporting whether it was queued
+func Remove(path string, id int) (bool, error) {
+	actions, err := Load(path)
+	if err != nil {
+		return false, err
+	}
+	i := slices.IndexFunc(actions, func(a Action) bool { return a.ID == id })
+	if i < 0 {
+		return false, nil
+	}
+	return true, Save(path, slices.Delete(actions, i, i+1))
+}
+
+// Update applies the result of running actions to the queue as it is on disk now, so actions
+// queued while they ran are kept. Actions missing from done were not run; actions in done whose
+// NextRun is zero are removed.
+func Update(path string, done []Action) error {
+	actions, err := Load(path)
+	if err != nil {
+		return err
+	}
+	updated := make([]Action, 0, len(actions))
+	for _, action := range actions {
+		if i := slices.IndexFunc(done, func(a Action) bool { return a.ID == action.ID }); i >= 0 {
+			action = done[i]
+			if action.NextRun.IsZero() {
+				continue
+			}
+		}
+		updated = append(updated, action)
+	}
+	return Save(path, updated)
+}
+
+// ParseAt parses the time of a one-off action: a time of day (03:00), run at its next occurrence
+// after now, a local date and time (2024-06-01 03:00) or an RFC 3339 timestamp
+func ParseAt(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.ParseInLocation("15:04", value, now.Location()); err == nil {
+		at := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		if !at.After(now) {
+			at = time.Date(now.Year(), now.Month(), now.Day()+1, t.Hour(), t.Minute(), 0, 0, now.Location())
+		}
+		return at, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: use HH:MM, 'YYYY-MM-DD HH:MM' or RFC 3339", value)
+}
@@ -0,0 +1,113 @@
+package schedule
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	// Wednesday
+	from := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 5, 1, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 5, 1, 10, 45, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2024, 5, 2, 3, 0, 0, 0, time.UTC)},
+		{"0 3 * * 0", time.Date(2024, 5, 5, 3, 0, 0, 0, time.UTC)},
+		{"0 3 * * 7", time.Date(2024, 5, 5, 3, 0, 0, 0, time.UTC)},
+		{"30 2 1,15 * *", time.Date(2024, 5, 15, 2, 30, 0, 0, time.UTC)},
+		{"0 0 1 1-3/2 *", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// Day of month or day of week when both are restricted
+		{"0 0 20 * 5", time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		cron, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) error: %v", tt.expr, err)
+		}
+		if got := cron.Next(from); !got.Equal(tt.want) {
+			t.Errorf("Next(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) accepted an invalid expression", expr)
+		}
+	}
+}
+
+func TestParseAt(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"11:00", time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC)},
+		{"03:00", time.Date(2024, 5, 2, 3, 0, 0, 0, time.UTC)},
+		{"10:30", time.Date(2024, 5, 2, 10, 30, 0, 0, time.UTC)},
+		{"2024-06-01 03:00", time.Date(2024, 6, 1, 3, 0, 0, 0, time.UTC)},
+		{"2024-06-01T03:00:00Z", time.Date(2024, 6, 1, 3, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseAt(tt.value, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseAt(%q) = %v, %v, want %v", tt.value, got, err, tt.want)
+		}
+	}
+
+	if _, err := ParseAt("tomorrow", now); err == nil {
+		t.Error("ParseAt() accepted an invalid time")
+	}
+}
+
+func TestQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule.json")
+	now := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+
+	once, err := Add(path, Action{Description: "once", NextRun: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	weekly, err := Add(path, Action{Description: "weekly", Cron: "0 3 * * 0", NextRun: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if once.ID != 1 || weekly.ID != 2 {
+		t.Fatalf("Add() IDs = %d, %d, want 1, 2", once.ID, weekly.ID)
+	}
+	if !once.Due(now) || weekly.Due(now) {
+		t.Errorf("Due() = %t, %t, want true, false", once.Due(now), weekly.Due(now))
+	}
+
+	// Running the one-off action removes it, an action queued meanwhile is kept
+	if _, err := Add(path, Action{Description: "later", NextRun: now.Add(2 * time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	once.NextRun = time.Time{}
+	if err := Update(path, []Action{once}); err != nil {
+		t.Fatal(err)
+	}
+
+	actions, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 2 || actions[0].ID != 2 || actions[1].ID != 3 {
+		t.Fatalf("Load() after Update() = %+v", actions)
+	}
+
+	if removed, err := Remove(path, 2); err != nil || !removed {
+		t.Errorf("Remove(2) = %t, %v", removed, err)
+	}
+	if removed, err := Remove(path, 2); err != nil || removed {
+		t.Errorf("Remove(2) again = %t, %v", removed, err)
+	}
+}
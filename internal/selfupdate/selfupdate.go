@@ -0,0 +1,360 @@
+// Package selfupdate finds, downloads and verifies coolifyme releases published on GitHub.
+package selfupdate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Channel selects which releases are considered for updates
+type Channel string
+
+const (
+	// Stable only considers full releases
+	Stable Channel = "stable"
+	// Beta also considers pre-releases such as v1.4.0-beta.1
+	Beta Channel = "beta"
+)
+
+// ChecksumsAsset is the release asset listing the SHA-256 checksums of the other assets
+const ChecksumsAsset = "checksums.txt"
+
+// DefaultRepository is the GitHub repository coolifyme is released from
+const DefaultRepository = "hongkongkiwi/coolifyme"
+
+// ParseChannel parses a channel name, defaulting to stable when empty
+func ParseChannel(name string) (Channel, error) {
+	switch Channel(strings.ToLower(strings.TrimSpace(name))) {
+	case "", Stable:
+		return Stable, nil
+	case Beta:
+		return Beta, nil
+	default:
+		return "", fmt.Errorf("invalid update channel '%s' (use stable or beta)", name)
+	}
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Release is a published coolifyme release
+type Release struct {
+	TagName    string  `json:"tag_name"`
+	Prerelease bool    `json:"prerelease"`
+	Draft      bool    `json:"draft"`
+	URL        string  `json:"html_url"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Asset returns the asset with the given name
+func (r *Release) Asset(name string) (*Asset, bool) {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i], true
+		}
+	}
+	return nil, false
+}
+
+// Updater talks to the GitHub releases API
+type Updater struct {
+	HTTPClient *http.Client
+	// APIURL is the GitHub API base URL, e.g. https://api.github.com
+	APIURL string
+	// Repository is the owner/repo path releases are read from
+	Repository string
+}
+
+// New returns an updater for the coolifyme releases on github.com
+func New(httpClient *http.Client) *Updater {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Updater{HTTPClient: httpClient, APIURL: "https://api.github.com", Repository: DefaultRepository}
+}
+
+// Latest returns the newest release of the channel. Stable ignores pre-releases, beta returns
+// whichever of the newest release and the newest pre-release has the higher version.
+func (u *Updater) Latest(ctx context.Context, channel Channel) (*Release, error) {
+	var releases []Release
+	url := fmt.Sprintf("%s/repos/%s/releases?per_page=30", strings.TrimRight(u.APIURL, "/"), u.Repository)
+	if err := u.getJSON(ctx, url, &releases); err != nil {
+		return nil, fmt.Errorf("failed to list releases: %w", err)
+	}
+
+	var latest *Release
+	for i := range releases {
+		release := &releases[i]
+		if release.Draft || (release.Prerelease && channel != Beta) {
+			continue
+		}
+		if _, err := ParseVersion(release.TagName); err != nil {
+			continue
+		}
+		if latest == nil || CompareVersions(release.TagName, latest.TagName) > 0 {
+			latest = release
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no %s release found", channel)
+	}
+	return latest, nil
+}
+
+// Download downloads the binary for the platform from a release and verifies it against the
+// release checksums. Releases without checksums are rejected unless verification is skipped.
+func (u *Updater) Download(ctx context.Context, release *Release, goos, goarch string, skipVerify bool) ([]byte, error) {
+	name := AssetName(goos, goarch)
+	asset, ok := release.Asset(name)
+	if !ok {
+		return nil, fmt.Errorf("release %s has no binary for %s/%s", release.TagName, goos, goarch)
+	}
+
+	binary, err := u.get(ctx, asset.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	if skipVerify {
+		return binary, nil
+	}
+
+	checksumsAsset, ok := release.Asset(ChecksumsAsset)
+	if !ok {
+		return nil, fmt.Errorf("release %s has no %s to verify the download against", release.TagName, ChecksumsAsset)
+	}
+	data, err := u.get(ctx, checksumsAsset.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", ChecksumsAsset, err)
+	}
+	if err := VerifyChecksum(binary, name, ParseChecksums(data)); err != nil {
+		return nil, err
+	}
+	return binary, nil
+}
+
+// AssetName returns the name of the release binary for a platform
+func AssetName(goos, goarch string) string {
+	name := fmt.Sprintf("coolifyme-%s-%s", goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// ParseChecksums parses a sha256sum listing ("<hex>  <file>" per line) into a map of file names
+// to checksums
+func ParseChecksums(data []byte) map[string]string {
+	checksums := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		// sha256sum marks binary mode with '*' and find prints paths as ./name
+		name := strings.TrimPrefix(strings.TrimPrefix(fields[1], "*"), "./")
+		checksums[name] = strings.ToLower(fields[0])
+	}
+	return checksums
+}
+
+// VerifyChecksum checks the SHA-256 checksum of a downloaded asset
+func VerifyChecksum(data []byte, name string, checksums map[string]string) error {
+	want, ok := checksums[name]
+	if !ok {
+		return fmt.Errorf("no checksum published for %s", name)
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, want, got)
+	}
+	return nil
+}
+
+// Replace atomically replaces the executable at path with a new binary. The new binary is
+// written next to the old one and renamed over it, so a failed update leaves the old one intact.
+func Replace(path string, binary []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat executable: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".coolifyme-update-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(binary); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()|0o111); err != nil {
+		return fmt.Errorf("failed to make new binary executable: %w", err)
+	}
+
+	// Windows cannot replace a running executable, but it can rename it out of the way
+	old := path + ".old"
+	_ = os.Remove(old)
+	if err := os.Rename(path, old); err != nil {
+		return fmt.Errorf("failed to move current binary aside: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Rename(old, path)
+		return fmt.Errorf("failed to install new binary: %w", err)
+	}
+	_ = os.Remove(old)
+	return nil
+}
+
+// gitDescribeSuffix matches the commit count, hash and dirty marker git describe appends to a tag
+var gitDescribeSuffix = regexp.MustCompile(`(-\d+-g[0-9a-f]+)?(-dirty)?$`)
+
+// Version is a parsed semantic version
+type Version struct {
+	Major, Minor, Patch int
+	// Pre is the pre-release part without the leading '-', e.g. "beta.1"
+	Pre string
+}
+
+// ParseVersion parses versions such as v1.2.3, 1.2.3-beta.1 or v1.2.3-4-gabcdef-dirty as printed
+// by git describe. Build metadata after '+' is ignored.
+func ParseVersion(s string) (Version, error) {
+	raw := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	raw, _, _ = strings.Cut(raw, "+")
+	// Builds from commits after a tag are treated as that tag's version
+	raw = gitDescribeSuffix.ReplaceAllString(raw, "")
+	core, pre, _ := strings.Cut(raw, "-")
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("invalid version '%s'", s)
+	}
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version '%s'", s)
+		}
+		numbers[i] = n
+	}
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2], Pre: pre}, nil
+}
+
+// CompareVersions compares two versions like semver, returning -1, 0 or 1. Invalid versions sort
+// before valid ones.
+func CompareVersions(a, b string) int {
+	va, errA := ParseVersion(a)
+	vb, errB := ParseVersion(b)
+	switch {
+	case errA != nil && errB != nil:
+		return 0
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	}
+
+	for _, pair := range [][2]int{{va.Major, vb.Major}, {va.Minor, vb.Minor}, {va.Patch, vb.Patch}} {
+		if pair[0] != pair[1] {
+			return compareInts(pair[0], pair[1])
+		}
+	}
+	return comparePrerelease(va.Pre, vb.Pre)
+}
+
+// comparePrerelease compares pre-release identifiers; a version without one is the higher
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	partsA, partsB := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		if partsA[i] == partsB[i] {
+			continue
+		}
+		numA, errA := strconv.Atoi(partsA[i])
+		numB, errB := strconv.Atoi(partsB[i])
+		switch {
+		case errA == nil && errB == nil:
+			return compareInts(numA, numB)
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			return strings.Compare(partsA[i], partsB[i])
+		}
+	}
+	return compareInts(len(partsA), len(partsB))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// getJSON fetches a URL and decodes the JSON response
+func (u *Updater) getJSON(ctx context.Context, url string, target any) error {
+	data, err := u.get(ctx, url)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// get fetches a URL
+func (u *Updater) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := u.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,156 @@
+package selfupdate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.2.3", "1.2.3", 0},
+		{"v1.10.0", "v1.9.9", 1},
+		{"v1.2.3", "v1.2.3-beta.1", 1},
+		{"v1.2.3-beta.2", "v1.2.3-beta.10", -1},
+		{"v1.2.3-alpha", "v1.2.3-beta", -1},
+		{"v1.2.3-beta", "v1.2.3-beta.1", -1},
+		{"v1.2.3-4-gabc1234-dirty", "v1.2.3", 0},
+		{"dev", "v0.0.1", -1},
+	}
+
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestParseChannel(t *testing.T) {
+	if channel, err := ParseChannel(""); err != nil || channel != Stable {
+		t.Errorf("ParseChannel(\"\") = %q, %v", channel, err)
+	}
+	if channel, err := ParseChannel("Beta"); err != nil || channel != Beta {
+		t.Errorf("ParseChannel(\"Beta\") = %q, %v", channel, err)
+	}
+	if _, err := ParseChannel("nightly"); err == nil {
+		t.Error("ParseChannel(\"nightly\") returned no error")
+	}
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func newReleaseServer(t *testing.T, binary []byte, checksums string) *Updater {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/cli/releases":
+			_, _ = fmt.Fprintf(w, `[
+				{"tag_name":"v1.3.0-beta.1","prerelease":true,"assets":[]},
+				{"tag_name":"v1.4.0","draft":true,"assets":[]},
+				{"tag_name":"v1.2.0","assets":[
+					{"name":"coolifyme-linux-amd64","browser_download_url":"%[1]s/download/coolifyme-linux-amd64"},
+					{"name":"checksums.txt","browser_download_url":"%[1]s/download/checksums.txt"}]},
+				{"tag_name":"v1.1.0","assets":[]}
+			]`, server.URL)
+		case "/download/coolifyme-linux-amd64":
+			_, _ = w.Write(binary)
+		case "/download/checksums.txt":
+			_, _ = w.Write([]byte(checksums))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return &Updater{HTTPClient: server.Client(), APIURL: server.URL, Repository: "acme/cli"}
+}
+
+func TestLatestAndDownload(t *testing.T) {
+	binary := []byte("new binary")
+	updater := newReleaseServer(t, binary, sha256Hex(binary)+"  ./coolifyme-linux-amd64\n")
+	ctx := context.Background()
+
+	release, err := updater.Latest(ctx, Stable)
+	if err != nil || release.TagName != "v1.2.0" {
+		t.Fatalf("Latest(stable) = %+v, %v", release, err)
+	}
+	if beta, err := updater.Latest(ctx, Beta); err != nil || beta.TagName != "v1.3.0-beta.1" {
+		t.Errorf("Latest(beta) = %+v, %v", beta, err)
+	}
+
+	data, err := updater.Download(ctx, release, "linux", "amd64", false)
+	if err != nil || string(data) != string(binary) {
+		t.Errorf("Download() = %q, %v", data, err)
+	}
+	if _, err := updater.Download(ctx, release, "darwin", "arm64", false); err == nil {
+		t.Error("Download() for a missing platform returned no error")
+	}
+}
+
+func TestDownloadChecksumMismatch(t *testing.T) {
+	updater := newReleaseServer(t, []byte("tampered"), sha256Hex([]byte("original"))+"  coolifyme-linux-amd64\n")
+	ctx := context.Background()
+
+	release, err := updater.Latest(ctx, Stable)
+	if err != nil {
+		t.Fatalf("Latest() error = %v", err)
+	}
+	if _, err := updater.Download(ctx, release, "linux", "amd64", false); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Download() error = %v, want checksum mismatch", err)
+	}
+	if _, err := updater.Download(ctx, release, "linux", "amd64", true); err != nil {
+		t.Errorf("Download() with skipVerify error = %v", err)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coolifyme")
+	if err := os.WriteFile(path, []byte("old"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Replace(path, []byte("new")); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	info, _ := os.Stat(path)
+	if string(data) != "new" || info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("Replace() left %q with mode %v", data, info.Mode())
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("Replace() left %d files, want 1", len(entries))
+	}
+}
+
+func TestState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "update-check.json")
+	now := time.Now()
+
+	if state := LoadState(path); !state.Due(now) {
+		t.Error("missing state is not due")
+	}
+	if err := SaveState(path, &State{CheckedAt: now.Add(-time.Hour), Latest: "v1.2.0"}); err != nil {
+		t.Fatalf("SaveState() error = %v", err)
+	}
+	state := LoadState(path)
+	if state.Latest != "v1.2.0" || state.Due(now) {
+		t.Errorf("LoadState() = %+v, due %t", state, state.Due(now))
+	}
+	if !state.Due(now.Add(CheckInterval)) {
+		t.Error("state is not due a day later")
+	}
+}
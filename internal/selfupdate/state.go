@@ -0,0 +1,52 @@
+package selfupdate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CheckInterval is how often the background version check runs and prints its hint
+const CheckInterval = 24 * time.Hour
+
+// State records the outcome of the last background version check
+type State struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Latest    string    `json:"latest,omitempty"`
+	Channel   Channel   `json:"channel,omitempty"`
+}
+
+// Due reports whether the next background check should run
+func (s *State) Due(now time.Time) bool {
+	return now.Sub(s.CheckedAt) >= CheckInterval
+}
+
+// LoadState reads the state file, returning an empty state when it does not exist or is invalid
+func LoadState(path string) *State {
+	state := &State{}
+	data, err := os.ReadFile(path) // #nosec G304 - path is derived from the user's config directory
+	if err != nil {
+		return state
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return &State{}
+	}
+	return state
+}
+
+// SaveState writes the state file
+func SaveState(path string, state *State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal update check state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write update check state: %w", err)
+	}
+	return nil
+}
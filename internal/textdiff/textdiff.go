@@ -0,0 +1,140 @@
+// Package textdiff renders line-based unified diffs, used to preview changes to files stored in
+// Coolify such as compose files before they are uploaded.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Context is the number of unchanged lines shown around each change
+const Context = 3
+
+// op is one line of an edit script: ' ' kept, '-' removed or '+' added
+type op struct {
+	kind byte
+	text string
+}
+
+// Unified returns the unified diff turning a into b, labelled with the given names, or an empty
+// string when they have the same lines
+func Unified(fromName, toName, a, b string) string {
+	ops := diffLines(splitLines(a), splitLines(b))
+
+	// aLine and bLine hold the line numbers of a and b before each op
+	aLine := make([]int, len(ops)+1)
+	bLine := make([]int, len(ops)+1)
+	for i, o := range ops {
+		aLine[i+1], bLine[i+1] = aLine[i], bLine[i]
+		if o.kind != '+' {
+			aLine[i+1]++
+		}
+		if o.kind != '-' {
+			bLine[i+1]++
+		}
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(ops); {
+		for i < len(ops) && ops[i].kind == ' ' {
+			i++
+		}
+		if i == len(ops) {
+			break
+		}
+
+		// Extend the hunk over changes separated by at most twice the context
+		end := i
+		for {
+			for end < len(ops) && ops[end].kind != ' ' {
+				end++
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' && next-end < 2*Context {
+				next++
+			}
+			if next < len(ops) && ops[next].kind != ' ' {
+				end = next
+				continue
+			}
+			break
+		}
+
+		start, stop := max(0, i-Context), min(len(ops), end+Context)
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n",
+			hunkRange(aLine[start], aLine[stop]-aLine[start]),
+			hunkRange(bLine[start], bLine[stop]-bLine[start]))
+		for _, o := range ops[start:stop] {
+			out.WriteByte(o.kind)
+			out.WriteString(o.text)
+			out.WriteByte('\n')
+		}
+		i = stop
+	}
+	return out.String()
+}
+
+// hunkRange formats the range of a hunk header from the line before the hunk and its length
+func hunkRange(before, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", before+1)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
+
+// splitLines splits text into lines, ignoring a final newline
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines returns the shortest edit script turning a into b, from their longest common
+// subsequence
+func diffLines(a, b []string) []op {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]op, 0, max(n, m))
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, op{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, op{'-', a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, op{'+', b[j]})
+	}
+	return ops
+}
@@ -0,0 +1,41 @@
+package textdiff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	if diff := Unified("a", "b", "x\ny\n", "x\ny"); diff != "" {
+		t.Errorf("Unified() of equal lines = %q, want empty", diff)
+	}
+
+	before := strings.Join([]string{"services:", "  web:", "    image: nginx:1.25", "    ports:", "      - 80:80",
+		"  cache:", "    image: redis", "volumes:", "  data:", "  logs:", "  tmp:", "  other:"}, "\n") + "\n"
+	after := strings.Replace(before, "nginx:1.25", "nginx:1.27", 1) + "networks:\n  default:\n"
+
+	want := `--- current
++++ compose.yml
+@@ -1,6 +1,6 @@
+ services:
+   web:
+-    image: nginx:1.25
++    image: nginx:1.27
+     ports:
+       - 80:80
+   cache:
+@@ -10,3 +10,5 @@
+   logs:
+   tmp:
+   other:
++networks:
++  default:
+`
+	if diff := Unified("current", "compose.yml", before, after); diff != want {
+		t.Errorf("Unified() =\n%s\nwant\n%s", diff, want)
+	}
+
+	if diff := Unified("a", "b", "", "one\n"); diff != "--- a\n+++ b\n@@ -0,0 +1 @@\n+one\n" {
+		t.Errorf("Unified() from empty = %q", diff)
+	}
+}
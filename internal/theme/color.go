@@ -0,0 +1,73 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+)
+
+// ColorMode is a --color setting
+type ColorMode string
+
+const (
+	// ColorAuto colors output written to a terminal
+	ColorAuto ColorMode = "auto"
+	// ColorAlways colors output even when it is redirected
+	ColorAlways ColorMode = "always"
+	// ColorNever disables colors
+	ColorNever ColorMode = "never"
+)
+
+// ParseColorMode parses a --color value
+func ParseColorMode(value string) (ColorMode, error) {
+	switch mode := ColorMode(value); mode {
+	case ColorAuto, ColorAlways, ColorNever:
+		return mode, nil
+	case "":
+		return ColorAuto, nil
+	}
+	return "", fmt.Errorf("invalid color setting: %s (use auto, always or never)", value)
+}
+
+// ResolveColorMode picks the color mode from, in order of precedence: the --color flag when it
+// was given, the NO_COLOR and CLICOLOR_FORCE environment variables (see no-color.org), the
+// color_output setting of the config file, and auto. color_output: true only colors output
+// written to a terminal, so piping into jq or grep stays plain; only --color=always and
+// CLICOLOR_FORCE color redirected output. getenv is usually os.Getenv.
+func ResolveColorMode(flag string, flagSet bool, configured *bool, getenv func(string) string) (ColorMode, error) {
+	if flagSet {
+		return ParseColorMode(flag)
+	}
+	if getenv("NO_COLOR") != "" {
+		return ColorNever, nil
+	}
+	if force := getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return ColorAlways, nil
+	}
+	if configured != nil {
+		if *configured {
+			return ColorAuto, nil
+		}
+		return ColorNever, nil
+	}
+	return ColorAuto, nil
+}
+
+// Enabled reports whether output written to a terminal, or elsewhere, is colored in this mode
+func (m ColorMode) Enabled(terminal bool) bool {
+	switch m {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	return terminal
+}
+
+// IsTerminal reports whether a file is a terminal
+func IsTerminal(file *os.File) bool {
+	stat, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return (stat.Mode() & os.ModeCharDevice) != 0
+}
@@ -0,0 +1,46 @@
+package theme
+
+import "testing"
+
+func TestResolveColorMode(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name       string
+		flag       string
+		flagSet    bool
+		env        map[string]string
+		configured *bool
+		want       ColorMode
+	}{
+		{"default", "auto", false, nil, nil, ColorAuto},
+		{"flag wins over env", "always", true, map[string]string{"NO_COLOR": "1"}, nil, ColorAlways},
+		{"flag default is ignored", "auto", false, nil, &no, ColorNever},
+		{"NO_COLOR", "auto", false, map[string]string{"NO_COLOR": "1"}, &yes, ColorNever},
+		{"NO_COLOR wins over CLICOLOR_FORCE", "auto", false, map[string]string{"NO_COLOR": "1", "CLICOLOR_FORCE": "1"}, nil, ColorNever},
+		{"CLICOLOR_FORCE", "auto", false, map[string]string{"CLICOLOR_FORCE": "1"}, &no, ColorAlways},
+		{"CLICOLOR_FORCE=0", "auto", false, map[string]string{"CLICOLOR_FORCE": "0"}, nil, ColorAuto},
+		{"config true only colors a terminal", "auto", false, nil, &yes, ColorAuto},
+	}
+
+	for _, tt := range tests {
+		getenv := func(key string) string { return tt.env[key] }
+		got, err := ResolveColorMode(tt.flag, tt.flagSet, tt.configured, getenv)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: ResolveColorMode() = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+
+	if _, err := ResolveColorMode("sometimes", true, nil, func(string) string { return "" }); err == nil {
+		t.Error("ResolveColorMode() with an invalid flag should fail")
+	}
+}
+
+func TestColorModeEnabled(t *testing.T) {
+	if !ColorAuto.Enabled(true) || ColorAuto.Enabled(false) {
+		t.Error("auto should follow the terminal")
+	}
+	if !ColorAlways.Enabled(false) || ColorNever.Enabled(true) {
+		t.Error("always and never should ignore the terminal")
+	}
+}
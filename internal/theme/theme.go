@@ -0,0 +1,305 @@
+// Package theme provides centralized emoji and color handling for human-facing output of the coolifyme CLI tool.
+package theme
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Name represents a color theme name
+type Name string
+
+const (
+	// Dark is the default theme for terminals with a dark background
+	Dark Name = "dark"
+	// Light is a theme for terminals with a light background
+	Light Name = "light"
+	// None disables all colors
+	None Name = "none"
+)
+
+// Palette holds the ANSI escape sequences used by a theme
+type Palette struct {
+	Success string
+	Error   string
+	Warning string
+	Info    string
+}
+
+const ansiReset = "\033[0m"
+
+var palettes = map[Name]Palette{
+	Dark: {
+		Success: "\033[92m",
+		Error:   "\033[91m",
+		Warning: "\033[93m",
+		Info:    "\033[96m",
+	},
+	Light: {
+		Success: "\033[32m",
+		Error:   "\033[31m",
+		Warning: "\033[33m",
+		Info:    "\033[34m",
+	},
+	None: {},
+}
+
+// glyphs maps every emoji used in CLI output to a plain ASCII fallback.
+// Variants with a trailing variation selector must come before their bare form.
+var glyphs = []string{
+	"⚠️", "[WARN]",
+	"⚠", "[WARN]",
+	"✅", "[OK]",
+	"❌", "[FAIL]",
+	"❓", "[?]",
+	"🖥️", "*",
+	"🖥", "*",
+	"🏗️", "*",
+	"🏗", "*",
+	"⏱️", "*",
+	"⏱", "*",
+	"⏹️", "*",
+	"⏹", "*",
+	"🗑️", "*",
+	"🗑", "*",
+	"⚙️", "*",
+	"⚙", "*",
+	"❤️", "*",
+	"❤", "*",
+	"🛡️", "*",
+	"🛡", "*",
+	"📦", "*",
+	"📝", "*",
+	"🔄", "*",
+	"🌐", "*",
+	"🚀", "*",
+	"🔧", "*",
+	"📊", "*",
+	"🔑", "*",
+	"📛", "*",
+	"📋", "*",
+	"📄", "*",
+	"📁", "*",
+	"💬", "*",
+	"📭", "*",
+	"👤", "*",
+	"🔍", "*",
+	"🧪", "*",
+	"🎨", "*",
+	"📈", "*",
+	"⚡", "*",
+	"📡", "*",
+	"📱", "*",
+	"💡", "*",
+	"⏳", "*",
+	"🎯", "*",
+	"🔌", "*",
+	"📤", "*",
+	"📥", "*",
+	"🎉", "*",
+	"🌿", "*",
+	"🌍", "*",
+	"🕐", "*",
+	"🕒", "*",
+	"📜", "*",
+	"🩺", "*",
+	"🏥", "*",
+	"🍺", "*",
+	"🚨", "[ALERT]",
+	"🟢", "[UP]",
+	"🔴", "[DOWN]",
+	"🔔", "*",
+	"🔗", "*",
+	"🔒", "*",
+	"→", "->",
+}
+
+var (
+	mu           sync.RWMutex
+	current      = Dark
+	colorEnabled bool
+	emojiEnabled           = true
+	writer       io.Writer = os.Stdout
+	statusWriter io.Writer = os.Stderr
+	asciiGlyphs            = strings.NewReplacer(glyphs...)
+)
+
+// Set activates a theme by name
+func Set(name string) error {
+	n := Name(strings.ToLower(strings.TrimSpace(name)))
+	if n == "" {
+		n = Dark
+	}
+	if _, ok := palettes[n]; !ok {
+		return fmt.Errorf("unknown theme: %s (available: dark, light, none)", name)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	current = n
+	return nil
+}
+
+// Current returns the active theme name
+func Current() Name {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// SetColor enables or disables colored output
+func SetColor(enabled bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	colorEnabled = enabled
+}
+
+// ColorEnabled returns whether output is colored, which is never the case with the none theme
+func ColorEnabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return colorEnabled && current != None
+}
+
+// SetEmoji enables or disables emoji glyphs; when disabled glyphs are replaced with ASCII
+func SetEmoji(enabled bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	emojiEnabled = enabled
+}
+
+// EmojiEnabled returns whether emoji glyphs are rendered
+func EmojiEnabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return emojiEnabled
+}
+
+// SetWriter sets the writer used by the print helpers
+func SetWriter(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	writer = w
+}
+
+// SetStatusWriter sets the writer used by the status helpers
+func SetStatusWriter(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	statusWriter = w
+}
+
+// TerminalSupportsUTF8 reports whether the locale environment advertises UTF-8 support.
+// An unset locale is treated as UTF-8 capable.
+func TerminalSupportsUTF8() bool {
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := os.Getenv(key); value != "" {
+			value = strings.ToUpper(value)
+			return strings.Contains(value, "UTF-8") || strings.Contains(value, "UTF8")
+		}
+	}
+	return true
+}
+
+// Render applies the active emoji and color settings to a piece of human-facing text.
+// Lines are colored according to the status glyph they start with.
+func Render(s string) string {
+	mu.RLock()
+	palette := palettes[current]
+	useColor := colorEnabled && current != None
+	useEmoji := emojiEnabled
+	mu.RUnlock()
+
+	if useColor {
+		lines := strings.SplitAfter(s, "\n")
+		for i, line := range lines {
+			if color := lineColor(line, palette); color != "" {
+				body := strings.TrimRight(line, "\n")
+				lines[i] = color + body + ansiReset + line[len(body):]
+			}
+		}
+		s = strings.Join(lines, "")
+	}
+
+	if !useEmoji {
+		s = asciiGlyphs.Replace(s)
+	}
+
+	return s
+}
+
+// Glyphs applies only the emoji setting to a string, leaving colors untouched.
+// Use it for values that end up inside aligned tables.
+func Glyphs(s string) string {
+	if EmojiEnabled() {
+		return s
+	}
+	return asciiGlyphs.Replace(s)
+}
+
+// lineColor picks the palette color for a line based on its leading status glyph
+func lineColor(line string, palette Palette) string {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case trimmed == "":
+		return ""
+	case strings.HasPrefix(trimmed, "✅"), strings.HasPrefix(trimmed, "🎉"):
+		return palette.Success
+	case strings.HasPrefix(trimmed, "❌"):
+		return palette.Error
+	case strings.HasPrefix(trimmed, "⚠"):
+		return palette.Warning
+	case strings.HasPrefix(trimmed, "💡"), strings.HasPrefix(trimmed, "🔄"), strings.HasPrefix(trimmed, "🚀"):
+		return palette.Info
+	}
+	return ""
+}
+
+// Printf formats according to a format specifier and writes the themed result to standard output
+func Printf(format string, a ...any) {
+	mu.RLock()
+	w := writer
+	mu.RUnlock()
+	_, _ = fmt.Fprint(w, Render(fmt.Sprintf(format, a...)))
+}
+
+// Println writes its operands followed by a newline, themed, to standard output
+func Println(a ...any) {
+	mu.RLock()
+	w := writer
+	mu.RUnlock()
+	_, _ = fmt.Fprint(w, Render(fmt.Sprintln(a...)))
+}
+
+// Print writes its operands, themed, to standard output
+func Print(a ...any) {
+	mu.RLock()
+	w := writer
+	mu.RUnlock()
+	_, _ = fmt.Fprint(w, Render(fmt.Sprint(a...)))
+}
+
+// Statusf formats according to a format specifier and writes the themed result to standard
+// error. Decorative status lines use it, so standard output carries only the command's result.
+func Statusf(format string, a ...any) {
+	mu.RLock()
+	w := statusWriter
+	mu.RUnlock()
+	_, _ = fmt.Fprint(w, Render(fmt.Sprintf(format, a...)))
+}
+
+// Statusln writes its operands followed by a newline, themed, to standard error
+func Statusln(a ...any) {
+	mu.RLock()
+	w := statusWriter
+	mu.RUnlock()
+	_, _ = fmt.Fprint(w, Render(fmt.Sprintln(a...)))
+}
+
+// Sprintf formats according to a format specifier and returns the themed string
+func Sprintf(format string, a ...any) string {
+	return Render(fmt.Sprintf(format, a...))
+}
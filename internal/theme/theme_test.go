@@ -0,0 +1,26 @@
+package theme
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestStatusWriter(t *testing.T) {
+	originalWriter, originalStatusWriter := writer, statusWriter
+	defer SetWriter(originalWriter)
+	defer SetStatusWriter(originalStatusWriter)
+
+	var out, status bytes.Buffer
+	SetWriter(&out)
+	SetStatusWriter(&status)
+
+	Statusf("✅ Created %s\n", "project")
+	Printf("   UUID: %s\n", "abc")
+
+	if got := status.String(); got != "✅ Created project\n" {
+		t.Errorf("status output = %q", got)
+	}
+	if got := out.String(); got != "   UUID: abc\n" {
+		t.Errorf("standard output = %q", got)
+	}
+}
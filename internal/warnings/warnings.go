@@ -0,0 +1,62 @@
+// Package warnings collects the non-fatal problems of a command, e.g. one resource type of a
+// search that could not be listed, so they can be reported after the primary output instead of
+// in the middle of it.
+package warnings
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Warning is a non-fatal problem of a command
+type Warning struct {
+	// Source is what the problem happened in, e.g. "search applications"
+	Source  string `json:"source,omitempty"`
+	Message string `json:"message"`
+}
+
+// String formats the warning for terminal output
+func (w Warning) String() string {
+	if w.Source == "" {
+		return w.Message
+	}
+	return w.Source + ": " + w.Message
+}
+
+// Collector gathers warnings. It is safe for concurrent use; the zero value is ready to use.
+type Collector struct {
+	mu       sync.Mutex
+	warnings []Warning
+	// taken is the number of warnings already returned by Take
+	taken int
+}
+
+// Add records a warning
+func (c *Collector) Add(source string, err error) {
+	c.Addf(source, "%v", err)
+}
+
+// Addf records a warning with a formatted message
+func (c *Collector) Addf(source, format string, args ...any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.warnings = append(c.warnings, Warning{Source: source, Message: fmt.Sprintf(format, args...)})
+}
+
+// Len returns the number of warnings recorded, including the ones already taken
+func (c *Collector) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.warnings)
+}
+
+// Take returns the warnings not taken yet, so a command that includes them in its JSON output
+// keeps them from being printed again at the end. It never returns nil, so the warnings of JSON
+// output are an empty array rather than null.
+func (c *Collector) Take() []Warning {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	taken := append([]Warning{}, c.warnings[c.taken:]...)
+	c.taken = len(c.warnings)
+	return taken
+}
@@ -0,0 +1,48 @@
+package warnings
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestCollector(t *testing.T) {
+	var c Collector
+	if taken := c.Take(); taken == nil || len(taken) != 0 {
+		t.Errorf("Take() on an empty collector = %#v, want an empty slice", taken)
+	}
+
+	c.Add("search applications", errors.New("connection refused"))
+	c.Addf("", "%d endpoints missing", 2)
+	taken := c.Take()
+	if len(taken) != 2 || taken[0].String() != "search applications: connection refused" || taken[1].String() != "2 endpoints missing" {
+		t.Errorf("Take() = %v", taken)
+	}
+	if taken := c.Take(); len(taken) != 0 {
+		t.Errorf("second Take() = %v, want the warnings to be taken once", taken)
+	}
+
+	c.Addf("rollback", "no history")
+	if taken := c.Take(); len(taken) != 1 || taken[0].Source != "rollback" {
+		t.Errorf("Take() after Addf = %v", taken)
+	}
+	if c.Len() != 3 {
+		t.Errorf("Len() = %d, want taken warnings to be counted", c.Len())
+	}
+}
+
+func TestCollectorConcurrent(t *testing.T) {
+	var c Collector
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Addf("worker", "failed")
+		}()
+	}
+	wg.Wait()
+	if c.Len() != 50 {
+		t.Errorf("Len() = %d, want 50", c.Len())
+	}
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+)
+
+// DefaultMaxResponseSize is the largest response body accepted unless WithMaxResponseSize
+// changes it. It is far above the size of listings of instances with thousands of resources.
+const DefaultMaxResponseSize int64 = 256 << 20
+
+// maxLoggedBody is the number of response body bytes logged at debug level
+const maxLoggedBody = 10000
+
+// ErrResponseTooLarge is returned when a response body exceeds the maximum response size
+var ErrResponseTooLarge = errors.New("response body exceeds the maximum response size")
+
+// responseTooLarge returns the error for a response to req that exceeded limit bytes
+func responseTooLarge(req *http.Request, limit int64) error {
+	return fmt.Errorf("%w of %d bytes: %s %s", ErrResponseTooLarge, limit, req.Method, req.URL.Path)
+}
+
+// limitedBody fails reads once more than limit bytes of a response body have been read
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+	limit     int64
+	req       *http.Request
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// A body of exactly the limit is fine, one more byte is not
+		var probe [1]byte
+		n, err := b.body.Read(probe[:])
+		if n > 0 {
+			return 0, responseTooLarge(b.req, b.limit)
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.body.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
+
+// prefixedBody streams a response body whose first bytes were already read for logging
+type prefixedBody struct {
+	io.Reader
+	body io.ReadCloser
+}
+
+func (b *prefixedBody) Close() error {
+	return b.body.Close()
+}
+
+// streamList decodes the JSON array of a listing response one element at a time with
+// json.Decoder tokens, so listings of instances with thousands of resources are never held in
+// memory as raw body and decoded items at once, as the generated *WithResponse methods do. The
+// elements are checked for unknown fields like checkDecode does for whole responses.
+func streamList[T any](c *Client, resp *http.Response) ([]T, error) {
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, apiError(resp, body)
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	token, err := dec.Token()
+	if err == io.EOF {
+		return nil, fmt.Errorf("empty response body")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("failed to decode response: expected a JSON array, got %v", token)
+	}
+
+	check := c.strictDecode || (resp.Request != nil && debugEnabled(resp.Request.Context(), c.logger))
+	unknown := make(map[string]bool)
+	items := []T{}
+	for dec.More() {
+		var item T
+		if !check {
+			if err := dec.Decode(&item); err != nil {
+				return nil, fmt.Errorf("failed to decode response: %w", err)
+			}
+			items = append(items, item)
+			continue
+		}
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		if fields, err := UnknownFields(raw, &item); err == nil {
+			for _, field := range fields {
+				unknown["[]."+field] = true
+			}
+		}
+		items = append(items, item)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(unknown) == 0 {
+		return items, nil
+	}
+	fields := make([]string, 0, len(unknown))
+	for field := range unknown {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	if err := c.reportUnknownFields(resp, fields); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaxResponseSize(t *testing.T) {
+	items := `["` + strings.Repeat("x", 20000) + `"]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chunked" {
+			// Flushing before writing the body forces chunked encoding without a Content-Length
+			w.(http.Flusher).Flush()
+		}
+		_, _ = w.Write([]byte(items))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		path    string
+		limit   int64
+		tooLong bool
+	}{
+		{"content length over the limit", "/sized", 1024, true},
+		{"chunked body over the limit", "/chunked", 1024, true},
+		{"body of exactly the limit", "/chunked", int64(len(items)), false},
+		{"no limit", "/chunked", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := New(nil, WithBaseURL(server.URL), WithToken("token"), WithMaxResponseSize(tt.limit))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			var out []string
+			err = c.doRequest(context.Background(), http.MethodGet, tt.path, nil, &out)
+			if tt.tooLong {
+				if !errors.Is(err, ErrResponseTooLarge) {
+					t.Errorf("doRequest() error = %v, want ErrResponseTooLarge", err)
+				}
+				return
+			}
+			if err != nil || len(out) != 1 || len(out[0]) != 20000 {
+				t.Errorf("doRequest() = %d items, %v", len(out), err)
+			}
+		})
+	}
+}
+
+func TestDebugLoggingKeepsBody(t *testing.T) {
+	items := `["` + strings.Repeat("x", 3*maxLoggedBody) + `"]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(items))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	debugLogger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c, err := New(nil, WithBaseURL(server.URL), WithToken("token"), WithLogger(debugLogger))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	resp, err := c.httpClient.Get(server.URL + "/items")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(resp.Body)
+
+	if string(body) != items {
+		t.Errorf("body has %d bytes after logging, want %d", len(body), len(items))
+	}
+	if !strings.Contains(logs.String(), "(truncated)") {
+		t.Error("large response body was not logged truncated")
+	}
+}
+
+func TestStreamList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/applications":
+			_, _ = w.Write([]byte(`[{"uuid": "a", "name": "api"}, {"uuid": "b", "name": "web", "new_field": 1}]`))
+		case "/servers":
+			_, _ = w.Write([]byte(`{"message": "not a list"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(nil, WithBaseURL(server.URL), WithToken("token"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	apps, err := c.Applications().List(context.Background())
+	if err != nil || len(apps) != 2 || *apps[1].Name != "web" {
+		t.Fatalf("List() = %v, %v", apps, err)
+	}
+	if _, err := c.Servers().List(context.Background()); err == nil {
+		t.Error("List() of an object succeeded, want an error")
+	}
+	if _, err := c.Services().List(context.Background()); StatusCode(err) != http.StatusNotFound {
+		t.Errorf("List() error = %v, want a 404", err)
+	}
+
+	c.strictDecode = true
+	var unknownErr *UnknownFieldsError
+	if _, err := c.Applications().List(context.Background()); !errors.As(err, &unknownErr) || unknownErr.Fields[0] != "[].new_field" {
+		t.Errorf("List() with strict decoding error = %v, want UnknownFieldsError for [].new_field", err)
+	}
+}
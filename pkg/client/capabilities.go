@@ -0,0 +1,286 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Capability is an API feature that older Coolify releases do not provide
+type Capability string
+
+const (
+	// CapabilityApplicationDeployments lists the deployments of a single application
+	CapabilityApplicationDeployments Capability = "application-deployments"
+	// CapabilityDeploymentCancel cancels queued or running deployments
+	CapabilityDeploymentCancel Capability = "deployment-cancel"
+)
+
+// capabilityInfo describes a capability and what the client does on servers without it
+type capabilityInfo struct {
+	Description string
+	// Fallback describes the behavior on servers without the capability; empty when the feature
+	// fails with an *UnsupportedError
+	Fallback string
+}
+
+// capabilities lists every capability. Coolify does not document in which release its endpoints
+// appeared, so support is learnt from the server instead of derived from its version: the
+// endpoint is called, and a 404 or 405 for a resource that exists records the capability as
+// missing for the rest of the client's life. Add an entry here before calling an endpoint that
+// older servers lack.
+var capabilities = map[Capability]capabilityInfo{
+	CapabilityApplicationDeployments: {"Listing deployments of an application", "only running deployments are listed"},
+	CapabilityDeploymentCancel:       {"Cancelling deployments", ""},
+}
+
+// MinTestedVersion and MaxTestedVersion are the oldest and newest Coolify releases this client is
+// tested against. Older servers lack endpoints the client relies on; newer ones may have changed
+// them. Raise MaxTestedVersion after testing against a new release.
+const (
+	MinTestedVersion = "4.0.0-beta.380"
+	MaxTestedVersion = "4.0.0-beta.420"
+)
+
+// VersionRangeError is returned by CheckTestedVersion for servers outside the tested range
+type VersionRangeError struct {
+	ServerVersion string
+	// TooOld is set when the server is older than MinTestedVersion, unset when it is newer than
+	// MaxTestedVersion
+	TooOld bool
+}
+
+// Error implements the error interface
+func (e *VersionRangeError) Error() string {
+	if e.TooOld {
+		return fmt.Sprintf("Coolify %s is older than the oldest supported release %s", e.ServerVersion, MinTestedVersion)
+	}
+	return fmt.Sprintf("Coolify %s is newer than the newest tested release %s", e.ServerVersion, MaxTestedVersion)
+}
+
+// CheckTestedVersion returns a *VersionRangeError when a server version is outside the tested
+// range. Versions that cannot be parsed, e.g. development builds, are assumed to be in range.
+func CheckTestedVersion(serverVersion string) error {
+	v := parseCoolifyVersion(serverVersion)
+	switch {
+	case v == nil:
+		return nil
+	case v.compare(parseCoolifyVersion(MinTestedVersion)) < 0:
+		return &VersionRangeError{ServerVersion: serverVersion, TooOld: true}
+	case v.compare(parseCoolifyVersion(MaxTestedVersion)) > 0:
+		return &VersionRangeError{ServerVersion: serverVersion}
+	}
+	return nil
+}
+
+// UnsupportedError is returned when the server does not provide a capability
+type UnsupportedError struct {
+	Capability    Capability
+	Description   string
+	ServerVersion string
+}
+
+// Error implements the error interface
+func (e *UnsupportedError) Error() string {
+	if e.ServerVersion == "" {
+		return fmt.Sprintf("%s is not supported by this server", e.Description)
+	}
+	return fmt.Sprintf("%s is not supported by this server (Coolify %s)", e.Description, e.ServerVersion)
+}
+
+// UnsupportedEndpointError is returned when a server does not provide an endpoint outside the
+// Coolify API specification that a feature relies on
+type UnsupportedEndpointError struct {
+	Feature string
+	Method  string
+	Path    string
+}
+
+// Error implements the error interface
+func (e *UnsupportedEndpointError) Error() string {
+	return fmt.Sprintf("%s is unsupported by this server (%s %s is not part of its API)", e.Feature, e.Method, e.Path)
+}
+
+// IsUnsupportedEndpointError reports whether err was caused by an endpoint the server does not provide
+func IsUnsupportedEndpointError(err error) bool {
+	var unsupported *UnsupportedEndpointError
+	return errors.As(err, &unsupported)
+}
+
+// Capabilities holds the features a client has learnt its Coolify server to provide or lack
+type Capabilities struct {
+	// ServerVersion is the version reported by the server
+	ServerVersion string
+	support       map[Capability]bool
+}
+
+// CapabilitySupport reports whether a server provides a capability, for display
+type CapabilitySupport struct {
+	Capability  Capability `json:"capability"`
+	Description string     `json:"description"`
+	// Supported is nil while the capability has not been used
+	Supported *bool  `json:"supported"`
+	Fallback  string `json:"fallback,omitempty"`
+}
+
+// Supports reports whether the server provides a capability, and whether that is known yet
+func (c *Capabilities) Supports(capability Capability) (supported, known bool) {
+	supported, known = c.support[capability]
+	return supported, known
+}
+
+// List returns the support status of every known capability, sorted by name
+func (c *Capabilities) List() []CapabilitySupport {
+	list := make([]CapabilitySupport, 0, len(capabilities))
+	for capability, info := range capabilities {
+		item := CapabilitySupport{Capability: capability, Description: info.Description, Fallback: info.Fallback}
+		if supported, known := c.Supports(capability); known {
+			item.Supported = &supported
+		}
+		list = append(list, item)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Capability < list[j].Capability })
+	return list
+}
+
+// Capabilities returns the version of the server, looked up once per client, with the
+// capabilities learnt so far
+func (c *Client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	c.capMu.Lock()
+	defer c.capMu.Unlock()
+
+	if c.serverVersion == "" {
+		version, err := c.System().Version(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.serverVersion = version
+	}
+	support := make(map[Capability]bool, len(c.support))
+	for capability, supported := range c.support {
+		support[capability] = supported
+	}
+	return &Capabilities{ServerVersion: c.serverVersion, support: support}, nil
+}
+
+// ProbeCapabilities learns the capabilities that can be checked without changing anything and
+// returns the capabilities of the server: the deployments of the first application of the team
+// are read. Other capabilities stay unknown until they are used.
+func (c *Client) ProbeCapabilities(ctx context.Context) (*Capabilities, error) {
+	c.capMu.Lock()
+	_, known := c.support[CapabilityApplicationDeployments]
+	c.capMu.Unlock()
+	if !known {
+		if apps, err := c.Applications().List(ctx); err == nil && len(apps) > 0 && apps[0].Uuid != nil {
+			_, _ = c.Deployments().Page(ctx, *apps[0].Uuid, 0, 1)
+		}
+	}
+	return c.Capabilities(ctx)
+}
+
+// require returns an *UnsupportedError when the server is known to lack a capability, so its
+// endpoint is not called again
+func (c *Client) require(capability Capability) error {
+	c.capMu.Lock()
+	defer c.capMu.Unlock()
+
+	if supported, known := c.support[capability]; !known || supported {
+		return nil
+	}
+	return &UnsupportedError{Capability: capability, Description: capabilities[capability].Description, ServerVersion: c.serverVersion}
+}
+
+// learn records whether the server provides a capability from the outcome err of calling its
+// endpoint for a resource. Coolify answers 404 for missing endpoints and missing resources alike,
+// so a 404 only counts when exists confirms the resource; a 405 always does. It returns an
+// *UnsupportedError when the capability is missing and err otherwise.
+func (c *Client) learn(ctx context.Context, capability Capability, err error, exists func(ctx context.Context) bool) error {
+	var status *statusError
+	switch {
+	case err == nil:
+		c.record(capability, true)
+		return nil
+	case !errors.As(err, &status):
+		return err
+	case status.StatusCode == http.StatusMethodNotAllowed,
+		status.StatusCode == http.StatusNotFound && exists(ctx):
+		c.record(capability, false)
+		return c.require(capability)
+	}
+	return err
+}
+
+// record stores whether the server provides a capability
+func (c *Client) record(capability Capability, supported bool) {
+	c.capMu.Lock()
+	defer c.capMu.Unlock()
+
+	if c.support == nil {
+		c.support = make(map[Capability]bool)
+	}
+	c.support[capability] = supported
+}
+
+// coolifyVersion is a parsed Coolify release such as 4.0.0-beta.420
+type coolifyVersion struct {
+	parts      [3]int
+	prerelease string
+	preNumber  int
+}
+
+// parseCoolifyVersion parses a version like "4.0.0-beta.420" or "v4.0.0", returning nil when
+// the version is not in that form
+func parseCoolifyVersion(raw string) *coolifyVersion {
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), "v")
+	core, pre, _ := strings.Cut(raw, "-")
+
+	fields := strings.Split(core, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return nil
+	}
+	v := &coolifyVersion{}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil
+		}
+		v.parts[i] = n
+	}
+
+	if pre != "" {
+		name, number, _ := strings.Cut(pre, ".")
+		v.prerelease = name
+		if number != "" {
+			n, err := strconv.Atoi(number)
+			if err != nil {
+				return nil
+			}
+			v.preNumber = n
+		}
+	}
+	return v
+}
+
+// compare returns -1, 0 or 1 when v is older than, equal to or newer than other. A release
+// is newer than its pre-releases.
+func (v *coolifyVersion) compare(other *coolifyVersion) int {
+	for i := range v.parts {
+		if v.parts[i] != other.parts[i] {
+			return cmp.Compare(v.parts[i], other.parts[i])
+		}
+	}
+	switch {
+	case v.prerelease == other.prerelease:
+		return cmp.Compare(v.preNumber, other.preNumber)
+	case v.prerelease == "":
+		return 1
+	case other.prerelease == "":
+		return -1
+	}
+	return strings.Compare(v.prerelease, other.prerelease)
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCoolifyVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"4.0.0-beta.420", "4.0.0-beta.420", 0},
+		{"4.0.0-beta.99", "4.0.0-beta.380", -1},
+		{"v4.0.0-beta.421", "4.0.0-beta.420", 1},
+		{"4.0.0", "4.0.0-beta.999", 1},
+		{"4.1.0-beta.1", "4.0.0", 1},
+		{"3.12.36", "4.0.0-beta.1", -1},
+	}
+
+	for _, tt := range tests {
+		got := parseCoolifyVersion(tt.a).compare(parseCoolifyVersion(tt.b))
+		if got != tt.want {
+			t.Errorf("compare(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCapabilitiesFallback(t *testing.T) {
+	const appUUID = "8f1d1f7e-33b6-4a0f-9d39-0a6b1c2d3e4f"
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/deployments/applications/" + appUUID:
+			calls++
+			http.NotFound(w, r)
+		case "/applications/" + appUUID:
+			_, _ = w.Write([]byte(`{"id": 5, "uuid": "` + appUUID + `"}`))
+		case "/deployments":
+			_, _ = w.Write([]byte(`[{"deployment_uuid": "a", "application_id": "5"}, {"deployment_uuid": "b", "application_id": "6"}]`))
+		case "/version":
+			_, _ = w.Write([]byte(`"4.0.0-beta.380"`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(nil, WithBaseURL(server.URL), WithToken("token"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for range 2 {
+		deployments, err := c.Deployments().Page(context.Background(), appUUID, 0, 10)
+		if err != nil {
+			t.Fatalf("Page() error = %v", err)
+		}
+		if len(deployments) != 1 || *deployments[0].DeploymentUuid != "a" {
+			t.Errorf("Page() = %v, want the running deployment of the application", deployments)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("application deployments endpoint called %d times, want 1", calls)
+	}
+
+	var unsupported *UnsupportedError
+	if err := c.require(CapabilityApplicationDeployments); !errors.As(err, &unsupported) {
+		t.Errorf("require() error = %v, want *UnsupportedError", err)
+	}
+	if err := c.require(CapabilityDeploymentCancel); err != nil {
+		t.Errorf("require() on an unused capability error = %v", err)
+	}
+
+	caps, err := c.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Capabilities() error = %v", err)
+	}
+	if supported, known := caps.Supports(CapabilityApplicationDeployments); supported || !known {
+		t.Errorf("Supports() = %t, %t, want false, true", supported, known)
+	}
+	if _, known := caps.Supports(CapabilityDeploymentCancel); known {
+		t.Error("Supports() knows an unused capability")
+	}
+}
+
+func TestCheckTestedVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		inRange bool
+		tooOld  bool
+	}{
+		{MinTestedVersion, true, false},
+		{MaxTestedVersion, true, false},
+		{"v4.0.0-beta.400", true, false},
+		{"dev", true, false},
+		{"4.0.0-beta.200", false, true},
+		{"3.12.36", false, true},
+		{"4.0.0", false, false},
+	}
+
+	for _, tt := range tests {
+		err := CheckTestedVersion(tt.version)
+		var rangeErr *VersionRangeError
+		switch {
+		case tt.inRange && err != nil:
+			t.Errorf("CheckTestedVersion(%s) error = %v", tt.version, err)
+		case !tt.inRange && (!errors.As(err, &rangeErr) || rangeErr.TooOld != tt.tooOld):
+			t.Errorf("CheckTestedVersion(%s) error = %v, want VersionRangeError with TooOld %t", tt.version, err, tt.tooOld)
+		}
+	}
+}
+
+func TestUnsupportedEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cleanup":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "Missing required permissions: write"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(nil, WithBaseURL(server.URL), WithToken("token"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	_, err = c.System().Upgrade(context.Background())
+	var unsupported *UnsupportedEndpointError
+	if !errors.As(err, &unsupported) || unsupported.Path != "/upgrade" {
+		t.Fatalf("Upgrade() error = %v, want *UnsupportedEndpointError", err)
+	}
+
+	if _, err := c.System().Cleanup(context.Background()); !IsPermissionError(err) {
+		t.Errorf("Cleanup() error = %v, want a permission error", err)
+	}
+}
@@ -1,58 +1,222 @@
 // Package client provides HTTP client functionality for interacting with the Coolify API.
+//
+// It can be used as a standalone SDK by Go programs without the coolifyme CLI configuration:
+//
+//	c, err := client.New(nil,
+//		client.WithBaseURL("https://coolify.example.com/api/v1"),
+//		client.WithToken(os.Getenv("COOLIFY_TOKEN")),
+//		client.WithUserAgent("my-tool/1.0"),
+//		client.WithRetryPolicy(client.RetryPolicy{MaxRetries: 3, Delay: time.Second}),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	apps, err := c.Applications().List(ctx)
 package client
 
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
 	"github.com/hongkongkiwi/coolifyme/internal/config"
 	"github.com/hongkongkiwi/coolifyme/internal/logger"
+	"github.com/hongkongkiwi/coolifyme/pkg/client/lite"
 )
 
 // Client wraps the generated Coolify API client
 type Client struct {
-	API    *coolify.ClientWithResponses
-	config *config.Config
-}
+	API        *coolify.ClientWithResponses
+	baseURL    string
+	httpClient *http.Client
+	logger     *slog.Logger
+	// tokenHash identifies the API token without revealing it
+	tokenHash string
+	// strictDecode fails requests whose responses contain fields unknown to the API types
+	strictDecode bool
+	// readOnly rejects every request that would change something
+	readOnly bool
+
+	capMu sync.Mutex
+	// serverVersion is the version of the server, looked up once by Capabilities
+	serverVersion string
+	// support holds the capabilities learnt to be provided or missing
+	support map[Capability]bool
+}
+
+// New creates a new Coolify client. The config may be nil when the base URL and token
+// are supplied with WithBaseURL and WithToken, e.g. by programs embedding this package.
+func New(cfg *config.Config, opts ...Option) (*Client, error) {
+	o := options{maxResponseSize: DefaultMaxResponseSize, maxIdleConnsPerHost: DefaultMaxIdleConnsPerHost}
+	if cfg != nil {
+		o.baseURL = cfg.BaseURL
+		o.token = cfg.APIToken
+		o.readOnly = cfg.ReadOnly
+		for name, value := range cfg.Headers {
+			WithHeader(name, value)(&o)
+		}
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
 
-// New creates a new Coolify client
-func New(cfg *config.Config) (*Client, error) {
-	if cfg.APIToken == "" {
+	if o.token == "" {
 		return nil, fmt.Errorf("API token is required")
 	}
+	if o.baseURL == "" {
+		return nil, fmt.Errorf("base URL is required")
+	}
 
-	// Create HTTP client with authentication and logging
-	httpClient := &http.Client{
-		Transport: &loggingTransport{
-			token: cfg.APIToken,
-			base:  http.DefaultTransport,
-		},
+	// Start from the caller's HTTP client so its timeout and redirect policy are kept
+	httpClient := &http.Client{}
+	var base http.RoundTripper = newTransport(o.maxIdleConnsPerHost)
+	if o.httpClient != nil {
+		clientCopy := *o.httpClient
+		httpClient = &clientCopy
+		if clientCopy.Transport != nil {
+			base = clientCopy.Transport
+		}
+	}
+	if o.retry != nil {
+		base = &retryTransport{policy: *o.retry, base: base}
 	}
+	for i := len(o.middleware) - 1; i >= 0; i-- {
+		base = o.middleware[i](base)
+	}
+
+	// Add authentication, logging and conditional GET caching
+	var transport http.RoundTripper = newConditionalTransport(&loggingTransport{
+		token:     o.token,
+		userAgent: o.userAgent,
+		headers:   o.headers,
+		editors:   o.editors,
+		responses: o.responses,
+		logger:    o.logger,
+		maxBody:   o.maxResponseSize,
+		base:      base,
+	})
+	if o.readOnly {
+		transport = &readOnlyTransport{base: transport}
+	}
+	httpClient.Transport = transport
 
 	// Create the API client
-	apiClient, err := coolify.NewClientWithResponses(cfg.BaseURL, coolify.WithHTTPClient(httpClient))
+	apiClient, err := coolify.NewClientWithResponses(o.baseURL, coolify.WithHTTPClient(httpClient))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create API client: %w", err)
 	}
 
 	return &Client{
-		API:    apiClient,
-		config: cfg,
+		API:          apiClient,
+		baseURL:      o.baseURL,
+		httpClient:   httpClient,
+		logger:       o.logger,
+		strictDecode: o.strictDecode,
+		readOnly:     o.readOnly,
+		tokenHash:    fmt.Sprintf("%x", sha256.Sum256([]byte(o.token)))[:16],
 	}, nil
 }
 
+// doRequest performs a raw API request for endpoints that are not covered by the generated client.
+// The path is relative to the configured base URL. If out is non-nil the response body is decoded into it.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	url := strings.TrimSuffix(c.baseURL, "/") + "/" + strings.TrimPrefix(path, "/")
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return apiError(resp, body)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && err != io.EOF {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// statusError is the error of a request answered with a non-2xx status
+type statusError struct {
+	StatusCode int
+	err        error
+}
+
+// Error implements the error interface
+func (e *statusError) Error() string { return e.err.Error() }
+
+// Unwrap returns the API error, e.g. a *PermissionError
+func (e *statusError) Unwrap() error { return e.err }
+
+// StatusCode returns the HTTP status of a request the API rejected, or 0 for other errors
+func StatusCode(err error) int {
+	var status *statusError
+	if errors.As(err, &status) {
+		return status.StatusCode
+	}
+	return 0
+}
+
+// doOptionalRequest is doRequest for endpoints that are not part of the Coolify API
+// specification. Servers without the endpoint answer 404 or 405, which is reported as an
+// *UnsupportedEndpointError describing the feature instead of a bare status.
+func (c *Client) doOptionalRequest(ctx context.Context, feature, method, path string, body interface{}, out interface{}) error {
+	err := c.doRequest(ctx, method, path, body, out)
+	var status *statusError
+	if errors.As(err, &status) && (status.StatusCode == http.StatusNotFound || status.StatusCode == http.StatusMethodNotAllowed) {
+		return &UnsupportedEndpointError{Feature: feature, Method: method, Path: path}
+	}
+	return err
+}
+
 // loggingTransport implements HTTP transport with Bearer token authentication and request/response logging
 type loggingTransport struct {
-	token string
-	base  http.RoundTripper
+	token     string
+	userAgent string
+	headers   http.Header
+	editors   []RequestEditor
+	responses []ResponseEditor
+	logger    *slog.Logger
+	// maxBody is the largest response body accepted, 0 for no limit
+	maxBody int64
+	base    http.RoundTripper
 }
 
 func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -62,21 +226,34 @@ func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error)
 	req.Header.Set("Authorization", "Bearer "+t.token)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	for name, values := range t.headers {
+		req.Header[name] = values
+	}
+	setIdempotencyKey(req)
+	for _, edit := range t.editors {
+		if err := edit(req.Context(), req); err != nil {
+			return nil, fmt.Errorf("request editor failed: %w", err)
+		}
+	}
 
 	// Log request details if debug logging is enabled
-	logger.Debug("API Request",
+	t.debug("API Request",
 		"method", req.Method,
-		"url", req.URL.String(),
-		"headers", formatHeaders(req.Header),
+		"url", scrubURL(req.URL),
+		"headers", formatHeaders(req.Header, t.headers),
 	)
 
 	// Log request body if present
-	if req.Body != nil {
+	debug := t.debugEnabled(req.Context())
+	if debug && req.Body != nil {
 		bodyBytes, err := io.ReadAll(req.Body)
 		if err == nil {
 			req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 			if len(bodyBytes) > 0 {
-				logger.Debug("API Request Body", "body", string(bodyBytes))
+				t.debug("API Request Body", "body", scrubBody(bodyBytes))
 			}
 		}
 	}
@@ -86,9 +263,9 @@ func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error)
 	duration := time.Since(start)
 
 	if err != nil {
-		logger.Debug("API Request Failed",
+		t.debug("API Request Failed",
 			"method", req.Method,
-			"url", req.URL.String(),
+			"url", scrubURL(req.URL),
 			"duration", duration.String(),
 			"error", err.Error(),
 		)
@@ -96,33 +273,92 @@ func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error)
 	}
 
 	// Log response details
-	logger.Debug("API Response",
+	t.debug("API Response",
 		"method", req.Method,
-		"url", req.URL.String(),
+		"url", scrubURL(req.URL),
 		"status", resp.Status,
 		"duration", duration.String(),
-		"headers", formatHeaders(resp.Header),
+		"headers", formatHeaders(resp.Header, t.headers),
 	)
 
-	// Log response body if debug logging and it's a small response
-	if resp.Body != nil && resp.ContentLength < 10000 { // Only log small responses
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err == nil {
-			resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
-			if len(bodyBytes) > 0 {
-				logger.Debug("API Response Body", "body", string(bodyBytes))
-			}
+	if resp.Body != nil && t.maxBody > 0 {
+		if resp.ContentLength > t.maxBody {
+			_ = resp.Body.Close()
+			return nil, responseTooLarge(req, t.maxBody)
+		}
+		resp.Body = &limitedBody{body: resp.Body, remaining: t.maxBody, req: req, limit: t.maxBody}
+	}
+
+	// Log the start of the response body; the rest is streamed to the caller unbuffered
+	if debug && resp.Body != nil {
+		prefix, err := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBody+1))
+		if err != nil {
+			_ = resp.Body.Close()
+			return nil, err
+		}
+		resp.Body = &prefixedBody{Reader: io.MultiReader(bytes.NewReader(prefix), resp.Body), body: resp.Body}
+		if len(prefix) > maxLoggedBody {
+			t.debug("API Response Body", "body", scrubBody(prefix[:maxLoggedBody])+"... (truncated)")
+		} else if len(prefix) > 0 {
+			t.debug("API Response Body", "body", scrubBody(prefix))
+		}
+	}
+
+	for _, edit := range t.responses {
+		if err := edit(req.Context(), resp); err != nil {
+			_ = resp.Body.Close()
+			return nil, fmt.Errorf("response editor failed: %w", err)
 		}
 	}
 
 	return resp, nil
 }
 
-// formatHeaders formats HTTP headers for logging (excluding sensitive ones)
-func formatHeaders(headers http.Header) string {
+func (t *loggingTransport) debug(msg string, args ...any) {
+	logDebug(t.logger, msg, args...)
+}
+
+func (t *loggingTransport) debugEnabled(ctx context.Context) bool {
+	return debugEnabled(ctx, t.logger)
+}
+
+func (c *Client) debug(msg string, args ...any) {
+	logDebug(c.logger, msg, args...)
+}
+
+// debugEnabled reports whether debug messages are logged by the logger supplied by WithLogger,
+// or the CLI logger if none was given
+func debugEnabled(ctx context.Context, l *slog.Logger) bool {
+	if l != nil {
+		return l.Enabled(ctx, slog.LevelDebug)
+	}
+	return logger.Enabled(slog.LevelDebug)
+}
+
+// logDebug logs with the logger supplied by WithLogger, or the CLI logger if none was given
+func logDebug(l *slog.Logger, msg string, args ...any) {
+	if l != nil {
+		l.Debug(msg, args...)
+		return
+	}
+	logger.Debug(msg, args...)
+}
+
+// logWarn logs a warning with the logger supplied by WithLogger, or the CLI logger if none was given
+func logWarn(l *slog.Logger, msg string, args ...any) {
+	if l != nil {
+		l.Warn(msg, args...)
+		return
+	}
+	logger.Warn(msg, args...)
+}
+
+// formatHeaders formats HTTP headers for logging (excluding sensitive ones). The custom headers
+// are redacted as well since they usually carry proxy credentials.
+func formatHeaders(headers, custom http.Header) string {
 	var formatted []string
 	for key, values := range headers {
-		if strings.ToLower(key) == "authorization" {
+		if _, ok := custom[key]; ok || strings.ToLower(key) == "authorization" {
 			formatted = append(formatted, fmt.Sprintf("%s: [REDACTED]", key))
 		} else {
 			formatted = append(formatted, fmt.Sprintf("%s: %s", key, strings.Join(values, ", ")))
@@ -131,56 +367,72 @@ func formatHeaders(headers http.Header) string {
 	return strings.Join(formatted, "; ")
 }
 
+// BaseURL returns the Coolify API base URL the client talks to
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// TokenFingerprint returns a short hash of the API token, which identifies the token, e.g. in
+// cache keys, without revealing it
+func (c *Client) TokenFingerprint() string {
+	return c.tokenHash
+}
+
 // Applications returns an applications client
-func (c *Client) Applications() *ApplicationsClient {
+func (c *Client) Applications() ApplicationsAPI {
 	return &ApplicationsClient{client: c}
 }
 
 // Projects returns a projects client
-func (c *Client) Projects() *ProjectsClient {
+func (c *Client) Projects() ProjectsAPI {
 	return &ProjectsClient{client: c}
 }
 
 // Servers returns a servers client
-func (c *Client) Servers() *ServersClient {
+func (c *Client) Servers() ServersAPI {
 	return &ServersClient{client: c}
 }
 
 // Services returns a services client
-func (c *Client) Services() *ServicesClient {
+func (c *Client) Services() ServicesAPI {
 	return &ServicesClient{client: c}
 }
 
 // Deployments returns a deployments client
-func (c *Client) Deployments() *DeploymentsClient {
+func (c *Client) Deployments() DeploymentsAPI {
 	return &DeploymentsClient{client: c}
 }
 
 // Databases returns a databases client
-func (c *Client) Databases() *DatabasesClient {
+func (c *Client) Databases() DatabasesAPI {
 	return &DatabasesClient{client: c}
 }
 
 // PrivateKeys returns a private keys client
-func (c *Client) PrivateKeys() *PrivateKeysClient {
+func (c *Client) PrivateKeys() PrivateKeysAPI {
 	return &PrivateKeysClient{client: c}
 }
 
 // Resources returns a resources client
-func (c *Client) Resources() *ResourcesClient {
+func (c *Client) Resources() ResourcesAPI {
 	return &ResourcesClient{client: c}
 }
 
 // Teams returns a teams client
-func (c *Client) Teams() *TeamsClient {
+func (c *Client) Teams() TeamsAPI {
 	return &TeamsClient{client: c}
 }
 
 // System returns a system client
-func (c *Client) System() *SystemClient {
+func (c *Client) System() SystemAPI {
 	return &SystemClient{client: c}
 }
 
+// Sources returns a sources client
+func (c *Client) Sources() SourcesAPI {
+	return &SourcesClient{client: c}
+}
+
 // ApplicationsClient handles application-related operations
 type ApplicationsClient struct {
 	client *Client
@@ -188,20 +440,29 @@ type ApplicationsClient struct {
 
 // List returns all applications
 func (ac *ApplicationsClient) List(ctx context.Context) ([]coolify.Application, error) {
-	resp, err := ac.client.API.ListApplicationsWithResponse(ctx)
+	resp, err := ac.client.API.ListApplications(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list applications: %w", err)
 	}
+	return streamList[coolify.Application](ac.client, resp)
+}
 
-	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+// ListByTag returns the applications with the given tag. The API has no tag filter, so the
+// applications listing is filtered here by the tags it carries; a listing without tags matches
+// nothing rather than every application.
+func (ac *ApplicationsClient) ListByTag(ctx context.Context, tag string) ([]coolify.Application, error) {
+	var listed []taggedApplication
+	if err := ac.client.doRequest(ctx, http.MethodGet, "/applications", nil, &listed); err != nil {
+		return nil, fmt.Errorf("failed to list applications with tag '%s': %w", tag, err)
 	}
 
-	if resp.JSON200 == nil {
-		return nil, fmt.Errorf("empty response body")
+	var apps []coolify.Application
+	for _, app := range listed {
+		if hasTag(app.Tags, tag) {
+			apps = append(apps, app.Application)
+		}
 	}
-
-	return *resp.JSON200, nil
+	return apps, nil
 }
 
 // CreatePublic creates a new application from a public repository
@@ -212,16 +473,17 @@ func (ac *ApplicationsClient) CreatePublic(ctx context.Context, req coolify.Crea
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
+	// The API only returns the UUID of the new application
 	if resp.JSON201 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
-
-	// Note: The API returns just a UUID, we'd need to fetch the full application
-	// This is a simplified implementation
-	return nil, nil
+	if err := ac.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return nil, err
+	}
+	return &coolify.Application{Uuid: resp.JSON201.Uuid}, nil
 }
 
 // Get returns an application by UUID
@@ -237,12 +499,15 @@ func (ac *ApplicationsClient) Get(ctx context.Context, uuidStr string) (*coolify
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := ac.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return resp.JSON200, nil
 }
@@ -260,7 +525,7 @@ func (ac *ApplicationsClient) Delete(ctx context.Context, uuidStr string, option
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	return nil
@@ -279,16 +544,33 @@ func (ac *ApplicationsClient) Update(ctx context.Context, uuidStr string, req co
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil || resp.JSON200.Uuid == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := ac.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON200.Uuid, nil
 }
 
+// UpdateFields updates raw application fields that the generated request body does not cover,
+// such as dockerfile_location
+func (ac *ApplicationsClient) UpdateFields(ctx context.Context, uuidStr string, fields map[string]any) error {
+	appUUID, err := uuid.Parse(uuidStr)
+	if err != nil {
+		return fmt.Errorf("invalid UUID: %w", err)
+	}
+
+	if err := ac.client.doRequest(ctx, http.MethodPatch, "/applications/"+appUUID.String(), fields, nil); err != nil {
+		return fmt.Errorf("failed to update application: %w", err)
+	}
+	return nil
+}
+
 // CreatePrivateGithubApp creates a new application from a private GitHub app repository
 func (ac *ApplicationsClient) CreatePrivateGithubApp(ctx context.Context, req coolify.CreatePrivateGithubAppApplicationJSONRequestBody) (*coolify.Application, error) {
 	resp, err := ac.client.API.CreatePrivateGithubAppApplicationWithResponse(ctx, req)
@@ -297,10 +579,17 @@ func (ac *ApplicationsClient) CreatePrivateGithubApp(ctx context.Context, req co
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
-	return nil, nil // API returns UUID, would need to fetch full application
+	// The API only returns the UUID of the new application
+	if resp.JSON201 == nil {
+		return nil, fmt.Errorf("empty response body")
+	}
+	if err := ac.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return nil, err
+	}
+	return &coolify.Application{Uuid: resp.JSON201.Uuid}, nil
 }
 
 // CreatePrivateDeployKey creates a new application from a private repository with deploy key
@@ -311,10 +600,17 @@ func (ac *ApplicationsClient) CreatePrivateDeployKey(ctx context.Context, req co
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
-	return nil, nil // API returns UUID, would need to fetch full application
+	// The API only returns the UUID of the new application
+	if resp.JSON201 == nil {
+		return nil, fmt.Errorf("empty response body")
+	}
+	if err := ac.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return nil, err
+	}
+	return &coolify.Application{Uuid: resp.JSON201.Uuid}, nil
 }
 
 // CreateDockerfile creates a new application from a Dockerfile
@@ -325,10 +621,17 @@ func (ac *ApplicationsClient) CreateDockerfile(ctx context.Context, req coolify.
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
-	return nil, nil // API returns UUID, would need to fetch full application
+	// The API only returns the UUID of the new application
+	if resp.JSON201 == nil {
+		return nil, fmt.Errorf("empty response body")
+	}
+	if err := ac.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return nil, err
+	}
+	return &coolify.Application{Uuid: resp.JSON201.Uuid}, nil
 }
 
 // CreateDockerImage creates a new application from a Docker image
@@ -339,10 +642,17 @@ func (ac *ApplicationsClient) CreateDockerImage(ctx context.Context, req coolify
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
-	return nil, nil // API returns UUID, would need to fetch full application
+	// The API only returns the UUID of the new application
+	if resp.JSON201 == nil {
+		return nil, fmt.Errorf("empty response body")
+	}
+	if err := ac.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return nil, err
+	}
+	return &coolify.Application{Uuid: resp.JSON201.Uuid}, nil
 }
 
 // CreateDockerCompose creates a new application from a Docker Compose file
@@ -353,10 +663,17 @@ func (ac *ApplicationsClient) CreateDockerCompose(ctx context.Context, req cooli
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
-	return nil, nil // API returns UUID, would need to fetch full application
+	// The API only returns the UUID of the new application
+	if resp.JSON201 == nil {
+		return nil, fmt.Errorf("empty response body")
+	}
+	if err := ac.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return nil, err
+	}
+	return &coolify.Application{Uuid: resp.JSON201.Uuid}, nil
 }
 
 // Start starts an application
@@ -372,12 +689,15 @@ func (ac *ApplicationsClient) Start(ctx context.Context, uuidStr string, options
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := ac.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	startResponse := &StartResponse{}
 	if resp.JSON200.Message != nil {
@@ -403,7 +723,7 @@ func (ac *ApplicationsClient) Stop(ctx context.Context, uuidStr string) error {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	return nil
@@ -422,12 +742,15 @@ func (ac *ApplicationsClient) Restart(ctx context.Context, uuidStr string) (*Res
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := ac.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	restartResponse := &RestartResponse{}
 	if resp.JSON200.Message != nil {
@@ -453,12 +776,15 @@ func (ac *ApplicationsClient) GetLogs(ctx context.Context, uuidStr string, param
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil || resp.JSON200.Logs == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := ac.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON200.Logs, nil
 }
@@ -476,12 +802,15 @@ func (ac *ApplicationsClient) ListEnvs(ctx context.Context, uuidStr string) ([]c
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := ac.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return *resp.JSON200, nil
 }
@@ -499,12 +828,15 @@ func (ac *ApplicationsClient) CreateEnv(ctx context.Context, uuidStr string, req
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON201 == nil || resp.JSON201.Uuid == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := ac.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON201.Uuid, nil
 }
@@ -522,12 +854,15 @@ func (ac *ApplicationsClient) UpdateEnv(ctx context.Context, uuidStr string, req
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON201 == nil || resp.JSON201.Message == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := ac.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON201.Message, nil
 }
@@ -545,12 +880,15 @@ func (ac *ApplicationsClient) UpdateEnvs(ctx context.Context, uuidStr string, re
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON201 == nil || resp.JSON201.Message == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := ac.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON201.Message, nil
 }
@@ -573,12 +911,15 @@ func (ac *ApplicationsClient) DeleteEnv(ctx context.Context, uuidStr string, env
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil || resp.JSON200.Message == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := ac.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON200.Message, nil
 }
@@ -596,12 +937,15 @@ func (pc *ProjectsClient) List(ctx context.Context) ([]coolify.Project, error) {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := pc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return *resp.JSON200, nil
 }
@@ -614,12 +958,15 @@ func (pc *ProjectsClient) Create(ctx context.Context, req coolify.CreateProjectJ
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON201 == nil || resp.JSON201.Uuid == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := pc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON201.Uuid, nil
 }
@@ -632,12 +979,15 @@ func (pc *ProjectsClient) Get(ctx context.Context, uuidStr string) (*coolify.Pro
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := pc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return resp.JSON200, nil
 }
@@ -655,7 +1005,7 @@ func (pc *ProjectsClient) Delete(ctx context.Context, uuidStr string) error {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	return nil
@@ -674,12 +1024,15 @@ func (pc *ProjectsClient) Update(ctx context.Context, uuidStr string, req coolif
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON201 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := pc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return nil, err
+	}
 
 	// Convert the response to a full Project object
 	project := &coolify.Project{}
@@ -704,16 +1057,76 @@ func (pc *ProjectsClient) GetEnvironment(ctx context.Context, projectUUID, envir
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := pc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return resp.JSON200, nil
 }
 
+// CreateEnvironment creates an environment in a project and returns its UUID. The endpoint is
+// not part of the Coolify API specification; servers without it report an
+// *UnsupportedEndpointError.
+func (pc *ProjectsClient) CreateEnvironment(ctx context.Context, projectUUID, name string) (string, error) {
+	var result struct {
+		UUID string `json:"uuid"`
+	}
+	body := map[string]string{"name": name}
+	if err := pc.client.doOptionalRequest(ctx, "Creating project environments", http.MethodPost, "/projects/"+url.PathEscape(projectUUID)+"/environments", body, &result); err != nil {
+		return "", fmt.Errorf("failed to create environment: %w", err)
+	}
+	return result.UUID, nil
+}
+
+// DeleteEnvironment deletes an environment of a project by name or UUID. Coolify refuses to
+// delete an environment that still contains resources. The endpoint is not part of the Coolify
+// API specification; servers without it report an *UnsupportedEndpointError.
+func (pc *ProjectsClient) DeleteEnvironment(ctx context.Context, projectUUID, environmentNameOrUUID string) error {
+	path := "/projects/" + url.PathEscape(projectUUID) + "/environments/" + url.PathEscape(environmentNameOrUUID)
+	if err := pc.client.doOptionalRequest(ctx, "Deleting project environments", http.MethodDelete, path, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete environment: %w", err)
+	}
+	return nil
+}
+
+// Resolve returns a project by UUID or name, including its environments
+func (pc *ProjectsClient) Resolve(ctx context.Context, nameOrUUID string) (*coolify.Project, error) {
+	projects, err := pc.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var match *coolify.Project
+	for i, project := range projects {
+		if project.Uuid != nil && *project.Uuid == nameOrUUID {
+			match = &projects[i]
+			break
+		}
+		if project.Name != nil && strings.EqualFold(*project.Name, nameOrUUID) {
+			if match != nil {
+				return nil, fmt.Errorf("project name '%s' is ambiguous, use the project UUID", nameOrUUID)
+			}
+			match = &projects[i]
+		}
+	}
+
+	if match == nil || match.Uuid == nil {
+		return nil, fmt.Errorf("project '%s' not found", nameOrUUID)
+	}
+
+	// The list endpoint does not always include environments
+	if match.Environments == nil {
+		return pc.Get(ctx, *match.Uuid)
+	}
+	return match, nil
+}
+
 // ServersClient handles server-related operations
 type ServersClient struct {
 	client *Client
@@ -721,20 +1134,11 @@ type ServersClient struct {
 
 // List returns all servers
 func (sc *ServersClient) List(ctx context.Context) ([]coolify.Server, error) {
-	resp, err := sc.client.API.ListServersWithResponse(ctx)
+	resp, err := sc.client.API.ListServers(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list servers: %w", err)
 	}
-
-	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
-	}
-
-	if resp.JSON200 == nil {
-		return nil, fmt.Errorf("empty response body")
-	}
-
-	return *resp.JSON200, nil
+	return streamList[coolify.Server](sc.client, resp)
 }
 
 // Create creates a new server
@@ -745,12 +1149,15 @@ func (sc *ServersClient) Create(ctx context.Context, req coolify.CreateServerJSO
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON201 == nil || resp.JSON201.Uuid == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON201.Uuid, nil
 }
@@ -763,12 +1170,15 @@ func (sc *ServersClient) Get(ctx context.Context, uuidStr string) (*coolify.Serv
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return resp.JSON200, nil
 }
@@ -786,7 +1196,7 @@ func (sc *ServersClient) Delete(ctx context.Context, uuidStr string) error {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	return nil
@@ -800,12 +1210,15 @@ func (sc *ServersClient) Update(ctx context.Context, uuidStr string, req coolify
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON201 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return nil, err
+	}
 
 	return resp.JSON201, nil
 }
@@ -818,12 +1231,15 @@ func (sc *ServersClient) GetResources(ctx context.Context, uuidStr string) (stri
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return "", err
+	}
 
 	// Convert to JSON string for consistent API interface
 	jsonBytes, err := json.Marshal(*resp.JSON200)
@@ -842,12 +1258,15 @@ func (sc *ServersClient) GetDomains(ctx context.Context, uuidStr string) (string
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return "", err
+	}
 
 	// Convert to JSON string for consistent API interface
 	jsonBytes, err := json.Marshal(*resp.JSON200)
@@ -866,16 +1285,63 @@ func (sc *ServersClient) Validate(ctx context.Context, uuidStr string) (string,
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON201 == nil || resp.JSON201.Message == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON201.Message, nil
 }
 
+// SSHTarget holds the connection details Coolify uses to reach a server over SSH
+type SSHTarget struct {
+	ServerUUID     string
+	Host           string
+	Port           int
+	User           string
+	PrivateKeyUUID string
+}
+
+// SSHTarget returns the SSH connection details of a server. The private key is referenced by
+// ID in the server response, which is not part of the generated types, so it is read directly.
+func (sc *ServersClient) SSHTarget(ctx context.Context, uuidStr string) (*SSHTarget, error) {
+	var raw struct {
+		UUID         string `json:"uuid"`
+		IP           string `json:"ip"`
+		Port         int    `json:"port"`
+		User         string `json:"user"`
+		PrivateKeyID int    `json:"private_key_id"`
+	}
+	if err := sc.client.doRequest(ctx, http.MethodGet, "/servers/"+uuidStr, nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get server: %w", err)
+	}
+
+	target := &SSHTarget{ServerUUID: raw.UUID, Host: raw.IP, Port: raw.Port, User: raw.User}
+	if target.Port == 0 {
+		target.Port = 22
+	}
+	if target.User == "" {
+		target.User = "root"
+	}
+
+	keys, err := sc.client.PrivateKeys().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		if key.Id != nil && *key.Id == raw.PrivateKeyID && key.Uuid != nil {
+			target.PrivateKeyUUID = *key.Uuid
+			break
+		}
+	}
+	return target, nil
+}
+
 // ServicesClient handles service-related operations
 type ServicesClient struct {
 	client *Client
@@ -883,20 +1349,11 @@ type ServicesClient struct {
 
 // List returns all services
 func (sc *ServicesClient) List(ctx context.Context) ([]coolify.Service, error) {
-	resp, err := sc.client.API.ListServicesWithResponse(ctx)
+	resp, err := sc.client.API.ListServices(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list services: %w", err)
 	}
-
-	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
-	}
-
-	if resp.JSON200 == nil {
-		return nil, fmt.Errorf("empty response body")
-	}
-
-	return *resp.JSON200, nil
+	return streamList[coolify.Service](sc.client, resp)
 }
 
 // Get returns a service by UUID
@@ -907,12 +1364,15 @@ func (sc *ServicesClient) Get(ctx context.Context, uuidStr string) (*coolify.Ser
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return resp.JSON200, nil
 }
@@ -930,7 +1390,7 @@ func (sc *ServicesClient) Start(ctx context.Context, uuidStr string) error {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	return nil
@@ -949,7 +1409,7 @@ func (sc *ServicesClient) Stop(ctx context.Context, uuidStr string) error {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	return nil
@@ -968,7 +1428,7 @@ func (sc *ServicesClient) Restart(ctx context.Context, uuidStr string) error {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	return nil
@@ -982,12 +1442,15 @@ func (sc *ServicesClient) Create(ctx context.Context, req coolify.CreateServiceJ
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON201 == nil || resp.JSON201.Uuid == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON201.Uuid, nil
 }
@@ -1000,7 +1463,7 @@ func (sc *ServicesClient) Delete(ctx context.Context, uuidStr string, options *c
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	return nil
@@ -1019,12 +1482,15 @@ func (sc *ServicesClient) Update(ctx context.Context, uuidStr string, req coolif
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil || resp.JSON200.Uuid == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON200.Uuid, nil
 }
@@ -1042,12 +1508,15 @@ func (sc *ServicesClient) ListEnvs(ctx context.Context, uuidStr string) ([]cooli
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return *resp.JSON200, nil
 }
@@ -1065,12 +1534,15 @@ func (sc *ServicesClient) CreateEnv(ctx context.Context, uuidStr string, req coo
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON201 == nil || resp.JSON201.Uuid == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON201.Uuid, nil
 }
@@ -1088,12 +1560,15 @@ func (sc *ServicesClient) UpdateEnv(ctx context.Context, uuidStr string, req coo
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON201 == nil || resp.JSON201.Message == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON201.Message, nil
 }
@@ -1111,12 +1586,15 @@ func (sc *ServicesClient) UpdateEnvs(ctx context.Context, uuidStr string, req co
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON201 == nil || resp.JSON201.Message == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON201.Message, nil
 }
@@ -1139,16 +1617,182 @@ func (sc *ServicesClient) DeleteEnv(ctx context.Context, uuidStr string, envUUID
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil || resp.JSON200.Message == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON200.Message, nil
 }
 
+// ServiceTemplatesURL is the location of the official Coolify one-click service template catalog
+const ServiceTemplatesURL = "https://cdn.coollabs.io/coolify/service-templates.json"
+
+// OpenAPISpecURL is the location of the official Coolify OpenAPI specification for a git ref
+const OpenAPISpecURL = "https://raw.githubusercontent.com/coollabsio/coolify/%s/openapi.yaml"
+
+// FetchOpenAPISpec downloads the official Coolify OpenAPI specification published for a git ref,
+// such as a release tag like v4.0.0-beta.420. The specification is public, so no API token is needed.
+func FetchOpenAPISpec(ctx context.Context, ref string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(OpenAPISpecURL, ref), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OpenAPI spec: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI spec: %w", err)
+	}
+	return data, nil
+}
+
+// ServiceTypes lists the one-click service types accepted by the create service endpoint
+var ServiceTypes = []string{
+	"activepieces", "appsmith", "appwrite", "authentik", "babybuddy", "budge", "changedetection",
+	"chatwoot", "classicpress-with-mariadb", "classicpress-with-mysql",
+	"classicpress-without-database", "cloudflared", "code-server", "dashboard", "directus",
+	"directus-with-postgresql", "docker-registry", "docuseal", "docuseal-with-postgres", "dokuwiki",
+	"duplicati", "emby", "embystat", "fider", "filebrowser", "firefly", "formbricks", "ghost",
+	"gitea", "gitea-with-mariadb", "gitea-with-mysql", "gitea-with-postgresql", "glance", "glances",
+	"glitchtip", "grafana", "grafana-with-postgresql", "grocy", "heimdall", "homepage", "jellyfin",
+	"kuzzle", "listmonk", "logto", "mediawiki", "meilisearch", "metabase", "metube", "minio",
+	"moodle", "n8n", "n8n-with-postgresql", "next-image-transformation", "nextcloud", "nocodb",
+	"odoo", "openblocks", "pairdrop", "penpot", "phpmyadmin", "pocketbase", "posthog",
+	"reactive-resume", "rocketchat", "shlink", "slash", "snapdrop", "statusnook", "stirling-pdf",
+	"supabase", "syncthing", "tolgee", "trigger", "trigger-with-external-database", "twenty", "umami",
+	"unleash-with-postgresql", "unleash-without-database", "uptime-kuma", "vaultwarden", "vikunja",
+	"weblate", "whoogle", "wordpress-with-mariadb", "wordpress-with-mysql",
+	"wordpress-without-database",
+}
+
+// TemplateEnvVar describes an environment variable referenced by a service template
+type TemplateEnvVar struct {
+	Name      string `json:"name"`
+	Default   string `json:"default,omitempty"`
+	Generated bool   `json:"generated"`
+}
+
+// ServiceTemplate describes a one-click service template
+type ServiceTemplate struct {
+	Name          string           `json:"name"`
+	Slogan        string           `json:"slogan,omitempty"`
+	Documentation string           `json:"documentation,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+	Port          string           `json:"port,omitempty"`
+	MinVersion    string           `json:"min_version,omitempty"`
+	EnvVars       []TemplateEnvVar `json:"env_vars,omitempty"`
+	Compose       string           `json:"-"`
+}
+
+// templateEnvPattern matches ${VAR}, ${VAR:-default} and ${VAR-default} references in compose files
+var templateEnvPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::?[-?]([^}]*))?\}`)
+
+// ListServiceTemplates fetches the one-click service template catalog.
+// The catalog is public, so no API token is needed.
+func ListServiceTemplates(ctx context.Context) ([]ServiceTemplate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ServiceTemplatesURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch service templates: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error: %s", resp.Status)
+	}
+
+	var raw map[string]struct {
+		Slogan        string      `json:"slogan"`
+		Documentation string      `json:"documentation"`
+		Tags          []string    `json:"tags"`
+		Port          interface{} `json:"port"`
+		MinVersion    string      `json:"minversion"`
+		Compose       string      `json:"compose"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode service templates: %w", err)
+	}
+
+	templates := make([]ServiceTemplate, 0, len(raw))
+	for name, t := range raw {
+		template := ServiceTemplate{
+			Name:          name,
+			Slogan:        t.Slogan,
+			Documentation: t.Documentation,
+			Tags:          t.Tags,
+			MinVersion:    t.MinVersion,
+		}
+		if t.Port != nil {
+			template.Port = fmt.Sprint(t.Port)
+		}
+		if compose, err := base64.StdEncoding.DecodeString(t.Compose); err == nil {
+			template.Compose = string(compose)
+			template.EnvVars = parseTemplateEnvVars(template.Compose)
+		}
+		templates = append(templates, template)
+	}
+
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// GetServiceTemplate returns a single service template by name
+func GetServiceTemplate(ctx context.Context, name string) (*ServiceTemplate, error) {
+	templates, err := ListServiceTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range templates {
+		if templates[i].Name == name {
+			return &templates[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("service template '%s' not found", name)
+}
+
+// parseTemplateEnvVars extracts the environment variables referenced by a compose file.
+// Variables prefixed with SERVICE_ are generated by Coolify when the service is created.
+func parseTemplateEnvVars(compose string) []TemplateEnvVar {
+	seen := make(map[string]bool)
+	var vars []TemplateEnvVar
+	for _, match := range templateEnvPattern.FindAllStringSubmatch(compose, -1) {
+		name := match[1]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		vars = append(vars, TemplateEnvVar{
+			Name:      name,
+			Default:   match[2],
+			Generated: strings.HasPrefix(name, "SERVICE_"),
+		})
+	}
+
+	sort.Slice(vars, func(i, j int) bool { return vars[i].Name < vars[j].Name })
+	return vars
+}
+
 // DeploymentsClient handles deployment-related operations
 type DeploymentsClient struct {
 	client *Client
@@ -1156,11 +1800,22 @@ type DeploymentsClient struct {
 
 // DeployApplicationOptions contains options for deploying an application
 type DeployApplicationOptions struct {
+	// ForceRebuild builds the application without the build cache (Coolify's force parameter)
+	ForceRebuild bool
+	// Force is the former name of ForceRebuild.
+	//
+	// Deprecated: use ForceRebuild. Force never skipped anything but the build cache.
 	Force  bool
 	Branch string
 	PR     *int
 }
 
+// forceRebuild returns the force parameter of the deploy endpoint
+func (o *DeployApplicationOptions) forceRebuild() *bool {
+	force := o.ForceRebuild || o.Force
+	return &force
+}
+
 // DeploymentResult contains information about a triggered deployment
 type DeploymentResult struct {
 	Message        string `json:"message"`
@@ -1190,11 +1845,11 @@ type StopResponse struct {
 	Message string `json:"message"`
 }
 
-// DeployApplication deploys an application by UUID
-func (dc *DeploymentsClient) DeployApplication(ctx context.Context, uuidStr string, force bool, branch string) (*DeployResponse, error) {
+// DeployApplication deploys an application by UUID, without the build cache if forceRebuild is set
+func (dc *DeploymentsClient) DeployApplication(ctx context.Context, uuidStr string, forceRebuild bool, branch string) (*DeployResponse, error) {
 	return dc.DeployApplicationWithOptions(ctx, uuidStr, &DeployApplicationOptions{
-		Force:  force,
-		Branch: branch,
+		ForceRebuild: forceRebuild,
+		Branch:       branch,
 	})
 }
 
@@ -1202,7 +1857,7 @@ func (dc *DeploymentsClient) DeployApplication(ctx context.Context, uuidStr stri
 func (dc *DeploymentsClient) DeployApplicationWithOptions(ctx context.Context, uuidStr string, options *DeployApplicationOptions) (*DeployResponse, error) {
 	params := &coolify.DeployByTagOrUuidParams{
 		Uuid:  &uuidStr,
-		Force: &options.Force,
+		Force: options.forceRebuild(),
 	}
 
 	// Branch and PR are mutually exclusive
@@ -1226,12 +1881,15 @@ func (dc *DeploymentsClient) DeployApplicationWithOptions(ctx context.Context, u
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil || resp.JSON200.Deployments == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := dc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	// Convert the response to our struct
 	result := &DeployResponse{
@@ -1273,32 +1931,26 @@ func (dc *DeploymentsClient) List(ctx context.Context, appUUIDStr string) ([]coo
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := dc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return *resp.JSON200, nil
 }
 
 // ListAll returns all deployments
 func (dc *DeploymentsClient) ListAll(ctx context.Context) ([]coolify.ApplicationDeploymentQueue, error) {
-	resp, err := dc.client.API.ListDeploymentsWithResponse(ctx)
+	resp, err := dc.client.API.ListDeployments(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list deployments: %w", err)
 	}
-
-	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
-	}
-
-	if resp.JSON200 == nil {
-		return nil, fmt.Errorf("empty response body")
-	}
-
-	return *resp.JSON200, nil
+	return streamList[coolify.ApplicationDeploymentQueue](dc.client, resp)
 }
 
 // GetByUUID returns a deployment by UUID
@@ -1309,12 +1961,15 @@ func (dc *DeploymentsClient) GetByUUID(ctx context.Context, uuidStr string) (*co
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := dc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return resp.JSON200, nil
 }
@@ -1364,6 +2019,75 @@ func (dc *DeploymentsClient) Watch(ctx context.Context, uuidStr string) error {
 	}
 }
 
+// DeploymentSucceeded reports whether a deployment status marks a successful deployment
+func DeploymentSucceeded(status string) bool {
+	return lite.DeploymentSucceeded(status)
+}
+
+// DeploymentFailed reports whether a deployment status marks a failed deployment
+func DeploymentFailed(status string) bool {
+	return lite.DeploymentFailed(status)
+}
+
+// DeploymentPending reports whether a deployment status marks a queued or running deployment
+func DeploymentPending(status string) bool {
+	return lite.DeploymentPending(status)
+}
+
+// Cancel cancels a queued or running deployment. The endpoint is not part of the Coolify API
+// specification; servers without it report an *UnsupportedError.
+func (dc *DeploymentsClient) Cancel(ctx context.Context, deploymentUUID string) error {
+	if err := dc.client.require(CapabilityDeploymentCancel); err != nil {
+		return err
+	}
+
+	err := dc.client.doRequest(ctx, http.MethodPost, "/deployments/"+url.PathEscape(deploymentUUID)+"/cancel", nil, nil)
+	err = dc.client.learn(ctx, CapabilityDeploymentCancel, err, func(ctx context.Context) bool {
+		_, err := dc.GetByUUID(ctx, deploymentUUID)
+		return err == nil
+	})
+	var unsupported *UnsupportedError
+	if err != nil && !errors.As(err, &unsupported) {
+		return fmt.Errorf("failed to cancel deployment: %w", err)
+	}
+	return err
+}
+
+// Wait polls a deployment until it succeeds or fails and returns its final state.
+// onStatus, if set, is called whenever the deployment status changes.
+func (dc *DeploymentsClient) Wait(ctx context.Context, uuidStr string, interval time.Duration, onStatus func(status string)) (*coolify.ApplicationDeploymentQueue, error) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	lastStatus := ""
+	for {
+		deployment, err := dc.GetByUUID(ctx, uuidStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment status: %w", err)
+		}
+
+		status := ""
+		if deployment.Status != nil {
+			status = *deployment.Status
+		}
+		if status != lastStatus && onStatus != nil {
+			onStatus(status)
+		}
+		lastStatus = status
+
+		if DeploymentSucceeded(status) || DeploymentFailed(status) {
+			return deployment, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return deployment, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
 // DeployMultiple deploys multiple applications by their UUIDs
 func (dc *DeploymentsClient) DeployMultiple(ctx context.Context, uuids []string, options *DeployApplicationOptions) (*DeployResponse, error) {
 	if len(uuids) == 0 {
@@ -1375,7 +2099,7 @@ func (dc *DeploymentsClient) DeployMultiple(ctx context.Context, uuids []string,
 
 	params := &coolify.DeployByTagOrUuidParams{
 		Uuid:  &uuidList,
-		Force: &options.Force,
+		Force: options.forceRebuild(),
 	}
 
 	// If branch is specified, we need to deploy from a specific tag/branch
@@ -1394,12 +2118,15 @@ func (dc *DeploymentsClient) DeployMultiple(ctx context.Context, uuids []string,
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil || resp.JSON200.Deployments == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := dc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	// Convert the response to our struct
 	result := &DeployResponse{
@@ -1444,16 +2171,108 @@ func (dc *DeploymentsClient) ListWithPagination(ctx context.Context, appUUIDStr
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := dc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return *resp.JSON200, nil
 }
 
+// Latest returns the most recent deployment of an application, or nil if it has never been deployed
+func (dc *DeploymentsClient) Latest(ctx context.Context, appUUIDStr string) (*coolify.ApplicationDeploymentQueue, error) {
+	deployments, err := dc.History(ctx, appUUIDStr, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deployments) == 0 {
+		return nil, nil
+	}
+	return &deployments[0], nil
+}
+
+// History returns up to take of the most recent deployments of an application, newest first
+func (dc *DeploymentsClient) History(ctx context.Context, appUUIDStr string, take int) ([]coolify.ApplicationDeploymentQueue, error) {
+	return dc.Page(ctx, appUUIDStr, 0, take)
+}
+
+// ListIter returns a pager over the full deployment history of an application, newest first.
+// A pageSize of 0 or less uses DefaultPageSize.
+func (dc *DeploymentsClient) ListIter(appUUIDStr string, pageSize int) *Pager[coolify.ApplicationDeploymentQueue] {
+	return NewPager(func(ctx context.Context, skip, take int) ([]coolify.ApplicationDeploymentQueue, error) {
+		return dc.Page(ctx, appUUIDStr, skip, take)
+	}, pageSize)
+}
+
+// Page returns up to take deployments of an application, newest first, skipping the first skip.
+// Servers that cannot list the deployments of an application get the running deployments of
+// the application instead.
+func (dc *DeploymentsClient) Page(ctx context.Context, appUUIDStr string, skip, take int) ([]coolify.ApplicationDeploymentQueue, error) {
+	if _, err := uuid.Parse(appUUIDStr); err != nil {
+		return nil, fmt.Errorf("invalid UUID: %w", err)
+	}
+	if err := dc.client.require(CapabilityApplicationDeployments); err != nil {
+		return dc.runningPage(ctx, appUUIDStr, skip, take)
+	}
+
+	// The API wraps the list in {"count": n, "deployments": [...]}, older versions return a bare array
+	var raw json.RawMessage
+	path := fmt.Sprintf("/deployments/applications/%s?skip=%d&take=%d", appUUIDStr, skip, take)
+	err := dc.client.doRequest(ctx, http.MethodGet, path, nil, &raw)
+	err = dc.client.learn(ctx, CapabilityApplicationDeployments, err, func(ctx context.Context) bool {
+		_, err := dc.client.Applications().Get(ctx, appUUIDStr)
+		return err == nil
+	})
+	var unsupported *UnsupportedError
+	if errors.As(err, &unsupported) {
+		return dc.runningPage(ctx, appUUIDStr, skip, take)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	var deployments []coolify.ApplicationDeploymentQueue
+	var wrapped struct {
+		Deployments []coolify.ApplicationDeploymentQueue `json:"deployments"`
+	}
+	if err := json.Unmarshal(raw, &wrapped); err == nil {
+		deployments = wrapped.Deployments
+	} else if err := json.Unmarshal(raw, &deployments); err != nil {
+		return nil, fmt.Errorf("failed to decode deployments: %w", err)
+	}
+	return deployments, nil
+}
+
+// runningPage returns a page of the running deployments of an application, the fallback for
+// servers that cannot list the deployments of an application
+func (dc *DeploymentsClient) runningPage(ctx context.Context, appUUIDStr string, skip, take int) ([]coolify.ApplicationDeploymentQueue, error) {
+	app, err := dc.client.Applications().Get(ctx, appUUIDStr)
+	if err != nil {
+		return nil, err
+	}
+	all, err := dc.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var deployments []coolify.ApplicationDeploymentQueue
+	for _, deployment := range all {
+		if app.Id != nil && deployment.ApplicationId != nil && *deployment.ApplicationId == strconv.Itoa(*app.Id) {
+			deployments = append(deployments, deployment)
+		}
+	}
+	if skip >= len(deployments) {
+		return nil, nil
+	}
+	return deployments[skip:min(skip+take, len(deployments))], nil
+}
+
 // DatabasesClient handles database-related operations
 type DatabasesClient struct {
 	client *Client
@@ -1467,12 +2286,15 @@ func (dc *DatabasesClient) List(ctx context.Context) (string, error) {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := dc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON200, nil
 }
@@ -1490,12 +2312,15 @@ func (dc *DatabasesClient) Get(ctx context.Context, uuidStr string) (string, err
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := dc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON200, nil
 }
@@ -1513,7 +2338,7 @@ func (dc *DatabasesClient) Start(ctx context.Context, uuidStr string) error {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	return nil
@@ -1532,7 +2357,7 @@ func (dc *DatabasesClient) Stop(ctx context.Context, uuidStr string) error {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	return nil
@@ -1551,7 +2376,7 @@ func (dc *DatabasesClient) Restart(ctx context.Context, uuidStr string) error {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	return nil
@@ -1570,7 +2395,7 @@ func (dc *DatabasesClient) Delete(ctx context.Context, uuidStr string, options *
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	return nil
@@ -1589,122 +2414,183 @@ func (dc *DatabasesClient) Update(ctx context.Context, uuidStr string, req cooli
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	return nil
 }
 
-// CreatePostgreSQL creates a new PostgreSQL database
-func (dc *DatabasesClient) CreatePostgreSQL(ctx context.Context, req coolify.CreateDatabasePostgresqlJSONRequestBody) error {
+// parseCreatedUUID extracts the UUID from a create response body.
+// Database create endpoints are not typed in the OpenAPI spec, so the body is decoded manually.
+func parseCreatedUUID(body []byte) string {
+	var created struct {
+		UUID string `json:"uuid"`
+	}
+	if err := json.Unmarshal(body, &created); err != nil {
+		return ""
+	}
+	return created.UUID
+}
+
+// CreatePostgreSQL creates a new PostgreSQL database and returns its UUID
+func (dc *DatabasesClient) CreatePostgreSQL(ctx context.Context, req coolify.CreateDatabasePostgresqlJSONRequestBody) (string, error) {
 	resp, err := dc.client.API.CreateDatabasePostgresqlWithResponse(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to create PostgreSQL database: %w", err)
+		return "", fmt.Errorf("failed to create PostgreSQL database: %w", err)
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
-	return nil
+	return parseCreatedUUID(resp.Body), nil
 }
 
-// CreateMySQL creates a new MySQL database
-func (dc *DatabasesClient) CreateMySQL(ctx context.Context, req coolify.CreateDatabaseMysqlJSONRequestBody) error {
+// CreateMySQL creates a new MySQL database and returns its UUID
+func (dc *DatabasesClient) CreateMySQL(ctx context.Context, req coolify.CreateDatabaseMysqlJSONRequestBody) (string, error) {
 	resp, err := dc.client.API.CreateDatabaseMysqlWithResponse(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to create MySQL database: %w", err)
+		return "", fmt.Errorf("failed to create MySQL database: %w", err)
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
-	return nil
+	return parseCreatedUUID(resp.Body), nil
 }
 
-// CreateRedis creates a new Redis database
-func (dc *DatabasesClient) CreateRedis(ctx context.Context, req coolify.CreateDatabaseRedisJSONRequestBody) error {
+// CreateRedis creates a new Redis database and returns its UUID
+func (dc *DatabasesClient) CreateRedis(ctx context.Context, req coolify.CreateDatabaseRedisJSONRequestBody) (string, error) {
 	resp, err := dc.client.API.CreateDatabaseRedisWithResponse(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to create Redis database: %w", err)
+		return "", fmt.Errorf("failed to create Redis database: %w", err)
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
-	return nil
+	return parseCreatedUUID(resp.Body), nil
 }
 
-// CreateMongoDB creates a new MongoDB database
-func (dc *DatabasesClient) CreateMongoDB(ctx context.Context, req coolify.CreateDatabaseMongodbJSONRequestBody) error {
+// CreateMongoDB creates a new MongoDB database and returns its UUID
+func (dc *DatabasesClient) CreateMongoDB(ctx context.Context, req coolify.CreateDatabaseMongodbJSONRequestBody) (string, error) {
 	resp, err := dc.client.API.CreateDatabaseMongodbWithResponse(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to create MongoDB database: %w", err)
+		return "", fmt.Errorf("failed to create MongoDB database: %w", err)
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
-	return nil
+	return parseCreatedUUID(resp.Body), nil
 }
 
-// CreateClickHouse creates a new ClickHouse database
-func (dc *DatabasesClient) CreateClickHouse(ctx context.Context, req coolify.CreateDatabaseClickhouseJSONRequestBody) error {
+// CreateClickHouse creates a new ClickHouse database and returns its UUID
+func (dc *DatabasesClient) CreateClickHouse(ctx context.Context, req coolify.CreateDatabaseClickhouseJSONRequestBody) (string, error) {
 	resp, err := dc.client.API.CreateDatabaseClickhouseWithResponse(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to create ClickHouse database: %w", err)
+		return "", fmt.Errorf("failed to create ClickHouse database: %w", err)
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
-	return nil
+	return parseCreatedUUID(resp.Body), nil
 }
 
-// CreateDragonfly creates a new Dragonfly database
-func (dc *DatabasesClient) CreateDragonfly(ctx context.Context, req coolify.CreateDatabaseDragonflyJSONRequestBody) error {
+// CreateDragonfly creates a new Dragonfly database and returns its UUID
+func (dc *DatabasesClient) CreateDragonfly(ctx context.Context, req coolify.CreateDatabaseDragonflyJSONRequestBody) (string, error) {
 	resp, err := dc.client.API.CreateDatabaseDragonflyWithResponse(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to create Dragonfly database: %w", err)
+		return "", fmt.Errorf("failed to create Dragonfly database: %w", err)
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
-	return nil
+	return parseCreatedUUID(resp.Body), nil
 }
 
-// CreateKeyDB creates a new KeyDB database
-func (dc *DatabasesClient) CreateKeyDB(ctx context.Context, req coolify.CreateDatabaseKeydbJSONRequestBody) error {
+// CreateKeyDB creates a new KeyDB database and returns its UUID
+func (dc *DatabasesClient) CreateKeyDB(ctx context.Context, req coolify.CreateDatabaseKeydbJSONRequestBody) (string, error) {
 	resp, err := dc.client.API.CreateDatabaseKeydbWithResponse(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to create KeyDB database: %w", err)
+		return "", fmt.Errorf("failed to create KeyDB database: %w", err)
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
-	return nil
+	return parseCreatedUUID(resp.Body), nil
 }
 
-// CreateMariaDB creates a new MariaDB database
-func (dc *DatabasesClient) CreateMariaDB(ctx context.Context, req coolify.CreateDatabaseMariadbJSONRequestBody) error {
+// CreateMariaDB creates a new MariaDB database and returns its UUID
+func (dc *DatabasesClient) CreateMariaDB(ctx context.Context, req coolify.CreateDatabaseMariadbJSONRequestBody) (string, error) {
 	resp, err := dc.client.API.CreateDatabaseMariadbWithResponse(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to create MariaDB database: %w", err)
+		return "", fmt.Errorf("failed to create MariaDB database: %w", err)
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
-	return nil
+	return parseCreatedUUID(resp.Body), nil
+}
+
+// SourcesClient handles git source (GitHub App) operations
+type SourcesClient struct {
+	client *Client
+}
+
+// GitHubApp represents a GitHub App source configured in Coolify
+type GitHubApp struct {
+	ID             int    `json:"id"`
+	UUID           string `json:"uuid"`
+	Name           string `json:"name"`
+	Organization   string `json:"organization,omitempty"`
+	APIURL         string `json:"api_url,omitempty"`
+	HTMLURL        string `json:"html_url,omitempty"`
+	CustomUser     string `json:"custom_user,omitempty"`
+	CustomPort     int    `json:"custom_port,omitempty"`
+	AppID          int    `json:"app_id,omitempty"`
+	InstallationID int    `json:"installation_id,omitempty"`
+	ClientID       string `json:"client_id,omitempty"`
+	IsSystemWide   bool   `json:"is_system_wide"`
+	IsPublic       bool   `json:"is_public"`
+}
+
+// List returns all GitHub App sources. The endpoint is not part of the Coolify API
+// specification; servers without it report an *UnsupportedEndpointError.
+func (sc *SourcesClient) List(ctx context.Context) ([]GitHubApp, error) {
+	var apps []GitHubApp
+	if err := sc.client.doOptionalRequest(ctx, "Listing GitHub App sources", http.MethodGet, "/github-apps", nil, &apps); err != nil {
+		return nil, fmt.Errorf("failed to list sources: %w", err)
+	}
+
+	return apps, nil
+}
+
+// Get returns a GitHub App source by UUID
+func (sc *SourcesClient) Get(ctx context.Context, uuidStr string) (*GitHubApp, error) {
+	apps, err := sc.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range apps {
+		if apps[i].UUID == uuidStr {
+			return &apps[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("source '%s' not found", uuidStr)
 }
 
 // TeamsClient handles team-related operations
@@ -1720,12 +2606,15 @@ func (tc *TeamsClient) List(ctx context.Context) ([]coolify.Team, error) {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := tc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return *resp.JSON200, nil
 }
@@ -1738,12 +2627,15 @@ func (tc *TeamsClient) Get(ctx context.Context, teamID int) (*coolify.Team, erro
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := tc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return resp.JSON200, nil
 }
@@ -1756,12 +2648,15 @@ func (tc *TeamsClient) GetMembers(ctx context.Context, teamID int) ([]coolify.Us
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := tc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return *resp.JSON200, nil
 }
@@ -1774,12 +2669,15 @@ func (tc *TeamsClient) GetCurrent(ctx context.Context) (*coolify.Team, error) {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := tc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return resp.JSON200, nil
 }
@@ -1792,12 +2690,15 @@ func (tc *TeamsClient) GetCurrentMembers(ctx context.Context) ([]coolify.User, e
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := tc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return *resp.JSON200, nil
 }
@@ -1815,12 +2716,15 @@ func (sc *SystemClient) Version(ctx context.Context) (string, error) {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON200, nil
 }
@@ -1833,12 +2737,15 @@ func (sc *SystemClient) Healthcheck(ctx context.Context) (string, error) {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON200, nil
 }
@@ -1851,12 +2758,15 @@ func (sc *SystemClient) EnableAPI(ctx context.Context) (string, error) {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil || resp.JSON200.Message == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON200.Message, nil
 }
@@ -1869,16 +2779,73 @@ func (sc *SystemClient) DisableAPI(ctx context.Context) (string, error) {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil || resp.JSON200.Message == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := sc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON200.Message, nil
 }
 
+// MessageResponse is a generic API response carrying only a message
+type MessageResponse struct {
+	Message string `json:"message"`
+}
+
+// InstanceSettings holds the instance-wide settings of a Coolify installation. The upgrade,
+// settings and cleanup endpoints are not part of the Coolify API specification; servers without
+// them report an *UnsupportedEndpointError.
+type InstanceSettings map[string]interface{}
+
+// Upgrade triggers an upgrade of the Coolify instance to the latest available version
+func (sc *SystemClient) Upgrade(ctx context.Context) (string, error) {
+	var result MessageResponse
+	if err := sc.client.doOptionalRequest(ctx, "Upgrading the instance", http.MethodPost, "/upgrade", nil, &result); err != nil {
+		return "", fmt.Errorf("failed to upgrade instance: %w", err)
+	}
+
+	return result.Message, nil
+}
+
+// GetSettings returns the instance settings
+func (sc *SystemClient) GetSettings(ctx context.Context) (InstanceSettings, error) {
+	var settings InstanceSettings
+	if err := sc.client.doOptionalRequest(ctx, "Reading instance settings", http.MethodGet, "/settings", nil, &settings); err != nil {
+		return nil, fmt.Errorf("failed to get instance settings: %w", err)
+	}
+
+	if settings == nil {
+		return nil, fmt.Errorf("empty response body")
+	}
+
+	return settings, nil
+}
+
+// UpdateSettings updates the given instance settings
+func (sc *SystemClient) UpdateSettings(ctx context.Context, settings InstanceSettings) (string, error) {
+	var result MessageResponse
+	if err := sc.client.doOptionalRequest(ctx, "Changing instance settings", http.MethodPatch, "/settings", settings, &result); err != nil {
+		return "", fmt.Errorf("failed to update instance settings: %w", err)
+	}
+
+	return result.Message, nil
+}
+
+// Cleanup removes unused docker images, containers and build caches on all servers
+func (sc *SystemClient) Cleanup(ctx context.Context) (string, error) {
+	var result MessageResponse
+	if err := sc.client.doOptionalRequest(ctx, "Cleaning up the instance", http.MethodPost, "/cleanup", nil, &result); err != nil {
+		return "", fmt.Errorf("failed to clean up instance: %w", err)
+	}
+
+	return result.Message, nil
+}
+
 // PrivateKeysClient handles private key-related operations
 type PrivateKeysClient struct {
 	client *Client
@@ -1892,12 +2859,15 @@ func (pkc *PrivateKeysClient) List(ctx context.Context) ([]coolify.PrivateKey, e
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := pkc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return *resp.JSON200, nil
 }
@@ -1910,12 +2880,15 @@ func (pkc *PrivateKeysClient) Create(ctx context.Context, req coolify.CreatePriv
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON201 == nil || resp.JSON201.Uuid == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := pkc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON201.Uuid, nil
 }
@@ -1928,12 +2901,15 @@ func (pkc *PrivateKeysClient) Get(ctx context.Context, uuidStr string) (*coolify
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("API error: %s", resp.Status())
+		return nil, apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON200 == nil {
 		return nil, fmt.Errorf("empty response body")
 	}
+	if err := pkc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return nil, err
+	}
 
 	return resp.JSON200, nil
 }
@@ -1946,12 +2922,15 @@ func (pkc *PrivateKeysClient) Update(ctx context.Context, req coolify.UpdatePriv
 	}
 
 	if resp.StatusCode() != http.StatusCreated {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	if resp.JSON201 == nil || resp.JSON201.Uuid == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := pkc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON201); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON201.Uuid, nil
 }
@@ -1964,7 +2943,7 @@ func (pkc *PrivateKeysClient) Delete(ctx context.Context, uuidStr string) error
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return fmt.Errorf("API error: %s", resp.Status())
+		return apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	return nil
@@ -1983,13 +2962,16 @@ func (rc *ResourcesClient) List(ctx context.Context) (string, error) {
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf("API error: %s", resp.Status())
+		return "", apiError(resp.HTTPResponse, resp.Body)
 	}
 
 	// Note: API returns string according to OpenAPI spec
 	if resp.JSON200 == nil {
 		return "", fmt.Errorf("empty response body")
 	}
+	if err := rc.client.checkDecode(resp.HTTPResponse, resp.Body, resp.JSON200); err != nil {
+		return "", err
+	}
 
 	return *resp.JSON200, nil
 }
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/hongkongkiwi/coolifyme/internal/logger"
+)
+
+// cachedResponse holds a response body that can be revalidated with a conditional request
+type cachedResponse struct {
+	etag         string
+	lastModified string
+	header       http.Header
+	body         []byte
+}
+
+// conditionalTransport issues conditional GET requests (If-None-Match / If-Modified-Since) for
+// responses that carried an ETag or Last-Modified header, and reuses the cached body on 304.
+// This keeps repeated listings in watch and monitor loops cheap.
+type conditionalTransport struct {
+	base  http.RoundTripper
+	mu    sync.Mutex
+	cache map[string]*cachedResponse
+}
+
+func newConditionalTransport(base http.RoundTripper) *conditionalTransport {
+	return &conditionalTransport{
+		base:  base,
+		cache: make(map[string]*cachedResponse),
+	}
+}
+
+func (t *conditionalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		resp, err := t.base.RoundTrip(req)
+		if err == nil && resp.StatusCode < http.StatusBadRequest {
+			// Any change may affect cached listings
+			t.mu.Lock()
+			t.cache = make(map[string]*cachedResponse)
+			t.mu.Unlock()
+		}
+		return resp, err
+	}
+
+	key := req.URL.String()
+	t.mu.Lock()
+	cached := t.cache[key]
+	t.mu.Unlock()
+
+	if cached != nil && req.Header.Get("If-None-Match") == "" && req.Header.Get("If-Modified-Since") == "" {
+		req = req.Clone(req.Context())
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		_ = resp.Body.Close()
+		logger.Debug("API Response served from cache", "url", scrubURL(req.URL))
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        cached.header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(cached.body)),
+			ContentLength: int64(len(cached.body)),
+			Request:       req,
+		}, nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || (etag == "" && lastModified == "") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.mu.Lock()
+	t.cache[key] = &cachedResponse{
+		etag:         etag,
+		lastModified: lastModified,
+		header:       resp.Header.Clone(),
+		body:         body,
+	}
+	t.mu.Unlock()
+
+	return resp, nil
+}
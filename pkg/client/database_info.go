@@ -0,0 +1,187 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DatabaseInfo holds the type-independent details of a database, decoded from the per-type
+// fields returned by the database API (postgres_user, mysql_password, redis_password, ...)
+type DatabaseInfo struct {
+	UUID       string `json:"uuid"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	Image      string `json:"image"`
+	IsPublic   bool   `json:"is_public"`
+	PublicPort int    `json:"public_port,omitempty"`
+	User       string `json:"user,omitempty"`
+	Password   string `json:"-"`
+	Database   string `json:"database,omitempty"`
+	ServerIP   string `json:"server_ip,omitempty"`
+	ServerUUID string `json:"server_uuid,omitempty"`
+	// EnvironmentID is the ID of the project environment the database belongs to
+	EnvironmentID int `json:"environment_id,omitempty"`
+}
+
+// databaseCredentialFields lists the API fields holding the user, password and database name of each type
+var databaseCredentialFields = map[string][3]string{
+	"postgresql": {"postgres_user", "postgres_password", "postgres_db"},
+	"mysql":      {"mysql_user", "mysql_password", "mysql_database"},
+	"mariadb":    {"mariadb_user", "mariadb_password", "mariadb_database"},
+	"mongodb":    {"mongo_initdb_root_username", "mongo_initdb_root_password", "mongo_initdb_database"},
+	"redis":      {"redis_username", "redis_password", ""},
+	"keydb":      {"", "keydb_password", ""},
+	"dragonfly":  {"", "dragonfly_password", ""},
+	"clickhouse": {"clickhouse_admin_user", "clickhouse_admin_password", ""},
+}
+
+// databaseDefaults holds the URL scheme and internal port of each database type
+var databaseDefaults = map[string]struct {
+	scheme string
+	port   int
+}{
+	"postgresql": {"postgres", 5432},
+	"mysql":      {"mysql", 3306},
+	"mariadb":    {"mysql", 3306},
+	"mongodb":    {"mongodb", 27017},
+	"redis":      {"redis", 6379},
+	"keydb":      {"redis", 6379},
+	"dragonfly":  {"redis", 6379},
+	"clickhouse": {"clickhouse", 9000},
+}
+
+// ParseDatabase decodes the raw JSON returned by DatabasesClient.Get
+func ParseDatabase(raw string) (*DatabaseInfo, error) {
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse database: %w", err)
+	}
+	return parseDatabaseFields(fields), nil
+}
+
+// ParseDatabases decodes the raw JSON array returned by DatabasesClient.List
+func ParseDatabases(raw string) ([]DatabaseInfo, error) {
+	var list []map[string]any
+	if err := json.Unmarshal([]byte(raw), &list); err != nil {
+		return nil, fmt.Errorf("failed to parse databases: %w", err)
+	}
+
+	databases := make([]DatabaseInfo, 0, len(list))
+	for _, fields := range list {
+		databases = append(databases, *parseDatabaseFields(fields))
+	}
+	return databases, nil
+}
+
+// parseDatabaseFields builds a DatabaseInfo from the decoded fields of a single database
+func parseDatabaseFields(fields map[string]any) *DatabaseInfo {
+	str := func(key string) string {
+		if key == "" {
+			return ""
+		}
+		switch v := fields[key].(type) {
+		case string:
+			return v
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		return ""
+	}
+
+	info := &DatabaseInfo{
+		UUID:   str("uuid"),
+		Name:   str("name"),
+		Status: str("status"),
+		Image:  str("image"),
+	}
+	info.Type = databaseType(str("database_type"), fields)
+	info.IsPublic, _ = fields["is_public"].(bool)
+	info.PublicPort, _ = strconv.Atoi(str("public_port"))
+	info.EnvironmentID, _ = strconv.Atoi(str("environment_id"))
+
+	if credentials, ok := databaseCredentialFields[info.Type]; ok {
+		info.User = str(credentials[0])
+		info.Password = str(credentials[1])
+		info.Database = str(credentials[2])
+	}
+
+	if destination, ok := fields["destination"].(map[string]any); ok {
+		if server, ok := destination["server"].(map[string]any); ok {
+			info.ServerIP, _ = server["ip"].(string)
+			info.ServerUUID, _ = server["uuid"].(string)
+		}
+	}
+	return info
+}
+
+// databaseType normalizes the database_type field ("standalone-postgresql") to a short type
+// name, falling back to the credential fields present for older API versions
+func databaseType(raw string, fields map[string]any) string {
+	if raw != "" {
+		return strings.TrimPrefix(strings.ToLower(raw), "standalone-")
+	}
+	for dbType, credentials := range databaseCredentialFields {
+		if _, ok := fields[credentials[1]]; ok {
+			return dbType
+		}
+	}
+	return ""
+}
+
+// InternalPort returns the port the database listens on inside its container, or 0 for unknown types
+func (d *DatabaseInfo) InternalPort() int {
+	return databaseDefaults[d.Type].port
+}
+
+// ConnectionString returns a DSN for the database. The internal DSN uses the container name
+// and is reachable from other resources on the same Docker network; the public DSN uses the
+// server IP (or fallbackHost when the IP is unusable from outside) and the public port.
+func (d *DatabaseInfo) ConnectionString(public bool, fallbackHost string) (string, error) {
+	defaults, ok := databaseDefaults[d.Type]
+	if !ok {
+		return "", fmt.Errorf("connection strings are not supported for database type '%s'", d.Type)
+	}
+
+	host, port := d.UUID, defaults.port
+	if public {
+		if !d.IsPublic || d.PublicPort == 0 {
+			return "", fmt.Errorf("database %s is not publicly accessible, make it public first", d.Name)
+		}
+		host, port = d.ServerIP, d.PublicPort
+		if host == "" || host == "host.docker.internal" || net.ParseIP(host).IsLoopback() {
+			host = fallbackHost
+		}
+		if host == "" {
+			return "", fmt.Errorf("cannot determine the public host of database %s", d.Name)
+		}
+	}
+
+	dsn := url.URL{
+		Scheme: defaults.scheme,
+		Host:   net.JoinHostPort(host, strconv.Itoa(port)),
+	}
+
+	user := d.User
+	if user == "" && defaults.scheme == "redis" && d.Password != "" {
+		user = "default"
+	}
+	if user != "" {
+		dsn.User = url.UserPassword(user, d.Password)
+	}
+
+	switch defaults.scheme {
+	case "postgres", "mysql":
+		dsn.Path = "/" + d.Database
+	case "mongodb":
+		dsn.Path = "/" + d.Database
+		dsn.RawQuery = "authSource=admin"
+	case "redis":
+		dsn.Path = "/0"
+	}
+	return dsn.String(), nil
+}
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownFieldsError is returned in strict decoding mode when a response contains fields that
+// the API types of this client do not declare, which usually means the server is newer
+type UnknownFieldsError struct {
+	Method string
+	Path   string
+	// Fields are the JSON paths of the unknown fields, e.g. "destination.server_id" or "[].tags"
+	Fields []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("response of %s %s contains fields unknown to this client: %s", e.Method, e.Path, strings.Join(e.Fields, ", "))
+}
+
+// UnknownFields returns the JSON paths of the fields in data that are not declared by the type
+// of target. Types with their own JSON decoding, maps and interface values accept any field.
+func UnknownFields(data []byte, target any) ([]string, error) {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool)
+	collectUnknownFields(value, reflect.TypeOf(target), "", found)
+
+	fields := make([]string, 0, len(found))
+	for field := range found {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// collectUnknownFields walks a decoded JSON value alongside the Go type it is decoded into
+func collectUnknownFields(value any, typ reflect.Type, path string, found map[string]bool) {
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ == nil || reflect.PointerTo(typ).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		switch typ.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(typ)
+			for key, item := range v {
+				field, ok := fields[key]
+				if !ok {
+					// encoding/json falls back to case-insensitive matching
+					for name, candidate := range fields {
+						if strings.EqualFold(name, key) {
+							field, ok = candidate, true
+							break
+						}
+					}
+				}
+				if !ok {
+					found[joinFieldPath(path, key)] = true
+					continue
+				}
+				collectUnknownFields(item, field, joinFieldPath(path, key), found)
+			}
+		case reflect.Map:
+			for key, item := range v {
+				collectUnknownFields(item, typ.Elem(), joinFieldPath(path, key), found)
+			}
+		}
+	case []any:
+		if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+			for _, item := range v {
+				collectUnknownFields(item, typ.Elem(), path+"[]", found)
+			}
+		}
+	}
+}
+
+// jsonFields maps the JSON names of a struct's fields, including promoted ones, to their types
+func jsonFields(typ reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for promoted, promotedType := range jsonFields(embedded) {
+					if _, ok := fields[promoted]; !ok {
+						fields[promoted] = promotedType
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// joinFieldPath appends a key to a JSON field path
+func joinFieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// checkDecode reports response fields that were dropped while decoding into target. They are
+// logged at debug level, and fail the request when strict decoding is enabled. Requests that
+// change something have already succeeded on the server, so failing them would invite a retry
+// that repeats the change; their unknown fields are logged as a warning instead.
+func (c *Client) checkDecode(resp *http.Response, body []byte, target any) error {
+	// Decoding large listings a second time is only worth it when the result is used
+	if !c.strictDecode && (resp == nil || resp.Request == nil || !debugEnabled(resp.Request.Context(), c.logger)) {
+		return nil
+	}
+
+	fields, err := UnknownFields(body, target)
+	if err != nil || len(fields) == 0 {
+		// Malformed bodies are already reported by the generated response parser
+		return nil
+	}
+	return c.reportUnknownFields(resp, fields)
+}
+
+// reportUnknownFields logs or returns the unknown fields of a response as checkDecode describes
+func (c *Client) reportUnknownFields(resp *http.Response, fields []string) error {
+	unknownErr := &UnknownFieldsError{Fields: fields}
+	if resp != nil && resp.Request != nil {
+		unknownErr.Method = resp.Request.Method
+		unknownErr.Path = resp.Request.URL.Path
+	}
+	args := []any{
+		"method", unknownErr.Method,
+		"path", unknownErr.Path,
+		"fields", strings.Join(fields, ", "),
+	}
+
+	switch {
+	case !c.strictDecode:
+		c.debug("Unknown response fields", args...)
+	case RequiredAbility(unknownErr.Method, unknownErr.Path) != AbilityRead:
+		logWarn(c.logger, "Unknown response fields in a request that succeeded", args...)
+	default:
+		return unknownErr
+	}
+	return nil
+}
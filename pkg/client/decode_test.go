@@ -0,0 +1,73 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type decodeTestBase struct {
+	ID int `json:"id"`
+}
+
+type decodeTestItem struct {
+	decodeTestBase
+	Name   *string           `json:"name,omitempty"`
+	Tags   []decodeTestTag   `json:"tags,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Extra  json.RawMessage   `json:"extra,omitempty"`
+	Hidden string            `json:"-"`
+}
+
+type decodeTestTag struct {
+	Name string `json:"name"`
+}
+
+func TestUnknownFields(t *testing.T) {
+	data := []byte(`[{"id":1,"NAME":"web","tags":[{"name":"a","color":"red"}],"labels":{"x":"y"},"extra":{"any":1},"Hidden":"h","build_pack":"nixpacks"}]`)
+
+	fields, err := UnknownFields(data, &[]decodeTestItem{})
+	if err != nil {
+		t.Fatalf("UnknownFields() error = %v", err)
+	}
+	want := []string{"[].Hidden", "[].build_pack", "[].tags[].color"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("UnknownFields() = %v, want %v", fields, want)
+	}
+
+	if fields, _ := UnknownFields([]byte(`{"id":1}`), &decodeTestItem{}); len(fields) != 0 {
+		t.Errorf("UnknownFields() for known fields = %v", fields)
+	}
+}
+
+func TestCheckDecode(t *testing.T) {
+	resp := &http.Response{Request: &http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/api/v1/items"}}}
+	body := []byte(`{"id":1,"status":"running"}`)
+
+	c := &Client{}
+	if err := c.checkDecode(resp, body, &decodeTestItem{}); err != nil {
+		t.Errorf("checkDecode() without strict decoding = %v", err)
+	}
+
+	c.strictDecode = true
+	err := c.checkDecode(resp, body, &decodeTestItem{})
+	var unknownErr *UnknownFieldsError
+	if !errors.As(err, &unknownErr) {
+		t.Fatalf("checkDecode() = %v, want UnknownFieldsError", err)
+	}
+	if unknownErr.Path != "/api/v1/items" || !reflect.DeepEqual(unknownErr.Fields, []string{"status"}) {
+		t.Errorf("UnknownFieldsError = %+v", unknownErr)
+	}
+
+	// A create that succeeded on the server must not be reported as failed
+	c.logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	resp.Request.Method = http.MethodPost
+	if err := c.checkDecode(resp, body, &decodeTestItem{}); err != nil {
+		t.Errorf("checkDecode() for a POST = %v, want nil", err)
+	}
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ErrDeployKeyNotApplied is returned when Coolify accepted a deploy key change but the application
+// still uses its old key, which happens with versions that ignore the key field of updates
+var ErrDeployKeyNotApplied = errors.New("coolify did not change the deploy key, this version does not support changing it through the API")
+
+// SetDeployKey switches a deploy-key based application to another private key. The application
+// update endpoint does not document the key field, so the application is read back afterwards
+// and ErrDeployKeyNotApplied is returned when it still uses another key than keyID.
+func (ac *ApplicationsClient) SetDeployKey(ctx context.Context, uuidStr, keyUUID string, keyID int) error {
+	appUUID, err := uuid.Parse(uuidStr)
+	if err != nil {
+		return fmt.Errorf("invalid UUID: %w", err)
+	}
+
+	path := "/applications/" + appUUID.String()
+	if err := ac.client.doRequest(ctx, http.MethodPatch, path, map[string]any{"private_key_uuid": keyUUID}, nil); err != nil {
+		return fmt.Errorf("failed to change deploy key: %w", err)
+	}
+
+	var app struct {
+		PrivateKeyID *int `json:"private_key_id"`
+	}
+	if err := ac.client.doRequest(ctx, http.MethodGet, path, nil, &app); err != nil {
+		return fmt.Errorf("failed to change deploy key: %w", err)
+	}
+	if app.PrivateKeyID == nil || *app.PrivateKeyID != keyID {
+		return ErrDeployKeyNotApplied
+	}
+	return nil
+}
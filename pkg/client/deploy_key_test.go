@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetDeployKey(t *testing.T) {
+	const appUUID = "0d1e2f3a-4b5c-4d6e-8f70-8192a3b4c5d6"
+	privateKeyID := 1
+	ignoreKey := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/applications/"+appUUID {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodPatch {
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if len(body) != 1 || body["private_key_uuid"] != "key-2" {
+				t.Errorf("unexpected update body %v", body)
+			}
+			if !ignoreKey {
+				privateKeyID = 2
+			}
+			_, _ = w.Write([]byte(`{"uuid": "` + appUUID + `"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"uuid": appUUID, "private_key_id": privateKeyID})
+	}))
+	defer server.Close()
+
+	c, err := New(nil, WithBaseURL(server.URL), WithToken("token"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := c.Applications().SetDeployKey(context.Background(), appUUID, "key-2", 2); err != nil {
+		t.Fatalf("SetDeployKey() error = %v", err)
+	}
+
+	privateKeyID, ignoreKey = 1, true
+	if err := c.Applications().SetDeployKey(context.Background(), appUUID, "key-2", 2); !errors.Is(err, ErrDeployKeyNotApplied) {
+		t.Errorf("SetDeployKey() ignored by the server error = %v, want ErrDeployKeyNotApplied", err)
+	}
+}
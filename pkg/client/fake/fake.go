@@ -0,0 +1,449 @@
+// Package fake provides in-memory implementations of the pkg/client interfaces for tests of code
+// that talks to Coolify, so that it can be tested without an HTTP server:
+//
+//	c := fake.New()
+//	c.Apps.Items = []coolify.Application{{Uuid: ptr("app-1"), Name: ptr("web")}}
+//	err := restartAll(ctx, c) // accepts a client.API
+//	// c.Apps.Calls() == []string{"Restart app-1"}
+//
+// The resource fakes embed their interface, so calling a method a fake does not implement panics.
+// To fake such a method, embed the fake in a type of your own that adds it, or assign your own
+// implementation of the interface to the Client field.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/pkg/client"
+)
+
+// ErrNotFound is returned when a resource with the requested UUID does not exist
+var ErrNotFound = fmt.Errorf("API error: 404 Not Found")
+
+// Client implements client.API with the resource implementations in its fields. Fields left nil
+// make the matching method return a nil interface.
+type Client struct {
+	ApplicationsAPI client.ApplicationsAPI
+	DeploymentsAPI  client.DeploymentsAPI
+	ProjectsAPI     client.ProjectsAPI
+	ServersAPI      client.ServersAPI
+	ServicesAPI     client.ServicesAPI
+	DatabasesAPI    client.DatabasesAPI
+	PrivateKeysAPI  client.PrivateKeysAPI
+	ResourcesAPI    client.ResourcesAPI
+	TeamsAPI        client.TeamsAPI
+	SystemAPI       client.SystemAPI
+	SourcesAPI      client.SourcesAPI
+	TagsAPI         client.TagsAPI
+
+	// Apps, Deploys, Projs, Srvs and Svcs are the in-memory fakes created by New
+	Apps    *Applications
+	Deploys *Deployments
+	Projs   *Projects
+	Srvs    *Servers
+	Svcs    *Services
+}
+
+// New returns a client with empty in-memory fakes for applications, deployments, projects,
+// servers and services
+func New() *Client {
+	c := &Client{
+		Apps:    &Applications{},
+		Deploys: &Deployments{},
+		Projs:   &Projects{},
+		Srvs:    &Servers{},
+		Svcs:    &Services{},
+	}
+	c.ApplicationsAPI = c.Apps
+	c.DeploymentsAPI = c.Deploys
+	c.ProjectsAPI = c.Projs
+	c.ServersAPI = c.Srvs
+	c.ServicesAPI = c.Svcs
+	return c
+}
+
+// Applications returns the applications implementation
+func (c *Client) Applications() client.ApplicationsAPI { return c.ApplicationsAPI }
+
+// Deployments returns the deployments implementation
+func (c *Client) Deployments() client.DeploymentsAPI { return c.DeploymentsAPI }
+
+// Projects returns the projects implementation
+func (c *Client) Projects() client.ProjectsAPI { return c.ProjectsAPI }
+
+// Servers returns the servers implementation
+func (c *Client) Servers() client.ServersAPI { return c.ServersAPI }
+
+// Services returns the services implementation
+func (c *Client) Services() client.ServicesAPI { return c.ServicesAPI }
+
+// Databases returns the databases implementation
+func (c *Client) Databases() client.DatabasesAPI { return c.DatabasesAPI }
+
+// PrivateKeys returns the private keys implementation
+func (c *Client) PrivateKeys() client.PrivateKeysAPI { return c.PrivateKeysAPI }
+
+// Resources returns the resources implementation
+func (c *Client) Resources() client.ResourcesAPI { return c.ResourcesAPI }
+
+// Teams returns the teams implementation
+func (c *Client) Teams() client.TeamsAPI { return c.TeamsAPI }
+
+// System returns the system implementation
+func (c *Client) System() client.SystemAPI { return c.SystemAPI }
+
+// Sources returns the sources implementation
+func (c *Client) Sources() client.SourcesAPI { return c.SourcesAPI }
+
+// Tags returns the tags implementation
+func (c *Client) Tags() client.TagsAPI { return c.TagsAPI }
+
+// recorder records the calls made to a fake and holds the error the fake returns
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+	// Err is returned by every implemented method when set
+	Err error
+}
+
+// record notes a call and returns the configured error
+func (r *recorder) record(method string, args ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, strings.TrimSpace(method+" "+strings.Join(args, " ")))
+	return r.Err
+}
+
+// Calls returns the calls made so far as "Method arg..." strings, in the order they were made.
+// Concurrent calls are recorded in the order they reached the fake.
+func (r *recorder) Calls() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.calls...)
+}
+
+// SortedCalls returns the calls made so far in sorted order, for checking concurrent calls
+func (r *recorder) SortedCalls() []string {
+	calls := r.Calls()
+	sort.Strings(calls)
+	return calls
+}
+
+// uuidOf returns the value of an optional UUID field
+func uuidOf(uuid *string) string {
+	if uuid == nil {
+		return ""
+	}
+	return *uuid
+}
+
+// Applications is an in-memory client.ApplicationsAPI
+type Applications struct {
+	client.ApplicationsAPI
+	recorder
+	Items []coolify.Application
+	// Tags maps application UUIDs to their tags for ListByTag
+	Tags map[string][]string
+}
+
+// List returns all applications
+func (a *Applications) List(_ context.Context) ([]coolify.Application, error) {
+	if err := a.record("List"); err != nil {
+		return nil, err
+	}
+	return append([]coolify.Application(nil), a.Items...), nil
+}
+
+// ListByTag returns the applications with the given tag
+func (a *Applications) ListByTag(_ context.Context, tag string) ([]coolify.Application, error) {
+	if err := a.record("ListByTag", tag); err != nil {
+		return nil, err
+	}
+	var apps []coolify.Application
+	for _, app := range a.Items {
+		for _, appTag := range a.Tags[uuidOf(app.Uuid)] {
+			if appTag == tag {
+				apps = append(apps, app)
+				break
+			}
+		}
+	}
+	return apps, nil
+}
+
+// Get returns an application by UUID
+func (a *Applications) Get(_ context.Context, uuid string) (*coolify.Application, error) {
+	if err := a.record("Get", uuid); err != nil {
+		return nil, err
+	}
+	for _, app := range a.Items {
+		if uuidOf(app.Uuid) == uuid {
+			return &app, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Move moves an application to the target environment
+func (a *Applications) Move(_ context.Context, uuid string, target client.MoveTarget) error {
+	if err := a.record("Move", uuid, target.ProjectUUID, target.EnvironmentName); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i, app := range a.Items {
+		if uuidOf(app.Uuid) == uuid {
+			environmentID := target.EnvironmentID
+			a.Items[i].EnvironmentId = &environmentID
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+// Delete removes an application
+func (a *Applications) Delete(_ context.Context, uuid string, _ *coolify.DeleteApplicationByUuidParams) error {
+	if err := a.record("Delete", uuid); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i, app := range a.Items {
+		if uuidOf(app.Uuid) == uuid {
+			a.Items = append(a.Items[:i], a.Items[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+// Start records the start of an application
+func (a *Applications) Start(_ context.Context, uuid string, _ *coolify.StartApplicationByUuidParams) (*client.StartResponse, error) {
+	if err := a.record("Start", uuid); err != nil {
+		return nil, err
+	}
+	return &client.StartResponse{Message: "Deployment request queued."}, nil
+}
+
+// Stop records the stop of an application
+func (a *Applications) Stop(_ context.Context, uuid string) error {
+	return a.record("Stop", uuid)
+}
+
+// Restart records the restart of an application
+func (a *Applications) Restart(_ context.Context, uuid string) (*client.RestartResponse, error) {
+	if err := a.record("Restart", uuid); err != nil {
+		return nil, err
+	}
+	return &client.RestartResponse{Message: "Restart request queued."}, nil
+}
+
+// Deployments is an in-memory client.DeploymentsAPI
+type Deployments struct {
+	client.DeploymentsAPI
+	recorder
+	// Items are the deployments of all applications, newest first
+	Items []coolify.ApplicationDeploymentQueue
+}
+
+// ListAll returns all deployments
+func (d *Deployments) ListAll(_ context.Context) ([]coolify.ApplicationDeploymentQueue, error) {
+	if err := d.record("ListAll"); err != nil {
+		return nil, err
+	}
+	return append([]coolify.ApplicationDeploymentQueue(nil), d.Items...), nil
+}
+
+// GetByUUID returns a deployment by UUID
+func (d *Deployments) GetByUUID(_ context.Context, uuid string) (*coolify.ApplicationDeploymentQueue, error) {
+	if err := d.record("GetByUUID", uuid); err != nil {
+		return nil, err
+	}
+	for _, deployment := range d.Items {
+		if uuidOf(deployment.DeploymentUuid) == uuid {
+			return &deployment, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// History returns up to take deployments of an application, newest first
+func (d *Deployments) History(_ context.Context, appUUID string, take int) ([]coolify.ApplicationDeploymentQueue, error) {
+	if err := d.record("History", appUUID); err != nil {
+		return nil, err
+	}
+	var deployments []coolify.ApplicationDeploymentQueue
+	for _, deployment := range d.Items {
+		if uuidOf(deployment.ApplicationId) == appUUID && len(deployments) < take {
+			deployments = append(deployments, deployment)
+		}
+	}
+	return deployments, nil
+}
+
+// Latest returns the newest deployment of an application, or nil when it has none
+func (d *Deployments) Latest(ctx context.Context, appUUID string) (*coolify.ApplicationDeploymentQueue, error) {
+	deployments, err := d.History(ctx, appUUID, 1)
+	if err != nil || len(deployments) == 0 {
+		return nil, err
+	}
+	return &deployments[0], nil
+}
+
+// Projects is an in-memory client.ProjectsAPI
+type Projects struct {
+	client.ProjectsAPI
+	recorder
+	Items []coolify.Project
+}
+
+// List returns all projects
+func (p *Projects) List(_ context.Context) ([]coolify.Project, error) {
+	if err := p.record("List"); err != nil {
+		return nil, err
+	}
+	return append([]coolify.Project(nil), p.Items...), nil
+}
+
+// Get returns a project by UUID
+func (p *Projects) Get(_ context.Context, uuid string) (*coolify.Project, error) {
+	if err := p.record("Get", uuid); err != nil {
+		return nil, err
+	}
+	for _, project := range p.Items {
+		if uuidOf(project.Uuid) == uuid {
+			return &project, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Resolve returns a project by UUID or case-insensitive name
+func (p *Projects) Resolve(_ context.Context, nameOrUUID string) (*coolify.Project, error) {
+	if err := p.record("Resolve", nameOrUUID); err != nil {
+		return nil, err
+	}
+	for _, project := range p.Items {
+		if uuidOf(project.Uuid) == nameOrUUID || (project.Name != nil && strings.EqualFold(*project.Name, nameOrUUID)) {
+			return &project, nil
+		}
+	}
+	return nil, fmt.Errorf("project '%s' not found", nameOrUUID)
+}
+
+// Servers is an in-memory client.ServersAPI
+type Servers struct {
+	client.ServersAPI
+	recorder
+	Items []coolify.Server
+}
+
+// List returns all servers
+func (s *Servers) List(_ context.Context) ([]coolify.Server, error) {
+	if err := s.record("List"); err != nil {
+		return nil, err
+	}
+	return append([]coolify.Server(nil), s.Items...), nil
+}
+
+// Get returns a server by UUID
+func (s *Servers) Get(_ context.Context, uuid string) (*coolify.Server, error) {
+	if err := s.record("Get", uuid); err != nil {
+		return nil, err
+	}
+	for _, server := range s.Items {
+		if uuidOf(server.Uuid) == uuid {
+			return &server, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Services is an in-memory client.ServicesAPI
+type Services struct {
+	client.ServicesAPI
+	recorder
+	Items []coolify.Service
+	// ServiceComponents maps service UUIDs to their applications and databases
+	ServiceComponents map[string][]client.ServiceComponent
+}
+
+// List returns all services
+func (s *Services) List(_ context.Context) ([]coolify.Service, error) {
+	if err := s.record("List"); err != nil {
+		return nil, err
+	}
+	return append([]coolify.Service(nil), s.Items...), nil
+}
+
+// Get returns a service by UUID
+func (s *Services) Get(_ context.Context, uuid string) (*coolify.Service, error) {
+	if err := s.record("Get", uuid); err != nil {
+		return nil, err
+	}
+	for _, service := range s.Items {
+		if uuidOf(service.Uuid) == uuid {
+			return &service, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Move moves a service to the target environment
+func (s *Services) Move(_ context.Context, uuid string, target client.MoveTarget) error {
+	if err := s.record("Move", uuid, target.ProjectUUID, target.EnvironmentName); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, service := range s.Items {
+		if uuidOf(service.Uuid) == uuid {
+			environmentID := target.EnvironmentID
+			s.Items[i].EnvironmentId = &environmentID
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+// Start records the start of a service
+func (s *Services) Start(_ context.Context, uuid string) error {
+	return s.record("Start", uuid)
+}
+
+// Stop records the stop of a service
+func (s *Services) Stop(_ context.Context, uuid string) error {
+	return s.record("Stop", uuid)
+}
+
+// Restart records the restart of a service
+func (s *Services) Restart(_ context.Context, uuid string) error {
+	return s.record("Restart", uuid)
+}
+
+// Components returns the applications and databases of a service
+func (s *Services) Components(_ context.Context, uuid string) ([]client.ServiceComponent, error) {
+	if err := s.record("Components", uuid); err != nil {
+		return nil, err
+	}
+	components, ok := s.ServiceComponents[uuid]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]client.ServiceComponent(nil), components...), nil
+}
+
+// Compile-time checks that the fakes implement the client interfaces
+var (
+	_ client.API             = (*Client)(nil)
+	_ client.ApplicationsAPI = (*Applications)(nil)
+	_ client.DeploymentsAPI  = (*Deployments)(nil)
+	_ client.ProjectsAPI     = (*Projects)(nil)
+	_ client.ServersAPI      = (*Servers)(nil)
+	_ client.ServicesAPI     = (*Services)(nil)
+)
@@ -0,0 +1,92 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+	"github.com/hongkongkiwi/coolifyme/pkg/client"
+)
+
+func ptr(s string) *string { return &s }
+
+// restartAll is an example of code written against client.API
+func restartAll(ctx context.Context, c client.API) error {
+	apps, err := c.Applications().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list applications: %w", err)
+	}
+	for _, app := range apps {
+		if _, err := c.Applications().Restart(ctx, *app.Uuid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestApplications(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+	c.Apps.Items = []coolify.Application{
+		{Uuid: ptr("app-1"), Name: ptr("web")},
+		{Uuid: ptr("app-2"), Name: ptr("worker")},
+	}
+	c.Apps.Tags = map[string][]string{"app-2": {"jobs"}}
+
+	if err := restartAll(ctx, c); err != nil {
+		t.Fatalf("restartAll() error = %v", err)
+	}
+	want := []string{"List", "Restart app-1", "Restart app-2"}
+	if got := c.Apps.Calls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Calls() = %v, want %v", got, want)
+	}
+
+	tagged, _ := c.Applications().ListByTag(ctx, "jobs")
+	if len(tagged) != 1 || *tagged[0].Uuid != "app-2" {
+		t.Errorf("ListByTag() = %v", tagged)
+	}
+
+	if err := c.Applications().Delete(ctx, "app-1", nil); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := c.Applications().Get(ctx, "app-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want ErrNotFound", err)
+	}
+
+	c.Apps.Err = errors.New("boom")
+	if err := restartAll(ctx, c); err == nil {
+		t.Error("restartAll() with failing fake returned no error")
+	}
+}
+
+func TestDeploymentsHistory(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+	c.Deploys.Items = []coolify.ApplicationDeploymentQueue{
+		{DeploymentUuid: ptr("d3"), ApplicationId: ptr("app-1")},
+		{DeploymentUuid: ptr("d2"), ApplicationId: ptr("app-2")},
+		{DeploymentUuid: ptr("d1"), ApplicationId: ptr("app-1")},
+	}
+
+	history, err := c.Deployments().History(ctx, "app-1", 5)
+	if err != nil || len(history) != 2 {
+		t.Fatalf("History() = %v, %v", history, err)
+	}
+	latest, err := c.Deployments().Latest(ctx, "app-1")
+	if err != nil || latest == nil || *latest.DeploymentUuid != "d3" {
+		t.Errorf("Latest() = %v, %v", latest, err)
+	}
+}
+
+func TestProjectsResolve(t *testing.T) {
+	c := New()
+	c.Projs.Items = []coolify.Project{{Uuid: ptr("p1"), Name: ptr("Website")}}
+
+	project, err := c.Projects().Resolve(context.Background(), "website")
+	if err != nil || *project.Uuid != "p1" {
+		t.Errorf("Resolve() = %v, %v", project, err)
+	}
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyHeader carries a client-generated key that is the same for every attempt of a
+// POST request, so that a server or proxy supporting it can recognise a retried create. Coolify
+// currently ignores the header. POST requests are only retried when they were never sent, so
+// callers detect possible duplicates from failures whose outcome is unknown, see OutcomeUnknown.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKeyContextKey struct{}
+
+// NewIdempotencyKey returns a new random idempotency key
+func NewIdempotencyKey() string {
+	return uuid.NewString()
+}
+
+// WithIdempotencyKey returns a context whose POST requests are sent with the given idempotency
+// key. POST requests made without one get a fresh key per request.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// setIdempotencyKey adds the idempotency key header to POST requests that do not have one
+func setIdempotencyKey(req *http.Request) {
+	if req.Method != http.MethodPost || req.Header.Get(IdempotencyKeyHeader) != "" {
+		return
+	}
+	key, _ := req.Context().Value(idempotencyKeyContextKey{}).(string)
+	if key == "" {
+		key = NewIdempotencyKey()
+	}
+	req.Header.Set(IdempotencyKeyHeader, key)
+}
+
+// OutcomeUnknown reports whether a request failed without a response, e.g. on a network error
+// or timeout, so that the server may or may not have applied it
+func OutcomeUnknown(err error) bool {
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// dialFailures fails the first attempts of every request as if the connection was refused
+type dialFailures struct {
+	mu       sync.Mutex
+	failures int
+}
+
+func (d *dialFailures) RoundTrip(req *http.Request) (*http.Response, error) {
+	d.mu.Lock()
+	fail := d.failures > 0
+	if fail {
+		d.failures--
+	}
+	d.mu.Unlock()
+	if fail {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestIdempotencyKeyAndRetries(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		attempt := len(keys)
+		mu.Unlock()
+		if attempt == 1 && status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	dial := &dialFailures{}
+	c, err := New(nil, WithBaseURL(server.URL), WithToken("token"),
+		WithHTTPClient(&http.Client{Transport: dial}),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 2, Delay: time.Millisecond}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// A write that failed to connect was never sent, so it is retried with the same key
+	dial.failures = 1
+	ctx := WithIdempotencyKey(context.Background(), "key-1")
+	if err := c.doRequest(ctx, http.MethodPost, "/projects", map[string]string{"name": "web"}, nil); err != nil {
+		t.Fatalf("doRequest() error = %v", err)
+	}
+	if len(keys) != 1 || keys[0] != "key-1" {
+		t.Errorf("idempotency keys = %v, want key-1 once", keys)
+	}
+
+	// A gateway timeout may come after the server created the project, so it is not retried and
+	// cannot create a duplicate
+	keys, status = nil, http.StatusGatewayTimeout
+	if err := c.doRequest(context.Background(), http.MethodPost, "/projects", nil, nil); err == nil {
+		t.Error("doRequest() error = nil for a 504 response")
+	}
+	if len(keys) != 1 || keys[0] == "" {
+		t.Errorf("idempotency keys = %v, want one generated key", keys)
+	}
+
+	// Deployments are triggered with GET, so they are not retried either
+	keys = nil
+	_ = c.doRequest(context.Background(), http.MethodGet, "/applications/app-1/restart", nil, nil)
+	if len(keys) != 1 {
+		t.Errorf("restart attempts = %d, want 1", len(keys))
+	}
+
+	// Reads are retried after a gateway timeout
+	keys = nil
+	if err := c.doRequest(context.Background(), http.MethodGet, "/applications", nil, nil); err != nil {
+		t.Fatalf("doRequest() error = %v", err)
+	}
+	if len(keys) != 2 {
+		t.Errorf("read attempts = %d, want 2", len(keys))
+	}
+}
+
+func TestOutcomeUnknown(t *testing.T) {
+	if !OutcomeUnknown(&url.Error{Op: "Post", URL: "https://x", Err: errors.New("connection reset")}) {
+		t.Error("OutcomeUnknown() = false for a network error")
+	}
+	if !OutcomeUnknown(context.DeadlineExceeded) {
+		t.Error("OutcomeUnknown() = false for a timeout")
+	}
+	if OutcomeUnknown(errors.New("API error: 422 Unprocessable Entity")) {
+		t.Error("OutcomeUnknown() = true for an API error")
+	}
+}
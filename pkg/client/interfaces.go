@@ -0,0 +1,318 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+)
+
+// API gives access to the resource clients. It is implemented by Client and can be implemented
+// by fakes, such as the ones in pkg/client/fake, to test code without an HTTP server.
+type API interface {
+	Applications() ApplicationsAPI
+	Deployments() DeploymentsAPI
+	Projects() ProjectsAPI
+	Servers() ServersAPI
+	Services() ServicesAPI
+	Databases() DatabasesAPI
+	PrivateKeys() PrivateKeysAPI
+	Resources() ResourcesAPI
+	Teams() TeamsAPI
+	System() SystemAPI
+	Sources() SourcesAPI
+	Tags() TagsAPI
+}
+
+// ApplicationsAPI manages applications and their environment variables. It is implemented by ApplicationsClient.
+type ApplicationsAPI interface {
+	// List returns all applications
+	List(ctx context.Context) ([]coolify.Application, error)
+	// ListByTag returns the applications with the given tag
+	ListByTag(ctx context.Context, tag string) ([]coolify.Application, error)
+	// CreatePublic creates a new application from a public repository
+	CreatePublic(ctx context.Context, req coolify.CreatePublicApplicationJSONRequestBody) (*coolify.Application, error)
+	// Get returns an application by UUID
+	Get(ctx context.Context, uuidStr string) (*coolify.Application, error)
+	// Delete deletes an application by UUID
+	Delete(ctx context.Context, uuidStr string, options *coolify.DeleteApplicationByUuidParams) error
+	// Update updates an application by UUID
+	Update(ctx context.Context, uuidStr string, req coolify.UpdateApplicationByUuidJSONRequestBody) (string, error)
+	// UpdateFields updates raw application fields that the generated request body does not cover,
+	// such as dockerfile_location
+	UpdateFields(ctx context.Context, uuidStr string, fields map[string]any) error
+	// SetLimits updates the resource limits of an application that differ from current
+	SetLimits(ctx context.Context, uuidStr string, current, limits ResourceLimits) error
+	// Move moves an application to another project environment
+	Move(ctx context.Context, uuidStr string, target MoveTarget) error
+	// SetDeployKey switches a deploy-key based application to another private key
+	SetDeployKey(ctx context.Context, uuidStr, keyUUID string, keyID int) error
+	// CreatePrivateGithubApp creates a new application from a private GitHub app repository
+	CreatePrivateGithubApp(ctx context.Context, req coolify.CreatePrivateGithubAppApplicationJSONRequestBody) (*coolify.Application, error)
+	// CreatePrivateDeployKey creates a new application from a private repository with deploy key
+	CreatePrivateDeployKey(ctx context.Context, req coolify.CreatePrivateDeployKeyApplicationJSONRequestBody) (*coolify.Application, error)
+	// CreateDockerfile creates a new application from a Dockerfile
+	CreateDockerfile(ctx context.Context, req coolify.CreateDockerfileApplicationJSONRequestBody) (*coolify.Application, error)
+	// CreateDockerImage creates a new application from a Docker image
+	CreateDockerImage(ctx context.Context, req coolify.CreateDockerimageApplicationJSONRequestBody) (*coolify.Application, error)
+	// CreateDockerCompose creates a new application from a Docker Compose file
+	CreateDockerCompose(ctx context.Context, req coolify.CreateDockercomposeApplicationJSONRequestBody) (*coolify.Application, error)
+	// Start starts an application
+	Start(ctx context.Context, uuidStr string, options *coolify.StartApplicationByUuidParams) (*StartResponse, error)
+	// Stop stops an application
+	Stop(ctx context.Context, uuidStr string) error
+	// Restart restarts an application
+	Restart(ctx context.Context, uuidStr string) (*RestartResponse, error)
+	// GetLogs gets application logs
+	GetLogs(ctx context.Context, uuidStr string, params *coolify.GetApplicationLogsByUuidParams) (string, error)
+	// ListEnvs lists environment variables for an application
+	ListEnvs(ctx context.Context, uuidStr string) ([]coolify.EnvironmentVariable, error)
+	// CreateEnv creates an environment variable for an application
+	CreateEnv(ctx context.Context, uuidStr string, req coolify.CreateEnvByApplicationUuidJSONRequestBody) (string, error)
+	// UpdateEnv updates an environment variable for an application
+	UpdateEnv(ctx context.Context, uuidStr string, req coolify.UpdateEnvByApplicationUuidJSONRequestBody) (string, error)
+	// UpdateEnvs updates multiple environment variables for an application
+	UpdateEnvs(ctx context.Context, uuidStr string, req coolify.UpdateEnvsByApplicationUuidJSONRequestBody) (string, error)
+	// DeleteEnv deletes an environment variable for an application
+	DeleteEnv(ctx context.Context, uuidStr string, envUUIDStr string) (string, error)
+}
+
+// DeploymentsAPI manages deployments. It is implemented by DeploymentsClient.
+type DeploymentsAPI interface {
+	// DeployApplication deploys an application by UUID
+	DeployApplication(ctx context.Context, uuidStr string, forceRebuild bool, branch string) (*DeployResponse, error)
+	// DeployApplicationWithOptions deploys an application with advanced options
+	DeployApplicationWithOptions(ctx context.Context, uuidStr string, options *DeployApplicationOptions) (*DeployResponse, error)
+	// DeployService deploys a service by starting it (services use start/restart for deployment)
+	DeployService(ctx context.Context, uuidStr string) error
+	// List returns deployment history for an application
+	List(ctx context.Context, appUUIDStr string) ([]coolify.Application, error)
+	// ListAll returns all deployments
+	ListAll(ctx context.Context) ([]coolify.ApplicationDeploymentQueue, error)
+	// GetByUUID returns a deployment by UUID
+	GetByUUID(ctx context.Context, uuidStr string) (*coolify.ApplicationDeploymentQueue, error)
+	// Watch monitors a deployment until it completes or fails
+	Watch(ctx context.Context, uuidStr string) error
+	// Cancel cancels a queued or running deployment.
+	// The endpoint is not part of the generated client, so the request is made directly.
+	Cancel(ctx context.Context, deploymentUUID string) error
+	// Wait polls a deployment until it succeeds or fails and returns its final state.
+	// onStatus, if set, is called whenever the deployment status changes.
+	Wait(ctx context.Context, uuidStr string, interval time.Duration, onStatus func(status string)) (*coolify.ApplicationDeploymentQueue, error)
+	// DeployMultiple deploys multiple applications by their UUIDs
+	DeployMultiple(ctx context.Context, uuids []string, options *DeployApplicationOptions) (*DeployResponse, error)
+	// ListWithPagination returns deployment history for an application with pagination support
+	ListWithPagination(ctx context.Context, appUUIDStr string, skip, take int) ([]coolify.Application, error)
+	// Latest returns the most recent deployment of an application, or nil if it has never been deployed
+	Latest(ctx context.Context, appUUIDStr string) (*coolify.ApplicationDeploymentQueue, error)
+	// History returns up to take of the most recent deployments of an application, newest first
+	History(ctx context.Context, appUUIDStr string, take int) ([]coolify.ApplicationDeploymentQueue, error)
+	// ListIter returns a pager over the full deployment history of an application, newest first.
+	// A pageSize of 0 or less uses DefaultPageSize.
+	ListIter(appUUIDStr string, pageSize int) *Pager[coolify.ApplicationDeploymentQueue]
+	// Page returns up to take deployments of an application, newest first, skipping the first skip
+	Page(ctx context.Context, appUUIDStr string, skip, take int) ([]coolify.ApplicationDeploymentQueue, error)
+}
+
+// ProjectsAPI manages projects and their environments. It is implemented by ProjectsClient.
+type ProjectsAPI interface {
+	// List returns all projects
+	List(ctx context.Context) ([]coolify.Project, error)
+	// Create creates a new project
+	Create(ctx context.Context, req coolify.CreateProjectJSONRequestBody) (string, error)
+	// Get returns a project by UUID
+	Get(ctx context.Context, uuidStr string) (*coolify.Project, error)
+	// Delete deletes a project by UUID
+	Delete(ctx context.Context, uuidStr string) error
+	// Update updates a project by UUID
+	Update(ctx context.Context, uuidStr string, req coolify.UpdateProjectByUuidJSONRequestBody) (*coolify.Project, error)
+	// GetEnvironment returns an environment by name or UUID within a project
+	GetEnvironment(ctx context.Context, projectUUID, environmentNameOrUUID string) (*coolify.Environment, error)
+	// CreateEnvironment creates an environment in a project and returns its UUID.
+	// The endpoint is not part of the generated client, so the request is made directly.
+	CreateEnvironment(ctx context.Context, projectUUID, name string) (string, error)
+	// DeleteEnvironment deletes an empty environment of a project by name or UUID
+	DeleteEnvironment(ctx context.Context, projectUUID, environmentNameOrUUID string) error
+	// Resolve returns a project by UUID or name, including its environments
+	Resolve(ctx context.Context, nameOrUUID string) (*coolify.Project, error)
+}
+
+// ServersAPI manages servers. It is implemented by ServersClient.
+type ServersAPI interface {
+	// List returns all servers
+	List(ctx context.Context) ([]coolify.Server, error)
+	// Create creates a new server
+	Create(ctx context.Context, req coolify.CreateServerJSONRequestBody) (string, error)
+	// Get returns a server by UUID
+	Get(ctx context.Context, uuidStr string) (*coolify.Server, error)
+	// Delete deletes a server by UUID
+	Delete(ctx context.Context, uuidStr string) error
+	// Update updates a server by UUID
+	Update(ctx context.Context, uuidStr string, req coolify.UpdateServerByUuidJSONRequestBody) (*coolify.Server, error)
+	// GetResources returns resources for a server by UUID (returns as JSON string per API spec)
+	GetResources(ctx context.Context, uuidStr string) (string, error)
+	// GetDomains returns domains for a server by UUID (returns as JSON string per API spec)
+	GetDomains(ctx context.Context, uuidStr string) (string, error)
+	// Validate validates a server by UUID
+	Validate(ctx context.Context, uuidStr string) (string, error)
+	// SSHTarget returns the SSH connection details of a server. The private key is referenced by
+	// ID in the server response, which is not part of the generated types, so it is read directly.
+	SSHTarget(ctx context.Context, uuidStr string) (*SSHTarget, error)
+}
+
+// ServicesAPI manages services and their environment variables. It is implemented by ServicesClient.
+type ServicesAPI interface {
+	// List returns all services
+	List(ctx context.Context) ([]coolify.Service, error)
+	// Get returns a service by UUID
+	Get(ctx context.Context, uuidStr string) (*coolify.Service, error)
+	// Start starts a service
+	Start(ctx context.Context, uuidStr string) error
+	// Stop stops a service
+	Stop(ctx context.Context, uuidStr string) error
+	// Restart restarts a service
+	Restart(ctx context.Context, uuidStr string) error
+	// Create creates a new service
+	Create(ctx context.Context, req coolify.CreateServiceJSONRequestBody) (string, error)
+	// Delete deletes a service by UUID
+	Delete(ctx context.Context, uuidStr string, options *coolify.DeleteServiceByUuidParams) error
+	// Update updates a service by UUID
+	Update(ctx context.Context, uuidStr string, req coolify.UpdateServiceByUuidJSONRequestBody) (string, error)
+	// Move moves a service to another project environment
+	Move(ctx context.Context, uuidStr string, target MoveTarget) error
+	// ListEnvs lists environment variables for a service
+	ListEnvs(ctx context.Context, uuidStr string) ([]coolify.EnvironmentVariable, error)
+	// CreateEnv creates an environment variable for a service
+	CreateEnv(ctx context.Context, uuidStr string, req coolify.CreateEnvByServiceUuidJSONRequestBody) (string, error)
+	// UpdateEnv updates an environment variable for a service
+	UpdateEnv(ctx context.Context, uuidStr string, req coolify.UpdateEnvByServiceUuidJSONRequestBody) (string, error)
+	// UpdateEnvs updates multiple environment variables for a service
+	UpdateEnvs(ctx context.Context, uuidStr string, req coolify.UpdateEnvsByServiceUuidJSONRequestBody) (string, error)
+	// DeleteEnv deletes an environment variable for a service
+	DeleteEnv(ctx context.Context, uuidStr string, envUUIDStr string) (string, error)
+	// Components returns the applications and databases running inside a service
+	Components(ctx context.Context, uuidStr string) ([]ServiceComponent, error)
+}
+
+// DatabasesAPI manages databases. It is implemented by DatabasesClient.
+type DatabasesAPI interface {
+	// List returns all databases (currently returns raw string as API is not fully implemented)
+	List(ctx context.Context) (string, error)
+	// Get returns a database by UUID (currently returns raw string as API is not fully implemented)
+	Get(ctx context.Context, uuidStr string) (string, error)
+	// Start starts a database
+	Start(ctx context.Context, uuidStr string) error
+	// Stop stops a database
+	Stop(ctx context.Context, uuidStr string) error
+	// Restart restarts a database
+	Restart(ctx context.Context, uuidStr string) error
+	// Delete deletes a database by UUID
+	Delete(ctx context.Context, uuidStr string, options *coolify.DeleteDatabaseByUuidParams) error
+	// Update updates a database by UUID
+	Update(ctx context.Context, uuidStr string, req coolify.UpdateDatabaseByUuidJSONRequestBody) error
+	// Move moves a database to another project environment
+	Move(ctx context.Context, uuidStr string, target MoveTarget) error
+	// CreatePostgreSQL creates a new PostgreSQL database and returns its UUID
+	CreatePostgreSQL(ctx context.Context, req coolify.CreateDatabasePostgresqlJSONRequestBody) (string, error)
+	// CreateMySQL creates a new MySQL database and returns its UUID
+	CreateMySQL(ctx context.Context, req coolify.CreateDatabaseMysqlJSONRequestBody) (string, error)
+	// CreateRedis creates a new Redis database and returns its UUID
+	CreateRedis(ctx context.Context, req coolify.CreateDatabaseRedisJSONRequestBody) (string, error)
+	// CreateMongoDB creates a new MongoDB database and returns its UUID
+	CreateMongoDB(ctx context.Context, req coolify.CreateDatabaseMongodbJSONRequestBody) (string, error)
+	// CreateClickHouse creates a new ClickHouse database and returns its UUID
+	CreateClickHouse(ctx context.Context, req coolify.CreateDatabaseClickhouseJSONRequestBody) (string, error)
+	// CreateDragonfly creates a new Dragonfly database and returns its UUID
+	CreateDragonfly(ctx context.Context, req coolify.CreateDatabaseDragonflyJSONRequestBody) (string, error)
+	// CreateKeyDB creates a new KeyDB database and returns its UUID
+	CreateKeyDB(ctx context.Context, req coolify.CreateDatabaseKeydbJSONRequestBody) (string, error)
+	// CreateMariaDB creates a new MariaDB database and returns its UUID
+	CreateMariaDB(ctx context.Context, req coolify.CreateDatabaseMariadbJSONRequestBody) (string, error)
+}
+
+// PrivateKeysAPI manages private keys. It is implemented by PrivateKeysClient.
+type PrivateKeysAPI interface {
+	// List returns all private keys
+	List(ctx context.Context) ([]coolify.PrivateKey, error)
+	// Create creates a new private key
+	Create(ctx context.Context, req coolify.CreatePrivateKeyJSONRequestBody) (string, error)
+	// Get returns a private key by UUID
+	Get(ctx context.Context, uuidStr string) (*coolify.PrivateKey, error)
+	// Update updates a private key
+	Update(ctx context.Context, req coolify.UpdatePrivateKeyJSONRequestBody) (string, error)
+	// Delete deletes a private key by UUID
+	Delete(ctx context.Context, uuidStr string) error
+}
+
+// ResourcesAPI manages resources of all kinds. It is implemented by ResourcesClient.
+type ResourcesAPI interface {
+	// List returns all resources
+	List(ctx context.Context) (string, error)
+}
+
+// TeamsAPI manages teams. It is implemented by TeamsClient.
+type TeamsAPI interface {
+	// List returns all teams
+	List(ctx context.Context) ([]coolify.Team, error)
+	// Get returns a team by ID
+	Get(ctx context.Context, teamID int) (*coolify.Team, error)
+	// GetMembers returns members of a team by team ID
+	GetMembers(ctx context.Context, teamID int) ([]coolify.User, error)
+	// GetCurrent returns the current team
+	GetCurrent(ctx context.Context) (*coolify.Team, error)
+	// GetCurrentMembers returns members of the current team
+	GetCurrentMembers(ctx context.Context) ([]coolify.User, error)
+}
+
+// SystemAPI manages the Coolify instance. It is implemented by SystemClient.
+type SystemAPI interface {
+	// Version returns the system version
+	Version(ctx context.Context) (string, error)
+	// Healthcheck performs a health check
+	Healthcheck(ctx context.Context) (string, error)
+	// EnableAPI enables the API
+	EnableAPI(ctx context.Context) (string, error)
+	// DisableAPI disables the API
+	DisableAPI(ctx context.Context) (string, error)
+	// Upgrade triggers an upgrade of the Coolify instance to the latest available version
+	Upgrade(ctx context.Context) (string, error)
+	// GetSettings returns the instance settings
+	GetSettings(ctx context.Context) (InstanceSettings, error)
+	// UpdateSettings updates the given instance settings
+	UpdateSettings(ctx context.Context, settings InstanceSettings) (string, error)
+	// Cleanup removes unused docker images, containers and build caches on all servers
+	Cleanup(ctx context.Context) (string, error)
+}
+
+// SourcesAPI manages git sources. It is implemented by SourcesClient.
+type SourcesAPI interface {
+	// List returns all GitHub App sources
+	List(ctx context.Context) ([]GitHubApp, error)
+	// Get returns a GitHub App source by UUID
+	Get(ctx context.Context, uuidStr string) (*GitHubApp, error)
+}
+
+// TagsAPI lists tags and the resources carrying them. It is implemented by TagsClient.
+type TagsAPI interface {
+	// List returns the tags of the team
+	List(ctx context.Context) ([]Tag, error)
+	// Resources returns the applications and services carrying a tag
+	Resources(ctx context.Context, name string) ([]TagResource, error)
+}
+
+// Compile-time checks that the resource clients implement their interfaces
+var (
+	_ API             = (*Client)(nil)
+	_ ApplicationsAPI = (*ApplicationsClient)(nil)
+	_ DeploymentsAPI  = (*DeploymentsClient)(nil)
+	_ ProjectsAPI     = (*ProjectsClient)(nil)
+	_ ServersAPI      = (*ServersClient)(nil)
+	_ ServicesAPI     = (*ServicesClient)(nil)
+	_ DatabasesAPI    = (*DatabasesClient)(nil)
+	_ PrivateKeysAPI  = (*PrivateKeysClient)(nil)
+	_ ResourcesAPI    = (*ResourcesClient)(nil)
+	_ TeamsAPI        = (*TeamsClient)(nil)
+	_ SystemAPI       = (*SystemClient)(nil)
+	_ SourcesAPI      = (*SourcesClient)(nil)
+	_ TagsAPI         = (*TagsClient)(nil)
+)
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+)
+
+// ResourceLimits are the Docker resource limits of an application. Values use Docker's formats:
+// memory sizes such as 512m or 1g, a number of CPUs such as 0.5, and "0" for no limit.
+type ResourceLimits struct {
+	Memory            string `json:"memory"`
+	MemorySwap        string `json:"memory_swap"`
+	MemoryReservation string `json:"memory_reservation"`
+	MemorySwappiness  *int   `json:"memory_swappiness,omitempty"`
+	CPUs              string `json:"cpus"`
+	CPUSet            string `json:"cpuset"`
+	CPUShares         *int   `json:"cpu_shares,omitempty"`
+}
+
+// ApplicationLimits returns the resource limits of an application
+func ApplicationLimits(app *coolify.Application) ResourceLimits {
+	value := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	return ResourceLimits{
+		Memory:            value(app.LimitsMemory),
+		MemorySwap:        value(app.LimitsMemorySwap),
+		MemoryReservation: value(app.LimitsMemoryReservation),
+		MemorySwappiness:  app.LimitsMemorySwappiness,
+		CPUs:              value(app.LimitsCpus),
+		CPUSet:            value(app.LimitsCpuset),
+		CPUShares:         app.LimitsCpuShares,
+	}
+}
+
+var (
+	memoryPattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([bkmgt]?)(?:i?b|i)?$`)
+	cpuSetPattern = regexp.MustCompile(`^\d+(-\d+)?(,\d+(-\d+)?)*$`)
+	memoryUnits   = map[string]float64{"": 1, "b": 1, "k": 1 << 10, "m": 1 << 20, "g": 1 << 30, "t": 1 << 40}
+)
+
+// NormalizeMemory validates a memory size and returns it in Docker's format, e.g. "512MB",
+// "512Mi" and "512m" all become "512m". "0" means no limit.
+func NormalizeMemory(value string) (string, error) {
+	m := memoryPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value)))
+	if m == nil {
+		return "", fmt.Errorf("invalid memory size '%s' (use e.g. 512m, 1g or 0 for no limit)", value)
+	}
+	if m[1] == "0" || strings.Trim(m[1], "0.") == "" {
+		return "0", nil
+	}
+	if strings.Contains(m[1], ".") && m[2] == "" {
+		return "", fmt.Errorf("invalid memory size '%s': fractional sizes need a unit", value)
+	}
+	return m[1] + m[2], nil
+}
+
+// MemoryBytes returns the number of bytes of a memory size in Docker's format
+func MemoryBytes(value string) (int64, error) {
+	m := memoryPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value)))
+	if m == nil {
+		return 0, fmt.Errorf("invalid memory size '%s'", value)
+	}
+	n, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory size '%s': %w", value, err)
+	}
+	return int64(math.Round(n * memoryUnits[m[2]])), nil
+}
+
+// NormalizeSwap validates the memory plus swap limit: a memory size, "0" for Docker's default of
+// twice the memory limit, or "-1" for unlimited swap
+func NormalizeSwap(value string) (string, error) {
+	if strings.TrimSpace(value) == "-1" {
+		return "-1", nil
+	}
+	return NormalizeMemory(value)
+}
+
+// NormalizeCPUs validates a number of CPUs such as 0.5 or 2. "0" means no limit.
+func NormalizeCPUs(value string) (string, error) {
+	n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || n < 0 || math.IsInf(n, 0) {
+		return "", fmt.Errorf("invalid number of CPUs '%s' (use e.g. 0.5, 2 or 0 for no limit)", value)
+	}
+	return strconv.FormatFloat(n, 'f', -1, 64), nil
+}
+
+// ValidateCPUSet validates the CPUs a container may run on, e.g. "0-3" or "0,2". An empty set
+// allows all CPUs.
+func ValidateCPUSet(value string) error {
+	if value != "" && !cpuSetPattern.MatchString(value) {
+		return fmt.Errorf("invalid CPU set '%s' (use e.g. 0-3 or 0,2)", value)
+	}
+	return nil
+}
+
+// Validate checks the combination of limits: the memory plus swap limit cannot be below the
+// memory limit, the reservation cannot exceed it and the swappiness is a percentage
+func (l ResourceLimits) Validate() error {
+	if l.MemorySwappiness != nil && (*l.MemorySwappiness < 0 || *l.MemorySwappiness > 100) {
+		return fmt.Errorf("memory swappiness %d must be between 0 and 100", *l.MemorySwappiness)
+	}
+	memory, err := MemoryBytes(l.Memory)
+	if err != nil || memory == 0 {
+		return nil
+	}
+	if swap, err := MemoryBytes(l.MemorySwap); err == nil && swap > 0 && swap < memory {
+		return fmt.Errorf("memory swap %s is memory plus swap and must be at least the memory limit %s", l.MemorySwap, l.Memory)
+	}
+	if reservation, err := MemoryBytes(l.MemoryReservation); err == nil && reservation > memory {
+		return fmt.Errorf("memory reservation %s exceeds the memory limit %s", l.MemoryReservation, l.Memory)
+	}
+	return nil
+}
+
+// SetLimits updates the resource limits of an application. Only the limits that differ from
+// current are sent; Coolify applies them with the next deployment or restart.
+func (ac *ApplicationsClient) SetLimits(ctx context.Context, uuidStr string, current, limits ResourceLimits) error {
+	req := coolify.UpdateApplicationByUuidJSONRequestBody{}
+	changed := false
+	setString := func(field **string, from, to string) {
+		if from != to {
+			value := to
+			*field, changed = &value, true
+		}
+	}
+	setInt := func(field **int, from, to *int) {
+		if to != nil && (from == nil || *from != *to) {
+			value := *to
+			*field, changed = &value, true
+		}
+	}
+	setString(&req.LimitsMemory, current.Memory, limits.Memory)
+	setString(&req.LimitsMemorySwap, current.MemorySwap, limits.MemorySwap)
+	setString(&req.LimitsMemoryReservation, current.MemoryReservation, limits.MemoryReservation)
+	setInt(&req.LimitsMemorySwappiness, current.MemorySwappiness, limits.MemorySwappiness)
+	setString(&req.LimitsCpus, current.CPUs, limits.CPUs)
+	setString(&req.LimitsCpuset, current.CPUSet, limits.CPUSet)
+	setInt(&req.LimitsCpuShares, current.CPUShares, limits.CPUShares)
+	if !changed {
+		return nil
+	}
+
+	_, err := ac.Update(ctx, uuidStr, req)
+	return err
+}
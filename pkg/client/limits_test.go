@@ -0,0 +1,88 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestNormalizeMemory(t *testing.T) {
+	tests := map[string]string{
+		"512m":  "512m",
+		"512MB": "512m",
+		"512Mi": "512m",
+		"1G":    "1g",
+		"1.5g":  "1.5g",
+		"0":     "0",
+		"0m":    "0",
+		"65536": "65536",
+	}
+	for in, want := range tests {
+		if got, err := NormalizeMemory(in); err != nil || got != want {
+			t.Errorf("NormalizeMemory(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "-1", "512x", "1.5", "lots"} {
+		if got, err := NormalizeMemory(in); err == nil {
+			t.Errorf("NormalizeMemory(%q) = %q, want an error", in, got)
+		}
+	}
+	if got, err := NormalizeSwap("-1"); err != nil || got != "-1" {
+		t.Errorf("NormalizeSwap(-1) = %q, %v", got, err)
+	}
+}
+
+func TestMemoryBytes(t *testing.T) {
+	tests := map[string]int64{"0": 0, "512": 512, "1k": 1024, "512m": 512 << 20, "1.5g": 3 << 29}
+	for in, want := range tests {
+		if got, err := MemoryBytes(in); err != nil || got != want {
+			t.Errorf("MemoryBytes(%q) = %d, %v, want %d", in, got, err, want)
+		}
+	}
+}
+
+func TestNormalizeCPUs(t *testing.T) {
+	tests := map[string]string{"0.5": "0.5", "2": "2", "1.50": "1.5", "0": "0"}
+	for in, want := range tests {
+		if got, err := NormalizeCPUs(in); err != nil || got != want {
+			t.Errorf("NormalizeCPUs(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "-1", "half", "Inf"} {
+		if got, err := NormalizeCPUs(in); err == nil {
+			t.Errorf("NormalizeCPUs(%q) = %q, want an error", in, got)
+		}
+	}
+}
+
+func TestValidateCPUSet(t *testing.T) {
+	for _, valid := range []string{"", "0", "0-3", "0,2,4-7"} {
+		if err := ValidateCPUSet(valid); err != nil {
+			t.Errorf("ValidateCPUSet(%q) error = %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"0-", "a", "0;1"} {
+		if err := ValidateCPUSet(invalid); err == nil {
+			t.Errorf("ValidateCPUSet(%q) succeeded, want an error", invalid)
+		}
+	}
+}
+
+func TestResourceLimitsValidate(t *testing.T) {
+	swappiness := 101
+	tests := []struct {
+		limits  ResourceLimits
+		wantErr bool
+	}{
+		{ResourceLimits{Memory: "512m", MemorySwap: "1g"}, false},
+		{ResourceLimits{Memory: "512m", MemorySwap: "0"}, false},
+		{ResourceLimits{Memory: "512m", MemorySwap: "-1"}, false},
+		{ResourceLimits{Memory: "0", MemorySwap: "256m"}, false},
+		{ResourceLimits{Memory: "1g", MemorySwap: "512m"}, true},
+		{ResourceLimits{Memory: "512m", MemoryReservation: "1g"}, true},
+		{ResourceLimits{MemorySwappiness: &swappiness}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.limits.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%+v) error = %v, wantErr %v", tt.limits, err, tt.wantErr)
+		}
+	}
+}
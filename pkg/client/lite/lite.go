@@ -0,0 +1,310 @@
+// Package lite is a small Coolify API client for programs that only deploy and control
+// applications, such as CI tools. Unlike the parent client package it does not depend on the
+// client generated from the whole OpenAPI spec or on the CLI configuration (viper), and only
+// uses the standard library, which keeps binaries embedding it small:
+//
+//	c := lite.New("https://coolify.example.com/api/v1", os.Getenv("COOLIFY_TOKEN"))
+//	deployments, err := c.Deploy(ctx, lite.DeployRequest{UUIDs: []string{appUUID}})
+//	if err != nil {
+//		return err
+//	}
+//	deployment, err := c.WaitForDeployment(ctx, deployments[0].DeploymentUUID, 5*time.Second)
+//
+// Use the parent package for everything else.
+package lite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client talks to the Coolify API with a bearer token
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+	userAgent  string
+}
+
+// Option customizes a Client created with New
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// New creates a client for the API at baseURL, e.g. https://coolify.example.com/api/v1
+func New(baseURL, token string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		token:      token,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// APIError is returned for responses with an unexpected status code
+type APIError struct {
+	StatusCode int
+	Status     string
+	// Message is the message of the error response, if any
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("API error: %s: %s", e.Status, e.Message)
+	}
+	return fmt.Sprintf("API error: %s", e.Status)
+}
+
+// DeployRequest selects the resources to deploy by UUID and/or tag
+type DeployRequest struct {
+	UUIDs []string
+	Tags  []string
+	// Force rebuilds without cache
+	Force bool
+	// PR deploys a pull request preview when set; it cannot be combined with Tags
+	PR int
+}
+
+// DeploymentResult is a deployment triggered by Deploy
+type DeploymentResult struct {
+	Message        string `json:"message"`
+	ResourceUUID   string `json:"resource_uuid"`
+	DeploymentUUID string `json:"deployment_uuid"`
+}
+
+// Deployment is the state of a deployment
+type Deployment struct {
+	DeploymentUUID  string `json:"deployment_uuid"`
+	ApplicationName string `json:"application_name"`
+	ServerName      string `json:"server_name"`
+	Status          string `json:"status"`
+	Commit          string `json:"commit"`
+	CreatedAt       string `json:"created_at"`
+	UpdatedAt       string `json:"updated_at"`
+}
+
+// Succeeded reports whether the deployment finished successfully
+func (d *Deployment) Succeeded() bool {
+	return DeploymentSucceeded(d.Status)
+}
+
+// Failed reports whether the deployment failed or was cancelled
+func (d *Deployment) Failed() bool {
+	return DeploymentFailed(d.Status)
+}
+
+// DeploymentSucceeded reports whether a deployment status marks a successful deployment
+func DeploymentSucceeded(status string) bool {
+	switch status {
+	case "finished", "success", "completed":
+		return true
+	}
+	return false
+}
+
+// DeploymentFailed reports whether a deployment status marks a failed deployment
+func DeploymentFailed(status string) bool {
+	switch status {
+	case "failed", "error", "cancelled", "cancelled-by-user":
+		return true
+	}
+	return false
+}
+
+// DeploymentPending reports whether a deployment status marks a queued or running deployment
+func DeploymentPending(status string) bool {
+	switch status {
+	case "queued", "in_progress":
+		return true
+	}
+	return false
+}
+
+// Version returns the Coolify version
+func (c *Client) Version(ctx context.Context) (string, error) {
+	resp, err := c.do(ctx, "/version", nil)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	return strings.Trim(strings.TrimSpace(string(data)), `"`), nil
+}
+
+// Deploy triggers deployments and returns one result per deployment started
+func (c *Client) Deploy(ctx context.Context, req DeployRequest) ([]DeploymentResult, error) {
+	if len(req.UUIDs) == 0 && len(req.Tags) == 0 {
+		return nil, errors.New("no UUID or tag to deploy")
+	}
+	if req.PR > 0 && len(req.Tags) > 0 {
+		return nil, errors.New("a pull request cannot be deployed by tag")
+	}
+
+	query := url.Values{}
+	if len(req.UUIDs) > 0 {
+		query.Set("uuid", strings.Join(req.UUIDs, ","))
+	}
+	if len(req.Tags) > 0 {
+		query.Set("tag", strings.Join(req.Tags, ","))
+	}
+	if req.Force {
+		query.Set("force", "true")
+	}
+	if req.PR > 0 {
+		query.Set("pr", strconv.Itoa(req.PR))
+	}
+
+	var result struct {
+		Deployments []DeploymentResult `json:"deployments"`
+	}
+	if err := c.getJSON(ctx, "/deploy", query, &result); err != nil {
+		return nil, fmt.Errorf("failed to deploy: %w", err)
+	}
+	return result.Deployments, nil
+}
+
+// Deployment returns the state of a deployment
+func (c *Client) Deployment(ctx context.Context, deploymentUUID string) (*Deployment, error) {
+	var deployment Deployment
+	if err := c.getJSON(ctx, "/deployments/"+url.PathEscape(deploymentUUID), nil, &deployment); err != nil {
+		return nil, fmt.Errorf("failed to get deployment: %w", err)
+	}
+	return &deployment, nil
+}
+
+// WaitForDeployment polls a deployment every interval until it succeeds, fails or ctx ends.
+// A failed deployment is returned together with an error.
+func (c *Client) WaitForDeployment(ctx context.Context, deploymentUUID string, interval time.Duration) (*Deployment, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deployment, err := c.Deployment(ctx, deploymentUUID)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case deployment.Succeeded():
+			return deployment, nil
+		case deployment.Failed():
+			return deployment, fmt.Errorf("deployment %s %s", deploymentUUID, deployment.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return deployment, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// StartApplication starts (deploys) an application and returns the deployment UUID
+func (c *Client) StartApplication(ctx context.Context, uuid string) (string, error) {
+	var result struct {
+		DeploymentUUID string `json:"deployment_uuid"`
+	}
+	if err := c.getJSON(ctx, "/applications/"+url.PathEscape(uuid)+"/start", nil, &result); err != nil {
+		return "", fmt.Errorf("failed to start application: %w", err)
+	}
+	return result.DeploymentUUID, nil
+}
+
+// StopApplication stops an application
+func (c *Client) StopApplication(ctx context.Context, uuid string) error {
+	if err := c.getJSON(ctx, "/applications/"+url.PathEscape(uuid)+"/stop", nil, nil); err != nil {
+		return fmt.Errorf("failed to stop application: %w", err)
+	}
+	return nil
+}
+
+// RestartApplication restarts an application and returns the deployment UUID
+func (c *Client) RestartApplication(ctx context.Context, uuid string) (string, error) {
+	var result struct {
+		DeploymentUUID string `json:"deployment_uuid"`
+	}
+	if err := c.getJSON(ctx, "/applications/"+url.PathEscape(uuid)+"/restart", nil, &result); err != nil {
+		return "", fmt.Errorf("failed to restart application: %w", err)
+	}
+	return result.DeploymentUUID, nil
+}
+
+// getJSON performs a GET request and decodes the response into out, if non-nil
+func (c *Client) getJSON(ctx context.Context, path string, query url.Values, out any) error {
+	resp, err := c.do(ctx, path, query)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && err != io.EOF {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// do performs an authenticated GET request and fails on non-2xx responses
+func (c *Client) do(ctx context.Context, path string, query url.Values) (*http.Response, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer func() { _ = resp.Body.Close() }()
+		apiErr := &APIError{StatusCode: resp.StatusCode, Status: resp.Status}
+		var payload struct {
+			Message string `json:"message"`
+		}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if json.Unmarshal(body, &payload) == nil {
+			apiErr.Message = payload.Message
+		}
+		return nil, apiErr
+	}
+	return resp, nil
+}
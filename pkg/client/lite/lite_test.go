@@ -0,0 +1,78 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeployAndWait(t *testing.T) {
+	polls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message": "Unauthenticated."}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/version":
+			_, _ = w.Write([]byte("4.0.0-beta.400"))
+		case "/deploy":
+			if got := r.URL.Query().Get("uuid"); got != "app-1,app-2" {
+				t.Errorf("deploy uuid = %q", got)
+			}
+			if got := r.URL.Query().Get("force"); got != "true" {
+				t.Errorf("deploy force = %q", got)
+			}
+			_, _ = w.Write([]byte(`{"deployments": [{"message": "queued", "resource_uuid": "app-1", "deployment_uuid": "dep-1"}]}`))
+		case "/deployments/dep-1":
+			polls++
+			status := "in_progress"
+			if polls > 1 {
+				status = "finished"
+			}
+			_, _ = w.Write([]byte(`{"deployment_uuid": "dep-1", "status": "` + status + `"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	c := New(server.URL+"/", "token")
+
+	version, err := c.Version(ctx)
+	if err != nil || version != "4.0.0-beta.400" {
+		t.Errorf("Version() = %q, %v", version, err)
+	}
+
+	deployments, err := c.Deploy(ctx, DeployRequest{UUIDs: []string{"app-1", "app-2"}, Force: true})
+	if err != nil {
+		t.Fatalf("Deploy() error = %v", err)
+	}
+	if len(deployments) != 1 || deployments[0].DeploymentUUID != "dep-1" {
+		t.Fatalf("Deploy() = %+v", deployments)
+	}
+
+	deployment, err := c.WaitForDeployment(ctx, "dep-1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitForDeployment() error = %v", err)
+	}
+	if !deployment.Succeeded() || polls != 2 {
+		t.Errorf("WaitForDeployment() = %+v after %d polls", deployment, polls)
+	}
+
+	_, err = New(server.URL, "wrong").Deploy(ctx, DeployRequest{Tags: []string{"web"}})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized || apiErr.Message != "Unauthenticated." {
+		t.Errorf("Deploy() with a wrong token error = %v", err)
+	}
+
+	if _, err := c.Deploy(ctx, DeployRequest{}); err == nil {
+		t.Error("Deploy() without UUIDs or tags should fail")
+	}
+}
@@ -0,0 +1,281 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+)
+
+// The generated API types change whenever the OpenAPI spec is regenerated and use pointers for
+// every field. The models below are plain values that keep their shape across spec versions;
+// missing fields are zero values and timestamps are parsed.
+
+// Application is a stable model of an application
+type Application struct {
+	UUID          string    `json:"uuid"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description,omitempty"`
+	Status        string    `json:"status"`
+	FQDN          string    `json:"fqdn,omitempty"`
+	BuildPack     string    `json:"build_pack,omitempty"`
+	GitRepository string    `json:"git_repository,omitempty"`
+	GitBranch     string    `json:"git_branch,omitempty"`
+	GitCommitSHA  string    `json:"git_commit_sha,omitempty"`
+	DockerImage   string    `json:"docker_image,omitempty"`
+	PortsExposes  string    `json:"ports_exposes,omitempty"`
+	EnvironmentID int       `json:"environment_id,omitempty"`
+	DestinationID int       `json:"destination_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Server is a stable model of a server
+type Server struct {
+	UUID        string `json:"uuid"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	IP          string `json:"ip"`
+	Port        int    `json:"port"`
+	User        string `json:"user"`
+	ProxyType   string `json:"proxy_type,omitempty"`
+	Reachable   bool   `json:"reachable"`
+	Usable      bool   `json:"usable"`
+	BuildServer bool   `json:"build_server"`
+}
+
+// Service is a stable model of a service
+type Service struct {
+	UUID          string    `json:"uuid"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description,omitempty"`
+	Type          string    `json:"type,omitempty"`
+	EnvironmentID int       `json:"environment_id,omitempty"`
+	ServerID      int       `json:"server_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Deployment is a stable model of an application deployment
+type Deployment struct {
+	UUID            string    `json:"uuid"`
+	ApplicationID   string    `json:"application_id,omitempty"`
+	ApplicationName string    `json:"application_name,omitempty"`
+	ServerName      string    `json:"server_name,omitempty"`
+	Status          string    `json:"status"`
+	Commit          string    `json:"commit,omitempty"`
+	CommitMessage   string    `json:"commit_message,omitempty"`
+	URL             string    `json:"url,omitempty"`
+	PullRequestID   int       `json:"pull_request_id,omitempty"`
+	ForceRebuild    bool      `json:"force_rebuild,omitempty"`
+	Rollback        bool      `json:"rollback,omitempty"`
+	Webhook         bool      `json:"webhook,omitempty"`
+	Logs            string    `json:"logs,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// Succeeded reports whether the deployment finished successfully
+func (d Deployment) Succeeded() bool {
+	return DeploymentSucceeded(d.Status)
+}
+
+// Failed reports whether the deployment failed or was cancelled
+func (d Deployment) Failed() bool {
+	return DeploymentFailed(d.Status)
+}
+
+// Pending reports whether the deployment is queued or in progress
+func (d Deployment) Pending() bool {
+	return DeploymentPending(d.Status)
+}
+
+// ApplicationFromAPI converts a generated application to the stable model
+func ApplicationFromAPI(app coolify.Application) Application {
+	model := Application{
+		UUID:          value(app.Uuid),
+		Name:          value(app.Name),
+		Description:   value(app.Description),
+		Status:        value(app.Status),
+		FQDN:          value(app.Fqdn),
+		GitRepository: value(app.GitRepository),
+		GitBranch:     value(app.GitBranch),
+		GitCommitSHA:  value(app.GitCommitSha),
+		DockerImage:   value(app.DockerRegistryImageName),
+		PortsExposes:  value(app.PortsExposes),
+		EnvironmentID: value(app.EnvironmentId),
+		DestinationID: value(app.DestinationId),
+		CreatedAt:     value(app.CreatedAt),
+		UpdatedAt:     value(app.UpdatedAt),
+	}
+	if app.BuildPack != nil {
+		model.BuildPack = string(*app.BuildPack)
+	}
+	if tag := value(app.DockerRegistryImageTag); model.DockerImage != "" && tag != "" {
+		model.DockerImage += ":" + tag
+	}
+	return model
+}
+
+// ServerFromAPI converts a generated server to the stable model
+func ServerFromAPI(server coolify.Server) Server {
+	model := Server{
+		UUID:        value(server.Uuid),
+		Name:        value(server.Name),
+		Description: value(server.Description),
+		IP:          value(server.Ip),
+		Port:        value(server.Port),
+		User:        value(server.User),
+	}
+	if server.ProxyType != nil {
+		model.ProxyType = string(*server.ProxyType)
+	}
+	if settings := server.Settings; settings != nil {
+		model.Reachable = value(settings.IsReachable)
+		model.Usable = value(settings.IsUsable)
+		model.BuildServer = value(settings.IsBuildServer)
+	}
+	return model
+}
+
+// ServiceFromAPI converts a generated service to the stable model
+func ServiceFromAPI(service coolify.Service) Service {
+	return Service{
+		UUID:          value(service.Uuid),
+		Name:          value(service.Name),
+		Description:   value(service.Description),
+		Type:          value(service.ServiceType),
+		EnvironmentID: value(service.EnvironmentId),
+		ServerID:      value(service.ServerId),
+		CreatedAt:     parseTimestamp(service.CreatedAt),
+		UpdatedAt:     parseTimestamp(service.UpdatedAt),
+	}
+}
+
+// DeploymentFromAPI converts a generated deployment to the stable model
+func DeploymentFromAPI(deployment coolify.ApplicationDeploymentQueue) Deployment {
+	return Deployment{
+		UUID:            value(deployment.DeploymentUuid),
+		ApplicationID:   value(deployment.ApplicationId),
+		ApplicationName: value(deployment.ApplicationName),
+		ServerName:      value(deployment.ServerName),
+		Status:          value(deployment.Status),
+		Commit:          value(deployment.Commit),
+		CommitMessage:   value(deployment.CommitMessage),
+		URL:             value(deployment.DeploymentUrl),
+		PullRequestID:   value(deployment.PullRequestId),
+		ForceRebuild:    value(deployment.ForceRebuild),
+		Rollback:        value(deployment.Rollback),
+		Webhook:         value(deployment.IsWebhook),
+		Logs:            value(deployment.Logs),
+		CreatedAt:       parseTimestamp(deployment.CreatedAt),
+		UpdatedAt:       parseTimestamp(deployment.UpdatedAt),
+	}
+}
+
+// convertAll converts a list of generated types to models
+func convertAll[T, M any](items []T, convert func(T) M) []M {
+	models := make([]M, 0, len(items))
+	for _, item := range items {
+		models = append(models, convert(item))
+	}
+	return models
+}
+
+// value dereferences a pointer of the generated types, returning the zero value for nil
+func value[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// parseTimestamp parses an API timestamp, returning the zero time when it is missing or invalid
+func parseTimestamp(s *string) time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339Nano, *s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// ListApplications returns all applications as stable models
+func (c *Client) ListApplications(ctx context.Context) ([]Application, error) {
+	apps, err := c.Applications().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return convertAll(apps, ApplicationFromAPI), nil
+}
+
+// GetApplication returns an application by UUID as a stable model
+func (c *Client) GetApplication(ctx context.Context, uuidStr string) (*Application, error) {
+	app, err := c.Applications().Get(ctx, uuidStr)
+	if err != nil {
+		return nil, err
+	}
+	model := ApplicationFromAPI(*app)
+	return &model, nil
+}
+
+// ListServers returns all servers as stable models
+func (c *Client) ListServers(ctx context.Context) ([]Server, error) {
+	servers, err := c.Servers().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return convertAll(servers, ServerFromAPI), nil
+}
+
+// GetServer returns a server by UUID as a stable model
+func (c *Client) GetServer(ctx context.Context, uuidStr string) (*Server, error) {
+	server, err := c.Servers().Get(ctx, uuidStr)
+	if err != nil {
+		return nil, err
+	}
+	model := ServerFromAPI(*server)
+	return &model, nil
+}
+
+// ListServices returns all services as stable models
+func (c *Client) ListServices(ctx context.Context) ([]Service, error) {
+	services, err := c.Services().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return convertAll(services, ServiceFromAPI), nil
+}
+
+// GetService returns a service by UUID as a stable model
+func (c *Client) GetService(ctx context.Context, uuidStr string) (*Service, error) {
+	service, err := c.Services().Get(ctx, uuidStr)
+	if err != nil {
+		return nil, err
+	}
+	model := ServiceFromAPI(*service)
+	return &model, nil
+}
+
+// GetDeployment returns a deployment by UUID as a stable model
+func (c *Client) GetDeployment(ctx context.Context, uuidStr string) (*Deployment, error) {
+	deployment, err := c.Deployments().GetByUUID(ctx, uuidStr)
+	if err != nil {
+		return nil, err
+	}
+	model := DeploymentFromAPI(*deployment)
+	return &model, nil
+}
+
+// DeploymentHistory returns up to take of the most recent deployments of an application as
+// stable models, newest first
+func (c *Client) DeploymentHistory(ctx context.Context, appUUIDStr string, take int) ([]Deployment, error) {
+	deployments, err := c.Deployments().History(ctx, appUUIDStr, take)
+	if err != nil {
+		return nil, err
+	}
+	return convertAll(deployments, DeploymentFromAPI), nil
+}
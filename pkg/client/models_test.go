@@ -0,0 +1,80 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+)
+
+func TestApplicationFromAPI(t *testing.T) {
+	uuid, name, status := "app-1", "web", "running:healthy"
+	image, tag := "nginx", "1.27"
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	buildPack := coolify.ApplicationBuildPack("dockerimage")
+	environmentID := 3
+
+	app := ApplicationFromAPI(coolify.Application{
+		Uuid:                    &uuid,
+		Name:                    &name,
+		Status:                  &status,
+		BuildPack:               &buildPack,
+		DockerRegistryImageName: &image,
+		DockerRegistryImageTag:  &tag,
+		EnvironmentId:           &environmentID,
+		CreatedAt:               &created,
+	})
+
+	want := Application{
+		UUID:          uuid,
+		Name:          name,
+		Status:        status,
+		BuildPack:     "dockerimage",
+		DockerImage:   "nginx:1.27",
+		EnvironmentID: 3,
+		CreatedAt:     created,
+	}
+	if app != want {
+		t.Errorf("ApplicationFromAPI() = %+v, want %+v", app, want)
+	}
+
+	if empty := ApplicationFromAPI(coolify.Application{}); empty != (Application{}) {
+		t.Errorf("ApplicationFromAPI() of an empty application = %+v", empty)
+	}
+}
+
+func TestServerFromAPI(t *testing.T) {
+	uuid, ip, port := "srv-1", "10.0.0.1", 22
+	reachable := true
+
+	server := ServerFromAPI(coolify.Server{
+		Uuid:     &uuid,
+		Ip:       &ip,
+		Port:     &port,
+		Settings: &coolify.ServerSetting{IsReachable: &reachable},
+	})
+	if server.UUID != uuid || server.IP != ip || server.Port != port || !server.Reachable || server.Usable {
+		t.Errorf("ServerFromAPI() = %+v", server)
+	}
+}
+
+func TestDeploymentFromAPI(t *testing.T) {
+	uuid, status, updated := "dep-1", "finished", "invalid"
+
+	deployment := DeploymentFromAPI(coolify.ApplicationDeploymentQueue{
+		DeploymentUuid: &uuid,
+		Status:         &status,
+		UpdatedAt:      &updated,
+	})
+	if deployment.UUID != uuid || !deployment.Succeeded() || deployment.Failed() || deployment.Pending() {
+		t.Errorf("DeploymentFromAPI() = %+v", deployment)
+	}
+	if !deployment.UpdatedAt.IsZero() {
+		t.Errorf("invalid timestamp parsed as %v", deployment.UpdatedAt)
+	}
+
+	services := convertAll([]coolify.Service{{Uuid: &uuid}, {}}, ServiceFromAPI)
+	if len(services) != 2 || services[0].UUID != uuid {
+		t.Errorf("convertAll() = %+v", services)
+	}
+}
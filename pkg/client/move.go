@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ErrMoveNotApplied is returned when Coolify accepted a move but the resource stayed in its
+// environment, which happens with versions that ignore the project fields of updates
+var ErrMoveNotApplied = errors.New("coolify did not move the resource, this version does not support moving it through the API")
+
+// MoveTarget is the project environment a resource is moved to
+type MoveTarget struct {
+	ProjectUUID     string
+	EnvironmentName string
+	// EnvironmentID is compared with the environment of the resource after the move
+	EnvironmentID int
+}
+
+// Move moves an application to another project environment
+func (ac *ApplicationsClient) Move(ctx context.Context, uuidStr string, target MoveTarget) error {
+	appUUID, err := uuid.Parse(uuidStr)
+	if err != nil {
+		return fmt.Errorf("invalid UUID: %w", err)
+	}
+	if err := ac.client.move(ctx, "/applications/"+appUUID.String(), target); err != nil {
+		return fmt.Errorf("failed to move application: %w", err)
+	}
+	return nil
+}
+
+// Move moves a service to another project environment. Unlike Update, the request does not
+// replace the compose file.
+func (sc *ServicesClient) Move(ctx context.Context, uuidStr string, target MoveTarget) error {
+	serviceUUID, err := uuid.Parse(uuidStr)
+	if err != nil {
+		return fmt.Errorf("invalid UUID: %w", err)
+	}
+	if err := sc.client.move(ctx, "/services/"+serviceUUID.String(), target); err != nil {
+		return fmt.Errorf("failed to move service: %w", err)
+	}
+	return nil
+}
+
+// Move moves a database to another project environment. The database update endpoint does not
+// document the project fields, so older Coolify versions return ErrMoveNotApplied.
+func (dc *DatabasesClient) Move(ctx context.Context, uuidStr string, target MoveTarget) error {
+	dbUUID, err := uuid.Parse(uuidStr)
+	if err != nil {
+		return fmt.Errorf("invalid UUID: %w", err)
+	}
+	if err := dc.client.move(ctx, "/databases/"+dbUUID.String(), target); err != nil {
+		return fmt.Errorf("failed to move database: %w", err)
+	}
+	return nil
+}
+
+// move updates the project fields of the resource at path and reads it back, since Coolify
+// answers updates of fields it ignores with success. ErrMoveNotApplied is returned when the
+// resource is still in its old environment.
+func (c *Client) move(ctx context.Context, path string, target MoveTarget) error {
+	body := map[string]any{"project_uuid": target.ProjectUUID, "environment_name": target.EnvironmentName}
+	if err := c.doRequest(ctx, http.MethodPatch, path, body, nil); err != nil {
+		return err
+	}
+
+	var resource struct {
+		EnvironmentID *int `json:"environment_id"`
+	}
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &resource); err != nil {
+		return err
+	}
+	if resource.EnvironmentID == nil || *resource.EnvironmentID != target.EnvironmentID {
+		return ErrMoveNotApplied
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMove(t *testing.T) {
+	const appUUID = "0d1e2f3a-4b5c-4d6e-8f70-8192a3b4c5d6"
+	environmentID := 1
+	ignoreMove := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/applications/"+appUUID {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodPatch {
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["project_uuid"] != "project-2" || body["environment_name"] != "staging" {
+				t.Errorf("unexpected move body %v", body)
+			}
+			if !ignoreMove {
+				environmentID = 2
+			}
+			_, _ = w.Write([]byte(`{"uuid": "` + appUUID + `"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"uuid": appUUID, "environment_id": environmentID})
+	}))
+	defer server.Close()
+
+	c, err := New(nil, WithBaseURL(server.URL), WithToken("token"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	target := MoveTarget{ProjectUUID: "project-2", EnvironmentName: "staging", EnvironmentID: 2}
+	if err := c.Applications().Move(context.Background(), appUUID, target); err != nil {
+		t.Fatalf("Move() error = %v", err)
+	}
+
+	environmentID, ignoreMove = 1, true
+	if err := c.Applications().Move(context.Background(), appUUID, target); !errors.Is(err, ErrMoveNotApplied) {
+		t.Errorf("Move() ignored by the server error = %v, want ErrMoveNotApplied", err)
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+// OperationMethods maps the operation IDs of the bundled OpenAPI specification to the typed client
+// methods calling them, written as the accessor and method, e.g. "Applications().List". It is
+// checked against the code by TestOperationMethods, so it lists every operation the client wraps.
+var OperationMethods = map[string][]string{
+	"create-database-clickhouse":            {"Databases().CreateClickHouse"},
+	"create-database-dragonfly":             {"Databases().CreateDragonfly"},
+	"create-database-keydb":                 {"Databases().CreateKeyDB"},
+	"create-database-mariadb":               {"Databases().CreateMariaDB"},
+	"create-database-mongodb":               {"Databases().CreateMongoDB"},
+	"create-database-mysql":                 {"Databases().CreateMySQL"},
+	"create-database-postgresql":            {"Databases().CreatePostgreSQL"},
+	"create-database-redis":                 {"Databases().CreateRedis"},
+	"create-dockercompose-application":      {"Applications().CreateDockerCompose"},
+	"create-dockerfile-application":         {"Applications().CreateDockerfile"},
+	"create-dockerimage-application":        {"Applications().CreateDockerImage"},
+	"create-env-by-application-uuid":        {"Applications().CreateEnv"},
+	"create-env-by-service-uuid":            {"Services().CreateEnv"},
+	"create-private-deploy-key-application": {"Applications().CreatePrivateDeployKey"},
+	"create-private-github-app-application": {"Applications().CreatePrivateGithubApp"},
+	"create-private-key":                    {"PrivateKeys().Create"},
+	"create-project":                        {"Projects().Create"},
+	"create-public-application":             {"Applications().CreatePublic"},
+	"create-server":                         {"Servers().Create"},
+	"create-service":                        {"Services().Create"},
+	"delete-application-by-uuid":            {"Applications().Delete"},
+	"delete-database-by-uuid":               {"Databases().Delete"},
+	"delete-env-by-application-uuid":        {"Applications().DeleteEnv"},
+	"delete-env-by-service-uuid":            {"Services().DeleteEnv"},
+	"delete-private-key-by-uuid":            {"PrivateKeys().Delete"},
+	"delete-project-by-uuid":                {"Projects().Delete"},
+	"delete-server-by-uuid":                 {"Servers().Delete"},
+	"delete-service-by-uuid":                {"Services().Delete"},
+	"deploy-by-tag-or-uuid":                 {"Deployments().DeployApplicationWithOptions", "Deployments().DeployMultiple"},
+	"disable-api":                           {"System().DisableAPI"},
+	"enable-api":                            {"System().EnableAPI"},
+	"get-application-by-uuid":               {"Applications().Get"},
+	"get-application-logs-by-uuid":          {"Applications().GetLogs"},
+	"get-current-team":                      {"Teams().GetCurrent"},
+	"get-current-team-members":              {"Teams().GetCurrentMembers"},
+	"get-database-by-uuid":                  {"Databases().Get"},
+	"get-deployment-by-uuid":                {"Deployments().GetByUUID"},
+	"get-domains-by-server-uuid":            {"Servers().GetDomains"},
+	"get-environment-by-name-or-uuid":       {"Projects().GetEnvironment"},
+	"get-members-by-team-id":                {"Teams().GetMembers"},
+	"get-private-key-by-uuid":               {"PrivateKeys().Get"},
+	"get-project-by-uuid":                   {"Projects().Get"},
+	"get-resources-by-server-uuid":          {"Servers().GetResources"},
+	"get-server-by-uuid":                    {"Servers().Get"},
+	"get-service-by-uuid":                   {"Services().Get"},
+	"get-team-by-id":                        {"Teams().Get"},
+	"healthcheck":                           {"System().Healthcheck"},
+	"list-applications":                     {"Applications().List"},
+	"list-databases":                        {"Databases().List"},
+	"list-deployments":                      {"Deployments().ListAll"},
+	"list-deployments-by-app-uuid":          {"Deployments().List", "Deployments().ListWithPagination"},
+	"list-envs-by-application-uuid":         {"Applications().ListEnvs"},
+	"list-envs-by-service-uuid":             {"Services().ListEnvs"},
+	"list-private-keys":                     {"PrivateKeys().List"},
+	"list-projects":                         {"Projects().List"},
+	"list-resources":                        {"Resources().List"},
+	"list-servers":                          {"Servers().List"},
+	"list-services":                         {"Services().List"},
+	"list-teams":                            {"Teams().List"},
+	"restart-application-by-uuid":           {"Applications().Restart"},
+	"restart-database-by-uuid":              {"Databases().Restart"},
+	"restart-service-by-uuid":               {"Services().Restart"},
+	"start-application-by-uuid":             {"Applications().Start"},
+	"start-database-by-uuid":                {"Databases().Start"},
+	"start-service-by-uuid":                 {"Services().Start"},
+	"stop-application-by-uuid":              {"Applications().Stop"},
+	"stop-database-by-uuid":                 {"Databases().Stop"},
+	"stop-service-by-uuid":                  {"Services().Stop"},
+	"update-application-by-uuid":            {"Applications().Update"},
+	"update-database-by-uuid":               {"Databases().Update"},
+	"update-env-by-application-uuid":        {"Applications().UpdateEnv"},
+	"update-env-by-service-uuid":            {"Services().UpdateEnv"},
+	"update-envs-by-application-uuid":       {"Applications().UpdateEnvs"},
+	"update-envs-by-service-uuid":           {"Services().UpdateEnvs"},
+	"update-private-key":                    {"PrivateKeys().Update"},
+	"update-project-by-uuid":                {"Projects().Update"},
+	"update-server-by-uuid":                 {"Servers().Update"},
+	"update-service-by-uuid":                {"Services().Update"},
+	"validate-server-by-uuid":               {"Servers().Validate"},
+	"version":                               {"System().Version"},
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hongkongkiwi/coolifyme/internal/compat"
+	"github.com/hongkongkiwi/coolifyme/spec"
+)
+
+// generatedName returns the name oapi-codegen gives the client method of an operation ID
+func generatedName(operationID string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(operationID, func(r rune) bool { return r == '-' || r == '_' }) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// TestOperationMethods scans the client for calls of generated API methods and checks that
+// OperationMethods lists exactly the methods making them
+func TestOperationMethods(t *testing.T) {
+	operations, err := compat.ParseOperations(spec.OpenAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	operationIDs := make(map[string]string, len(operations))
+	for _, operation := range operations {
+		operationIDs[generatedName(operation.ID)] = operation.ID
+	}
+
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := make(map[string][]string)
+	for _, file := range packages["client"].Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Body == nil {
+				continue
+			}
+			recv := fn.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			if index, ok := recv.(*ast.IndexExpr); ok {
+				recv = index.X
+			}
+			receiver, ok := recv.(*ast.Ident)
+			if !ok || !strings.HasSuffix(receiver.Name, "Client") || receiver.Name == "Client" {
+				continue
+			}
+			method := strings.TrimSuffix(receiver.Name, "Client") + "()." + fn.Name.Name
+
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				sel, ok := n.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				if api, ok := sel.X.(*ast.SelectorExpr); !ok || api.Sel.Name != "API" {
+					return true
+				}
+				// Listings are streamed from the raw response of the plain operation method
+				name := strings.TrimSuffix(sel.Sel.Name, "WithResponse")
+				id, ok := operationIDs[name]
+				if !ok && !strings.HasSuffix(sel.Sel.Name, "WithResponse") {
+					return true
+				}
+				if !ok {
+					t.Errorf("%s calls %s, which is not an operation of the bundled spec", method, sel.Sel.Name)
+					return true
+				}
+				found[id] = append(found[id], method)
+				return true
+			})
+		}
+	}
+
+	for id, methods := range found {
+		sort.Strings(methods)
+		methods = slices.Compact(methods)
+		want := append([]string(nil), OperationMethods[id]...)
+		sort.Strings(want)
+		if !reflect.DeepEqual(methods, want) {
+			t.Errorf("OperationMethods[%q] = %q, want %q", id, want, methods)
+		}
+	}
+	for id := range OperationMethods {
+		if _, ok := found[id]; !ok {
+			t.Errorf("OperationMethods lists %q, but no client method calls it", id)
+		}
+	}
+}
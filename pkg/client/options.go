@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Option customizes a Client created with New
+type Option func(*options)
+
+// RequestEditor is called with every outgoing request before it is sent, e.g. to add headers
+type RequestEditor func(ctx context.Context, req *http.Request) error
+
+// ResponseEditor is called with every response before it is returned to the caller, e.g. to
+// record metrics or turn custom error payloads into errors
+type ResponseEditor func(ctx context.Context, resp *http.Response) error
+
+// Middleware wraps the transport that sends authenticated requests, allowing arbitrary pre
+// and post request handling such as tracing or request signing
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RetryPolicy controls how requests failing with a network error or a 429, 502, 503 or 504
+// response are retried. Only reads are retried after such failures, since the server may have
+// acted on a write or deployment before a gateway gave up; other requests are only retried when
+// the connection could not be established, so they were never sent. Only requests without a
+// body or with a replayable body are retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+	// Delay is the wait before the first retry; it doubles with every further retry
+	Delay time.Duration
+	// MaxDelay caps the wait between retries (0 means no cap)
+	MaxDelay time.Duration
+}
+
+// options holds the settings collected from the config and all Option values
+type options struct {
+	baseURL    string
+	token      string
+	userAgent  string
+	headers    http.Header
+	httpClient *http.Client
+	retry      *RetryPolicy
+	logger     *slog.Logger
+	editors    []RequestEditor
+	responses  []ResponseEditor
+	middleware []Middleware
+	// maxResponseSize is the largest response body accepted, 0 for no limit
+	maxResponseSize int64
+	// strictDecode fails requests whose responses have fields unknown to the API types
+	strictDecode bool
+	// maxIdleConnsPerHost is the number of idle connections kept for reuse by the default transport
+	maxIdleConnsPerHost int
+	// readOnly rejects every request that would change something
+	readOnly bool
+}
+
+// WithBaseURL sets the Coolify API base URL, e.g. https://coolify.example.com/api/v1
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithToken sets the API token used to authenticate requests
+func WithToken(token string) Option {
+	return func(o *options) {
+		o.token = token
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for requests. Its transport, timeout, cookie jar
+// and redirect policy are kept; authentication, logging and caching are layered on top.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithHeader sets a header sent with every request, e.g. the credentials required by an
+// authenticating proxy such as Cloudflare Access in front of Coolify. A later WithHeader for the
+// same name replaces the earlier value, and the header replaces the default value set by the client.
+func WithHeader(name, value string) Option {
+	return func(o *options) {
+		if o.headers == nil {
+			o.headers = make(http.Header)
+		}
+		o.headers.Set(name, value)
+	}
+}
+
+// WithMaxResponseSize sets the largest response body in bytes the client accepts. Larger
+// responses fail with ErrResponseTooLarge instead of exhausting memory. The default is
+// DefaultMaxResponseSize; 0 disables the limit.
+func WithMaxResponseSize(size int64) Option {
+	return func(o *options) {
+		o.maxResponseSize = size
+	}
+}
+
+// WithRetryPolicy enables retrying of failed requests
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = &policy
+	}
+}
+
+// WithLogger sets the logger used for request and response debug logging
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithStrictDecoding makes reads fail with an UnknownFieldsError when a response contains fields
+// the API types do not declare; for requests that change something the fields are logged as a
+// warning and the request succeeds. Without it such fields are dropped and logged at debug
+// level, which helps to spot drift between the client and the server version.
+func WithStrictDecoding(strict bool) Option {
+	return func(o *options) {
+		o.strictDecode = strict
+	}
+}
+
+// WithReadOnly makes the client reject every request that is not a read with a ReadOnlyError
+// before it is sent, including deployments and start, stop and restart actions
+func WithReadOnly(readOnly bool) Option {
+	return func(o *options) {
+		o.readOnly = readOnly
+	}
+}
+
+// WithMaxIdleConnsPerHost sets the number of idle connections to the server kept open for reuse
+// when the client creates its own transport. The default is DefaultMaxIdleConnsPerHost; it has no
+// effect with a transport given by WithHTTPClient.
+func WithMaxIdleConnsPerHost(n int) Option {
+	return func(o *options) {
+		o.maxIdleConnsPerHost = n
+	}
+}
+
+// WithRequestEditor adds a function that can modify every outgoing request
+func WithRequestEditor(editor RequestEditor) Option {
+	return func(o *options) {
+		o.editors = append(o.editors, editor)
+	}
+}
+
+// WithResponseEditor adds a function that is called with every response. An error returned by
+// the function fails the request.
+func WithResponseEditor(editor ResponseEditor) Option {
+	return func(o *options) {
+		o.responses = append(o.responses, editor)
+	}
+}
+
+// WithMiddleware adds a transport middleware. Middleware sees requests after authentication and
+// request editors were applied, and before retries; the first middleware added is the outermost.
+func WithMiddleware(middleware Middleware) Option {
+	return func(o *options) {
+		o.middleware = append(o.middleware, middleware)
+	}
+}
+
+// retryTransport retries requests according to a RetryPolicy
+type retryTransport struct {
+	policy RetryPolicy
+	base   http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	delay := t.policy.Delay
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= t.policy.MaxRetries || !retryable(req, resp, err) {
+			return resp, err
+		}
+
+		// Replay the request body, giving up when it cannot be replayed
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req.Body = body
+		}
+
+		wait := delay
+		if resp != nil {
+			if seconds, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil && seconds > 0 {
+				wait = time.Duration(seconds) * time.Second
+			}
+			_ = resp.Body.Close()
+		}
+		if t.policy.MaxDelay > 0 && wait > t.policy.MaxDelay {
+			wait = t.policy.MaxDelay
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// retryable reports whether a request outcome is worth retrying. Requests that may change
+// something are only retried when they never reached the server.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if RequiredAbility(req.Method, req.URL.Path) != AbilityRead {
+		return err != nil && notSent(err)
+	}
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// notSent reports whether a request failed while connecting, before any of it was sent
+func notSent(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
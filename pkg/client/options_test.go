@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+)
+
+func TestWithHeader(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		BaseURL:  server.URL,
+		APIToken: "token",
+		Headers:  map[string]string{"CF-Access-Client-Id": "profile-id", "CF-Access-Client-Secret": "secret"},
+	}
+	c, err := New(cfg, WithHeader("cf-access-client-id", "flag-id"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := c.doRequest(context.Background(), http.MethodGet, "/version", nil, nil); err != nil {
+		t.Fatalf("doRequest() error = %v", err)
+	}
+	if id := got.Get("CF-Access-Client-Id"); id != "flag-id" {
+		t.Errorf("CF-Access-Client-Id = %q, want the flag value to override the profile", id)
+	}
+	if secret := got.Get("CF-Access-Client-Secret"); secret != "secret" {
+		t.Errorf("CF-Access-Client-Secret = %q, want secret", secret)
+	}
+	if auth := got.Get("Authorization"); auth != "Bearer token" {
+		t.Errorf("Authorization = %q, want Bearer token", auth)
+	}
+
+	logged := formatHeaders(got, http.Header{"Cf-Access-Client-Secret": {"secret"}})
+	if strings.Contains(logged, "secret") || strings.Contains(logged, "Bearer token") {
+		t.Errorf("formatHeaders() leaked a credential: %s", logged)
+	}
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+)
+
+// DefaultPageSize is the number of items requested per page when no page size is given
+const DefaultPageSize = 50
+
+// maxPages bounds page walking so a misbehaving server cannot cause an endless loop
+const maxPages = 10000
+
+// ErrPaginationIgnored is returned when the server answers two different offsets with the same page
+var ErrPaginationIgnored = errors.New("the server ignored the page offset, this endpoint does not support pagination")
+
+// PageFetcher fetches up to take items starting at offset skip
+type PageFetcher[T any] func(ctx context.Context, skip, take int) ([]T, error)
+
+// Pager walks a paginated list endpoint one page at a time, handling skip and take.
+// It stops after a short page and reports errors through Err:
+//
+//	pager := c.Deployments().ListIter(appUUID, 0)
+//	for pager.NextPage(ctx) {
+//		for _, deployment := range pager.Page() {
+//			...
+//		}
+//	}
+//	if err := pager.Err(); err != nil {
+//		...
+//	}
+type Pager[T any] struct {
+	fetch    PageFetcher[T]
+	pageSize int
+	skip     int
+	pages    int
+	page     []T
+	done     bool
+	err      error
+}
+
+// NewPager returns a pager over fetch. A pageSize of 0 or less uses DefaultPageSize.
+func NewPager[T any](fetch PageFetcher[T], pageSize int) *Pager[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return &Pager[T]{fetch: fetch, pageSize: pageSize}
+}
+
+// NextPage fetches the next page. It returns false when there are no more items or an error
+// occurred; check Err afterwards.
+func (p *Pager[T]) NextPage(ctx context.Context) bool {
+	if p.done || p.err != nil {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		p.err = err
+		return false
+	}
+	if p.pages >= maxPages {
+		p.err = fmt.Errorf("pagination stopped after %d pages", maxPages)
+		return false
+	}
+
+	page, err := p.fetch(ctx, p.skip, p.pageSize)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	if p.pages > 0 && len(page) > 0 && len(p.page) > 0 && reflect.DeepEqual(page[0], p.page[0]) {
+		p.err = ErrPaginationIgnored
+		return false
+	}
+
+	p.pages++
+	p.skip += len(page)
+	p.page = page
+	// A short page is the last one; a page larger than requested means the server ignored take
+	// and returned everything
+	if len(page) != p.pageSize {
+		p.done = true
+	}
+	return len(page) > 0
+}
+
+// Page returns the items of the current page
+func (p *Pager[T]) Page() []T {
+	return p.page
+}
+
+// Err returns the error that stopped the pager, if any
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// All returns an iterator over the items of all remaining pages. An error ends the iteration
+// and is yielded together with the zero value of T.
+func (p *Pager[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for p.NextPage(ctx) {
+			for _, item := range p.page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+		if p.err != nil {
+			var zero T
+			yield(zero, p.err)
+		}
+	}
+}
+
+// ListAllPages walks every page of a paginated endpoint and returns all items
+func ListAllPages[T any](ctx context.Context, fetch PageFetcher[T], pageSize int) ([]T, error) {
+	var items []T
+	pager := NewPager(fetch, pageSize)
+	for pager.NextPage(ctx) {
+		items = append(items, pager.Page()...)
+	}
+	if err := pager.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// sliceFetcher serves items from a slice, optionally ignoring the offset like an old server
+func sliceFetcher(items []int, ignoreSkip bool, calls *int) PageFetcher[int] {
+	return func(_ context.Context, skip, take int) ([]int, error) {
+		*calls++
+		if ignoreSkip {
+			skip = 0
+		}
+		if skip >= len(items) {
+			return nil, nil
+		}
+		return items[skip:min(skip+take, len(items))], nil
+	}
+}
+
+func TestListAllPages(t *testing.T) {
+	items := make([]int, 25)
+	for i := range items {
+		items[i] = i
+	}
+
+	tests := []struct {
+		name      string
+		items     []int
+		pageSize  int
+		wantCalls int
+	}{
+		{"several pages", items, 10, 3},
+		{"exact multiple", items[:20], 10, 3},
+		{"single short page", items[:3], 10, 1},
+		{"empty", nil, 10, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			got, err := ListAllPages(context.Background(), sliceFetcher(tt.items, false, &calls), tt.pageSize)
+			if err != nil {
+				t.Fatalf("ListAllPages() error = %v", err)
+			}
+			if len(got) != len(tt.items) {
+				t.Errorf("ListAllPages() returned %d items, want %d", len(got), len(tt.items))
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("fetched %d pages, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestPagerIgnoredSkip(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	calls := 0
+	_, err := ListAllPages(context.Background(), sliceFetcher(items, true, &calls), 2)
+	if !errors.Is(err, ErrPaginationIgnored) {
+		t.Fatalf("ListAllPages() error = %v, want ErrPaginationIgnored", err)
+	}
+}
+
+func TestPagerAllStopsEarly(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	calls := 0
+	pager := NewPager(sliceFetcher(items, false, &calls), 2)
+
+	var seen []int
+	for item, err := range pager.All(context.Background()) {
+		if err != nil {
+			t.Fatalf("All() error = %v", err)
+		}
+		seen = append(seen, item)
+		if len(seen) == 3 {
+			break
+		}
+	}
+	if len(seen) != 3 || calls != 2 {
+		t.Errorf("saw %v after %d fetches, want 3 items after 2", seen, calls)
+	}
+}
+
+func TestPagerCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	pager := NewPager(sliceFetcher([]int{1}, false, &calls), 0)
+	if pager.NextPage(ctx) {
+		t.Fatal("NextPage() = true on a cancelled context")
+	}
+	if !errors.Is(pager.Err(), context.Canceled) || calls != 0 {
+		t.Errorf("Err() = %v after %d fetches, want context.Canceled without fetching", pager.Err(), calls)
+	}
+}
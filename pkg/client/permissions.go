@@ -0,0 +1,249 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Abilities of Coolify API tokens
+const (
+	AbilityRoot          = "root"
+	AbilityRead          = "read"
+	AbilityReadSensitive = "read:sensitive"
+	AbilityWrite         = "write"
+	AbilityDeploy        = "deploy"
+)
+
+// PermissionError is returned when the API rejects a request because the token lacks an ability
+type PermissionError struct {
+	Method string
+	Path   string
+	// Required is the ability the request needs
+	Required string
+	// Message is the error message returned by the API
+	Message string
+}
+
+func (e *PermissionError) Error() string {
+	msg := fmt.Sprintf("permission denied for %s %s: the API token needs the '%s' permission", e.Method, e.Path, e.Required)
+	if e.Message != "" {
+		msg += " (" + e.Message + ")"
+	}
+	return msg
+}
+
+// IsPermissionError reports whether err was caused by missing token permissions
+func IsPermissionError(err error) bool {
+	var permErr *PermissionError
+	return errors.As(err, &permErr)
+}
+
+// RequiredAbility returns the token ability Coolify requires for a request: deploy for
+// deployments and start/stop/restart actions, read for other reads and write for changes.
+// Enabling and disabling the API and validating a server are GET requests that change state,
+// so they count as writes.
+func RequiredAbility(method, path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	path = strings.TrimSuffix(path, "/")
+	switch {
+	case strings.HasSuffix(path, "/deploy"), strings.HasSuffix(path, "/start"),
+		strings.HasSuffix(path, "/stop"), strings.HasSuffix(path, "/restart"):
+		return AbilityDeploy
+	case strings.HasSuffix(path, "/enable"), strings.HasSuffix(path, "/disable"),
+		strings.Contains(path, "/servers/") && strings.HasSuffix(path, "/validate"):
+		return AbilityWrite
+	case method == http.MethodGet:
+		return AbilityRead
+	}
+	return AbilityWrite
+}
+
+// apiError converts an unexpected API response into an error. 403 responses become a
+// PermissionError naming the missing ability. The status is kept for StatusCode.
+func apiError(resp *http.Response, body []byte) error {
+	if resp == nil {
+		return fmt.Errorf("API error: no response")
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		return &statusError{StatusCode: resp.StatusCode, err: fmt.Errorf("API error: %s", resp.Status)}
+	}
+
+	permErr := &PermissionError{}
+	if resp.Request != nil {
+		permErr.Method = resp.Request.Method
+		permErr.Path = resp.Request.URL.Path
+		permErr.Required = RequiredAbility(resp.Request.Method, resp.Request.URL.Path)
+	}
+
+	var payload struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &payload) == nil && payload.Message != "" {
+		permErr.Message = payload.Message
+		// Coolify answers "Missing required permissions: write"
+		if _, abilities, ok := strings.Cut(payload.Message, "permissions:"); ok {
+			if ability := strings.TrimSpace(strings.Split(abilities, ",")[0]); ability != "" {
+				permErr.Required = ability
+			}
+		}
+	}
+	return &statusError{StatusCode: resp.StatusCode, err: permErr}
+}
+
+// AbilityCheck is the result of probing a single token ability
+type AbilityCheck struct {
+	Ability string `json:"ability"`
+	// Granted is nil when the ability could not be determined
+	Granted *bool  `json:"granted"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// TokenInfo describes the team and the abilities of the API token
+type TokenInfo struct {
+	TeamID    int            `json:"team_id,omitempty"`
+	TeamName  string         `json:"team_name,omitempty"`
+	Abilities []AbilityCheck `json:"abilities"`
+}
+
+// Root reports whether every ability was granted, as is the case for root tokens
+func (t *TokenInfo) Root() bool {
+	for _, check := range t.Abilities {
+		if check.Granted == nil || !*check.Granted {
+			return false
+		}
+	}
+	return len(t.Abilities) > 0
+}
+
+// Lacks reports whether the token is known not to have an ability. Abilities that could not
+// be determined are not reported as missing. Root tokens have every ability.
+func (t *TokenInfo) Lacks(ability string) bool {
+	for _, check := range t.Abilities {
+		if check.Ability == ability {
+			return check.Granted != nil && !*check.Granted
+		}
+	}
+	return false
+}
+
+// Scope describes the token: "root", "read-only", "none" or the list of granted abilities
+func (t *TokenInfo) Scope() string {
+	if t.Root() {
+		return "root"
+	}
+	var granted []string
+	for _, check := range t.Abilities {
+		if check.Granted != nil && *check.Granted {
+			granted = append(granted, check.Ability)
+		}
+	}
+	switch {
+	case len(granted) == 0:
+		return "none"
+	case !t.Lacks(AbilityRead) && t.Lacks(AbilityWrite) && t.Lacks(AbilityDeploy):
+		return "read-only"
+	}
+	return strings.Join(granted, ", ")
+}
+
+// nonexistentUUID is the resource UUID of ability probes, which no resource ever has
+const nonexistentUUID = "00000000-0000-0000-0000-000000000000"
+
+// TokenInfo determines the team and abilities of the API token. The API has no endpoint
+// describing tokens, so abilities are probed with requests that cannot change anything:
+// writes and deployments target a resource that does not exist and fail with a 404 when allowed.
+func (c *Client) TokenInfo(ctx context.Context) (*TokenInfo, error) {
+	info := &TokenInfo{}
+	granted := func(ok bool) *bool { return &ok }
+
+	var team struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	status, body, err := c.probe(ctx, http.MethodGet, "/teams/current", nil)
+	if err != nil {
+		return nil, err
+	}
+	switch status {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, fmt.Errorf("the API token is invalid or expired")
+	case http.StatusForbidden:
+		info.Abilities = append(info.Abilities, AbilityCheck{Ability: AbilityRead, Granted: granted(false)})
+		return info, nil
+	default:
+		return nil, fmt.Errorf("failed to get current team: API error: %d", status)
+	}
+	if err := json.Unmarshal(body, &team); err != nil {
+		return nil, fmt.Errorf("failed to decode current team: %w", err)
+	}
+	info.TeamID, info.TeamName = team.ID, team.Name
+	info.Abilities = append(info.Abilities, AbilityCheck{Ability: AbilityRead, Granted: granted(true)})
+
+	// Private keys are only returned to tokens that may read sensitive data
+	sensitive := AbilityCheck{Ability: AbilityReadSensitive}
+	var keys []map[string]any
+	if err := c.doRequest(ctx, http.MethodGet, "/security/keys", nil, &keys); err == nil {
+		for _, key := range keys {
+			value, _ := key["private_key"].(string)
+			sensitive.Granted = granted(value != "")
+			break
+		}
+		if sensitive.Granted == nil {
+			sensitive.Detail = "cannot tell without a private key in the team"
+		}
+	}
+	info.Abilities = append(info.Abilities, sensitive)
+
+	// Updating a project that does not exist is rejected after the permission check, so allowed
+	// requests find nothing to change
+	write := AbilityCheck{Ability: AbilityWrite}
+	if status, _, err := c.probe(ctx, http.MethodPatch, "/projects/"+nonexistentUUID, map[string]any{}); err == nil {
+		write.Granted = granted(status != http.StatusForbidden)
+	}
+	info.Abilities = append(info.Abilities, write)
+
+	// Deploying a resource that does not exist is rejected after the permission check
+	deploy := AbilityCheck{Ability: AbilityDeploy}
+	if status, _, err := c.probe(ctx, http.MethodGet, "/deploy?uuid="+nonexistentUUID, nil); err == nil {
+		deploy.Granted = granted(status != http.StatusForbidden)
+	}
+	info.Abilities = append(info.Abilities, deploy)
+
+	return info, nil
+}
+
+// probe performs a raw API request and returns the response status and body without
+// treating error statuses as failures
+func (c *Client) probe(ctx context.Context, method, path string, body any) (int, []byte, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.baseURL, "/")+path, reader)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return resp.StatusCode, data, nil
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestRequiredAbility(t *testing.T) {
+	tests := []struct {
+		method, path, want string
+	}{
+		{http.MethodGet, "/api/v1/applications", AbilityRead},
+		{http.MethodPost, "/api/v1/applications/public", AbilityWrite},
+		{http.MethodDelete, "/api/v1/servers/abc", AbilityWrite},
+		{http.MethodGet, "/api/v1/applications/abc/restart", AbilityDeploy},
+		{http.MethodGet, "/api/v1/deploy?uuid=abc", AbilityDeploy},
+		{http.MethodGet, "/api/v1/enable", AbilityWrite},
+		{http.MethodGet, "/api/v1/disable", AbilityWrite},
+		{http.MethodGet, "/api/v1/servers/abc/validate", AbilityWrite},
+		{http.MethodGet, "/api/v1/servers/abc/domains", AbilityRead},
+	}
+
+	for _, tt := range tests {
+		if got := RequiredAbility(tt.method, tt.path); got != tt.want {
+			t.Errorf("RequiredAbility(%s, %s) = %s, want %s", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestAPIErrorPermission(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusForbidden,
+		Status:     "403 Forbidden",
+		Request:    &http.Request{Method: http.MethodPatch, URL: &url.URL{Path: "/api/v1/projects/abc"}},
+	}
+
+	err := apiError(resp, []byte(`{"message":"Missing required permissions: write"}`))
+	var permErr *PermissionError
+	if !errors.As(err, &permErr) {
+		t.Fatalf("apiError() = %v, want PermissionError", err)
+	}
+	if permErr.Required != AbilityWrite || permErr.Method != http.MethodPatch {
+		t.Errorf("PermissionError = %+v", permErr)
+	}
+	if status := StatusCode(fmt.Errorf("failed to update project: %w", err)); status != http.StatusForbidden {
+		t.Errorf("StatusCode() = %d, want 403", status)
+	}
+
+	resp.StatusCode, resp.Status = http.StatusNotFound, "404 Not Found"
+	if err := apiError(resp, nil); IsPermissionError(err) || err.Error() != "API error: 404 Not Found" || StatusCode(err) != http.StatusNotFound {
+		t.Errorf("apiError() for 404 = %v", err)
+	}
+	if status := StatusCode(errors.New("request failed")); status != 0 {
+		t.Errorf("StatusCode() without a response = %d, want 0", status)
+	}
+}
+
+func TestTokenInfoScope(t *testing.T) {
+	check := func(ability string, granted *bool) AbilityCheck {
+		return AbilityCheck{Ability: ability, Granted: granted}
+	}
+	yes, no := true, false
+
+	tests := []struct {
+		name       string
+		abilities  []AbilityCheck
+		want       string
+		lacksWrite bool
+	}{
+		{"root", []AbilityCheck{check(AbilityRead, &yes), check(AbilityWrite, &yes), check(AbilityDeploy, &yes)}, "root", false},
+		{"read-only", []AbilityCheck{check(AbilityRead, &yes), check(AbilityReadSensitive, nil), check(AbilityWrite, &no), check(AbilityDeploy, &no)}, "read-only", true},
+		{"deploy", []AbilityCheck{check(AbilityRead, &yes), check(AbilityWrite, &no), check(AbilityDeploy, &yes)}, "read, deploy", true},
+		{"unknown write", []AbilityCheck{check(AbilityRead, &yes), check(AbilityWrite, nil), check(AbilityDeploy, &no)}, "read", false},
+		{"forbidden", []AbilityCheck{check(AbilityRead, &no)}, "none", false},
+	}
+
+	for _, tt := range tests {
+		info := &TokenInfo{Abilities: tt.abilities}
+		if got := info.Scope(); got != tt.want {
+			t.Errorf("%s: Scope() = %q, want %q", tt.name, got, tt.want)
+		}
+		if got := info.Lacks(AbilityWrite); got != tt.lacksWrite {
+			t.Errorf("%s: Lacks(write) = %v, want %v", tt.name, got, tt.lacksWrite)
+		}
+	}
+}
+
+func TestTokenInfoProbesChangeNothing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/teams/current":
+			_, _ = w.Write([]byte(`{"id": 1, "name": "ops"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/security/keys":
+			_, _ = w.Write([]byte(`[]`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/projects/"+nonexistentUUID:
+			http.NotFound(w, r)
+		case r.Method == http.MethodGet && r.URL.Path == "/deploy":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			t.Errorf("unexpected probe %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusTeapot)
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(nil, WithBaseURL(server.URL), WithToken("token"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	info, err := c.TokenInfo(context.Background())
+	if err != nil {
+		t.Fatalf("TokenInfo() error = %v", err)
+	}
+	if info.TeamName != "ops" || info.Lacks(AbilityWrite) || !info.Lacks(AbilityDeploy) {
+		t.Errorf("TokenInfo() = %+v", info)
+	}
+}
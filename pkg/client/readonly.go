@@ -0,0 +1,47 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ReadOnlyError is returned for requests that would change something when the client is read-only
+type ReadOnlyError struct {
+	Method string
+	Path   string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("refusing %s %s: the client is read-only (read_only profile setting or --read-only)", e.Method, e.Path)
+}
+
+// IsReadOnlyError reports whether err was caused by a request rejected in read-only mode
+func IsReadOnlyError(err error) bool {
+	var readOnlyErr *ReadOnlyError
+	return errors.As(err, &readOnlyErr)
+}
+
+// ReadOnly reports whether the client rejects changes. Callers changing a server by other means,
+// e.g. docker over SSH, check it to honor read-only mode as well.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// readOnlyTransport rejects every request that is not a plain read before it is sent. Coolify
+// triggers deployments, start, stop and restart actions, enabling and disabling the API and
+// server validation with GET requests, so those are rejected as well.
+type readOnlyTransport struct {
+	base http.RoundTripper
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead ||
+		RequiredAbility(req.Method, req.URL.Path) != AbilityRead {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, &ReadOnlyError{Method: req.Method, Path: req.URL.Path}
+	}
+	return t.base.RoundTrip(req)
+}
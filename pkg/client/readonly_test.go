@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hongkongkiwi/coolifyme/internal/config"
+)
+
+func TestReadOnly(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c, err := New(&config.Config{BaseURL: server.URL, APIToken: "token", ReadOnly: true})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if err := c.doRequest(ctx, http.MethodGet, "/applications", nil, nil); err != nil {
+		t.Fatalf("GET error = %v, want reads to pass", err)
+	}
+	for _, req := range []struct{ method, path string }{
+		{http.MethodPost, "/projects"},
+		{http.MethodDelete, "/applications/abc"},
+		{http.MethodGet, "/applications/abc/restart"},
+		{http.MethodGet, "/deploy?uuid=abc"},
+		{http.MethodGet, "/disable"},
+		{http.MethodGet, "/enable"},
+		{http.MethodGet, "/servers/abc/validate"},
+	} {
+		err := c.doRequest(ctx, req.method, req.path, map[string]string{}, nil)
+		if !IsReadOnlyError(err) {
+			t.Errorf("%s %s error = %v, want a ReadOnlyError", req.method, req.path, err)
+		}
+	}
+	if len(requests) != 1 {
+		t.Errorf("server received %v, want only the read", requests)
+	}
+
+	// The option overrides the profile setting
+	c, err = New(&config.Config{BaseURL: server.URL, APIToken: "token", ReadOnly: true}, WithReadOnly(false))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := c.doRequest(ctx, http.MethodPost, "/projects", map[string]string{}, nil); err != nil {
+		t.Errorf("POST error = %v, want it to pass without read-only mode", err)
+	}
+}
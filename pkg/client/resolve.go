@@ -0,0 +1,213 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ResourceKind identifies a type of resource whose UUIDs can be resolved from a prefix
+type ResourceKind string
+
+// Resource kinds supported by ResolveUUID
+const (
+	KindApplication ResourceKind = "application"
+	KindService     ResourceKind = "service"
+	KindDatabase    ResourceKind = "database"
+	KindServer      ResourceKind = "server"
+	KindProject     ResourceKind = "project"
+	KindPrivateKey  ResourceKind = "private key"
+	KindSource      ResourceKind = "source"
+)
+
+// MinUUIDPrefix is the shortest prefix ResolveUUID will try to expand
+const MinUUIDPrefix = 4
+
+// ResourceRef is the UUID and name of a resource
+type ResourceRef struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+	// EnvironmentID is the environment of an application, service or database
+	EnvironmentID int `json:"environment_id,omitempty"`
+}
+
+// ListRefs lists the UUIDs and names of all resources of a kind
+func (c *Client) ListRefs(ctx context.Context, kind ResourceKind) ([]ResourceRef, error) {
+	var refs []ResourceRef
+	add := func(id, name *string, environmentID *int) {
+		if id != nil && *id != "" {
+			ref := ResourceRef{UUID: *id, EnvironmentID: value(environmentID)}
+			if name != nil {
+				ref.Name = *name
+			}
+			refs = append(refs, ref)
+		}
+	}
+
+	switch kind {
+	case KindApplication:
+		apps, err := c.Applications().List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, app := range apps {
+			add(app.Uuid, app.Name, app.EnvironmentId)
+		}
+	case KindService:
+		services, err := c.Services().List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, service := range services {
+			add(service.Uuid, service.Name, service.EnvironmentId)
+		}
+	case KindDatabase:
+		result, err := c.Databases().List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// The database list is returned as raw JSON
+		if err := json.Unmarshal([]byte(result), &refs); err != nil {
+			return nil, fmt.Errorf("failed to parse databases: %w", err)
+		}
+	case KindServer:
+		servers, err := c.Servers().List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, server := range servers {
+			add(server.Uuid, server.Name, nil)
+		}
+	case KindProject:
+		projects, err := c.Projects().List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range projects {
+			add(project.Uuid, project.Name, nil)
+		}
+	case KindPrivateKey:
+		keys, err := c.PrivateKeys().List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			add(key.Uuid, key.Name, nil)
+		}
+	case KindSource:
+		sources, err := c.Sources().List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, source := range sources {
+			refs = append(refs, ResourceRef{UUID: source.UUID, Name: source.Name})
+		}
+	default:
+		return nil, fmt.Errorf("unknown resource kind '%s'", kind)
+	}
+	return refs, nil
+}
+
+// FindByName returns the resources of a kind named name, ignoring case. Names are not unique in
+// Coolify, so several resources can match.
+func (c *Client) FindByName(ctx context.Context, kind ResourceKind, name string) ([]ResourceRef, error) {
+	refs, err := c.ListRefs(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	return refsNamed(refs, name), nil
+}
+
+// FindByNameInEnvironment returns the applications, services or databases named name in an
+// environment of a project, given by name or UUID, ignoring case. Other kinds are not part of an
+// environment; use FindByName for them.
+func (c *Client) FindByNameInEnvironment(ctx context.Context, kind ResourceKind, name, projectUUID, environmentNameOrUUID string) ([]ResourceRef, error) {
+	switch kind {
+	case KindApplication, KindService, KindDatabase:
+	default:
+		return nil, fmt.Errorf("%ss are not part of an environment", kind)
+	}
+
+	environment, err := c.Projects().GetEnvironment(ctx, projectUUID, environmentNameOrUUID)
+	if err != nil {
+		return nil, err
+	}
+	if environment.Id == nil {
+		return nil, fmt.Errorf("environment '%s' has no ID", environmentNameOrUUID)
+	}
+
+	refs, err := c.FindByName(ctx, kind, name)
+	if err != nil {
+		return nil, err
+	}
+	var matches []ResourceRef
+	for _, ref := range refs {
+		if ref.EnvironmentID == *environment.Id {
+			matches = append(matches, ref)
+		}
+	}
+	return matches, nil
+}
+
+// refsNamed returns the refs named name, ignoring case and surrounding whitespace
+func refsNamed(refs []ResourceRef, name string) []ResourceRef {
+	name = strings.TrimSpace(name)
+	var matches []ResourceRef
+	for _, ref := range refs {
+		if name != "" && strings.EqualFold(strings.TrimSpace(ref.Name), name) {
+			matches = append(matches, ref)
+		}
+	}
+	return matches
+}
+
+// ResolveUUID expands a unique UUID prefix to the full UUID of a resource, like git and docker
+// do for object IDs. Full UUIDs, exact matches and prefixes shorter than MinUUIDPrefix are
+// returned unchanged, as are prefixes matching nothing so the caller reports its usual
+// not-found error. A prefix matching several resources is an error listing the candidates.
+func (c *Client) ResolveUUID(ctx context.Context, kind ResourceKind, prefix string) (string, error) {
+	if len(prefix) < MinUUIDPrefix {
+		return prefix, nil
+	}
+	if _, err := uuid.Parse(prefix); err == nil && len(prefix) == 36 {
+		return prefix, nil
+	}
+
+	refs, err := c.ListRefs(ctx, kind)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s UUID '%s': %w", kind, prefix, err)
+	}
+
+	var matches []ResourceRef
+	for _, ref := range refs {
+		if ref.UUID == prefix {
+			return prefix, nil
+		}
+		if strings.HasPrefix(ref.UUID, prefix) {
+			matches = append(matches, ref)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return prefix, nil
+	case 1:
+		return matches[0].UUID, nil
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].UUID < matches[j].UUID })
+	candidates := make([]string, 0, len(matches))
+	for _, ref := range matches {
+		if ref.Name != "" {
+			candidates = append(candidates, fmt.Sprintf("%s (%s)", ref.UUID, ref.Name))
+		} else {
+			candidates = append(candidates, ref.UUID)
+		}
+	}
+	return "", fmt.Errorf("%s UUID prefix '%s' is ambiguous, it matches:\n  %s",
+		kind, prefix, strings.Join(candidates, "\n  "))
+}
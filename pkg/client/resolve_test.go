@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestRefsNamed(t *testing.T) {
+	refs := []ResourceRef{
+		{UUID: "a", Name: "Web"},
+		{UUID: "b", Name: "api"},
+		{UUID: "c", Name: "web "},
+		{UUID: "d"},
+	}
+
+	tests := []struct {
+		name string
+		want []ResourceRef
+	}{
+		{"web", []ResourceRef{{UUID: "a", Name: "Web"}, {UUID: "c", Name: "web "}}},
+		{" API", []ResourceRef{{UUID: "b", Name: "api"}}},
+		{"worker", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := refsNamed(refs, tt.name); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("refsNamed(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFindByNameInEnvironment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/projects/p-1/staging":
+			_, _ = w.Write([]byte(`{"id": 7, "name": "staging"}`))
+		case "/services":
+			_, _ = w.Write([]byte(`[
+				{"uuid": "svc-1", "name": "cache", "environment_id": 3},
+				{"uuid": "svc-2", "name": "Cache", "environment_id": 7},
+				{"uuid": "svc-3", "name": "queue", "environment_id": 7}
+			]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(nil, WithBaseURL(server.URL), WithToken("token"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	refs, err := c.FindByNameInEnvironment(context.Background(), KindService, "cache", "p-1", "staging")
+	if err != nil || len(refs) != 1 || refs[0].UUID != "svc-2" {
+		t.Errorf("FindByNameInEnvironment() = %+v, %v", refs, err)
+	}
+	if _, err := c.FindByNameInEnvironment(context.Background(), KindServer, "cache", "p-1", "staging"); err == nil {
+		t.Error("FindByNameInEnvironment() of servers succeeded")
+	}
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// redacted replaces secret values in debug output
+const redacted = "[REDACTED]"
+
+// secretKeyPattern matches names of fields, query parameters and environment variables that hold
+// secrets, e.g. postgres_password, manual_webhook_secret_github or API_TOKEN
+var secretKeyPattern = regexp.MustCompile(`(?i)secret|token|passw(or)?d|pwd|private_?key|api_?key|credential`)
+
+// secretFields are the JSON fields redacted even though their names do not look secret
+var secretFields = map[string]bool{
+	"internal_db_url": true,
+	"external_db_url": true,
+}
+
+// envValueFields are the fields holding the value of an environment variable, redacted when the
+// variable's key looks secret
+var envValueFields = []string{"value", "real_value"}
+
+var (
+	// pemPattern matches PEM encoded private keys, also inside JSON strings with escaped newlines
+	pemPattern = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)
+	// assignmentPattern matches secret assignments in bodies that are not valid JSON, e.g.
+	// truncated responses or form data
+	assignmentPattern = regexp.MustCompile(`(?i)("?[\w-]*(?:secret|token|passw(?:or)?d|private_?key|api_?key)[\w-]*"?\s*[:=]\s*)("(?:[^"\\]|\\.)*"|[^\s&,}]+)`)
+	// urlPasswordPattern matches the password of credentials embedded in URLs
+	urlPasswordPattern = regexp.MustCompile(`([a-z][a-z0-9+.-]*://[^:/@\s"]*:)[^@/\s"]+@`)
+)
+
+// isSecretKey reports whether a field or variable name looks like it holds a secret
+func isSecretKey(key string) bool {
+	return secretFields[strings.ToLower(key)] || secretKeyPattern.MatchString(key)
+}
+
+// scrubBody returns a request or response body for debug logging with secrets redacted: values
+// of secret fields, values of environment variables with secret keys, private keys and passwords
+// in URLs. Bodies that are not JSON are scrubbed by pattern.
+func scrubBody(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err == nil && !decoder.More() {
+		if scrubbed, err := json.Marshal(scrubValue(value)); err == nil {
+			return string(scrubbed)
+		}
+	}
+	return scrubText(string(body))
+}
+
+// scrubValue redacts secrets in a decoded JSON value
+func scrubValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if text, ok := field.(string); ok && text != "" && isSecretKey(key) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = scrubValue(field)
+		}
+		// Environment variables carry their name in "key" and the secret in "value"
+		if key, ok := v["key"].(string); ok && isSecretKey(key) {
+			for _, field := range envValueFields {
+				if text, ok := v[field].(string); ok && text != "" {
+					v[field] = redacted
+				}
+			}
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = scrubValue(item)
+		}
+		return v
+	case string:
+		return scrubText(v)
+	default:
+		return v
+	}
+}
+
+// scrubText redacts private keys, URL passwords and secret assignments in free text
+func scrubText(text string) string {
+	text = pemPattern.ReplaceAllString(text, redacted)
+	text = urlPasswordPattern.ReplaceAllString(text, "${1}"+redacted+"@")
+	return assignmentPattern.ReplaceAllStringFunc(text, func(match string) string {
+		parts := assignmentPattern.FindStringSubmatch(match)
+		if strings.HasPrefix(parts[2], `"`) {
+			return parts[1] + `"` + redacted + `"`
+		}
+		return parts[1] + redacted
+	})
+}
+
+// scrubURL returns a URL for debug logging with its password and secret query parameters redacted
+func scrubURL(u *url.URL) string {
+	scrubbed := *u
+	if scrubbed.User != nil {
+		if _, ok := scrubbed.User.Password(); ok {
+			scrubbed.User = url.UserPassword(scrubbed.User.Username(), redacted)
+		}
+	}
+	if scrubbed.RawQuery != "" {
+		query := scrubbed.Query()
+		for key, values := range query {
+			if isSecretKey(key) {
+				for i := range values {
+					values[i] = redacted
+				}
+			}
+		}
+		scrubbed.RawQuery = query.Encode()
+	}
+	return scrubbed.String()
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Service component kinds
+const (
+	// ComponentApplication is an application container of a service
+	ComponentApplication = "application"
+	// ComponentDatabase is a database container of a service
+	ComponentDatabase = "database"
+)
+
+// ServiceComponent is an application or database running inside a service, i.e. one of the
+// containers of its compose stack
+type ServiceComponent struct {
+	Kind      string `json:"kind"`
+	UUID      string `json:"uuid"`
+	Name      string `json:"name"`
+	HumanName string `json:"human_name,omitempty"`
+	Image     string `json:"image,omitempty"`
+	Status    string `json:"status,omitempty"`
+	FQDN      string `json:"fqdn,omitempty"`
+}
+
+// ContainerName returns the name Coolify gives the component's container: the compose service
+// name followed by the service UUID
+func (c ServiceComponent) ContainerName(serviceUUID string) string {
+	return c.Name + "-" + serviceUUID
+}
+
+// Components returns the applications and databases of a service. They are part of the service
+// response but not of the generated types, so the service is read directly.
+func (sc *ServicesClient) Components(ctx context.Context, uuidStr string) ([]ServiceComponent, error) {
+	var raw struct {
+		Applications []ServiceComponent `json:"applications"`
+		Databases    []ServiceComponent `json:"databases"`
+	}
+	if err := sc.client.doRequest(ctx, http.MethodGet, "/services/"+uuidStr, nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get service: %w", err)
+	}
+
+	components := make([]ServiceComponent, 0, len(raw.Applications)+len(raw.Databases))
+	for _, app := range raw.Applications {
+		app.Kind = ComponentApplication
+		components = append(components, app)
+	}
+	for _, db := range raw.Databases {
+		db.Kind = ComponentDatabase
+		components = append(components, db)
+	}
+	return components, nil
+}
+
+// FindComponent returns the component with the given compose service name or UUID
+func FindComponent(components []ServiceComponent, nameOrUUID string) (*ServiceComponent, error) {
+	for i := range components {
+		if components[i].Name == nameOrUUID || components[i].UUID == nameOrUUID {
+			return &components[i], nil
+		}
+	}
+	return nil, fmt.Errorf("service has no application or database named '%s'", nameOrUUID)
+}
+
+// ServiceHealth counts the containers of a service by state. Services have no status of their
+// own in the API; it is derived from the status of their applications and databases.
+type ServiceHealth struct {
+	Running   int `json:"running"`
+	Exited    int `json:"exited"`
+	Other     int `json:"other"`
+	Unhealthy int `json:"unhealthy"`
+}
+
+// Health counts the states of the components of a service. Coolify reports a component's status
+// as state:health, e.g. running:healthy or exited:unhealthy.
+func Health(components []ServiceComponent) ServiceHealth {
+	var health ServiceHealth
+	for _, component := range components {
+		state, check, _ := strings.Cut(component.Status, ":")
+		switch state {
+		case "running":
+			health.Running++
+			if check == "unhealthy" {
+				health.Unhealthy++
+			}
+		case "exited", "stopped":
+			health.Exited++
+		default:
+			health.Other++
+		}
+	}
+	return health
+}
+
+// Total returns the number of containers counted
+func (h ServiceHealth) Total() int {
+	return h.Running + h.Exited + h.Other
+}
+
+// State returns running when all containers run, exited when all have exited, degraded when
+// only some run and unknown for a service without containers
+func (h ServiceHealth) State() string {
+	switch {
+	case h.Total() == 0:
+		return "unknown"
+	case h.Running == h.Total():
+		return "running"
+	case h.Exited == h.Total():
+		return "exited"
+	case h.Running == 0 && h.Exited == 0:
+		return "starting"
+	default:
+		return "degraded"
+	}
+}
+
+// String returns the state with the container counts, e.g. "running (3/3)" or
+// "degraded (2/3 running, 1 exited)"
+func (h ServiceHealth) String() string {
+	state := h.State()
+	if state == "unknown" {
+		return state
+	}
+
+	counts := []string{fmt.Sprintf("%d/%d", h.Running, h.Total())}
+	if state == "degraded" || state == "starting" {
+		counts[0] += " running"
+		if h.Exited > 0 {
+			counts = append(counts, fmt.Sprintf("%d exited", h.Exited))
+		}
+		if h.Other > 0 {
+			counts = append(counts, fmt.Sprintf("%d other", h.Other))
+		}
+	}
+	if h.Unhealthy > 0 {
+		counts = append(counts, fmt.Sprintf("%d unhealthy", h.Unhealthy))
+	}
+	return fmt.Sprintf("%s (%s)", state, strings.Join(counts, ", "))
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServiceComponents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/svc-1" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{
+			"uuid": "svc-1",
+			"applications": [{"uuid": "app-1", "name": "ghost", "human_name": "Ghost", "image": "ghost:5", "status": "running:healthy", "fqdn": "https://blog.example.com"}],
+			"databases": [{"uuid": "db-1", "name": "mysql", "image": "mysql:8", "status": "running:healthy"}]
+		}`))
+	}))
+	defer server.Close()
+
+	c, err := New(nil, WithBaseURL(server.URL), WithToken("token"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	components, err := c.Services().Components(context.Background(), "svc-1")
+	if err != nil {
+		t.Fatalf("Components() error = %v", err)
+	}
+	if len(components) != 2 {
+		t.Fatalf("Components() returned %d components, want 2", len(components))
+	}
+	if components[0].Kind != ComponentApplication || components[0].FQDN != "https://blog.example.com" {
+		t.Errorf("unexpected application component %+v", components[0])
+	}
+	if components[1].Kind != ComponentDatabase || components[1].Image != "mysql:8" {
+		t.Errorf("unexpected database component %+v", components[1])
+	}
+
+	db, err := FindComponent(components, "mysql")
+	if err != nil || db.UUID != "db-1" {
+		t.Errorf("FindComponent(mysql) = %+v, %v", db, err)
+	}
+	if db.ContainerName("svc-1") != "mysql-svc-1" {
+		t.Errorf("ContainerName() = %s, want mysql-svc-1", db.ContainerName("svc-1"))
+	}
+	if _, err := FindComponent(components, "redis"); err == nil {
+		t.Error("FindComponent(redis) succeeded for a missing component")
+	}
+}
+
+func TestServiceHealth(t *testing.T) {
+	component := func(status string) ServiceComponent { return ServiceComponent{Status: status} }
+	tests := []struct {
+		name       string
+		components []ServiceComponent
+		want       string
+	}{
+		{"no containers", nil, "unknown"},
+		{"all running", []ServiceComponent{component("running:healthy"), component("running:unknown")}, "running (2/2)"},
+		{"unhealthy", []ServiceComponent{component("running:healthy"), component("running:unhealthy")}, "running (2/2, 1 unhealthy)"},
+		{"all exited", []ServiceComponent{component("exited:unhealthy"), component("exited")}, "exited (0/2)"},
+		{"degraded", []ServiceComponent{component("running:healthy"), component("running:healthy"), component("exited:unhealthy")}, "degraded (2/3 running, 1 exited)"},
+		{"starting", []ServiceComponent{component("starting"), component("restarting:unknown")}, "starting (0/2 running, 2 other)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Health(tt.components).String(); got != tt.want {
+				t.Errorf("Health().String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	coolify "github.com/hongkongkiwi/coolifyme/internal/api"
+)
+
+// TagsClient handles tag operations. The API has no tags endpoints, so tags are read from the
+// applications and services listings that carry them.
+type TagsClient struct {
+	client *Client
+}
+
+// Tags returns a tags client
+func (c *Client) Tags() TagsAPI {
+	return &TagsClient{client: c}
+}
+
+// Tag is a tag of the team, attached to applications and services
+type Tag struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// TagResource is a resource carrying a tag
+type TagResource struct {
+	UUID   string `json:"uuid"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Status string `json:"status,omitempty"`
+}
+
+// taggedApplication is an application of the applications listing with the tags it carries
+type taggedApplication struct {
+	coolify.Application
+	Tags []Tag `json:"tags"`
+}
+
+// hasTag reports whether tags contain name, ignoring case
+func hasTag(tags []Tag, name string) bool {
+	for _, tag := range tags {
+		if strings.EqualFold(tag.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// taggedService is a service of the services listing with the tags it carries
+type taggedService struct {
+	coolify.Service
+	Status *string `json:"status,omitempty"`
+	Tags   []Tag   `json:"tags"`
+}
+
+// listTagged returns the applications and services listings with their tags
+func (tc *TagsClient) listTagged(ctx context.Context) ([]taggedApplication, []taggedService, error) {
+	var apps []taggedApplication
+	if err := tc.client.doRequest(ctx, http.MethodGet, "/applications", nil, &apps); err != nil {
+		return nil, nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	var services []taggedService
+	if err := tc.client.doRequest(ctx, http.MethodGet, "/services", nil, &services); err != nil {
+		return nil, nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	return apps, services, nil
+}
+
+// List returns the tags carried by the applications and services of the team, sorted by name
+func (tc *TagsClient) List(ctx context.Context) ([]Tag, error) {
+	apps, services, err := tc.listTagged(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var tags []Tag
+	collect := func(carried []Tag) {
+		for _, tag := range carried {
+			if !seen[tag.Name] {
+				seen[tag.Name] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	for _, app := range apps {
+		collect(app.Tags)
+	}
+	for _, service := range services {
+		collect(service.Tags)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+// Resources returns the applications and services carrying a tag
+func (tc *TagsClient) Resources(ctx context.Context, name string) ([]TagResource, error) {
+	apps, services, err := tc.listTagged(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources with tag '%s': %w", name, err)
+	}
+
+	var resources []TagResource
+	for _, app := range apps {
+		if hasTag(app.Tags, name) {
+			resources = append(resources, TagResource{UUID: value(app.Uuid), Name: value(app.Name), Type: "application", Status: value(app.Status)})
+		}
+	}
+	for _, service := range services {
+		if hasTag(service.Tags, name) {
+			resources = append(resources, TagResource{UUID: value(service.Uuid), Name: value(service.Name), Type: "service", Status: value(service.Status)})
+		}
+	}
+	return resources, nil
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/applications":
+			_, _ = w.Write([]byte(`[
+				{"uuid": "app-1", "name": "api", "status": "running", "tags": [{"id": 2, "name": "team a"}, {"id": 1, "name": "backend"}]},
+				{"uuid": "app-2", "name": "web"}
+			]`))
+		case "/services":
+			_, _ = w.Write([]byte(`[{"uuid": "svc-1", "name": "cache", "status": "exited", "tags": [{"id": 1, "name": "backend"}]}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(nil, WithBaseURL(server.URL), WithToken("token"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tags, err := c.Tags().List(context.Background())
+	if err != nil || len(tags) != 2 || tags[0].Name != "backend" || tags[1].Name != "team a" {
+		t.Fatalf("List() = %+v, %v", tags, err)
+	}
+
+	resources, err := c.Tags().Resources(context.Background(), "backend")
+	if err != nil || len(resources) != 2 {
+		t.Fatalf("Resources() = %+v, %v", resources, err)
+	}
+	if resources[0].UUID != "app-1" || resources[0].Type != "application" || resources[0].Status != "running" {
+		t.Errorf("Resources()[0] = %+v", resources[0])
+	}
+	if resources[1].UUID != "svc-1" || resources[1].Type != "service" || resources[1].Status != "exited" {
+		t.Errorf("Resources()[1] = %+v", resources[1])
+	}
+
+	resources, err = c.Tags().Resources(context.Background(), "missing")
+	if err != nil || len(resources) != 0 {
+		t.Errorf("Resources() of a missing tag = %+v, %v", resources, err)
+	}
+}
+
+func TestListByTag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/applications" || r.URL.RawQuery != "" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"uuid": "app-1", "name": "api", "tags": [{"id": 1, "name": "Backend"}]},
+			{"uuid": "app-2", "name": "web", "tags": [{"id": 2, "name": "frontend"}]},
+			{"uuid": "app-3", "name": "worker"}
+		]`))
+	}))
+	defer server.Close()
+
+	c, err := New(nil, WithBaseURL(server.URL), WithToken("token"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	apps, err := c.Applications().ListByTag(context.Background(), "backend")
+	if err != nil || len(apps) != 1 || apps[0].Uuid == nil || *apps[0].Uuid != "app-1" {
+		t.Fatalf("ListByTag() = %+v, %v", apps, err)
+	}
+
+	apps, err = c.Applications().ListByTag(context.Background(), "missing")
+	if err != nil || len(apps) != 0 {
+		t.Errorf("ListByTag() of a missing tag = %+v, %v", apps, err)
+	}
+}
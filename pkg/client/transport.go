@@ -0,0 +1,23 @@
+package client
+
+import (
+	"net/http"
+	"time"
+)
+
+// DefaultMaxIdleConnsPerHost is the number of idle connections to the server kept open for reuse.
+// Bulk operations send many concurrent requests to one host; with the two idle connections of
+// http.DefaultTransport most of them would open a new connection and TLS session.
+const DefaultMaxIdleConnsPerHost = 32
+
+// newTransport returns the transport used unless the caller provides one. It is a copy of
+// http.DefaultTransport, keeping its proxy settings and HTTP/2 negotiation over TLS, with more
+// idle connections kept per host so consecutive requests reuse them.
+func newTransport(maxIdleConnsPerHost int) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ForceAttemptHTTP2 = true
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	transport.MaxIdleConns = max(transport.MaxIdleConns, maxIdleConnsPerHost)
+	transport.IdleConnTimeout = 90 * time.Second
+	return transport
+}
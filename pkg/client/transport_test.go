@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newMockServer starts a Coolify mock answering every request with an empty list after a short
+// delay, and counts the connections opened to it
+func newMockServer(tls bool) (*httptest.Server, *atomic.Int64) {
+	var conns atomic.Int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(time.Millisecond)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	if tls {
+		server.EnableHTTP2 = true
+		server.StartTLS()
+	} else {
+		server.Start()
+	}
+	return server, &conns
+}
+
+// trustServer makes a transport trust the certificate of a TLS mock server
+func trustServer(transport *http.Transport, server *httptest.Server) *http.Transport {
+	transport.TLSClientConfig = server.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+	return transport
+}
+
+func TestTransportHTTP2(t *testing.T) {
+	server, conns := newMockServer(true)
+	defer server.Close()
+
+	client := &http.Client{Transport: trustServer(newTransport(DefaultMaxIdleConnsPerHost), server)}
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if resp.ProtoMajor != 2 {
+			t.Fatalf("request used %s, want HTTP/2", resp.Proto)
+		}
+	}
+	if n := conns.Load(); n != 1 {
+		t.Errorf("opened %d connections, want 1", n)
+	}
+}
+
+// runBulkRequests sends requests from concurrent workers like the bulk operations of the CLI
+func runBulkRequests(b *testing.B, c *Client, requests, concurrent int) {
+	b.Helper()
+	jobs := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < concurrent; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range jobs {
+				if _, err := c.Applications().ListByTag(context.Background(), "bench"); err != nil {
+					b.Error(err)
+				}
+			}
+		}()
+	}
+	for i := 0; i < requests; i++ {
+		jobs <- struct{}{}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// BenchmarkBulkRequests compares batches of 100 requests with 10 workers, as sent by bulk
+// operations, over http.DefaultTransport settings and the transport of the client. Run it with
+// 'task bench'; conns/op shows how many connections each batch opened.
+func BenchmarkBulkRequests(b *testing.B) {
+	const requests, concurrent = 100, 10
+
+	defaultTransport := func(server *httptest.Server) *http.Transport {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if server.TLS != nil {
+			trustServer(transport, server)
+		}
+		return transport
+	}
+	clientTransport := func(server *httptest.Server) *http.Transport {
+		transport := newTransport(DefaultMaxIdleConnsPerHost)
+		if server.TLS != nil {
+			trustServer(transport, server)
+		}
+		return transport
+	}
+
+	benchmarks := []struct {
+		name      string
+		tls       bool
+		transport func(*httptest.Server) *http.Transport
+	}{
+		{"http1/default-transport", false, defaultTransport},
+		{"http1/client-transport", false, clientTransport},
+		{"http2/client-transport", true, clientTransport},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			server, conns := newMockServer(bm.tls)
+			defer server.Close()
+
+			c, err := New(nil, WithBaseURL(server.URL), WithToken("token"),
+				WithHTTPClient(&http.Client{Transport: bm.transport(server)}))
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				runBulkRequests(b, c, requests, concurrent)
+			}
+			b.ReportMetric(float64(conns.Load())/float64(b.N), "conns/op")
+		})
+	}
+}
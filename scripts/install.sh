@@ -58,6 +58,9 @@ detect_platform() {
         arm64|aarch64)
             arch="arm64"
             ;;
+        armv7l|armv7)
+            arch="arm"
+            ;;
         *)
             error "Unsupported architecture: $arch"
             ;;
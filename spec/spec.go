@@ -0,0 +1,12 @@
+// Package spec embeds the Coolify OpenAPI specification the API client is generated from.
+package spec
+
+import _ "embed" // required for go:embed
+
+// OpenAPI is the bundled Coolify OpenAPI specification
+//
+//go:embed coolify-openapi.yaml
+var OpenAPI []byte
+
+// SourceRef is the upstream git ref the bundled specification is downloaded from (see 'task update-spec')
+const SourceRef = "v4.x"